@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/thxhix/passKeeper/internal/app"
+	"github.com/thxhix/passKeeper/internal/config"
+	customLogger "github.com/thxhix/passKeeper/internal/logger"
+	"go.uber.org/zap"
+	"gopkg.in/urfave/cli.v1"
+	"log"
+)
+
+func main() {
+	cfg, err := config.NewClientConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger, err := customLogger.NewLogger()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer logger.Sync()
+
+	cliApp := cli.NewApp()
+	cliApp.Name = "passkeeper-admin"
+	cliApp.Usage = "manage passKeeper user accounts and global webhooks"
+	cliApp.Version = "dev"
+
+	err = app.RunAdmin(cfg, logger, cliApp)
+	if err != nil {
+		logger.Fatal("Admin client startup critical error", zap.Error(err))
+	}
+}
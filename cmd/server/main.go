@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"github.com/thxhix/passKeeper/internal/app"
 	"github.com/thxhix/passKeeper/internal/config"
 	customLogger "github.com/thxhix/passKeeper/internal/logger"
@@ -20,6 +21,14 @@ func main() {
 	}
 	defer logger.Sync()
 
+	// "verify-audit" checks the audit log hash chain instead of serving
+	if flag.Arg(0) == "verify-audit" {
+		if err := app.VerifyAudit(cfg, logger); err != nil {
+			logger.Fatal("Audit log verification failed", zap.Error(err))
+		}
+		return
+	}
+
 	err = app.RunServer(cfg, logger)
 	if err != nil {
 		logger.Fatal("App startup critical error", zap.Error(err))
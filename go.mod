@@ -13,6 +13,7 @@ require (
 	github.com/zalando/go-keyring v0.2.6
 	go.uber.org/zap v1.27.0
 	golang.org/x/crypto v0.43.0
+	golang.org/x/term v0.36.0
 	gopkg.in/urfave/cli.v1 v1.20.0
 )
 
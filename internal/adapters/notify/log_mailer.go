@@ -0,0 +1,27 @@
+package notify
+
+import (
+	"context"
+	"go.uber.org/zap"
+)
+
+// LogMailer writes emails to the server log instead of sending them. It is
+// meant for development, where links can be copied from the log.
+type LogMailer struct {
+	logger *zap.Logger
+}
+
+// NewLogMailer creates a LogMailer writing to logger.
+func NewLogMailer(logger *zap.Logger) *LogMailer {
+	return &LogMailer{logger: logger}
+}
+
+// Send logs the message.
+func (m *LogMailer) Send(_ context.Context, to, subject, body string) error {
+	m.logger.Info("Email not sent, logging it instead",
+		zap.String("to", to),
+		zap.String("subject", subject),
+		zap.String("body", body),
+	)
+	return nil
+}
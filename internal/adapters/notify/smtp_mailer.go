@@ -0,0 +1,81 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// ErrHeaderInjection is returned for addresses or subjects containing line
+// breaks, which would let them add headers to the message.
+var ErrHeaderInjection = errors.New("line break in mail header")
+
+// SMTPMailer sends plain text emails through an SMTP server.
+//
+// STARTTLS is used when the server offers it. Credentials are only sent over
+// TLS or to a server on localhost, as enforced by net/smtp.
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+	now  func() time.Time
+}
+
+// NewSMTPMailer creates an SMTPMailer for the server at addr (host:port)
+// sending as from. With an empty username no authentication is attempted.
+func NewSMTPMailer(addr, username, password, from string) (*SMTPMailer, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &SMTPMailer{
+		addr: addr,
+		from: from,
+		now:  time.Now,
+	}
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return m, nil
+}
+
+// Send delivers a plain text message to a single recipient.
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to+subject+m.from, "\r\n") {
+		return ErrHeaderInjection
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{to}, m.message(to, subject, body))
+}
+
+// message formats the RFC 5322 message with CRLF line endings.
+func (m *SMTPMailer) message(to, subject, body string) []byte {
+	var b bytes.Buffer
+
+	_, _ = fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	_, _ = fmt.Fprintf(&b, "To: %s\r\n", to)
+	_, _ = fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	_, _ = fmt.Fprintf(&b, "Date: %s\r\n", m.now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	return b.Bytes()
+}
@@ -0,0 +1,110 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// smtpSink is a minimal SMTP server that accepts every message and records
+// the envelope and data of the last one.
+type smtpSink struct {
+	ln   net.Listener
+	from string
+	to   []string
+	data string
+	done chan struct{}
+}
+
+func newSMTPSink(t *testing.T) *smtpSink {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	s := &smtpSink{ln: ln, done: make(chan struct{})}
+	go s.serve()
+	return s
+}
+
+func (s *smtpSink) serve() {
+	defer close(s.done)
+
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 sink ready")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 sink")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			s.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			reply("250 ok")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			s.to = append(s.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+			reply("250 ok")
+		case cmd == "DATA":
+			reply("354 go ahead")
+			var b strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				b.WriteString(l)
+			}
+			s.data = b.String()
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	sink := newSMTPSink(t)
+
+	m, err := NewSMTPMailer(sink.ln.Addr().String(), "", "", "passkeeper@example.com")
+	require.NoError(t, err)
+
+	err = m.Send(context.Background(), "alice@example.com", "Verify your email", "Hello\nline two")
+	require.NoError(t, err)
+	<-sink.done
+
+	require.Equal(t, "passkeeper@example.com", sink.from)
+	require.Equal(t, []string{"alice@example.com"}, sink.to)
+	require.Contains(t, sink.data, "To: alice@example.com\r\n")
+	require.Contains(t, sink.data, "Subject: Verify your email\r\n")
+	require.Contains(t, sink.data, "\r\n\r\nHello\r\nline two\r\n")
+}
+
+func TestSMTPMailer_Send_HeaderInjection(t *testing.T) {
+	m, err := NewSMTPMailer("127.0.0.1:1", "", "", "passkeeper@example.com")
+	require.NoError(t, err)
+
+	err = m.Send(context.Background(), "alice@example.com\r\nBcc: eve@example.com", "subject", "body")
+	require.ErrorIs(t, err, ErrHeaderInjection)
+}
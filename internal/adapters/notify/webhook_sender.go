@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"time"
+)
+
+// webhookTimeout bounds a single delivery attempt.
+const webhookTimeout = 10 * time.Second
+
+// WebhookSender posts webhook deliveries over HTTPS.
+//
+// Subscribers choose the URL, so deliveries are kept off the internal
+// network: the host is resolved when connecting and the connection is
+// refused with webhook.ErrBlockedAddress if any of its addresses is not
+// public. Checking the address actually dialed also defeats DNS rebinding.
+// Proxies from the environment are ignored and redirects are not followed,
+// as both would connect to hosts that are not checked.
+type WebhookSender struct {
+	client *http.Client
+}
+
+// NewWebhookSender creates a WebhookSender with a bounded timeout.
+func NewWebhookSender() *WebhookSender {
+	return newWebhookSender(webhook.IsPublicIP)
+}
+
+// newWebhookSender creates a WebhookSender that connects only to the
+// addresses allowed by allow.
+func newWebhookSender(allow func(netip.Addr) bool) *WebhookSender {
+	dialer := &publicDialer{
+		dialer:   &net.Dialer{Timeout: webhookTimeout},
+		resolver: net.DefaultResolver,
+		allow:    allow,
+	}
+
+	return &WebhookSender{
+		client: &http.Client{
+			Timeout: webhookTimeout,
+			Transport: &http.Transport{
+				Proxy:               nil,
+				DialContext:         dialer.DialContext,
+				ForceAttemptHTTP2:   true,
+				TLSHandshakeTimeout: webhookTimeout,
+				MaxIdleConns:        10,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// publicDialer resolves the host itself and dials only addresses allowed by
+// allow.
+type publicDialer struct {
+	dialer   *net.Dialer
+	resolver *net.Resolver
+	allow    func(netip.Addr) bool
+}
+
+// DialContext refuses the host if any of its addresses is not allowed and
+// otherwise dials them in turn until one answers.
+func (d *publicDialer) DialContext(ctx context.Context, network string, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := d.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	for _, ip := range ips {
+		if !d.allow(ip) {
+			return nil, webhook.ErrBlockedAddress
+		}
+	}
+
+	var lastErr error
+	for _, ip := range ips {
+		conn, err := d.dialer.DialContext(ctx, network, net.JoinHostPort(ip.Unmap().String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// Send posts the payload of d to url with the event, delivery ID and signature
+// headers and returns the response status code.
+func (s *WebhookSender) Send(ctx context.Context, url string, d *webhook.Delivery, signature string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "passKeeper-webhooks")
+	req.Header.Set(webhook.HeaderEvent, string(d.EventType))
+	req.Header.Set(webhook.HeaderDelivery, d.DeliveryID.String())
+	req.Header.Set(webhook.HeaderSignature, signature)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	return resp.StatusCode, nil
+}
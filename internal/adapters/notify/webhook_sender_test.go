@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
+)
+
+func TestWebhookSender_RefusesInternalAddress(t *testing.T) {
+	var hits atomic.Int32
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	_, port, err := net.SplitHostPort(ts.Listener.Addr().String())
+	require.NoError(t, err)
+
+	d := &webhook.Delivery{DeliveryID: uuid.New(), EventType: webhook.EventKeyRead, Payload: []byte(`{}`)}
+
+	// both are rejected by ValidateURL too, the sender checks them again on connect
+	for _, url := range []string{ts.URL, "https://localhost:" + port} {
+		code, err := NewWebhookSender().Send(context.Background(), url, d, "sig")
+		assert.ErrorIs(t, err, webhook.ErrBlockedAddress, url)
+		assert.Zero(t, code)
+	}
+	assert.Zero(t, hits.Load())
+}
+
+func TestWebhookSender_Send(t *testing.T) {
+	var got *http.Request
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	s := newWebhookSender(func(netip.Addr) bool { return true })
+	s.client.Transport.(*http.Transport).TLSClientConfig = ts.Client().Transport.(*http.Transport).TLSClientConfig
+
+	d := &webhook.Delivery{DeliveryID: uuid.New(), EventType: webhook.EventKeyRead, Payload: []byte(`{}`)}
+	code, err := s.Send(context.Background(), ts.URL, d, "sig")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, "sig", got.Header.Get(webhook.HeaderSignature))
+	assert.Equal(t, d.DeliveryID.String(), got.Header.Get(webhook.HeaderDelivery))
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type AccessRevocationRepository struct {
+	db *sql.DB
+}
+
+func NewAccessRevocationRepository(db *sql.DB) *AccessRevocationRepository {
+	return &AccessRevocationRepository{db: db}
+}
+
+func (repo *AccessRevocationRepository) RevokeBefore(ctx context.Context, userID int64, before time.Time, expiresAt time.Time) error {
+	query := `INSERT INTO access_token_revocations (user_id, revoked_before, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			revoked_before = GREATEST(access_token_revocations.revoked_before, EXCLUDED.revoked_before),
+			expires_at = GREATEST(access_token_revocations.expires_at, EXCLUDED.expires_at)`
+
+	_, err := repo.db.ExecContext(ctx, query, userID, before, expiresAt)
+	return err
+}
+
+func (repo *AccessRevocationRepository) GetRevokedBefore(ctx context.Context, userID int64) (*time.Time, error) {
+	var before time.Time
+
+	query := `SELECT revoked_before FROM access_token_revocations WHERE user_id = $1 AND expires_at > NOW()`
+
+	if err := repo.db.QueryRowContext(ctx, query, userID).Scan(&before); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &before, nil
+}
+
+func (repo *AccessRevocationRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	query := `DELETE FROM access_token_revocations WHERE expires_at <= $1`
+
+	res, err := repo.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
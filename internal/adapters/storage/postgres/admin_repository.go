@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/thxhix/passKeeper/internal/domain/admin"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"time"
+)
+
+// adminBootstrapLockKey is the advisory lock serializing the grant of the
+// first administrator.
+const adminBootstrapLockKey = 0x61646d6e
+
+// AdminRepository implements the account administration queries.
+type AdminRepository struct {
+	db *sql.DB
+}
+
+// NewAdminRepository constructs a new AdminRepository using the provided
+// *sql.DB driver.
+func NewAdminRepository(db *sql.DB) *AdminRepository {
+	return &AdminRepository{db: db}
+}
+
+func (repo *AdminRepository) ListUsers(ctx context.Context, limit int, offset int) ([]*admin.UserSummary, error) {
+	query := `SELECT id, login, is_admin, disabled_at, locked_until, created_at FROM users ORDER BY id LIMIT $1 OFFSET $2`
+
+	rows, err := repo.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []*admin.UserSummary
+	for rows.Next() {
+		var u admin.UserSummary
+		if err := rows.Scan(&u.ID, &u.Login, &u.IsAdmin, &u.DisabledAt, &u.LockedUntil, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, &u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// StorageStats ignores soft-deleted entries.
+func (repo *AdminRepository) StorageStats(ctx context.Context, userID int64) (*admin.StorageStats, error) {
+	stats := admin.StorageStats{UserID: userID, EntriesByType: map[string]int64{}}
+
+	query := `SELECT
+			(SELECT COUNT(*) FROM devices WHERE user_id = u.id),
+			(SELECT COUNT(*) FROM personal_access_tokens WHERE user_id = u.id),
+			(SELECT COUNT(*) FROM service_accounts WHERE owner_id = u.id)
+		FROM users u WHERE u.id = $1`
+
+	if err := repo.db.QueryRowContext(ctx, query, userID).Scan(&stats.Devices, &stats.PersonalTokens, &stats.ServiceAccounts); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, user.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	query = `SELECT type, COUNT(*), COALESCE(SUM(octet_length(data)), 0)
+		FROM keychain WHERE user_id = $1 AND soft_deleted IS NOT TRUE GROUP BY type`
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var keyType string
+		var entries, dataBytes int64
+		if err := rows.Scan(&keyType, &entries, &dataBytes); err != nil {
+			return nil, err
+		}
+		stats.EntriesByType[keyType] = entries
+		stats.Entries += entries
+		stats.DataBytes += dataBytes
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+func (repo *AdminRepository) SetDisabled(ctx context.Context, userID int64, at *time.Time) error {
+	query := `UPDATE users SET disabled_at = $1 WHERE id = $2`
+
+	res, err := repo.db.ExecContext(ctx, query, at, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return user.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (repo *AdminRepository) SetAdmin(ctx context.Context, login string, isAdmin bool) error {
+	query := `UPDATE users SET is_admin = $1 WHERE login = $2`
+
+	res, err := repo.db.ExecContext(ctx, query, isAdmin, login)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return user.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// GrantFirstAdmin holds adminBootstrapLockKey for the transaction: two
+// updates of different users would not block each other, so without the lock
+// both could pass the NOT EXISTS check.
+func (repo *AdminRepository) GrantFirstAdmin(ctx context.Context, login string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, adminBootstrapLockKey); err != nil {
+		return err
+	}
+
+	query := `UPDATE users SET is_admin = true
+		WHERE login = $1
+		AND NOT EXISTS (SELECT 1 FROM users WHERE is_admin)`
+
+	res, err := tx.ExecContext(ctx, query, login)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE is_admin)`).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return admin.ErrAlreadyBootstrapped
+		}
+		return user.ErrUserNotFound
+	}
+
+	return tx.Commit()
+}
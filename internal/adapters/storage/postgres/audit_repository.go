@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"strconv"
+	"strings"
+)
+
+// auditAppendLockKey is the advisory lock serializing appends to the audit chain.
+const auditAppendLockKey = 0x61756469
+
+// AuditEventsRepository implements persistence of audit events.
+type AuditEventsRepository struct {
+	db *sql.DB
+}
+
+// NewAuditEventsRepository constructs a new AuditRepository using the provided
+// *sql.DB driver.
+func NewAuditEventsRepository(db *sql.DB) *AuditEventsRepository {
+	return &AuditEventsRepository{db: db}
+}
+
+const auditColumns = `id, user_id, actor, action, key_uuid, ip, user_agent, result, created_at, prev_hash, hash`
+
+func scanAuditEvent(row interface{ Scan(dest ...any) error }) (*audit.Event, error) {
+	var e audit.Event
+	var userID sql.NullInt64
+	var keyUUID uuid.NullUUID
+
+	if err := row.Scan(&e.ID, &userID, &e.Actor, &e.Action, &keyUUID, &e.IP, &e.UserAgent, &e.Result, &e.CreatedAt, &e.PrevHash, &e.Hash); err != nil {
+		return nil, err
+	}
+
+	e.UserID = userID.Int64
+	if keyUUID.Valid {
+		e.KeyUUID = keyUUID.UUID
+	}
+
+	return &e, nil
+}
+
+func (repo *AuditEventsRepository) Append(ctx context.Context, e *audit.Event) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, auditAppendLockKey); err != nil {
+		return err
+	}
+
+	var prevHash string
+	err = tx.QueryRowContext(ctx, `SELECT hash FROM audit_events ORDER BY id DESC LIMIT 1`).Scan(&prevHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	e.PrevHash = prevHash
+	e.Hash = audit.ComputeHash(prevHash, e)
+
+	var argUserID, argKeyUUID any
+	if e.UserID != 0 {
+		argUserID = e.UserID
+	}
+	if e.KeyUUID != uuid.Nil {
+		argKeyUUID = e.KeyUUID
+	}
+
+	query := `INSERT INTO audit_events (user_id, actor, action, key_uuid, ip, user_agent, result, created_at, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+
+	if err := tx.QueryRowContext(ctx, query, argUserID, e.Actor, e.Action, argKeyUUID, e.IP, e.UserAgent, e.Result, e.CreatedAt, e.PrevHash, e.Hash).Scan(&e.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *AuditEventsRepository) List(ctx context.Context, userID int64, filter audit.Filter) ([]*audit.Event, error) {
+	conds := []string{"user_id = $1"}
+	args := []any{userID}
+
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, cond+" $"+strconv.Itoa(len(args)))
+	}
+
+	if filter.Action != "" {
+		add("action =", filter.Action)
+	}
+	if filter.KeyUUID != uuid.Nil {
+		add("key_uuid =", filter.KeyUUID)
+	}
+	if filter.Result != "" {
+		add("result =", filter.Result)
+	}
+	if filter.Since != nil {
+		add("created_at >=", *filter.Since)
+	}
+	if filter.Until != nil {
+		add("created_at <", *filter.Until)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = audit.DefaultListLimit
+	}
+	args = append(args, limit)
+
+	query := `SELECT ` + auditColumns + ` FROM audit_events WHERE ` + strings.Join(conds, " AND ") +
+		` ORDER BY id DESC LIMIT $` + strconv.Itoa(len(args))
+
+	return repo.query(ctx, query, args...)
+}
+
+func (repo *AuditEventsRepository) Chain(ctx context.Context, afterID int64, limit int) ([]*audit.Event, error) {
+	query := `SELECT ` + auditColumns + ` FROM audit_events WHERE id > $1 ORDER BY id LIMIT $2`
+
+	return repo.query(ctx, query, afterID, limit)
+}
+
+func (repo *AuditEventsRepository) query(ctx context.Context, query string, args ...any) ([]*audit.Event, error) {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []*audit.Event
+	for rows.Next() {
+		e, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/device"
+	"time"
+)
+
+// DevicesRepository implements persistence of user devices.
+type DevicesRepository struct {
+	db *sql.DB
+}
+
+// NewDevicesRepository constructs a new DevicesRepository using the provided
+// *sql.DB driver.
+func NewDevicesRepository(db *sql.DB) *DevicesRepository {
+	return &DevicesRepository{db: db}
+}
+
+const deviceColumns = `id, device_uuid, user_id, name, public_key, trusted, approval_code_hash, approval_expires_at, last_seen_at, created_at`
+
+func scanDevice(row interface{ Scan(dest ...any) error }) (*device.Device, error) {
+	var d device.Device
+	var codeHash sql.NullString
+	var approvalExpiresAt, lastSeenAt sql.NullTime
+
+	if err := row.Scan(&d.ID, &d.DeviceUUID, &d.UserID, &d.Name, &d.PublicKey, &d.Trusted, &codeHash, &approvalExpiresAt, &lastSeenAt, &d.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	d.ApprovalCodeHash = codeHash.String
+	if approvalExpiresAt.Valid {
+		d.ApprovalExpiresAt = &approvalExpiresAt.Time
+	}
+	if lastSeenAt.Valid {
+		d.LastSeenAt = &lastSeenAt.Time
+	}
+
+	return &d, nil
+}
+
+// Create inserts the device. A concurrent login that already stored the same
+// device wins and its row is returned unchanged.
+func (repo *DevicesRepository) Create(ctx context.Context, userID int64, ident device.Identity, trusted bool) (*device.Device, error) {
+	query := `INSERT INTO devices (device_uuid, user_id, name, public_key, trusted)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, device_uuid) DO UPDATE SET device_uuid = devices.device_uuid
+		RETURNING ` + deviceColumns
+
+	return scanDevice(repo.db.QueryRowContext(ctx, query, ident.ID, userID, ident.Name, ident.PublicKey, trusted))
+}
+
+func (repo *DevicesRepository) Get(ctx context.Context, userID int64, deviceUUID uuid.UUID) (*device.Device, error) {
+	query := `SELECT ` + deviceColumns + ` FROM devices WHERE user_id = $1 AND device_uuid = $2`
+
+	d, err := scanDevice(repo.db.QueryRowContext(ctx, query, userID, deviceUUID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, device.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (repo *DevicesRepository) ListByUser(ctx context.Context, userID int64) ([]*device.Device, error) {
+	query := `SELECT ` + deviceColumns + ` FROM devices WHERE user_id = $1 ORDER BY trusted DESC, created_at`
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []*device.Device
+	for rows.Next() {
+		d, err := scanDevice(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (repo *DevicesRepository) CountTrusted(ctx context.Context, userID int64) (int, error) {
+	query := `SELECT COUNT(*) FROM devices WHERE user_id = $1 AND trusted = true`
+
+	var n int
+	if err := repo.db.QueryRowContext(ctx, query, userID).Scan(&n); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (repo *DevicesRepository) SetApprovalCode(ctx context.Context, id int64, codeHash string, expiresAt time.Time) error {
+	query := `UPDATE devices SET approval_code_hash = $2, approval_expires_at = $3 WHERE id = $1 AND trusted = false`
+
+	_, err := repo.db.ExecContext(ctx, query, id, codeHash, expiresAt)
+	return err
+}
+
+func (repo *DevicesRepository) Approve(ctx context.Context, userID int64, deviceUUID uuid.UUID) error {
+	query := `UPDATE devices
+		SET trusted = true, approval_code_hash = NULL, approval_expires_at = NULL
+		WHERE user_id = $1 AND device_uuid = $2 AND trusted = false`
+
+	res, err := repo.db.ExecContext(ctx, query, userID, deviceUUID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return device.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *DevicesRepository) TouchLastSeen(ctx context.Context, id int64, at time.Time) error {
+	query := `UPDATE devices SET last_seen_at = $2 WHERE id = $1`
+
+	_, err := repo.db.ExecContext(ctx, query, id, at)
+	return err
+}
+
+func (repo *DevicesRepository) Delete(ctx context.Context, userID int64, deviceUUID uuid.UUID) error {
+	query := `DELETE FROM devices WHERE user_id = $1 AND device_uuid = $2`
+
+	res, err := repo.db.ExecContext(ctx, query, userID, deviceUUID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return device.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *DevicesRepository) CreateChallenge(ctx context.Context, deviceUUID uuid.UUID, nonceHash string, expiresAt time.Time) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM device_challenges WHERE expires_at <= now()`); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO device_challenges (device_uuid, nonce_hash, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (device_uuid) DO UPDATE SET nonce_hash = EXCLUDED.nonce_hash, expires_at = EXCLUDED.expires_at`
+
+	if _, err := tx.ExecContext(ctx, query, deviceUUID, nonceHash, expiresAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *DevicesRepository) ConsumeChallenge(ctx context.Context, deviceUUID uuid.UUID, nonceHash string, now time.Time) (bool, error) {
+	query := `DELETE FROM device_challenges WHERE device_uuid = $1 RETURNING nonce_hash, expires_at`
+
+	var storedHash string
+	var expiresAt time.Time
+	if err := repo.db.QueryRowContext(ctx, query, deviceUUID).Scan(&storedHash, &expiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return storedHash == nonceHash && now.Before(expiresAt), nil
+}
+
+func (repo *DevicesRepository) DeleteAllByUser(ctx context.Context, userID int64) (int64, error) {
+	query := `DELETE FROM devices WHERE user_id = $1`
+
+	res, err := repo.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
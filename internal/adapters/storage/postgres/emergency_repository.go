@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/emergency"
+	"time"
+)
+
+// EmergencyRepository implements emergency.ContactRepository on Postgres.
+type EmergencyRepository struct {
+	db *sql.DB
+}
+
+// NewEmergencyRepository constructs a new EmergencyRepository.
+func NewEmergencyRepository(db *sql.DB) *EmergencyRepository {
+	return &EmergencyRepository{db: db}
+}
+
+// contactColumns selects a contact joined with both users, in the order
+// scanContact expects.
+const contactColumns = `
+	c.id, c.contact_uuid, c.grantor_id, g.login, c.grantee_id, e.login,
+	c.access, c.wait_seconds, c.status, c.requested_at, c.created_at, c.updated_at
+`
+
+const contactJoins = `
+	JOIN users g ON g.id = c.grantor_id
+	JOIN users e ON e.id = c.grantee_id
+`
+
+func scanContact(row rowScanner) (*emergency.Contact, error) {
+	var (
+		c           emergency.Contact
+		waitSeconds int64
+		requestedAt sql.NullTime
+	)
+	if err := row.Scan(
+		&c.ID, &c.ContactUUID, &c.GrantorID, &c.GrantorLogin, &c.GranteeID, &c.GranteeLogin,
+		&c.Access, &waitSeconds, &c.Status, &requestedAt, &c.CreatedAt, &c.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	c.WaitPeriod = time.Duration(waitSeconds) * time.Second
+	if requestedAt.Valid {
+		c.RequestedAt = &requestedAt.Time
+	}
+
+	return &c, nil
+}
+
+func (repo *EmergencyRepository) Upsert(ctx context.Context, in *emergency.Contact) (*emergency.Contact, error) {
+	query := `
+		WITH c AS (
+			INSERT INTO emergency_contacts (contact_uuid, grantor_id, grantee_id, access, wait_seconds)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (grantor_id, grantee_id) DO UPDATE
+			SET access = EXCLUDED.access, wait_seconds = EXCLUDED.wait_seconds, updated_at = now()
+			RETURNING *
+		)
+		SELECT ` + contactColumns + ` FROM c` + contactJoins
+
+	row := repo.db.QueryRowContext(ctx, query, uuid.New(), in.GrantorID, in.GranteeID, in.Access, int64(in.WaitPeriod/time.Second))
+
+	return scanContact(row)
+}
+
+func (repo *EmergencyRepository) Get(ctx context.Context, contactUUID uuid.UUID) (*emergency.Contact, error) {
+	query := `SELECT ` + contactColumns + ` FROM emergency_contacts c` + contactJoins + ` WHERE c.contact_uuid = $1`
+
+	c, err := scanContact(repo.db.QueryRowContext(ctx, query, contactUUID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, emergency.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (repo *EmergencyRepository) ListByGrantor(ctx context.Context, grantorID int64) ([]*emergency.Contact, error) {
+	query := `SELECT ` + contactColumns + ` FROM emergency_contacts c` + contactJoins + ` WHERE c.grantor_id = $1 ORDER BY e.login`
+
+	return repo.queryContacts(ctx, query, grantorID)
+}
+
+func (repo *EmergencyRepository) ListByGrantee(ctx context.Context, granteeID int64) ([]*emergency.Contact, error) {
+	query := `SELECT ` + contactColumns + ` FROM emergency_contacts c` + contactJoins + ` WHERE c.grantee_id = $1 ORDER BY g.login`
+
+	return repo.queryContacts(ctx, query, granteeID)
+}
+
+func (repo *EmergencyRepository) UpdateStatus(ctx context.Context, id int64, from emergency.Status, to emergency.Status, requestedAt *time.Time) (*emergency.Contact, error) {
+	query := `
+		WITH c AS (
+			UPDATE emergency_contacts
+			SET status = $3, requested_at = $4, updated_at = now()
+			WHERE id = $1 AND status = $2
+			RETURNING *
+		)
+		SELECT ` + contactColumns + ` FROM c` + contactJoins
+
+	c, err := scanContact(repo.db.QueryRowContext(ctx, query, id, from, to, requestedAt))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, emergency.ErrStatusChanged
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (repo *EmergencyRepository) Delete(ctx context.Context, userID int64, contactUUID uuid.UUID) error {
+	query := `DELETE FROM emergency_contacts WHERE contact_uuid = $1 AND (grantor_id = $2 OR grantee_id = $2)`
+
+	res, err := repo.db.ExecContext(ctx, query, contactUUID, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return emergency.ErrNotFound
+	}
+
+	return nil
+}
+
+// queryContacts runs a query selecting contactColumns.
+func (repo *EmergencyRepository) queryContacts(ctx context.Context, query string, args ...any) ([]*emergency.Contact, error) {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*emergency.Contact
+	for rows.Next() {
+		c, err := scanContact(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/invite"
+	"time"
+)
+
+// InvitesRepository implements persistence of invite codes.
+type InvitesRepository struct {
+	db *sql.DB
+}
+
+// NewInvitesRepository constructs a new InvitesRepository using the provided
+// *sql.DB driver.
+func NewInvitesRepository(db *sql.DB) *InvitesRepository {
+	return &InvitesRepository{db: db}
+}
+
+const inviteColumns = `id, invite_uuid, created_by, code_hash, login, email, max_uses, uses, expires_at, created_at`
+
+func scanInvite(row interface{ Scan(dest ...any) error }) (*invite.Invite, error) {
+	var inv invite.Invite
+	var login, email sql.NullString
+
+	if err := row.Scan(&inv.ID, &inv.InviteUUID, &inv.CreatedBy, &inv.CodeHash, &login, &email, &inv.MaxUses, &inv.Uses, &inv.ExpiresAt, &inv.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	inv.Login = login.String
+	inv.Email = email.String
+
+	return &inv, nil
+}
+
+func (repo *InvitesRepository) Create(ctx context.Context, inv *invite.Invite) (*invite.Invite, error) {
+	query := `INSERT INTO invites (invite_uuid, created_by, code_hash, login, email, max_uses, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING ` + inviteColumns
+
+	var login, email any
+	if inv.Login != "" {
+		login = inv.Login
+	}
+	if inv.Email != "" {
+		email = inv.Email
+	}
+
+	return scanInvite(repo.db.QueryRowContext(ctx, query, uuid.New(), inv.CreatedBy, inv.CodeHash, login, email, inv.MaxUses, inv.ExpiresAt))
+}
+
+// Redeem takes the use in a single conditional UPDATE so concurrent
+// registrations cannot exceed max_uses. Both emails are normalized, so they
+// compare as stored.
+func (repo *InvitesRepository) Redeem(ctx context.Context, codeHash string, login string, email string, now time.Time) (*invite.Invite, error) {
+	query := `UPDATE invites SET uses = uses + 1
+		WHERE code_hash = $1
+		AND uses < max_uses
+		AND expires_at > $2
+		AND (login IS NULL OR login = $3)
+		AND (email IS NULL OR email = $4)
+		RETURNING ` + inviteColumns
+
+	inv, err := scanInvite(repo.db.QueryRowContext(ctx, query, codeHash, now, login, email))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, invite.ErrInvalidInvite
+		}
+		return nil, err
+	}
+
+	return inv, nil
+}
+
+func (repo *InvitesRepository) Release(ctx context.Context, id int64) error {
+	query := `UPDATE invites SET uses = uses - 1 WHERE id = $1 AND uses > 0`
+
+	_, err := repo.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (repo *InvitesRepository) ListByCreator(ctx context.Context, userID int64) ([]*invite.Invite, error) {
+	query := `SELECT ` + inviteColumns + ` FROM invites WHERE created_by = $1 ORDER BY created_at DESC`
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []*invite.Invite
+	for rows.Next() {
+		inv, err := scanInvite(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, inv)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (repo *InvitesRepository) Delete(ctx context.Context, userID int64, inviteUUID uuid.UUID) error {
+	query := `DELETE FROM invites WHERE created_by = $1 AND invite_uuid = $2`
+
+	res, err := repo.db.ExecContext(ctx, query, userID, inviteUUID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return invite.ErrNotFound
+	}
+
+	return nil
+}
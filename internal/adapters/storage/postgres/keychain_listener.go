@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/lib/pq"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"time"
+)
+
+// keychainEventsChannel is the channel the keychain_notify and
+// vault_entry_notify triggers announce new revisions of entries on.
+const keychainEventsChannel = "keychain_events"
+
+const (
+	listenerMinReconnect = time.Second
+	listenerMaxReconnect = time.Minute
+	listenerPing         = 90 * time.Second
+)
+
+// KeychainEventListener receives the keychain changes announced by the
+// database with LISTEN on a dedicated connection, so it sees the changes made
+// through every server instance.
+type KeychainEventListener struct {
+	dsn string
+}
+
+// NewKeychainEventListener returns a listener connecting to dsn.
+func NewKeychainEventListener(dsn string) *KeychainEventListener {
+	return &KeychainEventListener{dsn: dsn}
+}
+
+// Listen implements keychain.EventListener. The connection is re-established
+// on its own when lost; as notifications sent meanwhile are lost, every
+// reconnection is announced with an EventResync. Malformed notifications are
+// skipped.
+func (l *KeychainEventListener) Listen(ctx context.Context, handle func(keychain.Event)) error {
+	listener := pq.NewListener(l.dsn, listenerMinReconnect, listenerMaxReconnect, nil)
+	defer listener.Close()
+
+	if err := listener.Listen(keychainEventsChannel); err != nil {
+		return err
+	}
+
+	ping := time.NewTicker(listenerPing)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case n := <-listener.Notify:
+			if n == nil {
+				handle(keychain.Event{Type: keychain.EventResync})
+				continue
+			}
+
+			var event keychain.Event
+			if err := json.Unmarshal([]byte(n.Extra), &event); err != nil {
+				continue
+			}
+			handle(event)
+
+		case <-ping.C:
+			// a failed ping makes the listener reconnect
+			_ = listener.Ping()
+		}
+	}
+}
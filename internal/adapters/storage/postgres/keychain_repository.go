@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/thxhix/passKeeper/internal/domain/keychain"
 )
 
@@ -19,17 +20,20 @@ func NewKeychainRepository(db *sql.DB) *KeychainRepository {
 	return &KeychainRepository{db: db}
 }
 
+// execer runs statements on the database or inside a transaction.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
 // AddKey inserts a new keychain record for the given user and returns the
 // generated UUID as string. `data` and `nonce` are stored as bytea in Postgres.
+// The record takes the next keychain revision of the user.
 //
 // ctx controls the database call lifetime.
-func (repo *KeychainRepository) AddKey(ctx context.Context, userID int64, keyType keychain.KeyType, title string, data []byte, nonce []byte) (string, error) {
+func (repo *KeychainRepository) AddKey(ctx context.Context, userID int64, keyType keychain.KeyType, title string, tags []string, data []byte, nonce []byte) (string, error) {
 	keyUUID := uuid.New()
 
-	query := "INSERT INTO keychain (key_uuid, user_id, type, title, data, nonce) VALUES ($1, $2, $3, $4, $5, $6)"
-
-	_, err := repo.db.ExecContext(ctx, query, keyUUID, userID, keyType, title, data, nonce)
-	if err != nil {
+	if err := addKey(ctx, repo.db, userID, keyUUID, keyType, title, tags, data, nonce); err != nil {
 		return "", err
 	}
 
@@ -37,11 +41,127 @@ func (repo *KeychainRepository) AddKey(ctx context.Context, userID int64, keyTyp
 }
 
 // DeleteKey soft-deletes a key: sets soft_deleted = true for the given user
-// and key UUID. It returns sql.ErrNoRows when no rows were affected.
+// and key UUID, leaving a tombstone at the next keychain revision of the user.
+// It returns sql.ErrNoRows when no rows were affected.
 func (repo *KeychainRepository) DeleteKey(ctx context.Context, userID int64, keyUUID string) error {
-	query := "UPDATE keychain SET soft_deleted = true WHERE soft_deleted = false AND user_id = $1 AND key_uuid = $2"
+	return deleteKey(ctx, repo.db, userID, keyUUID)
+}
+
+// WriteBatch applies writes to the personal entries of the user in order,
+// in one transaction. Each failed write gets its Err set; updating or
+// deleting a missing entry fails with sql.ErrNoRows. With atomic the
+// transaction is rolled back at the first failure; otherwise each write
+// runs under a savepoint, so a failed one is undone alone and the others
+// are committed. The returned error is only set when the transaction
+// itself fails, in which case nothing was written.
+func (repo *KeychainRepository) WriteBatch(ctx context.Context, userID int64, writes []*keychain.BatchWrite, atomic bool) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	for _, w := range writes {
+		if !atomic {
+			if _, err := tx.ExecContext(ctx, `SAVEPOINT batch_write`); err != nil {
+				return err
+			}
+		}
+
+		switch w.Op {
+		case keychain.BatchAdd:
+			w.KeyUUID = uuid.New()
+			w.Err = addKey(ctx, tx, userID, w.KeyUUID, w.KeyType, w.Title, w.Tags, w.Data, w.Nonce)
+		case keychain.BatchUpdate:
+			w.Err = updateKey(ctx, tx, userID, w)
+		case keychain.BatchDelete:
+			w.Err = deleteKey(ctx, tx, userID, w.KeyUUID.String())
+		default:
+			w.Err = keychain.ErrBatchOp
+		}
+
+		switch {
+		case w.Err != nil && atomic:
+			return nil
+		case w.Err != nil:
+			if _, err := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT batch_write`); err != nil {
+				return err
+			}
+		case !atomic:
+			if _, err := tx.ExecContext(ctx, `RELEASE SAVEPOINT batch_write`); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+func addKey(ctx context.Context, db execer, userID int64, keyUUID uuid.UUID, keyType keychain.KeyType, title string, tags []string, data []byte, nonce []byte) error {
+	if tags == nil {
+		tags = []string{}
+	}
+
+	query := `
+		WITH rev AS (
+			UPDATE users SET keychain_revision = keychain_revision + 1
+			WHERE id = $2
+			RETURNING keychain_revision
+		)
+		INSERT INTO keychain (key_uuid, user_id, type, title, tags, data, nonce, revision)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, (SELECT keychain_revision FROM rev))
+	`
+
+	_, err := db.ExecContext(ctx, query, keyUUID, userID, keyType, title, pq.Array(tags), data, nonce)
+	return err
+}
+
+// updateKey replaces the title, tags and data of a live personal entry of
+// the type of w, moving it to the next keychain revision of the user.
+func updateKey(ctx context.Context, db execer, userID int64, w *keychain.BatchWrite) error {
+	tags := w.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	query := `
+		WITH rev AS (
+			UPDATE users SET keychain_revision = keychain_revision + 1
+			WHERE id = $1
+			AND EXISTS (SELECT 1 FROM keychain WHERE soft_deleted = false AND user_id = $1 AND key_uuid = $2 AND type = $3)
+			RETURNING keychain_revision
+		)
+		UPDATE keychain
+		SET title = $4, tags = $5, data = $6, nonce = $7, revision = rev.keychain_revision, updated_at = now()
+		FROM rev
+		WHERE soft_deleted = false AND user_id = $1 AND key_uuid = $2 AND type = $3
+	`
+
+	res, err := db.ExecContext(ctx, query, userID, w.KeyUUID, w.KeyType, w.Title, pq.Array(tags), w.Data, w.Nonce)
+	return affectedOne(res, err)
+}
+
+func deleteKey(ctx context.Context, db execer, userID int64, keyUUID string) error {
+	query := `
+		WITH rev AS (
+			UPDATE users SET keychain_revision = keychain_revision + 1
+			WHERE id = $1
+			AND EXISTS (SELECT 1 FROM keychain WHERE soft_deleted = false AND user_id = $1 AND key_uuid = $2)
+			RETURNING keychain_revision
+		)
+		UPDATE keychain
+		SET soft_deleted = true, revision = rev.keychain_revision, updated_at = now()
+		FROM rev
+		WHERE soft_deleted = false AND user_id = $1 AND key_uuid = $2
+	`
+
+	res, err := db.ExecContext(ctx, query, userID, keyUUID)
+	return affectedOne(res, err)
+}
 
-	res, err := repo.db.ExecContext(ctx, query, userID, keyUUID)
+// affectedOne turns a statement that changed no rows into sql.ErrNoRows.
+func affectedOne(res sql.Result, err error) error {
 	if err != nil {
 		return err
 	}
@@ -58,11 +178,89 @@ func (repo *KeychainRepository) DeleteKey(ctx context.Context, userID int64, key
 	return nil
 }
 
+// GetUserChanges returns the entries the user sees changed after the
+// revision since, tombstones included, and the current revision of the user.
+// Personal entries carry their own revision, vault entries the one recorded
+// for the user in vault_entry_revisions. Both are read from one snapshot so
+// the revision covers every change returned.
+func (repo *KeychainRepository) GetUserChanges(ctx context.Context, userID int64, since int64, limit int, withData bool) (changes []*keychain.KeyRecord, current int64, err error) {
+	tx, err := repo.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := tx.QueryRowContext(ctx, "SELECT keychain_revision FROM users WHERE id = $1", userID).Scan(&current); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, key_uuid, user_id, vault_id, vault_uuid, type, title, tags,
+			CASE WHEN $4 AND NOT deleted THEN data END,
+			CASE WHEN $4 AND NOT deleted THEN nonce END,
+			revision, deleted, created_at, updated_at
+		FROM (
+			SELECT id, key_uuid, user_id, NULL::bigint AS vault_id, NULL::uuid AS vault_uuid, type, title, tags,
+				data, nonce, revision, soft_deleted AS deleted, created_at, updated_at
+			FROM keychain
+			WHERE user_id = $1
+			AND revision > $2
+
+			UNION ALL
+
+			SELECT k.id, k.key_uuid, 0, k.vault_id, v.vault_uuid, k.type, k.title, k.tags,
+				k.data, k.nonce, r.revision, k.soft_deleted OR NOT vault_visible($1, k.vault_id), k.created_at, k.updated_at
+			FROM vault_entry_revisions r
+			JOIN keychain k ON k.id = r.key_id
+			JOIN vaults v ON v.id = k.vault_id
+			WHERE r.user_id = $1
+			AND r.revision > $2
+		) c
+		ORDER BY revision
+		LIMIT $3
+	`
+
+	rows, err := tx.QueryContext(ctx, query, userID, since, limit, withData)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			row       = &keychain.KeyRecord{}
+			vaultID   sql.NullInt64
+			vaultUUID uuid.NullUUID
+		)
+		err = rows.Scan(&row.ID, &row.KeyUUID, &row.UserID, &vaultID, &vaultUUID, &row.KeyType, &row.Title, pq.Array(&row.Tags), &row.Data, &row.Nonce, &row.Revision, &row.Deleted, &row.CreatedAt, &row.UpdatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		if vaultID.Valid {
+			id := vaultID.Int64
+			row.VaultID = &id
+		}
+		if vaultUUID.Valid {
+			u := vaultUUID.UUID
+			row.VaultUUID = &u
+		}
+
+		changes = append(changes, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return changes, current, nil
+}
+
 // GetUserKeys returns a list of the user's keys. If keyType is nil the method
 // returns all types; if non-nil the repository filters by the given type.
 func (repo *KeychainRepository) GetUserKeys(ctx context.Context, userID int64, keyType *string) (keys []*keychain.KeyRecord, err error) {
 	query := `
-		SELECT id, key_uuid, user_id, type, title, created_at, updated_at
+		SELECT id, key_uuid, user_id, type, title, tags, revision, created_at, updated_at
 		FROM keychain
 		WHERE soft_deleted = false
 		AND user_id = $1
@@ -86,7 +284,7 @@ func (repo *KeychainRepository) GetUserKeys(ctx context.Context, userID int64, k
 
 	for rows.Next() {
 		row := &keychain.KeyRecord{}
-		err = rows.Scan(&row.ID, &row.KeyUUID, &row.UserID, &row.KeyType, &row.Title, &row.CreatedAt, &row.UpdatedAt)
+		err = rows.Scan(&row.ID, &row.KeyUUID, &row.UserID, &row.KeyType, &row.Title, pq.Array(&row.Tags), &row.Revision, &row.CreatedAt, &row.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -107,9 +305,158 @@ func (repo *KeychainRepository) GetUserKeys(ctx context.Context, userID int64, k
 func (repo *KeychainRepository) GetUserKey(ctx context.Context, userID int64, keyUUID string) (*keychain.KeyRecord, error) {
 	var kr keychain.KeyRecord
 
-	query := `SELECT id, key_uuid, user_id, type, title, data, nonce, created_at, updated_at FROM keychain WHERE soft_deleted = false AND key_uuid = $1 AND user_id = $2`
+	query := `SELECT id, key_uuid, user_id, type, title, tags, data, nonce, revision, created_at, updated_at FROM keychain WHERE soft_deleted = false AND key_uuid = $1 AND user_id = $2`
+
+	if err := repo.db.QueryRowContext(ctx, query, keyUUID, userID).Scan(&kr.ID, &kr.KeyUUID, &kr.UserID, &kr.KeyType, &kr.Title, pq.Array(&kr.Tags), &kr.Data, &kr.Nonce, &kr.Revision, &kr.CreatedAt, &kr.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &kr, nil
+}
+
+// AddVaultKey inserts a new keychain record into a shared vault and returns
+// the generated UUID as string. The record takes the next keychain revision
+// of every user with access to the vault.
+func (repo *KeychainRepository) AddVaultKey(ctx context.Context, vaultID int64, keyType keychain.KeyType, title string, tags []string, data []byte, nonce []byte) (string, error) {
+	keyUUID := uuid.New()
+
+	if tags == nil {
+		tags = []string{}
+	}
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+
+	defer tx.Rollback()
+
+	query := "INSERT INTO keychain (key_uuid, vault_id, type, title, tags, data, nonce) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id"
+
+	var keyID int64
+	if err := tx.QueryRowContext(ctx, query, keyUUID, vaultID, keyType, title, pq.Array(tags), data, nonce).Scan(&keyID); err != nil {
+		return "", err
+	}
+
+	if err := touchVaultEntry(ctx, tx, vaultID, keyID); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
+	return keyUUID.String(), nil
+}
+
+// DeleteVaultKey soft-deletes a key of a shared vault, leaving a tombstone at
+// the next keychain revision of every user with access to the vault. It
+// returns sql.ErrNoRows when no rows were affected.
+func (repo *KeychainRepository) DeleteVaultKey(ctx context.Context, vaultID int64, keyUUID string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	query := "UPDATE keychain SET soft_deleted = true, updated_at = now() WHERE soft_deleted = false AND vault_id = $1 AND key_uuid = $2 RETURNING id"
+
+	var keyID int64
+	if err := tx.QueryRowContext(ctx, query, vaultID, keyUUID).Scan(&keyID); err != nil {
+		return err
+	}
+
+	if err := touchVaultEntry(ctx, tx, vaultID, keyID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// touchVaultEntry moves every user with access to the vault to their next
+// keychain revision and records it as the revision of the entry keyID for
+// them. The vault is share-locked first, so its members cannot change
+// until the transaction ends, and the users are locked in id order, so
+// concurrent writes to vaults sharing members cannot deadlock.
+func touchVaultEntry(ctx context.Context, tx *sql.Tx, vaultID int64, keyID int64) error {
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM vaults WHERE id = $1 FOR SHARE`, vaultID); err != nil {
+		return err
+	}
+
+	query := `
+		WITH members AS (
+			SELECT id FROM users
+			WHERE id IN (
+				SELECT user_id FROM vault_members WHERE vault_id = $1
+				UNION
+				SELECT om.user_id FROM organization_members om JOIN vaults v ON v.org_id = om.org_id WHERE v.id = $1
+			)
+			ORDER BY id
+			FOR UPDATE
+		), rev AS (
+			UPDATE users u SET keychain_revision = u.keychain_revision + 1
+			FROM members m
+			WHERE u.id = m.id
+			RETURNING u.id, u.keychain_revision
+		)
+		INSERT INTO vault_entry_revisions (user_id, key_id, revision)
+		SELECT id, $2, keychain_revision FROM rev
+		ON CONFLICT (user_id, key_id) DO UPDATE SET revision = EXCLUDED.revision
+	`
+
+	_, err := tx.ExecContext(ctx, query, vaultID, keyID)
+	return err
+}
+
+// GetVaultKeys returns the keys of a shared vault, filtered by keyType when
+// it is non-nil.
+func (repo *KeychainRepository) GetVaultKeys(ctx context.Context, vaultID int64, keyType *string) (keys []*keychain.KeyRecord, err error) {
+	query := `
+		SELECT id, key_uuid, vault_id, type, title, tags, created_at, updated_at
+		FROM keychain
+		WHERE soft_deleted = false
+		AND vault_id = $1
+		AND ($2::text IS NULL OR type = $2)
+		ORDER BY created_at DESC
+	`
+
+	var argKeyType any
+	if keyType != nil {
+		argKeyType = *keyType
+	}
+
+	rows, err := repo.db.QueryContext(ctx, query, vaultID, argKeyType)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		row := &keychain.KeyRecord{}
+		err = rows.Scan(&row.ID, &row.KeyUUID, &row.VaultID, &row.KeyType, &row.Title, pq.Array(&row.Tags), &row.CreatedAt, &row.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, err
+}
+
+// GetVaultKey returns the full key record of a shared vault. If the record is
+// not found sql.ErrNoRows is returned.
+func (repo *KeychainRepository) GetVaultKey(ctx context.Context, vaultID int64, keyUUID string) (*keychain.KeyRecord, error) {
+	var kr keychain.KeyRecord
+
+	query := `SELECT id, key_uuid, vault_id, type, title, tags, data, nonce, created_at, updated_at FROM keychain WHERE soft_deleted = false AND key_uuid = $1 AND vault_id = $2`
 
-	if err := repo.db.QueryRowContext(ctx, query, keyUUID, userID).Scan(&kr.ID, &kr.KeyUUID, &kr.UserID, &kr.KeyType, &kr.Title, &kr.Data, &kr.Nonce, &kr.CreatedAt, &kr.UpdatedAt); err != nil {
+	if err := repo.db.QueryRowContext(ctx, query, keyUUID, vaultID).Scan(&kr.ID, &kr.KeyUUID, &kr.VaultID, &kr.KeyType, &kr.Title, pq.Array(&kr.Tags), &kr.Data, &kr.Nonce, &kr.CreatedAt, &kr.UpdatedAt); err != nil {
 		return nil, err
 	}
 	return &kr, nil
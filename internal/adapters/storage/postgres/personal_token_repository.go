@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"time"
+)
+
+// PersonalTokensRepository implements persistence of personal access tokens.
+type PersonalTokensRepository struct {
+	db *sql.DB
+}
+
+// NewPersonalTokensRepository constructs a new PersonalTokensRepository using
+// the provided *sql.DB driver.
+func NewPersonalTokensRepository(db *sql.DB) *PersonalTokensRepository {
+	return &PersonalTokensRepository{db: db}
+}
+
+const personalTokenColumns = `id, token_uuid, user_id, name, token_hash, scopes, expires_at, last_used_at, created_at`
+
+func scanPersonalToken(row interface{ Scan(dest ...any) error }) (*pat.PersonalTokenRecord, error) {
+	var r pat.PersonalTokenRecord
+	var expiresAt, lastUsedAt sql.NullTime
+
+	if err := row.Scan(&r.ID, &r.TokenUUID, &r.UserID, &r.Name, &r.TokenHash, pq.Array(&r.Scopes), &expiresAt, &lastUsedAt, &r.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if expiresAt.Valid {
+		r.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		r.LastUsedAt = &lastUsedAt.Time
+	}
+
+	return &r, nil
+}
+
+func (repo *PersonalTokensRepository) Create(ctx context.Context, userID int64, name string, tokenHash string, scopes []string, expiresAt *time.Time) (*pat.PersonalTokenRecord, error) {
+	query := `INSERT INTO personal_access_tokens (token_uuid, user_id, name, token_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING ` + personalTokenColumns
+
+	var argExpiresAt any
+	if expiresAt != nil {
+		argExpiresAt = *expiresAt
+	}
+
+	return scanPersonalToken(repo.db.QueryRowContext(ctx, query, uuid.New(), userID, name, tokenHash, pq.Array(scopes), argExpiresAt))
+}
+
+// GetByHash does not return tokens of disabled users.
+func (repo *PersonalTokensRepository) GetByHash(ctx context.Context, tokenHash string) (*pat.PersonalTokenRecord, error) {
+	query := `SELECT ` + personalTokenColumns + ` FROM personal_access_tokens
+		WHERE token_hash = $1
+		AND NOT EXISTS (SELECT 1 FROM users u WHERE u.id = personal_access_tokens.user_id AND u.disabled_at IS NOT NULL)`
+
+	r, err := scanPersonalToken(repo.db.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pat.ErrTokenNotFound
+		}
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (repo *PersonalTokensRepository) ListByUser(ctx context.Context, userID int64) ([]*pat.PersonalTokenRecord, error) {
+	query := `SELECT ` + personalTokenColumns + ` FROM personal_access_tokens WHERE user_id = $1 ORDER BY created_at DESC`
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []*pat.PersonalTokenRecord
+	for rows.Next() {
+		r, err := scanPersonalToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (repo *PersonalTokensRepository) Revoke(ctx context.Context, userID int64, tokenUUID uuid.UUID) error {
+	query := `DELETE FROM personal_access_tokens WHERE user_id = $1 AND token_uuid = $2`
+
+	res, err := repo.db.ExecContext(ctx, query, userID, tokenUUID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return pat.ErrTokenNotFound
+	}
+
+	return nil
+}
+
+func (repo *PersonalTokensRepository) TouchLastUsed(ctx context.Context, id int64, at time.Time) error {
+	query := `UPDATE personal_access_tokens SET last_used_at = $1 WHERE id = $2`
+
+	_, err := repo.db.ExecContext(ctx, query, at, id)
+	return err
+}
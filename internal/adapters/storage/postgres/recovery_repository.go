@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/thxhix/passKeeper/internal/domain/recovery"
+)
+
+// RecoveryRepository implements recovery.RecoveryRepository on Postgres.
+type RecoveryRepository struct {
+	db *sql.DB
+}
+
+// NewRecoveryRepository constructs a new RecoveryRepository.
+func NewRecoveryRepository(db *sql.DB) *RecoveryRepository {
+	return &RecoveryRepository{db: db}
+}
+
+// recoveryShareColumns selects a share joined with both users, in the order
+// scanRecoveryShare expects.
+const recoveryShareColumns = `
+	s.id, s.share_uuid, s.owner_id, o.login, s.holder_id, h.login,
+	s.wrapped_key, s.nonce, s.data, s.created_at
+`
+
+const recoveryShareJoins = `
+	JOIN users o ON o.id = s.owner_id
+	JOIN users h ON h.id = s.holder_id
+`
+
+func scanRecoveryShare(row rowScanner) (*recovery.Share, error) {
+	var s recovery.Share
+	if err := row.Scan(
+		&s.ID, &s.ShareUUID, &s.OwnerID, &s.OwnerLogin, &s.HolderID, &s.HolderLogin,
+		&s.WrappedKey, &s.Nonce, &s.Data, &s.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Replace deletes the earlier setup, whose shares cascade, and inserts the
+// new one in a single transaction.
+func (repo *RecoveryRepository) Replace(ctx context.Context, setup *recovery.Setup, shares []*recovery.Share) (*recovery.Setup, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_setups WHERE user_id = $1`, setup.UserID); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO recovery_setups (user_id, public_key, key_material, threshold, shares)
+		VALUES ($1, $2, $3, $4, $5) RETURNING created_at`
+
+	out := *setup
+	err = tx.QueryRowContext(ctx, query, setup.UserID, setup.PublicKey, setup.KeyMaterial, setup.Threshold, setup.Shares).Scan(&out.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range shares {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO recovery_shares (share_uuid, owner_id, holder_id, wrapped_key, nonce, data)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			uuid.New(), setup.UserID, s.HolderID, s.WrappedKey, s.Nonce, s.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (repo *RecoveryRepository) Get(ctx context.Context, userID int64) (*recovery.Setup, error) {
+	query := `
+		SELECT r.user_id, r.public_key, r.key_material, r.threshold, r.shares, r.created_at,
+		       ARRAY(
+		           SELECT h.login FROM recovery_shares s JOIN users h ON h.id = s.holder_id
+		           WHERE s.owner_id = r.user_id ORDER BY h.login
+		       )
+		FROM recovery_setups r WHERE r.user_id = $1`
+
+	var s recovery.Setup
+	err := repo.db.QueryRowContext(ctx, query, userID).Scan(
+		&s.UserID, &s.PublicKey, &s.KeyMaterial, &s.Threshold, &s.Shares, &s.CreatedAt, pq.Array(&s.Holders),
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, recovery.ErrNotSetUp
+		}
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (repo *RecoveryRepository) Delete(ctx context.Context, userID int64) error {
+	res, err := repo.db.ExecContext(ctx, `DELETE FROM recovery_setups WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return recovery.ErrNotSetUp
+	}
+
+	return nil
+}
+
+func (repo *RecoveryRepository) ListHeld(ctx context.Context, holderID int64) ([]*recovery.Share, error) {
+	query := `SELECT ` + recoveryShareColumns + ` FROM recovery_shares s` + recoveryShareJoins + ` WHERE s.holder_id = $1 ORDER BY o.login`
+
+	rows, err := repo.db.QueryContext(ctx, query, holderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*recovery.Share
+	for rows.Next() {
+		s, err := scanRecoveryShare(rows)
+		if err != nil {
+			return nil, err
+		}
+		s.WrappedKey, s.Nonce, s.Data = nil, nil, nil
+		list = append(list, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (repo *RecoveryRepository) GetHeld(ctx context.Context, holderID int64, shareUUID uuid.UUID) (*recovery.Share, error) {
+	query := `SELECT ` + recoveryShareColumns + ` FROM recovery_shares s` + recoveryShareJoins + ` WHERE s.holder_id = $1 AND s.share_uuid = $2`
+
+	s, err := scanRecoveryShare(repo.db.QueryRowContext(ctx, query, holderID, shareUUID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, recovery.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return s, nil
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/send"
+	"time"
+)
+
+// SendsRepository implements persistence of secret links.
+type SendsRepository struct {
+	db *sql.DB
+}
+
+// NewSendsRepository constructs a new SendsRepository using the provided
+// *sql.DB driver.
+func NewSendsRepository(db *sql.DB) *SendsRepository {
+	return &SendsRepository{db: db}
+}
+
+const sendColumns = `id, send_uuid, owner_id, nonce, data, max_views, views, expires_at, created_at`
+
+// sendListColumns leaves the ciphertext out of listings.
+const sendListColumns = `id, send_uuid, owner_id, ''::bytea, ''::bytea, max_views, views, expires_at, created_at`
+
+func scanSend(row interface{ Scan(dest ...any) error }) (*send.Send, error) {
+	var s send.Send
+
+	if err := row.Scan(&s.ID, &s.SendUUID, &s.OwnerID, &s.Nonce, &s.Data, &s.MaxViews, &s.Views, &s.ExpiresAt, &s.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (repo *SendsRepository) Create(ctx context.Context, s *send.Send) (*send.Send, error) {
+	query := `INSERT INTO sends (send_uuid, owner_id, nonce, data, max_views, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING ` + sendColumns
+
+	return scanSend(repo.db.QueryRowContext(ctx, query, uuid.New(), s.OwnerID, s.Nonce, s.Data, s.MaxViews, s.ExpiresAt))
+}
+
+// Consume locks the row, so concurrent reads cannot exceed max_views, and
+// deletes it on the last view instead of counting it.
+func (repo *SendsRepository) Consume(ctx context.Context, sendUUID uuid.UUID, now time.Time) (*send.Send, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	query := `SELECT ` + sendColumns + ` FROM sends
+		WHERE send_uuid = $1 AND views < max_views AND expires_at > $2
+		FOR UPDATE`
+
+	s, err := scanSend(tx.QueryRowContext(ctx, query, sendUUID, now))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, send.ErrNotFound
+		}
+		return nil, err
+	}
+
+	s.Views++
+	if s.Views >= s.MaxViews {
+		_, err = tx.ExecContext(ctx, `DELETE FROM sends WHERE id = $1`, s.ID)
+	} else {
+		_, err = tx.ExecContext(ctx, `UPDATE sends SET views = $1 WHERE id = $2`, s.Views, s.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (repo *SendsRepository) ListByOwner(ctx context.Context, userID int64) ([]*send.Send, error) {
+	query := `SELECT ` + sendListColumns + ` FROM sends WHERE owner_id = $1 ORDER BY created_at DESC`
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []*send.Send
+	for rows.Next() {
+		s, err := scanSend(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (repo *SendsRepository) Delete(ctx context.Context, userID int64, sendUUID uuid.UUID) error {
+	query := `DELETE FROM sends WHERE owner_id = $1 AND send_uuid = $2`
+
+	res, err := repo.db.ExecContext(ctx, query, userID, sendUUID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return send.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *SendsRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	query := `DELETE FROM sends WHERE expires_at <= $1`
+
+	res, err := repo.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
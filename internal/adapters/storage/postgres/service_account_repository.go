@@ -0,0 +1,245 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/serviceaccount"
+	"time"
+)
+
+// ServiceAccountsRepository implements persistence of service accounts and
+// their entry grants.
+type ServiceAccountsRepository struct {
+	db *sql.DB
+}
+
+// NewServiceAccountsRepository constructs a new ServiceAccountsRepository
+// using the provided *sql.DB driver.
+func NewServiceAccountsRepository(db *sql.DB) *ServiceAccountsRepository {
+	return &ServiceAccountsRepository{db: db}
+}
+
+const serviceAccountColumns = `id, client_id, owner_id, name, secret_hash, public_key, last_used_at, created_at`
+
+func scanServiceAccount(row interface{ Scan(dest ...any) error }) (*serviceaccount.ServiceAccount, error) {
+	var r serviceaccount.ServiceAccount
+	var publicKey sql.NullString
+	var lastUsedAt sql.NullTime
+
+	if err := row.Scan(&r.ID, &r.ClientID, &r.OwnerID, &r.Name, &r.SecretHash, &publicKey, &lastUsedAt, &r.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	r.PublicKey = publicKey.String
+	if lastUsedAt.Valid {
+		r.LastUsedAt = &lastUsedAt.Time
+	}
+
+	return &r, nil
+}
+
+func (repo *ServiceAccountsRepository) Create(ctx context.Context, ownerID int64, name string, secretHash string, publicKey string) (*serviceaccount.ServiceAccount, error) {
+	query := `INSERT INTO service_accounts (client_id, owner_id, name, secret_hash, public_key)
+		VALUES ($1, $2, $3, $4, $5) RETURNING ` + serviceAccountColumns
+
+	argPublicKey := sql.NullString{String: publicKey, Valid: publicKey != ""}
+
+	return scanServiceAccount(repo.db.QueryRowContext(ctx, query, uuid.New(), ownerID, name, secretHash, argPublicKey))
+}
+
+// GetByClientID does not return service accounts of disabled users.
+func (repo *ServiceAccountsRepository) GetByClientID(ctx context.Context, clientID uuid.UUID) (*serviceaccount.ServiceAccount, error) {
+	query := `SELECT ` + serviceAccountColumns + ` FROM service_accounts
+		WHERE client_id = $1
+		AND NOT EXISTS (SELECT 1 FROM users u WHERE u.id = service_accounts.owner_id AND u.disabled_at IS NOT NULL)`
+
+	r, err := scanServiceAccount(repo.db.QueryRowContext(ctx, query, clientID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, serviceaccount.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (repo *ServiceAccountsRepository) ListByOwner(ctx context.Context, ownerID int64) ([]*serviceaccount.ServiceAccount, error) {
+	query := `SELECT ` + serviceAccountColumns + ` FROM service_accounts WHERE owner_id = $1 ORDER BY created_at DESC`
+
+	rows, err := repo.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []*serviceaccount.ServiceAccount
+	for rows.Next() {
+		r, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (repo *ServiceAccountsRepository) Delete(ctx context.Context, ownerID int64, clientID uuid.UUID) error {
+	query := `DELETE FROM service_accounts WHERE owner_id = $1 AND client_id = $2`
+
+	res, err := repo.db.ExecContext(ctx, query, ownerID, clientID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return serviceaccount.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *ServiceAccountsRepository) TouchLastUsed(ctx context.Context, id int64, at time.Time) error {
+	query := `UPDATE service_accounts SET last_used_at = $1 WHERE id = $2`
+
+	_, err := repo.db.ExecContext(ctx, query, at, id)
+	return err
+}
+
+func (repo *ServiceAccountsRepository) Grant(ctx context.Context, ownerID int64, clientID uuid.UUID, keyUUID uuid.UUID) error {
+	// the no-op update makes an existing grant count as an affected row
+	query := `
+		INSERT INTO service_account_grants (service_account_id, key_id)
+		SELECT sa.id, k.id
+		FROM service_accounts sa
+		JOIN keychain k ON k.user_id = sa.owner_id
+		WHERE sa.owner_id = $1
+		AND sa.client_id = $2
+		AND k.key_uuid = $3
+		AND k.soft_deleted = false
+		ON CONFLICT (service_account_id, key_id) DO UPDATE SET created_at = service_account_grants.created_at
+	`
+
+	res, err := repo.db.ExecContext(ctx, query, ownerID, clientID, keyUUID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return serviceaccount.ErrGrantNotFound
+	}
+
+	return nil
+}
+
+func (repo *ServiceAccountsRepository) RevokeGrant(ctx context.Context, ownerID int64, clientID uuid.UUID, keyUUID uuid.UUID) error {
+	query := `
+		DELETE FROM service_account_grants g
+		USING service_accounts sa, keychain k
+		WHERE g.service_account_id = sa.id
+		AND g.key_id = k.id
+		AND sa.owner_id = $1
+		AND sa.client_id = $2
+		AND k.key_uuid = $3
+	`
+
+	res, err := repo.db.ExecContext(ctx, query, ownerID, clientID, keyUUID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return serviceaccount.ErrGrantNotFound
+	}
+
+	return nil
+}
+
+func (repo *ServiceAccountsRepository) ListGrants(ctx context.Context, ownerID int64, clientID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT k.key_uuid
+		FROM service_account_grants g
+		JOIN service_accounts sa ON sa.id = g.service_account_id
+		JOIN keychain k ON k.id = g.key_id
+		WHERE sa.owner_id = $1
+		AND sa.client_id = $2
+		AND k.soft_deleted = false
+		ORDER BY g.created_at
+	`
+
+	rows, err := repo.db.QueryContext(ctx, query, ownerID, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []uuid.UUID
+	for rows.Next() {
+		var keyUUID uuid.UUID
+		if err := rows.Scan(&keyUUID); err != nil {
+			return nil, err
+		}
+		list = append(list, keyUUID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (repo *ServiceAccountsRepository) GrantedKeyIDs(ctx context.Context, clientID uuid.UUID) ([]int64, error) {
+	query := `
+		SELECT g.key_id
+		FROM service_account_grants g
+		JOIN service_accounts sa ON sa.id = g.service_account_id
+		WHERE sa.client_id = $1
+	`
+
+	rows, err := repo.db.QueryContext(ctx, query, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		list = append(list, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
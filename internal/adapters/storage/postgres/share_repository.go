@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/share"
+)
+
+// SharesRepository implements share.ShareRepository on Postgres.
+//
+// Shares of soft-deleted entries are not returned, so deleting an entry
+// revokes its shares as well.
+type SharesRepository struct {
+	db *sql.DB
+}
+
+// NewSharesRepository constructs a new SharesRepository.
+func NewSharesRepository(db *sql.DB) *SharesRepository {
+	return &SharesRepository{db: db}
+}
+
+// shareColumns selects a share joined with its entry and both users, in the
+// order scanShare expects.
+const shareColumns = `
+	s.id, s.share_uuid, s.key_id, k.key_uuid, k.type, k.title,
+	s.owner_id, o.login, s.recipient_id, r.login, s.wrapped_key, s.nonce, s.data, s.created_at
+`
+
+const shareJoins = `
+	JOIN keychain k ON k.id = s.key_id AND k.soft_deleted = false
+	JOIN users o ON o.id = s.owner_id
+	JOIN users r ON r.id = s.recipient_id
+`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanShare(row rowScanner) (*share.Share, error) {
+	var s share.Share
+	if err := row.Scan(
+		&s.ID, &s.ShareUUID, &s.KeyID, &s.KeyUUID, &s.KeyType, &s.Title,
+		&s.OwnerID, &s.OwnerLogin, &s.RecipientID, &s.RecipientLogin,
+		&s.WrappedKey, &s.Nonce, &s.Data, &s.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (repo *SharesRepository) Upsert(ctx context.Context, in *share.Share) (*share.Share, error) {
+	query := `
+		WITH s AS (
+			INSERT INTO key_shares (share_uuid, key_id, owner_id, recipient_id, wrapped_key, nonce, data)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (key_id, recipient_id) DO UPDATE
+			SET owner_id = EXCLUDED.owner_id, wrapped_key = EXCLUDED.wrapped_key,
+			    nonce = EXCLUDED.nonce, data = EXCLUDED.data, created_at = now()
+			RETURNING *
+		)
+		SELECT ` + shareColumns + ` FROM s` + shareJoins
+
+	row := repo.db.QueryRowContext(ctx, query, uuid.New(), in.KeyID, in.OwnerID, in.RecipientID, in.WrappedKey, in.Nonce, in.Data)
+
+	return scanShare(row)
+}
+
+func (repo *SharesRepository) ListByKey(ctx context.Context, keyID int64) ([]*share.Share, error) {
+	query := `SELECT ` + shareColumns + ` FROM key_shares s` + shareJoins + ` WHERE s.key_id = $1 ORDER BY r.login`
+
+	return repo.queryShares(ctx, query, keyID)
+}
+
+func (repo *SharesRepository) ListForRecipient(ctx context.Context, recipientID int64) ([]*share.Share, error) {
+	query := `SELECT ` + shareColumns + ` FROM key_shares s` + shareJoins + ` WHERE s.recipient_id = $1 ORDER BY s.created_at DESC`
+
+	list, err := repo.queryShares(ctx, query, recipientID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range list {
+		s.WrappedKey, s.Nonce, s.Data = nil, nil, nil
+	}
+
+	return list, nil
+}
+
+func (repo *SharesRepository) GetForRecipient(ctx context.Context, recipientID int64, shareUUID uuid.UUID) (*share.Share, error) {
+	query := `SELECT ` + shareColumns + ` FROM key_shares s` + shareJoins + ` WHERE s.recipient_id = $1 AND s.share_uuid = $2`
+
+	s, err := scanShare(repo.db.QueryRowContext(ctx, query, recipientID, shareUUID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, share.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (repo *SharesRepository) DeleteByKey(ctx context.Context, keyID int64, recipientID int64) error {
+	return repo.execDelete(ctx, `DELETE FROM key_shares WHERE key_id = $1 AND recipient_id = $2`, keyID, recipientID)
+}
+
+func (repo *SharesRepository) DeleteForRecipient(ctx context.Context, recipientID int64, shareUUID uuid.UUID) error {
+	return repo.execDelete(ctx, `DELETE FROM key_shares WHERE recipient_id = $1 AND share_uuid = $2`, recipientID, shareUUID)
+}
+
+// queryShares runs a query selecting shareColumns.
+func (repo *SharesRepository) queryShares(ctx context.Context, query string, args ...any) ([]*share.Share, error) {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*share.Share
+	for rows.Next() {
+		s, err := scanShare(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// execDelete runs a delete of a single share.
+func (repo *SharesRepository) execDelete(ctx context.Context, query string, args ...any) error {
+	res, err := repo.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return share.ErrNotFound
+	}
+
+	return nil
+}
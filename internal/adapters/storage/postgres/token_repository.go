@@ -18,11 +18,16 @@ func NewTokensRepository(db *sql.DB) *TokensRepository {
 }
 
 var (
-	queryInsert = `INSERT INTO auth_refresh_tokens (user_id, jti, token_hash, issued_at, expires_at) VALUES ($1, $2, $3, $4, $5);`
+	queryInsert = `INSERT INTO auth_refresh_tokens (user_id, jti, token_hash, issued_at, expires_at, device_id) VALUES ($1, $2, $3, $4, $5, $6);`
 )
 
-func (repo *TokensRepository) Create(ctx context.Context, userID int64, jti uuid.UUID, tokenHash string, issuedAt time.Time, expiresAt time.Time) error {
-	_, err := repo.db.ExecContext(ctx, queryInsert, userID, jti, tokenHash, issuedAt, expiresAt)
+func (repo *TokensRepository) Create(ctx context.Context, userID int64, deviceID *int64, jti uuid.UUID, tokenHash string, issuedAt time.Time, expiresAt time.Time) error {
+	var argDeviceID any
+	if deviceID != nil {
+		argDeviceID = *deviceID
+	}
+
+	_, err := repo.db.ExecContext(ctx, queryInsert, userID, jti, tokenHash, issuedAt, expiresAt, argDeviceID)
 	return err
 }
 
@@ -45,7 +50,11 @@ func (r *TokensRepository) Rotate(ctx context.Context, userID int64, oldJTI uuid
 		return token.ErrTokenAlreadyRotatedOrExpired
 	}
 
-	if _, err := tx.ExecContext(ctx, queryInsert, userID, newJTI, newHash, newIssuedAt, newExpiresAt); err != nil {
+	// The rotated token stays bound to the device of the old one.
+	const queryInsertRotated = `INSERT INTO auth_refresh_tokens (user_id, jti, token_hash, issued_at, expires_at, device_id)
+		SELECT $1, $2, $3, $4, $5, device_id FROM auth_refresh_tokens WHERE jti = $6;`
+
+	if _, err := tx.ExecContext(ctx, queryInsertRotated, userID, newJTI, newHash, newIssuedAt, newExpiresAt, oldJTI); err != nil {
 		return err
 	}
 
@@ -72,3 +81,10 @@ func (r *TokensRepository) GetByJTI(ctx context.Context, jti uuid.UUID) (*token.
 	}
 	return &rt, nil
 }
+
+func (r *TokensRepository) RevokeAllByUser(ctx context.Context, userID int64) error {
+	query := `DELETE FROM auth_refresh_tokens WHERE user_id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"github.com/lib/pq"
 	"github.com/thxhix/passKeeper/internal/domain/user"
+	"time"
 )
 
 type UsersRepository struct {
@@ -16,35 +17,297 @@ func NewUsersRepository(db *sql.DB) *UsersRepository {
 	return &UsersRepository{db: db}
 }
 
-func (repo *UsersRepository) Create(ctx context.Context, login string, passwordHash string) (int64, error) {
+// emailIndex is the unique index on the lowercased email of users.
+const emailIndex = "idx_users_email"
+
+const userColumns = `id, login, password, email, email_verified_at, key_material, public_key, failed_login_attempts, lockout_count, locked_until, is_admin, disabled_at`
+
+func scanUser(row *sql.Row) (*user.UserRecord, error) {
+	var au user.UserRecord
+	var email sql.NullString
+
+	if err := row.Scan(&au.ID, &au.Login, &au.PasswordHash, &email, &au.EmailVerifiedAt, &au.KeyMaterial, &au.PublicKey, &au.FailedLoginAttempts, &au.LockoutCount, &au.LockedUntil, &au.IsAdmin, &au.DisabledAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, user.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	au.Email = email.String
+
+	return &au, nil
+}
+
+// mapUniqueViolation converts a unique violation on users into the matching
+// domain error.
+func mapUniqueViolation(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		if pqErr.Constraint == emailIndex {
+			return user.ErrDuplicateEmail
+		}
+		return user.ErrDuplicateLogin
+	}
+	return err
+}
+
+func (repo *UsersRepository) Create(ctx context.Context, login string, passwordHash string, email string) (int64, error) {
 	var id int64
 
-	query := "INSERT INTO users (login, password) VALUES ($1, $2) RETURNING id"
+	query := "INSERT INTO users (login, password, email) VALUES ($1, $2, $3) RETURNING id"
+
+	var argEmail any
+	if email != "" {
+		argEmail = email
+	}
 
-	err := repo.db.QueryRowContext(ctx, query, login, passwordHash).Scan(&id)
+	err := repo.db.QueryRowContext(ctx, query, login, passwordHash, argEmail).Scan(&id)
 	if err != nil {
-		var pqErr *pq.Error
-		if errors.As(err, &pqErr) {
-			if pqErr.Code == "23505" {
-				return 0, user.ErrDuplicateLogin
-			}
-		}
-		return 0, err
+		return 0, mapUniqueViolation(err)
 	}
 
 	return id, nil
 }
 
 func (repo *UsersRepository) GetByLogin(ctx context.Context, login string) (*user.UserRecord, error) {
-	var au user.UserRecord
+	query := `SELECT ` + userColumns + ` FROM users WHERE login = $1`
+
+	return scanUser(repo.db.QueryRowContext(ctx, query, login))
+}
+
+func (repo *UsersRepository) GetByEmail(ctx context.Context, email string) (*user.UserRecord, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE lower(email) = lower($1)`
+
+	return scanUser(repo.db.QueryRowContext(ctx, query, email))
+}
+
+func (repo *UsersRepository) GetByID(ctx context.Context, id int64) (*user.UserRecord, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+	return scanUser(repo.db.QueryRowContext(ctx, query, id))
+}
+
+func (repo *UsersRepository) UpdateCredentials(ctx context.Context, userID int64, passwordHash string, keyMaterial []byte) error {
+	query := `UPDATE users SET password = $1, key_material = COALESCE($2, key_material), password_changed_at = now() WHERE id = $3`
+
+	var argKeyMaterial any
+	if keyMaterial != nil {
+		argKeyMaterial = keyMaterial
+	}
+
+	res, err := repo.db.ExecContext(ctx, query, passwordHash, argKeyMaterial, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return user.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (repo *UsersRepository) ResetCredentials(ctx context.Context, userID int64, passwordHash string) error {
+	query := `
+		WITH dropped AS (
+			DELETE FROM key_shares WHERE recipient_id = $2
+		), dropped_recovery AS (
+			DELETE FROM recovery_shares WHERE holder_id = $2
+		)
+		UPDATE users SET password = $1, key_material = NULL, public_key = NULL, password_changed_at = now() WHERE id = $2`
+
+	res, err := repo.db.ExecContext(ctx, query, passwordHash, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return user.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (repo *UsersRepository) SetKeyPair(ctx context.Context, userID int64, publicKey []byte, keyMaterial []byte) error {
+	query := `
+		WITH dropped AS (
+			DELETE FROM key_shares
+			WHERE recipient_id = $3
+			  AND EXISTS (SELECT 1 FROM users WHERE id = $3 AND public_key IS DISTINCT FROM $1)
+		), dropped_recovery AS (
+			DELETE FROM recovery_shares
+			WHERE holder_id = $3
+			  AND EXISTS (SELECT 1 FROM users WHERE id = $3 AND public_key IS DISTINCT FROM $1)
+		)
+		UPDATE users SET public_key = $1, key_material = $2 WHERE id = $3`
+
+	res, err := repo.db.ExecContext(ctx, query, publicKey, keyMaterial, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return user.ErrUserNotFound
+	}
 
-	query := `SELECT id, login, password FROM users WHERE login = $1`
+	return nil
+}
+
+func (repo *UsersRepository) SetEmail(ctx context.Context, userID int64, email string) error {
+	query := `UPDATE users SET email = $1, email_verified_at = NULL WHERE id = $2`
+
+	res, err := repo.db.ExecContext(ctx, query, email, userID)
+	if err != nil {
+		return mapUniqueViolation(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return user.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (repo *UsersRepository) MarkEmailVerified(ctx context.Context, userID int64, email string, at time.Time) error {
+	query := `UPDATE users SET email_verified_at = COALESCE(email_verified_at, $1) WHERE id = $2 AND email = $3`
+
+	res, err := repo.db.ExecContext(ctx, query, at, userID, email)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return user.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (repo *UsersRepository) UpdatePasswordHash(ctx context.Context, userID int64, passwordHash string) error {
+	query := `UPDATE users SET password = $1 WHERE id = $2`
+
+	res, err := repo.db.ExecContext(ctx, query, passwordHash, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return user.ErrUserNotFound
+	}
 
-	if err := repo.db.QueryRowContext(ctx, query, login).Scan(&au.ID, &au.Login, &au.PasswordHash); err != nil {
+	return nil
+}
+
+// Delete removes the vaults and organizations nobody but the user is a member
+// of before the user, so the cascade does not leave them behind without any
+// member. An organization is kept while one of its vaults has another member.
+func (repo *UsersRepository) Delete(ctx context.Context, userID int64) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	vaultsQuery := `
+		DELETE FROM vaults v
+		WHERE v.org_id IS NULL
+		AND EXISTS (SELECT 1 FROM vault_members m WHERE m.vault_id = v.id AND m.user_id = $1)
+		AND NOT EXISTS (SELECT 1 FROM vault_members m WHERE m.vault_id = v.id AND m.user_id <> $1)
+	`
+	if _, err := tx.ExecContext(ctx, vaultsQuery, userID); err != nil {
+		return err
+	}
+
+	orgsQuery := `
+		DELETE FROM organizations o
+		WHERE EXISTS (SELECT 1 FROM organization_members m WHERE m.org_id = o.id AND m.user_id = $1)
+		AND NOT EXISTS (SELECT 1 FROM organization_members m WHERE m.org_id = o.id AND m.user_id <> $1)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM vault_members m
+			JOIN vaults v ON v.id = m.vault_id
+			WHERE v.org_id = o.id AND m.user_id <> $1
+		)
+	`
+	if _, err := tx.ExecContext(ctx, orgsQuery, userID); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return user.ErrUserNotFound
+	}
+
+	return tx.Commit()
+}
+
+func (repo *UsersRepository) IncrementLoginFailures(ctx context.Context, login string, now time.Time) (int64, int, int, error) {
+	var userID int64
+	var failedAttempts, lockoutCount int
+
+	query := `UPDATE users SET failed_login_attempts = failed_login_attempts + 1
+		WHERE login = $1 AND (locked_until IS NULL OR locked_until <= $2)
+		RETURNING id, failed_login_attempts, lockout_count`
+
+	if err := repo.db.QueryRowContext(ctx, query, login, now).Scan(&userID, &failedAttempts, &lockoutCount); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, user.ErrUserNotFound
+			return 0, 0, 0, user.ErrUserNotFound
 		}
-		return nil, err
+		return 0, 0, 0, err
 	}
-	return &au, nil
+	return userID, failedAttempts, lockoutCount, nil
+}
+
+func (repo *UsersRepository) LockAccount(ctx context.Context, userID int64, until time.Time) error {
+	query := `UPDATE users SET failed_login_attempts = 0, lockout_count = lockout_count + 1, locked_until = $1 WHERE id = $2`
+
+	_, err := repo.db.ExecContext(ctx, query, until, userID)
+	return err
+}
+
+func (repo *UsersRepository) ResetLoginFailures(ctx context.Context, userID int64) error {
+	query := `UPDATE users SET failed_login_attempts = 0, lockout_count = 0, locked_until = NULL WHERE id = $1`
+
+	_, err := repo.db.ExecContext(ctx, query, userID)
+	return err
 }
@@ -0,0 +1,377 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+)
+
+// VaultsRepository implements persistence of organizations, vaults and their
+// members.
+type VaultsRepository struct {
+	db *sql.DB
+}
+
+// NewVaultsRepository constructs a new VaultsRepository using the provided
+// *sql.DB driver.
+func NewVaultsRepository(db *sql.DB) *VaultsRepository {
+	return &VaultsRepository{db: db}
+}
+
+// CreateOrganization inserts the organization and its owner in one statement.
+func (repo *VaultsRepository) CreateOrganization(ctx context.Context, ownerID int64, name string) (*vault.Organization, error) {
+	query := `
+		WITH o AS (
+			INSERT INTO organizations (org_uuid, name) VALUES ($1, $2)
+			RETURNING id, org_uuid, name, created_at
+		), m AS (
+			INSERT INTO organization_members (org_id, user_id, role) SELECT id, $3, 'owner' FROM o
+		)
+		SELECT id, org_uuid, name, created_at FROM o
+	`
+
+	org := vault.Organization{Role: vault.RoleOwner}
+	if err := repo.db.QueryRowContext(ctx, query, uuid.New(), name, ownerID).Scan(&org.ID, &org.OrgUUID, &org.Name, &org.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+func (repo *VaultsRepository) GetOrganization(ctx context.Context, userID int64, orgUUID uuid.UUID) (*vault.Organization, error) {
+	query := `
+		SELECT o.id, o.org_uuid, o.name, m.role, o.created_at
+		FROM organizations o
+		JOIN organization_members m ON m.org_id = o.id
+		WHERE m.user_id = $1 AND o.org_uuid = $2
+	`
+
+	var org vault.Organization
+	if err := repo.db.QueryRowContext(ctx, query, userID, orgUUID).Scan(&org.ID, &org.OrgUUID, &org.Name, &org.Role, &org.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, vault.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+func (repo *VaultsRepository) ListOrganizations(ctx context.Context, userID int64) ([]*vault.Organization, error) {
+	query := `
+		SELECT o.id, o.org_uuid, o.name, m.role, o.created_at
+		FROM organizations o
+		JOIN organization_members m ON m.org_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name, o.id
+	`
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []*vault.Organization
+	for rows.Next() {
+		var org vault.Organization
+		if err := rows.Scan(&org.ID, &org.OrgUUID, &org.Name, &org.Role, &org.CreatedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, &org)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// SetOrganizationMember adds the user to the organization or changes their
+// role. The entries of the vaults of the organization take the next keychain
+// revisions of the user.
+func (repo *VaultsRepository) SetOrganizationMember(ctx context.Context, orgID int64, userID int64, role vault.Role) error {
+	query := `
+		INSERT INTO organization_members (org_id, user_id, role) VALUES ($1, $2, $3)
+		ON CONFLICT (org_id, user_id) DO UPDATE SET role = EXCLUDED.role
+	`
+
+	return repo.changeMember(ctx, orgVaultsFilter, orgID, userID, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, query, orgID, userID, role)
+		return err
+	})
+}
+
+// RemoveOrganizationMember removes the user from the organization. The
+// entries of its vaults take the next keychain revisions of the user, as
+// tombstones unless the user still has access to the vault.
+func (repo *VaultsRepository) RemoveOrganizationMember(ctx context.Context, orgID int64, userID int64) error {
+	query := `DELETE FROM organization_members WHERE org_id = $1 AND user_id = $2`
+
+	return repo.changeMember(ctx, orgVaultsFilter, orgID, userID, func(tx *sql.Tx) error {
+		return execMemberChange(ctx, tx, query, orgID, userID)
+	})
+}
+
+func (repo *VaultsRepository) ListOrganizationMembers(ctx context.Context, orgID int64) ([]*vault.Member, error) {
+	query := `
+		SELECT m.user_id, u.login, m.role, m.created_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1
+		ORDER BY u.login
+	`
+
+	return queryMembers(ctx, repo.db, query, orgID)
+}
+
+// CreateVault inserts the vault and its owner in one statement.
+func (repo *VaultsRepository) CreateVault(ctx context.Context, ownerID int64, orgID *int64, name string) (*vault.Vault, error) {
+	query := `
+		WITH v AS (
+			INSERT INTO vaults (vault_uuid, org_id, name) VALUES ($1, $2, $3)
+			RETURNING id, vault_uuid, org_id, name, created_at
+		), m AS (
+			INSERT INTO vault_members (vault_id, user_id, role) SELECT id, $4, 'owner' FROM v
+		)
+		SELECT v.id, v.vault_uuid, v.org_id, o.org_uuid, v.name, v.created_at
+		FROM v
+		LEFT JOIN organizations o ON o.id = v.org_id
+	`
+
+	var argOrgID any
+	if orgID != nil {
+		argOrgID = *orgID
+	}
+
+	var (
+		v          = vault.Vault{Role: vault.RoleOwner}
+		scanOrgID  sql.NullInt64
+		scanOrgUID uuid.NullUUID
+	)
+	if err := repo.db.QueryRowContext(ctx, query, uuid.New(), argOrgID, name, ownerID).Scan(&v.ID, &v.VaultUUID, &scanOrgID, &scanOrgUID, &v.Name, &v.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	setVaultOrg(&v, scanOrgID, scanOrgUID)
+
+	return &v, nil
+}
+
+// vaultAccessQuery selects the vaults a user has a role in, directly or
+// through the organization of the vault.
+const vaultAccessQuery = `
+	SELECT v.id, v.vault_uuid, v.org_id, o.org_uuid, v.name, v.created_at,
+		COALESCE(vm.role, ''), COALESCE(om.role, '')
+	FROM vaults v
+	LEFT JOIN organizations o ON o.id = v.org_id
+	LEFT JOIN vault_members vm ON vm.vault_id = v.id AND vm.user_id = $1
+	LEFT JOIN organization_members om ON om.org_id = v.org_id AND om.user_id = $1
+	WHERE (vm.user_id IS NOT NULL OR om.user_id IS NOT NULL)
+`
+
+func scanVaultAccess(row interface{ Scan(dest ...any) error }) (*vault.Vault, error) {
+	var (
+		v                  vault.Vault
+		orgID              sql.NullInt64
+		orgUUID            uuid.NullUUID
+		vaultRole, orgRole string
+	)
+
+	if err := row.Scan(&v.ID, &v.VaultUUID, &orgID, &orgUUID, &v.Name, &v.CreatedAt, &vaultRole, &orgRole); err != nil {
+		return nil, err
+	}
+
+	setVaultOrg(&v, orgID, orgUUID)
+	v.Role = vault.Stronger(vault.Role(vaultRole), vault.Role(orgRole))
+
+	return &v, nil
+}
+
+func setVaultOrg(v *vault.Vault, orgID sql.NullInt64, orgUUID uuid.NullUUID) {
+	if orgID.Valid {
+		id := orgID.Int64
+		v.OrgID = &id
+	}
+	if orgUUID.Valid {
+		u := orgUUID.UUID
+		v.OrgUUID = &u
+	}
+}
+
+func (repo *VaultsRepository) GetVault(ctx context.Context, userID int64, vaultUUID uuid.UUID) (*vault.Vault, error) {
+	query := vaultAccessQuery + ` AND v.vault_uuid = $2`
+
+	v, err := scanVaultAccess(repo.db.QueryRowContext(ctx, query, userID, vaultUUID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, vault.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (repo *VaultsRepository) ListVaults(ctx context.Context, userID int64) ([]*vault.Vault, error) {
+	query := vaultAccessQuery + ` ORDER BY v.name, v.id`
+
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []*vault.Vault
+	for rows.Next() {
+		v, err := scanVaultAccess(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// SetVaultMember adds the user to the vault or changes their role. The
+// entries of the vault take the next keychain revisions of the user.
+func (repo *VaultsRepository) SetVaultMember(ctx context.Context, vaultID int64, userID int64, role vault.Role) error {
+	query := `
+		INSERT INTO vault_members (vault_id, user_id, role) VALUES ($1, $2, $3)
+		ON CONFLICT (vault_id, user_id) DO UPDATE SET role = EXCLUDED.role
+	`
+
+	return repo.changeMember(ctx, vaultFilter, vaultID, userID, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, query, vaultID, userID, role)
+		return err
+	})
+}
+
+// RemoveVaultMember removes the user from the vault. Its entries take the
+// next keychain revisions of the user, as tombstones unless the user still
+// has access through the organization.
+func (repo *VaultsRepository) RemoveVaultMember(ctx context.Context, vaultID int64, userID int64) error {
+	query := `DELETE FROM vault_members WHERE vault_id = $1 AND user_id = $2`
+
+	return repo.changeMember(ctx, vaultFilter, vaultID, userID, func(tx *sql.Tx) error {
+		return execMemberChange(ctx, tx, query, vaultID, userID)
+	})
+}
+
+func (repo *VaultsRepository) ListVaultMembers(ctx context.Context, vaultID int64) ([]*vault.Member, error) {
+	query := `
+		SELECT m.user_id, u.login, m.role, m.created_at
+		FROM vault_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.vault_id = $1
+		ORDER BY u.login
+	`
+
+	return queryMembers(ctx, repo.db, query, vaultID)
+}
+
+// Filters selecting the vaults affected by a membership change; $1 is the
+// vault or the organization.
+const (
+	vaultFilter     = `SELECT $1::bigint`
+	orgVaultsFilter = `SELECT id FROM vaults WHERE org_id = $1`
+)
+
+// changeMember runs change and then moves every entry of the vaults selected
+// by filter with id, deleted ones the user has seen included, to a next
+// keychain revision of the user, in one transaction. This way the delta sync
+// and the event stream of the user pick up the entries they gained or lost
+// access to. The vaults stay locked until the end, so an entry written
+// meanwhile is recorded either here or by touchVaultEntry for the user.
+func (repo *VaultsRepository) changeMember(ctx context.Context, filter string, id int64, userID int64, change func(tx *sql.Tx) error) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM vaults WHERE id IN (`+filter+`) ORDER BY id FOR UPDATE`, id); err != nil {
+		return err
+	}
+
+	if err := change(tx); err != nil {
+		return err
+	}
+
+	query := `
+		WITH entries AS (
+			SELECT id, row_number() OVER (ORDER BY id) AS n
+			FROM keychain
+			WHERE vault_id IN (` + filter + `)
+			AND (soft_deleted = false OR id IN (SELECT key_id FROM vault_entry_revisions WHERE user_id = $2))
+		), rev AS (
+			UPDATE users SET keychain_revision = keychain_revision + (SELECT count(*) FROM entries)
+			WHERE id = $2
+			RETURNING keychain_revision - (SELECT count(*) FROM entries) AS base
+		)
+		INSERT INTO vault_entry_revisions (user_id, key_id, revision)
+		SELECT $2, e.id, rev.base + e.n FROM entries e, rev
+		ON CONFLICT (user_id, key_id) DO UPDATE SET revision = EXCLUDED.revision
+	`
+
+	if _, err := tx.ExecContext(ctx, query, id, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// execMemberChange runs a statement removing a member and reports
+// vault.ErrMemberNotFound when nothing was removed.
+func execMemberChange(ctx context.Context, db execer, query string, args ...any) error {
+	res, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return vault.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+func queryMembers(ctx context.Context, db *sql.DB, query string, args ...any) ([]*vault.Member, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []*vault.Member
+	for rows.Next() {
+		var m vault.Member
+		if err := rows.Scan(&m.UserID, &m.Login, &m.Role, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
@@ -0,0 +1,267 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
+	"time"
+)
+
+// WebhooksRepository implements persistence of webhook subscriptions and
+// their delivery outbox.
+type WebhooksRepository struct {
+	db *sql.DB
+}
+
+// NewWebhooksRepository constructs a new WebhooksRepository using the
+// provided *sql.DB driver.
+func NewWebhooksRepository(db *sql.DB) *WebhooksRepository {
+	return &WebhooksRepository{db: db}
+}
+
+const subscriptionColumns = `id, subscription_id, user_id, url, events, tags, secret_nonce, secret_encrypted, created_at`
+
+// ownedBy selects the subscriptions of the user in $1, or the global ones
+// when $1 is webhook.GlobalOwner.
+const ownedBy = `(user_id = $1 OR ($1::bigint = 0 AND user_id IS NULL))`
+
+const deliveryColumns = `id, delivery_id, subscription_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at`
+
+func scanSubscription(row interface{ Scan(dest ...any) error }) (*webhook.Subscription, error) {
+	var s webhook.Subscription
+	var userID sql.NullInt64
+	var events []string
+
+	if err := row.Scan(&s.ID, &s.SubscriptionID, &userID, &s.URL, pq.Array(&events), pq.Array(&s.Tags), &s.SecretNonce, &s.SecretEncrypted, &s.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	s.UserID = userID.Int64
+	s.Events = make([]webhook.EventType, 0, len(events))
+	for _, e := range events {
+		s.Events = append(s.Events, webhook.EventType(e))
+	}
+
+	return &s, nil
+}
+
+func scanDelivery(row interface{ Scan(dest ...any) error }) (*webhook.Delivery, error) {
+	var d webhook.Delivery
+	var statusCode sql.NullInt64
+	var lastError sql.NullString
+	var deliveredAt sql.NullTime
+
+	if err := row.Scan(&d.ID, &d.DeliveryID, &d.SubscriptionID, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt, &statusCode, &lastError, &d.CreatedAt, &deliveredAt); err != nil {
+		return nil, err
+	}
+
+	d.LastStatusCode = int(statusCode.Int64)
+	d.LastError = lastError.String
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+
+	return &d, nil
+}
+
+func (repo *WebhooksRepository) Create(ctx context.Context, sub *webhook.Subscription) (*webhook.Subscription, error) {
+	query := `INSERT INTO webhook_subscriptions (subscription_id, user_id, url, events, tags, secret_nonce, secret_encrypted)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING ` + subscriptionColumns
+
+	events := make([]string, 0, len(sub.Events))
+	for _, e := range sub.Events {
+		events = append(events, string(e))
+	}
+	tags := sub.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	owner := sql.NullInt64{Int64: sub.UserID, Valid: sub.UserID != webhook.GlobalOwner}
+
+	return scanSubscription(repo.db.QueryRowContext(ctx, query, uuid.New(), owner, sub.URL, pq.Array(events), pq.Array(tags), sub.SecretNonce, sub.SecretEncrypted))
+}
+
+func (repo *WebhooksRepository) ListByUser(ctx context.Context, userID int64) ([]*webhook.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions WHERE ` + ownedBy + ` ORDER BY created_at DESC`
+
+	return repo.listSubscriptions(ctx, query, userID)
+}
+
+func (repo *WebhooksRepository) ListForEvents(ctx context.Context, userID int64) ([]*webhook.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions WHERE user_id = $1 OR user_id IS NULL ORDER BY created_at DESC`
+
+	return repo.listSubscriptions(ctx, query, userID)
+}
+
+func (repo *WebhooksRepository) listSubscriptions(ctx context.Context, query string, args ...any) ([]*webhook.Subscription, error) {
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []*webhook.Subscription
+	for rows.Next() {
+		s, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+
+	return list, rows.Err()
+}
+
+func (repo *WebhooksRepository) Get(ctx context.Context, userID int64, subscriptionID uuid.UUID) (*webhook.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions WHERE ` + ownedBy + ` AND subscription_id = $2`
+
+	s, err := scanSubscription(repo.db.QueryRowContext(ctx, query, userID, subscriptionID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, webhook.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (repo *WebhooksRepository) Delete(ctx context.Context, userID int64, subscriptionID uuid.UUID) error {
+	query := `DELETE FROM webhook_subscriptions WHERE ` + ownedBy + ` AND subscription_id = $2`
+
+	res, err := repo.db.ExecContext(ctx, query, userID, subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return webhook.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *WebhooksRepository) Enqueue(ctx context.Context, deliveries []*webhook.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO webhook_deliveries (delivery_id, subscription_id, event_type, payload, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	for _, d := range deliveries {
+		if _, err := tx.ExecContext(ctx, query, d.DeliveryID, d.SubscriptionID, d.EventType, d.Payload, d.NextAttemptAt); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *WebhooksRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*webhook.Delivery, map[int64]*webhook.Subscription, error) {
+	query := `WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d SET next_attempt_at = $2
+		FROM due WHERE d.id = due.id
+		RETURNING d.id, d.delivery_id, d.subscription_id, d.event_type, d.payload, d.status, d.attempts,
+			d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.delivered_at`
+
+	rows, err := repo.db.QueryContext(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer rows.Close()
+
+	var (
+		list   []*webhook.Delivery
+		subIDs []int64
+	)
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		list = append(list, d)
+		subIDs = append(subIDs, d.SubscriptionID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	subs := make(map[int64]*webhook.Subscription)
+	if len(subIDs) == 0 {
+		return list, subs, nil
+	}
+
+	subRows, err := repo.db.QueryContext(ctx, `SELECT `+subscriptionColumns+` FROM webhook_subscriptions WHERE id = ANY($1)`, pq.Array(subIDs))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer subRows.Close()
+
+	for subRows.Next() {
+		s, err := scanSubscription(subRows)
+		if err != nil {
+			return nil, nil, err
+		}
+		subs[s.ID] = s
+	}
+
+	return list, subs, subRows.Err()
+}
+
+func (repo *WebhooksRepository) SaveAttempt(ctx context.Context, d *webhook.Delivery) error {
+	query := `UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5, last_error = $6, delivered_at = $7
+		WHERE id = $1`
+
+	argStatusCode := sql.NullInt64{Int64: int64(d.LastStatusCode), Valid: d.LastStatusCode != 0}
+	argLastError := sql.NullString{String: d.LastError, Valid: d.LastError != ""}
+
+	_, err := repo.db.ExecContext(ctx, query, d.ID, d.Status, d.Attempts, d.NextAttemptAt, argStatusCode, argLastError, d.DeliveredAt)
+	return err
+}
+
+func (repo *WebhooksRepository) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]*webhook.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE subscription_id = $1 ORDER BY id DESC LIMIT $2`
+
+	rows, err := repo.db.QueryContext(ctx, query, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var list []*webhook.Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, d)
+	}
+
+	return list, rows.Err()
+}
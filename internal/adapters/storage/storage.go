@@ -4,9 +4,21 @@ import (
 	"context"
 	"github.com/thxhix/passKeeper/internal/adapters/storage/postgres"
 	"github.com/thxhix/passKeeper/internal/config"
+	"github.com/thxhix/passKeeper/internal/domain/admin"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/device"
+	"github.com/thxhix/passKeeper/internal/domain/emergency"
+	"github.com/thxhix/passKeeper/internal/domain/invite"
 	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"github.com/thxhix/passKeeper/internal/domain/recovery"
+	"github.com/thxhix/passKeeper/internal/domain/send"
+	"github.com/thxhix/passKeeper/internal/domain/serviceaccount"
+	"github.com/thxhix/passKeeper/internal/domain/share"
 	"github.com/thxhix/passKeeper/internal/domain/token"
 	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
 	"go.uber.org/zap"
 	"time"
 )
@@ -16,6 +28,23 @@ type Storage struct {
 	User     user.UserRepository
 	Token    token.TokenRepository
 	Keychain keychain.KeychainRepository
+
+	// KeychainEvents receives the keychain changes of every server instance.
+	KeychainEvents keychain.EventListener
+
+	AccessRevocation token.AccessRevocationRepository
+	PersonalToken    pat.PersonalTokenRepository
+	ServiceAccount   serviceaccount.ServiceAccountRepository
+	Device           device.DeviceRepository
+	Audit            audit.AuditRepository
+	Webhook          webhook.WebhookRepository
+	Admin            admin.AdminRepository
+	Invite           invite.InviteRepository
+	Vault            vault.VaultRepository
+	Share            share.ShareRepository
+	Send             send.SendRepository
+	Emergency        emergency.ContactRepository
+	Recovery         recovery.RecoveryRepository
 }
 
 // NewStorage creates repository instances, runs migrations and returns a cleanup
@@ -55,10 +84,39 @@ func NewStorage(ctx context.Context, cfg *config.Config, logger *zap.Logger) (*S
 	userRepository := postgres.NewUsersRepository(db.Driver)
 	tokenRepository := postgres.NewTokensRepository(db.Driver)
 	keychainRepository := postgres.NewKeychainRepository(db.Driver)
+	accessRevocationRepository := postgres.NewAccessRevocationRepository(db.Driver)
+	personalTokenRepository := postgres.NewPersonalTokensRepository(db.Driver)
+	serviceAccountRepository := postgres.NewServiceAccountsRepository(db.Driver)
+	deviceRepository := postgres.NewDevicesRepository(db.Driver)
+	auditRepository := postgres.NewAuditEventsRepository(db.Driver)
+	webhookRepository := postgres.NewWebhooksRepository(db.Driver)
+	adminRepository := postgres.NewAdminRepository(db.Driver)
+	inviteRepository := postgres.NewInvitesRepository(db.Driver)
+	vaultRepository := postgres.NewVaultsRepository(db.Driver)
+	shareRepository := postgres.NewSharesRepository(db.Driver)
+	sendRepository := postgres.NewSendsRepository(db.Driver)
+	emergencyRepository := postgres.NewEmergencyRepository(db.Driver)
+	recoveryRepository := postgres.NewRecoveryRepository(db.Driver)
 
 	return &Storage{
 		User:     userRepository,
 		Token:    tokenRepository,
 		Keychain: keychainRepository,
+
+		KeychainEvents: postgres.NewKeychainEventListener(cfg.PostgresQL),
+
+		AccessRevocation: accessRevocationRepository,
+		PersonalToken:    personalTokenRepository,
+		ServiceAccount:   serviceAccountRepository,
+		Device:           deviceRepository,
+		Audit:            auditRepository,
+		Webhook:          webhookRepository,
+		Admin:            adminRepository,
+		Invite:           inviteRepository,
+		Vault:            vaultRepository,
+		Share:            shareRepository,
+		Send:             sendRepository,
+		Emergency:        emergencyRepository,
+		Recovery:         recoveryRepository,
 	}, closeFn, nil
 }
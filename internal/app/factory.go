@@ -2,11 +2,16 @@ package app
 
 import (
 	"context"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/adapters/notify"
 	reposStorage "github.com/thxhix/passKeeper/internal/adapters/storage"
 	"github.com/thxhix/passKeeper/internal/client/api"
 	"github.com/thxhix/passKeeper/internal/client/cli/commands"
 	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"github.com/thxhix/passKeeper/internal/client/replica"
 	"github.com/thxhix/passKeeper/internal/config"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/ratelimit"
 	"github.com/thxhix/passKeeper/internal/security"
 	"github.com/thxhix/passKeeper/internal/server/http_server"
 	"github.com/thxhix/passKeeper/internal/services"
@@ -16,8 +21,15 @@ import (
 	"go.uber.org/zap"
 	"gopkg.in/urfave/cli.v1"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+// keychainListenerRestart is how long to wait before listening for keychain
+// changes again after the listener failed.
+const keychainListenerRestart = 5 * time.Second
+
 func RunServer(cfg *config.Config, logger *zap.Logger) error {
 	var err error
 
@@ -31,15 +43,103 @@ func RunServer(cfg *config.Config, logger *zap.Logger) error {
 
 	defer closeFn()
 
-	hasher := security.NewHasher()
-	jwtManager := security.NewJWTManager(cfg)
-	authService := services.NewAuthService(storage.User, storage.Token, &hasher, &jwtManager)
+	keyRing, err := security.LoadKeyRing(cfg)
+	if err != nil {
+		logger.Error("Failed to load JWT signing keys", zap.Error(err))
+		return err
+	}
+	if cfg.JWTKeysDir == "" {
+		logger.Warn("JWT_KEYS_DIR is not set, access tokens are signed with an ephemeral key")
+	}
+	go reloadKeyRingOnSignal(keyRing, cfg, logger)
+
+	hasher := security.NewHasherWithLimit(
+		security.DefaultArgon2Params,
+		cfg.PasswordHashConcurrency,
+		time.Duration(cfg.PasswordHashWaitMilliseconds)*time.Millisecond,
+	)
+	jwtManager := security.NewJWTManager(cfg, keyRing)
+	revocationService := services.NewAccessRevocationService(storage.AccessRevocation, cfg)
+	auditService := services.NewAuditService(storage.Audit)
 
 	aead, err := security.NewAEAD(logger, cfg)
-	keychainService := services.NewKeychainService(storage.Keychain, aead)
+	if err != nil {
+		logger.Error("Failed to create AEAD", zap.Error(err))
+		return err
+	}
+	webhookService := services.NewWebhookService(storage.Webhook, aead, notify.NewWebhookSender())
+	go dispatchWebhooks(ctx, &webhookService, time.Duration(cfg.WebhookDispatchSeconds)*time.Second, logger)
+
+	passwordPolicy, err := services.NewPasswordPolicy(cfg)
+	if err != nil {
+		logger.Error("Failed to load password policy", zap.Error(err))
+		return err
+	}
+
+	var mailer services.Mailer
+	switch cfg.Mailer {
+	case config.MailerLog:
+		mailer = notify.NewLogMailer(logger)
+	case config.MailerSMTP:
+		mailer, err = notify.NewSMTPMailer(cfg.SMTPAddress, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+		if err != nil {
+			logger.Error("Failed to configure SMTP mailer", zap.Error(err))
+			return err
+		}
+	default:
+		err := fmt.Errorf("unknown mailer %q", cfg.Mailer)
+		logger.Error("Failed to configure mailer", zap.Error(err))
+		return err
+	}
+
+	actionTokens, err := security.NewActionTokenSigner(cfg.MailTokenSecret, cfg.JWTIssuer)
+	if err != nil {
+		logger.Error("Failed to create mail token signer", zap.Error(err))
+		return err
+	}
+	emailService := services.NewEmailService(storage.User, storage.Token, &hasher, passwordPolicy, revocationService, actionTokens, mailer)
+	deviceService := services.NewDeviceService(storage.Device, &emailService)
+
+	inviteService := services.NewInviteService(storage.Invite)
+	var invites services.InviteGate
+	switch cfg.RegistrationMode {
+	case config.RegistrationOpen:
+	case config.RegistrationInvite:
+		invites = &inviteService
+	default:
+		err := fmt.Errorf("unknown registration mode %q", cfg.RegistrationMode)
+		logger.Error("Failed to configure registration", zap.Error(err))
+		return err
+	}
+
+	var uniformRegistration bool
+	switch cfg.RegistrationResponse {
+	case config.RegistrationResponseDetailed:
+	case config.RegistrationResponseUniform:
+		uniformRegistration = true
+	default:
+		err := fmt.Errorf("unknown registration response %q", cfg.RegistrationResponse)
+		logger.Error("Failed to configure registration", zap.Error(err))
+		return err
+	}
 
-	h := handlers.NewHandlers(logger, &authService, &keychainService)
-	r := http.NewRouter(h, &jwtManager)
+	authService := services.NewAuthService(storage.User, storage.Token, &hasher, &jwtManager, revocationService, &deviceService, &auditService, &webhookService, invites, &emailService, services.NewLockoutPolicy(cfg), passwordPolicy)
+	keychainService := services.NewKeychainService(storage.Keychain, storage.ServiceAccount, storage.Vault, &auditService, &webhookService, aead)
+	keychainEventService := services.NewKeychainEventService(storage.ServiceAccount)
+	go listenKeychainEvents(ctx, storage.KeychainEvents, keychainEventService, logger)
+	accountService := services.NewAccountService(storage.User, storage.Keychain, storage.Vault, storage.Share, storage.Send, &hasher, aead, revocationService, &auditService, &webhookService, services.NewLockoutPolicy(cfg))
+	personalTokenService := services.NewPersonalTokenService(storage.PersonalToken)
+	serviceAccountService := services.NewServiceAccountService(storage.ServiceAccount, &jwtManager)
+	vaultService := services.NewVaultService(storage.Vault, storage.User)
+	shareService := services.NewShareService(storage.Share, storage.Keychain, storage.User, &auditService)
+	sendService := services.NewSendService(storage.Send, &auditService)
+	emergencyService := services.NewEmergencyService(storage.Emergency, storage.User, storage.Token, &keychainService, &hasher, passwordPolicy, revocationService, &auditService, &webhookService, mailer)
+	recoveryService := services.NewRecoveryService(storage.Recovery, storage.User, &auditService)
+	adminService := services.NewAdminService(storage.Admin, storage.User, storage.Token, storage.Device, revocationService)
+
+	h := handlers.NewHandlers(logger, &authService, &accountService, &keychainService, keychainEventService, &personalTokenService, serviceAccountService, &deviceService, &auditService, &webhookService, &adminService, &inviteService, &emailService, &vaultService, &shareService, &sendService, &emergencyService, &recoveryService, &jwtManager, uniformRegistration)
+	limiter := ratelimit.NewLimiter(ratelimit.NewPolicy(cfg), time.Now)
+	r := http.NewRouter(h, &jwtManager, revocationService, &personalTokenService, serviceAccountService, &adminService, limiter)
 	s := http_server.NewServer(r, cfg, logger)
 
 	err = s.Start()
@@ -51,6 +151,105 @@ func RunServer(cfg *config.Config, logger *zap.Logger) error {
 	return err
 }
 
+// VerifyAudit checks the hash chain of the whole audit log and logs the number
+// of events and the hash of the newest one, which can be stored elsewhere to
+// detect later removal of events. A broken chain is returned as an error.
+func VerifyAudit(cfg *config.Config, logger *zap.Logger) error {
+	ctx := context.Background()
+
+	storage, closeFn, err := reposStorage.NewStorage(ctx, cfg, logger)
+	if err != nil {
+		logger.Error("Failed to create repo storage", zap.Error(err))
+		return err
+	}
+
+	defer closeFn()
+
+	auditService := services.NewAuditService(storage.Audit)
+	res, err := auditService.Verify(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Audit log verified", zap.Int64("events", res.Events), zap.String("head_hash", res.HeadHash))
+	return nil
+}
+
+// BootstrapAdmin grants the administrator role to the registered user with the
+// given login, connecting to the database directly. It refuses to run once an
+// administrator exists.
+func BootstrapAdmin(cfg *config.Config, logger *zap.Logger, login string) error {
+	ctx := context.Background()
+
+	storage, closeFn, err := reposStorage.NewStorage(ctx, cfg, logger)
+	if err != nil {
+		logger.Error("Failed to create repo storage", zap.Error(err))
+		return err
+	}
+
+	defer closeFn()
+
+	revocationService := services.NewAccessRevocationService(storage.AccessRevocation, cfg)
+	adminService := services.NewAdminService(storage.Admin, storage.User, storage.Token, storage.Device, revocationService)
+	if err := adminService.Bootstrap(ctx, login); err != nil {
+		return err
+	}
+
+	logger.Info("Administrator bootstrapped", zap.String("login", login))
+	return nil
+}
+
+// dispatchWebhooks sends due webhook deliveries every interval. Deliveries
+// live in the database, so nothing is lost if the server stops in between.
+// A non-positive interval disables delivery.
+func dispatchWebhooks(ctx context.Context, webhooks services.IWebhookService, interval time.Duration, logger *zap.Logger) {
+	if interval <= 0 {
+		logger.Warn("WEBHOOK_DISPATCH_SECONDS is not positive, webhooks are not delivered")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := webhooks.DeliverDue(ctx); err != nil {
+			logger.Error("Failed to deliver webhooks", zap.Error(err))
+		}
+	}
+}
+
+// listenKeychainEvents feeds the keychain changes announced by the database
+// to the event streams of this instance, starting the listener again if it
+// fails. Event streams are told to resync after each restart.
+func listenKeychainEvents(ctx context.Context, listener keychain.EventListener, events *services.KeychainEventService, logger *zap.Logger) {
+	for {
+		err := listener.Listen(ctx, events.Dispatch)
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.Error("Keychain event listener stopped", zap.Error(err))
+		time.Sleep(keychainListenerRestart)
+		events.Dispatch(keychain.Event{Type: keychain.EventResync})
+	}
+}
+
+// reloadKeyRingOnSignal re-reads the JWT signing keys and the active key file
+// on every SIGHUP, which lets operators rotate keys without restarting the
+// server.
+func reloadKeyRingOnSignal(keyRing *security.KeyRing, cfg *config.Config, logger *zap.Logger) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+
+	for range sigCh {
+		if err := keyRing.Reload(cfg); err != nil {
+			logger.Error("Failed to reload JWT signing keys", zap.Error(err))
+			continue
+		}
+		logger.Info("JWT signing keys reloaded", zap.String("active_kid", keyRing.Active().ID))
+	}
+}
+
 func RunClient(cfg *config.ClientConfig, logger *zap.Logger, cliApp *cli.App) error {
 	var err error
 
@@ -59,24 +258,138 @@ func RunClient(cfg *config.ClientConfig, logger *zap.Logger, cliApp *cli.App) er
 		logger.Error("Failed to create http client", zap.Error(err))
 		return err
 	}
+	if cfg.AccessToken != "" {
+		httpClient.SetAccessToken(cfg.AccessToken)
+	}
 
 	authAPI := api.NewAuthAPI(httpClient)
 	authService := client_services.NewAuthClientService(authAPI, httpClient)
-	authCmd := commands.NewAuthCLICommands(authService)
+
+	accountAPI := api.NewAccountAPI(httpClient)
+	accountService := client_services.NewAccountClientService(accountAPI, httpClient)
+	accountCmd := commands.NewAccountCLICommands(accountService)
 
 	keychainAPI := api.NewKeychainAPI(httpClient)
-	keychainService := client_services.NewKeychainClientService(keychainAPI, httpClient)
+	var replicaStore *replica.Store
+	if !cfg.NoReplica && cfg.AccessToken == "" && cfg.ReplicaPath != "" {
+		replicaStore = replica.NewStore(cfg.ReplicaPath, true, commands.ReplicaPassphrase(cfg.ReplicaPassphrase))
+	}
+	keychainService := client_services.NewKeychainClientService(keychainAPI, httpClient, replicaStore)
 	keychainCmd := commands.NewKeychainCLICommands(keychainService)
 
+	sharesAPI := api.NewSharesAPI(httpClient)
+	shareService := client_services.NewShareClientService(sharesAPI, keychainAPI, httpClient)
+	shareCmd := commands.NewShareCLICommands(shareService)
+
+	recoveryAPI := api.NewRecoveryAPI(httpClient)
+	recoveryService := client_services.NewRecoveryClientService(recoveryAPI, sharesAPI, httpClient)
+	recoveryCmd := commands.NewRecoveryCLICommands(recoveryService)
+
+	sendsAPI := api.NewSendsAPI(httpClient)
+	sendService := client_services.NewSendClientService(sendsAPI, keychainAPI, httpClient)
+	sendCmd := commands.NewSendCLICommands(sendService)
+
+	authCmd := commands.NewAuthCLICommands(authService, shareService, keychainService)
+
+	tokensAPI := api.NewTokensAPI(httpClient)
+	tokenService := client_services.NewTokenClientService(tokensAPI, httpClient)
+	tokenCmd := commands.NewTokenCLICommands(tokenService)
+
+	devicesAPI := api.NewDevicesAPI(httpClient)
+	deviceService := client_services.NewDeviceClientService(devicesAPI, httpClient)
+	deviceCmd := commands.NewDeviceCLICommands(deviceService)
+
+	auditAPI := api.NewAuditAPI(httpClient)
+	auditService := client_services.NewAuditClientService(auditAPI, httpClient)
+	auditCmd := commands.NewAuditCLICommands(auditService)
+
+	webhooksAPI := api.NewWebhooksAPI(httpClient)
+	webhookService := client_services.NewWebhookClientService(webhooksAPI, httpClient)
+	webhookCmd := commands.NewWebhookCLICommands(webhookService)
+
+	invitesAPI := api.NewInvitesAPI(httpClient)
+	inviteService := client_services.NewInviteClientService(invitesAPI, httpClient)
+	inviteCmd := commands.NewInviteCLICommands(inviteService)
+
+	vaultsAPI := api.NewVaultsAPI(httpClient)
+	vaultService := client_services.NewVaultClientService(vaultsAPI, httpClient)
+	vaultCmd := commands.NewVaultCLICommands(vaultService)
+
+	emergencyAPI := api.NewEmergencyAPI(httpClient)
+	emergencyService := client_services.NewEmergencyClientService(emergencyAPI, httpClient)
+	emergencyCmd := commands.NewEmergencyCLICommands(emergencyService)
+
 	cliApp.Commands = []cli.Command{
 		authCmd.RegisterCmd(),
 		authCmd.LoginCmd(),
 		authCmd.RefreshTokenCmd(),
+		authCmd.PasswdCmd(),
+		authCmd.VerifyEmailCmd(),
+		authCmd.ForgotPasswordCmd(),
+		authCmd.ResetPasswordCmd(),
+		authCmd.LogoutCmd(),
+
+		accountCmd.Account(),
+		tokenCmd.Token(),
+		deviceCmd.Devices(),
+		auditCmd.Audit(),
+		webhookCmd.Webhooks(),
+		inviteCmd.Invite(),
+		vaultCmd.Org(),
+		vaultCmd.Vault(),
+		shareCmd.Keys(),
+		shareCmd.Share(),
+		shareCmd.Shared(),
+		recoveryCmd.Recovery(),
+		sendCmd.Send(),
+		sendCmd.Sends(),
+		sendCmd.Receive(),
+		emergencyCmd.Emergency(),
 
 		keychainCmd.Add(),
 		keychainCmd.List(),
 		keychainCmd.Get(),
 		keychainCmd.Delete(),
+		keychainCmd.Sync(),
+		keychainCmd.Watch(),
+	}
+
+	return cliApp.Run(os.Args)
+}
+
+// RunAdmin runs the passkeeper-admin CLI. User management goes through the API
+// with the session stored by the passKeeper client; bootstrap connects to the
+// database with the server configuration.
+func RunAdmin(cfg *config.ClientConfig, logger *zap.Logger, cliApp *cli.App) error {
+	httpClient, err := client_http.NewHttpClient(cfg.ServerAddress, logger)
+	if err != nil {
+		logger.Error("Failed to create http client", zap.Error(err))
+		return err
+	}
+
+	adminAPI := api.NewAdminAPI(httpClient)
+	adminService := client_services.NewAdminClientService(adminAPI, httpClient)
+	adminCmd := commands.NewAdminCLICommands(adminService)
+
+	webhooksAPI := api.NewGlobalWebhooksAPI(httpClient)
+	webhookService := client_services.NewWebhookClientService(webhooksAPI, httpClient)
+	webhookCmd := commands.NewGlobalWebhookCLICommands(webhookService)
+
+	bootstrap := func(login string, databaseURI string) error {
+		serverCfg, err := config.NewAdminConfig()
+		if err != nil {
+			return err
+		}
+		if databaseURI != "" {
+			serverCfg.PostgresQL = databaseURI
+		}
+		return BootstrapAdmin(serverCfg, logger, login)
+	}
+
+	cliApp.Commands = []cli.Command{
+		adminCmd.Bootstrap(bootstrap),
+		adminCmd.Users(),
+		webhookCmd.Webhooks(),
 	}
 
 	return cliApp.Run(os.Args)
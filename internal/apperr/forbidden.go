@@ -0,0 +1,17 @@
+package apperr
+
+// ForbiddenError reports that the caller is authenticated but not allowed to
+// perform the operation.
+type ForbiddenError struct {
+	Message string
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Message
+}
+
+func NewForbiddenError(m string) *ForbiddenError {
+	return &ForbiddenError{
+		Message: m,
+	}
+}
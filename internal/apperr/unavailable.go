@@ -0,0 +1,21 @@
+package apperr
+
+import "time"
+
+// UnavailableError reports that the server is temporarily out of capacity
+// and the caller should try again after RetryAfter.
+type UnavailableError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *UnavailableError) Error() string {
+	return e.Message
+}
+
+func NewUnavailableError(m string, retryAfter time.Duration) *UnavailableError {
+	return &UnavailableError{
+		Message:    m,
+		RetryAfter: retryAfter,
+	}
+}
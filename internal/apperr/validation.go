@@ -1,9 +1,19 @@
 package apperr
 
-type ValidationError struct {
+// Violation is a single failed rule of a validation. Rule is a stable
+// identifier clients can match on, Message explains it to a person.
+type Violation struct {
+	Rule    string
 	Message string
 }
 
+// ValidationError reports invalid input. Violations, when present, list every
+// rule the input failed.
+type ValidationError struct {
+	Message    string
+	Violations []Violation
+}
+
 func (e *ValidationError) Error() string {
 	return e.Message
 }
@@ -13,3 +23,10 @@ func NewValidationError(m string) *ValidationError {
 		Message: m,
 	}
 }
+
+func NewViolationsError(m string, violations []Violation) *ValidationError {
+	return &ValidationError{
+		Message:    m,
+		Violations: violations,
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"io"
+	"net/http"
+)
+
+// AccountAPI provides HTTP methods for account-wide operations such as
+// personal data export and account deletion.
+type AccountAPI struct {
+	c *client_http.Client
+}
+
+// NewAccountAPI creates a new AccountAPI instance using the provided HTTP client.
+func NewAccountAPI(client *client_http.Client) *AccountAPI {
+	return &AccountAPI{
+		c: client,
+	}
+}
+
+// Export downloads the zip archive with all personal data of the user and
+// writes it into dst. The password is re-checked by the server.
+func (a *AccountAPI) Export(ctx context.Context, req *dto.AccountPasswordRequest, dst io.Writer) error {
+	if err := a.c.DoStream(ctx, http.MethodPost, "/api/account/export", req, dst); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete permanently removes the account of the current user. The password is
+// re-checked by the server.
+func (a *AccountAPI) Delete(ctx context.Context, req *dto.AccountPasswordRequest) error {
+	if err := a.c.Do(ctx, http.MethodDelete, "/api/account", req, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// ChangeEmail sets a new email address for the current user. The server mails
+// a verification token to it.
+func (a *AccountAPI) ChangeEmail(ctx context.Context, req *dto.ChangeEmailRequest) error {
+	if err := a.c.Do(ctx, http.MethodPut, "/api/account/email", req, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
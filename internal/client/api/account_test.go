@@ -0,0 +1,68 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	clientpkg "github.com/thxhix/passKeeper/internal/transport/client_http"
+	dto "github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestAccountAPI_ExportAndDelete(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in dto.AccountPasswordRequest
+		_ = json.NewDecoder(r.Body).Decode(&in)
+
+		if in.Password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "wrong login or password"})
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/account/export":
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write([]byte("PK-archive"))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/account":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	api := NewAccountAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var buf bytes.Buffer
+	if err := api.Export(ctx, &dto.AccountPasswordRequest{Password: "secret"}, &buf); err != nil {
+		t.Fatalf("Export expected nil err, got: %v", err)
+	}
+	if buf.String() != "PK-archive" {
+		t.Fatalf("Export unexpected body: %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := api.Export(ctx, &dto.AccountPasswordRequest{Password: "wrong"}, &buf); err == nil {
+		t.Fatalf("Export expected error for wrong password, got nil")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("Export must not write error body into dst")
+	}
+
+	if err := api.Delete(ctx, &dto.AccountPasswordRequest{Password: "secret"}); err != nil {
+		t.Fatalf("Delete expected nil err, got: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// AdminAPI provides HTTP methods for user administration.
+type AdminAPI struct {
+	c *client_http.Client
+}
+
+// NewAdminAPI creates a new AdminAPI instance using the provided HTTP client.
+func NewAdminAPI(client *client_http.Client) *AdminAPI {
+	return &AdminAPI{
+		c: client,
+	}
+}
+
+// ListUsers returns a page of users. Zero values use the server defaults.
+func (a *AdminAPI) ListUsers(ctx context.Context, limit int, offset int) (*dto.AdminUsersResponse, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+
+	path := "/api/admin/users"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var resp dto.AdminUsersResponse
+	if err := a.c.Do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Disable disables the user with the given ID and ends their sessions.
+func (a *AdminAPI) Disable(ctx context.Context, userID int64) error {
+	return a.post(ctx, userID, "disable", nil)
+}
+
+// Enable enables the user with the given ID.
+func (a *AdminAPI) Enable(ctx context.Context, userID int64) error {
+	return a.post(ctx, userID, "enable", nil)
+}
+
+// Logout revokes every session of the user with the given ID.
+func (a *AdminAPI) Logout(ctx context.Context, userID int64) error {
+	return a.post(ctx, userID, "logout", nil)
+}
+
+// ResetSecondFactor removes every device of the user with the given ID.
+func (a *AdminAPI) ResetSecondFactor(ctx context.Context, userID int64) (*dto.AdminResetSecondFactorResponse, error) {
+	var resp dto.AdminResetSecondFactorResponse
+	if err := a.post(ctx, userID, "reset-2fa", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Stats returns the storage stats of the user with the given ID.
+func (a *AdminAPI) Stats(ctx context.Context, userID int64) (*dto.AdminUserStatsResponse, error) {
+	var resp dto.AdminUserStatsResponse
+	if err := a.c.Do(ctx, http.MethodGet, adminUserPath(userID)+"/stats", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// post performs an administrative action on the user.
+func (a *AdminAPI) post(ctx context.Context, userID int64, action string, out any) error {
+	if err := a.c.Do(ctx, http.MethodPost, adminUserPath(userID)+"/"+action, nil, out); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+func adminUserPath(userID int64) string {
+	return "/api/admin/users/" + strconv.FormatInt(userID, 10)
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	clientpkg "github.com/thxhix/passKeeper/internal/transport/client_http"
+	dto "github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestAdminAPI_Users(t *testing.T) {
+	var actions []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/users":
+			if r.URL.Query().Get("limit") != "10" || r.URL.Query().Get("offset") != "20" {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "bad query params provided"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(dto.AdminUsersResponse{
+				Users: []*dto.AdminUserRecord{{ID: 21, Login: "alice", IsAdmin: true}},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/admin/users/21/reset-2fa":
+			_ = json.NewEncoder(w).Encode(dto.AdminResetSecondFactorResponse{RemovedDevices: 2})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/admin/users/21/disable",
+			r.Method == http.MethodPost && r.URL.Path == "/api/admin/users/21/enable",
+			r.Method == http.MethodPost && r.URL.Path == "/api/admin/users/21/logout":
+			actions = append(actions, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/admin/users/21/stats":
+			_ = json.NewEncoder(w).Encode(dto.AdminUserStatsResponse{UserID: 21, Entries: 3, EntriesByType: map[string]int64{"text": 3}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "resource not found"})
+		}
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	api := NewAdminAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	list, err := api.ListUsers(ctx, 10, 20)
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if len(list.Users) != 1 || list.Users[0].Login != "alice" {
+		t.Fatalf("unexpected users: %+v", list.Users)
+	}
+
+	if err := api.Disable(ctx, 21); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	if err := api.Enable(ctx, 21); err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	if err := api.Logout(ctx, 21); err != nil {
+		t.Fatalf("Logout: %v", err)
+	}
+	if len(actions) != 3 {
+		t.Fatalf("unexpected actions: %v", actions)
+	}
+
+	reset, err := api.ResetSecondFactor(ctx, 21)
+	if err != nil {
+		t.Fatalf("ResetSecondFactor: %v", err)
+	}
+	if reset.RemovedDevices != 2 {
+		t.Fatalf("unexpected reset: %+v", reset)
+	}
+
+	stats, err := api.Stats(ctx, 21)
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.Entries != 3 || stats.EntriesByType["text"] != 3 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	if err := api.Disable(ctx, 22); err == nil {
+		t.Fatalf("expected error for unknown user")
+	}
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/url"
+)
+
+// AuditAPI provides HTTP methods for reading the audit log of the user.
+type AuditAPI struct {
+	c *client_http.Client
+}
+
+// NewAuditAPI creates a new AuditAPI instance using the provided HTTP client.
+func NewAuditAPI(client *client_http.Client) *AuditAPI {
+	return &AuditAPI{
+		c: client,
+	}
+}
+
+// List returns the audit events of the current user matching query, newest first.
+func (a *AuditAPI) List(ctx context.Context, query url.Values) (*dto.AuditEventsResponse, error) {
+	path := "/api/audit"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var resp dto.AuditEventsResponse
+	if err := a.c.Do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	clientpkg "github.com/thxhix/passKeeper/internal/transport/client_http"
+	dto "github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestAuditAPI_List(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/audit" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("action") != "login" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "unknown audit action"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(dto.AuditEventsResponse{
+			Events: []*dto.AuditEventRecord{{ID: 1, Actor: "user", Action: "login", Result: "success"}},
+		})
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	api := NewAuditAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	resp, err := api.List(ctx, url.Values{"action": {"login"}})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(resp.Events) != 1 || resp.Events[0].Action != "login" {
+		t.Fatalf("unexpected events: %+v", resp.Events)
+	}
+
+	if _, err := api.List(ctx, url.Values{"action": {"nope"}}); err == nil {
+		t.Fatalf("expected error for bad action")
+	}
+}
@@ -7,8 +7,17 @@ import (
 	"github.com/thxhix/passKeeper/internal/transport/client_http"
 	"github.com/thxhix/passKeeper/internal/transport/http/dto"
 	"net/http"
+	"strings"
 )
 
+// ErrDeviceApprovalRequired is returned by Login when the server requires the
+// device to be approved before issuing tokens.
+var ErrDeviceApprovalRequired = errors.New("device approval required")
+
+// ErrVaultKeyWillBeLost is returned by ResetPassword when the reset would
+// discard a zero-knowledge vault key and was not confirmed.
+var ErrVaultKeyWillBeLost = errors.New("vault key will be lost")
+
 // AuthAPI defines HTTP methods for authentication and token management.
 //
 // It acts as a lightweight layer over client_http.Client — it performs HTTP requests,
@@ -27,7 +36,8 @@ func NewAuthAPI(client *client_http.Client) *AuthAPI {
 // Register sends a registration request to the backend.
 //
 // It expects a non-nil RegisterRequest containing login and password fields.
-// On success, it returns a TokenResponse with issued access and refresh tokens.
+// On success, it returns a TokenResponse with issued access and refresh tokens;
+// a server in uniform response mode answers 202 and the tokens are empty.
 // If the server responds with a non-2xx code, an error of type *client_http.HTTPError
 // will be returned.
 func (a *AuthAPI) Register(ctx context.Context, req *dto.RegisterRequest) (dto.TokenResponse, error) {
@@ -35,22 +45,41 @@ func (a *AuthAPI) Register(ctx context.Context, req *dto.RegisterRequest) (dto.T
 	if err := a.c.Do(ctx, http.MethodPost, "/api/auth/register", req, &out); err != nil {
 		var he *client_http.HTTPError
 		if errors.As(err, &he) {
-			return dto.TokenResponse{}, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+			return dto.TokenResponse{}, fmt.Errorf("http code %d: %s%s", he.StatusCode, he.Body, formatViolations(he.Violations))
 		}
 		return dto.TokenResponse{}, err
 	}
 	return out, nil
 }
 
+// DeviceChallenge asks the server for the nonce the device deviceID signs
+// on registration or login.
+func (a *AuthAPI) DeviceChallenge(ctx context.Context, deviceID string) (dto.DeviceChallengeResponse, error) {
+	var out dto.DeviceChallengeResponse
+	req := &dto.DeviceChallengeRequest{DeviceID: deviceID}
+	if err := a.c.Do(ctx, http.MethodPost, "/api/auth/device/challenge", req, &out); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return dto.DeviceChallengeResponse{}, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return dto.DeviceChallengeResponse{}, err
+	}
+	return out, nil
+}
+
 // Login performs a login request using the provided credentials.
 //
 // On success, it returns a TokenResponse with access and refresh tokens.
+// If the device is not trusted yet, the error wraps ErrDeviceApprovalRequired.
 // On error, it returns either a *client_http.HTTPError or another wrapped error
 // if the HTTP call failed before receiving a response.
 func (a *AuthAPI) Login(ctx context.Context, req *dto.LoginRequest) (dto.TokenResponse, error) {
 	var out dto.TokenResponse
 	if err := a.c.Do(ctx, http.MethodPost, "/api/auth/login", req, &out); err != nil {
 		var he *client_http.HTTPError
+		if errors.As(err, &he) && he.StatusCode == http.StatusForbidden {
+			return dto.TokenResponse{}, fmt.Errorf("%w: %s", ErrDeviceApprovalRequired, he.Body)
+		}
 		if errors.As(err, &he) {
 			return dto.TokenResponse{}, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
 		}
@@ -75,3 +104,86 @@ func (a *AuthAPI) RefreshToken(ctx context.Context, req *dto.RefreshRequest) (dt
 	}
 	return out, nil
 }
+
+// ChangePassword replaces the password of the currently authenticated user.
+//
+// The request must carry a valid access token. On success the server revokes
+// all other sessions and returns a new pair of tokens.
+func (a *AuthAPI) ChangePassword(ctx context.Context, req *dto.ChangePasswordRequest) (dto.RefreshedTokenResponse, error) {
+	var out dto.RefreshedTokenResponse
+	if err := a.c.Do(ctx, http.MethodPost, "/api/auth/password", req, &out); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return dto.RefreshedTokenResponse{}, fmt.Errorf("http code %d: %s%s", he.StatusCode, he.Body, formatViolations(he.Violations))
+		}
+		return dto.RefreshedTokenResponse{}, err
+	}
+	return out, nil
+}
+
+// Logout terminates every session of the currently authenticated user on the
+// server, revoking both refresh and access tokens.
+func (a *AuthAPI) Logout(ctx context.Context) error {
+	if err := a.c.Do(ctx, http.MethodPost, "/api/auth/logout", nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// formatViolations lists the failed validation rules one per line, so the CLI
+// can tell exactly what to fix.
+func formatViolations(violations []*dto.ErrorViolation) string {
+	var b strings.Builder
+	for _, v := range violations {
+		fmt.Fprintf(&b, "\n  - %s (%s)", v.Message, v.Rule)
+	}
+	return b.String()
+}
+
+// VerifyEmail confirms the email address a verification token was mailed to.
+func (a *AuthAPI) VerifyEmail(ctx context.Context, req *dto.VerifyEmailRequest) error {
+	if err := a.c.Do(ctx, http.MethodPost, "/api/auth/email/verify", req, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// ForgotPassword asks the server to mail a password reset token. The server
+// answers the same way whether or not the address is registered.
+func (a *AuthAPI) ForgotPassword(ctx context.Context, req *dto.ForgotPasswordRequest) error {
+	if err := a.c.Do(ctx, http.MethodPost, "/api/auth/password/forgot", req, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// ResetPassword sets a new password with a mailed reset token.
+//
+// If the reset would discard a zero-knowledge vault key and
+// req.DiscardVaultKey is not set, the error wraps ErrVaultKeyWillBeLost.
+func (a *AuthAPI) ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) (dto.ResetPasswordResponse, error) {
+	var out dto.ResetPasswordResponse
+	if err := a.c.Do(ctx, http.MethodPost, "/api/auth/password/reset", req, &out); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) && he.StatusCode == http.StatusConflict {
+			return dto.ResetPasswordResponse{}, fmt.Errorf("%w: %s", ErrVaultKeyWillBeLost, he.Body)
+		}
+		if errors.As(err, &he) {
+			return dto.ResetPasswordResponse{}, fmt.Errorf("http code %d: %s%s", he.StatusCode, he.Body, formatViolations(he.Violations))
+		}
+		return dto.ResetPasswordResponse{}, err
+	}
+	return out, nil
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -26,6 +27,11 @@ func TestAuthAPI_Success(t *testing.T) {
 		case "/api/auth/refresh":
 			w.Header().Set("Content-Type", "application/json")
 			_ = json.NewEncoder(w).Encode(dto.RefreshedTokenResponse{AccessToken: "AR", RefreshToken: "RR"})
+		case "/api/auth/password":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(dto.RefreshedTokenResponse{AccessToken: "AP", RefreshToken: "RP"})
+		case "/api/auth/logout":
+			w.WriteHeader(http.StatusNoContent)
 		default:
 			http.NotFound(w, r)
 		}
@@ -59,6 +65,20 @@ func TestAuthAPI_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("RefreshToken expected nil err, got: %v", err)
 	}
+
+	// ChangePassword
+	out, err := api.ChangePassword(ctx, &dto.ChangePasswordRequest{CurrentPassword: "p", NewPassword: "np"})
+	if err != nil {
+		t.Fatalf("ChangePassword expected nil err, got: %v", err)
+	}
+	if out.AccessToken != "AP" || out.RefreshToken != "RP" {
+		t.Fatalf("ChangePassword unexpected tokens: %+v", out)
+	}
+
+	// Logout
+	if err := api.Logout(ctx); err != nil {
+		t.Fatalf("Logout expected nil err, got: %v", err)
+	}
 }
 
 // TestAuthAPI_ErrorPaths проверяет поведение при ошибке с JSON-описанием и при plain-text ошибке.
@@ -102,3 +122,98 @@ func TestAuthAPI_ErrorPaths(t *testing.T) {
 		t.Fatalf("Login expected error, got nil")
 	}
 }
+
+func TestAuthAPI_Login_DeviceApprovalRequired(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "new device must be approved"})
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	api := NewAuthAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err = api.Login(ctx, &dto.LoginRequest{Login: "x", Password: "p"})
+	if !errors.Is(err, ErrDeviceApprovalRequired) {
+		t.Fatalf("Login expected ErrDeviceApprovalRequired, got: %v", err)
+	}
+}
+
+func TestAuthAPI_Register_PasswordPolicyViolations(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(dto.ErrorResponse{
+			Code:      http.StatusBadRequest,
+			ErrorText: "password does not meet the password policy",
+			Violations: []*dto.ErrorViolation{
+				{Rule: "min_length", Message: "must be at least 8 characters long"},
+				{Rule: "contains_login", Message: "must not contain the login"},
+			},
+		})
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	api := NewAuthAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err = api.Register(ctx, &dto.RegisterRequest{Login: "alice", Password: "alice1"})
+	if err == nil {
+		t.Fatalf("Register expected error, got nil")
+	}
+
+	want := "http code 400: password does not meet the password policy\n" +
+		"  - must be at least 8 characters long (min_length)\n" +
+		"  - must not contain the login (contains_login)"
+	if err.Error() != want {
+		t.Fatalf("unexpected error:\n%s", err)
+	}
+}
+
+func TestAuthAPI_ResetPassword_VaultKeyWillBeLost(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req dto.ResetPasswordRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		if !req.DiscardVaultKey {
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "vault key will be lost"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(dto.ResetPasswordResponse{VaultKeyDiscarded: true})
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	api := NewAuthAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err = api.ResetPassword(ctx, &dto.ResetPasswordRequest{Token: "t", NewPassword: "n"})
+	if !errors.Is(err, ErrVaultKeyWillBeLost) {
+		t.Fatalf("ResetPassword expected ErrVaultKeyWillBeLost, got: %v", err)
+	}
+
+	out, err := api.ResetPassword(ctx, &dto.ResetPasswordRequest{Token: "t", NewPassword: "n", DiscardVaultKey: true})
+	if err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	if !out.VaultKeyDiscarded {
+		t.Fatalf("expected VaultKeyDiscarded")
+	}
+}
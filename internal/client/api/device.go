@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/url"
+)
+
+// DevicesAPI provides HTTP methods for managing the devices of the user.
+type DevicesAPI struct {
+	c *client_http.Client
+}
+
+// NewDevicesAPI creates a new DevicesAPI instance using the provided HTTP client.
+func NewDevicesAPI(client *client_http.Client) *DevicesAPI {
+	return &DevicesAPI{
+		c: client,
+	}
+}
+
+// List returns the trusted and pending devices of the current user.
+func (a *DevicesAPI) List(ctx context.Context) (*dto.DevicesResponse, error) {
+	var resp dto.DevicesResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/devices", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Approve trusts the pending device with the given ID.
+func (a *DevicesAPI) Approve(ctx context.Context, deviceID string) error {
+	if err := a.c.Do(ctx, http.MethodPost, "/api/devices/"+url.PathEscape(deviceID)+"/approve", nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// Remove deletes the device with the given ID and signs it out.
+func (a *DevicesAPI) Remove(ctx context.Context, deviceID string) error {
+	if err := a.c.Do(ctx, http.MethodDelete, "/api/devices/"+url.PathEscape(deviceID), nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
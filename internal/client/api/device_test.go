@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	clientpkg "github.com/thxhix/passKeeper/internal/transport/client_http"
+	dto "github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestDevicesAPI_ListApproveRemove(t *testing.T) {
+	deviceID := uuid.New()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/devices":
+			_ = json.NewEncoder(w).Encode(dto.DevicesResponse{
+				Devices: []*dto.DeviceRecord{{ID: deviceID, Name: "laptop", Trusted: false}},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/devices/"+deviceID.String()+"/approve":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/devices/"+deviceID.String():
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "resource not found"})
+		}
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	api := NewDevicesAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	list, err := api.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list.Devices) != 1 || list.Devices[0].ID != deviceID {
+		t.Fatalf("unexpected devices: %+v", list.Devices)
+	}
+
+	if err := api.Approve(ctx, deviceID.String()); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if err := api.Remove(ctx, deviceID.String()); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := api.Remove(ctx, uuid.NewString()); err == nil {
+		t.Fatalf("Remove of unknown device expected error")
+	}
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/url"
+)
+
+// EmergencyAPI provides HTTP methods for emergency access: naming trusted
+// contacts, and requesting and using access as one.
+type EmergencyAPI struct {
+	c *client_http.Client
+}
+
+// NewEmergencyAPI creates a new EmergencyAPI instance using the provided HTTP client.
+func NewEmergencyAPI(client *client_http.Client) *EmergencyAPI {
+	return &EmergencyAPI{
+		c: client,
+	}
+}
+
+// Nominate names a user as emergency contact of the current user.
+func (a *EmergencyAPI) Nominate(ctx context.Context, req *dto.NominateEmergencyContactRequest) (*dto.EmergencyContactRecord, error) {
+	var resp dto.EmergencyContactRecord
+	if err := a.c.Do(ctx, http.MethodPost, "/api/emergency/contacts", req, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Contacts returns the emergency contacts named by the current user.
+func (a *EmergencyAPI) Contacts(ctx context.Context) (*dto.EmergencyContactsResponse, error) {
+	var resp dto.EmergencyContactsResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/emergency/contacts", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Approve grants the pending request of the contact with the given UUID.
+func (a *EmergencyAPI) Approve(ctx context.Context, contactUUID string) (*dto.EmergencyContactRecord, error) {
+	var resp dto.EmergencyContactRecord
+	if err := a.c.Do(ctx, http.MethodPost, "/api/emergency/contacts/"+url.PathEscape(contactUUID)+"/approve", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Reject rejects the request of the contact with the given UUID or withdraws
+// its granted access.
+func (a *EmergencyAPI) Reject(ctx context.Context, contactUUID string) (*dto.EmergencyContactRecord, error) {
+	var resp dto.EmergencyContactRecord
+	if err := a.c.Do(ctx, http.MethodPost, "/api/emergency/contacts/"+url.PathEscape(contactUUID)+"/reject", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RemoveContact removes the emergency contact with the given UUID.
+func (a *EmergencyAPI) RemoveContact(ctx context.Context, contactUUID string) error {
+	if err := a.c.Do(ctx, http.MethodDelete, "/api/emergency/contacts/"+url.PathEscape(contactUUID), nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// Grantors returns the contacts the current user was named as.
+func (a *EmergencyAPI) Grantors(ctx context.Context) (*dto.EmergencyContactsResponse, error) {
+	var resp dto.EmergencyContactsResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/emergency/grantors", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Request requests access through the contact with the given UUID.
+func (a *EmergencyAPI) Request(ctx context.Context, contactUUID string) (*dto.EmergencyContactRecord, error) {
+	var resp dto.EmergencyContactRecord
+	if err := a.c.Do(ctx, http.MethodPost, "/api/emergency/grantors/"+url.PathEscape(contactUUID)+"/request", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Keys returns the entries of the grantor of the contact with the given UUID,
+// optionally only those of keyType.
+func (a *EmergencyAPI) Keys(ctx context.Context, contactUUID string, keyType string) (*dto.GetKeysResponse, error) {
+	path := "/api/emergency/grantors/" + url.PathEscape(contactUUID) + "/keychain"
+	if keyType != "" {
+		path += "?" + url.Values{"type": {keyType}}.Encode()
+	}
+
+	var resp dto.GetKeysResponse
+	if err := a.c.Do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Key returns an entry of the grantor of the contact with the given UUID.
+func (a *EmergencyAPI) Key(ctx context.Context, contactUUID string, keyUUID string) (*dto.GetKeyResponse, error) {
+	var resp dto.GetKeyResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/emergency/grantors/"+url.PathEscape(contactUUID)+"/keychain/"+url.PathEscape(keyUUID), nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Takeover sets a new password for the account of the grantor of the contact
+// with the given UUID. If the account has a vault key and
+// req.DiscardVaultKey is not set, the error wraps ErrVaultKeyWillBeLost.
+func (a *EmergencyAPI) Takeover(ctx context.Context, contactUUID string, req *dto.EmergencyTakeoverRequest) (*dto.ResetPasswordResponse, error) {
+	var resp dto.ResetPasswordResponse
+	if err := a.c.Do(ctx, http.MethodPost, "/api/emergency/grantors/"+url.PathEscape(contactUUID)+"/takeover", req, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) && he.StatusCode == http.StatusConflict {
+			return nil, fmt.Errorf("%w: %s", ErrVaultKeyWillBeLost, he.Body)
+		}
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// StepDown removes the current user as the contact with the given UUID.
+func (a *EmergencyAPI) StepDown(ctx context.Context, contactUUID string) error {
+	if err := a.c.Do(ctx, http.MethodDelete, "/api/emergency/grantors/"+url.PathEscape(contactUUID), nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
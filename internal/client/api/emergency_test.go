@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/emergency"
+	clientpkg "github.com/thxhix/passKeeper/internal/transport/client_http"
+	dto "github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestEmergencyAPI(t *testing.T) {
+	contactUUID := uuid.New()
+	keyUUID := uuid.New()
+	contactPath := "/api/emergency/contacts/" + contactUUID.String()
+	grantorPath := "/api/emergency/grantors/" + contactUUID.String()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/emergency/contacts":
+			var in dto.NominateEmergencyContactRequest
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			if in.Access != "view" {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "access must be view or takeover"})
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(dto.EmergencyContactRecord{ContactUUID: contactUUID, Grantee: in.Login, Access: emergency.AccessView, WaitSeconds: in.WaitSeconds})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/emergency/contacts":
+			_ = json.NewEncoder(w).Encode(dto.EmergencyContactsResponse{Contacts: []*dto.EmergencyContactRecord{{ContactUUID: contactUUID, Grantee: "bob"}}})
+		case r.Method == http.MethodPost && r.URL.Path == contactPath+"/reject":
+			_ = json.NewEncoder(w).Encode(dto.EmergencyContactRecord{ContactUUID: contactUUID, Status: emergency.StatusRejected})
+		case r.Method == http.MethodDelete && r.URL.Path == contactPath:
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == grantorPath+"/request":
+			_ = json.NewEncoder(w).Encode(dto.EmergencyContactRecord{ContactUUID: contactUUID, Grantor: "alice", Status: emergency.StatusRequested})
+		case r.Method == http.MethodGet && r.URL.Path == grantorPath+"/keychain":
+			if r.URL.Query().Get("type") != "text" {
+				w.WriteHeader(http.StatusForbidden)
+				_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "emergency access has not been granted yet"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(dto.GetKeysResponse{Keys: []*dto.GetKeysRecord{{KeyUUID: keyUUID, Title: "note"}}})
+		case r.Method == http.MethodGet && r.URL.Path == grantorPath+"/keychain/"+keyUUID.String():
+			_ = json.NewEncoder(w).Encode(dto.GetKeyResponse{KeyUUID: keyUUID, Title: "note"})
+		case r.Method == http.MethodPost && r.URL.Path == grantorPath+"/takeover":
+			var in dto.EmergencyTakeoverRequest
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			if !in.DiscardVaultKey {
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "vault key will be lost"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(dto.ResetPasswordResponse{VaultKeyDiscarded: true})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "emergency contact not found"})
+		}
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	client.SetAccessToken("session")
+	api := NewEmergencyAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	rec, err := api.Nominate(ctx, &dto.NominateEmergencyContactRequest{Login: "bob", Access: "view", WaitSeconds: 3600})
+	if err != nil || rec.Grantee != "bob" || rec.WaitSeconds != 3600 {
+		t.Fatalf("Nominate unexpected result: %+v, %v", rec, err)
+	}
+	if _, err := api.Nominate(ctx, &dto.NominateEmergencyContactRequest{Login: "bob", Access: "owner"}); err == nil || !strings.Contains(err.Error(), "http code 400") {
+		t.Fatalf("Nominate expected http 400 error, got: %v", err)
+	}
+
+	contacts, err := api.Contacts(ctx)
+	if err != nil || len(contacts.Contacts) != 1 {
+		t.Fatalf("Contacts unexpected result: %+v, %v", contacts, err)
+	}
+
+	rejected, err := api.Reject(ctx, contactUUID.String())
+	if err != nil || rejected.Status != emergency.StatusRejected {
+		t.Fatalf("Reject unexpected result: %+v, %v", rejected, err)
+	}
+	if _, err := api.Approve(ctx, uuid.NewString()); err == nil || !strings.Contains(err.Error(), "http code 404") {
+		t.Fatalf("Approve expected http 404 error, got: %v", err)
+	}
+
+	requested, err := api.Request(ctx, contactUUID.String())
+	if err != nil || requested.Status != emergency.StatusRequested {
+		t.Fatalf("Request unexpected result: %+v, %v", requested, err)
+	}
+
+	if _, err := api.Keys(ctx, contactUUID.String(), ""); err == nil || !strings.Contains(err.Error(), "http code 403") {
+		t.Fatalf("Keys expected http 403 error, got: %v", err)
+	}
+	keys, err := api.Keys(ctx, contactUUID.String(), "text")
+	if err != nil || len(keys.Keys) != 1 {
+		t.Fatalf("Keys unexpected result: %+v, %v", keys, err)
+	}
+
+	key, err := api.Key(ctx, contactUUID.String(), keyUUID.String())
+	if err != nil || key.Title != "note" {
+		t.Fatalf("Key unexpected result: %+v, %v", key, err)
+	}
+
+	if _, err := api.Takeover(ctx, contactUUID.String(), &dto.EmergencyTakeoverRequest{NewPassword: "pw"}); !errors.Is(err, ErrVaultKeyWillBeLost) {
+		t.Fatalf("Takeover expected ErrVaultKeyWillBeLost, got: %v", err)
+	}
+	taken, err := api.Takeover(ctx, contactUUID.String(), &dto.EmergencyTakeoverRequest{NewPassword: "pw", DiscardVaultKey: true})
+	if err != nil || !taken.VaultKeyDiscarded {
+		t.Fatalf("Takeover unexpected result: %+v, %v", taken, err)
+	}
+
+	if err := api.RemoveContact(ctx, contactUUID.String()); err != nil {
+		t.Fatalf("RemoveContact expected nil err, got: %v", err)
+	}
+	if err := api.StepDown(ctx, contactUUID.String()); err == nil || !strings.Contains(err.Error(), "http code 404") {
+		t.Fatalf("StepDown expected http 404 error, got: %v", err)
+	}
+}
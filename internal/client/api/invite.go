@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/url"
+)
+
+// InvitesAPI provides HTTP methods for managing invite codes.
+type InvitesAPI struct {
+	c *client_http.Client
+}
+
+// NewInvitesAPI creates a new InvitesAPI instance using the provided HTTP client.
+func NewInvitesAPI(client *client_http.Client) *InvitesAPI {
+	return &InvitesAPI{
+		c: client,
+	}
+}
+
+// Create issues a new invite code. The plain code is only present in this
+// response.
+func (a *InvitesAPI) Create(ctx context.Context, req *dto.CreateInviteRequest) (*dto.CreateInviteResponse, error) {
+	var resp dto.CreateInviteResponse
+	if err := a.c.Do(ctx, http.MethodPost, "/api/invites", req, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List returns the invites created by the current user.
+func (a *InvitesAPI) List(ctx context.Context) (*dto.InvitesResponse, error) {
+	var resp dto.InvitesResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/invites", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Revoke deletes the invite with the given UUID.
+func (a *InvitesAPI) Revoke(ctx context.Context, inviteUUID string) error {
+	if err := a.c.Do(ctx, http.MethodDelete, "/api/invites/"+url.PathEscape(inviteUUID), nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
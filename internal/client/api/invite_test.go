@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	clientpkg "github.com/thxhix/passKeeper/internal/transport/client_http"
+	dto "github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestInvitesAPI_CreateListRevoke(t *testing.T) {
+	inviteUUID := uuid.New()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/invites":
+			var in dto.CreateInviteRequest
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			if in.MaxUses < 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "max uses must be between 1 and 100"})
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(dto.CreateInviteResponse{
+				InviteRecord: dto.InviteRecord{InviteUUID: inviteUUID, Login: in.Login, MaxUses: in.MaxUses},
+				Code:         "pki_secret",
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/invites":
+			_ = json.NewEncoder(w).Encode(dto.InvitesResponse{
+				Invites: []*dto.InviteRecord{{InviteUUID: inviteUUID, MaxUses: 1}},
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/invites/"+inviteUUID.String():
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "resource not found"})
+		}
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	client.SetAccessToken("session")
+	api := NewInvitesAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	created, err := api.Create(ctx, &dto.CreateInviteRequest{Login: "alice", MaxUses: 1})
+	if err != nil {
+		t.Fatalf("Create expected nil err, got: %v", err)
+	}
+	if created.Code != "pki_secret" || created.InviteUUID != inviteUUID || created.Login != "alice" {
+		t.Fatalf("Create unexpected response: %+v", created)
+	}
+
+	if _, err := api.Create(ctx, &dto.CreateInviteRequest{}); err == nil || !strings.Contains(err.Error(), "http code 400") {
+		t.Fatalf("Create expected http 400 error, got: %v", err)
+	}
+
+	list, err := api.List(ctx)
+	if err != nil || len(list.Invites) != 1 {
+		t.Fatalf("List unexpected result: %+v, %v", list, err)
+	}
+
+	if err := api.Revoke(ctx, inviteUUID.String()); err != nil {
+		t.Fatalf("Revoke expected nil err, got: %v", err)
+	}
+	if err := api.Revoke(ctx, uuid.NewString()); err == nil {
+		t.Fatalf("Revoke expected error for unknown invite, got nil")
+	}
+}
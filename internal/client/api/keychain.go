@@ -1,7 +1,10 @@
 package api
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/thxhix/passKeeper/internal/transport/client_http"
@@ -9,8 +12,10 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 )
 
 // KeychainAPI provides low-level methods to operate on the user's keychain
@@ -73,7 +78,22 @@ func (a *KeychainAPI) AddText(ctx context.Context, req *dto.AddTextDTO) (dto.Add
 	return out, nil
 }
 
-// AddFile uploads a file to the server together with optional metadata (title/note).
+// Batch sends several add, update and delete operations at once. The
+// response holds the outcome of every operation; a batch whose operations
+// failed is not an error by itself.
+func (a *KeychainAPI) Batch(ctx context.Context, req *dto.BatchRequest) (dto.BatchResponse, error) {
+	var out dto.BatchResponse
+	if err := a.c.Do(ctx, http.MethodPost, "/api/keychain/batch", req, &out); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return dto.BatchResponse{}, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return dto.BatchResponse{}, err
+	}
+	return out, nil
+}
+
+// AddFile uploads a file to the server together with optional metadata (title/note/tags).
 //
 // The file is streamed using a pipe + multipart.Writer to avoid buffering the
 // entire file in memory. filePath must point to a readable file.
@@ -105,6 +125,12 @@ func (a *KeychainAPI) AddFile(ctx context.Context, req *dto.AddFileDTO, filePath
 			return
 		}
 
+		if req.Vault != "" {
+			if err := mw.WriteField("vault", req.Vault); err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+		}
 		if req.Title != "" {
 			if err := mw.WriteField("title", req.Title); err != nil {
 				_ = pw.CloseWithError(err)
@@ -117,6 +143,12 @@ func (a *KeychainAPI) AddFile(ctx context.Context, req *dto.AddFileDTO, filePath
 				return
 			}
 		}
+		for _, tag := range req.Tags {
+			if err := mw.WriteField("tags", tag); err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+		}
 	}()
 
 	contentType := mw.FormDataContentType()
@@ -133,16 +165,25 @@ func (a *KeychainAPI) AddFile(ctx context.Context, req *dto.AddFileDTO, filePath
 }
 
 // GetKeysList fetches listing of keys. If keyType is non-empty it is used as a
-// query parameter (server-side filtering).
-func (a *KeychainAPI) GetKeysList(ctx context.Context, keyType string) (dto.GetKeysResponse, error) {
+// query parameter (server-side filtering). With vaultUUID set the entries of
+// that shared vault are listed instead of the personal ones.
+func (a *KeychainAPI) GetKeysList(ctx context.Context, keyType string, vaultUUID string) (dto.GetKeysResponse, error) {
 	var out dto.GetKeysResponse
 
-	url := "/api/keychain"
+	query := url.Values{}
 	if keyType != "" {
-		url = fmt.Sprintf("/api/keychain/?type=%s", keyType)
+		query.Set("type", keyType)
+	}
+	if vaultUUID != "" {
+		query.Set("vault", vaultUUID)
 	}
 
-	if err := a.c.Do(ctx, http.MethodGet, url, nil, &out); err != nil {
+	path := "/api/keychain"
+	if len(query) > 0 {
+		path = "/api/keychain/?" + query.Encode()
+	}
+
+	if err := a.c.Do(ctx, http.MethodGet, path, nil, &out); err != nil {
 		var he *client_http.HTTPError
 		if errors.As(err, &he) {
 			return dto.GetKeysResponse{}, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
@@ -152,13 +193,85 @@ func (a *KeychainAPI) GetKeysList(ctx context.Context, keyType string) (dto.GetK
 	return out, nil
 }
 
-// GetKey fetches a single key by uuid.
-func (a *KeychainAPI) GetKey(ctx context.Context, keyUUID string) (dto.GetKeyResponse, error) {
-	var out dto.GetKeyResponse
+// GetChanges fetches the changes of the personal keys after the revision
+// since. With withData the live keys carry their data. While the response
+// has More set, the caller asks again from its Revision.
+func (a *KeychainAPI) GetChanges(ctx context.Context, since int64, withData bool) (dto.ChangesResponse, error) {
+	var out dto.ChangesResponse
+
+	query := url.Values{}
+	query.Set("since", strconv.FormatInt(since, 10))
+	if withData {
+		query.Set("data", "true")
+	}
+
+	if err := a.c.Do(ctx, http.MethodGet, "/api/keychain/changes?"+query.Encode(), nil, &out); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return dto.ChangesResponse{}, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return dto.ChangesResponse{}, err
+	}
+	return out, nil
+}
+
+// maxEventSize is the largest server-sent event line WatchEvents accepts.
+const maxEventSize = 1 << 20
+
+// WatchEvents follows the event stream of the personal keys and calls handle
+// with every event, until the server ends the stream, which returns nil, or
+// ctx is done. A stream cut off while reading is reported like a failed
+// request, wrapping client_http.ErrClientRequestFailed.
+func (a *KeychainAPI) WatchEvents(ctx context.Context, handle func(dto.KeyEvent)) error {
+	body, err := a.c.OpenStream(ctx, "/api/keychain/events", "text/event-stream")
+	if err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	defer body.Close()
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 4096), maxEventSize)
+
+	var data []byte
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		switch {
+		case len(line) == 0:
+			// a blank line ends an event; comments and empty events carry no data
+			if len(data) > 0 {
+				var event dto.KeyEvent
+				if err := json.Unmarshal(data, &event); err == nil {
+					handle(event)
+				}
+			}
+			data = data[:0]
+		case bytes.HasPrefix(line, []byte("data:")):
+			if len(data) > 0 {
+				data = append(data, '\n')
+			}
+			data = append(data, bytes.TrimPrefix(bytes.TrimPrefix(line, []byte("data:")), []byte(" "))...)
+		}
+	}
 
-	url := fmt.Sprintf("/api/keychain/%s", keyUUID)
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("%w: %v", client_http.ErrClientRequestFailed, err)
+	}
+	return ctx.Err()
+}
 
-	if err := a.c.Do(ctx, http.MethodGet, url, nil, &out); err != nil {
+// GetKey fetches a single key by uuid, from the shared vault vaultUUID when
+// it is set.
+func (a *KeychainAPI) GetKey(ctx context.Context, keyUUID string, vaultUUID string) (dto.GetKeyResponse, error) {
+	var out dto.GetKeyResponse
+
+	if err := a.c.Do(ctx, http.MethodGet, keyPath(keyUUID, vaultUUID), nil, &out); err != nil {
 		var he *client_http.HTTPError
 		if errors.As(err, &he) {
 			return dto.GetKeyResponse{}, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
@@ -168,11 +281,10 @@ func (a *KeychainAPI) GetKey(ctx context.Context, keyUUID string) (dto.GetKeyRes
 	return out, nil
 }
 
-// DeleteKey deletes a record by uuid.
-func (a *KeychainAPI) DeleteKey(ctx context.Context, keyUUID string) error {
-	url := fmt.Sprintf("/api/keychain/%s", keyUUID)
-
-	if err := a.c.Do(ctx, http.MethodDelete, url, nil, nil); err != nil {
+// DeleteKey deletes a record by uuid, from the shared vault vaultUUID when it
+// is set.
+func (a *KeychainAPI) DeleteKey(ctx context.Context, keyUUID string, vaultUUID string) error {
+	if err := a.c.Do(ctx, http.MethodDelete, keyPath(keyUUID, vaultUUID), nil, nil); err != nil {
 		var he *client_http.HTTPError
 		if errors.As(err, &he) {
 			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
@@ -181,3 +293,13 @@ func (a *KeychainAPI) DeleteKey(ctx context.Context, keyUUID string) error {
 	}
 	return nil
 }
+
+// keyPath returns the path of the entry keyUUID, scoped to the shared vault
+// vaultUUID when it is set.
+func keyPath(keyUUID string, vaultUUID string) string {
+	path := "/api/keychain/" + url.PathEscape(keyUUID)
+	if vaultUUID != "" {
+		path += "?vault=" + url.QueryEscape(vaultUUID)
+	}
+	return path
+}
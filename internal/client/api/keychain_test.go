@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"github.com/google/uuid"
 	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -164,19 +166,166 @@ func TestKeychainAPI_ListGetDelete(t *testing.T) {
 	defer cancel()
 
 	// list
-	_, err := api.GetKeysList(ctx, "")
+	_, err := api.GetKeysList(ctx, "", "")
 	if err != nil {
 		t.Fatalf("GetKeysList failed: %v", err)
 	}
 
 	// get key
-	_, err = api.GetKey(ctx, uuidTest.String())
+	_, err = api.GetKey(ctx, uuidTest.String(), "")
 	if err != nil {
 		t.Fatalf("GetKey failed: %v", err)
 	}
 
 	// delete missing -> expect error
-	if err := api.DeleteKey(ctx, "22222222-2222-2222-2222-222222222222"); err == nil {
+	if err := api.DeleteKey(ctx, "22222222-2222-2222-2222-222222222222", ""); err == nil {
 		t.Fatalf("DeleteKey expected error for not found id")
 	}
 }
+
+func TestKeychainAPI_VaultQuery(t *testing.T) {
+	vaultUUID := uuid.NewString()
+	keyUUID := uuid.New()
+
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("vault"))
+		switch r.Method {
+		case http.MethodGet:
+			if strings.HasSuffix(r.URL.Path, keyUUID.String()) {
+				_ = json.NewEncoder(w).Encode(dto.GetKeyResponse{KeyUUID: keyUUID})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(dto.GetKeysResponse{})
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts.URL)
+	api := NewKeychainAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if _, err := api.GetKeysList(ctx, "text", vaultUUID); err != nil {
+		t.Fatalf("GetKeysList failed: %v", err)
+	}
+	if _, err := api.GetKey(ctx, keyUUID.String(), vaultUUID); err != nil {
+		t.Fatalf("GetKey failed: %v", err)
+	}
+	if err := api.DeleteKey(ctx, keyUUID.String(), vaultUUID); err != nil {
+		t.Fatalf("DeleteKey failed: %v", err)
+	}
+
+	for i, q := range queries {
+		if q != vaultUUID {
+			t.Fatalf("request %d: expected vault query %q, got %q", i, vaultUUID, q)
+		}
+	}
+}
+
+func TestKeychainAPI_GetChanges(t *testing.T) {
+	keyUUID := uuid.New()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/keychain/changes" || r.URL.Query().Get("since") != "7" || r.URL.Query().Get("data") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "bad query"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(dto.ChangesResponse{
+			Revision: 9,
+			Changes:  []*dto.KeyChange{{KeyUUID: keyUUID, Revision: 9, Deleted: true}},
+		})
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts.URL)
+	api := NewKeychainAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	got, err := api.GetChanges(ctx, 7, true)
+	if err != nil {
+		t.Fatalf("GetChanges failed: %v", err)
+	}
+	if got.Revision != 9 || len(got.Changes) != 1 || !got.Changes[0].Deleted || got.Changes[0].KeyUUID != keyUUID {
+		t.Fatalf("unexpected changes: %+v", got)
+	}
+
+	if _, err := api.GetChanges(ctx, 0, false); err == nil || !strings.Contains(err.Error(), "http code 400") {
+		t.Fatalf("expected http error, got %v", err)
+	}
+}
+
+func TestKeychainAPI_WatchEvents(t *testing.T) {
+	keyUUID := uuid.New()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/keychain/events" || r.Header.Get("Accept") != "text/event-stream" {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "forbidden"})
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, ": ping\n\n")
+		_, _ = io.WriteString(w, "event: add\nid: 3\ndata: {\"event\":\"add\",\"uuid\":\""+keyUUID.String()+"\",\"type\":\"text\",\"title\":\"note\",\"revision\":3}\n\n")
+		_, _ = io.WriteString(w, "event: resync\ndata: {\"event\":\"resync\"}\n\n")
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts.URL)
+	api := NewKeychainAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var got []dto.KeyEvent
+	if err := api.WatchEvents(ctx, func(event dto.KeyEvent) { got = append(got, event) }); err != nil {
+		t.Fatalf("WatchEvents failed: %v", err)
+	}
+	if len(got) != 2 || got[0].Event != "add" || *got[0].KeyUUID != keyUUID || got[0].Revision != 3 || got[1].Event != "resync" {
+		t.Fatalf("unexpected events: %+v", got)
+	}
+}
+
+func TestKeychainAPI_Batch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in dto.BatchRequest
+		if r.Method != http.MethodPost || r.URL.Path != "/api/keychain/batch" || json.NewDecoder(r.Body).Decode(&in) != nil || len(in.Operations) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "bad batch"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(dto.BatchResponse{
+			Mode:    keychain.BatchMode(in.Mode),
+			Applied: 1,
+			Results: []*dto.BatchItemResult{{Index: 0, Op: in.Operations[0].Op, UUID: "uuid-1", Status: "applied"}},
+		})
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts.URL)
+	api := NewKeychainAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	got, err := api.Batch(ctx, &dto.BatchRequest{
+		Mode:       string(keychain.BatchBestEffort),
+		Operations: []*dto.BatchOperation{{Op: keychain.BatchAdd, Text: &dto.AddTextDTO{Title: "t", Text: "x"}}},
+	})
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	if got.Mode != keychain.BatchBestEffort || got.Applied != 1 || len(got.Results) != 1 || got.Results[0].UUID != "uuid-1" {
+		t.Fatalf("unexpected batch response: %+v", got)
+	}
+
+	if _, err := api.Batch(ctx, &dto.BatchRequest{}); err == nil || !strings.Contains(err.Error(), "http code 400") {
+		t.Fatalf("expected http error, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/url"
+)
+
+// ErrNoRecovery is returned by GetRecovery when account recovery is not set
+// up for the current user.
+var ErrNoRecovery = errors.New("account recovery is not set up")
+
+// RecoveryAPI provides HTTP methods for Shamir-shared account recovery. It
+// transports opaque ciphertext only; splitting and combining happen in the
+// e2e package.
+type RecoveryAPI struct {
+	c *client_http.Client
+}
+
+// NewRecoveryAPI creates a new RecoveryAPI instance using the provided HTTP client.
+func NewRecoveryAPI(client *client_http.Client) *RecoveryAPI {
+	return &RecoveryAPI{
+		c: client,
+	}
+}
+
+// SetRecovery replaces the recovery setup of the current user.
+func (a *RecoveryAPI) SetRecovery(ctx context.Context, req *dto.SetRecoveryRequest) (*dto.RecoveryResponse, error) {
+	var resp dto.RecoveryResponse
+	if err := a.c.Do(ctx, http.MethodPut, "/api/recovery", req, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetRecovery returns the recovery setup of the current user. If there is
+// none, the error wraps ErrNoRecovery.
+func (a *RecoveryAPI) GetRecovery(ctx context.Context) (*dto.RecoveryResponse, error) {
+	var resp dto.RecoveryResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/recovery", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) && he.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %s", ErrNoRecovery, he.Body)
+		}
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteRecovery removes the recovery setup of the current user.
+func (a *RecoveryAPI) DeleteRecovery(ctx context.Context) error {
+	if err := a.c.Do(ctx, http.MethodDelete, "/api/recovery", nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// HeldShares returns the recovery shares the current user holds for others.
+func (a *RecoveryAPI) HeldShares(ctx context.Context) (*dto.RecoverySharesResponse, error) {
+	var resp dto.RecoverySharesResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/recovery/held", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetHeldShare returns the sealed recovery share with the given UUID held by
+// the current user.
+func (a *RecoveryAPI) GetHeldShare(ctx context.Context, shareUUID string) (*dto.HeldRecoveryShareResponse, error) {
+	var resp dto.HeldRecoveryShareResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/recovery/held/"+url.PathEscape(shareUUID), nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
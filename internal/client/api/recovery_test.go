@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	clientpkg "github.com/thxhix/passKeeper/internal/transport/client_http"
+	dto "github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestRecoveryAPI(t *testing.T) {
+	shareUUID := uuid.New()
+	setUp := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/recovery":
+			if !setUp {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "account recovery is not set up"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(dto.RecoveryResponse{KeyMaterial: []byte("material"), Threshold: 2, Shares: 3, Holders: []string{"bob"}})
+		case r.Method == http.MethodPut && r.URL.Path == "/api/recovery":
+			var in dto.SetRecoveryRequest
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			if in.Threshold < 2 {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "invalid threshold"})
+				return
+			}
+			setUp = true
+			_ = json.NewEncoder(w).Encode(dto.RecoveryResponse{Threshold: in.Threshold, Shares: in.Shares, Holders: []string{in.Held[0].Login}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/recovery":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/recovery/held":
+			_ = json.NewEncoder(w).Encode(dto.RecoverySharesResponse{Shares: []*dto.RecoveryShareRecord{{ShareUUID: shareUUID, Owner: "alice"}}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/recovery/held/"+shareUUID.String():
+			_ = json.NewEncoder(w).Encode(dto.HeldRecoveryShareResponse{
+				RecoveryShareRecord: dto.RecoveryShareRecord{ShareUUID: shareUUID, Owner: "alice"},
+				WrappedKey:          []byte("wrapped"),
+				Nonce:               []byte("nonce"),
+				Data:                []byte("sealed"),
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "recovery share not found"})
+		}
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	client.SetAccessToken("session")
+	api := NewRecoveryAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if _, err := api.GetRecovery(ctx); !errors.Is(err, ErrNoRecovery) {
+		t.Fatalf("GetRecovery expected ErrNoRecovery, got: %v", err)
+	}
+
+	if _, err := api.SetRecovery(ctx, &dto.SetRecoveryRequest{Threshold: 1, Shares: 3}); err == nil || !strings.Contains(err.Error(), "http code 400") {
+		t.Fatalf("SetRecovery expected http 400 error, got: %v", err)
+	}
+	setup, err := api.SetRecovery(ctx, &dto.SetRecoveryRequest{Threshold: 2, Shares: 3, Held: []*dto.RecoveryShareRequest{{Login: "bob"}}})
+	if err != nil || setup.Threshold != 2 || len(setup.Holders) != 1 {
+		t.Fatalf("SetRecovery unexpected result: %+v, %v", setup, err)
+	}
+
+	got, err := api.GetRecovery(ctx)
+	if err != nil || string(got.KeyMaterial) != "material" {
+		t.Fatalf("GetRecovery unexpected result: %+v, %v", got, err)
+	}
+
+	held, err := api.HeldShares(ctx)
+	if err != nil || len(held.Shares) != 1 || held.Shares[0].Owner != "alice" {
+		t.Fatalf("HeldShares unexpected result: %+v, %v", held, err)
+	}
+
+	share, err := api.GetHeldShare(ctx, shareUUID.String())
+	if err != nil || string(share.Data) != "sealed" {
+		t.Fatalf("GetHeldShare unexpected result: %+v, %v", share, err)
+	}
+	if _, err := api.GetHeldShare(ctx, uuid.NewString()); err == nil || !strings.Contains(err.Error(), "http code 404") {
+		t.Fatalf("GetHeldShare expected http 404 error, got: %v", err)
+	}
+
+	if err := api.DeleteRecovery(ctx); err != nil {
+		t.Fatalf("DeleteRecovery expected nil err, got: %v", err)
+	}
+}
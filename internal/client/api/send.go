@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/url"
+)
+
+// SendsAPI provides HTTP methods for one-time secret links. It transports
+// ciphertext only; the key stays in the fragment of the link.
+type SendsAPI struct {
+	c *client_http.Client
+}
+
+// NewSendsAPI creates a new SendsAPI instance using the provided HTTP client.
+func NewSendsAPI(client *client_http.Client) *SendsAPI {
+	return &SendsAPI{
+		c: client,
+	}
+}
+
+// Create stores the ciphertext of a secret link.
+func (a *SendsAPI) Create(ctx context.Context, req *dto.CreateSendRequest) (*dto.SendRecord, error) {
+	var resp dto.SendRecord
+	if err := a.c.Do(ctx, http.MethodPost, "/api/send", req, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List returns the secret links of the current user.
+func (a *SendsAPI) List(ctx context.Context) (*dto.SendsResponse, error) {
+	var resp dto.SendsResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/send", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Revoke deletes the secret link with the given UUID.
+func (a *SendsAPI) Revoke(ctx context.Context, sendUUID string) error {
+	if err := a.c.Do(ctx, http.MethodDelete, "/api/send/"+url.PathEscape(sendUUID), nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// Open takes one view of the secret link with the given UUID and returns its
+// ciphertext. The server does not require authentication for it.
+func (a *SendsAPI) Open(ctx context.Context, sendUUID string) (*dto.SendResponse, error) {
+	var resp dto.SendResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/send/"+url.PathEscape(sendUUID), nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	clientpkg "github.com/thxhix/passKeeper/internal/transport/client_http"
+	dto "github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestSendsAPI_CreateOpenRevoke(t *testing.T) {
+	sendUUID := uuid.New()
+	views := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/send":
+			var in dto.CreateSendRequest
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			if in.MaxViews != 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "max views must be between 1 and 100"})
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(dto.SendRecord{SendUUID: sendUUID, MaxViews: in.MaxViews})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/send":
+			_ = json.NewEncoder(w).Encode(dto.SendsResponse{Sends: []*dto.SendRecord{{SendUUID: sendUUID, MaxViews: 1}}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/send/"+sendUUID.String() && views == 0:
+			views++
+			_ = json.NewEncoder(w).Encode(dto.SendResponse{Nonce: []byte("nonce"), Data: []byte("sealed")})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/send/"+sendUUID.String():
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "not found"})
+		}
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	client.SetAccessToken("session")
+	api := NewSendsAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	rec, err := api.Create(ctx, &dto.CreateSendRequest{Nonce: []byte("nonce"), Data: []byte("sealed"), MaxViews: 1})
+	if err != nil || rec.SendUUID != sendUUID {
+		t.Fatalf("Create unexpected result: %+v, %v", rec, err)
+	}
+	if _, err := api.Create(ctx, &dto.CreateSendRequest{MaxViews: 0}); err == nil || !strings.Contains(err.Error(), "http code 400") {
+		t.Fatalf("Create expected http 400 error, got: %v", err)
+	}
+
+	list, err := api.List(ctx)
+	if err != nil || len(list.Sends) != 1 {
+		t.Fatalf("List unexpected result: %+v, %v", list, err)
+	}
+
+	opened, err := api.Open(ctx, sendUUID.String())
+	if err != nil || string(opened.Data) != "sealed" {
+		t.Fatalf("Open unexpected result: %+v, %v", opened, err)
+	}
+	if _, err := api.Open(ctx, sendUUID.String()); err == nil || !strings.Contains(err.Error(), "http code 404") {
+		t.Fatalf("second Open expected http 404 error, got: %v", err)
+	}
+
+	if err := api.Revoke(ctx, sendUUID.String()); err != nil {
+		t.Fatalf("Revoke expected nil err, got: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/url"
+)
+
+// ErrNoKeyPair is returned by GetKeyPair when the current user has not set up
+// a sharing key pair yet.
+var ErrNoKeyPair = errors.New("no sharing key pair")
+
+// SharesAPI provides HTTP methods for end-to-end encrypted sharing of single
+// entries. It transports opaque ciphertext only; encryption happens in the
+// e2e package.
+type SharesAPI struct {
+	c *client_http.Client
+}
+
+// NewSharesAPI creates a new SharesAPI instance using the provided HTTP client.
+func NewSharesAPI(client *client_http.Client) *SharesAPI {
+	return &SharesAPI{
+		c: client,
+	}
+}
+
+// SetKeyPair stores the public key and the wrapped private key of the current
+// user.
+func (a *SharesAPI) SetKeyPair(ctx context.Context, req *dto.SetKeyPairRequest) error {
+	if err := a.c.Do(ctx, http.MethodPut, "/api/keys", req, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetKeyPair returns the public key and the wrapped private key of the
+// current user. If there is none, the error wraps ErrNoKeyPair.
+func (a *SharesAPI) GetKeyPair(ctx context.Context) (*dto.KeyPairResponse, error) {
+	var resp dto.KeyPairResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/keys", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) && he.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %s", ErrNoKeyPair, he.Body)
+		}
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PublicKey returns the public key of the user with the given login.
+func (a *SharesAPI) PublicKey(ctx context.Context, login string) (*dto.PublicKeyResponse, error) {
+	var resp dto.PublicKeyResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/keys/"+url.PathEscape(login), nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Share stores the entry with the given UUID sealed for a recipient.
+func (a *SharesAPI) Share(ctx context.Context, keyUUID string, req *dto.ShareKeyRequest) (*dto.ShareRecord, error) {
+	var resp dto.ShareRecord
+	if err := a.c.Do(ctx, http.MethodPost, "/api/keychain/"+url.PathEscape(keyUUID)+"/share", req, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Shares returns the users the entry with the given UUID is shared with.
+func (a *SharesAPI) Shares(ctx context.Context, keyUUID string) (*dto.SharesResponse, error) {
+	var resp dto.SharesResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/keychain/"+url.PathEscape(keyUUID)+"/share", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Revoke withdraws the entry with the given UUID from the user with the given
+// login.
+func (a *SharesAPI) Revoke(ctx context.Context, keyUUID string, login string) error {
+	if err := a.c.Do(ctx, http.MethodDelete, "/api/keychain/"+url.PathEscape(keyUUID)+"/share/"+url.PathEscape(login), nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// SharedWithMe returns the entries other users shared with the current user.
+func (a *SharesAPI) SharedWithMe(ctx context.Context) (*dto.SharesResponse, error) {
+	var resp dto.SharesResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/shared", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetShared returns the sealed entry of the share with the given UUID.
+func (a *SharesAPI) GetShared(ctx context.Context, shareUUID string) (*dto.SharedKeyResponse, error) {
+	var resp dto.SharedKeyResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/shared/"+url.PathEscape(shareUUID), nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Dismiss removes the share with the given UUID from the entries shared with
+// the current user.
+func (a *SharesAPI) Dismiss(ctx context.Context, shareUUID string) error {
+	if err := a.c.Do(ctx, http.MethodDelete, "/api/shared/"+url.PathEscape(shareUUID), nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	clientpkg "github.com/thxhix/passKeeper/internal/transport/client_http"
+	dto "github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestSharesAPI_ShareAndOpen(t *testing.T) {
+	keyUUID := uuid.New()
+	shareUUID := uuid.New()
+	sharePath := "/api/keychain/" + keyUUID.String() + "/share"
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/keys":
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "no key pair"})
+		case r.Method == http.MethodPut && r.URL.Path == "/api/keys":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/keys/bob":
+			_ = json.NewEncoder(w).Encode(dto.PublicKeyResponse{Login: "bob", PublicKey: []byte("public")})
+		case r.Method == http.MethodPost && r.URL.Path == sharePath:
+			var in dto.ShareKeyRequest
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			if string(in.Data) != "sealed" {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "invalid payload"})
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(dto.ShareRecord{ShareUUID: shareUUID, KeyUUID: keyUUID, From: "alice", To: in.Login})
+		case r.Method == http.MethodGet && r.URL.Path == sharePath:
+			_ = json.NewEncoder(w).Encode(dto.SharesResponse{Shares: []*dto.ShareRecord{{ShareUUID: shareUUID, To: "bob"}}})
+		case r.Method == http.MethodDelete && r.URL.Path == sharePath+"/bob":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/shared":
+			_ = json.NewEncoder(w).Encode(dto.SharesResponse{Shares: []*dto.ShareRecord{{ShareUUID: shareUUID, From: "alice"}}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/shared/"+shareUUID.String():
+			_ = json.NewEncoder(w).Encode(dto.SharedKeyResponse{
+				ShareRecord: dto.ShareRecord{ShareUUID: shareUUID, From: "alice"},
+				WrappedKey:  []byte("wrapped"),
+				Nonce:       []byte("nonce"),
+				Data:        []byte("sealed"),
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/shared/"+shareUUID.String():
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "share not found"})
+		}
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	client.SetAccessToken("session")
+	api := NewSharesAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if _, err := api.GetKeyPair(ctx); !errors.Is(err, ErrNoKeyPair) {
+		t.Fatalf("GetKeyPair expected ErrNoKeyPair, got: %v", err)
+	}
+	if err := api.SetKeyPair(ctx, &dto.SetKeyPairRequest{PublicKey: []byte("public"), KeyMaterial: []byte("wrapped")}); err != nil {
+		t.Fatalf("SetKeyPair expected nil err, got: %v", err)
+	}
+
+	pub, err := api.PublicKey(ctx, "bob")
+	if err != nil || string(pub.PublicKey) != "public" {
+		t.Fatalf("PublicKey unexpected result: %+v, %v", pub, err)
+	}
+
+	rec, err := api.Share(ctx, keyUUID.String(), &dto.ShareKeyRequest{Login: "bob", Data: []byte("sealed")})
+	if err != nil || rec.ShareUUID != shareUUID || rec.To != "bob" {
+		t.Fatalf("Share unexpected result: %+v, %v", rec, err)
+	}
+	if _, err := api.Share(ctx, keyUUID.String(), &dto.ShareKeyRequest{Login: "bob"}); err == nil || !strings.Contains(err.Error(), "http code 400") {
+		t.Fatalf("Share expected http 400 error, got: %v", err)
+	}
+
+	shares, err := api.Shares(ctx, keyUUID.String())
+	if err != nil || len(shares.Shares) != 1 {
+		t.Fatalf("Shares unexpected result: %+v, %v", shares, err)
+	}
+	if err := api.Revoke(ctx, keyUUID.String(), "bob"); err != nil {
+		t.Fatalf("Revoke expected nil err, got: %v", err)
+	}
+
+	incoming, err := api.SharedWithMe(ctx)
+	if err != nil || len(incoming.Shares) != 1 {
+		t.Fatalf("SharedWithMe unexpected result: %+v, %v", incoming, err)
+	}
+
+	shared, err := api.GetShared(ctx, shareUUID.String())
+	if err != nil || shared.From != "alice" || string(shared.Data) != "sealed" {
+		t.Fatalf("GetShared unexpected result: %+v, %v", shared, err)
+	}
+	if _, err := api.GetShared(ctx, uuid.NewString()); err == nil || !strings.Contains(err.Error(), "http code 404") {
+		t.Fatalf("GetShared expected http 404 error, got: %v", err)
+	}
+
+	if err := api.Dismiss(ctx, shareUUID.String()); err != nil {
+		t.Fatalf("Dismiss expected nil err, got: %v", err)
+	}
+}
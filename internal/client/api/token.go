@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/url"
+)
+
+// TokensAPI provides HTTP methods for managing personal access tokens.
+type TokensAPI struct {
+	c *client_http.Client
+}
+
+// NewTokensAPI creates a new TokensAPI instance using the provided HTTP client.
+func NewTokensAPI(client *client_http.Client) *TokensAPI {
+	return &TokensAPI{
+		c: client,
+	}
+}
+
+// Create issues a new personal access token. The plain token is only present
+// in this response.
+func (a *TokensAPI) Create(ctx context.Context, req *dto.CreatePersonalTokenRequest) (*dto.CreatePersonalTokenResponse, error) {
+	var resp dto.CreatePersonalTokenResponse
+	if err := a.c.Do(ctx, http.MethodPost, "/api/auth/tokens", req, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List returns the personal access tokens of the current user.
+func (a *TokensAPI) List(ctx context.Context) (*dto.PersonalTokensResponse, error) {
+	var resp dto.PersonalTokensResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/auth/tokens", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Revoke deletes the personal access token with the given UUID.
+func (a *TokensAPI) Revoke(ctx context.Context, tokenUUID string) error {
+	if err := a.c.Do(ctx, http.MethodDelete, "/api/auth/tokens/"+url.PathEscape(tokenUUID), nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
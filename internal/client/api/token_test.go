@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	clientpkg "github.com/thxhix/passKeeper/internal/transport/client_http"
+	dto "github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestTokensAPI_CreateListRevoke(t *testing.T) {
+	tokenUUID := uuid.New()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/auth/tokens":
+			var in dto.CreatePersonalTokenRequest
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			if len(in.Scopes) == 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "at least one scope is required"})
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(dto.CreatePersonalTokenResponse{
+				PersonalTokenRecord: dto.PersonalTokenRecord{TokenUUID: tokenUUID, Name: in.Name, Scopes: in.Scopes},
+				Token:               "pkp_secret",
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/auth/tokens":
+			_ = json.NewEncoder(w).Encode(dto.PersonalTokensResponse{
+				Tokens: []*dto.PersonalTokenRecord{{TokenUUID: tokenUUID, Name: "ci"}},
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/auth/tokens/"+tokenUUID.String():
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "resource not found"})
+		}
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	client.SetAccessToken("session")
+	api := NewTokensAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	created, err := api.Create(ctx, &dto.CreatePersonalTokenRequest{Name: "ci", Scopes: []string{"read"}})
+	if err != nil {
+		t.Fatalf("Create expected nil err, got: %v", err)
+	}
+	if created.Token != "pkp_secret" || created.TokenUUID != tokenUUID {
+		t.Fatalf("Create unexpected response: %+v", created)
+	}
+
+	if _, err := api.Create(ctx, &dto.CreatePersonalTokenRequest{Name: "ci"}); err == nil || !strings.Contains(err.Error(), "http code 400") {
+		t.Fatalf("Create expected http 400 error, got: %v", err)
+	}
+
+	list, err := api.List(ctx)
+	if err != nil || len(list.Tokens) != 1 {
+		t.Fatalf("List unexpected result: %+v, %v", list, err)
+	}
+
+	if err := api.Revoke(ctx, tokenUUID.String()); err != nil {
+		t.Fatalf("Revoke expected nil err, got: %v", err)
+	}
+	if err := api.Revoke(ctx, uuid.NewString()); err == nil {
+		t.Fatalf("Revoke expected error for unknown token, got nil")
+	}
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/url"
+)
+
+// VaultsAPI provides HTTP methods for managing organizations, shared vaults
+// and their members.
+type VaultsAPI struct {
+	c *client_http.Client
+}
+
+// NewVaultsAPI creates a new VaultsAPI instance using the provided HTTP client.
+func NewVaultsAPI(client *client_http.Client) *VaultsAPI {
+	return &VaultsAPI{
+		c: client,
+	}
+}
+
+// CreateOrganization creates an organization owned by the current user.
+func (a *VaultsAPI) CreateOrganization(ctx context.Context, req *dto.CreateOrganizationRequest) (*dto.OrganizationRecord, error) {
+	var resp dto.OrganizationRecord
+	if err := a.c.Do(ctx, http.MethodPost, "/api/orgs", req, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListOrganizations returns the organizations the current user is a member of.
+func (a *VaultsAPI) ListOrganizations(ctx context.Context) (*dto.OrganizationsResponse, error) {
+	var resp dto.OrganizationsResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/orgs", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// OrganizationMembers returns the members of the organization with the given UUID.
+func (a *VaultsAPI) OrganizationMembers(ctx context.Context, orgUUID string) (*dto.MembersResponse, error) {
+	var resp dto.MembersResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/orgs/"+url.PathEscape(orgUUID)+"/members", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetOrganizationMember adds the user with the given login to the
+// organization or changes their role in it.
+func (a *VaultsAPI) SetOrganizationMember(ctx context.Context, orgUUID string, login string, req *dto.SetMemberRequest) error {
+	if err := a.c.Do(ctx, http.MethodPut, "/api/orgs/"+url.PathEscape(orgUUID)+"/members/"+url.PathEscape(login), req, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveOrganizationMember removes the user with the given login from the
+// organization.
+func (a *VaultsAPI) RemoveOrganizationMember(ctx context.Context, orgUUID string, login string) error {
+	if err := a.c.Do(ctx, http.MethodDelete, "/api/orgs/"+url.PathEscape(orgUUID)+"/members/"+url.PathEscape(login), nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// CreateVault creates a shared vault owned by the current user.
+func (a *VaultsAPI) CreateVault(ctx context.Context, req *dto.CreateVaultRequest) (*dto.VaultRecord, error) {
+	var resp dto.VaultRecord
+	if err := a.c.Do(ctx, http.MethodPost, "/api/vaults", req, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListVaults returns the vaults the current user has a role in.
+func (a *VaultsAPI) ListVaults(ctx context.Context) (*dto.VaultsResponse, error) {
+	var resp dto.VaultsResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/vaults", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Members returns everyone with a role in the vault with the given UUID.
+func (a *VaultsAPI) Members(ctx context.Context, vaultUUID string) (*dto.MembersResponse, error) {
+	var resp dto.MembersResponse
+	if err := a.c.Do(ctx, http.MethodGet, "/api/vaults/"+url.PathEscape(vaultUUID)+"/members", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Share gives the user with the given login a role in the vault.
+func (a *VaultsAPI) Share(ctx context.Context, vaultUUID string, login string, req *dto.SetMemberRequest) error {
+	if err := a.c.Do(ctx, http.MethodPut, "/api/vaults/"+url.PathEscape(vaultUUID)+"/members/"+url.PathEscape(login), req, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// Unshare removes the role of the user with the given login from the vault.
+func (a *VaultsAPI) Unshare(ctx context.Context, vaultUUID string, login string) error {
+	if err := a.c.Do(ctx, http.MethodDelete, "/api/vaults/"+url.PathEscape(vaultUUID)+"/members/"+url.PathEscape(login), nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	clientpkg "github.com/thxhix/passKeeper/internal/transport/client_http"
+	dto "github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestVaultsAPI_CreateShareMembers(t *testing.T) {
+	vaultUUID := uuid.New()
+	membersPath := "/api/vaults/" + vaultUUID.String() + "/members"
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/vaults":
+			var in dto.CreateVaultRequest
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(dto.VaultRecord{VaultUUID: vaultUUID, Name: in.Name, Role: "owner"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/vaults":
+			_ = json.NewEncoder(w).Encode(dto.VaultsResponse{
+				Vaults: []*dto.VaultRecord{{VaultUUID: vaultUUID, Name: "Ops", Role: "owner"}},
+			})
+		case r.Method == http.MethodPut && r.URL.Path == membersPath+"/bob":
+			var in dto.SetMemberRequest
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			if in.Role != "editor" {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "unknown role"})
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == membersPath+"/bob":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == membersPath:
+			_ = json.NewEncoder(w).Encode(dto.MembersResponse{
+				Members: []*dto.MemberRecord{{Login: "alice", Role: "owner"}, {Login: "bob", Role: "editor"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "vault not found"})
+		}
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	client.SetAccessToken("session")
+	api := NewVaultsAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	created, err := api.CreateVault(ctx, &dto.CreateVaultRequest{Name: "Ops"})
+	if err != nil || created.VaultUUID != vaultUUID || created.Name != "Ops" {
+		t.Fatalf("CreateVault unexpected result: %+v, %v", created, err)
+	}
+
+	list, err := api.ListVaults(ctx)
+	if err != nil || len(list.Vaults) != 1 {
+		t.Fatalf("ListVaults unexpected result: %+v, %v", list, err)
+	}
+
+	if err := api.Share(ctx, vaultUUID.String(), "bob", &dto.SetMemberRequest{Role: "editor"}); err != nil {
+		t.Fatalf("Share expected nil err, got: %v", err)
+	}
+	if err := api.Share(ctx, vaultUUID.String(), "bob", &dto.SetMemberRequest{Role: "root"}); err == nil || !strings.Contains(err.Error(), "http code 400") {
+		t.Fatalf("Share expected http 400 error, got: %v", err)
+	}
+
+	members, err := api.Members(ctx, vaultUUID.String())
+	if err != nil || len(members.Members) != 2 {
+		t.Fatalf("Members unexpected result: %+v, %v", members, err)
+	}
+
+	if err := api.Unshare(ctx, vaultUUID.String(), "bob"); err != nil {
+		t.Fatalf("Unshare expected nil err, got: %v", err)
+	}
+	if _, err := api.Members(ctx, uuid.NewString()); err == nil || !strings.Contains(err.Error(), "http code 404") {
+		t.Fatalf("Members expected http 404 error, got: %v", err)
+	}
+}
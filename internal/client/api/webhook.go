@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/url"
+)
+
+// WebhooksAPI provides HTTP methods for managing webhook subscriptions.
+type WebhooksAPI struct {
+	c    *client_http.Client
+	path string
+}
+
+// NewWebhooksAPI creates a new WebhooksAPI instance using the provided HTTP
+// client, managing the subscriptions of the current user.
+func NewWebhooksAPI(client *client_http.Client) *WebhooksAPI {
+	return &WebhooksAPI{
+		c:    client,
+		path: "/api/webhooks",
+	}
+}
+
+// NewGlobalWebhooksAPI creates a WebhooksAPI managing the global
+// subscriptions through the admin API.
+func NewGlobalWebhooksAPI(client *client_http.Client) *WebhooksAPI {
+	return &WebhooksAPI{
+		c:    client,
+		path: "/api/admin/webhooks",
+	}
+}
+
+// Create subscribes to events. The signing secret is only present in this response.
+func (a *WebhooksAPI) Create(ctx context.Context, req *dto.CreateWebhookRequest) (*dto.CreateWebhookResponse, error) {
+	var resp dto.CreateWebhookResponse
+	if err := a.c.Do(ctx, http.MethodPost, a.path, req, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List returns the webhook subscriptions.
+func (a *WebhooksAPI) List(ctx context.Context) (*dto.WebhooksResponse, error) {
+	var resp dto.WebhooksResponse
+	if err := a.c.Do(ctx, http.MethodGet, a.path, nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Delete removes the webhook subscription with the given ID.
+func (a *WebhooksAPI) Delete(ctx context.Context, webhookID string) error {
+	if err := a.c.Do(ctx, http.MethodDelete, a.path+"/"+url.PathEscape(webhookID), nil, nil); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return err
+	}
+	return nil
+}
+
+// Deliveries returns the latest deliveries of the webhook subscription with the given ID.
+func (a *WebhooksAPI) Deliveries(ctx context.Context, webhookID string) (*dto.WebhookDeliveriesResponse, error) {
+	var resp dto.WebhookDeliveriesResponse
+	if err := a.c.Do(ctx, http.MethodGet, a.path+"/"+url.PathEscape(webhookID)+"/deliveries", nil, &resp); err != nil {
+		var he *client_http.HTTPError
+		if errors.As(err, &he) {
+			return nil, fmt.Errorf("http code %d: %s", he.StatusCode, he.Body)
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	clientpkg "github.com/thxhix/passKeeper/internal/transport/client_http"
+	dto "github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+)
+
+func TestWebhooksAPI_CreateListDeliveriesDelete(t *testing.T) {
+	webhookID := uuid.New()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/webhooks":
+			var req dto.CreateWebhookRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(dto.CreateWebhookResponse{
+				WebhookRecord: dto.WebhookRecord{ID: webhookID, URL: req.URL, Events: req.Events},
+				Secret:        "whsec_secret",
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/webhooks":
+			_ = json.NewEncoder(w).Encode(dto.WebhooksResponse{
+				Webhooks: []*dto.WebhookRecord{{ID: webhookID, URL: "https://soc.example.com/hook"}},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/webhooks/"+webhookID.String()+"/deliveries":
+			_ = json.NewEncoder(w).Encode(dto.WebhookDeliveriesResponse{
+				Deliveries: []*dto.WebhookDeliveryRecord{{ID: uuid.New(), Event: "key.read", Status: "delivered", Attempts: 1}},
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/webhooks/"+webhookID.String():
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "resource not found"})
+		}
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+	api := NewWebhooksAPI(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	created, err := api.Create(ctx, &dto.CreateWebhookRequest{URL: "https://soc.example.com/hook", Events: []string{"key.read"}})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID != webhookID || created.Secret != "whsec_secret" {
+		t.Fatalf("unexpected webhook: %+v", created)
+	}
+
+	list, err := api.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list.Webhooks) != 1 {
+		t.Fatalf("unexpected webhooks: %+v", list.Webhooks)
+	}
+
+	deliveries, err := api.Deliveries(ctx, webhookID.String())
+	if err != nil {
+		t.Fatalf("Deliveries: %v", err)
+	}
+	if len(deliveries.Deliveries) != 1 || deliveries.Deliveries[0].Status != "delivered" {
+		t.Fatalf("unexpected deliveries: %+v", deliveries.Deliveries)
+	}
+
+	if err := api.Delete(ctx, webhookID.String()); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := api.Delete(ctx, uuid.NewString()); err == nil {
+		t.Fatalf("expected error for unknown webhook")
+	}
+}
+
+func TestWebhooksAPI_Global(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/admin/webhooks" {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "resource not found"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(dto.WebhooksResponse{
+			Webhooks: []*dto.WebhookRecord{{ID: uuid.New(), URL: "https://soc.example.com/hook"}},
+		})
+	}))
+	defer ts.Close()
+
+	client, err := clientpkg.NewHttpClient(ts.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	got, err := NewGlobalWebhooksAPI(client).List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(got.Webhooks) != 1 {
+		t.Fatalf("unexpected webhooks: %+v", got.Webhooks)
+	}
+}
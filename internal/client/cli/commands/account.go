@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"gopkg.in/urfave/cli.v1"
+	"time"
+)
+
+type AccountCLICommands struct {
+	s *client_services.AccountClientService
+}
+
+func NewAccountCLICommands(s *client_services.AccountClientService) *AccountCLICommands {
+	return &AccountCLICommands{s: s}
+}
+
+func (cmd *AccountCLICommands) Account() cli.Command {
+	return cli.Command{
+		Name:  "account",
+		Usage: "account email|export|delete — manage personal data of the account",
+		Subcommands: []cli.Command{
+			{
+				Name:      "email",
+				Usage:     "passKeeper account email [address] — set the email used for password recovery",
+				ArgsUsage: "[address]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper account email [address]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.ChangeEmail(ctx, c.Args().Get(0)); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Email changed. Confirm it with: passKeeper verify-email <token>")
+					return nil
+				},
+			},
+
+			{
+				Name:      "export",
+				Usage:     "passKeeper account export [file] — download all entries as a zip archive",
+				ArgsUsage: "[file(optional)]",
+				Action: func(c *cli.Context) error {
+					filePath := c.Args().Get(0)
+					if filePath == "" {
+						filePath = fmt.Sprintf("passkeeper-export-%s.zip", time.Now().Format("20060102-150405"))
+					}
+
+					password, err := readSecret("Password: ")
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+					defer cancel()
+
+					if err := cmd.s.Export(ctx, password, filePath); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("Exported to %s. The archive contains unencrypted secrets, keep it safe.\n", filePath)
+					return nil
+				},
+			},
+
+			{
+				Name:  "delete",
+				Usage: "passKeeper account delete — permanently delete the account and all entries",
+				Action: func(c *cli.Context) error {
+					confirm, err := readLine("Type DELETE to permanently remove the account: ")
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+					if confirm != "DELETE" {
+						return cli.NewExitError("aborted", 2)
+					}
+
+					password, err := readSecret("Password: ")
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.Delete(ctx, password); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Account deleted.")
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,215 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+type AdminCLICommands struct {
+	s *client_services.AdminClientService
+}
+
+func NewAdminCLICommands(s *client_services.AdminClientService) *AdminCLICommands {
+	return &AdminCLICommands{s: s}
+}
+
+// Bootstrap returns the command granting the administrator role to the first
+// administrator. It talks to the database directly through bootstrap, since no
+// administrator exists yet to do it through the API.
+func (cmd *AdminCLICommands) Bootstrap(bootstrap func(login string, databaseURI string) error) cli.Command {
+	return cli.Command{
+		Name:      "bootstrap",
+		Usage:     "passkeeper-admin bootstrap [--database-uri dsn] [login] — make a registered user the first administrator",
+		ArgsUsage: "[login]",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:   "database-uri, d",
+				Usage:  "PostgreSQL DSN of the server",
+				EnvVar: "DATABASE_URI",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return cli.NewExitError("usage: passkeeper-admin bootstrap [--database-uri dsn] [login]", 2)
+			}
+
+			if err := bootstrap(c.Args().Get(0), c.String("database-uri")); err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			fmt.Printf("%s is now an administrator. Log in with passKeeper to use the admin commands.\n", c.Args().Get(0))
+			return nil
+		},
+	}
+}
+
+func (cmd *AdminCLICommands) Users() cli.Command {
+	return cli.Command{
+		Name:  "users",
+		Usage: "users list|disable|enable|logout|reset-2fa|stats — manage user accounts",
+		Subcommands: []cli.Command{
+			{
+				Name:  "list",
+				Usage: "passkeeper-admin users list [--limit 50] [--offset 0] — show user accounts",
+				Flags: []cli.Flag{
+					cli.IntFlag{
+						Name:  "limit",
+						Usage: "maximum number of users, at most 500",
+					},
+					cli.IntFlag{
+						Name:  "offset",
+						Usage: "number of users to skip",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.ListUsers(ctx, c.Int("limit"), c.Int("offset"))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Users) == 0 {
+						fmt.Println("No users.")
+						return nil
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintf(w, "ID\tLOGIN\tADMIN\tDISABLED_AT\tLOCKED_UNTIL\tCREATED_AT\n")
+
+					for _, rec := range resp.Users {
+						_, err = fmt.Fprintf(
+							w,
+							"%d\t%s\t%t\t%s\t%s\t%s\n",
+							rec.ID,
+							rec.Login,
+							rec.IsAdmin,
+							formatOptionalTime(rec.DisabledAt, "-"),
+							formatOptionalTime(rec.LockedUntil, "-"),
+							formatOptionalTime(&rec.CreatedAt, "-"),
+						)
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+					}
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+
+			cmd.userAction("disable", "prevent a user from logging in and end their sessions", "User disabled.", cmd.s.Disable),
+			cmd.userAction("enable", "allow a disabled user to log in again", "User enabled.", cmd.s.Enable),
+			cmd.userAction("logout", "end every session of a user", "Sessions revoked.", cmd.s.Logout),
+
+			{
+				Name:      "reset-2fa",
+				Usage:     "passkeeper-admin users reset-2fa [id] — forget the devices of a user who lost them; the next login device becomes trusted",
+				ArgsUsage: "[id]",
+				Action: func(c *cli.Context) error {
+					userID, err := parseUserIDArg(c, "reset-2fa")
+					if err != nil {
+						return err
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.ResetSecondFactor(ctx, userID)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("Removed %d device(s), sessions revoked.\n", resp.RemovedDevices)
+					return nil
+				},
+			},
+
+			{
+				Name:      "stats",
+				Usage:     "passkeeper-admin users stats [id] — show what a user stores",
+				ArgsUsage: "[id]",
+				Action: func(c *cli.Context) error {
+					userID, err := parseUserIDArg(c, "stats")
+					if err != nil {
+						return err
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.Stats(ctx, userID)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					types := make([]string, 0, len(resp.EntriesByType))
+					for t := range resp.EntriesByType {
+						types = append(types, t)
+					}
+					sort.Strings(types)
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintf(w, "Entries:\t%d\n", resp.Entries)
+					for _, t := range types {
+						_, _ = fmt.Fprintf(w, "  %s:\t%d\n", t, resp.EntriesByType[t])
+					}
+					_, _ = fmt.Fprintf(w, "Encrypted data:\t%d bytes\n", resp.DataBytes)
+					_, _ = fmt.Fprintf(w, "Devices:\t%d\n", resp.Devices)
+					_, _ = fmt.Fprintf(w, "Personal tokens:\t%d\n", resp.PersonalTokens)
+					_, _ = fmt.Fprintf(w, "Service accounts:\t%d\n", resp.ServiceAccounts)
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// userAction builds a subcommand applying action to the user given by ID.
+func (cmd *AdminCLICommands) userAction(name string, usage string, done string, action func(ctx context.Context, userID int64) error) cli.Command {
+	return cli.Command{
+		Name:      name,
+		Usage:     fmt.Sprintf("passkeeper-admin users %s [id] — %s", name, usage),
+		ArgsUsage: "[id]",
+		Action: func(c *cli.Context) error {
+			userID, err := parseUserIDArg(c, name)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			if err := action(ctx, userID); err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			fmt.Println(done)
+			return nil
+		},
+	}
+}
+
+func parseUserIDArg(c *cli.Context, name string) (int64, error) {
+	usage := fmt.Sprintf("usage: passkeeper-admin users %s [id]", name)
+	if c.NArg() != 1 {
+		return 0, cli.NewExitError(usage, 2)
+	}
+
+	userID, err := strconv.ParseInt(c.Args().Get(0), 10, 64)
+	if err != nil {
+		return 0, cli.NewExitError(usage, 2)
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+type AuditCLICommands struct {
+	s *client_services.AuditClientService
+}
+
+func NewAuditCLICommands(s *client_services.AuditClientService) *AuditCLICommands {
+	return &AuditCLICommands{s: s}
+}
+
+func (cmd *AuditCLICommands) Audit() cli.Command {
+	return cli.Command{
+		Name:  "audit",
+		Usage: "passKeeper audit [--action A] [--key UUID] [--result R] [--since T] [--limit N] — show who accessed your account",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "action",
+				Usage: "only events of this action: login, refresh, key.read, key.add, key.update, key.delete, key.share, key.unshare, send.create, send.view, emergency.request, emergency.takeover, recovery.setup, recovery.reveal or account.export",
+			},
+			cli.StringFlag{
+				Name:  "key",
+				Usage: "only events of the entry with this UUID",
+			},
+			cli.StringFlag{
+				Name:  "result",
+				Usage: "only events with this result: success, denied or failure",
+			},
+			cli.StringFlag{
+				Name:  "since",
+				Usage: "only events after this time, RFC 3339 (2025-10-25T09:00:00Z) or a duration ago (24h)",
+			},
+			cli.IntFlag{
+				Name:  "limit",
+				Usage: "maximum number of events, 100 by default",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			since, err := parseSince(c.String("since"))
+			if err != nil {
+				return cli.NewExitError("--since must be an RFC 3339 time or a duration", 2)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			resp, err := cmd.s.List(ctx, client_services.AuditQuery{
+				Action: c.String("action"),
+				Key:    c.String("key"),
+				Result: c.String("result"),
+				Since:  since,
+				Limit:  c.Int("limit"),
+			})
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			if len(resp.Events) == 0 {
+				fmt.Println("No events.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintf(w, "TIME\tACTION\tRESULT\tKEY\tACTOR\tIP\tUSER_AGENT\n")
+
+			for _, rec := range resp.Events {
+				key := "-"
+				if rec.KeyUUID != nil {
+					key = rec.KeyUUID.String()
+				}
+
+				_, err = fmt.Fprintf(
+					w,
+					"%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					formatOptionalTime(&rec.CreatedAt, "-"),
+					rec.Action,
+					rec.Result,
+					key,
+					rec.Actor,
+					orDash(rec.IP),
+					orDash(rec.UserAgent),
+				)
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+			}
+
+			_ = w.Flush()
+			return nil
+		},
+	}
+}
+
+// parseSince accepts an RFC 3339 time or a duration before now and returns it
+// in RFC 3339, or an empty string for an empty value.
+func parseSince(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	if d, err := time.ParseDuration(raw); err == nil {
+		return time.Now().Add(-d).UTC().Format(time.RFC3339), nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(time.RFC3339), nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
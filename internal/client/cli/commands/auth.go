@@ -2,41 +2,60 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/thxhix/passKeeper/internal/client/api"
 	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"github.com/thxhix/passKeeper/internal/client/token"
+	"github.com/thxhix/passKeeper/internal/domain/device"
 	"gopkg.in/urfave/cli.v1"
 	"time"
 )
 
 type AuthCLICommands struct {
-	s *client_services.AuthClientService
+	s      *client_services.AuthClientService
+	shares *client_services.ShareClientService
+	keys   *client_services.KeychainClientService
 }
 
-func NewAuthCLICommands(s *client_services.AuthClientService) *AuthCLICommands {
-	return &AuthCLICommands{s: s}
+func NewAuthCLICommands(s *client_services.AuthClientService, shares *client_services.ShareClientService, keys *client_services.KeychainClientService) *AuthCLICommands {
+	return &AuthCLICommands{s: s, shares: shares, keys: keys}
 }
 
 func (cmd *AuthCLICommands) RegisterCmd() cli.Command {
 	return cli.Command{
 		Name:      "register",
-		Usage:     "register [login] [password] — register new user",
+		Usage:     "register [--email ADDRESS] [--invite CODE] [login] [password] — register new user",
 		ArgsUsage: "[login] [password]",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "email",
+				Usage: "email address for password recovery, a verification token is mailed to it",
+			},
+			cli.StringFlag{
+				Name:  "invite",
+				Usage: "invite code, required when the server only admits invited users",
+			},
+		},
 
 		Action: func(c *cli.Context) error {
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
 
 			if c.NArg() < 2 {
-				return cli.NewExitError("usage: passKeeper register [login] [password]", 2)
+				return cli.NewExitError("usage: passKeeper register [--email ADDRESS] [--invite CODE] [login] [password]", 2)
 			}
 			login := c.Args().Get(0)
 			password := c.Args().Get(1)
 
-			if err := cmd.s.Register(ctx, login, password); err != nil {
+			if err := cmd.s.Register(ctx, login, password, c.String("email"), c.String("invite")); err != nil {
 				return cli.NewExitError(err.Error(), 1)
 			}
 
 			fmt.Println("Registered successfully.")
+			if c.String("email") != "" {
+				fmt.Println("Check your inbox and confirm the address with: passKeeper verify-email <token>")
+			}
 
 			return nil
 		},
@@ -46,20 +65,29 @@ func (cmd *AuthCLICommands) RegisterCmd() cli.Command {
 func (cmd *AuthCLICommands) LoginCmd() cli.Command {
 	return cli.Command{
 		Name:      "login",
-		Usage:     "login [login] [password] — login user by credentials",
+		Usage:     "login [--code CODE] [login] [password] — login user by credentials",
 		ArgsUsage: "[login] [password]",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "code",
+				Usage: "one-time approval code for a new device",
+			},
+		},
 
 		Action: func(c *cli.Context) error {
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
 
 			if c.NArg() < 2 {
-				return cli.NewExitError("usage: passKeeper login [login] [password]", 2)
+				return cli.NewExitError("usage: passKeeper login [--code CODE] [login] [password]", 2)
 			}
 			login := c.Args().Get(0)
 			password := c.Args().Get(1)
 
-			if err := cmd.s.Login(ctx, login, password); err != nil {
+			if err := cmd.s.Login(ctx, login, password, c.String("code")); err != nil {
+				if errors.Is(err, api.ErrDeviceApprovalRequired) {
+					return cli.NewExitError(cmd.approvalHint(), 1)
+				}
 				return cli.NewExitError(err.Error(), 1)
 			}
 
@@ -89,3 +117,200 @@ func (cmd *AuthCLICommands) RefreshTokenCmd() cli.Command {
 		},
 	}
 }
+
+func (cmd *AuthCLICommands) PasswdCmd() cli.Command {
+	return cli.Command{
+		Name:  "passwd",
+		Usage: "passwd — change account password, other sessions are logged out",
+
+		Action: func(c *cli.Context) error {
+			current, err := readSecret("Current password: ")
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+			next, err := readSecret("New password: ")
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+			confirm, err := readSecret("Repeat new password: ")
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+			if next != confirm {
+				return cli.NewExitError("passwords do not match", 2)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			// The private sharing key is wrapped with the password, so it is
+			// rewrapped before the password changes.
+			keyMaterial, err := cmd.shares.RewrapKeyMaterial(ctx, current, next)
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			if err := cmd.s.ChangePassword(ctx, current, next, keyMaterial); err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			fmt.Println("Password changed successfully.")
+
+			return nil
+		},
+	}
+}
+
+func (cmd *AuthCLICommands) VerifyEmailCmd() cli.Command {
+	return cli.Command{
+		Name:      "verify-email",
+		Usage:     "verify-email [token] — confirm the email address with the token mailed to it",
+		ArgsUsage: "[token]",
+
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return cli.NewExitError("usage: passKeeper verify-email [token]", 2)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			if err := cmd.s.VerifyEmail(ctx, c.Args().Get(0)); err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			fmt.Println("Email verified.")
+
+			return nil
+		},
+	}
+}
+
+func (cmd *AuthCLICommands) ForgotPasswordCmd() cli.Command {
+	return cli.Command{
+		Name:      "forgot-password",
+		Usage:     "forgot-password [email] — mail a password reset token to the verified address",
+		ArgsUsage: "[email]",
+
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return cli.NewExitError("usage: passKeeper forgot-password [email]", 2)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			if err := cmd.s.ForgotPassword(ctx, c.Args().Get(0)); err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			fmt.Println("If the address belongs to an account and is verified, a reset token was mailed to it.")
+			fmt.Println("Then run: passKeeper reset-password --token <token>")
+
+			return nil
+		},
+	}
+}
+
+func (cmd *AuthCLICommands) ResetPasswordCmd() cli.Command {
+	return cli.Command{
+		Name:  "reset-password",
+		Usage: "reset-password --token TOKEN — choose a new password with a mailed reset token",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "token",
+				Usage: "reset token from the email",
+			},
+		},
+
+		Action: func(c *cli.Context) error {
+			resetToken := c.String("token")
+			if resetToken == "" {
+				return cli.NewExitError("usage: passKeeper reset-password --token TOKEN", 2)
+			}
+
+			next, err := readSecret("New password: ")
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+			confirm, err := readSecret("Repeat new password: ")
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+			if next != confirm {
+				return cli.NewExitError("passwords do not match", 2)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			discarded, err := cmd.s.ResetPassword(ctx, resetToken, next, false)
+			if errors.Is(err, api.ErrVaultKeyWillBeLost) {
+				fmt.Println("WARNING: this account uses a zero-knowledge vault key protected by the old password.")
+				fmt.Println("The server cannot recover it. Resetting the password discards the key and the")
+				fmt.Println("secrets stored in the vault become permanently unreadable.")
+
+				answer, err := readLine("Type DISCARD to reset anyway: ")
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				if answer != "DISCARD" {
+					return cli.NewExitError("aborted, the password was not changed", 2)
+				}
+
+				discarded, err = cmd.s.ResetPassword(ctx, resetToken, next, true)
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+			} else if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			fmt.Println("Password reset. All sessions were logged out, log in with the new password.")
+			if discarded {
+				fmt.Println("The vault key was discarded; secrets stored before the reset cannot be decrypted.")
+			}
+
+			return nil
+		},
+	}
+}
+
+func (cmd *AuthCLICommands) LogoutCmd() cli.Command {
+	return cli.Command{
+		Name:  "logout",
+		Usage: "logout — end all sessions and remove local tokens and the local replica",
+
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			// the replica goes unless it holds changes not sent yet
+			if err := cmd.keys.ForgetReplica(false); err != nil {
+				fmt.Printf("The local replica was kept: %v. Run 'passKeeper sync' before logging out, or 'passKeeper sync --purge' to discard it.\n", err)
+			}
+
+			if err := cmd.s.Logout(ctx); err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			fmt.Println("Logged out.")
+
+			return nil
+		},
+	}
+}
+
+// approvalHint explains how to approve this device after a rejected login.
+func (cmd *AuthCLICommands) approvalHint() string {
+	d, err := token.LoadOrCreateDevice()
+	if err != nil {
+		return "this device must be approved before logging in"
+	}
+
+	return fmt.Sprintf("This device is not trusted yet.\n"+
+		"Device %s (%s), fingerprint %s.\n"+
+		"Approve it on a trusted device with `passKeeper devices approve %s` and log in again,\n"+
+		"or log in again with --code and the one-time code sent to you.",
+		d.ID, d.Name, device.Fingerprint(d.PublicKey), d.ID)
+}
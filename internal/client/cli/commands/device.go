@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+type DeviceCLICommands struct {
+	s *client_services.DeviceClientService
+}
+
+func NewDeviceCLICommands(s *client_services.DeviceClientService) *DeviceCLICommands {
+	return &DeviceCLICommands{s: s}
+}
+
+func (cmd *DeviceCLICommands) Devices() cli.Command {
+	return cli.Command{
+		Name:  "devices",
+		Usage: "devices list|approve|remove — manage devices allowed to log in",
+		Subcommands: []cli.Command{
+			{
+				Name:  "list",
+				Usage: "passKeeper devices list — show trusted and pending devices",
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.List(ctx)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Devices) == 0 {
+						fmt.Println("No devices.")
+						return nil
+					}
+
+					current, _ := cmd.s.Current()
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintf(w, "ID\tNAME\tFINGERPRINT\tSTATUS\tLAST_SEEN_AT\n")
+
+					for _, rec := range resp.Devices {
+						status := "pending"
+						if rec.Trusted {
+							status = "trusted"
+						}
+						if rec.ID == current.ID {
+							status += " (this device)"
+						}
+
+						_, err = fmt.Fprintf(
+							w,
+							"%s\t%s\t%s\t%s\t%s\n",
+							rec.ID,
+							rec.Name,
+							rec.Fingerprint,
+							status,
+							formatOptionalTime(rec.LastSeenAt, "-"),
+						)
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+					}
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+
+			{
+				Name:      "approve",
+				Usage:     "passKeeper devices approve [id] — trust a pending device, compare its fingerprint first",
+				ArgsUsage: "[id]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper devices approve [id]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.Approve(ctx, c.Args().Get(0)); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Device approved, it can log in now.")
+					return nil
+				},
+			},
+
+			{
+				Name:      "remove",
+				Usage:     "passKeeper devices remove [id] — remove a device and sign it out",
+				ArgsUsage: "[id]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper devices remove [id]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.Remove(ctx, c.Args().Get(0)); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Device removed.")
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,390 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type EmergencyCLICommands struct {
+	s *client_services.EmergencyClientService
+}
+
+func NewEmergencyCLICommands(s *client_services.EmergencyClientService) *EmergencyCLICommands {
+	return &EmergencyCLICommands{s: s}
+}
+
+func (cmd *EmergencyCLICommands) Emergency() cli.Command {
+	return cli.Command{
+		Name:  "emergency",
+		Usage: "emergency add|list|approve|reject|remove|grantors|request|keys|get|takeover|leave — emergency access for trusted contacts",
+		Subcommands: []cli.Command{
+			{
+				Name:      "add",
+				Usage:     "passKeeper emergency add [login] [--access view|takeover] [--wait 168h] — name a trusted contact or change one",
+				ArgsUsage: "[login]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "access",
+						Value: "view",
+						Usage: "view to read your entries, takeover to also set a new password for your account",
+					},
+					cli.DurationFlag{
+						Name:  "wait",
+						Usage: "waiting period before a request is granted automatically, e.g. 72h; 7 days by default",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper emergency add [login] [--access view|takeover] [--wait 168h]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					rec, err := cmd.s.Nominate(ctx, c.Args().Get(0), c.String("access"), c.Duration("wait"))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("%s can request %s access; it is granted %s after a request unless you reject it.\n",
+						rec.Grantee, rec.Access, time.Duration(rec.WaitSeconds)*time.Second)
+					return nil
+				},
+			},
+
+			{
+				Name:  "list",
+				Usage: "passKeeper emergency list — show your trusted contacts and their requests",
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.Contacts(ctx)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Contacts) == 0 {
+						fmt.Println("You have no emergency contacts.")
+						return nil
+					}
+
+					return printContacts(resp, "CONTACT", func(rec *dto.EmergencyContactRecord) string { return rec.Grantee })
+				},
+			},
+
+			{
+				Name:      "approve",
+				Usage:     "passKeeper emergency approve [uuid] — grant a pending request now",
+				ArgsUsage: "[uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper emergency approve [uuid]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					rec, err := cmd.s.Approve(ctx, c.Args().Get(0))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("%s now has %s access.\n", rec.Grantee, rec.Access)
+					return nil
+				},
+			},
+
+			{
+				Name:      "reject",
+				Usage:     "passKeeper emergency reject [uuid] — reject a request or withdraw granted access",
+				ArgsUsage: "[uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper emergency reject [uuid]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					rec, err := cmd.s.Reject(ctx, c.Args().Get(0))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("Access of %s rejected. They can request it again.\n", rec.Grantee)
+					return nil
+				},
+			},
+
+			{
+				Name:      "remove",
+				Usage:     "passKeeper emergency remove [uuid] — remove a trusted contact",
+				ArgsUsage: "[uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper emergency remove [uuid]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.Remove(ctx, c.Args().Get(0)); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Emergency contact removed.")
+					return nil
+				},
+			},
+
+			{
+				Name:  "grantors",
+				Usage: "passKeeper emergency grantors — show the accounts you are an emergency contact for",
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.Grantors(ctx)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Contacts) == 0 {
+						fmt.Println("Nobody named you as emergency contact.")
+						return nil
+					}
+
+					return printContacts(resp, "GRANTOR", func(rec *dto.EmergencyContactRecord) string { return rec.Grantor })
+				},
+			},
+
+			{
+				Name:      "request",
+				Usage:     "passKeeper emergency request [uuid] — request access to an account you are a contact for",
+				ArgsUsage: "[uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper emergency request [uuid]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					rec, err := cmd.s.Request(ctx, c.Args().Get(0))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("Access requested. %s was notified; unless they reject it, access is granted on %s.\n",
+						rec.Grantor, rec.GrantedAt.Local().Format("2006-01-02 15:04:05"))
+					return nil
+				},
+			},
+
+			{
+				Name:      "keys",
+				Usage:     "passKeeper emergency keys [uuid] [type] — list the entries of a granted account",
+				ArgsUsage: "[uuid] [type]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() < 1 || c.NArg() > 2 {
+						return cli.NewExitError("usage: passKeeper emergency keys [uuid] [type]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.Keys(ctx, c.Args().Get(0), c.Args().Get(1))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Keys) == 0 {
+						fmt.Println("The account has no entries.")
+						return nil
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintf(w, "UUID\tTYPE\tTITLE\tTAGS\tCREATED_AT\n")
+
+					for _, rec := range resp.Keys {
+						_, err = fmt.Fprintf(
+							w,
+							"%s\t%s\t%s\t%s\t%s\n",
+							rec.KeyUUID,
+							rec.KeyType,
+							rec.Title,
+							strings.Join(rec.Tags, ","),
+							rec.CreatedAt.Format("2006-01-02 15:04:05"),
+						)
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+					}
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+
+			{
+				Name:      "get",
+				Usage:     "passKeeper emergency get [uuid] [key_uuid] — show an entry of a granted account",
+				ArgsUsage: "[uuid] [key_uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 2 {
+						return cli.NewExitError("usage: passKeeper emergency get [uuid] [key_uuid]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.Key(ctx, c.Args().Get(0), c.Args().Get(1))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintln(w, "UUID\tTYPE\tTITLE\tDATA\tCREATED_AT\tUPDATED_AT")
+
+					_, err = fmt.Fprintf(
+						w,
+						"%s\t%s\t%s\t%s\t%s\t%s\n",
+						resp.KeyUUID,
+						resp.KeyType,
+						resp.Title,
+						resp.Data,
+						resp.CreatedAt.Format("2006-01-02 15:04:05"),
+						resp.UpdatedAt.Format("2006-01-02 15:04:05"),
+					)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+
+			{
+				Name:      "takeover",
+				Usage:     "passKeeper emergency takeover [uuid] — set a new password for a granted account",
+				ArgsUsage: "[uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper emergency takeover [uuid]", 2)
+					}
+					contactUUID := c.Args().Get(0)
+
+					next, err := readSecret("New password for the account: ")
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+					confirm, err := readSecret("Repeat new password: ")
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+					if next != confirm {
+						return cli.NewExitError("passwords do not match", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					discarded, err := cmd.s.Takeover(ctx, contactUUID, next, false)
+					if errors.Is(err, api.ErrVaultKeyWillBeLost) {
+						fmt.Println("WARNING: this account uses a zero-knowledge vault key protected by its current password.")
+						fmt.Println("A takeover discards the key and the secrets stored in the vault become permanently unreadable.")
+
+						answer, err := readLine("Type DISCARD to take over anyway: ")
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+						if answer != "DISCARD" {
+							return cli.NewExitError("aborted, the password was not changed", 2)
+						}
+
+						discarded, err = cmd.s.Takeover(ctx, contactUUID, next, true)
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+					} else if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Password set. All sessions of the account were logged out, log in with the new password.")
+					if discarded {
+						fmt.Println("The vault key was discarded; secrets stored before the takeover cannot be decrypted.")
+					}
+					return nil
+				},
+			},
+
+			{
+				Name:      "leave",
+				Usage:     "passKeeper emergency leave [uuid] — stop being an emergency contact of an account",
+				ArgsUsage: "[uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper emergency leave [uuid]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.StepDown(ctx, c.Args().Get(0)); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("You are no longer an emergency contact of that account.")
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// printContacts prints emergency contacts, naming the other side of each in
+// the column title.
+func printContacts(resp *dto.EmergencyContactsResponse, title string, other func(rec *dto.EmergencyContactRecord) string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "UUID\t%s\tACCESS\tWAIT\tSTATUS\tGRANTED_AT\n", title)
+
+	for _, rec := range resp.Contacts {
+		status := string(rec.Status)
+		if rec.Granted {
+			status = "granted"
+		}
+
+		grantedAt := "-"
+		if rec.GrantedAt != nil {
+			grantedAt = rec.GrantedAt.Local().Format("2006-01-02 15:04:05")
+		}
+
+		_, err := fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			rec.ContactUUID,
+			other(rec),
+			rec.Access,
+			time.Duration(rec.WaitSeconds)*time.Second,
+			status,
+			grantedAt,
+		)
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+	}
+
+	_ = w.Flush()
+	return nil
+}
@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+type InviteCLICommands struct {
+	s *client_services.InviteClientService
+}
+
+func NewInviteCLICommands(s *client_services.InviteClientService) *InviteCLICommands {
+	return &InviteCLICommands{s: s}
+}
+
+func (cmd *InviteCLICommands) Invite() cli.Command {
+	return cli.Command{
+		Name:  "invite",
+		Usage: "invite create|list|revoke — manage invite codes for new users",
+		Subcommands: []cli.Command{
+			{
+				Name:  "create",
+				Usage: "passKeeper invite create [--login LOGIN] [--email EMAIL] [--max-uses N] [--days N]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "login",
+						Usage: "the only login the invite registers",
+					},
+					cli.StringFlag{
+						Name:  "email",
+						Usage: "the only email the invite registers",
+					},
+					cli.IntFlag{
+						Name:  "max-uses",
+						Usage: "how many accounts the invite registers",
+						Value: 1,
+					},
+					cli.IntFlag{
+						Name:  "days",
+						Usage: "invite lifetime in days, 0 for the server default",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 0 {
+						return cli.NewExitError("usage: passKeeper invite create [--login LOGIN] [--email EMAIL] [--max-uses N] [--days N]", 2)
+					}
+					if c.Int("days") < 0 {
+						return cli.NewExitError("--days must not be negative", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					ttl := time.Duration(c.Int("days")) * 24 * time.Hour
+
+					resp, err := cmd.s.Create(ctx, c.String("login"), c.String("email"), c.Int("max-uses"), ttl)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("Invite %s created. Copy it now, it will not be shown again:\n%s\n", resp.InviteUUID, resp.Code)
+					fmt.Println("Register with it by running: passKeeper register --invite <code> [login] [password]")
+					return nil
+				},
+			},
+
+			{
+				Name:  "list",
+				Usage: "passKeeper invite list — show invites created by you",
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.List(ctx)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Invites) == 0 {
+						fmt.Println("No invites.")
+						return nil
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintf(w, "UUID\tLOGIN\tEMAIL\tUSES\tEXPIRES_AT\n")
+
+					for _, rec := range resp.Invites {
+						login := rec.Login
+						if login == "" {
+							login = "any"
+						}
+						email := rec.Email
+						if email == "" {
+							email = "any"
+						}
+
+						_, err = fmt.Fprintf(
+							w,
+							"%s\t%s\t%s\t%d/%d\t%s\n",
+							rec.InviteUUID,
+							login,
+							email,
+							rec.Uses,
+							rec.MaxUses,
+							formatOptionalTime(&rec.ExpiresAt, "-"),
+						)
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+					}
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+
+			{
+				Name:      "revoke",
+				Usage:     "passKeeper invite revoke [uuid] — revoke an invite",
+				ArgsUsage: "[uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper invite revoke [uuid]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.Revoke(ctx, c.Args().Get(0)); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Invite revoked.")
+					return nil
+				},
+			},
+		},
+	}
+}
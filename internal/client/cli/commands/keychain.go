@@ -2,12 +2,16 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/thxhix/passKeeper/internal/client/client_services"
 	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
 	"gopkg.in/urfave/cli.v1"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"text/tabwriter"
 	"time"
 )
@@ -20,6 +24,24 @@ func NewKeychainCLICommands(s *client_services.KeychainClientService) *KeychainC
 	return &KeychainCLICommands{s: s}
 }
 
+// tagFlag attaches tags to a new entry; it can be repeated.
+var tagFlag = cli.StringSliceFlag{
+	Name:  "tag",
+	Usage: "метка записи, можно указать несколько раз",
+}
+
+// vaultFlag selects the shared vault a command works on instead of the
+// personal entries.
+var vaultFlag = cli.StringFlag{
+	Name:  "vault",
+	Usage: "UUID общего хранилища вместо личных записей",
+}
+
+const (
+	offlineMessage = "⚠️ Сервер недоступен, показана локальная копия."
+	queuedMessage  = "📥 Сервер недоступен: изменение сохранено локально и будет отправлено при следующей синхронизации."
+)
+
 func (cmd *KeychainCLICommands) Add() cli.Command {
 	return cli.Command{
 		Name:  "add",
@@ -27,8 +49,9 @@ func (cmd *KeychainCLICommands) Add() cli.Command {
 		Subcommands: []cli.Command{
 			{
 				Name:      "credential",
-				Usage:     "passKeeper add credential [title] [login] [password] [site] [note]",
+				Usage:     "passKeeper add credential [--vault UUID] [--tag tag...] [title] [login] [password] [site] [note]",
 				ArgsUsage: "[title] [login] [password] [site(optional)] [note(optional)]",
+				Flags:     []cli.Flag{vaultFlag, tagFlag},
 				Action: func(c *cli.Context) error {
 					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 					defer cancel()
@@ -44,7 +67,12 @@ func (cmd *KeychainCLICommands) Add() cli.Command {
 					site := c.Args().Get(3)
 					note := c.Args().Get(4)
 
-					if err := cmd.s.AddCredential(ctx, title, login, password, site, note); err != nil {
+					err := cmd.s.AddCredential(ctx, c.String("vault"), title, login, password, site, note, c.StringSlice("tag"))
+					if errors.Is(err, client_services.ErrQueued) {
+						fmt.Println(queuedMessage)
+						return nil
+					}
+					if err != nil {
 						return cli.NewExitError(err.Error(), 1)
 					}
 
@@ -55,8 +83,9 @@ func (cmd *KeychainCLICommands) Add() cli.Command {
 
 			{
 				Name:      "card",
-				Usage:     "passKeeper add card [title] [number] [expDate] [cvv] [holder] [bank] [note]",
+				Usage:     "passKeeper add card [--vault UUID] [--tag tag...] [title] [number] [expDate] [cvv] [holder] [bank] [note]",
 				ArgsUsage: "[title] [number] [expDate] [cvv] [holder(optional)] [bank(optional)] [note(optional)]",
+				Flags:     []cli.Flag{vaultFlag, tagFlag},
 				Action: func(c *cli.Context) error {
 					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 					defer cancel()
@@ -74,7 +103,12 @@ func (cmd *KeychainCLICommands) Add() cli.Command {
 					bank := c.Args().Get(5)
 					note := c.Args().Get(6)
 
-					if err := cmd.s.AddCard(ctx, title, number, expDate, cvv, holder, bank, note); err != nil {
+					err := cmd.s.AddCard(ctx, c.String("vault"), title, number, expDate, cvv, holder, bank, note, c.StringSlice("tag"))
+					if errors.Is(err, client_services.ErrQueued) {
+						fmt.Println(queuedMessage)
+						return nil
+					}
+					if err != nil {
 						return cli.NewExitError(err.Error(), 1)
 					}
 
@@ -85,8 +119,9 @@ func (cmd *KeychainCLICommands) Add() cli.Command {
 
 			{
 				Name:      "text",
-				Usage:     "passKeeper add text [title] [text] [note]",
+				Usage:     "passKeeper add text [--vault UUID] [--tag tag...] [title] [text] [note]",
 				ArgsUsage: "[title] [text] [note(optional)]",
+				Flags:     []cli.Flag{vaultFlag, tagFlag},
 				Action: func(c *cli.Context) error {
 					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 					defer cancel()
@@ -100,7 +135,12 @@ func (cmd *KeychainCLICommands) Add() cli.Command {
 					text := c.Args().Get(1)
 					note := c.Args().Get(2)
 
-					if err := cmd.s.AddText(ctx, title, text, note); err != nil {
+					err := cmd.s.AddText(ctx, c.String("vault"), title, text, note, c.StringSlice("tag"))
+					if errors.Is(err, client_services.ErrQueued) {
+						fmt.Println(queuedMessage)
+						return nil
+					}
+					if err != nil {
 						return cli.NewExitError(err.Error(), 1)
 					}
 
@@ -111,8 +151,9 @@ func (cmd *KeychainCLICommands) Add() cli.Command {
 
 			{
 				Name:      "file",
-				Usage:     "passKeeper add file [title] [filePath] [note]",
+				Usage:     "passKeeper add file [--vault UUID] [--tag tag...] [title] [filePath] [note]",
 				ArgsUsage: "[title] [filePath] [note(optional)]",
+				Flags:     []cli.Flag{vaultFlag, tagFlag},
 				Action: func(c *cli.Context) error {
 					ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 					defer cancel()
@@ -129,7 +170,12 @@ func (cmd *KeychainCLICommands) Add() cli.Command {
 						note = c.Args().Get(2)
 					}
 
-					if err := cmd.s.AddFile(ctx, title, filePath, note); err != nil {
+					err := cmd.s.AddFile(ctx, c.String("vault"), title, filePath, note, c.StringSlice("tag"))
+					if errors.Is(err, client_services.ErrQueued) {
+						fmt.Println(queuedMessage)
+						return nil
+					}
+					if err != nil {
 						return cli.NewExitError(err.Error(), 1)
 					}
 
@@ -151,22 +197,26 @@ func (cmd *KeychainCLICommands) List() cli.Command {
 
 	return cli.Command{
 		Name:      "list",
-		Usage:     "passKeeper list [type]",
+		Usage:     "passKeeper list [--vault UUID] [type]",
 		ArgsUsage: "[type:" + strings.Join(types, "|") + "]",
+		Flags:     []cli.Flag{vaultFlag},
 
 		Action: func(c *cli.Context) error {
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
 
 			if c.NArg() > 1 {
-				return cli.NewExitError("usage: passKeeper list [type]", 2)
+				return cli.NewExitError("usage: passKeeper list [--vault UUID] [type]", 2)
 			}
 			keyType := c.Args().Get(0)
 
-			resp, err := cmd.s.GetList(ctx, keyType)
+			resp, offline, err := cmd.s.GetList(ctx, c.String("vault"), keyType)
 			if err != nil {
 				return cli.NewExitError(err.Error(), 1)
 			}
+			if offline {
+				fmt.Println(offlineMessage)
+			}
 
 			if len(resp.Keys) == 0 {
 				fmt.Println("Нет сохранённых элементов.")
@@ -175,7 +225,7 @@ func (cmd *KeychainCLICommands) List() cli.Command {
 
 			// Настраиваем табличный вывод
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			_, err = fmt.Fprintf(w, "UUID\tTYPE\tTITLE\tCREATED_AT")
+			_, err = fmt.Fprintf(w, "UUID\tTYPE\tTITLE\tTAGS\tCREATED_AT\n")
 			if err != nil {
 				return cli.NewExitError(err.Error(), 1)
 			}
@@ -183,10 +233,11 @@ func (cmd *KeychainCLICommands) List() cli.Command {
 			for _, rec := range resp.Keys {
 				_, err = fmt.Fprintf(
 					w,
-					"%s\t%s\t%s\t%s\n",
+					"%s\t%s\t%s\t%s\t%s\n",
 					rec.KeyUUID,
 					rec.KeyType,
 					rec.Title,
+					strings.Join(rec.Tags, ","),
 					rec.CreatedAt.Format("2006-01-02 15:04:05"),
 				)
 				if err != nil {
@@ -203,22 +254,26 @@ func (cmd *KeychainCLICommands) List() cli.Command {
 func (cmd *KeychainCLICommands) Get() cli.Command {
 	return cli.Command{
 		Name:      "get",
-		Usage:     "get [key_uuid]",
+		Usage:     "get [--vault UUID] [key_uuid]",
 		ArgsUsage: "[key_uuid]",
+		Flags:     []cli.Flag{vaultFlag},
 
 		Action: func(c *cli.Context) error {
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
 
 			if c.NArg() > 1 {
-				return cli.NewExitError("usage: passKeeper get [key_uuid]", 2)
+				return cli.NewExitError("usage: passKeeper get [--vault UUID] [key_uuid]", 2)
 			}
 			keyUUID := c.Args().Get(0)
 
-			resp, err := cmd.s.Get(ctx, keyUUID)
+			resp, offline, err := cmd.s.Get(ctx, c.String("vault"), keyUUID)
 			if err != nil {
 				return cli.NewExitError(err.Error(), 1)
 			}
+			if offline {
+				fmt.Println(offlineMessage)
+			}
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 			_, err = fmt.Fprintln(w, "UUID\tTYPE\tTITLE\tDATA\tCREATED_AT\tUPDATED_AT")
@@ -249,19 +304,24 @@ func (cmd *KeychainCLICommands) Get() cli.Command {
 func (cmd *KeychainCLICommands) Delete() cli.Command {
 	return cli.Command{
 		Name:      "delete",
-		Usage:     "delete [key_uuid]",
+		Usage:     "delete [--vault UUID] [key_uuid]",
 		ArgsUsage: "[key_uuid]",
+		Flags:     []cli.Flag{vaultFlag},
 
 		Action: func(c *cli.Context) error {
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
 
 			if c.NArg() > 1 {
-				return cli.NewExitError("usage: passKeeper delete [key_uuid]", 2)
+				return cli.NewExitError("usage: passKeeper delete [--vault UUID] [key_uuid]", 2)
 			}
 			keyUUID := c.Args().Get(0)
 
-			err := cmd.s.Delete(ctx, keyUUID)
+			err := cmd.s.Delete(ctx, c.String("vault"), keyUUID)
+			if errors.Is(err, client_services.ErrQueued) {
+				fmt.Println(queuedMessage)
+				return nil
+			}
 			if err != nil {
 				return cli.NewExitError(err.Error(), 1)
 			}
@@ -271,3 +331,133 @@ func (cmd *KeychainCLICommands) Delete() cli.Command {
 		},
 	}
 }
+
+func (cmd *KeychainCLICommands) Sync() cli.Command {
+	return cli.Command{
+		Name:  "sync",
+		Usage: "sync [--force | --discard | --purge] — отправить отложенные изменения и обновить локальную копию",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "force",
+				Usage: "отправить конфликтующие изменения несмотря на конфликт",
+			},
+			cli.BoolFlag{
+				Name:  "discard",
+				Usage: "отбросить конфликтующие изменения, оставив версию сервера",
+			},
+			cli.BoolFlag{
+				Name:  "purge",
+				Usage: "удалить локальную копию вместе с неотправленными изменениями",
+			},
+		},
+
+		Action: func(c *cli.Context) error {
+			if c.Bool("purge") {
+				if err := cmd.s.ForgetReplica(true); err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				fmt.Println("✅ Локальная копия удалена.")
+				return nil
+			}
+
+			policy := client_services.KeepConflicts
+			switch {
+			case c.Bool("force") && c.Bool("discard"):
+				return cli.NewExitError("usage: passKeeper sync [--force | --discard | --purge]", 2)
+			case c.Bool("force"):
+				policy = client_services.ForceConflicts
+			case c.Bool("discard"):
+				policy = client_services.DiscardConflicts
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+
+			res, err := cmd.s.Sync(ctx, policy)
+			if err != nil {
+				if res != nil && res.Pushed > 0 {
+					fmt.Printf("Отправлено изменений: %d\n", res.Pushed)
+				}
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			fmt.Printf("✅ Синхронизировано до ревизии %d, отправлено изменений: %d\n", res.Revision, res.Pushed)
+			if len(res.Conflicts) == 0 {
+				return nil
+			}
+
+			fmt.Printf("Конфликтов: %d. Запустите sync --force, чтобы отправить их, или sync --discard, чтобы отбросить.\n", len(res.Conflicts))
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, err = fmt.Fprintln(w, "KIND\tTYPE\tTITLE\tQUEUED_AT\tCONFLICT")
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			for _, op := range res.Conflicts {
+				_, err = fmt.Fprintf(
+					w,
+					"%s\t%s\t%s\t%s\t%s\n",
+					op.Kind,
+					op.KeyType,
+					op.Title,
+					op.QueuedAt.Format("2006-01-02 15:04:05"),
+					op.Conflict,
+				)
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+			}
+
+			_ = w.Flush()
+			return nil
+		},
+	}
+}
+
+// Watch prints the changes of the personal entries as they happen on the
+// server, until interrupted.
+func (cmd *KeychainCLICommands) Watch() cli.Command {
+	return cli.Command{
+		Name:  "watch",
+		Usage: "watch — следить за изменениями личных записей на сервере",
+
+		Action: func(c *cli.Context) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			remove := cmd.s.OnChange(func(event dto.KeyEvent) {
+				now := time.Now().Format("2006-01-02 15:04:05")
+				if event.Event == keychain.EventResync {
+					fmt.Printf("%s\t🔄 часть событий могла быть пропущена, выполните sync\n", now)
+					return
+				}
+				fmt.Printf("%s\t%s\t%s\t%s\t%s\n", now, eventLabel(event.Event), event.KeyUUID, event.KeyType, event.Title)
+			})
+			defer remove()
+
+			fmt.Println("👀 Ожидание изменений, Ctrl+C для выхода.")
+			err := cmd.s.Watch(ctx, func(err error) {
+				fmt.Fprintf(os.Stderr, "⚠️ %v\n", err)
+			})
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+			return nil
+		},
+	}
+}
+
+// eventLabel names a kind of change for the output of watch.
+func eventLabel(t keychain.EventType) string {
+	switch t {
+	case keychain.EventAdd:
+		return "➕ добавлена"
+	case keychain.EventUpdate:
+		return "✏️ изменена"
+	case keychain.EventDelete:
+		return "🗑 удалена"
+	default:
+		return string(t)
+	}
+}
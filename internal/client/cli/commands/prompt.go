@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"golang.org/x/term"
+	"os"
+	"strings"
+)
+
+var ErrNotATerminal = errors.New("hidden input requires an interactive terminal")
+
+// readSecret prints prompt and reads a line from the terminal without echoing it.
+func readSecret(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", ErrNotATerminal
+	}
+
+	fmt.Print(prompt)
+	b, err := term.ReadPassword(fd)
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// readLine prints prompt and reads a single visible line from stdin.
+func readLine(prompt string) (string, error) {
+	fmt.Print(prompt)
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+// ReplicaPassphrase returns the passphrase source of the local replica: the
+// configured passphrase or, failing that, a prompt on the terminal. Without
+// either the replica cannot be used.
+func ReplicaPassphrase(configured string) func() (string, error) {
+	return func() (string, error) {
+		if configured != "" {
+			return configured, nil
+		}
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return "", nil
+		}
+		return readSecret("Passphrase of the local replica: ")
+	}
+}
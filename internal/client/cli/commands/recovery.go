@@ -0,0 +1,224 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type RecoveryCLICommands struct {
+	s *client_services.RecoveryClientService
+}
+
+func NewRecoveryCLICommands(s *client_services.RecoveryClientService) *RecoveryCLICommands {
+	return &RecoveryCLICommands{s: s}
+}
+
+func (cmd *RecoveryCLICommands) Recovery() cli.Command {
+	return cli.Command{
+		Name:  "recovery",
+		Usage: "recovery setup|status|held|reveal|combine|remove — recover your key pair from shares held by people you trust",
+		Subcommands: []cli.Command{
+			{
+				Name:  "setup",
+				Usage: "passKeeper recovery setup --shares 5 --threshold 3 [--holder login ...] — split a recovery key into shares",
+				Flags: []cli.Flag{
+					cli.IntFlag{
+						Name:  "shares",
+						Value: 3,
+						Usage: "number of shares, at most 16",
+					},
+					cli.IntFlag{
+						Name:  "threshold",
+						Value: 2,
+						Usage: "number of shares that restore the key, at least 2",
+					},
+					cli.StringSliceFlag{
+						Name:  "holder",
+						Usage: "login of a user who keeps a share on their account, can be given several times; the other shares are printed",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					password, err := readSecret("Account password: ")
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+					defer cancel()
+
+					setup, mnemonics, err := cmd.s.Setup(ctx, password, c.Int("shares"), c.Int("threshold"), c.StringSlice("holder"))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("Recovery set up: any %d of %d shares restore your key pair.\n", setup.Threshold, setup.Shares)
+					if len(setup.Holders) > 0 {
+						fmt.Printf("Shares kept on the accounts of: %s\n", strings.Join(setup.Holders, ", "))
+					}
+					if len(mnemonics) > 0 {
+						fmt.Println("Print each of these shares and give it to a different person. They are not shown again:")
+						for i, m := range mnemonics {
+							fmt.Printf("\n  Share %d: %s\n", i+1, m)
+						}
+						fmt.Println()
+					}
+					fmt.Println("Earlier shares no longer work.")
+					return nil
+				},
+			},
+
+			{
+				Name:  "status",
+				Usage: "passKeeper recovery status — show your recovery setup",
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					setup, err := cmd.s.Status(ctx)
+					if errors.Is(err, api.ErrNoRecovery) {
+						fmt.Println("Account recovery is not set up.")
+						return nil
+					}
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					holders := "none"
+					if len(setup.Holders) > 0 {
+						holders = strings.Join(setup.Holders, ", ")
+					}
+					fmt.Printf("Any %d of %d shares restore your key pair. Holders on the server: %s. Set up %s.\n",
+						setup.Threshold, setup.Shares, holders, setup.CreatedAt.Format("2006-01-02 15:04:05"))
+					return nil
+				},
+			},
+
+			{
+				Name:  "held",
+				Usage: "passKeeper recovery held — list the recovery shares you keep for others",
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.Held(ctx)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Shares) == 0 {
+						fmt.Println("You keep no recovery shares.")
+						return nil
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintln(w, "UUID\tOWNER\tCREATED_AT")
+					for _, rec := range resp.Shares {
+						_, err := fmt.Fprintf(w, "%s\t%s\t%s\n", rec.ShareUUID, rec.Owner, rec.CreatedAt.Format("2006-01-02 15:04:05"))
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+					}
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+
+			{
+				Name:      "reveal",
+				Usage:     "passKeeper recovery reveal [share_uuid] — show a share you keep so you can hand it to its owner",
+				ArgsUsage: "[share_uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper recovery reveal [share_uuid]", 2)
+					}
+
+					password, err := readSecret("Account password: ")
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					held, mnemonic, err := cmd.s.Reveal(ctx, c.Args().Get(0), password)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("Recovery share of %s. Give it only to them, in person or over a channel you trust:\n\n  %s\n\n", held.Owner, mnemonic)
+					return nil
+				},
+			},
+
+			{
+				Name:  "combine",
+				Usage: "passKeeper recovery combine [--replace] — restore your key pair from shares, e.g. after a password reset",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "replace",
+						Usage: "replace a different key pair created since, entries shared with it are dropped",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+					defer cancel()
+
+					threshold, err := cmd.s.Threshold(ctx)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					mnemonics := make([]string, 0, threshold)
+					for i := 1; i <= threshold; i++ {
+						m, err := readLine(fmt.Sprintf("Share %d of %d: ", i, threshold))
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+						mnemonics = append(mnemonics, m)
+					}
+
+					password, err := readSecret("Current account password: ")
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					err = cmd.s.Combine(ctx, mnemonics, password, c.Bool("replace"))
+					if errors.Is(err, client_services.ErrKeyPairExists) {
+						return cli.NewExitError(err.Error()+"; use --replace to continue", 1)
+					}
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Key pair restored and wrapped with your current password.")
+					return nil
+				},
+			},
+
+			{
+				Name:  "remove",
+				Usage: "passKeeper recovery remove — delete your recovery setup, all shares stop working",
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.Remove(ctx); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Recovery setup removed.")
+					return nil
+				},
+			},
+		},
+	}
+}
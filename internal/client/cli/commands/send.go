@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+type SendCLICommands struct {
+	s *client_services.SendClientService
+}
+
+func NewSendCLICommands(s *client_services.SendClientService) *SendCLICommands {
+	return &SendCLICommands{s: s}
+}
+
+func (cmd *SendCLICommands) Send() cli.Command {
+	return cli.Command{
+		Name:      "send",
+		Usage:     "send [--expires 1h] [--max-views 1] [--text] [key_uuid|text] — create a one-time secret link",
+		ArgsUsage: "[key_uuid|text]",
+		Flags: []cli.Flag{
+			cli.DurationFlag{
+				Name:  "expires",
+				Usage: "lifetime of the link, at most 168h",
+				Value: time.Hour,
+			},
+			cli.IntFlag{
+				Name:  "max-views",
+				Usage: "number of times the link can be opened",
+				Value: 1,
+			},
+			cli.BoolFlag{
+				Name:  "text",
+				Usage: "send the argument as text even if it looks like an entry UUID",
+			},
+		},
+
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return cli.NewExitError("usage: passKeeper send [--expires 1h] [--max-views 1] [--text] [key_uuid|text]", 2)
+			}
+			arg := c.Args().Get(0)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			var (
+				link string
+				err  error
+			)
+			if _, perr := uuid.Parse(arg); perr == nil && !c.Bool("text") {
+				link, _, err = cmd.s.SendEntry(ctx, arg, c.Int("max-views"), c.Duration("expires"))
+			} else {
+				link, _, err = cmd.s.SendText(ctx, arg, c.Int("max-views"), c.Duration("expires"))
+			}
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			fmt.Println(link)
+			fmt.Println("Anyone with this link can open it with: passKeeper receive [link]")
+			return nil
+		},
+	}
+}
+
+func (cmd *SendCLICommands) Sends() cli.Command {
+	return cli.Command{
+		Name:  "sends",
+		Usage: "sends list|revoke — manage your secret links",
+		Subcommands: []cli.Command{
+			{
+				Name:  "list",
+				Usage: "passKeeper sends list — show your links that were not used up",
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.List(ctx)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Sends) == 0 {
+						fmt.Println("No secret links.")
+						return nil
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintf(w, "UUID\tVIEWS\tEXPIRES_AT\tCREATED_AT\n")
+
+					for _, rec := range resp.Sends {
+						_, err = fmt.Fprintf(
+							w,
+							"%s\t%d/%d\t%s\t%s\n",
+							rec.SendUUID,
+							rec.Views,
+							rec.MaxViews,
+							rec.ExpiresAt.Format("2006-01-02 15:04:05"),
+							rec.CreatedAt.Format("2006-01-02 15:04:05"),
+						)
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+					}
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+
+			{
+				Name:      "revoke",
+				Usage:     "passKeeper sends revoke [send_uuid]",
+				ArgsUsage: "[send_uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper sends revoke [send_uuid]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.Revoke(ctx, c.Args().Get(0)); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Link revoked.")
+					return nil
+				},
+			},
+		},
+	}
+}
+
+func (cmd *SendCLICommands) Receive() cli.Command {
+	return cli.Command{
+		Name:      "receive",
+		Usage:     "receive [link] — open a secret link, no account needed; this uses up one view",
+		ArgsUsage: "[link]",
+
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return cli.NewExitError("usage: passKeeper receive [link]", 2)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			secret, resp, err := cmd.s.Receive(ctx, c.Args().Get(0))
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			if secret.Entry != nil {
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				_, _ = fmt.Fprintln(w, "TYPE\tTITLE\tDATA")
+				if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", secret.Entry.KeyType, secret.Entry.Title, secret.Entry.Data); err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				_ = w.Flush()
+			} else {
+				fmt.Println(secret.Text)
+			}
+
+			if resp.ViewsLeft == 0 {
+				fmt.Fprintln(os.Stderr, "The link is used up and was deleted from the server.")
+			} else {
+				fmt.Fprintf(os.Stderr, "The link can be opened %d more time(s).\n", resp.ViewsLeft)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,256 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+type ShareCLICommands struct {
+	s *client_services.ShareClientService
+}
+
+func NewShareCLICommands(s *client_services.ShareClientService) *ShareCLICommands {
+	return &ShareCLICommands{s: s}
+}
+
+func (cmd *ShareCLICommands) Keys() cli.Command {
+	return cli.Command{
+		Name:  "keys",
+		Usage: "keys init — set up the key pair for receiving shared entries",
+		Subcommands: []cli.Command{
+			{
+				Name:  "init",
+				Usage: "passKeeper keys init [--replace] — generate a key pair, the private key is wrapped with your password",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "replace",
+						Usage: "replace an existing key pair, entries shared with you are dropped",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					password, err := readSecret("Account password: ")
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					err = cmd.s.InitKeys(ctx, password, c.Bool("replace"))
+					if errors.Is(err, client_services.ErrKeyPairExists) {
+						return cli.NewExitError(err.Error()+"; use --replace to continue", 1)
+					}
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Key pair created. Other users can now share entries with you.")
+					return nil
+				},
+			},
+		},
+	}
+}
+
+func (cmd *ShareCLICommands) Share() cli.Command {
+	return cli.Command{
+		Name:  "share",
+		Usage: "share add|list|revoke — share single entries end-to-end encrypted",
+		Subcommands: []cli.Command{
+			{
+				Name:      "add",
+				Usage:     "passKeeper share add [key_uuid] [login] — share the current content of an entry",
+				ArgsUsage: "[key_uuid] [login]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 2 {
+						return cli.NewExitError("usage: passKeeper share add [key_uuid] [login]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					rec, err := cmd.s.ShareEntry(ctx, c.Args().Get(0), c.Args().Get(1))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("Entry shared with %s. Share it again after editing to update their copy.\n", rec.To)
+					return nil
+				},
+			},
+
+			{
+				Name:      "list",
+				Usage:     "passKeeper share list [key_uuid] — show who an entry is shared with",
+				ArgsUsage: "[key_uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper share list [key_uuid]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.Recipients(ctx, c.Args().Get(0))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Shares) == 0 {
+						fmt.Println("The entry is not shared.")
+						return nil
+					}
+
+					return printShares(resp)
+				},
+			},
+
+			{
+				Name:      "revoke",
+				Usage:     "passKeeper share revoke [key_uuid] [login]",
+				ArgsUsage: "[key_uuid] [login]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 2 {
+						return cli.NewExitError("usage: passKeeper share revoke [key_uuid] [login]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.Revoke(ctx, c.Args().Get(0), c.Args().Get(1)); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Share revoked.")
+					return nil
+				},
+			},
+		},
+	}
+}
+
+func (cmd *ShareCLICommands) Shared() cli.Command {
+	return cli.Command{
+		Name:  "shared",
+		Usage: "shared list|get|dismiss — entries other users shared with you",
+		Subcommands: []cli.Command{
+			{
+				Name:  "list",
+				Usage: "passKeeper shared list",
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.SharedWithMe(ctx)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Shares) == 0 {
+						fmt.Println("Nothing is shared with you.")
+						return nil
+					}
+
+					return printShares(resp)
+				},
+			},
+
+			{
+				Name:      "get",
+				Usage:     "passKeeper shared get [share_uuid] — decrypt a shared entry",
+				ArgsUsage: "[share_uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper shared get [share_uuid]", 2)
+					}
+
+					password, err := readSecret("Account password: ")
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					shared, entry, err := cmd.s.Open(ctx, c.Args().Get(0), password)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintln(w, "FROM\tTYPE\tTITLE\tDATA\tSHARED_AT")
+
+					_, err = fmt.Fprintf(
+						w,
+						"%s\t%s\t%s\t%s\t%s\n",
+						shared.From,
+						entry.KeyType,
+						entry.Title,
+						entry.Data,
+						shared.CreatedAt.Format("2006-01-02 15:04:05"),
+					)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+
+			{
+				Name:      "dismiss",
+				Usage:     "passKeeper shared dismiss [share_uuid] — remove an entry shared with you",
+				ArgsUsage: "[share_uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper shared dismiss [share_uuid]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.Dismiss(ctx, c.Args().Get(0)); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Shared entry dismissed.")
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// printShares prints shares as a table.
+func printShares(resp *dto.SharesResponse) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "UUID\tKEY_UUID\tTYPE\tTITLE\tFROM\tTO\tSHARED_AT\n")
+
+	for _, rec := range resp.Shares {
+		_, err := fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			rec.ShareUUID,
+			rec.KeyUUID,
+			rec.KeyType,
+			rec.Title,
+			rec.From,
+			rec.To,
+			rec.CreatedAt.Format("2006-01-02 15:04:05"),
+		)
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+	}
+
+	_ = w.Flush()
+	return nil
+}
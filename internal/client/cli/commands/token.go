@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type TokenCLICommands struct {
+	s *client_services.TokenClientService
+}
+
+func NewTokenCLICommands(s *client_services.TokenClientService) *TokenCLICommands {
+	return &TokenCLICommands{s: s}
+}
+
+func (cmd *TokenCLICommands) Token() cli.Command {
+	return cli.Command{
+		Name:  "token",
+		Usage: "token create|list|revoke — manage personal access tokens for automation",
+		Subcommands: []cli.Command{
+			{
+				Name:      "create",
+				Usage:     "passKeeper token create --scope read:tag:prod [--scope ...] [--days N] [name]",
+				ArgsUsage: "[name]",
+				Flags: []cli.Flag{
+					cli.StringSliceFlag{
+						Name:  "scope",
+						Usage: "granted scope <read|write>[:<type>][:tag:<tag>], can be repeated",
+					},
+					cli.IntFlag{
+						Name:  "days",
+						Usage: "token lifetime in days, 0 for a token that never expires",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper token create --scope <scope> [--days N] [name]", 2)
+					}
+					if c.Int("days") < 0 {
+						return cli.NewExitError("--days must not be negative", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					ttl := time.Duration(c.Int("days")) * 24 * time.Hour
+
+					resp, err := cmd.s.Create(ctx, c.Args().Get(0), c.StringSlice("scope"), ttl)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("Token %s created. Copy it now, it will not be shown again:\n%s\n", resp.TokenUUID, resp.Token)
+					fmt.Println("Use it by setting PASSKEEPER_TOKEN in the environment.")
+					return nil
+				},
+			},
+
+			{
+				Name:  "list",
+				Usage: "passKeeper token list — show personal access tokens",
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.List(ctx)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Tokens) == 0 {
+						fmt.Println("No personal access tokens.")
+						return nil
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintf(w, "UUID\tNAME\tSCOPES\tEXPIRES_AT\tLAST_USED_AT\n")
+
+					for _, rec := range resp.Tokens {
+						_, err = fmt.Fprintf(
+							w,
+							"%s\t%s\t%s\t%s\t%s\n",
+							rec.TokenUUID,
+							rec.Name,
+							strings.Join(rec.Scopes, " "),
+							formatOptionalTime(rec.ExpiresAt, "never"),
+							formatOptionalTime(rec.LastUsedAt, "-"),
+						)
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+					}
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+
+			{
+				Name:      "revoke",
+				Usage:     "passKeeper token revoke [uuid] — revoke a personal access token",
+				ArgsUsage: "[uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper token revoke [uuid]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.Revoke(ctx, c.Args().Get(0)); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Token revoked.")
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// formatOptionalTime formats t in local time or returns fallback when t is nil.
+func formatOptionalTime(t *time.Time, fallback string) string {
+	if t == nil {
+		return fallback
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,329 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+type VaultCLICommands struct {
+	s *client_services.VaultClientService
+}
+
+func NewVaultCLICommands(s *client_services.VaultClientService) *VaultCLICommands {
+	return &VaultCLICommands{s: s}
+}
+
+// roleFlag is the role a member gets in a vault or an organization.
+var roleFlag = cli.StringFlag{
+	Name:  "role",
+	Usage: "role: owner, admin, editor or viewer",
+	Value: "viewer",
+}
+
+func (cmd *VaultCLICommands) Vault() cli.Command {
+	return cli.Command{
+		Name:  "vault",
+		Usage: "vault create|list|share|unshare|members — manage shared vaults",
+		Subcommands: []cli.Command{
+			{
+				Name:      "create",
+				Usage:     "passKeeper vault create [--org UUID] [name]",
+				ArgsUsage: "[name]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "org",
+						Usage: "organization the vault belongs to",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper vault create [--org UUID] [name]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					v, err := cmd.s.CreateVault(ctx, c.Args().Get(0), c.String("org"))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("Vault %s created.\n", v.VaultUUID)
+					fmt.Printf("Add entries to it with: passKeeper add text --vault %s [title] [text]\n", v.VaultUUID)
+					return nil
+				},
+			},
+
+			{
+				Name:  "list",
+				Usage: "passKeeper vault list — show vaults you have a role in",
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.ListVaults(ctx)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Vaults) == 0 {
+						fmt.Println("No shared vaults.")
+						return nil
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintf(w, "UUID\tNAME\tROLE\tORG\tCREATED_AT\n")
+
+					for _, rec := range resp.Vaults {
+						org := "-"
+						if rec.OrgUUID != nil {
+							org = rec.OrgUUID.String()
+						}
+
+						_, err = fmt.Fprintf(
+							w,
+							"%s\t%s\t%s\t%s\t%s\n",
+							rec.VaultUUID,
+							rec.Name,
+							rec.Role,
+							org,
+							rec.CreatedAt.Format("2006-01-02 15:04:05"),
+						)
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+					}
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+
+			{
+				Name:      "share",
+				Usage:     "passKeeper vault share [--role ROLE] [vault_uuid] [login]",
+				ArgsUsage: "[vault_uuid] [login]",
+				Flags:     []cli.Flag{roleFlag},
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 2 {
+						return cli.NewExitError("usage: passKeeper vault share [--role ROLE] [vault_uuid] [login]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					login := c.Args().Get(1)
+					if err := cmd.s.Share(ctx, c.Args().Get(0), login, c.String("role")); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("%s is now %s of the vault.\n", login, c.String("role"))
+					return nil
+				},
+			},
+
+			{
+				Name:      "unshare",
+				Usage:     "passKeeper vault unshare [vault_uuid] [login]",
+				ArgsUsage: "[vault_uuid] [login]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 2 {
+						return cli.NewExitError("usage: passKeeper vault unshare [vault_uuid] [login]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.Unshare(ctx, c.Args().Get(0), c.Args().Get(1)); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Member removed.")
+					return nil
+				},
+			},
+
+			{
+				Name:      "members",
+				Usage:     "passKeeper vault members [vault_uuid]",
+				ArgsUsage: "[vault_uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper vault members [vault_uuid]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.Members(ctx, c.Args().Get(0))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					return printMembers(resp)
+				},
+			},
+		},
+	}
+}
+
+func (cmd *VaultCLICommands) Org() cli.Command {
+	return cli.Command{
+		Name:  "org",
+		Usage: "org create|list|members|add|remove — manage organizations",
+		Subcommands: []cli.Command{
+			{
+				Name:      "create",
+				Usage:     "passKeeper org create [name]",
+				ArgsUsage: "[name]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper org create [name]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					org, err := cmd.s.CreateOrganization(ctx, c.Args().Get(0))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("Organization %s created.\n", org.OrgUUID)
+					fmt.Printf("Create vaults in it with: passKeeper vault create --org %s [name]\n", org.OrgUUID)
+					return nil
+				},
+			},
+
+			{
+				Name:  "list",
+				Usage: "passKeeper org list — show organizations you are a member of",
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.ListOrganizations(ctx)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Organizations) == 0 {
+						fmt.Println("No organizations.")
+						return nil
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintf(w, "UUID\tNAME\tROLE\tCREATED_AT\n")
+
+					for _, rec := range resp.Organizations {
+						_, err = fmt.Fprintf(
+							w,
+							"%s\t%s\t%s\t%s\n",
+							rec.OrgUUID,
+							rec.Name,
+							rec.Role,
+							rec.CreatedAt.Format("2006-01-02 15:04:05"),
+						)
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+					}
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+
+			{
+				Name:      "members",
+				Usage:     "passKeeper org members [org_uuid]",
+				ArgsUsage: "[org_uuid]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: passKeeper org members [org_uuid]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.OrganizationMembers(ctx, c.Args().Get(0))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					return printMembers(resp)
+				},
+			},
+
+			{
+				Name:      "add",
+				Usage:     "passKeeper org add [--role ROLE] [org_uuid] [login] — add a member or change their role",
+				ArgsUsage: "[org_uuid] [login]",
+				Flags:     []cli.Flag{roleFlag},
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 2 {
+						return cli.NewExitError("usage: passKeeper org add [--role ROLE] [org_uuid] [login]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					login := c.Args().Get(1)
+					if err := cmd.s.SetOrganizationMember(ctx, c.Args().Get(0), login, c.String("role")); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("%s is now %s of the organization.\n", login, c.String("role"))
+					return nil
+				},
+			},
+
+			{
+				Name:      "remove",
+				Usage:     "passKeeper org remove [org_uuid] [login]",
+				ArgsUsage: "[org_uuid] [login]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 2 {
+						return cli.NewExitError("usage: passKeeper org remove [org_uuid] [login]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.RemoveOrganizationMember(ctx, c.Args().Get(0), c.Args().Get(1)); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Member removed.")
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// printMembers prints the members of a vault or an organization as a table.
+func printMembers(resp *dto.MembersResponse) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "LOGIN\tROLE\tSINCE\n")
+
+	for _, rec := range resp.Members {
+		role := rec.Role
+		if rec.Inherited {
+			role += " (org)"
+		}
+
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", rec.Login, role, rec.CreatedAt.Format("2006-01-02 15:04:05")); err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+	}
+
+	_ = w.Flush()
+	return nil
+}
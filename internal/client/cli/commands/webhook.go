@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/client/client_services"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type WebhookCLICommands struct {
+	s    *client_services.WebhookClientService
+	prog string
+}
+
+func NewWebhookCLICommands(s *client_services.WebhookClientService) *WebhookCLICommands {
+	return &WebhookCLICommands{s: s, prog: "passKeeper"}
+}
+
+// NewGlobalWebhookCLICommands returns the webhook commands of
+// passkeeper-admin, which manage the global subscriptions receiving the
+// events of every user. s must use api.NewGlobalWebhooksAPI.
+func NewGlobalWebhookCLICommands(s *client_services.WebhookClientService) *WebhookCLICommands {
+	return &WebhookCLICommands{s: s, prog: "passkeeper-admin"}
+}
+
+func (cmd *WebhookCLICommands) Webhooks() cli.Command {
+	return cli.Command{
+		Name:  "webhooks",
+		Usage: "webhooks add|list|remove|deliveries — push security and vault events to an HTTPS endpoint",
+		Subcommands: []cli.Command{
+			{
+				Name:      "add",
+				Usage:     cmd.prog + " webhooks add --event key.read [--event ...] [--tag prod] [url]",
+				ArgsUsage: "[url]",
+				Flags: []cli.Flag{
+					cli.StringSliceFlag{
+						Name:  "event",
+						Usage: "event to send: login.locked, refresh.replayed, key.read, key.delete, emergency.requested, emergency.approved or emergency.rejected, can be repeated",
+					},
+					cli.StringSliceFlag{
+						Name:  "tag",
+						Usage: "only send entry events for entries with this tag, can be repeated",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: "+cmd.prog+" webhooks add --event <event> [--tag <tag>] [url]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.Create(ctx, c.Args().Get(0), c.StringSlice("event"), c.StringSlice("tag"))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Printf("Webhook %s created. Copy its signing secret now, it will not be shown again:\n%s\n", resp.ID, resp.Secret)
+					fmt.Println("Deliveries carry X-PassKeeper-Signature: t=<unix>,v1=<hex HMAC-SHA256 of \"<unix>.<body>\">.")
+					return nil
+				},
+			},
+
+			{
+				Name:  "list",
+				Usage: cmd.prog + " webhooks list — show webhook subscriptions",
+				Action: func(c *cli.Context) error {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.List(ctx)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Webhooks) == 0 {
+						fmt.Println("No webhooks.")
+						return nil
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintf(w, "ID\tURL\tEVENTS\tTAGS\tCREATED_AT\n")
+
+					for _, rec := range resp.Webhooks {
+						tags := "-"
+						if len(rec.Tags) > 0 {
+							tags = strings.Join(rec.Tags, " ")
+						}
+
+						_, err = fmt.Fprintf(
+							w,
+							"%s\t%s\t%s\t%s\t%s\n",
+							rec.ID,
+							rec.URL,
+							strings.Join(rec.Events, " "),
+							tags,
+							formatOptionalTime(&rec.CreatedAt, "-"),
+						)
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+					}
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+
+			{
+				Name:      "remove",
+				Usage:     cmd.prog + " webhooks remove [id] — delete a webhook and drop its pending deliveries",
+				ArgsUsage: "[id]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: "+cmd.prog+" webhooks remove [id]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					if err := cmd.s.Delete(ctx, c.Args().Get(0)); err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					fmt.Println("Webhook removed.")
+					return nil
+				},
+			},
+
+			{
+				Name:      "deliveries",
+				Usage:     cmd.prog + " webhooks deliveries [id] — show the latest deliveries of a webhook",
+				ArgsUsage: "[id]",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.NewExitError("usage: "+cmd.prog+" webhooks deliveries [id]", 2)
+					}
+
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+
+					resp, err := cmd.s.Deliveries(ctx, c.Args().Get(0))
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+
+					if len(resp.Deliveries) == 0 {
+						fmt.Println("No deliveries.")
+						return nil
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintf(w, "ID\tEVENT\tSTATUS\tATTEMPTS\tLAST_RESPONSE\tNEXT_ATTEMPT_AT\tCREATED_AT\n")
+
+					for _, rec := range resp.Deliveries {
+						last := "-"
+						switch {
+						case rec.LastStatusCode != 0:
+							last = fmt.Sprintf("HTTP %d", rec.LastStatusCode)
+						case rec.LastError != "":
+							last = rec.LastError
+						}
+
+						_, err = fmt.Fprintf(
+							w,
+							"%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+							rec.ID,
+							rec.Event,
+							rec.Status,
+							rec.Attempts,
+							last,
+							formatOptionalTime(rec.NextAttemptAt, "-"),
+							formatOptionalTime(&rec.CreatedAt, "-"),
+						)
+						if err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+					}
+
+					_ = w.Flush()
+					return nil
+				},
+			},
+		},
+	}
+}
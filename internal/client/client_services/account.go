@@ -0,0 +1,64 @@
+package client_services
+
+import (
+	"context"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/client/token"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"os"
+)
+
+// AccountClientService exposes account-wide operations to the CLI layer.
+type AccountClientService struct {
+	API    *api.AccountAPI
+	Client *client_http.Client
+}
+
+// NewAccountClientService constructs a new AccountClientService.
+func NewAccountClientService(api *api.AccountAPI, httpClient *client_http.Client) *AccountClientService {
+	return &AccountClientService{
+		API:    api,
+		Client: httpClient,
+	}
+}
+
+// Export downloads the personal data archive into filePath. The file is
+// created with owner-only permissions and removed if the download fails.
+func (s *AccountClientService) Export(ctx context.Context, password, filePath string) error {
+	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	in := &dto.AccountPasswordRequest{
+		Password: password,
+	}
+
+	if err := s.API.Export(ctx, in, f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(filePath)
+		return err
+	}
+
+	return f.Close()
+}
+
+// Delete removes the account on the server and clears locally stored tokens.
+func (s *AccountClientService) Delete(ctx context.Context, password string) error {
+	in := &dto.AccountPasswordRequest{
+		Password: password,
+	}
+
+	if err := s.API.Delete(ctx, in); err != nil {
+		return err
+	}
+
+	return token.DeleteTokens()
+}
+
+// ChangeEmail sets a new email address for the account; it has to be verified
+// with the token mailed to it.
+func (s *AccountClientService) ChangeEmail(ctx context.Context, email string) error {
+	return s.API.ChangeEmail(ctx, &dto.ChangeEmailRequest{Email: email})
+}
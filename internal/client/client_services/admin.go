@@ -0,0 +1,52 @@
+package client_services
+
+import (
+	"context"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+)
+
+// AdminClientService exposes user administration to the admin CLI.
+type AdminClientService struct {
+	API    *api.AdminAPI
+	Client *client_http.Client
+}
+
+// NewAdminClientService constructs a new AdminClientService.
+func NewAdminClientService(api *api.AdminAPI, httpClient *client_http.Client) *AdminClientService {
+	return &AdminClientService{
+		API:    api,
+		Client: httpClient,
+	}
+}
+
+// ListUsers returns a page of users.
+func (s *AdminClientService) ListUsers(ctx context.Context, limit int, offset int) (*dto.AdminUsersResponse, error) {
+	return s.API.ListUsers(ctx, limit, offset)
+}
+
+// Disable disables the user and ends their sessions.
+func (s *AdminClientService) Disable(ctx context.Context, userID int64) error {
+	return s.API.Disable(ctx, userID)
+}
+
+// Enable enables a disabled user.
+func (s *AdminClientService) Enable(ctx context.Context, userID int64) error {
+	return s.API.Enable(ctx, userID)
+}
+
+// Logout revokes every session of the user.
+func (s *AdminClientService) Logout(ctx context.Context, userID int64) error {
+	return s.API.Logout(ctx, userID)
+}
+
+// ResetSecondFactor removes every device of the user.
+func (s *AdminClientService) ResetSecondFactor(ctx context.Context, userID int64) (*dto.AdminResetSecondFactorResponse, error) {
+	return s.API.ResetSecondFactor(ctx, userID)
+}
+
+// Stats returns the storage stats of the user.
+func (s *AdminClientService) Stats(ctx context.Context, userID int64) (*dto.AdminUserStatsResponse, error) {
+	return s.API.Stats(ctx, userID)
+}
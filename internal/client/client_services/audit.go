@@ -0,0 +1,55 @@
+package client_services
+
+import (
+	"context"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/url"
+	"strconv"
+)
+
+// AuditQuery filters the audit log. Empty fields and a zero Limit are not sent.
+type AuditQuery struct {
+	Action string
+	Key    string
+	Result string
+	Since  string
+	Until  string
+	Limit  int
+}
+
+// AuditClientService exposes the audit log to the CLI layer.
+type AuditClientService struct {
+	API    *api.AuditAPI
+	Client *client_http.Client
+}
+
+// NewAuditClientService constructs a new AuditClientService.
+func NewAuditClientService(api *api.AuditAPI, httpClient *client_http.Client) *AuditClientService {
+	return &AuditClientService{
+		API:    api,
+		Client: httpClient,
+	}
+}
+
+// List returns the audit events of the current user matching q, newest first.
+func (s *AuditClientService) List(ctx context.Context, q AuditQuery) (*dto.AuditEventsResponse, error) {
+	query := url.Values{}
+	for name, value := range map[string]string{
+		"action": q.Action,
+		"key":    q.Key,
+		"result": q.Result,
+		"since":  q.Since,
+		"until":  q.Until,
+	} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	return s.API.List(ctx, query)
+}
@@ -2,6 +2,7 @@ package client_services
 
 import (
 	"context"
+	"fmt"
 	"github.com/thxhix/passKeeper/internal/client/api"
 	"github.com/thxhix/passKeeper/internal/client/token"
 	"github.com/thxhix/passKeeper/internal/transport/client_http"
@@ -27,12 +28,32 @@ func NewAuthClientService(api *api.AuthAPI, httpClient *client_http.Client) *Aut
 }
 
 // Register registers a new user on the server and persists returned tokens
-// using the token package. On success the tokens are stored; on failure the
-// returned error is propagated.
-func (s *AuthClientService) Register(ctx context.Context, login, password string) error {
+// using the token package. email is optional; the server mails a verification
+// token to it. inviteCode is required when the server only admits invited
+// users and may be empty otherwise. This device becomes the first
+// trusted device of the account. On success the tokens are stored; on failure
+// the returned error is propagated.
+//
+// A server in uniform response mode accepts the registration without tokens
+// and without telling whether the login was free, so the client logs in
+// right after it.
+func (s *AuthClientService) Register(ctx context.Context, login, password, email, inviteCode string) error {
+	device, err := token.LoadOrCreateDevice()
+	if err != nil {
+		return err
+	}
+
+	info, err := s.deviceInfo(ctx, device, "")
+	if err != nil {
+		return err
+	}
+
 	in := &dto.RegisterRequest{
 		Login:    login,
 		Password: password,
+		Email:    email,
+		Invite:   inviteCode,
+		Device:   info,
 	}
 
 	tokens, err := s.API.Register(ctx, in)
@@ -40,6 +61,13 @@ func (s *AuthClientService) Register(ctx context.Context, login, password string
 		return err
 	}
 
+	if tokens.AccessToken == "" {
+		if err := s.Login(ctx, login, password, ""); err != nil {
+			return fmt.Errorf("registration accepted, but logging in failed (the login or email may already be taken): %w", err)
+		}
+		return nil
+	}
+
 	keyRingTokens := token.Tokens{
 		Access:  tokens.AccessToken,
 		Refresh: tokens.RefreshToken,
@@ -54,12 +82,25 @@ func (s *AuthClientService) Register(ctx context.Context, login, password string
 }
 
 // Login authenticates the user and persists received tokens
-// using the token package. On success the tokens are stored; on failure the
-// returned error is propagated.
-func (s *AuthClientService) Login(ctx context.Context, login, password string) error {
+// using the token package. approvalCode is the one-time code for a device
+// awaiting approval and may be empty. If the device still needs approval the
+// error wraps api.ErrDeviceApprovalRequired. On success the tokens are
+// stored; on failure the returned error is propagated.
+func (s *AuthClientService) Login(ctx context.Context, login, password, approvalCode string) error {
+	device, err := token.LoadOrCreateDevice()
+	if err != nil {
+		return err
+	}
+
+	info, err := s.deviceInfo(ctx, device, approvalCode)
+	if err != nil {
+		return err
+	}
+
 	in := &dto.LoginRequest{
 		Login:    login,
 		Password: password,
+		Device:   info,
 	}
 
 	tokens, err := s.API.Login(ctx, in)
@@ -108,3 +149,109 @@ func (s *AuthClientService) RefreshToken(ctx context.Context) error {
 
 	return nil
 }
+
+// ChangePassword replaces the account password and persists the new pair of
+// tokens returned by the server. Sessions on other devices are terminated by
+// the server. keyMaterial is the private sharing key rewrapped with the new
+// password and is stored in the same request; nil keeps the stored one. The
+// new tokens are bound to the local device, which proves its key like on
+// login.
+func (s *AuthClientService) ChangePassword(ctx context.Context, currentPassword, newPassword string, keyMaterial []byte) error {
+	device, err := token.LoadOrCreateDevice()
+	if err != nil {
+		return err
+	}
+
+	info, err := s.deviceInfo(ctx, device, "")
+	if err != nil {
+		return err
+	}
+
+	in := &dto.ChangePasswordRequest{
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+		KeyMaterial:     keyMaterial,
+		Device:          info,
+	}
+
+	tokens, err := s.API.ChangePassword(ctx, in)
+	if err != nil {
+		return err
+	}
+
+	keyRingTokens := token.Tokens{
+		Access:  tokens.AccessToken,
+		Refresh: tokens.RefreshToken,
+	}
+
+	err = token.SaveTokens(keyRingTokens)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Logout terminates all sessions on the server and removes the locally stored
+// tokens. Local tokens are removed even if the server call fails, so the
+// client never keeps credentials the user asked to discard.
+func (s *AuthClientService) Logout(ctx context.Context) error {
+	apiErr := s.API.Logout(ctx)
+
+	if err := token.DeleteTokens(); err != nil {
+		return err
+	}
+
+	return apiErr
+}
+
+// deviceInfo describes the local device in registration, login and password
+// change requests, answering a fresh server challenge with the device key.
+func (s *AuthClientService) deviceInfo(ctx context.Context, device token.Device, approvalCode string) (dto.DeviceInfo, error) {
+	challenge, err := s.API.DeviceChallenge(ctx, device.ID.String())
+	if err != nil {
+		return dto.DeviceInfo{}, err
+	}
+
+	signature, err := device.Sign(challenge.Nonce)
+	if err != nil {
+		return dto.DeviceInfo{}, err
+	}
+
+	return dto.DeviceInfo{
+		ID:           device.ID,
+		Name:         device.Name,
+		PublicKey:    device.PublicKey,
+		Nonce:        challenge.Nonce,
+		Signature:    signature,
+		ApprovalCode: approvalCode,
+	}, nil
+}
+
+// VerifyEmail confirms the email address of the account with the token mailed
+// to it.
+func (s *AuthClientService) VerifyEmail(ctx context.Context, verificationToken string) error {
+	return s.API.VerifyEmail(ctx, &dto.VerifyEmailRequest{Token: verificationToken})
+}
+
+// ForgotPassword asks the server to mail a password reset token to email.
+func (s *AuthClientService) ForgotPassword(ctx context.Context, email string) error {
+	return s.API.ForgotPassword(ctx, &dto.ForgotPasswordRequest{Email: email})
+}
+
+// ResetPassword sets a new password with a mailed reset token. discardVaultKey
+// confirms that a zero-knowledge vault key may be discarded; without it such a
+// reset fails with an error wrapping api.ErrVaultKeyWillBeLost. Returns whether
+// a vault key was discarded.
+func (s *AuthClientService) ResetPassword(ctx context.Context, resetToken, newPassword string, discardVaultKey bool) (bool, error) {
+	resp, err := s.API.ResetPassword(ctx, &dto.ResetPasswordRequest{
+		Token:           resetToken,
+		NewPassword:     newPassword,
+		DiscardVaultKey: discardVaultKey,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return resp.VaultKeyDiscarded, nil
+}
@@ -32,6 +32,10 @@ func TestAuthClientService_RegisterAndLoginAndRefresh(t *testing.T) {
 	// test server that handles register, login, refresh endpoints
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
+		case "/api/auth/device/challenge":
+			_ = json.NewEncoder(w).Encode(dto.DeviceChallengeResponse{Nonce: "nonce"})
+			return
+
 		case "/api/auth/register":
 			// read request body to ensure it's valid JSON
 			var in dto.RegisterRequest
@@ -95,7 +99,7 @@ func TestAuthClientService_RegisterAndLoginAndRefresh(t *testing.T) {
 	defer cancel()
 
 	// 1) Register: should save tokens returned by server
-	if err := authSvc.Register(ctx, "user-reg", "pass"); err != nil {
+	if err := authSvc.Register(ctx, "user-reg", "pass", "", ""); err != nil {
 		t.Fatalf("Register failed: %v", err)
 	}
 	tok, err := token.LoadTokens()
@@ -107,7 +111,7 @@ func TestAuthClientService_RegisterAndLoginAndRefresh(t *testing.T) {
 	}
 
 	// 2) Login: should overwrite tokens with login's tokens
-	if err := authSvc.Login(ctx, "user-log", "pass"); err != nil {
+	if err := authSvc.Login(ctx, "user-log", "pass", ""); err != nil {
 		t.Fatalf("Login failed: %v", err)
 	}
 	tok, err = token.LoadTokens()
@@ -0,0 +1,43 @@
+package client_services
+
+import (
+	"context"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/client/token"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+)
+
+// DeviceClientService exposes device management to the CLI layer.
+type DeviceClientService struct {
+	API    *api.DevicesAPI
+	Client *client_http.Client
+}
+
+// NewDeviceClientService constructs a new DeviceClientService.
+func NewDeviceClientService(api *api.DevicesAPI, httpClient *client_http.Client) *DeviceClientService {
+	return &DeviceClientService{
+		API:    api,
+		Client: httpClient,
+	}
+}
+
+// Current returns the identity of this device.
+func (s *DeviceClientService) Current() (token.Device, error) {
+	return token.LoadOrCreateDevice()
+}
+
+// List returns the trusted and pending devices of the current user.
+func (s *DeviceClientService) List(ctx context.Context) (*dto.DevicesResponse, error) {
+	return s.API.List(ctx)
+}
+
+// Approve trusts the pending device with the given ID.
+func (s *DeviceClientService) Approve(ctx context.Context, deviceID string) error {
+	return s.API.Approve(ctx, deviceID)
+}
+
+// Remove deletes the device with the given ID.
+func (s *DeviceClientService) Remove(ctx context.Context, deviceID string) error {
+	return s.API.Remove(ctx, deviceID)
+}
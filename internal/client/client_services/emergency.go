@@ -0,0 +1,93 @@
+package client_services
+
+import (
+	"context"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"time"
+)
+
+// EmergencyClientService exposes emergency access to the CLI layer: naming
+// trusted contacts and deciding on their requests as grantor, and requesting
+// and using access as contact.
+type EmergencyClientService struct {
+	API    *api.EmergencyAPI
+	Client *client_http.Client
+}
+
+// NewEmergencyClientService constructs a new EmergencyClientService.
+func NewEmergencyClientService(api *api.EmergencyAPI, httpClient *client_http.Client) *EmergencyClientService {
+	return &EmergencyClientService{
+		API:    api,
+		Client: httpClient,
+	}
+}
+
+// Nominate names login as emergency contact with access after wait; a zero
+// wait uses the server default.
+func (s *EmergencyClientService) Nominate(ctx context.Context, login, access string, wait time.Duration) (*dto.EmergencyContactRecord, error) {
+	return s.API.Nominate(ctx, &dto.NominateEmergencyContactRequest{
+		Login:       login,
+		Access:      access,
+		WaitSeconds: int64(wait / time.Second),
+	})
+}
+
+// Contacts returns the emergency contacts of the current user.
+func (s *EmergencyClientService) Contacts(ctx context.Context) (*dto.EmergencyContactsResponse, error) {
+	return s.API.Contacts(ctx)
+}
+
+// Approve grants a pending request of a contact before its waiting period ends.
+func (s *EmergencyClientService) Approve(ctx context.Context, contactUUID string) (*dto.EmergencyContactRecord, error) {
+	return s.API.Approve(ctx, contactUUID)
+}
+
+// Reject rejects a request of a contact or withdraws its granted access.
+func (s *EmergencyClientService) Reject(ctx context.Context, contactUUID string) (*dto.EmergencyContactRecord, error) {
+	return s.API.Reject(ctx, contactUUID)
+}
+
+// Remove removes an emergency contact of the current user.
+func (s *EmergencyClientService) Remove(ctx context.Context, contactUUID string) error {
+	return s.API.RemoveContact(ctx, contactUUID)
+}
+
+// Grantors returns the accounts that named the current user as emergency contact.
+func (s *EmergencyClientService) Grantors(ctx context.Context) (*dto.EmergencyContactsResponse, error) {
+	return s.API.Grantors(ctx)
+}
+
+// Request requests access to the account of the grantor of a contact.
+func (s *EmergencyClientService) Request(ctx context.Context, contactUUID string) (*dto.EmergencyContactRecord, error) {
+	return s.API.Request(ctx, contactUUID)
+}
+
+// Keys lists the entries of the grantor of a contact with granted access.
+func (s *EmergencyClientService) Keys(ctx context.Context, contactUUID, keyType string) (*dto.GetKeysResponse, error) {
+	return s.API.Keys(ctx, contactUUID, keyType)
+}
+
+// Key returns an entry of the grantor of a contact with granted access.
+func (s *EmergencyClientService) Key(ctx context.Context, contactUUID, keyUUID string) (*dto.GetKeyResponse, error) {
+	return s.API.Key(ctx, contactUUID, keyUUID)
+}
+
+// Takeover sets a new password for the account of the grantor of a contact
+// with granted takeover access. It reports whether a vault key was discarded.
+func (s *EmergencyClientService) Takeover(ctx context.Context, contactUUID, newPassword string, discardVaultKey bool) (bool, error) {
+	resp, err := s.API.Takeover(ctx, contactUUID, &dto.EmergencyTakeoverRequest{
+		NewPassword:     newPassword,
+		DiscardVaultKey: discardVaultKey,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.VaultKeyDiscarded, nil
+}
+
+// StepDown removes the current user as emergency contact of a grantor.
+func (s *EmergencyClientService) StepDown(ctx context.Context, contactUUID string) error {
+	return s.API.StepDown(ctx, contactUUID)
+}
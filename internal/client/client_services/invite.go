@@ -0,0 +1,45 @@
+package client_services
+
+import (
+	"context"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"time"
+)
+
+// InviteClientService exposes invite code management to the CLI layer.
+type InviteClientService struct {
+	API    *api.InvitesAPI
+	Client *client_http.Client
+}
+
+// NewInviteClientService constructs a new InviteClientService.
+func NewInviteClientService(api *api.InvitesAPI, httpClient *client_http.Client) *InviteClientService {
+	return &InviteClientService{
+		API:    api,
+		Client: httpClient,
+	}
+}
+
+// Create issues an invite code usable maxUses times. login and email, when
+// not empty, are the only login and the only email the invite registers; a
+// zero ttl selects the server default lifetime.
+func (s *InviteClientService) Create(ctx context.Context, login string, email string, maxUses int, ttl time.Duration) (*dto.CreateInviteResponse, error) {
+	return s.API.Create(ctx, &dto.CreateInviteRequest{
+		Login:            login,
+		Email:            email,
+		MaxUses:          maxUses,
+		ExpiresInSeconds: int64(ttl / time.Second),
+	})
+}
+
+// List returns the invites created by the current user.
+func (s *InviteClientService) List(ctx context.Context) (*dto.InvitesResponse, error) {
+	return s.API.List(ctx)
+}
+
+// Revoke deletes the invite with the given UUID.
+func (s *InviteClientService) Revoke(ctx context.Context, inviteUUID string) error {
+	return s.API.Revoke(ctx, inviteUUID)
+}
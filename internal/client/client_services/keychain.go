@@ -2,31 +2,56 @@ package client_services
 
 import (
 	"context"
+	"errors"
+	"github.com/google/uuid"
 	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/client/replica"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
 	"github.com/thxhix/passKeeper/internal/transport/client_http"
 	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"os"
+	"sync"
 )
 
 // KeychainClientService is a thin service that exposes keychain-related
 // operations to the CLI layer.
+//
+// With a Replica the entries are also kept in an encrypted local replica:
+// they are read from it when the server is unreachable, and entries deleted
+// meanwhile are queued in it until the next sync. Personal entries may also
+// be added offline; adding to a shared vault always needs the server.
+//
+// Other client components can follow the changes made on the server through
+// the hooks registered with OnChange, which are called while Watch runs.
 type KeychainClientService struct {
-	API    *api.KeychainAPI
-	Client *client_http.Client
+	API     *api.KeychainAPI
+	Client  *client_http.Client
+	Replica *replica.Store
+
+	hooksMu sync.Mutex
+	hooks   []*keychainHook
 }
 
-// NewKeychainClientService constructs a new KeychainClientService.
-func NewKeychainClientService(api *api.KeychainAPI, httpClient *client_http.Client) *KeychainClientService {
+// NewKeychainClientService constructs a new KeychainClientService. store may
+// be nil to work without a local replica.
+func NewKeychainClientService(api *api.KeychainAPI, httpClient *client_http.Client, store *replica.Store) *KeychainClientService {
 	return &KeychainClientService{
-		API:    api,
-		Client: httpClient,
+		API:     api,
+		Client:  httpClient,
+		Replica: store,
 	}
 }
 
-// AddCredential sends a credential entry to the server.
+// AddCredential sends a credential entry to the server, into the shared vault
+// vaultUUID when it is set.
 // On success returns nil, otherwise returns an error returned by the API.
-func (s *KeychainClientService) AddCredential(ctx context.Context, title, login, password, site, note string) error {
+// A personal entry is queued in the local replica if the server is
+// unreachable, and ErrQueued is returned.
+func (s *KeychainClientService) AddCredential(ctx context.Context, vaultUUID, title, login, password, site, note string, tags []string) error {
 	in := &dto.AddCredentialsDTO{
+		Vault:    vaultUUID,
 		Title:    title,
+		Tags:     tags,
 		Login:    login,
 		Password: password,
 		Site:     site,
@@ -34,6 +59,17 @@ func (s *KeychainClientService) AddCredential(ctx context.Context, title, login,
 	}
 
 	_, err := s.API.AddCredential(ctx, in)
+	if vaultUUID == "" && s.offline(err) {
+		if err := keychain.ValidateCredential(login); err != nil {
+			return err
+		}
+		return s.queueAdd(err, keychain.KeyCredential, title, tags, in, nil, dto.CredentialsResponseDTO{
+			Login:    login,
+			Password: password,
+			Site:     site,
+			Note:     note,
+		})
+	}
 	if err != nil {
 		return err
 	}
@@ -41,10 +77,12 @@ func (s *KeychainClientService) AddCredential(ctx context.Context, title, login,
 	return nil
 }
 
-// AddCard sends a card entry to the server.
-func (s *KeychainClientService) AddCard(ctx context.Context, title, number, expDate, cvv, holder, bank, note string) error {
+// AddCard sends a card entry to the server the same as AddCredential.
+func (s *KeychainClientService) AddCard(ctx context.Context, vaultUUID, title, number, expDate, cvv, holder, bank, note string, tags []string) error {
 	in := &dto.AddCardDTO{
+		Vault:   vaultUUID,
 		Title:   title,
+		Tags:    tags,
 		Number:  number,
 		ExpDate: expDate,
 		CVV:     cvv,
@@ -54,6 +92,19 @@ func (s *KeychainClientService) AddCard(ctx context.Context, title, number, expD
 	}
 
 	_, err := s.API.AddCard(ctx, in)
+	if vaultUUID == "" && s.offline(err) {
+		if err := keychain.ValidateCard(number, cvv); err != nil {
+			return err
+		}
+		return s.queueAdd(err, keychain.KeyBankCard, title, tags, in, nil, dto.CardResponseDTO{
+			Number:  number,
+			ExpDate: expDate,
+			CVV:     cvv,
+			Holder:  holder,
+			Bank:    bank,
+			Note:    note,
+		})
+	}
 	if err != nil {
 		return err
 	}
@@ -61,15 +112,23 @@ func (s *KeychainClientService) AddCard(ctx context.Context, title, number, expD
 	return nil
 }
 
-// AddText sends a text entry to the server.
-func (s *KeychainClientService) AddText(ctx context.Context, title, text, note string) error {
+// AddText sends a text entry to the server the same as AddCredential.
+func (s *KeychainClientService) AddText(ctx context.Context, vaultUUID, title, text, note string, tags []string) error {
 	in := &dto.AddTextDTO{
+		Vault: vaultUUID,
 		Title: title,
+		Tags:  tags,
 		Text:  text,
 		Note:  note,
 	}
 
 	_, err := s.API.AddText(ctx, in)
+	if vaultUUID == "" && s.offline(err) {
+		if err := keychain.ValidateText(text); err != nil {
+			return err
+		}
+		return s.queueAdd(err, keychain.KeyText, title, tags, in, nil, dto.TextResponseDTO{Text: text, Note: note})
+	}
 	if err != nil {
 		return err
 	}
@@ -77,16 +136,25 @@ func (s *KeychainClientService) AddText(ctx context.Context, title, text, note s
 	return nil
 }
 
-// AddFile uploads a file to the server together with optional title and note.
+// AddFile uploads a file to the server together with optional title, note and tags.
 // filePath must point to a readable file. The method streams the file via the
 // underlying API's multipart endpoint.
-func (s *KeychainClientService) AddFile(ctx context.Context, title, filePath, note string) error {
+func (s *KeychainClientService) AddFile(ctx context.Context, vaultUUID, title, filePath, note string, tags []string) error {
 	in := &dto.AddFileDTO{
+		Vault: vaultUUID,
 		Title: title,
+		Tags:  tags,
 		Note:  note,
 	}
 
 	_, err := s.API.AddFile(ctx, in, filePath)
+	if vaultUUID == "" && s.offline(err) {
+		content, readErr := os.ReadFile(filePath)
+		if readErr != nil {
+			return readErr
+		}
+		return s.queueAdd(err, keychain.KeyFile, title, tags, in, content, dto.FileResponseDTO{Note: note})
+	}
 	if err != nil {
 		return err
 	}
@@ -95,16 +163,93 @@ func (s *KeychainClientService) AddFile(ctx context.Context, title, filePath, no
 }
 
 // GetList requests a list of keys. If keyType is empty, server should return all keys.
-func (s *KeychainClientService) GetList(ctx context.Context, keyType string) (dto.GetKeysResponse, error) {
-	return s.API.GetKeysList(ctx, keyType)
+// With vaultUUID set the entries of that shared vault are listed.
+//
+// The entries are listed from the local replica, if there is one, after
+// syncing it. offline is set when the server was unreachable and the list is
+// the one of the last sync, with the changes queued since.
+func (s *KeychainClientService) GetList(ctx context.Context, vaultUUID, keyType string) (resp dto.GetKeysResponse, offline bool, err error) {
+	var vault uuid.UUID
+	if vaultUUID != "" {
+		vault, err = uuid.Parse(vaultUUID)
+	}
+	if err != nil || s.Replica == nil {
+		resp, err = s.API.GetKeysList(ctx, keyType, vaultUUID)
+		return resp, false, err
+	}
+
+	var typ keychain.KeyType
+	if keyType != "" {
+		t, ok := keychain.ParseKeyType(keyType)
+		if !ok {
+			return resp, false, ErrInvalidKeyType
+		}
+		typ = t
+	}
+
+	st, err := s.loadReplica()
+	if errors.Is(err, replica.ErrNoKey) {
+		resp, err = s.API.GetKeysList(ctx, keyType, vaultUUID)
+		return resp, false, err
+	}
+	if err != nil {
+		return resp, false, err
+	}
+
+	_, syncErr := s.sync(ctx, st, KeepConflicts)
+	if err := s.Replica.Save(st); err != nil {
+		return resp, false, err
+	}
+	if syncErr != nil && !s.offline(syncErr) {
+		return resp, false, syncErr
+	}
+
+	for _, e := range st.List(vault, typ) {
+		resp.Keys = append(resp.Keys, &dto.GetKeysRecord{
+			KeyUUID:   e.KeyUUID,
+			KeyType:   e.KeyType,
+			Title:     e.Title,
+			Tags:      e.Tags,
+			CreatedAt: e.CreatedAt,
+			UpdatedAt: e.UpdatedAt,
+		})
+	}
+
+	return resp, syncErr != nil, nil
 }
 
 // Get fetches a single key payload by UUID.
-func (s *KeychainClientService) Get(ctx context.Context, keyUUID string) (dto.GetKeyResponse, error) {
-	return s.API.GetKey(ctx, keyUUID)
+//
+// The entry is read from the local replica when the server is unreachable,
+// or when it was added offline and is not sent yet; offline is set then.
+func (s *KeychainClientService) Get(ctx context.Context, vaultUUID, keyUUID string) (resp dto.GetKeyResponse, offline bool, err error) {
+	resp, err = s.API.GetKey(ctx, keyUUID, vaultUUID)
+	if err == nil || s.Replica == nil {
+		return resp, false, err
+	}
+
+	e, replicaErr := s.replicaEntry(vaultUUID, keyUUID)
+	if replicaErr != nil || !(s.offline(err) || e.Pending) {
+		return resp, false, err
+	}
+
+	return dto.GetKeyResponse{
+		KeyUUID:   e.KeyUUID,
+		KeyType:   e.KeyType,
+		Title:     e.Title,
+		Tags:      e.Tags,
+		Data:      e.Data,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}, true, nil
 }
 
-// Delete removes a key by UUID.
-func (s *KeychainClientService) Delete(ctx context.Context, keyUUID string) error {
-	return s.API.DeleteKey(ctx, keyUUID)
+// Delete removes a key by UUID. Deleting an entry while the server is
+// unreachable queues it in the local replica and returns ErrQueued.
+func (s *KeychainClientService) Delete(ctx context.Context, vaultUUID, keyUUID string) error {
+	err := s.API.DeleteKey(ctx, keyUUID, vaultUUID)
+	if s.offline(err) {
+		return s.queueDelete(err, vaultUUID, keyUUID)
+	}
+	return err
 }
@@ -63,23 +63,23 @@ func TestKeychainClientService_AddCredentialAndTextAndCard(t *testing.T) {
 
 	client := newTestClient(t, ts.URL)
 	api := api.NewKeychainAPI(client)
-	svc := NewKeychainClientService(api, client)
+	svc := NewKeychainClientService(api, client, nil)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
 	// AddCredential
-	if err := svc.AddCredential(ctx, "title", "login", "pass", "site", "note"); err != nil {
+	if err := svc.AddCredential(ctx, "", "title", "login", "pass", "site", "note", []string{"prod"}); err != nil {
 		t.Fatalf("AddCredential failed: %v", err)
 	}
 
 	// AddCard
-	if err := svc.AddCard(ctx, "t", "4111111111111111", "12/30", "123", "Holder", "Bank", "note"); err != nil {
+	if err := svc.AddCard(ctx, "", "t", "4111111111111111", "12/30", "123", "Holder", "Bank", "note", nil); err != nil {
 		t.Fatalf("AddCard failed: %v", err)
 	}
 
 	// AddText
-	if err := svc.AddText(ctx, "t", "some text", "note"); err != nil {
+	if err := svc.AddText(ctx, "", "t", "some text", "note", nil); err != nil {
 		t.Fatalf("AddText failed: %v", err)
 	}
 }
@@ -149,7 +149,7 @@ func TestKeychainClientService_AddFile_GetList_Get_Delete(t *testing.T) {
 
 	client := newTestClient(t, ts.URL)
 	api := api.NewKeychainAPI(client)
-	svc := NewKeychainClientService(api, client)
+	svc := NewKeychainClientService(api, client, nil)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -162,27 +162,27 @@ func TestKeychainClientService_AddFile_GetList_Get_Delete(t *testing.T) {
 	defer os.Remove(tmp)
 
 	// AddFile
-	if err := svc.AddFile(ctx, "mytitle", tmp, "mynote"); err != nil {
+	if err := svc.AddFile(ctx, "", "mytitle", tmp, "mynote", []string{"ci"}); err != nil {
 		t.Fatalf("AddFile failed: %v", err)
 	}
 
 	// GetList
-	if _, err := svc.GetList(ctx, ""); err != nil {
+	if _, _, err := svc.GetList(ctx, "", ""); err != nil {
 		t.Fatalf("GetList failed: %v", err)
 	}
 
 	// Get
-	if _, err := svc.Get(ctx, uuidTest.String()); err != nil {
+	if _, _, err := svc.Get(ctx, "", uuidTest.String()); err != nil {
 		t.Fatalf("Get failed: %v", err)
 	}
 
 	// Delete exists -> expect nil
-	if err := svc.Delete(ctx, "exists-uuid"); err != nil {
+	if err := svc.Delete(ctx, "", "exists-uuid"); err != nil {
 		t.Fatalf("Delete (exists) failed: %v", err)
 	}
 
 	// Delete missing -> expect error
-	if err := svc.Delete(ctx, "missing-uuid"); err == nil {
+	if err := svc.Delete(ctx, "", "missing-uuid"); err == nil {
 		t.Fatalf("Delete (missing) expected error, got nil")
 	}
 }
@@ -0,0 +1,195 @@
+package client_services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/client/e2e"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+)
+
+// ErrNotEnoughShares is returned by Combine when fewer shares than the
+// threshold of the setup were given.
+var ErrNotEnoughShares = errors.New("not enough recovery shares")
+
+// RecoveryClientService sets up and performs Shamir-shared recovery of the
+// sharing key pair. Shares are split, sealed and combined locally; the server
+// only stores the private key wrapped with the recovery key and the sealed
+// shares, neither of which it can open.
+type RecoveryClientService struct {
+	API       *api.RecoveryAPI
+	SharesAPI *api.SharesAPI
+	Client    *client_http.Client
+}
+
+// NewRecoveryClientService constructs a new RecoveryClientService.
+func NewRecoveryClientService(api *api.RecoveryAPI, sharesAPI *api.SharesAPI, httpClient *client_http.Client) *RecoveryClientService {
+	return &RecoveryClientService{
+		API:       api,
+		SharesAPI: sharesAPI,
+		Client:    httpClient,
+	}
+}
+
+// Setup splits a new recovery key for the key pair unlocked with password
+// into n shares of which threshold restore it. The first shares are sealed
+// for the holders and stored on their accounts; the rest are returned as
+// mnemonics to print and hand out. An earlier setup is replaced.
+func (s *RecoveryClientService) Setup(ctx context.Context, password string, n, threshold int, holders []string) (*dto.RecoveryResponse, []string, error) {
+	if threshold < 2 || threshold > n || len(holders) > n {
+		return nil, nil, e2e.ErrInvalidThreshold
+	}
+
+	resp, err := s.SharesAPI.GetKeyPair(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kp, err := e2e.UnwrapPrivateKey(resp.KeyMaterial, resp.PublicKey, password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyMaterial, shares, err := e2e.NewRecovery(kp, n, threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := &dto.SetRecoveryRequest{
+		PublicKey:   kp.PublicKey[:],
+		KeyMaterial: keyMaterial,
+		Threshold:   threshold,
+		Shares:      n,
+		Held:        make([]*dto.RecoveryShareRequest, 0, len(holders)),
+	}
+	for i, login := range holders {
+		holder, err := s.SharesAPI.PublicKey(ctx, login)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		wrappedKey, nonce, data, err := e2e.Seal(holder.PublicKey, shares[i])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		req.Held = append(req.Held, &dto.RecoveryShareRequest{
+			Login:      login,
+			WrappedKey: wrappedKey,
+			Nonce:      nonce,
+			Data:       data,
+		})
+	}
+
+	setup, err := s.API.SetRecovery(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mnemonics := make([]string, 0, n-len(holders))
+	for _, share := range shares[len(holders):] {
+		mnemonics = append(mnemonics, e2e.EncodeMnemonic(share))
+	}
+
+	return setup, mnemonics, nil
+}
+
+// Status returns the recovery setup of the current user.
+func (s *RecoveryClientService) Status(ctx context.Context) (*dto.RecoveryResponse, error) {
+	return s.API.GetRecovery(ctx)
+}
+
+// Remove deletes the recovery setup of the current user. Printed shares
+// become useless with it.
+func (s *RecoveryClientService) Remove(ctx context.Context) error {
+	return s.API.DeleteRecovery(ctx)
+}
+
+// Held returns the recovery shares the current user holds for others.
+func (s *RecoveryClientService) Held(ctx context.Context) (*dto.RecoverySharesResponse, error) {
+	return s.API.HeldShares(ctx)
+}
+
+// Reveal opens the recovery share shareUUID held by the current user with the
+// private key unwrapped with password and returns it as mnemonic, to be
+// handed to its owner.
+func (s *RecoveryClientService) Reveal(ctx context.Context, shareUUID, password string) (*dto.HeldRecoveryShareResponse, string, error) {
+	resp, err := s.SharesAPI.GetKeyPair(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	kp, err := e2e.UnwrapPrivateKey(resp.KeyMaterial, resp.PublicKey, password)
+	if err != nil {
+		return nil, "", err
+	}
+
+	held, err := s.API.GetHeldShare(ctx, shareUUID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	share, err := e2e.Open(kp, held.WrappedKey, held.Nonce, held.Data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return held, e2e.EncodeMnemonic(share), nil
+}
+
+// Threshold returns how many shares Combine needs.
+func (s *RecoveryClientService) Threshold(ctx context.Context) (int, error) {
+	setup, err := s.API.GetRecovery(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return setup.Threshold, nil
+}
+
+// Combine restores the sharing key pair from the mnemonics of at least the
+// threshold of shares and stores it wrapped with password, the current
+// account password. A different key pair of the user is only replaced if
+// replace is set, otherwise ErrKeyPairExists is returned.
+func (s *RecoveryClientService) Combine(ctx context.Context, mnemonics []string, password string, replace bool) error {
+	setup, err := s.API.GetRecovery(ctx)
+	if err != nil {
+		return err
+	}
+	if len(mnemonics) < setup.Threshold {
+		return ErrNotEnoughShares
+	}
+
+	current, err := s.SharesAPI.GetKeyPair(ctx)
+	if err == nil && !bytes.Equal(current.PublicKey, setup.PublicKey) && !replace {
+		return ErrKeyPairExists
+	}
+	if err != nil && !errors.Is(err, api.ErrNoKeyPair) {
+		return err
+	}
+
+	shares := make([][]byte, 0, len(mnemonics))
+	for _, m := range mnemonics {
+		share, err := e2e.DecodeMnemonic(m)
+		if err != nil {
+			return err
+		}
+		shares = append(shares, share)
+	}
+
+	kp, err := e2e.Recover(setup.KeyMaterial, setup.PublicKey, shares)
+	if err != nil {
+		return err
+	}
+
+	keyMaterial, err := e2e.WrapPrivateKey(kp.PrivateKey, password)
+	if err != nil {
+		return err
+	}
+
+	return s.SharesAPI.SetKeyPair(ctx, &dto.SetKeyPairRequest{
+		PublicKey:   kp.PublicKey[:],
+		KeyMaterial: keyMaterial,
+	})
+}
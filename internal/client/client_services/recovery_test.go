@@ -0,0 +1,125 @@
+package client_services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/client/e2e"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecoveryClientService_SetupRevealCombine(t *testing.T) {
+	shareUUID := uuid.New()
+	keyPairs := map[string]*dto.SetKeyPairRequest{}
+	var setup dto.SetRecoveryRequest
+
+	// The session token names the user, so alice and bob share one server.
+	user := func(r *http.Request) string {
+		return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/keys", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			var in dto.SetKeyPairRequest
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			keyPairs[user(r)] = &in
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		kp, ok := keyPairs[user(r)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "no key pair"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(dto.KeyPairResponse{PublicKey: kp.PublicKey, KeyMaterial: kp.KeyMaterial})
+	})
+	mux.HandleFunc("/api/keys/bob", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(dto.PublicKeyResponse{Login: "bob", PublicKey: keyPairs["bob"].PublicKey})
+	})
+	mux.HandleFunc("/api/recovery", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			_ = json.NewDecoder(r.Body).Decode(&setup)
+			_ = json.NewEncoder(w).Encode(dto.RecoveryResponse{Threshold: setup.Threshold, Shares: setup.Shares, Holders: []string{"bob"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(dto.RecoveryResponse{PublicKey: setup.PublicKey, KeyMaterial: setup.KeyMaterial, Threshold: setup.Threshold, Shares: setup.Shares})
+	})
+	mux.HandleFunc("/api/recovery/held/"+shareUUID.String(), func(w http.ResponseWriter, r *http.Request) {
+		held := setup.Held[0]
+		_ = json.NewEncoder(w).Encode(dto.HeldRecoveryShareResponse{
+			RecoveryShareRecord: dto.RecoveryShareRecord{ShareUUID: shareUUID, Owner: "alice", Holder: "bob"},
+			WrappedKey:          held.WrappedKey,
+			Nonce:               held.Nonce,
+			Data:                held.Data,
+		})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	newService := func(login string) *RecoveryClientService {
+		client := newTestClient(t, ts.URL)
+		client.SetAccessToken(login)
+		return NewRecoveryClientService(api.NewRecoveryAPI(client), api.NewSharesAPI(client), client)
+	}
+	alice, bob := newService("alice"), newService("bob")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for login, password := range map[string]string{"alice": "alice password", "bob": "bob password"} {
+		kp, err := e2e.GenerateKeyPair()
+		if err != nil {
+			t.Fatalf("GenerateKeyPair: %v", err)
+		}
+		keyMaterial, err := e2e.WrapPrivateKey(kp.PrivateKey, password)
+		if err != nil {
+			t.Fatalf("WrapPrivateKey: %v", err)
+		}
+		keyPairs[login] = &dto.SetKeyPairRequest{PublicKey: kp.PublicKey[:], KeyMaterial: keyMaterial}
+	}
+	alicePublicKey := keyPairs["alice"].PublicKey
+
+	if _, _, err := alice.Setup(ctx, "alice password", 3, 4, nil); !errors.Is(err, e2e.ErrInvalidThreshold) {
+		t.Fatalf("Setup expected ErrInvalidThreshold, got %v", err)
+	}
+
+	_, mnemonics, err := alice.Setup(ctx, "alice password", 3, 2, []string{"bob"})
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	if len(mnemonics) != 2 || len(setup.Held) != 1 || setup.Held[0].Login != "bob" {
+		t.Fatalf("unexpected setup: %d mnemonics, request %+v", len(mnemonics), setup)
+	}
+
+	_, revealed, err := bob.Reveal(ctx, shareUUID.String(), "bob password")
+	if err != nil {
+		t.Fatalf("Reveal: %v", err)
+	}
+
+	// alice forgot her password: the reset dropped her key pair.
+	delete(keyPairs, "alice")
+
+	if err := alice.Combine(ctx, mnemonics[:1], "new password", false); !errors.Is(err, ErrNotEnoughShares) {
+		t.Fatalf("Combine with one share expected ErrNotEnoughShares, got %v", err)
+	}
+	if err := alice.Combine(ctx, []string{mnemonics[0], revealed}, "new password", false); err != nil {
+		t.Fatalf("Combine: %v", err)
+	}
+
+	restored := keyPairs["alice"]
+	if string(restored.PublicKey) != string(alicePublicKey) {
+		t.Fatalf("restored a different key pair")
+	}
+	if _, err := e2e.UnwrapPrivateKey(restored.KeyMaterial, restored.PublicKey, "new password"); err != nil {
+		t.Fatalf("restored key is not wrapped with the new password: %v", err)
+	}
+}
@@ -0,0 +1,362 @@
+package client_services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/client/replica"
+	"github.com/thxhix/passKeeper/internal/client/token"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"os"
+	"time"
+)
+
+var (
+	ErrQueued          = errors.New("the server is unreachable; the change is saved locally and will be sent on the next sync")
+	ErrReplicaDisabled = errors.New("the local replica is disabled")
+	ErrOtherAccount    = errors.New("the local replica holds unsynced changes of another account; sync them as that account or discard them with 'passKeeper sync --purge'")
+	ErrPendingChanges  = errors.New("the local replica holds changes that were not sent to the server yet")
+	ErrInvalidKeyType  = errors.New("invalid key type")
+)
+
+// ConflictPolicy tells Sync what to do with queued changes that conflict
+// with changes made on the server meanwhile.
+type ConflictPolicy int
+
+const (
+	// KeepConflicts leaves conflicting changes queued for the user to decide.
+	KeepConflicts ConflictPolicy = iota
+	// ForceConflicts sends conflicting changes anyway.
+	ForceConflicts
+	// DiscardConflicts drops conflicting changes, keeping the server version.
+	DiscardConflicts
+)
+
+// SyncResult describes a finished sync. Conflicts are the changes left
+// queued because they conflict with the server; Queued counts every change
+// still waiting, conflicting or not.
+type SyncResult struct {
+	Revision  int64
+	Pushed    int
+	Queued    int
+	Conflicts []*replica.Op
+}
+
+// Sync sends the changes queued in the local replica and brings the replica
+// up to date with the server. The replica is saved even when the sync stops
+// halfway, so the changes already sent are not sent again.
+func (s *KeychainClientService) Sync(ctx context.Context, policy ConflictPolicy) (*SyncResult, error) {
+	if s.Replica == nil {
+		return nil, ErrReplicaDisabled
+	}
+
+	st, err := s.loadReplica()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.sync(ctx, st, policy)
+	if saveErr := s.Replica.Save(st); err == nil {
+		err = saveErr
+	}
+	return res, err
+}
+
+// ForgetReplica removes the local replica and its key. It returns
+// ErrPendingChanges while changes are queued, unless discard is set.
+func (s *KeychainClientService) ForgetReplica(discard bool) error {
+	if s.Replica == nil {
+		return nil
+	}
+
+	if !discard {
+		st, err := s.Replica.Load()
+		if err != nil {
+			return err
+		}
+		if len(st.Queue) > 0 {
+			return ErrPendingChanges
+		}
+	}
+
+	return s.Replica.Remove()
+}
+
+// sync pulls the changes of the server, sends the queued changes and pulls
+// again to pick up the entries just added.
+func (s *KeychainClientService) sync(ctx context.Context, st *replica.State, policy ConflictPolicy) (*SyncResult, error) {
+	err := s.pull(ctx, st)
+
+	res := &SyncResult{}
+	if err == nil {
+		res.Pushed, err = s.push(ctx, st, policy)
+	}
+	if err == nil && res.Pushed > 0 {
+		err = s.pull(ctx, st)
+	}
+	if err == nil {
+		st.SyncedAt = time.Now()
+	}
+
+	res.Revision = st.Revision
+	res.Queued = len(st.Queue)
+	res.Conflicts = st.Conflicts()
+	return res, err
+}
+
+// pull applies the changes of the server after the revision of the replica.
+func (s *KeychainClientService) pull(ctx context.Context, st *replica.State) error {
+	for {
+		changes, err := s.API.GetChanges(ctx, st.Revision, true)
+		if err != nil {
+			return err
+		}
+
+		st.Apply(changes)
+		if !changes.More {
+			return nil
+		}
+	}
+}
+
+// push sends the queued changes in order, checking each against the
+// replica freshly pulled from the server. It stops at the first change the
+// server does not take, leaving it and the rest queued.
+func (s *KeychainClientService) push(ctx context.Context, st *replica.State, policy ConflictPolicy) (pushed int, err error) {
+	for _, op := range append([]*replica.Op(nil), st.Queue...) {
+		if _, ok := st.Entries[op.KeyUUID]; op.Kind == replica.OpDelete && !ok {
+			// already deleted on the server
+			st.Remove(op)
+			continue
+		}
+
+		if op.Conflict == "" {
+			op.Conflict = conflictOf(st, op)
+		}
+		if op.Conflict != "" {
+			switch policy {
+			case KeepConflicts:
+				continue
+			case DiscardConflicts:
+				st.Remove(op)
+				continue
+			}
+		}
+
+		if err := s.send(ctx, op); err != nil {
+			return pushed, err
+		}
+		st.Remove(op)
+		pushed++
+	}
+
+	return pushed, nil
+}
+
+// conflictOf describes how op conflicts with the entries of the server, or
+// returns an empty string if it does not.
+func conflictOf(st *replica.State, op *replica.Op) string {
+	switch op.Kind {
+	case replica.OpAdd:
+		for _, e := range st.Entries {
+			if !e.Pending && e.InVault(uuid.Nil) && e.KeyType == op.KeyType && e.Title == op.Title && e.Revision > op.BaseRevision {
+				return "an entry of the same type and title was added on the server meanwhile"
+			}
+		}
+	case replica.OpDelete:
+		if e, ok := st.Entries[op.KeyUUID]; ok && e.Revision != op.BaseRevision {
+			return "the entry was changed on the server meanwhile"
+		}
+	}
+	return ""
+}
+
+// send makes the request of a queued change.
+func (s *KeychainClientService) send(ctx context.Context, op *replica.Op) error {
+	if op.Kind == replica.OpDelete {
+		vaultUUID := ""
+		if op.Vault != nil {
+			vaultUUID = op.Vault.String()
+		}
+		return s.API.DeleteKey(ctx, op.KeyUUID.String(), vaultUUID)
+	}
+
+	var err error
+	switch op.KeyType {
+	case keychain.KeyCredential:
+		var in dto.AddCredentialsDTO
+		if err = json.Unmarshal(op.Payload, &in); err == nil {
+			_, err = s.API.AddCredential(ctx, &in)
+		}
+	case keychain.KeyBankCard:
+		var in dto.AddCardDTO
+		if err = json.Unmarshal(op.Payload, &in); err == nil {
+			_, err = s.API.AddCard(ctx, &in)
+		}
+	case keychain.KeyText:
+		var in dto.AddTextDTO
+		if err = json.Unmarshal(op.Payload, &in); err == nil {
+			_, err = s.API.AddText(ctx, &in)
+		}
+	case keychain.KeyFile:
+		var in dto.AddFileDTO
+		if err = json.Unmarshal(op.Payload, &in); err == nil {
+			err = s.sendFile(ctx, &in, op.File)
+		}
+	default:
+		err = errors.New("unknown entry type " + op.KeyType.String())
+	}
+	return err
+}
+
+// sendFile uploads a queued file through a temporary copy, as the API
+// streams uploads from disk.
+func (s *KeychainClientService) sendFile(ctx context.Context, in *dto.AddFileDTO, content []byte) error {
+	f, err := os.CreateTemp("", "passkeeper-upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(content); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	_, err = s.API.AddFile(ctx, in, f.Name())
+	return err
+}
+
+// loadReplica loads the local replica of the current account. A replica of
+// another account is started afresh unless it holds unsynced changes.
+func (s *KeychainClientService) loadReplica() (*replica.State, error) {
+	st, err := s.Replica.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	account := s.Client.BaseURL() + "#" + token.SessionSubject()
+	if st.Account == account {
+		return st, nil
+	}
+	if st.Account != "" && len(st.Queue) > 0 {
+		return nil, ErrOtherAccount
+	}
+
+	return replica.NewState(account), nil
+}
+
+// offline reports whether err means that the server could not be reached
+// and the local replica can stand in for it.
+func (s *KeychainClientService) offline(err error) bool {
+	return s.Replica != nil && errors.Is(err, client_http.ErrClientRequestFailed)
+}
+
+// queueAdd queues an entry added while the server is unreachable and
+// returns ErrQueued. The entry is checked like the server would, as it is
+// sent unattended later. apiErr is returned if there is no usable replica.
+func (s *KeychainClientService) queueAdd(apiErr error, keyType keychain.KeyType, title string, tags []string, in any, file []byte, data any) error {
+	if err := keychain.ValidateTitle(title); err != nil {
+		return err
+	}
+	tags, err := keychain.NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	st, err := s.loadReplica()
+	if errors.Is(err, replica.ErrNoKey) {
+		return apiErr
+	}
+	if err != nil {
+		return err
+	}
+
+	st.QueueAdd(keyType, title, tags, payload, file, plain)
+	if err := s.Replica.Save(st); err != nil {
+		return err
+	}
+
+	return ErrQueued
+}
+
+// queueDelete queues the deletion of an entry of the vault vaultUUID, or of
+// a personal entry when it is empty, while the server is unreachable and
+// returns ErrQueued. apiErr is returned if there is no usable replica.
+func (s *KeychainClientService) queueDelete(apiErr error, vaultUUID, keyUUID string) error {
+	st, err := s.loadReplica()
+	if errors.Is(err, replica.ErrNoKey) {
+		return apiErr
+	}
+	if err != nil {
+		return err
+	}
+
+	e, err := replicaEntryOf(st, vaultUUID, keyUUID)
+	if err != nil {
+		return err
+	}
+
+	if err := st.QueueDelete(e.KeyUUID); err != nil {
+		return err
+	}
+	if err := s.Replica.Save(st); err != nil {
+		return err
+	}
+
+	return ErrQueued
+}
+
+// replicaEntry returns the entry keyUUID of the vault vaultUUID, or the
+// personal entry when it is empty, from the local replica.
+func (s *KeychainClientService) replicaEntry(vaultUUID, keyUUID string) (*replica.Entry, error) {
+	st, err := s.loadReplica()
+	if err != nil {
+		return nil, err
+	}
+
+	return replicaEntryOf(st, vaultUUID, keyUUID)
+}
+
+// replicaEntryOf returns the entry keyUUID of the vault vaultUUID, or the
+// personal entry when it is empty, from st.
+func replicaEntryOf(st *replica.State, vaultUUID, keyUUID string) (*replica.Entry, error) {
+	var vault uuid.UUID
+	if vaultUUID != "" {
+		v, err := uuid.Parse(vaultUUID)
+		if err != nil {
+			return nil, replica.ErrNotFound
+		}
+		vault = v
+	}
+
+	id, err := uuid.Parse(keyUUID)
+	if err != nil {
+		return nil, replica.ErrNotFound
+	}
+
+	e, err := st.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !e.InVault(vault) {
+		return nil, replica.ErrNotFound
+	}
+
+	return e, nil
+}
@@ -0,0 +1,194 @@
+package client_services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/client/replica"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeKeychain is a keychain server with revisions whose connection can be
+// cut to simulate an unreachable server.
+type fakeKeychain struct {
+	mu       sync.Mutex
+	down     bool
+	revision int64
+	changes  []*dto.KeyChange
+}
+
+func (f *fakeKeychain) add(keyType keychain.KeyType, title string, data json.RawMessage) uuid.UUID {
+	f.revision++
+	id := uuid.New()
+	f.changes = append(f.changes, &dto.KeyChange{KeyUUID: id, KeyType: keyType, Title: title, Revision: f.revision, Data: data, CreatedAt: time.Now()})
+	return id
+}
+
+func (f *fakeKeychain) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.down {
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		_ = conn.Close()
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/api/keychain/changes":
+		since, _ := strconv.ParseInt(r.URL.Query().Get("since"), 10, 64)
+		resp := dto.ChangesResponse{Revision: f.revision}
+		latest := make(map[uuid.UUID]*dto.KeyChange)
+		for _, ch := range f.changes {
+			latest[ch.KeyUUID] = ch
+		}
+		for _, ch := range f.changes {
+			if ch.Revision > since && latest[ch.KeyUUID] == ch {
+				resp.Changes = append(resp.Changes, ch)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+
+	case r.URL.Path == "/api/keychain/text" && r.Method == http.MethodPost:
+		var in dto.AddTextDTO
+		_ = json.NewDecoder(r.Body).Decode(&in)
+		data, _ := json.Marshal(dto.TextResponseDTO{Text: in.Text, Note: in.Note})
+		id := f.add(keychain.KeyText, in.Title, data)
+		_ = json.NewEncoder(w).Encode(dto.AddSuccessResponse{UUID: id.String()})
+
+	case r.Method == http.MethodDelete:
+		id := uuid.MustParse(strings.TrimPrefix(r.URL.Path, "/api/keychain/"))
+		f.revision++
+		f.changes = append(f.changes, &dto.KeyChange{KeyUUID: id, Revision: f.revision, Deleted: true})
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "not found"})
+	}
+}
+
+func (f *fakeKeychain) setDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+}
+
+func newReplicaService(t *testing.T, srv *fakeKeychain) *KeychainClientService {
+	t.Helper()
+
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	client := newTestClient(t, ts.URL)
+	store := replica.NewStore(filepath.Join(t.TempDir(), "replica.json"), false, func() (string, error) { return "passphrase", nil })
+	return NewKeychainClientService(api.NewKeychainAPI(client), client, store)
+}
+
+func TestKeychainClientService_Offline(t *testing.T) {
+	srv := &fakeKeychain{}
+	stored := srv.add(keychain.KeyText, "stored", json.RawMessage(`{"text":"hunter2"}`))
+	svc := newReplicaService(t, srv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	list, offline, err := svc.GetList(ctx, "", "")
+	if err != nil || offline || len(list.Keys) != 1 {
+		t.Fatalf("GetList online: offline=%v err=%v keys=%d", offline, err, len(list.Keys))
+	}
+
+	srv.setDown(true)
+
+	got, offline, err := svc.Get(ctx, "", stored.String())
+	if err != nil || !offline || string(got.Data) != `{"text":"hunter2"}` {
+		t.Fatalf("Get offline: offline=%v err=%v data=%s", offline, err, got.Data)
+	}
+
+	if err := svc.AddText(ctx, "", "offline", "text", "", nil); !errors.Is(err, ErrQueued) {
+		t.Fatalf("AddText offline: expected ErrQueued, got %v", err)
+	}
+	if err := svc.AddText(ctx, "", "", "text", "", nil); !errors.Is(err, keychain.ErrTitleEmpty) {
+		t.Fatalf("AddText offline: expected validation error, got %v", err)
+	}
+	if err := svc.Delete(ctx, "", stored.String()); !errors.Is(err, ErrQueued) {
+		t.Fatalf("Delete offline: expected ErrQueued, got %v", err)
+	}
+
+	list, offline, err = svc.GetList(ctx, "", "")
+	if err != nil || !offline || len(list.Keys) != 1 || list.Keys[0].Title != "offline" {
+		t.Fatalf("GetList offline: offline=%v err=%v keys=%+v", offline, err, list.Keys)
+	}
+
+	if _, err := svc.Sync(ctx, KeepConflicts); err == nil {
+		t.Fatalf("Sync offline: expected error")
+	}
+
+	srv.setDown(false)
+
+	res, err := svc.Sync(ctx, KeepConflicts)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if res.Pushed != 2 || res.Queued != 0 || res.Revision != srv.revision {
+		t.Fatalf("unexpected sync result: %+v", res)
+	}
+
+	list, offline, err = svc.GetList(ctx, "", "")
+	if err != nil || offline || len(list.Keys) != 1 || list.Keys[0].Title != "offline" || list.Keys[0].KeyUUID == stored {
+		t.Fatalf("GetList after sync: offline=%v err=%v keys=%+v", offline, err, list.Keys)
+	}
+}
+
+func TestKeychainClientService_SyncConflict(t *testing.T) {
+	srv := &fakeKeychain{}
+	svc := newReplicaService(t, srv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := svc.Sync(ctx, KeepConflicts); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	srv.setDown(true)
+	if err := svc.AddText(ctx, "", "wifi", "offline password", "", nil); !errors.Is(err, ErrQueued) {
+		t.Fatalf("AddText offline: expected ErrQueued, got %v", err)
+	}
+
+	// the same entry is added from another device meanwhile
+	srv.setDown(false)
+	srv.mu.Lock()
+	srv.add(keychain.KeyText, "wifi", json.RawMessage(`{"text":"online password"}`))
+	srv.mu.Unlock()
+
+	res, err := svc.Sync(ctx, KeepConflicts)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if res.Pushed != 0 || len(res.Conflicts) != 1 || res.Conflicts[0].Title != "wifi" {
+		t.Fatalf("expected a kept conflict, got %+v", res)
+	}
+
+	res, err = svc.Sync(ctx, DiscardConflicts)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if res.Pushed != 0 || res.Queued != 0 || len(res.Conflicts) != 0 {
+		t.Fatalf("expected the conflict to be discarded, got %+v", res)
+	}
+	if len(srv.changes) != 1 {
+		t.Fatalf("the discarded change reached the server")
+	}
+}
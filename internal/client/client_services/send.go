@@ -0,0 +1,161 @@
+package client_services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/client/e2e"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// sendPath is the path prefix of secret links.
+const sendPath = "/api/send/"
+
+// ErrInvalidLink is returned by Receive for links that are not secret links
+// or lack their key.
+var ErrInvalidLink = errors.New("not a valid secret link")
+
+// SentSecret is the plaintext of a secret link: either free text or a
+// keychain entry.
+type SentSecret struct {
+	Text  string              `json:"text,omitempty"`
+	Entry *dto.GetKeyResponse `json:"entry,omitempty"`
+}
+
+// SendClientService creates and opens one-time secret links. Secrets are
+// encrypted locally and the key is only put into the link fragment, which is
+// never sent to the server.
+type SendClientService struct {
+	API         *api.SendsAPI
+	KeychainAPI *api.KeychainAPI
+	Client      *client_http.Client
+}
+
+// NewSendClientService constructs a new SendClientService.
+func NewSendClientService(api *api.SendsAPI, keychainAPI *api.KeychainAPI, httpClient *client_http.Client) *SendClientService {
+	return &SendClientService{
+		API:         api,
+		KeychainAPI: keychainAPI,
+		Client:      httpClient,
+	}
+}
+
+// SendText creates a link to text that can be opened maxViews times within
+// ttl. It returns the link and the created send.
+func (s *SendClientService) SendText(ctx context.Context, text string, maxViews int, ttl time.Duration) (string, *dto.SendRecord, error) {
+	return s.create(ctx, SentSecret{Text: text}, maxViews, ttl)
+}
+
+// SendEntry creates a link to the current content of the personal entry
+// keyUUID the same as SendText.
+func (s *SendClientService) SendEntry(ctx context.Context, keyUUID string, maxViews int, ttl time.Duration) (string, *dto.SendRecord, error) {
+	entry, err := s.KeychainAPI.GetKey(ctx, keyUUID, "")
+	if err != nil {
+		return "", nil, err
+	}
+
+	return s.create(ctx, SentSecret{Entry: &entry}, maxViews, ttl)
+}
+
+func (s *SendClientService) create(ctx context.Context, secret SentSecret, maxViews int, ttl time.Duration) (string, *dto.SendRecord, error) {
+	plaintext, err := json.Marshal(secret)
+	if err != nil {
+		return "", nil, err
+	}
+
+	key, nonce, data, err := e2e.SealSecret(plaintext)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rec, err := s.API.Create(ctx, &dto.CreateSendRequest{
+		Nonce:            nonce,
+		Data:             data,
+		MaxViews:         maxViews,
+		ExpiresInSeconds: int64(ttl / time.Second),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	link := strings.TrimRight(s.Client.BaseURL(), "/") + sendPath + rec.SendUUID.String() +
+		"#" + base64.RawURLEncoding.EncodeToString(key)
+
+	return link, rec, nil
+}
+
+// List returns the secret links of the current user.
+func (s *SendClientService) List(ctx context.Context) (*dto.SendsResponse, error) {
+	return s.API.List(ctx)
+}
+
+// Revoke deletes the secret link sendUUID.
+func (s *SendClientService) Revoke(ctx context.Context, sendUUID string) error {
+	return s.API.Revoke(ctx, sendUUID)
+}
+
+// Receive opens a secret link, which takes one of its views, and decrypts
+// it. The link may point to any server; it is contacted without the stored
+// session, so no account is needed.
+func (s *SendClientService) Receive(ctx context.Context, link string) (*SentSecret, *dto.SendResponse, error) {
+	baseURL, sendUUID, key, err := parseLink(link)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpClient, err := client_http.NewHttpClient(baseURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	httpClient.DisableAuthorization()
+
+	resp, err := api.NewSendsAPI(httpClient).Open(ctx, sendUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plaintext, err := e2e.OpenSecret(key, resp.Nonce, resp.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var secret SentSecret
+	if err := json.Unmarshal(plaintext, &secret); err != nil {
+		return nil, nil, err
+	}
+
+	return &secret, resp, nil
+}
+
+// parseLink splits a secret link into the server address, the send UUID and
+// the key from the fragment.
+func parseLink(link string) (baseURL, sendUUID string, key []byte, err error) {
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", "", nil, ErrInvalidLink
+	}
+
+	i := strings.LastIndex(u.Path, sendPath)
+	if i < 0 {
+		return "", "", nil, ErrInvalidLink
+	}
+
+	id, err := uuid.Parse(u.Path[i+len(sendPath):])
+	if err != nil {
+		return "", "", nil, ErrInvalidLink
+	}
+
+	key, err = base64.RawURLEncoding.DecodeString(u.Fragment)
+	if err != nil || len(key) != e2e.SecretKeySize {
+		return "", "", nil, ErrInvalidLink
+	}
+
+	return u.Scheme + "://" + u.Host + u.Path[:i], id.String(), key, nil
+}
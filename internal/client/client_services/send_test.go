@@ -0,0 +1,69 @@
+package client_services
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSendClientService_SendAndReceive(t *testing.T) {
+	sendUUID := uuid.New()
+	var stored dto.CreateSendRequest
+	var openAuth string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/send", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&stored)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(dto.SendRecord{SendUUID: sendUUID, MaxViews: stored.MaxViews})
+	})
+	mux.HandleFunc("/api/send/"+sendUUID.String(), func(w http.ResponseWriter, r *http.Request) {
+		openAuth = r.Header.Get("Authorization")
+		_ = json.NewEncoder(w).Encode(dto.SendResponse{Nonce: stored.Nonce, Data: stored.Data})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client := newTestClient(t, ts.URL)
+	client.SetAccessToken("session")
+	svc := NewSendClientService(api.NewSendsAPI(client), api.NewKeychainAPI(client), client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	link, rec, err := svc.SendText(ctx, "hunter2", 1, time.Hour)
+	if err != nil {
+		t.Fatalf("SendText: %v", err)
+	}
+	if rec.SendUUID != sendUUID || stored.ExpiresInSeconds != 3600 || stored.MaxViews != 1 {
+		t.Fatalf("unexpected send: %+v, request %+v", rec, stored)
+	}
+	if !strings.HasPrefix(link, ts.URL+"/api/send/"+sendUUID.String()+"#") {
+		t.Fatalf("unexpected link %q", link)
+	}
+	if strings.Contains(string(stored.Data), "hunter2") {
+		t.Fatalf("server received plaintext")
+	}
+
+	secret, _, err := svc.Receive(ctx, link)
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	if secret.Text != "hunter2" {
+		t.Fatalf("unexpected secret %+v", secret)
+	}
+	if openAuth != "" {
+		t.Fatalf("link was opened with credentials %q", openAuth)
+	}
+
+	if _, _, err := svc.Receive(ctx, ts.URL+"/api/send/"+sendUUID.String()); err != ErrInvalidLink {
+		t.Fatalf("Receive without key expected ErrInvalidLink, got %v", err)
+	}
+}
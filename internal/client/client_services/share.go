@@ -0,0 +1,164 @@
+package client_services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/client/e2e"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+)
+
+// ErrKeyPairExists is returned by InitKeys when the user already has a
+// sharing key pair and replacing it was not requested.
+var ErrKeyPairExists = errors.New("sharing key pair already exists; replacing it drops every entry shared with you")
+
+// ShareClientService shares single entries end-to-end encrypted with other
+// users. Entries are sealed and opened locally; the server only relays
+// ciphertext.
+type ShareClientService struct {
+	API         *api.SharesAPI
+	KeychainAPI *api.KeychainAPI
+	Client      *client_http.Client
+}
+
+// NewShareClientService constructs a new ShareClientService.
+func NewShareClientService(api *api.SharesAPI, keychainAPI *api.KeychainAPI, httpClient *client_http.Client) *ShareClientService {
+	return &ShareClientService{
+		API:         api,
+		KeychainAPI: keychainAPI,
+		Client:      httpClient,
+	}
+}
+
+// InitKeys generates a sharing key pair, wraps the private key with password
+// and uploads both. An existing key pair is only replaced if replace is set,
+// otherwise ErrKeyPairExists is returned.
+func (s *ShareClientService) InitKeys(ctx context.Context, password string, replace bool) error {
+	_, err := s.API.GetKeyPair(ctx)
+	if err == nil && !replace {
+		return ErrKeyPairExists
+	}
+	if err != nil && !errors.Is(err, api.ErrNoKeyPair) {
+		return err
+	}
+
+	kp, err := e2e.GenerateKeyPair()
+	if err != nil {
+		return err
+	}
+
+	keyMaterial, err := e2e.WrapPrivateKey(kp.PrivateKey, password)
+	if err != nil {
+		return err
+	}
+
+	return s.API.SetKeyPair(ctx, &dto.SetKeyPairRequest{
+		PublicKey:   kp.PublicKey[:],
+		KeyMaterial: keyMaterial,
+	})
+}
+
+// ShareEntry seals the current content of the personal entry keyUUID for the
+// user with the given login and uploads it. Sharing again replaces the
+// snapshot the recipient sees.
+func (s *ShareClientService) ShareEntry(ctx context.Context, keyUUID, login string) (*dto.ShareRecord, error) {
+	entry, err := s.KeychainAPI.GetKey(ctx, keyUUID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient, err := s.API.PublicKey(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedKey, nonce, data, err := e2e.Seal(recipient.PublicKey, plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.API.Share(ctx, keyUUID, &dto.ShareKeyRequest{
+		Login:      login,
+		WrappedKey: wrappedKey,
+		Nonce:      nonce,
+		Data:       data,
+	})
+}
+
+// Recipients returns the users the entry keyUUID is shared with.
+func (s *ShareClientService) Recipients(ctx context.Context, keyUUID string) (*dto.SharesResponse, error) {
+	return s.API.Shares(ctx, keyUUID)
+}
+
+// Revoke withdraws the entry keyUUID from the user with the given login.
+func (s *ShareClientService) Revoke(ctx context.Context, keyUUID, login string) error {
+	return s.API.Revoke(ctx, keyUUID, login)
+}
+
+// SharedWithMe returns the entries other users shared with the current user.
+func (s *ShareClientService) SharedWithMe(ctx context.Context) (*dto.SharesResponse, error) {
+	return s.API.SharedWithMe(ctx)
+}
+
+// Open downloads the share shareUUID and decrypts it with the private key
+// unwrapped with password. It returns the share and the decrypted entry.
+func (s *ShareClientService) Open(ctx context.Context, shareUUID, password string) (*dto.SharedKeyResponse, dto.GetKeyResponse, error) {
+	var entry dto.GetKeyResponse
+
+	kp, err := s.keyPair(ctx, password)
+	if err != nil {
+		return nil, entry, err
+	}
+
+	shared, err := s.API.GetShared(ctx, shareUUID)
+	if err != nil {
+		return nil, entry, err
+	}
+
+	plaintext, err := e2e.Open(kp, shared.WrappedKey, shared.Nonce, shared.Data)
+	if err != nil {
+		return nil, entry, err
+	}
+
+	if err := json.Unmarshal(plaintext, &entry); err != nil {
+		return nil, entry, err
+	}
+
+	return shared, entry, nil
+}
+
+// Dismiss removes the share shareUUID from the entries shared with the
+// current user.
+func (s *ShareClientService) Dismiss(ctx context.Context, shareUUID string) error {
+	return s.API.Dismiss(ctx, shareUUID)
+}
+
+// RewrapKeyMaterial returns the private sharing key wrapped with newPassword
+// instead of currentPassword, or nil if the user has no key pair.
+func (s *ShareClientService) RewrapKeyMaterial(ctx context.Context, currentPassword, newPassword string) ([]byte, error) {
+	kp, err := s.keyPair(ctx, currentPassword)
+	if errors.Is(err, api.ErrNoKeyPair) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return e2e.WrapPrivateKey(kp.PrivateKey, newPassword)
+}
+
+func (s *ShareClientService) keyPair(ctx context.Context, password string) (*e2e.KeyPair, error) {
+	resp, err := s.API.GetKeyPair(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return e2e.UnwrapPrivateKey(resp.KeyMaterial, resp.PublicKey, password)
+}
@@ -0,0 +1,49 @@
+package client_services
+
+import (
+	"context"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"time"
+)
+
+// TokenClientService exposes personal access token management to the CLI layer.
+type TokenClientService struct {
+	API    *api.TokensAPI
+	Client *client_http.Client
+}
+
+// NewTokenClientService constructs a new TokenClientService.
+func NewTokenClientService(api *api.TokensAPI, httpClient *client_http.Client) *TokenClientService {
+	return &TokenClientService{
+		API:    api,
+		Client: httpClient,
+	}
+}
+
+// Create issues a personal access token with the given scopes. A zero ttl
+// creates a token that never expires.
+func (s *TokenClientService) Create(ctx context.Context, name string, scopes []string, ttl time.Duration) (*dto.CreatePersonalTokenResponse, error) {
+	in := &dto.CreatePersonalTokenRequest{
+		Name:   name,
+		Scopes: scopes,
+	}
+
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl).UTC()
+		in.ExpiresAt = &expiresAt
+	}
+
+	return s.API.Create(ctx, in)
+}
+
+// List returns the personal access tokens of the current user.
+func (s *TokenClientService) List(ctx context.Context) (*dto.PersonalTokensResponse, error) {
+	return s.API.List(ctx)
+}
+
+// Revoke deletes the personal access token with the given UUID.
+func (s *TokenClientService) Revoke(ctx context.Context, tokenUUID string) error {
+	return s.API.Revoke(ctx, tokenUUID)
+}
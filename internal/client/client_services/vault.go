@@ -0,0 +1,74 @@
+package client_services
+
+import (
+	"context"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+)
+
+// VaultClientService exposes organizations and shared vaults to the CLI layer.
+type VaultClientService struct {
+	API    *api.VaultsAPI
+	Client *client_http.Client
+}
+
+// NewVaultClientService constructs a new VaultClientService.
+func NewVaultClientService(api *api.VaultsAPI, httpClient *client_http.Client) *VaultClientService {
+	return &VaultClientService{
+		API:    api,
+		Client: httpClient,
+	}
+}
+
+// CreateOrganization creates an organization owned by the current user.
+func (s *VaultClientService) CreateOrganization(ctx context.Context, name string) (*dto.OrganizationRecord, error) {
+	return s.API.CreateOrganization(ctx, &dto.CreateOrganizationRequest{Name: name})
+}
+
+// ListOrganizations returns the organizations the current user is a member of.
+func (s *VaultClientService) ListOrganizations(ctx context.Context) (*dto.OrganizationsResponse, error) {
+	return s.API.ListOrganizations(ctx)
+}
+
+// OrganizationMembers returns the members of an organization.
+func (s *VaultClientService) OrganizationMembers(ctx context.Context, orgUUID string) (*dto.MembersResponse, error) {
+	return s.API.OrganizationMembers(ctx, orgUUID)
+}
+
+// SetOrganizationMember adds login to the organization with role, or changes
+// the role of a member.
+func (s *VaultClientService) SetOrganizationMember(ctx context.Context, orgUUID, login, role string) error {
+	return s.API.SetOrganizationMember(ctx, orgUUID, login, &dto.SetMemberRequest{Role: role})
+}
+
+// RemoveOrganizationMember removes login from the organization.
+func (s *VaultClientService) RemoveOrganizationMember(ctx context.Context, orgUUID, login string) error {
+	return s.API.RemoveOrganizationMember(ctx, orgUUID, login)
+}
+
+// CreateVault creates a shared vault, inside the organization orgUUID when it
+// is set.
+func (s *VaultClientService) CreateVault(ctx context.Context, name, orgUUID string) (*dto.VaultRecord, error) {
+	return s.API.CreateVault(ctx, &dto.CreateVaultRequest{Name: name, Org: orgUUID})
+}
+
+// ListVaults returns the vaults the current user has a role in.
+func (s *VaultClientService) ListVaults(ctx context.Context) (*dto.VaultsResponse, error) {
+	return s.API.ListVaults(ctx)
+}
+
+// Members returns everyone with a role in a vault.
+func (s *VaultClientService) Members(ctx context.Context, vaultUUID string) (*dto.MembersResponse, error) {
+	return s.API.Members(ctx, vaultUUID)
+}
+
+// Share gives login role in a vault.
+func (s *VaultClientService) Share(ctx context.Context, vaultUUID, login, role string) error {
+	return s.API.Share(ctx, vaultUUID, login, &dto.SetMemberRequest{Role: role})
+}
+
+// Unshare removes the role of login from a vault.
+func (s *VaultClientService) Unshare(ctx context.Context, vaultUUID, login string) error {
+	return s.API.Unshare(ctx, vaultUUID, login)
+}
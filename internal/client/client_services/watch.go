@@ -0,0 +1,111 @@
+package client_services
+
+import (
+	"context"
+	"errors"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"time"
+)
+
+const (
+	watchMinBackoff = time.Second
+	watchMaxBackoff = time.Minute
+)
+
+// KeychainHook is called with every event of the personal entries seen by
+// Watch, resync events included.
+type KeychainHook func(event dto.KeyEvent)
+
+type keychainHook struct {
+	fn KeychainHook
+}
+
+// OnChange registers hook to be called by Watch and returns a function that
+// removes it. Hooks run one after another on the goroutine of Watch, so a
+// slow hook delays the events after it.
+func (s *KeychainClientService) OnChange(hook KeychainHook) (remove func()) {
+	h := &keychainHook{fn: hook}
+
+	s.hooksMu.Lock()
+	s.hooks = append(s.hooks, h)
+	s.hooksMu.Unlock()
+
+	return func() {
+		s.hooksMu.Lock()
+		defer s.hooksMu.Unlock()
+
+		for i, registered := range s.hooks {
+			if registered == h {
+				s.hooks = append(s.hooks[:i:i], s.hooks[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Watch follows the event stream of the server until ctx is done and passes
+// every event to the hooks registered with OnChange. A lost connection is
+// opened again after a growing delay; other failures, such as a rejected
+// token, end Watch with their error.
+//
+// With a Replica, the replica is synced before every connection and after
+// every event, before the hooks run, so that hooks may read it. report is
+// called, if not nil, with the errors Watch recovers from: lost connections
+// and failed syncs.
+func (s *KeychainClientService) Watch(ctx context.Context, report func(err error)) error {
+	if report == nil {
+		report = func(error) {}
+	}
+
+	backoff := watchMinBackoff
+	for {
+		s.syncReplica(ctx, report)
+
+		err := s.API.WatchEvents(ctx, func(event dto.KeyEvent) {
+			backoff = watchMinBackoff
+			s.syncReplica(ctx, report)
+			s.notify(event)
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		wait := watchMinBackoff
+		if err != nil {
+			if !errors.Is(err, client_http.ErrClientRequestFailed) {
+				return err
+			}
+			report(err)
+			wait, backoff = backoff, min(backoff*2, watchMaxBackoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// syncReplica brings the local replica, if any, up to date with the server.
+func (s *KeychainClientService) syncReplica(ctx context.Context, report func(err error)) {
+	if s.Replica == nil {
+		return
+	}
+	// an unreachable server is reported by the stream itself
+	if _, err := s.Sync(ctx, KeepConflicts); err != nil && ctx.Err() == nil && !s.offline(err) {
+		report(err)
+	}
+}
+
+// notify passes event to the registered hooks.
+func (s *KeychainClientService) notify(event dto.KeyEvent) {
+	s.hooksMu.Lock()
+	hooks := append([]*keychainHook(nil), s.hooks...)
+	s.hooksMu.Unlock()
+
+	for _, h := range hooks {
+		h.fn(event)
+	}
+}
@@ -0,0 +1,63 @@
+package client_services
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeychainClientService_Watch(t *testing.T) {
+	var authorized atomic.Bool
+	authorized.Store(true)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !authorized.Load() {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(dto.ErrorResponse{ErrorText: "wrong provided auth token"})
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "event: delete\nid: 4\ndata: {\"event\":\"delete\",\"type\":\"text\",\"title\":\"note\",\"revision\":4}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, ts.URL)
+	svc := NewKeychainClientService(api.NewKeychainAPI(client), client, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []dto.KeyEvent
+	removed := 0
+	remove := svc.OnChange(func(dto.KeyEvent) { removed++ })
+	svc.OnChange(func(event dto.KeyEvent) {
+		got = append(got, event)
+		cancel()
+	})
+	remove()
+
+	if err := svc.Watch(ctx, nil); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if len(got) != 1 || got[0].Event != keychain.EventDelete || got[0].Revision != 4 || removed != 0 {
+		t.Fatalf("unexpected events: %+v, removed hook called %d times", got, removed)
+	}
+
+	authorized.Store(false)
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := svc.Watch(ctx, nil); err == nil || !strings.Contains(err.Error(), "http code 401") {
+		t.Fatalf("expected the rejected token to end Watch, got %v", err)
+	}
+}
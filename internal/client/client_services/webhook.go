@@ -0,0 +1,46 @@
+package client_services
+
+import (
+	"context"
+	"github.com/thxhix/passKeeper/internal/client/api"
+	"github.com/thxhix/passKeeper/internal/transport/client_http"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+)
+
+// WebhookClientService exposes webhook subscription management to the CLI layer.
+type WebhookClientService struct {
+	API    *api.WebhooksAPI
+	Client *client_http.Client
+}
+
+// NewWebhookClientService constructs a new WebhookClientService.
+func NewWebhookClientService(api *api.WebhooksAPI, httpClient *client_http.Client) *WebhookClientService {
+	return &WebhookClientService{
+		API:    api,
+		Client: httpClient,
+	}
+}
+
+// Create subscribes url to events, optionally only for entries with one of tags.
+func (s *WebhookClientService) Create(ctx context.Context, url string, events []string, tags []string) (*dto.CreateWebhookResponse, error) {
+	return s.API.Create(ctx, &dto.CreateWebhookRequest{
+		URL:    url,
+		Events: events,
+		Tags:   tags,
+	})
+}
+
+// List returns the webhook subscriptions.
+func (s *WebhookClientService) List(ctx context.Context) (*dto.WebhooksResponse, error) {
+	return s.API.List(ctx)
+}
+
+// Delete removes the webhook subscription with the given ID.
+func (s *WebhookClientService) Delete(ctx context.Context, webhookID string) error {
+	return s.API.Delete(ctx, webhookID)
+}
+
+// Deliveries returns the latest deliveries of the webhook subscription with the given ID.
+func (s *WebhookClientService) Deliveries(ctx context.Context, webhookID string) (*dto.WebhookDeliveriesResponse, error) {
+	return s.API.Deliveries(ctx, webhookID)
+}
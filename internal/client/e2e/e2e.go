@@ -0,0 +1,163 @@
+// Package e2e implements the client-side cryptography of end-to-end
+// encrypted sharing.
+//
+// Every user has an X25519 key pair. The private key leaves the client only
+// wrapped with a key derived from the account password, so the server stores
+// it without being able to use it. A shared entry is encrypted with a fresh
+// entry key, and the entry key is sealed for the public key of the
+// recipient. Secret links use the same symmetric encryption with the key
+// carried in the link instead.
+//
+// For account recovery the private key is additionally wrapped with a random
+// recovery key, which is split into Shamir shares held by other people, either
+// as printed words or sealed for their own key pairs.
+package e2e
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+)
+
+const (
+	// KeySize is the size of X25519 public and private keys.
+	KeySize = 32
+
+	wrapVersion = 1
+	saltSize    = 16
+
+	argonTime    = 3
+	argonMemory  = 64 * 1024
+	argonThreads = 4
+)
+
+var (
+	ErrInvalidKey    = errors.New("invalid key")
+	ErrWrongPassword = errors.New("wrong password or corrupted key material")
+	ErrCorrupted     = errors.New("encrypted data cannot be decrypted")
+)
+
+// KeyPair is an X25519 key pair used to receive shared entries.
+type KeyPair struct {
+	PublicKey  *[KeySize]byte
+	PrivateKey *[KeySize]byte
+}
+
+// GenerateKeyPair generates a new random key pair.
+func GenerateKeyPair() (*KeyPair, error) {
+	pub, priv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyPair{PublicKey: pub, PrivateKey: priv}, nil
+}
+
+// WrapPrivateKey encrypts the private key with a key derived from password.
+// The result is the key material stored by the server.
+func WrapPrivateKey(privateKey *[KeySize]byte, password string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := chacha20poly1305.NewX(deriveKey(password, salt))
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, 1+saltSize+len(nonce)+KeySize+aead.Overhead())
+	out = append(out, wrapVersion)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, privateKey[:], []byte{wrapVersion}), nil
+}
+
+// UnwrapPrivateKey reverses WrapPrivateKey and checks that the private key
+// belongs to publicKey. It returns ErrWrongPassword if the key material
+// cannot be decrypted with password.
+func UnwrapPrivateKey(keyMaterial []byte, publicKey []byte, password string) (*KeyPair, error) {
+	if len(publicKey) != KeySize {
+		return nil, ErrInvalidKey
+	}
+
+	nonceSize := chacha20poly1305.NonceSizeX
+	if len(keyMaterial) < 1+saltSize+nonceSize || keyMaterial[0] != wrapVersion {
+		return nil, ErrWrongPassword
+	}
+	salt := keyMaterial[1 : 1+saltSize]
+	nonce := keyMaterial[1+saltSize : 1+saltSize+nonceSize]
+
+	aead, err := chacha20poly1305.NewX(deriveKey(password, salt))
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := aead.Open(nil, nonce, keyMaterial[1+saltSize+nonceSize:], []byte{wrapVersion})
+	if err != nil || len(plain) != KeySize {
+		return nil, ErrWrongPassword
+	}
+
+	return keyPairFrom(plain, publicKey)
+}
+
+// keyPairFrom builds a key pair from a decrypted private key and checks that
+// it belongs to publicKey.
+func keyPairFrom(privateKey []byte, publicKey []byte) (*KeyPair, error) {
+	kp := &KeyPair{PublicKey: new([KeySize]byte), PrivateKey: new([KeySize]byte)}
+	copy(kp.PrivateKey[:], privateKey)
+	copy(kp.PublicKey[:], publicKey)
+
+	// The private key must match the published public key, otherwise
+	// entries shared with the user could not be opened.
+	derived, err := curve25519.X25519(kp.PrivateKey[:], curve25519.Basepoint)
+	if err != nil || subtle.ConstantTimeCompare(derived, publicKey) != 1 {
+		return nil, ErrInvalidKey
+	}
+
+	return kp, nil
+}
+
+// Seal encrypts plaintext for the owner of recipientPublicKey. It returns the
+// entry key sealed for the recipient, the nonce and the ciphertext.
+func Seal(recipientPublicKey []byte, plaintext []byte) (wrappedKey, nonce, data []byte, err error) {
+	if len(recipientPublicKey) != KeySize {
+		return nil, nil, nil, ErrInvalidKey
+	}
+	var pub [KeySize]byte
+	copy(pub[:], recipientPublicKey)
+
+	entryKey, nonce, data, err := SealSecret(plaintext)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	wrappedKey, err = box.SealAnonymous(nil, entryKey, &pub, rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return wrappedKey, nonce, data, nil
+}
+
+// Open decrypts an entry sealed with Seal for the key pair.
+func Open(kp *KeyPair, wrappedKey, nonce, data []byte) ([]byte, error) {
+	entryKey, ok := box.OpenAnonymous(nil, wrappedKey, kp.PublicKey, kp.PrivateKey)
+	if !ok || len(entryKey) != chacha20poly1305.KeySize {
+		return nil, ErrCorrupted
+	}
+
+	return OpenSecret(entryKey, nonce, data)
+}
+
+func deriveKey(password string, salt []byte) []byte {
+	return argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, chacha20poly1305.KeySize)
+}
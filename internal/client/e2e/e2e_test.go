@@ -0,0 +1,71 @@
+package e2e
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestWrapPrivateKey(t *testing.T) {
+	kp, err := GenerateKeyPair()
+	require.NoError(t, err)
+
+	keyMaterial, err := WrapPrivateKey(kp.PrivateKey, "correct horse")
+	require.NoError(t, err)
+	assert.NotContains(t, string(keyMaterial), string(kp.PrivateKey[:]))
+
+	got, err := UnwrapPrivateKey(keyMaterial, kp.PublicKey[:], "correct horse")
+	require.NoError(t, err)
+	assert.Equal(t, kp.PrivateKey, got.PrivateKey)
+
+	_, err = UnwrapPrivateKey(keyMaterial, kp.PublicKey[:], "wrong")
+	assert.ErrorIs(t, err, ErrWrongPassword)
+
+	other, err := GenerateKeyPair()
+	require.NoError(t, err)
+	_, err = UnwrapPrivateKey(keyMaterial, other.PublicKey[:], "correct horse")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestSealOpen(t *testing.T) {
+	recipient, err := GenerateKeyPair()
+	require.NoError(t, err)
+
+	wrappedKey, nonce, data, err := Seal(recipient.PublicKey[:], []byte(`{"text":"secret"}`))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+
+	plain, err := Open(recipient, wrappedKey, nonce, data)
+	require.NoError(t, err)
+	assert.Equal(t, `{"text":"secret"}`, string(plain))
+
+	stranger, err := GenerateKeyPair()
+	require.NoError(t, err)
+	_, err = Open(stranger, wrappedKey, nonce, data)
+	assert.ErrorIs(t, err, ErrCorrupted)
+
+	data[0] ^= 0xff
+	_, err = Open(recipient, wrappedKey, nonce, data)
+	assert.ErrorIs(t, err, ErrCorrupted)
+
+	_, _, _, err = Seal([]byte("short"), []byte("x"))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestSealOpenSecret(t *testing.T) {
+	key, nonce, data, err := SealSecret([]byte("hunter2"))
+	require.NoError(t, err)
+	assert.Len(t, key, SecretKeySize)
+
+	plain, err := OpenSecret(key, nonce, data)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", string(plain))
+
+	other, _, _, err := SealSecret(nil)
+	require.NoError(t, err)
+	_, err = OpenSecret(other, nonce, data)
+	assert.ErrorIs(t, err, ErrCorrupted)
+
+	_, err = OpenSecret(key[:8], nonce, data)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
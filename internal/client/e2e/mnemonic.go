@@ -0,0 +1,86 @@
+package e2e
+
+import (
+	"crypto/sha256"
+	"errors"
+	"strings"
+)
+
+// mnemonicChecksumSize is the number of SHA-256 bytes appended to a share
+// before it is written as words, so typos are caught before combining.
+const mnemonicChecksumSize = 2
+
+var ErrInvalidMnemonic = errors.New("invalid recovery share words")
+
+// EncodeMnemonic writes a recovery share as words, one word per byte plus a
+// checksum, so it can be printed and typed in again.
+func EncodeMnemonic(share []byte) string {
+	sum := sha256.Sum256(share)
+	data := append(append([]byte{}, share...), sum[:mnemonicChecksumSize]...)
+
+	words := make([]string, len(data))
+	for i, b := range data {
+		words[i] = mnemonicWords[b]
+	}
+	return strings.Join(words, " ")
+}
+
+// DecodeMnemonic reverses EncodeMnemonic. Case and extra whitespace are
+// ignored; unknown words and a wrong checksum return ErrInvalidMnemonic.
+func DecodeMnemonic(mnemonic string) ([]byte, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) <= mnemonicChecksumSize {
+		return nil, ErrInvalidMnemonic
+	}
+
+	data := make([]byte, len(words))
+	for i, w := range words {
+		b, ok := mnemonicIndex[w]
+		if !ok {
+			return nil, ErrInvalidMnemonic
+		}
+		data[i] = b
+	}
+
+	share := data[:len(data)-mnemonicChecksumSize]
+	sum := sha256.Sum256(share)
+	if string(sum[:mnemonicChecksumSize]) != string(data[len(share):]) {
+		return nil, ErrInvalidMnemonic
+	}
+	return share, nil
+}
+
+// mnemonicWords maps every byte value to a short, distinct English word.
+var mnemonicWords = [256]string{
+	"able", "acid", "aged", "also", "arch", "area", "army", "atom", "aunt", "away", "baby", "back",
+	"bake", "ball", "band", "bank", "barn", "base", "bath", "bead", "beam", "bean", "bear", "bell",
+	"belt", "bench", "bike", "bird", "blue", "boat", "body", "bold", "bolt", "bone", "book", "boot",
+	"bowl", "brave", "bread", "brick", "bride", "brown", "brush", "bulb", "bull", "cable", "cake",
+	"calm", "camp", "canal", "candy", "card", "cargo", "carpet", "cart", "case", "cash", "castle",
+	"cave", "cedar", "chain", "chair", "chalk", "chess", "chief", "child", "chin", "city", "clay",
+	"cliff", "clock", "cloud", "coal", "coast", "coat", "coin", "comet", "cook", "coral", "corn",
+	"cotton", "couch", "crab", "crane", "cream", "crow", "crown", "cube", "cup", "curve", "daisy",
+	"dance", "dawn", "deer", "delta", "desk", "dial", "diary", "dice", "dish", "dock", "door", "dove",
+	"dragon", "dream", "dress", "drum", "duck", "dune", "eagle", "earth", "echo", "edge", "eel",
+	"elbow", "elder", "elm", "ember", "engine", "epic", "exit", "fabric", "face", "fair", "falcon",
+	"farm", "feast", "fern", "ferry", "field", "fig", "film", "finch", "fire", "fish", "flag",
+	"flame", "flute", "foam", "fog", "forest", "fork", "fox", "frame", "frog", "frost", "fruit",
+	"gate", "gear", "gem", "ghost", "giant", "gift", "ginger", "glass", "globe", "glove", "goat",
+	"gold", "grain", "grape", "grass", "gravel", "guitar", "gull", "hammer", "harbor", "harp", "hawk",
+	"hazel", "heart", "hedge", "helmet", "herb", "hill", "honey", "hood", "horn", "horse", "hotel",
+	"house", "igloo", "iris", "iron", "island", "ivory", "ivy", "jacket", "jade", "jar", "jazz",
+	"jelly", "jewel", "judge", "juice", "jungle", "kayak", "kettle", "key", "king", "kite", "kiwi",
+	"knee", "knife", "koala", "ladder", "lake", "lamp", "lantern", "laser", "lava", "lawn", "lemon",
+	"lens", "lily", "lime", "lion", "lizard", "lobster", "lock", "lotus", "lunar", "magnet", "maple",
+	"marble", "market", "mask", "meadow", "melon", "metal", "mint", "mirror", "moon", "moss", "motor",
+	"mouse", "mule", "museum", "music", "nail", "needle", "nest", "net", "noble", "north", "nut",
+	"oak", "oasis", "ocean", "olive", "onion", "opal", "orange", "orbit", "otter", "owl",
+}
+
+var mnemonicIndex = func() map[string]byte {
+	index := make(map[string]byte, len(mnemonicWords))
+	for i, w := range mnemonicWords {
+		index[w] = byte(i)
+	}
+	return index
+}()
@@ -0,0 +1,57 @@
+package e2e
+
+import (
+	"errors"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// ErrRecoveryFailed is returned by Recover when the shares do not restore
+// the recovery key, usually because fewer than the threshold were given.
+var ErrRecoveryFailed = errors.New("the recovery shares do not restore the key; are there enough of them?")
+
+// NewRecovery wraps the private key of kp with a fresh recovery key and
+// splits that key into n shares of which threshold restore it. The returned
+// key material is stored by the server; without the shares it cannot open it.
+func NewRecovery(kp *KeyPair, n, threshold int) (keyMaterial []byte, shares [][]byte, err error) {
+	recoveryKey, nonce, data, err := SealSecret(kp.PrivateKey[:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shares, err = SplitSecret(recoveryKey, n, threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(nonce, data...), shares, nil
+}
+
+// Recover restores the key pair of publicKey from key material made by
+// NewRecovery and at least threshold of its shares.
+func Recover(keyMaterial []byte, publicKey []byte, shares [][]byte) (*KeyPair, error) {
+	if len(publicKey) != KeySize {
+		return nil, ErrInvalidKey
+	}
+	if len(keyMaterial) < chacha20poly1305.NonceSizeX {
+		return nil, ErrCorrupted
+	}
+
+	recoveryKey, err := CombineShares(shares)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, data := keyMaterial[:chacha20poly1305.NonceSizeX], keyMaterial[chacha20poly1305.NonceSizeX:]
+	plain, err := OpenSecret(recoveryKey, nonce, data)
+	if errors.Is(err, ErrCorrupted) || errors.Is(err, ErrInvalidKey) {
+		return nil, ErrRecoveryFailed
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(plain) != KeySize {
+		return nil, ErrCorrupted
+	}
+
+	return keyPairFrom(plain, publicKey)
+}
@@ -0,0 +1,83 @@
+package e2e
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestSplitCombineSecret(t *testing.T) {
+	secret := []byte("a recovery key of thirty-two byte")
+
+	shares, err := SplitSecret(secret, 5, 3)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+		var picked [][]byte
+		for _, i := range subset {
+			picked = append(picked, shares[i])
+		}
+		got, err := CombineShares(picked)
+		require.NoError(t, err)
+		assert.Equal(t, secret, got, "shares %v", subset)
+	}
+
+	got, err := CombineShares(shares[:2])
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, got)
+
+	_, err = CombineShares([][]byte{shares[0], shares[0], shares[1]})
+	assert.ErrorIs(t, err, ErrInvalidShares)
+	_, err = CombineShares([][]byte{shares[0], shares[1][:4]})
+	assert.ErrorIs(t, err, ErrInvalidShares)
+
+	_, err = SplitSecret(secret, 3, 4)
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+	_, err = SplitSecret(secret, 3, 1)
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+}
+
+func TestMnemonic(t *testing.T) {
+	share := []byte{1, 0, 255, 42, 7}
+
+	words := EncodeMnemonic(share)
+	got, err := DecodeMnemonic("  " + words + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, share, got)
+
+	got, err = DecodeMnemonic(strings.ToUpper(words))
+	require.NoError(t, err)
+	assert.Equal(t, share, got)
+
+	_, err = DecodeMnemonic("able " + words)
+	assert.ErrorIs(t, err, ErrInvalidMnemonic)
+	_, err = DecodeMnemonic(words + " xylophone")
+	assert.ErrorIs(t, err, ErrInvalidMnemonic)
+	_, err = DecodeMnemonic("able")
+	assert.ErrorIs(t, err, ErrInvalidMnemonic)
+}
+
+func TestRecover(t *testing.T) {
+	kp, err := GenerateKeyPair()
+	require.NoError(t, err)
+
+	keyMaterial, shares, err := NewRecovery(kp, 3, 2)
+	require.NoError(t, err)
+	assert.NotContains(t, string(keyMaterial), string(kp.PrivateKey[:]))
+
+	restored, err := Recover(keyMaterial, kp.PublicKey[:], [][]byte{shares[2], shares[0]})
+	require.NoError(t, err)
+	assert.Equal(t, kp.PrivateKey, restored.PrivateKey)
+
+	other, _, err := NewRecovery(kp, 3, 2)
+	require.NoError(t, err)
+	_, err = Recover(other, kp.PublicKey[:], shares[:2])
+	assert.ErrorIs(t, err, ErrRecoveryFailed)
+
+	stranger, err := GenerateKeyPair()
+	require.NoError(t, err)
+	_, err = Recover(keyMaterial, stranger.PublicKey[:], shares[:2])
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
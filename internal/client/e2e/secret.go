@@ -0,0 +1,51 @@
+package e2e
+
+import (
+	"crypto/rand"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// SecretKeySize is the size of the key of a secret sealed with SealSecret.
+const SecretKeySize = chacha20poly1305.KeySize
+
+// SealSecret encrypts plaintext with a fresh random key, for secrets whose
+// key is handed over out of band, such as in the fragment of a link.
+func SealSecret(plaintext []byte) (key, nonce, data []byte, err error) {
+	key = make([]byte, SecretKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, nil, err
+	}
+
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return key, nonce, aead.Seal(nil, nonce, plaintext, nil), nil
+}
+
+// OpenSecret decrypts a secret sealed with SealSecret.
+func OpenSecret(key, nonce, data []byte) ([]byte, error) {
+	if len(key) != SecretKeySize {
+		return nil, ErrInvalidKey
+	}
+
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, ErrCorrupted
+	}
+
+	plain, err := aead.Open(nil, nonce, data, nil)
+	if err != nil {
+		return nil, ErrCorrupted
+	}
+	return plain, nil
+}
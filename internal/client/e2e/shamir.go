@@ -0,0 +1,114 @@
+package e2e
+
+import (
+	"crypto/rand"
+	"errors"
+)
+
+// MaxShares is the largest number of shares a secret can be split into; share
+// indexes are the non-zero elements of GF(256).
+const MaxShares = 255
+
+var (
+	ErrInvalidThreshold = errors.New("threshold must be at least 2 and at most the number of shares")
+	ErrInvalidShares    = errors.New("recovery shares are malformed, duplicated or of different secrets")
+)
+
+// SplitSecret splits secret into n shares with Shamir's scheme over GF(256),
+// so that any threshold of them restore it and fewer reveal nothing. Each
+// share is its index followed by one byte per byte of secret.
+func SplitSecret(secret []byte, n, threshold int) ([][]byte, error) {
+	if threshold < 2 || threshold > n || n > MaxShares {
+		return nil, ErrInvalidThreshold
+	}
+	if len(secret) == 0 {
+		return nil, ErrInvalidKey
+	}
+
+	shares := make([][]byte, n)
+	for i := range shares {
+		shares[i] = make([]byte, 1+len(secret))
+		shares[i][0] = byte(i + 1)
+	}
+
+	// One random polynomial of degree threshold-1 per secret byte, with the
+	// secret byte as constant term.
+	coeffs := make([]byte, threshold)
+	for j, b := range secret {
+		if _, err := rand.Read(coeffs[1:]); err != nil {
+			return nil, err
+		}
+		coeffs[0] = b
+
+		for _, share := range shares {
+			share[1+j] = evalPolynomial(coeffs, share[0])
+		}
+	}
+
+	return shares, nil
+}
+
+// CombineShares restores a secret from shares made by SplitSecret. Given
+// fewer shares than the threshold it returns a wrong secret, which callers
+// detect by the authentication of what the secret protects.
+func CombineShares(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, ErrInvalidShares
+	}
+
+	size := len(shares[0])
+	seen := make(map[byte]bool, len(shares))
+	for _, share := range shares {
+		if len(share) < 2 || len(share) != size || share[0] == 0 || seen[share[0]] {
+			return nil, ErrInvalidShares
+		}
+		seen[share[0]] = true
+	}
+
+	// Lagrange interpolation at x = 0.
+	secret := make([]byte, size-1)
+	for i, si := range shares {
+		basis := byte(1)
+		for k, sk := range shares {
+			if k != i {
+				basis = gfMul(basis, gfDiv(sk[0], sk[0]^si[0]))
+			}
+		}
+		for j := range secret {
+			secret[j] ^= gfMul(si[1+j], basis)
+		}
+	}
+
+	return secret, nil
+}
+
+// evalPolynomial evaluates the polynomial with coefficients coeffs, lowest
+// degree first, at x.
+func evalPolynomial(coeffs []byte, x byte) byte {
+	var y byte
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		y = gfMul(y, x) ^ coeffs[i]
+	}
+	return y
+}
+
+// gfMul multiplies in GF(256) with the AES polynomial x^8+x^4+x^3+x+1,
+// without branches on the operands.
+func gfMul(a, b byte) byte {
+	var p byte
+	for i := 0; i < 8; i++ {
+		p ^= a & -(b & 1)
+		a = a<<1 ^ 0x1b&-(a>>7)
+		b >>= 1
+	}
+	return p
+}
+
+// gfDiv divides a by the non-zero b in GF(256), using b^254 = b^-1.
+func gfDiv(a, b byte) byte {
+	inv := byte(1)
+	for i := 0; i < 254; i++ {
+		inv = gfMul(inv, b)
+	}
+	return gfMul(a, inv)
+}
@@ -0,0 +1,236 @@
+// Package replica keeps an encrypted local copy of the keychain entries the
+// user sees, personal ones and those of their shared vaults, so the CLI can
+// read them while the server is unreachable and queue the changes made
+// meanwhile.
+//
+// The replica is a single file encrypted with XChaCha20-Poly1305. Its key is
+// a random key held in the OS keyring or, where no keyring is available, a
+// key derived with Argon2id from a passphrase. The file is brought up to date
+// through the delta sync API of the server: it remembers the revision of the
+// user it has seen and only asks for the changes after it.
+package replica
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"sort"
+	"time"
+)
+
+var ErrNotFound = errors.New("entry not found in the local replica")
+
+// Entry is an entry as last seen on the server, or added while offline when
+// Pending is set. Vault is set on the entries of shared vaults. Data is the
+// entry data as the server returns it in dto.GetKeyResponse.
+type Entry struct {
+	KeyUUID   uuid.UUID        `json:"uuid"`
+	Vault     *uuid.UUID       `json:"vault,omitempty"`
+	KeyType   keychain.KeyType `json:"type"`
+	Title     string           `json:"title"`
+	Tags      []string         `json:"tags,omitempty"`
+	Revision  int64            `json:"revision"`
+	Data      json.RawMessage  `json:"data,omitempty"`
+	Pending   bool             `json:"pending,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// OpKind is the kind of a queued change.
+type OpKind string
+
+const (
+	OpAdd    OpKind = "add"
+	OpDelete OpKind = "delete"
+)
+
+// Op is a change made while the server was unreachable, waiting to be sent.
+//
+// An add carries the request DTO of its entry type in Payload, and the file
+// content in File for files; its KeyUUID is the one of the pending entry.
+// A delete of a vault entry carries the vault in Vault.
+// BaseRevision is the revision the change was made against: the revision of
+// the replica for an add, the revision of the entry for a delete. Conflict is
+// set once the change was found to conflict with one made on the server.
+type Op struct {
+	Kind         OpKind           `json:"kind"`
+	KeyUUID      uuid.UUID        `json:"uuid"`
+	Vault        *uuid.UUID       `json:"vault,omitempty"`
+	KeyType      keychain.KeyType `json:"type,omitempty"`
+	Title        string           `json:"title"`
+	Payload      json.RawMessage  `json:"payload,omitempty"`
+	File         []byte           `json:"file,omitempty"`
+	BaseRevision int64            `json:"base_revision"`
+	Conflict     string           `json:"conflict,omitempty"`
+	QueuedAt     time.Time        `json:"queued_at"`
+}
+
+// State is the content of the replica. Account names the server and the
+// user the entries belong to.
+type State struct {
+	Account  string               `json:"account"`
+	Revision int64                `json:"revision"`
+	Entries  map[uuid.UUID]*Entry `json:"entries"`
+	Queue    []*Op                `json:"queue,omitempty"`
+	SyncedAt time.Time            `json:"synced_at"`
+}
+
+// NewState returns an empty replica of account.
+func NewState(account string) *State {
+	return &State{Account: account, Entries: make(map[uuid.UUID]*Entry)}
+}
+
+// Apply merges changes fetched from the server and moves the replica to
+// their revision. Tombstones remove their entries.
+func (st *State) Apply(changes dto.ChangesResponse) {
+	for _, ch := range changes.Changes {
+		if ch.Deleted {
+			delete(st.Entries, ch.KeyUUID)
+			continue
+		}
+		st.Entries[ch.KeyUUID] = &Entry{
+			KeyUUID:   ch.KeyUUID,
+			Vault:     ch.Vault,
+			KeyType:   ch.KeyType,
+			Title:     ch.Title,
+			Tags:      ch.Tags,
+			Revision:  ch.Revision,
+			Data:      ch.Data,
+			CreatedAt: ch.CreatedAt,
+			UpdatedAt: ch.UpdatedAt,
+		}
+	}
+	st.Revision = changes.Revision
+}
+
+// List returns the entries of the vault, or the personal entries when vault
+// is uuid.Nil, of keyType, or of any type when it is empty, as they are
+// after the queued changes, newest first.
+func (st *State) List(vault uuid.UUID, keyType keychain.KeyType) []*Entry {
+	list := make([]*Entry, 0, len(st.Entries))
+	for _, e := range st.Entries {
+		if e.InVault(vault) && (keyType == "" || e.KeyType == keyType) && !st.deleting(e.KeyUUID) {
+			list = append(list, e)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.After(list[j].CreatedAt) })
+	return list
+}
+
+// Get returns the entry keyUUID as it is after the queued changes.
+func (st *State) Get(keyUUID uuid.UUID) (*Entry, error) {
+	e, ok := st.Entries[keyUUID]
+	if !ok || st.deleting(keyUUID) {
+		return nil, ErrNotFound
+	}
+	return e, nil
+}
+
+// QueueAdd queues the addition of an entry and shows it as pending until
+// it is sent. data is the entry data as it will be returned by the server.
+func (st *State) QueueAdd(keyType keychain.KeyType, title string, tags []string, payload json.RawMessage, file []byte, data json.RawMessage) *Op {
+	now := time.Now()
+	op := &Op{
+		Kind:         OpAdd,
+		KeyUUID:      uuid.New(),
+		KeyType:      keyType,
+		Title:        title,
+		Payload:      payload,
+		File:         file,
+		BaseRevision: st.Revision,
+		QueuedAt:     now,
+	}
+
+	st.Queue = append(st.Queue, op)
+	st.Entries[op.KeyUUID] = &Entry{
+		KeyUUID:   op.KeyUUID,
+		KeyType:   keyType,
+		Title:     title,
+		Tags:      tags,
+		Data:      data,
+		Pending:   true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return op
+}
+
+// QueueDelete queues the deletion of an entry. Deleting an entry that is
+// still pending drops its addition instead.
+func (st *State) QueueDelete(keyUUID uuid.UUID) error {
+	e, err := st.Get(keyUUID)
+	if err != nil {
+		return err
+	}
+
+	if e.Pending {
+		st.Remove(st.find(OpAdd, keyUUID))
+		return nil
+	}
+
+	st.Queue = append(st.Queue, &Op{
+		Kind:         OpDelete,
+		KeyUUID:      keyUUID,
+		Vault:        e.Vault,
+		KeyType:      e.KeyType,
+		Title:        e.Title,
+		BaseRevision: e.Revision,
+		QueuedAt:     time.Now(),
+	})
+	return nil
+}
+
+// InVault reports whether the entry belongs to the vault, or is a personal
+// entry when vault is uuid.Nil.
+func (e *Entry) InVault(vault uuid.UUID) bool {
+	if e.Vault == nil {
+		return vault == uuid.Nil
+	}
+	return *e.Vault == vault
+}
+
+// Remove drops op from the queue, together with the pending entry of an add.
+func (st *State) Remove(op *Op) {
+	if op == nil {
+		return
+	}
+
+	for i, queued := range st.Queue {
+		if queued == op {
+			st.Queue = append(st.Queue[:i], st.Queue[i+1:]...)
+			break
+		}
+	}
+	if op.Kind == OpAdd {
+		delete(st.Entries, op.KeyUUID)
+	}
+}
+
+// Conflicts returns the queued changes that conflict with the server.
+func (st *State) Conflicts() []*Op {
+	var ops []*Op
+	for _, op := range st.Queue {
+		if op.Conflict != "" {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// deleting reports whether a deletion of keyUUID is queued.
+func (st *State) deleting(keyUUID uuid.UUID) bool {
+	return st.find(OpDelete, keyUUID) != nil
+}
+
+func (st *State) find(kind OpKind, keyUUID uuid.UUID) *Op {
+	for _, op := range st.Queue {
+		if op.Kind == kind && op.KeyUUID == keyUUID {
+			return op
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package replica
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func passphrase(p string) func() (string, error) {
+	return func() (string, error) { return p, nil }
+}
+
+func TestStore_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "replica.json")
+
+	empty, err := NewStore(path, false, passphrase("secret")).Load()
+	require.NoError(t, err)
+	assert.Empty(t, empty.Entries)
+
+	st := NewState("http://server#1")
+	st.QueueAdd(keychain.KeyText, "note", []string{"home"}, json.RawMessage(`{"title":"note"}`), nil, json.RawMessage(`{"text":"hunter2"}`))
+	require.NoError(t, NewStore(path, false, passphrase("secret")).Save(st))
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "hunter2")
+
+	got, err := NewStore(path, false, passphrase("secret")).Load()
+	require.NoError(t, err)
+	assert.Equal(t, st.Account, got.Account)
+	assert.Len(t, got.Entries, 1)
+	assert.Len(t, got.Queue, 1)
+
+	_, err = NewStore(path, false, passphrase("wrong")).Load()
+	assert.ErrorIs(t, err, ErrLocked)
+	_, err = NewStore(path, false, passphrase("")).Load()
+	assert.ErrorIs(t, err, ErrNoKey)
+
+	require.NoError(t, NewStore(path, false, nil).Remove())
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestState_ApplyAndQueue(t *testing.T) {
+	st := NewState("account")
+	kept, gone := uuid.New(), uuid.New()
+	now := time.Now()
+
+	st.Apply(dto.ChangesResponse{Revision: 2, Changes: []*dto.KeyChange{
+		{KeyUUID: kept, KeyType: keychain.KeyText, Title: "kept", Revision: 1, CreatedAt: now},
+		{KeyUUID: gone, KeyType: keychain.KeyText, Title: "gone", Revision: 2, CreatedAt: now.Add(time.Second)},
+	}})
+	st.Apply(dto.ChangesResponse{Revision: 3, Changes: []*dto.KeyChange{{KeyUUID: gone, Revision: 3, Deleted: true}}})
+
+	assert.Equal(t, int64(3), st.Revision)
+	if list := st.List(uuid.Nil, ""); assert.Len(t, list, 1) {
+		assert.Equal(t, kept, list[0].KeyUUID)
+	}
+
+	op := st.QueueAdd(keychain.KeyText, "offline", nil, nil, nil, nil)
+	assert.Equal(t, int64(3), op.BaseRevision)
+	assert.Len(t, st.List(uuid.Nil, keychain.KeyText), 2)
+	assert.Empty(t, st.List(uuid.Nil, keychain.KeyCredential))
+
+	// deleting a pending entry drops its addition
+	require.NoError(t, st.QueueDelete(op.KeyUUID))
+	assert.Empty(t, st.Queue)
+
+	require.NoError(t, st.QueueDelete(kept))
+	assert.Empty(t, st.List(uuid.Nil, ""))
+	_, err := st.Get(kept)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, st.QueueDelete(kept), ErrNotFound)
+	assert.Equal(t, int64(1), st.Queue[0].BaseRevision)
+}
+
+func TestState_VaultEntries(t *testing.T) {
+	st := NewState("account")
+	vault, personal, shared := uuid.New(), uuid.New(), uuid.New()
+
+	st.Apply(dto.ChangesResponse{Revision: 2, Changes: []*dto.KeyChange{
+		{KeyUUID: personal, KeyType: keychain.KeyText, Title: "mine", Revision: 1},
+		{KeyUUID: shared, Vault: &vault, KeyType: keychain.KeyText, Title: "team", Revision: 2},
+	}})
+
+	if list := st.List(uuid.Nil, ""); assert.Len(t, list, 1) {
+		assert.Equal(t, personal, list[0].KeyUUID)
+	}
+	if list := st.List(vault, ""); assert.Len(t, list, 1) {
+		assert.Equal(t, shared, list[0].KeyUUID)
+	}
+
+	// the deletion of a vault entry is sent to its vault
+	require.NoError(t, st.QueueDelete(shared))
+	if assert.Len(t, st.Queue, 1) {
+		assert.Equal(t, &vault, st.Queue[0].Vault)
+	}
+
+	// losing access to the vault removes its entries
+	st.Apply(dto.ChangesResponse{Revision: 3, Changes: []*dto.KeyChange{{KeyUUID: shared, Vault: &vault, Revision: 3, Deleted: true}}})
+	assert.Empty(t, st.List(vault, ""))
+}
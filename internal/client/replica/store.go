@@ -0,0 +1,220 @@
+package replica
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/client/token"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"os"
+	"path/filepath"
+)
+
+const (
+	fileVersion = 1
+
+	kdfKeyring = "keyring"
+	kdfArgon2  = "argon2id"
+
+	saltSize = 16
+
+	argonTime    = 3
+	argonMemory  = 64 * 1024
+	argonThreads = 4
+)
+
+var (
+	ErrNoKey  = errors.New("no keyring and no passphrase to protect the local replica")
+	ErrLocked = errors.New("the local replica cannot be decrypted: wrong passphrase, lost keyring key or corrupted file")
+)
+
+// envelope is the file format of the replica.
+type envelope struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt,omitempty"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// Store reads and writes the replica file at path.
+//
+// A new replica is protected with a random key kept in the OS keyring when
+// useKeyring is set and the keyring works; otherwise its key is derived from
+// the passphrase returned by passphrase, which may prompt for it. The key is
+// resolved once and kept for the lifetime of the Store.
+type Store struct {
+	path       string
+	useKeyring bool
+	passphrase func() (string, error)
+
+	kdf  string
+	salt []byte
+	key  []byte
+}
+
+// NewStore returns a Store of the replica file at path.
+func NewStore(path string, useKeyring bool, passphrase func() (string, error)) *Store {
+	return &Store{path: path, useKeyring: useKeyring, passphrase: passphrase}
+}
+
+// Load reads and decrypts the replica. Without a replica file it returns an
+// empty state with no account.
+func (s *Store) Load() (*State, error) {
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewState(""), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil || env.Version != fileVersion {
+		return nil, ErrLocked
+	}
+
+	if s.key == nil {
+		if err := s.resolveKey(env.KDF, env.Salt); err != nil {
+			return nil, err
+		}
+	}
+
+	aead, err := chacha20poly1305.NewX(s.key)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, ErrLocked
+	}
+
+	plain, err := aead.Open(nil, env.Nonce, env.Data, s.additionalData())
+	if err != nil {
+		// forget the wrong key so that a later Save cannot use it
+		s.key, s.salt, s.kdf = nil, nil, ""
+		return nil, ErrLocked
+	}
+
+	st := NewState("")
+	if err := json.Unmarshal(plain, st); err != nil {
+		return nil, ErrLocked
+	}
+	if st.Entries == nil {
+		st.Entries = make(map[uuid.UUID]*Entry)
+	}
+
+	return st, nil
+}
+
+// Save encrypts st and replaces the replica file with it.
+func (s *Store) Save(st *State) error {
+	if s.key == nil {
+		if err := s.newKey(); err != nil {
+			return err
+		}
+	}
+
+	plain, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+
+	aead, err := chacha20poly1305.NewX(s.key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(envelope{
+		Version: fileVersion,
+		KDF:     s.kdf,
+		Salt:    s.salt,
+		Nonce:   nonce,
+		Data:    aead.Seal(nil, nonce, plain, s.additionalData()),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+
+	// write aside and rename, so an interrupted write never loses the replica
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Remove deletes the replica file and its keyring key.
+func (s *Store) Remove() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	s.key, s.salt, s.kdf = nil, nil, ""
+
+	if s.useKeyring {
+		return token.DeleteReplicaKey()
+	}
+	return nil
+}
+
+// newKey picks the key of a new replica file.
+func (s *Store) newKey() error {
+	if s.useKeyring {
+		if key, err := token.LoadOrCreateReplicaKey(); err == nil {
+			s.kdf, s.key = kdfKeyring, key
+			return nil
+		}
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	return s.resolveKey(kdfArgon2, salt)
+}
+
+// resolveKey restores the key of a replica file written with kdf and salt.
+func (s *Store) resolveKey(kdf string, salt []byte) error {
+	switch kdf {
+	case kdfKeyring:
+		key, err := token.LoadReplicaKey()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrLocked, err)
+		}
+		s.key = key
+
+	case kdfArgon2:
+		if s.passphrase == nil || len(salt) != saltSize {
+			return ErrNoKey
+		}
+		passphrase, err := s.passphrase()
+		if err != nil {
+			return err
+		}
+		if passphrase == "" {
+			return ErrNoKey
+		}
+		s.key = argon2.IDKey([]byte(passphrase), salt, argonTime, argonMemory, argonThreads, chacha20poly1305.KeySize)
+
+	default:
+		return ErrLocked
+	}
+
+	s.kdf, s.salt = kdf, salt
+	return nil
+}
+
+// additionalData binds the ciphertext to the file format and key source.
+func (s *Store) additionalData() []byte {
+	return []byte(fmt.Sprintf("passkeeper-replica/%d/%s", fileVersion, s.kdf))
+}
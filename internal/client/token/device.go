@@ -0,0 +1,94 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/device"
+	"github.com/zalando/go-keyring"
+	"os"
+)
+
+const (
+	keyringDeviceUser = "device"
+
+	// maxDeviceNameLength matches the device name limit of the server.
+	maxDeviceNameLength = 64
+)
+
+// ErrInvalidDeviceKey is returned by Device.Sign when the stored private key
+// cannot be decoded.
+var ErrInvalidDeviceKey = errors.New("invalid device private key")
+
+// Device is the identity of this client installation. It is generated on
+// first use and kept in the keyring next to the session tokens, so the server
+// recognises the device on later logins. Keys are base64-encoded Ed25519 keys.
+type Device struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	PublicKey  string    `json:"public_key"`
+	PrivateKey string    `json:"private_key"`
+}
+
+// LoadOrCreateDevice returns the stored device identity, generating and
+// storing a new one on first use.
+func LoadOrCreateDevice() (Device, error) {
+	var d Device
+	s, err := keyring.Get(keyringService, keyringDeviceUser)
+	if err == nil {
+		if err := json.Unmarshal([]byte(s), &d); err != nil {
+			return d, err
+		}
+		return d, nil
+	}
+	if !errors.Is(err, keyring.ErrNotFound) {
+		return d, err
+	}
+
+	d, err = newDevice()
+	if err != nil {
+		return d, err
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		return d, err
+	}
+	return d, keyring.Set(keyringService, keyringDeviceUser, string(b))
+}
+
+func newDevice() (Device, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return Device{}, err
+	}
+
+	name, err := os.Hostname()
+	if err != nil {
+		name = "passKeeper CLI"
+	}
+	if len(name) > maxDeviceNameLength {
+		name = name[:maxDeviceNameLength]
+	}
+
+	return Device{
+		ID:         uuid.New(),
+		Name:       name,
+		PublicKey:  base64.StdEncoding.EncodeToString(pub),
+		PrivateKey: base64.StdEncoding.EncodeToString(priv),
+	}, nil
+}
+
+// Sign answers the server challenge nonce and returns the base64-encoded
+// signature of device.ProofMessage made with the private key.
+func (d Device) Sign(nonce string) (string, error) {
+	priv, err := base64.StdEncoding.DecodeString(d.PrivateKey)
+	if err != nil || len(priv) != ed25519.PrivateKeySize {
+		return "", ErrInvalidDeviceKey
+	}
+	sig := ed25519.Sign(ed25519.PrivateKey(priv), device.ProofMessage(d.ID, nonce))
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
@@ -0,0 +1,82 @@
+package token
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/zalando/go-keyring"
+	"strings"
+)
+
+const (
+	keyringReplicaUser = "replica"
+
+	// ReplicaKeySize is the size of the key protecting the local replica.
+	ReplicaKeySize = 32
+)
+
+// LoadReplicaKey returns the key of the local replica stored in the keyring.
+// It returns keyring.ErrNotFound if there is none.
+func LoadReplicaKey() ([]byte, error) {
+	s, err := keyring.Get(keyringService, keyringReplicaUser)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
+
+// LoadOrCreateReplicaKey returns the stored key of the local replica,
+// generating and storing a new random one on first use.
+func LoadOrCreateReplicaKey() ([]byte, error) {
+	key, err := LoadReplicaKey()
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, keyring.ErrNotFound) {
+		return nil, err
+	}
+
+	key = make([]byte, ReplicaKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, keyring.Set(keyringService, keyringReplicaUser, base64.StdEncoding.EncodeToString(key))
+}
+
+// DeleteReplicaKey removes the key of the local replica from the keyring, if
+// there is one.
+func DeleteReplicaKey() error {
+	err := keyring.Delete(keyringService, keyringReplicaUser)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// SessionSubject returns the subject of the stored access token, which is the
+// user ID, or an empty string without a session. The token is not verified;
+// the subject only tells apart the accounts using this client.
+func SessionSubject() string {
+	tokens, err := LoadTokens()
+	if err != nil || tokens.Access == "" {
+		return ""
+	}
+
+	parts := strings.Split(tokens.Access, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+
+	var claims struct {
+		Subject string `json:"sub"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.Subject
+}
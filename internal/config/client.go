@@ -2,14 +2,26 @@ package config
 
 import (
 	"github.com/caarlos0/env/v11"
+	"os"
+	"path/filepath"
 )
 
 // ClientConfig holds the configuration for connecting to the REST server.
 //
-// The configuration can be loaded from environment variables. Currently, it
-// supports only the server address.
+// The configuration can be loaded from environment variables. AccessToken,
+// when set, is a personal access token used instead of the stored session,
+// which lets the CLI run unattended in CI jobs.
+//
+// ReplicaPath is the file of the encrypted local replica of the personal
+// entries, in the user cache directory by default. ReplicaPassphrase protects
+// it where no keyring is available; without one the CLI asks for it. The
+// replica is not kept with NoReplica set or when running with AccessToken.
 type ClientConfig struct {
-	ServerAddress string `envDefault:"http://localhost:8080"`
+	ServerAddress     string `envDefault:"http://localhost:8080"`
+	AccessToken       string `env:"PASSKEEPER_TOKEN"`
+	ReplicaPath       string `env:"PASSKEEPER_REPLICA"`
+	ReplicaPassphrase string `env:"PASSKEEPER_REPLICA_PASSPHRASE"`
+	NoReplica         bool   `env:"PASSKEEPER_NO_REPLICA"`
 }
 
 // NewClientConfig parses environment variables and returns a ClientConfig.
@@ -22,5 +34,11 @@ func NewClientConfig() (*ClientConfig, error) {
 		return nil, err
 	}
 
+	if cfg.ReplicaPath == "" {
+		if dir, err := os.UserCacheDir(); err == nil {
+			cfg.ReplicaPath = filepath.Join(dir, "passkeeper", "replica.json")
+		}
+	}
+
 	return cfg, nil
 }
@@ -1,15 +1,31 @@
 package config
 
 import (
+	"errors"
 	"flag"
 	"github.com/caarlos0/env/v11"
 )
 
+// MinSecretLength is the minimum length in bytes of the secrets that sign
+// tokens.
+const MinSecretLength = 32
+
+var (
+	// ErrMailTokenSecret is returned by NewConfig when MAIL_TOKEN_SECRET is
+	// unset or too short.
+	ErrMailTokenSecret = errors.New("MAIL_TOKEN_SECRET must be set to at least 32 bytes")
+
+	// ErrJWTRefreshSecret is returned by NewConfig when JWT_REFRESH_SECRET is
+	// unset or too short.
+	ErrJWTRefreshSecret = errors.New("JWT_REFRESH_SECRET must be set to at least 32 bytes")
+)
+
 // Config holds the application configuration loaded from environment variables
 // and optionally overridden by command-line flags.
 //
 // It includes REST server settings, database connection info, JWT configuration,
-// and encryption settings.
+// encryption, brute-force protection, password policy and hashing limits and
+// email settings.
 type Config struct {
 	// RESTAddress is the address where the REST server will listen.
 	// Loaded from the environment variable REST_ADDRESS (default: "localhost:8080").
@@ -25,27 +41,96 @@ type Config struct {
 	// MigrationsPath is the path to SQL migration files.
 	MigrationsPath string `envDefault:"file://migrations"`
 
+	// WebhookDispatchSeconds is the interval at which due webhook deliveries are sent.
+	// Loaded from WEBHOOK_DISPATCH_SECONDS (default: 5).
+	WebhookDispatchSeconds int `env:"WEBHOOK_DISPATCH_SECONDS" envDefault:"5"`
+
+	// RegistrationMode selects who may register: RegistrationOpen lets anyone
+	// register, RegistrationInvite requires an invite code.
+	// Loaded from REGISTRATION_MODE (default: "open").
+	RegistrationMode string `env:"REGISTRATION_MODE" envDefault:"open"`
+
+	// RegistrationResponse selects how registration answers:
+	// RegistrationResponseDetailed signs the new user in and reports a taken
+	// login or email with 409, RegistrationResponseUniform answers every
+	// acceptable request the same way, so it cannot be used to probe logins.
+	// Loaded from REGISTRATION_RESPONSE (default: "detailed").
+	RegistrationResponse string `env:"REGISTRATION_RESPONSE" envDefault:"detailed"`
+
 	// Embedded JWT configuration.
 	JWTConfig
 
 	// Embedded cryptography configuration.
 	CryptConfig
+
+	// Embedded brute-force protection configuration.
+	RateLimitConfig
+
+	// Embedded password policy configuration.
+	PasswordPolicyConfig
+
+	// Embedded password hashing limits.
+	PasswordHashConfig
+
+	// Embedded outgoing email configuration.
+	MailConfig
 }
 
+// Supported values of Config.RegistrationMode.
+const (
+	RegistrationOpen   = "open"
+	RegistrationInvite = "invite"
+)
+
+// Supported values of Config.RegistrationResponse.
+const (
+	RegistrationResponseDetailed = "detailed"
+	RegistrationResponseUniform  = "uniform"
+)
+
 // NewConfig parses environment variables and command-line flags to create a Config.
 //
-// Returns a pointer to Config or an error if parsing fails.
+// Returns a pointer to Config or an error if parsing fails or a required
+// secret is missing.
 func NewConfig() (*Config, error) {
 	cfg := &Config{}
 	if err := env.Parse(cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
 
 	cfg.parseFlags()
 
 	return cfg, nil
 }
 
+// NewAdminConfig parses environment variables into a Config for the
+// administration commands that open the database directly.
+//
+// Unlike NewConfig it neither parses command-line flags, which belong to the
+// command, nor requires the secrets only the running server uses.
+func NewAdminConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := env.Parse(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// validate checks the settings that have no safe default.
+func (c *Config) validate() error {
+	if len(c.JWTRefreshSecretKey) < MinSecretLength {
+		return ErrJWTRefreshSecret
+	}
+	if len(c.MailTokenSecret) < MinSecretLength {
+		return ErrMailTokenSecret
+	}
+	return nil
+}
+
 // parseFlags overrides configuration values with command-line flags if provided.
 //
 // - "-a" overrides RESTAddress
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestNewConfig_RejectsJWTRefreshSecret(t *testing.T) {
+	t.Setenv("MAIL_TOKEN_SECRET", strings.Repeat("s", MinSecretLength))
+
+	t.Run("missing", func(t *testing.T) {
+		t.Setenv("JWT_REFRESH_SECRET", "")
+		assert.NoError(t, os.Unsetenv("JWT_REFRESH_SECRET"))
+
+		cfg, err := NewConfig()
+		assert.ErrorIs(t, err, ErrJWTRefreshSecret)
+		assert.Nil(t, cfg)
+	})
+	t.Run("too short", func(t *testing.T) {
+		t.Setenv("JWT_REFRESH_SECRET", strings.Repeat("r", MinSecretLength-1))
+
+		_, err := NewConfig()
+		assert.ErrorIs(t, err, ErrJWTRefreshSecret)
+	})
+}
+
+func TestNewConfig_RejectsMailTokenSecret(t *testing.T) {
+	t.Setenv("JWT_REFRESH_SECRET", strings.Repeat("r", MinSecretLength))
+
+	t.Run("missing", func(t *testing.T) {
+		t.Setenv("MAIL_TOKEN_SECRET", "")
+		assert.NoError(t, os.Unsetenv("MAIL_TOKEN_SECRET"))
+
+		cfg, err := NewConfig()
+		assert.ErrorIs(t, err, ErrMailTokenSecret)
+		assert.Nil(t, cfg)
+	})
+	t.Run("too short", func(t *testing.T) {
+		t.Setenv("MAIL_TOKEN_SECRET", strings.Repeat("s", MinSecretLength-1))
+
+		_, err := NewConfig()
+		assert.ErrorIs(t, err, ErrMailTokenSecret)
+	})
+}
+
+func TestNewAdminConfig_WithoutServerSecrets(t *testing.T) {
+	t.Setenv("MAIL_TOKEN_SECRET", "")
+	assert.NoError(t, os.Unsetenv("MAIL_TOKEN_SECRET"))
+	t.Setenv("DATABASE_URI", "postgres://localhost/passkeeper")
+
+	cfg, err := NewAdminConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "postgres://localhost/passkeeper", cfg.PostgresQL)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := &Config{
+		JWTConfig:  JWTConfig{JWTRefreshSecretKey: strings.Repeat("r", MinSecretLength)},
+		MailConfig: MailConfig{MailTokenSecret: strings.Repeat("s", MinSecretLength)},
+	}
+	assert.NoError(t, cfg.validate())
+}
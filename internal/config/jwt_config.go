@@ -5,18 +5,33 @@ package config
 // JWTIssuer is the issuer field for all tokens.
 // JWTAudience is the audience field for tokens.
 //
-// JWTAccessSecretKey is the secret used for signing access tokens (must be >= 32 bytes).
+// JWTSigningAlgorithm is the algorithm of the ephemeral access-token key (EdDSA or ES256)
+// generated when JWTKeysDir is empty.
+// JWTKeysDir is the directory with PKCS#8 PEM signing keys, one <kid>.pem file per key.
+// JWTActiveKeyID is the key ID used to sign new access tokens; when empty the key
+// with the greatest ID in JWTKeysDir is used, so a key can be rotated by adding a
+// newer file and sending SIGHUP to the server. The variable is read once at
+// start; to switch a pinned key without a restart, write its ID to the file
+// "active" in JWTKeysDir, which takes precedence and is re-read on SIGHUP.
+// JWTKeyOverlapMinute is how long a rotated-out key keeps verifying tokens.
 // JWTAccessExpTimeMinute is the lifetime of access tokens in minutes.
+// JWTRevocationCacheSeconds is how long access token revocations are cached in memory.
 //
-// JWTRefreshSecretKey is the secret used for signing refresh tokens (must be >= 32 bytes).
+// JWTRefreshSecretKey is the secret used for signing refresh tokens (must be >= 32 bytes),
+// it has no default and NewConfig refuses to start without it.
 // JWTRefreshExpTimeDays is the lifetime of refresh tokens in days.
 type JWTConfig struct {
 	JWTIssuer   string `envDefault:"passKeeper"`
 	JWTAudience string `envDefault:"passKeeper-server"`
 
-	JWTAccessSecretKey     string `envDefault:"12345678901234567890123456789012"`
+	JWTSigningAlgorithm    string `env:"JWT_SIGNING_ALGORITHM" envDefault:"EdDSA"`
+	JWTKeysDir             string `env:"JWT_KEYS_DIR"`
+	JWTActiveKeyID         string `env:"JWT_ACTIVE_KEY_ID"`
+	JWTKeyOverlapMinute    int    `env:"JWT_KEY_OVERLAP_MINUTE" envDefault:"30"`
 	JWTAccessExpTimeMinute int    `envDefault:"15"`
 
-	JWTRefreshSecretKey   string `envDefault:"12345678901234567890123456789012"`
+	JWTRevocationCacheSeconds int `env:"JWT_REVOCATION_CACHE_SECONDS" envDefault:"5"`
+
+	JWTRefreshSecretKey   string `env:"JWT_REFRESH_SECRET"`
 	JWTRefreshExpTimeDays int    `envDefault:"30"`
 }
@@ -0,0 +1,27 @@
+package config
+
+// MailConfig holds the settings of outgoing email.
+//
+// Mailer selects the implementation: MailerLog writes messages to the server
+// log, which is meant for development; MailerSMTP sends them through
+// SMTPAddress (host:port), authenticating with SMTPUsername and SMTPPassword
+// when a username is set. MailFrom is the sender address.
+//
+// MailTokenSecret signs the verification and password reset links sent by
+// email. It has no default: the server refuses to start unless it is set to
+// at least MinSecretLength bytes.
+type MailConfig struct {
+	Mailer       string `env:"MAILER" envDefault:"log"`
+	SMTPAddress  string `env:"SMTP_ADDRESS" envDefault:"localhost:25"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+	MailFrom     string `env:"MAIL_FROM" envDefault:"passkeeper@localhost"`
+
+	MailTokenSecret string `env:"MAIL_TOKEN_SECRET"`
+}
+
+// Supported values of MailConfig.Mailer.
+const (
+	MailerLog  = "log"
+	MailerSMTP = "smtp"
+)
@@ -0,0 +1,12 @@
+package config
+
+// PasswordHashConfig bounds the resources spent on password hashing.
+//
+// PasswordHashConcurrency is the number of password hashes computed or
+// verified at once; every one holds the Argon2 memory cost. A request finding
+// all slots taken waits up to PasswordHashWaitMilliseconds and then fails with
+// 503. Zero disables the limit.
+type PasswordHashConfig struct {
+	PasswordHashConcurrency      int `env:"PASSWORD_HASH_CONCURRENCY" envDefault:"8"`
+	PasswordHashWaitMilliseconds int `env:"PASSWORD_HASH_WAIT_MILLISECONDS" envDefault:"1000"`
+}
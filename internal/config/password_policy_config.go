@@ -0,0 +1,15 @@
+package config
+
+// PasswordPolicyConfig holds the rules new passwords must satisfy.
+//
+// PasswordMinLength counts characters. PasswordMinClasses is the number of
+// character classes (lowercase, uppercase, digits, symbols) a password must
+// mix. PasswordBannedListPath points to a file with one banned password per
+// line; lines starting with # are ignored. PasswordMinEntropyBits is the
+// minimum estimated strength. Zero values disable a rule.
+type PasswordPolicyConfig struct {
+	PasswordMinLength      int     `env:"PASSWORD_MIN_LENGTH" envDefault:"8"`
+	PasswordMinClasses     int     `env:"PASSWORD_MIN_CLASSES" envDefault:"2"`
+	PasswordBannedListPath string  `env:"PASSWORD_BANNED_LIST_PATH"`
+	PasswordMinEntropyBits float64 `env:"PASSWORD_MIN_ENTROPY_BITS" envDefault:"30"`
+}
@@ -0,0 +1,22 @@
+package config
+
+// RateLimitConfig holds brute-force protection settings for the auth endpoints.
+//
+// AuthFreeAttempts is the number of failed attempts per IP or login allowed
+// before exponential backoff starts. The delay starts at AuthBackoffBaseSeconds,
+// doubles with every further failure and is capped at AuthBackoffMaxSeconds.
+// Counters are forgotten after AuthWindowSeconds of inactivity.
+//
+// LockoutThreshold is the number of consecutive failed logins after which the
+// account is locked; zero disables lockout. The first lockout lasts
+// LockoutBaseMinute, every following one doubles up to LockoutMaxMinute.
+type RateLimitConfig struct {
+	AuthFreeAttempts       int `env:"AUTH_FREE_ATTEMPTS" envDefault:"5"`
+	AuthBackoffBaseSeconds int `env:"AUTH_BACKOFF_BASE_SECONDS" envDefault:"1"`
+	AuthBackoffMaxSeconds  int `env:"AUTH_BACKOFF_MAX_SECONDS" envDefault:"300"`
+	AuthWindowSeconds      int `env:"AUTH_WINDOW_SECONDS" envDefault:"900"`
+
+	LockoutThreshold  int `env:"LOCKOUT_THRESHOLD" envDefault:"10"`
+	LockoutBaseMinute int `env:"LOCKOUT_BASE_MINUTE" envDefault:"15"`
+	LockoutMaxMinute  int `env:"LOCKOUT_MAX_MINUTE" envDefault:"1440"`
+}
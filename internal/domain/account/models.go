@@ -0,0 +1,37 @@
+package account
+
+import (
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/send"
+	"github.com/thxhix/passKeeper/internal/domain/share"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+	"time"
+)
+
+// Export is a full snapshot of a user's personal data.
+//
+// Entries holds the personal entries and the entries of every vault the user
+// has a role in. Sends and shares are end-to-end encrypted, so they are
+// exported as the server holds them: sends without their ciphertext, which
+// nobody but a holder of the link can open, shares the user made without the
+// payload sealed for the recipient, and received shares with their sealed
+// payload, which the user opens with their private key.
+type Export struct {
+	Login          string
+	ExportedAt     time.Time
+	Entries        []*ExportEntry
+	Sends          []*send.Send
+	SharesGiven    []*share.Share
+	SharesReceived []*share.Share
+}
+
+// ExportEntry is a single keychain entry together with its decrypted payload.
+//
+// Data holds the decrypted JSON document of the entry (CredentialData,
+// CardData, TextData or FileData depending on the record type). Vault is the
+// vault of the entry, nil for a personal entry.
+type ExportEntry struct {
+	Record *keychain.KeyRecord
+	Data   []byte
+	Vault  *vault.Vault
+}
@@ -0,0 +1,16 @@
+package admin
+
+import (
+	"errors"
+	"github.com/thxhix/passKeeper/internal/apperr"
+)
+
+var (
+	ErrAlreadyBootstrapped = errors.New("an administrator already exists, promote further admins through the API")
+
+	ErrAdminRequired = apperr.NewForbiddenError("this operation requires an administrator")
+
+	ErrSelfDisable  = apperr.NewValidationError("administrators cannot disable their own account")
+	ErrInvalidLimit = apperr.NewValidationError("limit must be between 1 and 500")
+	ErrInvalidPage  = apperr.NewValidationError("offset must not be negative")
+)
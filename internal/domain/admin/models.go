@@ -0,0 +1,34 @@
+// Package admin describes the administration of user accounts: listing
+// users, disabling them and inspecting how much they store.
+package admin
+
+import "time"
+
+// DefaultListLimit and MaxListLimit bound the number of users returned per page.
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 500
+)
+
+// UserSummary is a user as shown to administrators. It never includes
+// credentials or key material.
+type UserSummary struct {
+	ID          int64
+	Login       string
+	IsAdmin     bool
+	DisabledAt  *time.Time
+	LockedUntil *time.Time
+	CreatedAt   time.Time
+}
+
+// StorageStats describes what a user stores on the server. EntriesByType is
+// keyed by keychain type and DataBytes is the size of the encrypted entries.
+type StorageStats struct {
+	UserID          int64
+	Entries         int64
+	EntriesByType   map[string]int64
+	DataBytes       int64
+	Devices         int64
+	PersonalTokens  int64
+	ServiceAccounts int64
+}
@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"context"
+	"time"
+)
+
+// AdminRepository defines the storage operations of account administration.
+type AdminRepository interface {
+	// ListUsers returns a page of users ordered by ID.
+	ListUsers(ctx context.Context, limit int, offset int) ([]*UserSummary, error)
+
+	// StorageStats counts the entries, devices and tokens of a user.
+	//
+	// Returns user.ErrUserNotFound if no user is found.
+	StorageStats(ctx context.Context, userID int64) (*StorageStats, error)
+
+	// SetDisabled disables the user at the given time, or enables the user
+	// when at is nil.
+	//
+	// Returns user.ErrUserNotFound if no user is found.
+	SetDisabled(ctx context.Context, userID int64, at *time.Time) error
+
+	// SetAdmin grants or withdraws the administrator role of the user with
+	// the given login.
+	//
+	// Returns user.ErrUserNotFound if no user is found.
+	SetAdmin(ctx context.Context, login string, isAdmin bool) error
+
+	// GrantFirstAdmin grants the administrator role to the user with the
+	// given login unless an administrator already exists. Concurrent calls
+	// are serialized, so at most one of them succeeds.
+	//
+	// Returns ErrAlreadyBootstrapped if an administrator exists and
+	// user.ErrUserNotFound if no user is found.
+	GrantFirstAdmin(ctx context.Context, login string) error
+}
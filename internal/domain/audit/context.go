@@ -0,0 +1,23 @@
+package audit
+
+import "context"
+
+// RequestInfo describes the client of the request an event belongs to.
+type RequestInfo struct {
+	IP        string
+	UserAgent string
+}
+
+type ctxKey struct{}
+
+// WithRequestInfo returns a copy of ctx carrying the client of the request.
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, ctxKey{}, info)
+}
+
+// RequestInfoFromContext returns the client stored by the HTTP middleware, or
+// a zero RequestInfo for calls made outside of a request.
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(ctxKey{}).(RequestInfo)
+	return info
+}
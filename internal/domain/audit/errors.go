@@ -0,0 +1,15 @@
+package audit
+
+import (
+	"errors"
+	"github.com/thxhix/passKeeper/internal/apperr"
+)
+
+var (
+	ErrChainBroken = errors.New("audit log hash chain is broken")
+
+	ErrInvalidAction = apperr.NewValidationError("unknown audit action")
+	ErrInvalidResult = apperr.NewValidationError("unknown audit result, expected success, denied or failure")
+	ErrInvalidLimit  = apperr.NewValidationError("limit must be between 1 and 1000")
+	ErrInvalidRange  = apperr.NewValidationError("since must be before until")
+)
@@ -0,0 +1,134 @@
+// Package audit describes the append-only log of security relevant events:
+// who read or changed which entry and who logged in, from where and with
+// what result.
+//
+// Every event stores the hash of the previous one and its own hash over both,
+// so removing or modifying a stored event breaks the chain at that point.
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Action is the kind of an audited operation.
+type Action string
+
+const (
+	ActionLogin     Action = "login"
+	ActionRefresh   Action = "refresh"
+	ActionKeyRead   Action = "key.read"
+	ActionKeyAdd    Action = "key.add"
+	ActionKeyDelete Action = "key.delete"
+	ActionKeyUpdate Action = "key.update"
+	// ActionKeyShare and ActionKeyUnshare record sharing an entry with a
+	// user and revoking it.
+	ActionKeyShare   Action = "key.share"
+	ActionKeyUnshare Action = "key.unshare"
+	// ActionSendCreate and ActionSendView record creating a secret link and
+	// each read of it, which is anonymous and logged for the owner.
+	ActionSendCreate Action = "send.create"
+	ActionSendView   Action = "send.view"
+	// ActionEmergencyRequest and ActionEmergencyTakeover record an emergency
+	// contact requesting access and resetting the password of the account.
+	ActionEmergencyRequest  Action = "emergency.request"
+	ActionEmergencyTakeover Action = "emergency.takeover"
+	// ActionRecoverySetup records a new recovery setup of the account and
+	// ActionRecoveryReveal a holder fetching a recovery share of it.
+	ActionRecoverySetup  Action = "recovery.setup"
+	ActionRecoveryReveal Action = "recovery.reveal"
+	// ActionAccountExport records a full export of the account data.
+	ActionAccountExport Action = "account.export"
+)
+
+// Result is the outcome of an audited operation.
+type Result string
+
+const (
+	// ResultSuccess means the operation was performed.
+	ResultSuccess Result = "success"
+	// ResultDenied means the caller was authenticated but not allowed, e.g. by
+	// token scopes, device approval or account lockout.
+	ResultDenied Result = "denied"
+	// ResultFailure covers every other error, including wrong credentials.
+	ResultFailure Result = "failure"
+)
+
+// DefaultListLimit and MaxListLimit bound the number of events returned by a query.
+const (
+	DefaultListLimit = 100
+	MaxListLimit     = 1000
+)
+
+// Event is a single audit log entry.
+//
+// UserID is zero when the user is unknown, e.g. a login with a non-existent
+// login, and KeyUUID is uuid.Nil for events not related to an entry. Actor is
+// the kind of principal that acted, with the client ID for service accounts
+// and the contact UUID for emergency contacts.
+type Event struct {
+	ID        int64
+	UserID    int64
+	Actor     string
+	Action    Action
+	KeyUUID   uuid.UUID
+	IP        string
+	UserAgent string
+	Result    Result
+	CreatedAt time.Time
+	PrevHash  string
+	Hash      string
+}
+
+// Filter selects the events of a user. Zero fields do not restrict the result.
+type Filter struct {
+	Action  Action
+	KeyUUID uuid.UUID
+	Result  Result
+	Since   *time.Time
+	Until   *time.Time
+	Limit   int
+}
+
+// ComputeHash returns the chain hash of e appended after an event with hash
+// prevHash. The first event of the log uses an empty prevHash.
+//
+// CreatedAt is hashed in UTC with microsecond precision, which is what the
+// database keeps.
+func ComputeHash(prevHash string, e *Event) string {
+	payload, _ := json.Marshal(struct {
+		PrevHash  string `json:"prev_hash"`
+		UserID    int64  `json:"user_id"`
+		Actor     string `json:"actor"`
+		Action    string `json:"action"`
+		KeyUUID   string `json:"key_uuid"`
+		IP        string `json:"ip"`
+		UserAgent string `json:"user_agent"`
+		Result    string `json:"result"`
+		CreatedAt string `json:"created_at"`
+	}{
+		PrevHash:  prevHash,
+		UserID:    e.UserID,
+		Actor:     e.Actor,
+		Action:    string(e.Action),
+		KeyUUID:   e.KeyUUID.String(),
+		IP:        e.IP,
+		UserAgent: e.UserAgent,
+		Result:    string(e.Result),
+		CreatedAt: e.CreatedAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+	})
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// Verification is the result of a successful check of the whole chain.
+// HeadHash is the hash of the newest event; keeping it outside the database
+// also makes removal of the newest events detectable.
+type Verification struct {
+	Events   int64
+	HeadHash string
+}
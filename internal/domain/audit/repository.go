@@ -0,0 +1,17 @@
+package audit
+
+import "context"
+
+// AuditRepository stores audit events. Events are never updated or deleted.
+type AuditRepository interface {
+	// Append links e to the last stored event, computes its hash and stores
+	// it. Appends are serialized, so the chain has no forks.
+	Append(ctx context.Context, e *Event) error
+
+	// List returns the events of the user matching the filter, newest first.
+	List(ctx context.Context, userID int64, filter Filter) ([]*Event, error)
+
+	// Chain returns up to limit events with an ID greater than afterID in
+	// chain order.
+	Chain(ctx context.Context, afterID int64, limit int) ([]*Event, error)
+}
@@ -0,0 +1,27 @@
+package audit
+
+// ValidateFilter checks the action, result, time range and limit of a filter.
+func ValidateFilter(f Filter) error {
+	switch f.Action {
+	case "", ActionLogin, ActionRefresh, ActionKeyRead, ActionKeyAdd, ActionKeyDelete, ActionKeyUpdate, ActionKeyShare, ActionKeyUnshare, ActionSendCreate, ActionSendView,
+		ActionEmergencyRequest, ActionEmergencyTakeover, ActionRecoverySetup, ActionRecoveryReveal, ActionAccountExport:
+	default:
+		return ErrInvalidAction
+	}
+
+	switch f.Result {
+	case "", ResultSuccess, ResultDenied, ResultFailure:
+	default:
+		return ErrInvalidResult
+	}
+
+	if f.Since != nil && f.Until != nil && !f.Since.Before(*f.Until) {
+		return ErrInvalidRange
+	}
+
+	if f.Limit < 0 || f.Limit > MaxListLimit {
+		return ErrInvalidLimit
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package device
+
+import (
+	"errors"
+	"github.com/thxhix/passKeeper/internal/apperr"
+)
+
+var (
+	ErrNotFound = errors.New("device not found")
+
+	ErrApprovalRequired = apperr.NewForbiddenError("new device must be approved from a trusted device or with the one-time code sent to you")
+	ErrInvalidCode      = apperr.NewAuthError("invalid or expired device approval code")
+	ErrKeyMismatch      = apperr.NewAuthError("device public key does not match the registered one")
+	ErrInvalidProof     = apperr.NewAuthError("device signature is missing or invalid, or its challenge expired")
+
+	ErrIDEmpty          = apperr.NewValidationError("device id must be a UUID")
+	ErrNameLong         = apperr.NewValidationError("device name cannot be greater than 64 characters")
+	ErrInvalidPublicKey = apperr.NewValidationError("device public key must be a base64-encoded Ed25519 key")
+)
@@ -0,0 +1,82 @@
+// Package device describes the client installations of a user. A login from
+// an unknown device only receives tokens once the device is approved by an
+// already trusted one or with a one-time code delivered to the user.
+package device
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// MaxNameLength is the maximum length of a device name.
+const MaxNameLength = 64
+
+// ApprovalCodeTTL is how long a one-time approval code stays valid.
+const ApprovalCodeTTL = 10 * time.Minute
+
+// ChallengeTTL is how long a device challenge may be answered.
+const ChallengeTTL = 2 * time.Minute
+
+// ChallengeBytes is the amount of randomness in a device challenge.
+const ChallengeBytes = 32
+
+// Identity is what a client presents about itself on login and registration.
+//
+// ID and PublicKey are generated by the client on first start and kept next
+// to its tokens. PublicKey is a base64-encoded Ed25519 key. Nonce is a
+// challenge issued by the server for ID and Signature the base64-encoded
+// signature of ProofMessage(ID, Nonce) made with the private key, which
+// proves that the client holds it. ApprovalCode is the one-time code sent
+// for a pending device and is empty otherwise.
+type Identity struct {
+	ID           uuid.UUID
+	Name         string
+	PublicKey    string
+	Nonce        string
+	Signature    string
+	ApprovalCode string
+}
+
+// ProofMessage returns the message a device signs to answer the challenge
+// nonce. It names the device, so a signature cannot be replayed for
+// another one.
+func ProofMessage(id uuid.UUID, nonce string) []byte {
+	return []byte("passKeeper device proof\n" + id.String() + "\n" + nonce)
+}
+
+// Device represents a client device of a user as stored in the database.
+//
+// Only the SHA-256 hash of a pending approval code is stored.
+type Device struct {
+	ID                int64
+	DeviceUUID        uuid.UUID
+	UserID            int64
+	Name              string
+	PublicKey         string
+	Trusted           bool
+	ApprovalCodeHash  string
+	ApprovalExpiresAt *time.Time
+	LastSeenAt        *time.Time
+	CreatedAt         time.Time
+}
+
+// Fingerprint returns a short hex digest of a device public key, which users
+// compare before approving a device.
+func Fingerprint(publicKey string) string {
+	raw, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		raw = []byte(publicKey)
+	}
+	sum := sha256.Sum256(raw)
+	digest := hex.EncodeToString(sum[:8])
+
+	groups := make([]string, 0, len(digest)/4)
+	for i := 0; i < len(digest); i += 4 {
+		groups = append(groups, digest[i:i+4])
+	}
+	return strings.Join(groups, ":")
+}
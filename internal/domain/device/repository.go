@@ -0,0 +1,55 @@
+package device
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// DeviceRepository defines storage operations for user devices.
+type DeviceRepository interface {
+	// Create stores a device of the user, either trusted or pending approval.
+	Create(ctx context.Context, userID int64, ident Identity, trusted bool) (*Device, error)
+
+	// Get returns a device of the user by its UUID.
+	//
+	// Returns ErrNotFound if the user has no such device.
+	Get(ctx context.Context, userID int64, deviceUUID uuid.UUID) (*Device, error)
+
+	// ListByUser returns every device of the user, trusted ones first.
+	ListByUser(ctx context.Context, userID int64) ([]*Device, error)
+
+	// CountTrusted returns the number of trusted devices of the user.
+	CountTrusted(ctx context.Context, userID int64) (int, error)
+
+	// SetApprovalCode stores the hash of a new one-time approval code of a
+	// pending device, replacing the previous one.
+	SetApprovalCode(ctx context.Context, id int64, codeHash string, expiresAt time.Time) error
+
+	// Approve marks a pending device of the user as trusted and clears its
+	// approval code.
+	//
+	// Returns ErrNotFound if the user has no such pending device.
+	Approve(ctx context.Context, userID int64, deviceUUID uuid.UUID) error
+
+	// TouchLastSeen records the time of the last login from the device.
+	TouchLastSeen(ctx context.Context, id int64, at time.Time) error
+
+	// Delete removes a device of the user together with its refresh tokens.
+	//
+	// Returns ErrNotFound if the user has no such device.
+	Delete(ctx context.Context, userID int64, deviceUUID uuid.UUID) error
+
+	// CreateChallenge stores the hash of a challenge issued for the device
+	// deviceUUID, replacing any earlier one, and drops expired challenges.
+	// Challenges are issued before login, so they are not tied to a user.
+	CreateChallenge(ctx context.Context, deviceUUID uuid.UUID, nonceHash string, expiresAt time.Time) error
+
+	// ConsumeChallenge removes the challenge of the device and reports
+	// whether it matched nonceHash and had not expired at now.
+	ConsumeChallenge(ctx context.Context, deviceUUID uuid.UUID, nonceHash string, now time.Time) (bool, error)
+
+	// DeleteAllByUser removes every device of the user together with their
+	// refresh tokens and returns how many were removed.
+	DeleteAllByUser(ctx context.Context, userID int64) (int64, error)
+}
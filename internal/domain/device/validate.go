@@ -0,0 +1,39 @@
+package device
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"github.com/google/uuid"
+	"unicode/utf8"
+)
+
+// ValidateIdentity checks the device ID, the optional name and the public key
+// presented by a client.
+func ValidateIdentity(ident Identity) error {
+	if ident.ID == uuid.Nil {
+		return ErrIDEmpty
+	}
+	if utf8.RuneCountInString(ident.Name) > MaxNameLength {
+		return ErrNameLong
+	}
+	raw, err := base64.StdEncoding.DecodeString(ident.PublicKey)
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return ErrInvalidPublicKey
+	}
+	return nil
+}
+
+// VerifyProof reports whether the signature of ident was made over its nonce
+// with the private key of ident.PublicKey. Whether the nonce was issued and
+// is still unused is up to the caller.
+func VerifyProof(ident Identity) bool {
+	pub, err := base64.StdEncoding.DecodeString(ident.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return false
+	}
+	sig, err := base64.StdEncoding.DecodeString(ident.Signature)
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return false
+	}
+	return ed25519.Verify(pub, ProofMessage(ident.ID, ident.Nonce), sig)
+}
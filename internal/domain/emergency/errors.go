@@ -0,0 +1,29 @@
+package emergency
+
+import (
+	"errors"
+	"github.com/thxhix/passKeeper/internal/apperr"
+)
+
+var (
+	// ErrNotFound is also returned for contacts the user is neither the
+	// grantor nor the grantee of, so their existence is not disclosed.
+	ErrNotFound = errors.New("emergency contact not found")
+
+	// ErrStatusChanged is returned when the status of a contact changed
+	// between reading and updating it.
+	ErrStatusChanged = errors.New("emergency access status changed concurrently")
+
+	ErrNotGrantor      = apperr.NewForbiddenError("only the grantor can do this")
+	ErrNotGrantee      = apperr.NewForbiddenError("only the emergency contact can do this")
+	ErrNotGranted      = apperr.NewForbiddenError("emergency access has not been granted yet")
+	ErrTakeoverDenied  = apperr.NewForbiddenError("the emergency contact only has view access")
+	ErrReadOnly        = apperr.NewForbiddenError("emergency access is read-only")
+	ErrAlreadyPending  = apperr.NewValidationError("access was already requested")
+	ErrNoPendingAccess = apperr.NewValidationError("there is no pending or granted access request")
+
+	ErrContactWithSelf   = apperr.NewValidationError("you cannot name yourself as emergency contact")
+	ErrInvalidAccess     = apperr.NewValidationError("access must be view or takeover")
+	ErrInvalidWaitPeriod = apperr.NewValidationError("waiting period must be between 1 hour and 90 days")
+	ErrTooManyContacts   = apperr.NewValidationError("at most 10 emergency contacts are allowed")
+)
@@ -0,0 +1,95 @@
+// Package emergency describes emergency access: a user (the grantor) names
+// trusted contacts (grantees) who can request access to the personal entries
+// of the grantor. A request is granted automatically once the waiting period
+// of the contact has passed, unless the grantor rejects it first.
+package emergency
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Access is what a grantee may do once access is granted.
+type Access string
+
+const (
+	// AccessView lets the grantee read the personal entries of the grantor.
+	AccessView Access = "view"
+	// AccessTakeover additionally lets the grantee set a new password for
+	// the account of the grantor.
+	AccessTakeover Access = "takeover"
+)
+
+// ParseAccess parses an access type.
+func ParseAccess(s string) (Access, bool) {
+	switch a := Access(s); a {
+	case AccessView, AccessTakeover:
+		return a, true
+	}
+	return "", false
+}
+
+// Status is the state of the access request of a contact.
+type Status string
+
+const (
+	// StatusIdle contacts have not requested access.
+	StatusIdle Status = "idle"
+	// StatusRequested contacts are waiting for the waiting period to pass.
+	StatusRequested Status = "requested"
+	// StatusApproved contacts were granted access early by the grantor.
+	StatusApproved Status = "approved"
+	// StatusRejected contacts had their last request rejected and may
+	// request again.
+	StatusRejected Status = "rejected"
+)
+
+// DefaultWaitPeriod, MinWaitPeriod and MaxWaitPeriod bound the waiting
+// period of a contact. MaxContacts is the number of contacts a user may name.
+const (
+	DefaultWaitPeriod = 7 * 24 * time.Hour
+	MinWaitPeriod     = time.Hour
+	MaxWaitPeriod     = 90 * 24 * time.Hour
+
+	MaxContacts = 10
+)
+
+// Contact is a trusted contact of a grantor.
+//
+// RequestedAt is set while a request is pending or approved.
+type Contact struct {
+	ID           int64
+	ContactUUID  uuid.UUID
+	GrantorID    int64
+	GrantorLogin string
+	GranteeID    int64
+	GranteeLogin string
+	Access       Access
+	WaitPeriod   time.Duration
+	Status       Status
+	RequestedAt  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// GrantedAt returns when the pending or approved request of the contact
+// grants access, or nil if there is no such request.
+func (c *Contact) GrantedAt() *time.Time {
+	switch c.Status {
+	case StatusApproved:
+		return &c.UpdatedAt
+	case StatusRequested:
+		if c.RequestedAt == nil {
+			return nil
+		}
+		at := c.RequestedAt.Add(c.WaitPeriod)
+		return &at
+	}
+	return nil
+}
+
+// Granted reports whether the grantee has access at now.
+func (c *Contact) Granted(now time.Time) bool {
+	at := c.GrantedAt()
+	return at != nil && !now.Before(*at)
+}
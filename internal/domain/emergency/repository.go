@@ -0,0 +1,36 @@
+package emergency
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ContactRepository defines storage operations for emergency contacts.
+type ContactRepository interface {
+	// Upsert names the grantee as contact of the grantor, or changes the
+	// access and waiting period of an existing contact.
+	Upsert(ctx context.Context, c *Contact) (*Contact, error)
+
+	// Get returns the contact with the given UUID.
+	//
+	// Returns ErrNotFound if there is none.
+	Get(ctx context.Context, contactUUID uuid.UUID) (*Contact, error)
+
+	// ListByGrantor returns the contacts named by the user.
+	ListByGrantor(ctx context.Context, grantorID int64) ([]*Contact, error)
+
+	// ListByGrantee returns the contacts the user was named as.
+	ListByGrantee(ctx context.Context, granteeID int64) ([]*Contact, error)
+
+	// UpdateStatus moves the contact from status from to status to and sets
+	// requestedAt.
+	//
+	// Returns ErrStatusChanged if the contact is no longer in status from.
+	UpdateStatus(ctx context.Context, id int64, from Status, to Status, requestedAt *time.Time) (*Contact, error)
+
+	// Delete removes a contact the user is the grantor or the grantee of.
+	//
+	// Returns ErrNotFound if there is none.
+	Delete(ctx context.Context, userID int64, contactUUID uuid.UUID) error
+}
@@ -0,0 +1,12 @@
+package emergency
+
+import "time"
+
+// ValidateWaitPeriod checks that a waiting period is between MinWaitPeriod
+// and MaxWaitPeriod.
+func ValidateWaitPeriod(d time.Duration) error {
+	if d < MinWaitPeriod || d > MaxWaitPeriod {
+		return ErrInvalidWaitPeriod
+	}
+	return nil
+}
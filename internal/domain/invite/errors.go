@@ -0,0 +1,17 @@
+package invite
+
+import (
+	"errors"
+	"github.com/thxhix/passKeeper/internal/apperr"
+)
+
+var (
+	ErrNotFound = errors.New("invite not found")
+
+	// ErrInvalidInvite covers missing, unknown, expired, used up and
+	// login- or email-bound invites alike, so the reason is not disclosed.
+	ErrInvalidInvite = apperr.NewForbiddenError("registration requires a valid invite code")
+
+	ErrInvalidMaxUses = apperr.NewValidationError("max uses must be between 1 and 100")
+	ErrInvalidTTL     = apperr.NewValidationError("invite lifetime must be positive and at most 30 days")
+)
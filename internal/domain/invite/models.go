@@ -0,0 +1,36 @@
+// Package invite describes invite codes, which gate registration when the
+// server runs in invite-only mode.
+package invite
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CodePrefix starts every invite code.
+const CodePrefix = "pki_"
+
+// DefaultTTL, MaxTTL and MaxUsesLimit bound the invites a user can create.
+const (
+	DefaultTTL   = 7 * 24 * time.Hour
+	MaxTTL       = 30 * 24 * time.Hour
+	MaxUsesLimit = 100
+)
+
+// Invite is an invite code as stored in the database.
+//
+// Only the SHA-256 hash of the code is stored; the code itself is shown once
+// on creation. Login and Email, when set, are the only login and the only
+// email the invite registers; Email is stored normalized.
+type Invite struct {
+	ID         int64
+	InviteUUID uuid.UUID
+	CreatedBy  int64
+	CodeHash   string
+	Login      string
+	Email      string
+	MaxUses    int
+	Uses       int
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+}
@@ -0,0 +1,30 @@
+package invite
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// InviteRepository defines storage operations for invite codes.
+type InviteRepository interface {
+	// Create stores a new invite.
+	Create(ctx context.Context, inv *Invite) (*Invite, error)
+
+	// Redeem atomically takes one use of the invite with the given code hash,
+	// provided it is usable at now and admits login and email.
+	//
+	// Returns ErrInvalidInvite if no such usable invite exists.
+	Redeem(ctx context.Context, codeHash string, login string, email string, now time.Time) (*Invite, error)
+
+	// Release gives back a use taken by Redeem when the registration failed.
+	Release(ctx context.Context, id int64) error
+
+	// ListByCreator returns the invites created by the user, newest first.
+	ListByCreator(ctx context.Context, userID int64) ([]*Invite, error)
+
+	// Delete removes an invite created by the user.
+	//
+	// Returns ErrNotFound if the user has no such invite.
+	Delete(ctx context.Context, userID int64, inviteUUID uuid.UUID) error
+}
@@ -0,0 +1,19 @@
+package invite
+
+import "time"
+
+// ValidateMaxUses checks that an invite can be used between 1 and MaxUsesLimit times.
+func ValidateMaxUses(n int) error {
+	if n < 1 || n > MaxUsesLimit {
+		return ErrInvalidMaxUses
+	}
+	return nil
+}
+
+// ValidateTTL checks that an invite lifetime is positive and at most MaxTTL.
+func ValidateTTL(ttl time.Duration) error {
+	if ttl <= 0 || ttl > MaxTTL {
+		return ErrInvalidTTL
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package keychain
+
+import "github.com/google/uuid"
+
+// MaxBatchOperations is the largest number of operations in one batch.
+const MaxBatchOperations = 500
+
+// BatchOp is the kind of a batch operation.
+type BatchOp string
+
+const (
+	BatchAdd    BatchOp = "add"
+	BatchUpdate BatchOp = "update"
+	BatchDelete BatchOp = "delete"
+)
+
+// BatchMode tells what happens to a batch when some of its operations fail.
+type BatchMode string
+
+const (
+	// BatchAtomic applies every operation of the batch or none of them.
+	BatchAtomic BatchMode = "atomic"
+	// BatchBestEffort applies the operations that succeed and skips the
+	// failed ones.
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+// ParseBatchMode converts a string to a BatchMode, atomic when it is empty.
+func ParseBatchMode(s string) (BatchMode, bool) {
+	switch BatchMode(s) {
+	case "":
+		return BatchAtomic, true
+	case BatchAtomic, BatchBestEffort:
+		return BatchMode(s), true
+	default:
+		return "", false
+	}
+}
+
+// BatchWrite is a checked and encrypted operation of a batch, ready to be
+// written. KeyUUID is the entry to update or delete, or the new entry of an
+// add once written. Err is set when the write failed.
+type BatchWrite struct {
+	Op      BatchOp
+	KeyUUID uuid.UUID
+	KeyType KeyType
+	Title   string
+	Tags    []string
+	Data    []byte
+	Nonce   []byte
+	Err     error
+}
+
+// BatchResult is the outcome of one operation of a batch: applied when Err
+// is nil, left out when Err is ErrBatchAborted because another operation of
+// an atomic batch failed, failed otherwise.
+//
+// AuditErr is set when an applied operation could not be recorded in the
+// audit log. The write has persisted by then, so it does not change the
+// outcome.
+type BatchResult struct {
+	Op       BatchOp
+	KeyUUID  string
+	Err      error
+	AuditErr error
+}
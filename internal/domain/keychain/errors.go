@@ -1,6 +1,7 @@
 package keychain
 
 import (
+	"errors"
 	"github.com/thxhix/passKeeper/internal/apperr"
 )
 
@@ -14,4 +15,17 @@ var (
 	ErrCardCVVInvalid    = apperr.NewValidationError("invalid CVV, should be 3 chars")
 
 	ErrEmptyTextProvided = apperr.NewValidationError("text field is required")
+
+	ErrTooManyTags = apperr.NewValidationError("too many tags, maximum is 16")
+	ErrTagInvalid  = apperr.NewValidationError("tag must be 1-32 characters of a-z, 0-9, '-', '_' or '.'")
+
+	ErrBatchEmpty      = apperr.NewValidationError("batch has no operations")
+	ErrBatchTooLarge   = apperr.NewValidationError("batch has too many operations, maximum is 500")
+	ErrBatchMode       = apperr.NewValidationError("unknown batch mode, expected atomic or best_effort")
+	ErrBatchOp         = apperr.NewValidationError("unknown operation, expected add, update or delete")
+	ErrBatchPayload    = apperr.NewValidationError("operation needs exactly one of credential, card, text or file")
+	ErrBatchKeyUUID    = apperr.NewValidationError("operation needs the uuid of an entry")
+	ErrBatchVault      = apperr.NewValidationError("batch operations only work on personal entries")
+	ErrBatchTypeChange = apperr.NewValidationError("an update cannot change the type of an entry")
+	ErrBatchAborted    = errors.New("not applied, another operation of the atomic batch failed")
 )
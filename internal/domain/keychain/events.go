@@ -0,0 +1,66 @@
+package keychain
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// EventType is the kind of change announced by an Event.
+type EventType string
+
+const (
+	// EventAdd announces a new entry.
+	EventAdd EventType = "add"
+	// EventUpdate announces a change of an entry.
+	EventUpdate EventType = "update"
+	// EventDelete announces the deletion of an entry, or that the user lost
+	// access to the vault holding it.
+	EventDelete EventType = "delete"
+	// EventResync tells that events may have been missed, so the listeners
+	// should catch up through the delta sync. It is addressed to every user.
+	EventResync EventType = "resync"
+)
+
+// Event announces a new revision of an entry the user UserID sees: one of
+// their personal entries or, with VaultUUID set, an entry of a shared vault.
+// KeyID is the database id of the entry, used to check service account
+// grants; it is never shown to clients.
+type Event struct {
+	Type      EventType  `json:"event"`
+	UserID    int64      `json:"user_id"`
+	KeyID     int64      `json:"id"`
+	KeyUUID   uuid.UUID  `json:"uuid"`
+	VaultUUID *uuid.UUID `json:"vault_uuid"`
+	KeyType   KeyType    `json:"type"`
+	Title     string     `json:"title"`
+	Tags      []string   `json:"tags"`
+	Revision  int64      `json:"revision"`
+}
+
+// Record returns the entry of the event as far as the event describes it.
+func (e Event) Record() *KeyRecord {
+	rec := &KeyRecord{
+		ID:        e.KeyID,
+		KeyUUID:   e.KeyUUID,
+		UserID:    e.UserID,
+		VaultUUID: e.VaultUUID,
+		KeyType:   e.KeyType,
+		Title:     e.Title,
+		Tags:      e.Tags,
+		Revision:  e.Revision,
+		Deleted:   e.Type == EventDelete,
+	}
+	if e.VaultUUID != nil {
+		rec.UserID = 0
+	}
+	return rec
+}
+
+// EventListener receives the changes of entries made through any server
+// instance sharing the database.
+type EventListener interface {
+	// Listen calls handle with every change until ctx is done or the
+	// listener fails. Changes that may have been missed while reconnecting
+	// are announced with an EventResync.
+	Listen(ctx context.Context, handle func(Event)) error
+}
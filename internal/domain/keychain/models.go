@@ -58,23 +58,74 @@ type TextData struct {
 
 // FileData stores a file as byte slice for a key.
 type FileData struct {
-	File []byte `json:"-"`
+	File []byte `json:"file,omitempty"`
 	Note string `json:"note,omitempty"`
 }
 
+// MaxTags is the maximum number of tags attached to a single key.
+const MaxTags = 16
+
+// MaxTagLength is the maximum length of a single tag.
+const MaxTagLength = 32
+
 // KeyRecord represents a single key entry in the storage.
+//
+// A personal entry belongs to the user UserID; an entry of a shared vault has
+// VaultID set instead and UserID is zero. VaultUUID is only filled in by
+// KeychainRepository.GetUserChanges and in events. Revision is the revision
+// of the user at the last change of the entry seen by them; Deleted is only
+// set on the tombstones returned by KeychainRepository.GetUserChanges.
 type KeyRecord struct {
 	ID        int64
 	KeyUUID   uuid.UUID
 	UserID    int64
+	VaultID   *int64
+	VaultUUID *uuid.UUID
 	KeyType   KeyType
 	Title     string
+	Tags      []string
 	Data      []byte
 	Nonce     []byte
+	Revision  int64
+	Deleted   bool
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// MaxChanges is the largest number of changes returned at once; a client
+// asks again from the returned revision while ChangeSet.More is set.
+const MaxChanges = 500
+
+// Change is a changed entry with its decrypted data, if asked for.
+// Tombstones of deleted entries never carry data.
+type Change struct {
+	Record *KeyRecord
+	Data   []byte
+}
+
+// ChangeSet lists the changes of the entries a user sees after some
+// revision, oldest first. Revision is the revision to ask from next time.
+type ChangeSet struct {
+	Revision int64
+	More     bool
+	Changes  []Change
+}
+
+// HasTag reports whether the record is labelled with tag.
+func (r *KeyRecord) HasTag(tag string) bool {
+	for _, t := range r.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// InVault reports whether the record is an entry of a shared vault.
+func (r *KeyRecord) InVault() bool {
+	return r.VaultID != nil || r.VaultUUID != nil
+}
+
 // ParseKeyType converts a string to a KeyType.
 //
 // Returns the KeyType and true if the string is valid, or empty string and false otherwise.
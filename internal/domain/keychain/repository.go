@@ -5,6 +5,8 @@ import "context"
 // KeychainRepository defines the interface for managing user keys.
 //
 // It abstracts CRUD operations for different types of keys stored in the system.
+// The user methods only see personal entries, the vault methods only the
+// entries of the given vault.
 type KeychainRepository interface {
 	// GetUserKeys retrieves all keys for a given user.
 	//
@@ -20,13 +22,47 @@ type KeychainRepository interface {
 	// AddKey creates a new key for the user.
 	//
 	// keyType specifies the type of the key (credential, text, file, or card).
-	// title is a human-readable name for the key.
+	// title is a human-readable name for the key, tags are optional labels.
 	// data and nonce contain the encrypted key data and nonce for AEAD encryption.
 	// Returns the UUID of the created key as a string, or an error if creation failed.
-	AddKey(ctx context.Context, userID int64, keyType KeyType, title string, data []byte, nonce []byte) (string, error)
+	AddKey(ctx context.Context, userID int64, keyType KeyType, title string, tags []string, data []byte, nonce []byte) (string, error)
 
 	// DeleteKey removes a key by its UUID for a given user.
 	//
 	// Returns an error if the key does not exist or deletion failed.
 	DeleteKey(ctx context.Context, userID int64, keyUUID string) error
+
+	// WriteBatch applies writes to the personal entries of the user in order,
+	// in one transaction, setting Err on each failed write and KeyUUID on
+	// each added entry. With atomic nothing is written if any write fails;
+	// otherwise only the failed writes are left out. The returned error is
+	// only set when the transaction itself failed and nothing was written.
+	WriteBatch(ctx context.Context, userID int64, writes []*BatchWrite, atomic bool) error
+
+	// GetUserChanges retrieves at most limit entries the user sees, personal
+	// entries and entries of their vaults, deleted ones included, changed
+	// after the revision since, ordered by revision. An entry of a vault the
+	// user has lost access to is returned as deleted. Encrypted data and
+	// nonce are only loaded when withData is set. current is the revision of
+	// the user when the changes were read.
+	//
+	// Adding and deleting a key moves the revision of its user forward, or of
+	// every user with access to its vault.
+	GetUserChanges(ctx context.Context, userID int64, since int64, limit int, withData bool) (changes []*KeyRecord, current int64, err error)
+
+	// GetVaultKeys retrieves all keys of a shared vault, optionally filtered by keyType.
+	GetVaultKeys(ctx context.Context, vaultID int64, keyType *string) ([]*KeyRecord, error)
+
+	// GetVaultKey retrieves a single key of a shared vault by its UUID.
+	//
+	// Returns the KeyRecord or an error if the key is not found.
+	GetVaultKey(ctx context.Context, vaultID int64, keyUUID string) (*KeyRecord, error)
+
+	// AddVaultKey creates a new key in a shared vault, like AddKey does for a user.
+	AddVaultKey(ctx context.Context, vaultID int64, keyType KeyType, title string, tags []string, data []byte, nonce []byte) (string, error)
+
+	// DeleteVaultKey removes a key of a shared vault by its UUID.
+	//
+	// Returns an error if the key does not exist or deletion failed.
+	DeleteVaultKey(ctx context.Context, vaultID int64, keyUUID string) error
 }
@@ -64,6 +64,50 @@ func ValidateText(text string) error {
 	return nil
 }
 
+// NormalizeTags validates tags and returns them lower-cased, trimmed and
+// without duplicates, preserving the original order.
+//
+// Returns ErrTooManyTags if there are more than MaxTags distinct tags, or
+// ErrTagInvalid if a tag is empty, too long or contains other characters than
+// a-z, 0-9, '-', '_' and '.'.
+func NormalizeTags(tags []string) ([]string, error) {
+	out := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !isValidTag(tag) {
+			return nil, ErrTagInvalid
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		out = append(out, tag)
+	}
+
+	if len(out) > MaxTags {
+		return nil, ErrTooManyTags
+	}
+
+	return out, nil
+}
+
+// isValidTag reports whether tag is a non-empty, short label of allowed characters.
+func isValidTag(tag string) bool {
+	if tag == "" || len(tag) > MaxTagLength {
+		return false
+	}
+	for _, r := range tag {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // isValidLuhn validates a card number using the Luhn algorithm.
 //
 // Returns true if the number passes the Luhn checksum, false otherwise.
@@ -0,0 +1,20 @@
+package pat
+
+import (
+	"errors"
+	"github.com/thxhix/passKeeper/internal/apperr"
+)
+
+var (
+	ErrTokenNotFound = errors.New("personal access token not found")
+
+	ErrInvalidToken      = apperr.NewAuthError("invalid or expired personal access token")
+	ErrInsufficientScope = apperr.NewForbiddenError("token scope does not allow this operation")
+	ErrSessionRequired   = apperr.NewForbiddenError("this operation requires an interactive session")
+
+	ErrNameEmpty     = apperr.NewValidationError("token name cannot be empty")
+	ErrNameLong      = apperr.NewValidationError("token name cannot be greater than 64 characters")
+	ErrNoScopes      = apperr.NewValidationError("at least one scope is required")
+	ErrInvalidScope  = apperr.NewValidationError("invalid scope, expected <read|write>[:<type>][:tag:<tag>]")
+	ErrExpiresInPast = apperr.NewValidationError("expiry must be in the future")
+)
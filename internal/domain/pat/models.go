@@ -0,0 +1,36 @@
+// Package pat describes personal access tokens: long-lived, scoped bearer
+// tokens used by automation instead of an interactive login.
+package pat
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TokenPrefix starts every personal access token, which lets the server tell
+// them apart from session JWTs and makes leaked tokens easy to scan for.
+const TokenPrefix = "pkp_"
+
+// MaxNameLength is the maximum length of a token name.
+const MaxNameLength = 64
+
+// PersonalTokenRecord represents a personal access token as stored in the database.
+//
+// Only the SHA-256 hash of the token is stored; the token itself is shown
+// once on creation.
+type PersonalTokenRecord struct {
+	ID         int64
+	TokenUUID  uuid.UUID
+	UserID     int64
+	Name       string
+	TokenHash  string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// Expired reports whether the token has an expiry that is not after now.
+func (r *PersonalTokenRecord) Expired(now time.Time) bool {
+	return r.ExpiresAt != nil && !now.Before(*r.ExpiresAt)
+}
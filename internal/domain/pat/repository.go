@@ -0,0 +1,32 @@
+package pat
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// PersonalTokenRepository defines the interface for managing personal access
+// tokens in storage.
+type PersonalTokenRepository interface {
+	// Create stores a new token and returns the created record.
+	//
+	// expiresAt may be nil for a token that never expires.
+	Create(ctx context.Context, userID int64, name string, tokenHash string, scopes []string, expiresAt *time.Time) (*PersonalTokenRecord, error)
+
+	// GetByHash retrieves a token by the hash of its value.
+	//
+	// Returns ErrTokenNotFound if no token matches or its owner is disabled.
+	GetByHash(ctx context.Context, tokenHash string) (*PersonalTokenRecord, error)
+
+	// ListByUser returns every token of the user, newest first.
+	ListByUser(ctx context.Context, userID int64) ([]*PersonalTokenRecord, error)
+
+	// Revoke deletes a token of the user.
+	//
+	// Returns ErrTokenNotFound if the user has no such token.
+	Revoke(ctx context.Context, userID int64, tokenUUID uuid.UUID) error
+
+	// TouchLastUsed records that the token was used at the given moment.
+	TouchLastUsed(ctx context.Context, id int64, at time.Time) error
+}
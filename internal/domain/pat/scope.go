@@ -0,0 +1,156 @@
+package pat
+
+import (
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"strings"
+)
+
+// Action is the kind of keychain access granted by a scope.
+type Action string
+
+const (
+	// ActionRead allows listing and reading keys.
+	ActionRead Action = "read"
+	// ActionWrite allows adding and deleting keys. It does not imply ActionRead.
+	ActionWrite Action = "write"
+)
+
+// Scope grants an action on keychain entries, optionally narrowed to a key
+// type and/or to entries carrying a tag.
+//
+// The textual form is "<action>[:<type>][:tag:<tag>]", for example "read",
+// "write:text", "read:tag:prod" or "read:credential:tag:ci".
+type Scope struct {
+	Action  Action
+	KeyType keychain.KeyType
+	Tag     string
+}
+
+// Scopes is the set of scopes granted to a token. A request is allowed when
+// any of the scopes allows it.
+type Scopes []Scope
+
+// ParseScope parses the textual form of a scope.
+//
+// Returns ErrInvalidScope if the action, key type or tag is not valid.
+func ParseScope(s string) (Scope, error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(s)), ":")
+
+	var scope Scope
+
+	switch Action(parts[0]) {
+	case ActionRead, ActionWrite:
+		scope.Action = Action(parts[0])
+	default:
+		return Scope{}, ErrInvalidScope
+	}
+	parts = parts[1:]
+
+	if len(parts) > 0 && parts[0] != "tag" {
+		keyType, ok := keychain.ParseKeyType(parts[0])
+		if !ok {
+			return Scope{}, ErrInvalidScope
+		}
+		scope.KeyType = keyType
+		parts = parts[1:]
+	}
+
+	if len(parts) > 0 {
+		if len(parts) != 2 || parts[0] != "tag" {
+			return Scope{}, ErrInvalidScope
+		}
+		tags, err := keychain.NormalizeTags(parts[1:])
+		if err != nil {
+			return Scope{}, ErrInvalidScope
+		}
+		scope.Tag = tags[0]
+	}
+
+	return scope, nil
+}
+
+// ParseScopes parses a list of scopes, dropping duplicates.
+//
+// Returns ErrNoScopes if the list is empty.
+func ParseScopes(raw []string) (Scopes, error) {
+	if len(raw) == 0 {
+		return nil, ErrNoScopes
+	}
+
+	scopes := make(Scopes, 0, len(raw))
+	seen := make(map[Scope]struct{}, len(raw))
+
+	for _, s := range raw {
+		scope, err := ParseScope(s)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[scope]; ok {
+			continue
+		}
+		seen[scope] = struct{}{}
+		scopes = append(scopes, scope)
+	}
+
+	return scopes, nil
+}
+
+// String returns the textual form of the scope.
+func (s Scope) String() string {
+	out := string(s.Action)
+	if s.KeyType != "" {
+		out += ":" + s.KeyType.String()
+	}
+	if s.Tag != "" {
+		out += ":tag:" + s.Tag
+	}
+	return out
+}
+
+// Allows reports whether the scope grants action on an entry of keyType with tags.
+func (s Scope) Allows(action Action, keyType keychain.KeyType, tags []string) bool {
+	if s.Action != action {
+		return false
+	}
+	if s.KeyType != "" && s.KeyType != keyType {
+		return false
+	}
+	if s.Tag == "" {
+		return true
+	}
+	for _, tag := range tags {
+		if tag == s.Tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Strings returns the textual form of every scope.
+func (ss Scopes) Strings() []string {
+	out := make([]string, 0, len(ss))
+	for _, s := range ss {
+		out = append(out, s.String())
+	}
+	return out
+}
+
+// Allows reports whether any scope grants action on an entry of keyType with tags.
+func (ss Scopes) Allows(action Action, keyType keychain.KeyType, tags []string) bool {
+	for _, s := range ss {
+		if s.Allows(action, keyType, tags) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsAction reports whether any scope grants action on at least some entries.
+func (ss Scopes) AllowsAction(action Action) bool {
+	for _, s := range ss {
+		if s.Action == action {
+			return true
+		}
+	}
+	return false
+}
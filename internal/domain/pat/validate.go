@@ -0,0 +1,27 @@
+package pat
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidateName checks that the token name is not empty and not longer than MaxNameLength characters.
+func ValidateName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrNameEmpty
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return ErrNameLong
+	}
+	return nil
+}
+
+// ValidateExpiry checks that an optional expiry lies in the future.
+func ValidateExpiry(now time.Time, expiresAt *time.Time) error {
+	if expiresAt != nil && !expiresAt.After(now) {
+		return ErrExpiresInPast
+	}
+	return nil
+}
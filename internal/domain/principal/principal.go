@@ -0,0 +1,82 @@
+// Package principal describes who performs an authenticated request: a human
+// user with an interactive session, a personal access token acting for its
+// user, a service account acting on entries granted by its owner, or an
+// emergency contact reading the entries of the user who named them.
+package principal
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+)
+
+// Kind is the kind of identity behind a request.
+type Kind string
+
+const (
+	// KindUser is a human user authenticated with a session access token.
+	KindUser Kind = "user"
+	// KindPersonalToken is a personal access token limited by its scopes.
+	KindPersonalToken Kind = "personal_token"
+	// KindServiceAccount is a machine identity limited to granted entries.
+	KindServiceAccount Kind = "service_account"
+	// KindEmergencyContact is a trusted contact with granted emergency
+	// access, limited to reading.
+	KindEmergencyContact Kind = "emergency_contact"
+)
+
+// Principal is the authenticated identity of a request.
+//
+// UserID is always the account whose keychain is accessed; for a service
+// account it is the owner and for an emergency contact the grantor. Scopes is
+// only set for KindPersonalToken, ClientID only for KindServiceAccount and
+// ContactUUID only for KindEmergencyContact.
+type Principal struct {
+	Kind        Kind
+	UserID      int64
+	Scopes      pat.Scopes
+	ClientID    uuid.UUID
+	ContactUUID uuid.UUID
+}
+
+// User returns the principal of a human user with a session.
+func User(userID int64) Principal {
+	return Principal{Kind: KindUser, UserID: userID}
+}
+
+// PersonalToken returns the principal of a personal access token of the user.
+func PersonalToken(userID int64, scopes pat.Scopes) Principal {
+	return Principal{Kind: KindPersonalToken, UserID: userID, Scopes: scopes}
+}
+
+// ServiceAccount returns the principal of a service account owned by ownerID.
+func ServiceAccount(ownerID int64, clientID uuid.UUID) Principal {
+	return Principal{Kind: KindServiceAccount, UserID: ownerID, ClientID: clientID}
+}
+
+// EmergencyContact returns the principal of the emergency contact contactUUID
+// of the grantor.
+func EmergencyContact(grantorID int64, contactUUID uuid.UUID) Principal {
+	return Principal{Kind: KindEmergencyContact, UserID: grantorID, ContactUUID: contactUUID}
+}
+
+// Interactive reports whether the request comes from a human session, which
+// is required for account and credential management.
+func (p Principal) Interactive() bool {
+	return p.Kind == KindUser
+}
+
+type ctxKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal of the request.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, ctxKey{}, p)
+}
+
+// FromContext returns the principal stored by the auth middleware. ok is
+// false when the context carries none, e.g. for internal calls, which are
+// treated as made by the user.
+func FromContext(ctx context.Context) (p Principal, ok bool) {
+	p, ok = ctx.Value(ctxKey{}).(Principal)
+	return p, ok
+}
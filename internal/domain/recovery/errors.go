@@ -0,0 +1,24 @@
+package recovery
+
+import (
+	"errors"
+	"github.com/thxhix/passKeeper/internal/apperr"
+)
+
+var (
+	ErrNotSetUp = errors.New("account recovery is not set up")
+	// ErrNotFound is also returned for shares held by other users, so their
+	// existence is not disclosed.
+	ErrNotFound    = errors.New("recovery share not found")
+	ErrNoKeyPair   = errors.New("no sharing key pair; create one first")
+	ErrKeyMismatch = errors.New("the recovery setup is for a different key pair than the current one")
+	ErrNoPublicKey = errors.New("the holder has no sharing key yet")
+
+	ErrInvalidThreshold   = apperr.NewValidationError("threshold must be at least 2 and at most the number of shares, which is at most 16")
+	ErrTooManyHolders     = apperr.NewValidationError("there are more holders than shares")
+	ErrDuplicateHolder    = apperr.NewValidationError("every holder may hold one share")
+	ErrHolderIsSelf       = apperr.NewValidationError("you cannot hold a share of your own recovery key")
+	ErrInvalidPublicKey   = apperr.NewValidationError("public key must be 32 bytes")
+	ErrInvalidKeyMaterial = apperr.NewValidationError("key material must be between 1 and 1024 bytes")
+	ErrInvalidPayload     = apperr.NewValidationError("wrapped key, nonce and data of a share are required and must not exceed their size limits")
+)
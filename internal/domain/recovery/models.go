@@ -0,0 +1,65 @@
+// Package recovery describes Shamir-shared recovery of the vault key of an
+// account.
+//
+// The client wraps the private key of the user with a random recovery key
+// and splits that key into shares, of which a threshold restore it. The
+// server stores the wrapped private key and the shares the user gave to
+// other users, each sealed for the public key of its holder. Shares handed
+// out as printed words never reach the server. Restoring the key needs the
+// shares and happens on the client, so the server learns nothing from it.
+package recovery
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// PublicKeySize is the size of an X25519 public key.
+const PublicKeySize = 32
+
+// Bounds of a recovery setup and size limits of its sealed parts.
+const (
+	MinThreshold = 2
+	MaxShares    = 16
+
+	MaxKeyMaterial    = 1024
+	MaxWrappedKeySize = 256
+	MaxNonceSize      = 64
+	MaxShareDataSize  = 1024
+)
+
+// Setup is the recovery setup of a user.
+//
+// PublicKey is the public key of the key pair the setup restores. Shares is
+// the total number of shares, including those not held on the server.
+type Setup struct {
+	UserID      int64
+	PublicKey   []byte
+	KeyMaterial []byte
+	Threshold   int
+	Shares      int
+	Holders     []string
+	CreatedAt   time.Time
+}
+
+// Share is a recovery share sealed for the user holding it.
+type Share struct {
+	ID          int64
+	ShareUUID   uuid.UUID
+	OwnerID     int64
+	OwnerLogin  string
+	HolderID    int64
+	HolderLogin string
+	WrappedKey  []byte
+	Nonce       []byte
+	Data        []byte
+	CreatedAt   time.Time
+}
+
+// HeldShare is a share to hand to a holder in a new setup.
+type HeldShare struct {
+	Login      string
+	WrappedKey []byte
+	Nonce      []byte
+	Data       []byte
+}
@@ -0,0 +1,33 @@
+package recovery
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// RecoveryRepository defines storage operations for recovery setups and the
+// shares held by other users.
+type RecoveryRepository interface {
+	// Replace stores the setup of setup.UserID with its held shares,
+	// replacing an earlier setup and all of its shares.
+	Replace(ctx context.Context, setup *Setup, shares []*Share) (*Setup, error)
+
+	// Get returns the setup of userID with the logins of the holders.
+	//
+	// Returns ErrNotSetUp if there is none.
+	Get(ctx context.Context, userID int64) (*Setup, error)
+
+	// Delete removes the setup of userID and its shares.
+	//
+	// Returns ErrNotSetUp if there is none.
+	Delete(ctx context.Context, userID int64) error
+
+	// ListHeld returns the shares held by holderID without their sealed
+	// payload.
+	ListHeld(ctx context.Context, holderID int64) ([]*Share, error)
+
+	// GetHeld returns a share held by holderID.
+	//
+	// Returns ErrNotFound if there is no such share.
+	GetHeld(ctx context.Context, holderID int64, shareUUID uuid.UUID) (*Share, error)
+}
@@ -0,0 +1,46 @@
+package recovery
+
+// ValidateSetup checks the key pair, the share counts and the held shares of
+// a new setup.
+func ValidateSetup(publicKey []byte, keyMaterial []byte, threshold int, shares int, held []HeldShare) error {
+	if len(publicKey) != PublicKeySize {
+		return ErrInvalidPublicKey
+	}
+	if len(keyMaterial) == 0 || len(keyMaterial) > MaxKeyMaterial {
+		return ErrInvalidKeyMaterial
+	}
+	if threshold < MinThreshold || threshold > shares || shares > MaxShares {
+		return ErrInvalidThreshold
+	}
+	if len(held) > shares {
+		return ErrTooManyHolders
+	}
+
+	logins := make(map[string]bool, len(held))
+	for _, h := range held {
+		if logins[h.Login] {
+			return ErrDuplicateHolder
+		}
+		logins[h.Login] = true
+
+		if err := ValidatePayload(h.WrappedKey, h.Nonce, h.Data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidatePayload checks the sealed parts of a held share.
+func ValidatePayload(wrappedKey []byte, nonce []byte, data []byte) error {
+	if len(wrappedKey) == 0 || len(wrappedKey) > MaxWrappedKeySize {
+		return ErrInvalidPayload
+	}
+	if len(nonce) == 0 || len(nonce) > MaxNonceSize {
+		return ErrInvalidPayload
+	}
+	if len(data) == 0 || len(data) > MaxShareDataSize {
+		return ErrInvalidPayload
+	}
+	return nil
+}
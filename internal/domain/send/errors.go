@@ -0,0 +1,16 @@
+package send
+
+import (
+	"errors"
+	"github.com/thxhix/passKeeper/internal/apperr"
+)
+
+var (
+	// ErrNotFound covers unknown, expired and used up sends alike, so the
+	// reason is not disclosed.
+	ErrNotFound = errors.New("send not found")
+
+	ErrInvalidMaxViews = apperr.NewValidationError("max views must be between 1 and 100")
+	ErrInvalidTTL      = apperr.NewValidationError("send lifetime must be positive and at most 7 days")
+	ErrInvalidPayload  = apperr.NewValidationError("nonce and data are required and data must be at most 1 MiB")
+)
@@ -0,0 +1,38 @@
+// Package send describes one-time secret links: ciphertext that anyone with
+// the link can read a limited number of times before it expires.
+//
+// The decryption key travels in the URL fragment, which clients never send to
+// the server, so the server only ever holds the ciphertext.
+package send
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// DefaultTTL, MaxTTL and MaxViewsLimit bound the links a user can create.
+// MaxNonceSize and MaxDataSize bound the stored ciphertext.
+const (
+	DefaultTTL    = time.Hour
+	MaxTTL        = 7 * 24 * time.Hour
+	MaxViewsLimit = 100
+
+	MaxNonceSize = 64
+	MaxDataSize  = 1 << 20
+)
+
+// Send is a secret link as stored in the database.
+//
+// Views counts the reads so far; the send is removed by the read that
+// reaches MaxViews.
+type Send struct {
+	ID        int64
+	SendUUID  uuid.UUID
+	OwnerID   int64
+	Nonce     []byte
+	Data      []byte
+	MaxViews  int
+	Views     int
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
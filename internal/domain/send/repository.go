@@ -0,0 +1,32 @@
+package send
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// SendRepository defines storage operations for secret links.
+type SendRepository interface {
+	// Create stores a new send.
+	Create(ctx context.Context, s *Send) (*Send, error)
+
+	// Consume atomically takes one view of the send, provided it has not
+	// expired at now, and returns it with its ciphertext. The view reaching
+	// MaxViews deletes the send.
+	//
+	// Returns ErrNotFound if no such readable send exists.
+	Consume(ctx context.Context, sendUUID uuid.UUID, now time.Time) (*Send, error)
+
+	// ListByOwner returns the sends of the user, newest first, without
+	// their ciphertext.
+	ListByOwner(ctx context.Context, userID int64) ([]*Send, error)
+
+	// Delete removes a send of the user.
+	//
+	// Returns ErrNotFound if the user has no such send.
+	Delete(ctx context.Context, userID int64, sendUUID uuid.UUID) error
+
+	// DeleteExpired removes the sends that expired before now.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,30 @@
+package send
+
+import "time"
+
+// ValidateMaxViews checks that a send can be read between 1 and MaxViewsLimit times.
+func ValidateMaxViews(n int) error {
+	if n < 1 || n > MaxViewsLimit {
+		return ErrInvalidMaxViews
+	}
+	return nil
+}
+
+// ValidateTTL checks that a send lifetime is positive and at most MaxTTL.
+func ValidateTTL(ttl time.Duration) error {
+	if ttl <= 0 || ttl > MaxTTL {
+		return ErrInvalidTTL
+	}
+	return nil
+}
+
+// ValidatePayload checks the sizes of the ciphertext of a send.
+func ValidatePayload(nonce []byte, data []byte) error {
+	if len(nonce) == 0 || len(nonce) > MaxNonceSize {
+		return ErrInvalidPayload
+	}
+	if len(data) == 0 || len(data) > MaxDataSize {
+		return ErrInvalidPayload
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package serviceaccount
+
+import (
+	"errors"
+	"github.com/thxhix/passKeeper/internal/apperr"
+)
+
+var (
+	ErrNotFound       = errors.New("service account not found")
+	ErrGrantNotFound  = errors.New("service account or entry not found")
+	ErrNoAssertionKey = errors.New("service account has no public key for assertions")
+
+	ErrInvalidCredentials = apperr.NewAuthError("invalid client credentials")
+	ErrInvalidAssertion   = apperr.NewAuthError("invalid client assertion")
+
+	ErrReadOnly   = apperr.NewForbiddenError("service accounts can only read entries")
+	ErrNotGranted = apperr.NewForbiddenError("entry is not granted to the service account")
+
+	ErrNameEmpty            = apperr.NewValidationError("service account name cannot be empty")
+	ErrNameLong             = apperr.NewValidationError("service account name cannot be greater than 64 characters")
+	ErrInvalidPublicKey     = apperr.NewValidationError("public key must be a PEM-encoded Ed25519 or P-256 key")
+	ErrUnsupportedGrantType = apperr.NewValidationError("unsupported grant_type, expected client_credentials or urn:ietf:params:oauth:grant-type:jwt-bearer")
+)
@@ -0,0 +1,39 @@
+// Package serviceaccount describes service accounts: machine identities owned
+// by a human user that can only read the keychain entries granted to them.
+package serviceaccount
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SecretPrefix starts every client secret, which makes leaked secrets easy to scan for.
+const SecretPrefix = "pks_"
+
+// MaxNameLength is the maximum length of a service account name.
+const MaxNameLength = 64
+
+// Grant types accepted by the token-exchange endpoint.
+const (
+	// GrantClientCredentials authenticates with the client ID and secret.
+	GrantClientCredentials = "client_credentials"
+	// GrantJWTBearer authenticates with a JWT assertion signed by the key
+	// registered for the service account (RFC 7523).
+	GrantJWTBearer = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+)
+
+// ServiceAccount represents a service account as stored in the database.
+//
+// Only the SHA-256 hash of the client secret is stored; the secret itself is
+// shown once on creation. PublicKey is an optional PEM-encoded Ed25519 or
+// P-256 key that verifies JWT assertions.
+type ServiceAccount struct {
+	ID         int64
+	ClientID   uuid.UUID
+	OwnerID    int64
+	Name       string
+	SecretHash string
+	PublicKey  string
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
@@ -0,0 +1,49 @@
+package serviceaccount
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ServiceAccountRepository defines the interface for managing service
+// accounts and their entry grants in storage.
+type ServiceAccountRepository interface {
+	// Create stores a new service account and returns the created record.
+	//
+	// publicKey may be empty when the account only uses its client secret.
+	Create(ctx context.Context, ownerID int64, name string, secretHash string, publicKey string) (*ServiceAccount, error)
+
+	// GetByClientID retrieves a service account by its client ID.
+	//
+	// Returns ErrNotFound if no account matches or its owner is disabled.
+	GetByClientID(ctx context.Context, clientID uuid.UUID) (*ServiceAccount, error)
+
+	// ListByOwner returns every service account of the owner, newest first.
+	ListByOwner(ctx context.Context, ownerID int64) ([]*ServiceAccount, error)
+
+	// Delete removes a service account of the owner together with its grants.
+	//
+	// Returns ErrNotFound if the owner has no such account.
+	Delete(ctx context.Context, ownerID int64, clientID uuid.UUID) error
+
+	// TouchLastUsed records that the account obtained a token at the given moment.
+	TouchLastUsed(ctx context.Context, id int64, at time.Time) error
+
+	// Grant allows the service account to read an entry of its owner.
+	// Granting an entry twice is not an error.
+	//
+	// Returns ErrGrantNotFound if the owner has no such account or entry.
+	Grant(ctx context.Context, ownerID int64, clientID uuid.UUID, keyUUID uuid.UUID) error
+
+	// RevokeGrant withdraws a grant.
+	//
+	// Returns ErrGrantNotFound if the entry is not granted to the account.
+	RevokeGrant(ctx context.Context, ownerID int64, clientID uuid.UUID, keyUUID uuid.UUID) error
+
+	// ListGrants returns the UUIDs of the entries granted to a service account of the owner.
+	ListGrants(ctx context.Context, ownerID int64, clientID uuid.UUID) ([]uuid.UUID, error)
+
+	// GrantedKeyIDs returns the internal IDs of the entries granted to the service account.
+	GrantedKeyIDs(ctx context.Context, clientID uuid.UUID) ([]int64, error)
+}
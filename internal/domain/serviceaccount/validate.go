@@ -0,0 +1,18 @@
+package serviceaccount
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateName checks that the service account name is not empty and not longer than MaxNameLength characters.
+func ValidateName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrNameEmpty
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return ErrNameLong
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package share
+
+import (
+	"errors"
+	"github.com/thxhix/passKeeper/internal/apperr"
+)
+
+var (
+	// ErrNotFound is also returned for shares of other users, so their
+	// existence is not disclosed.
+	ErrNotFound      = errors.New("share not found")
+	ErrEntryNotFound = errors.New("entry not found")
+	ErrNoPublicKey   = errors.New("the user has no sharing key yet")
+	ErrNoKeyPair     = errors.New("no sharing key pair; create one first")
+
+	ErrShareWithSelf      = apperr.NewValidationError("an entry cannot be shared with yourself")
+	ErrInvalidPublicKey   = apperr.NewValidationError("public key must be 32 bytes")
+	ErrInvalidKeyMaterial = apperr.NewValidationError("key material must be between 1 and 1024 bytes")
+	ErrInvalidPayload     = apperr.NewValidationError("wrapped key, nonce and data are required and must not exceed their size limits")
+)
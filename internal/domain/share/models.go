@@ -0,0 +1,46 @@
+// Package share describes entries shared end-to-end encrypted with a single
+// user.
+//
+// The sharing client encrypts the entry with a fresh random entry key and
+// seals that key for the X25519 public key of the recipient. The server only
+// stores the sealed key, the nonce and the ciphertext; only the recipient can
+// open them with their private key, which never leaves the client unwrapped.
+package share
+
+import (
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"time"
+)
+
+// PublicKeySize is the size of an X25519 public key.
+const PublicKeySize = 32
+
+// Size limits of the sealed parts of a share.
+const (
+	MaxWrappedKeySize = 256
+	MaxNonceSize      = 64
+	MaxDataSize       = 1 << 20
+	MaxKeyMaterial    = 1024
+)
+
+// Share is an entry sealed for one recipient.
+//
+// KeyType and Title describe the shared entry as it is now; Data holds the
+// payload at the time it was shared.
+type Share struct {
+	ID             int64
+	ShareUUID      uuid.UUID
+	KeyID          int64
+	KeyUUID        uuid.UUID
+	KeyType        keychain.KeyType
+	Title          string
+	OwnerID        int64
+	OwnerLogin     string
+	RecipientID    int64
+	RecipientLogin string
+	WrappedKey     []byte
+	Nonce          []byte
+	Data           []byte
+	CreatedAt      time.Time
+}
@@ -0,0 +1,35 @@
+package share
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// ShareRepository defines storage operations for shared entries.
+type ShareRepository interface {
+	// Upsert stores the share of the entry keyID with recipientID, replacing
+	// the sealed payload of an existing one.
+	Upsert(ctx context.Context, s *Share) (*Share, error)
+
+	// ListByKey returns the shares of the entry keyID.
+	ListByKey(ctx context.Context, keyID int64) ([]*Share, error)
+
+	// ListForRecipient returns the shares with recipientID without their
+	// sealed payload.
+	ListForRecipient(ctx context.Context, recipientID int64) ([]*Share, error)
+
+	// GetForRecipient returns a share with recipientID.
+	//
+	// Returns ErrNotFound if there is no such share.
+	GetForRecipient(ctx context.Context, recipientID int64, shareUUID uuid.UUID) (*Share, error)
+
+	// DeleteByKey removes the share of the entry keyID with recipientID.
+	//
+	// Returns ErrNotFound if there is no such share.
+	DeleteByKey(ctx context.Context, keyID int64, recipientID int64) error
+
+	// DeleteForRecipient removes a share with recipientID.
+	//
+	// Returns ErrNotFound if there is no such share.
+	DeleteForRecipient(ctx context.Context, recipientID int64, shareUUID uuid.UUID) error
+}
@@ -0,0 +1,26 @@
+package share
+
+// ValidateKeyPair checks the public key and the wrapped private key of a user.
+func ValidateKeyPair(publicKey []byte, keyMaterial []byte) error {
+	if len(publicKey) != PublicKeySize {
+		return ErrInvalidPublicKey
+	}
+	if len(keyMaterial) == 0 || len(keyMaterial) > MaxKeyMaterial {
+		return ErrInvalidKeyMaterial
+	}
+	return nil
+}
+
+// ValidatePayload checks the sealed parts of a share.
+func ValidatePayload(wrappedKey []byte, nonce []byte, data []byte) error {
+	if len(wrappedKey) == 0 || len(wrappedKey) > MaxWrappedKeySize {
+		return ErrInvalidPayload
+	}
+	if len(nonce) == 0 || len(nonce) > MaxNonceSize {
+		return ErrInvalidPayload
+	}
+	if len(data) == 0 || len(data) > MaxDataSize {
+		return ErrInvalidPayload
+	}
+	return nil
+}
@@ -8,22 +8,24 @@ import (
 
 // TokenRepository defines the interface for managing refresh tokens in storage.
 //
-// It supports creating, rotating, and retrieving refresh tokens by JTI.
+// It supports creating, rotating, revoking and retrieving refresh tokens by JTI.
 type TokenRepository interface {
 	// Create inserts a new refresh token for a given user.
 	//
 	// Parameters:
 	//  - ctx: context for cancellation and deadlines
 	//  - userID: ID of the user the token belongs to
+	//  - deviceID: optional ID of the device the session runs on; removing the
+	//    device removes the token
 	//  - jti: unique token identifier (JTI)
 	//  - tokenHash: hashed token value to store securely
 	//  - issuedAt: token issuance time
 	//  - expiresAt: token expiration time
 	//
 	// Returns an error if the operation fails.
-	Create(ctx context.Context, userID int64, jti uuid.UUID, tokenHash string, issuedAt time.Time, expiresAt time.Time) error
+	Create(ctx context.Context, userID int64, deviceID *int64, jti uuid.UUID, tokenHash string, issuedAt time.Time, expiresAt time.Time) error
 
-	// Rotate replaces an old token with a new one.
+	// Rotate replaces an old token with a new one bound to the same device.
 	//
 	// Parameters:
 	//  - ctx: context for cancellation and deadlines
@@ -41,4 +43,32 @@ type TokenRepository interface {
 	//
 	// Returns the RefreshTokenRecord or an error if not found.
 	GetByJTI(ctx context.Context, jti uuid.UUID) (*RefreshTokenRecord, error)
+
+	// RevokeAllByUser removes every refresh token issued to the given user,
+	// terminating all of their sessions.
+	//
+	// Returns an error if the operation fails.
+	RevokeAllByUser(ctx context.Context, userID int64) error
+}
+
+// AccessRevocationRepository stores per-user access token revocations.
+//
+// A revocation rejects every access token of the user issued before the
+// recorded moment. It is only needed until the last of those tokens expires,
+// after which the entry may be removed.
+type AccessRevocationRepository interface {
+	// RevokeBefore records that access tokens of userID issued before the given
+	// moment are revoked. An existing entry is only ever moved forward.
+	//
+	// Returns an error if the operation fails.
+	RevokeBefore(ctx context.Context, userID int64, before time.Time, expiresAt time.Time) error
+
+	// GetRevokedBefore returns the revocation moment of the user, or nil if
+	// there is no active revocation.
+	GetRevokedBefore(ctx context.Context, userID int64) (*time.Time, error)
+
+	// DeleteExpired removes revocations that expired before now.
+	//
+	// Returns the number of removed entries.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
 }
@@ -8,11 +8,23 @@ import (
 var (
 	ErrUserNotFound   = errors.New("user not found")
 	ErrDuplicateLogin = errors.New("provided login already exists")
+	ErrDuplicateEmail = errors.New("provided email is already in use")
 
-	ErrLoginTooShort    = apperr.NewValidationError("login is too short, minimum length – 3")
-	ErrLoginTooLong     = apperr.NewValidationError("login is too short, maximum length – 64")
-	ErrPasswordTooShort = apperr.NewValidationError("password too short, minimum length – 8")
+	ErrLoginTooShort     = apperr.NewValidationError("login is too short, minimum length – 3")
+	ErrLoginTooLong      = apperr.NewValidationError("login is too long, maximum length – 64")
+	ErrPasswordUnchanged = apperr.NewValidationError("new password must differ from the current one")
+	ErrInvalidEmail      = apperr.NewValidationError("invalid email address")
+	ErrInvalidEmailToken = apperr.NewValidationError("the link is invalid or has expired")
 
-	ErrInvalidCredentials        = errors.New("wrong login or password")
-	ErrInvalidRefreshCredentials = errors.New("unregistered refresh token provided")
+	// ErrVaultKeyWillBeLost is returned by a password reset of a zero-knowledge
+	// account that was not confirmed to discard the wrapped vault key.
+	ErrVaultKeyWillBeLost = errors.New("the vault key is wrapped with the old password and cannot be recovered; " +
+		"resetting the password discards it and makes the stored secrets unreadable, confirm to continue")
+
+	ErrInvalidCredentials        = apperr.NewAuthError("wrong login or password")
+	ErrInvalidRefreshCredentials = apperr.NewAuthError("unregistered refresh token provided")
+
+	ErrAccountDisabled = apperr.NewForbiddenError("account is disabled, contact your administrator")
+
+	ErrTooManyAttempts = errors.New("too many attempts, try again later")
 )
@@ -15,4 +15,29 @@ type UserRecord struct {
 	ID           int64
 	Login        string
 	PasswordHash string
+
+	// Email is the optional address used for verification and password reset
+	// mails; it is only trusted for a reset once EmailVerifiedAt is set.
+	Email           string
+	EmailVerifiedAt *time.Time
+
+	// KeyMaterial is an opaque, client-wrapped vault key. The server never
+	// interprets it; zero-knowledge clients re-wrap it when the password changes.
+	// The passKeeper client keeps the private sharing key here.
+	KeyMaterial []byte
+	// PublicKey is the X25519 public key entries are shared with the user for.
+	PublicKey []byte
+
+	// FailedLoginAttempts is the number of consecutive failed logins since the
+	// last successful one or the last lockout.
+	FailedLoginAttempts int
+	// LockoutCount is the number of lockouts since the last successful login.
+	LockoutCount int
+	// LockedUntil is set while the account is temporarily locked.
+	LockedUntil *time.Time
+
+	// IsAdmin grants access to the administration API.
+	IsAdmin bool
+	// DisabledAt is set while an administrator has disabled the account.
+	DisabledAt *time.Time
 }
@@ -0,0 +1,274 @@
+package user
+
+import (
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Password rules reported in apperr.Violation.Rule.
+const (
+	RuleMinLength     = "min_length"
+	RuleCharClasses   = "char_classes"
+	RuleBanned        = "banned"
+	RuleEntropy       = "entropy"
+	RuleContainsLogin = "contains_login"
+)
+
+// minDictionaryMatch is the shortest banned word the strength estimate looks
+// for inside a password.
+const minDictionaryMatch = 4
+
+// keyboardRows are the rows of a QWERTY keyboard; runs along them are guessed
+// as easily as alphabetic sequences.
+var keyboardRows = []string{"`1234567890-=", "qwertyuiop[]", "asdfghjkl;'", "zxcvbnm,./"}
+
+// PasswordPolicy describes the passwords accepted for accounts. Zero fields
+// disable the corresponding rule.
+//
+// MinLength counts characters, not bytes. MinClasses is the number of
+// character classes – lowercase, uppercase, digits and everything else – a
+// password must mix. Banned holds lowercased passwords that are refused
+// outright, which also serve as the dictionary of the strength estimate.
+// MinEntropyBits is the minimum of EstimateEntropy.
+type PasswordPolicy struct {
+	MinLength      int
+	MinClasses     int
+	MinEntropyBits float64
+	Banned         map[string]struct{}
+}
+
+// Check validates password for the account login against every rule of the
+// policy and reports all failed rules at once.
+//
+// Returns an *apperr.ValidationError with one violation per failed rule.
+func (p PasswordPolicy) Check(login string, password string) error {
+	var violations []apperr.Violation
+
+	if p.MinLength > 0 && utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, apperr.Violation{
+			Rule:    RuleMinLength,
+			Message: fmt.Sprintf("must be at least %d characters long", p.MinLength),
+		})
+	}
+
+	if p.MinClasses > 0 && charClasses(password) < p.MinClasses {
+		violations = append(violations, apperr.Violation{
+			Rule:    RuleCharClasses,
+			Message: fmt.Sprintf("must mix at least %d of: lowercase letters, uppercase letters, digits, symbols", p.MinClasses),
+		})
+	}
+
+	lower := strings.ToLower(password)
+	if login != "" && strings.Contains(lower, strings.ToLower(login)) {
+		violations = append(violations, apperr.Violation{
+			Rule:    RuleContainsLogin,
+			Message: "must not contain the login",
+		})
+	}
+
+	if p.isBanned(lower) {
+		violations = append(violations, apperr.Violation{
+			Rule:    RuleBanned,
+			Message: "is a commonly used password",
+		})
+	}
+
+	if p.MinEntropyBits > 0 {
+		if bits := EstimateEntropy(password, p.Banned); bits < p.MinEntropyBits {
+			violations = append(violations, apperr.Violation{
+				Rule:    RuleEntropy,
+				Message: fmt.Sprintf("is too easy to guess: about %.0f bits of entropy, %.0f required", bits, p.MinEntropyBits),
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return apperr.NewViolationsError("password does not meet the password policy", violations)
+	}
+	return nil
+}
+
+// isBanned tells whether the lowercased password is banned, also with the
+// digits and symbols people tend to append removed.
+func (p PasswordPolicy) isBanned(lower string) bool {
+	if len(p.Banned) == 0 {
+		return false
+	}
+
+	if _, ok := p.Banned[lower]; ok {
+		return true
+	}
+
+	base := strings.TrimRightFunc(lower, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	_, ok := p.Banned[base]
+	return base != "" && ok
+}
+
+// EstimateEntropy returns a rough estimate of the number of guesses, in bits,
+// an attacker needs to find password.
+//
+// In the spirit of zxcvbn the password is split into the patterns attackers
+// try first – words from dictionary, repeated characters, alphabetic or
+// numeric sequences and keyboard runs – each costing little more than picking
+// the pattern. Characters outside of patterns cost log2 of the size of the
+// character set the password draws from.
+func EstimateEntropy(password string, dictionary map[string]struct{}) float64 {
+	runes := []rune(password)
+	lower := []rune(strings.ToLower(password))
+	charBits := math.Log2(float64(poolSize(password)))
+
+	var bits float64
+	for i := 0; i < len(runes); {
+		length, cost := 1, charBits
+
+		if n := dictionaryMatch(lower, i, dictionary); n > length {
+			// one bit for capitalisation on top of the word rank
+			length, cost = n, math.Log2(float64(len(dictionary)+1))+1
+		}
+		if n := repeatMatch(runes, i); n >= 3 && n > length {
+			length, cost = n, charBits+math.Log2(float64(n))
+		}
+		if n := sequenceMatch(lower, i); n >= 3 && n > length {
+			// one bit for the direction
+			length, cost = n, charBits+math.Log2(float64(n))+1
+		}
+		if n := keyboardMatch(lower, i); n >= 3 && n > length {
+			length, cost = n, math.Log2(float64(len(keyboardRows)))+math.Log2(float64(n))+4
+		}
+
+		bits += cost
+		i += length
+	}
+
+	return bits
+}
+
+func dictionaryMatch(lower []rune, start int, dictionary map[string]struct{}) int {
+	if len(dictionary) == 0 {
+		return 0
+	}
+
+	for end := len(lower); end-start >= minDictionaryMatch; end-- {
+		if _, ok := dictionary[string(lower[start:end])]; ok {
+			return end - start
+		}
+	}
+	return 0
+}
+
+func repeatMatch(runes []rune, start int) int {
+	n := 1
+	for start+n < len(runes) && runes[start+n] == runes[start] {
+		n++
+	}
+	return n
+}
+
+func sequenceMatch(lower []rune, start int) int {
+	if start+1 >= len(lower) {
+		return 1
+	}
+
+	delta := lower[start+1] - lower[start]
+	if delta != 1 && delta != -1 {
+		return 1
+	}
+
+	n := 2
+	for start+n < len(lower) && lower[start+n]-lower[start+n-1] == delta {
+		n++
+	}
+	return n
+}
+
+func keyboardMatch(lower []rune, start int) int {
+	best := 0
+	for _, row := range keyboardRows {
+		rowRunes := []rune(row)
+		for j := range rowRunes {
+			if rowRunes[j] != lower[start] {
+				continue
+			}
+
+			n := 1
+			for start+n < len(lower) && j+n < len(rowRunes) && lower[start+n] == rowRunes[j+n] {
+				n++
+			}
+			if n > best {
+				best = n
+			}
+		}
+	}
+	return best
+}
+
+// charClasses returns the number of character classes used in password.
+func charClasses(password string) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	n := 0
+	for _, used := range []bool{lower, upper, digit, other} {
+		if used {
+			n++
+		}
+	}
+	return n
+}
+
+// poolSize returns the size of the character set password draws from.
+func poolSize(password string) int {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < utf8.RuneSelf:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	size := 0
+	if lower {
+		size += 26
+	}
+	if upper {
+		size += 26
+	}
+	if digit {
+		size += 10
+	}
+	if symbol {
+		size += 33
+	}
+	if other {
+		size += 100
+	}
+	if size == 0 {
+		size = 1
+	}
+	return size
+}
@@ -1,16 +1,84 @@
 package user
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // UserRepository defines the interface for user storage operations.
 //
-// It supports creating a new user and retrieving a user by login.
+// It supports creating a new user, retrieving a user by login or ID and
+// updating stored credentials.
 type UserRepository interface {
-	// Create stores a new user with the given login and password hash.
-	// Returns the generated user ID or an error if creation fails.
-	Create(ctx context.Context, login string, passwordHash string) (int64, error)
+	// Create stores a new user with the given login, password hash and
+	// optional, unverified email.
+	// Returns the generated user ID, ErrDuplicateLogin or ErrDuplicateEmail.
+	Create(ctx context.Context, login string, passwordHash string, email string) (int64, error)
 
 	// GetByLogin retrieves a user by their login.
 	// Returns a UserRecord or nil if no user is found.
 	GetByLogin(ctx context.Context, login string) (*UserRecord, error)
+
+	// GetByEmail retrieves a user by their email address.
+	// Returns ErrUserNotFound if no user is found.
+	GetByEmail(ctx context.Context, email string) (*UserRecord, error)
+
+	// GetByID retrieves a user by their ID.
+	// Returns ErrUserNotFound if no user is found.
+	GetByID(ctx context.Context, id int64) (*UserRecord, error)
+
+	// UpdateCredentials replaces the password hash of the user. If keyMaterial
+	// is not nil, the stored key material is replaced in the same statement.
+	// Returns ErrUserNotFound if no user is found.
+	UpdateCredentials(ctx context.Context, userID int64, passwordHash string, keyMaterial []byte) error
+
+	// ResetCredentials replaces the password hash of the user and removes the
+	// stored key material, which cannot be re-wrapped without the old password.
+	// The public key and the entries and recovery shares sealed for it are
+	// removed with it.
+	// Returns ErrUserNotFound if no user is found.
+	ResetCredentials(ctx context.Context, userID int64, passwordHash string) error
+
+	// SetKeyPair replaces the public sharing key and the key material of the
+	// user. Entries and recovery shares sealed for a previous public key are
+	// removed.
+	// Returns ErrUserNotFound if no user is found.
+	SetKeyPair(ctx context.Context, userID int64, publicKey []byte, keyMaterial []byte) error
+
+	// SetEmail replaces the email of the user and marks it unverified.
+	// Returns ErrUserNotFound or ErrDuplicateEmail.
+	SetEmail(ctx context.Context, userID int64, email string) error
+
+	// MarkEmailVerified marks the email of the user verified at the given time,
+	// provided it still equals email.
+	// Returns ErrUserNotFound if no such user and email are found.
+	MarkEmailVerified(ctx context.Context, userID int64, email string, at time.Time) error
+
+	// UpdatePasswordHash replaces the stored hash of an unchanged password, for
+	// example when it is upgraded to a stronger algorithm.
+	// Returns ErrUserNotFound if no user is found.
+	UpdatePasswordHash(ctx context.Context, userID int64, passwordHash string) error
+
+	// Delete removes the user together with its key material. Keychain entries
+	// and refresh tokens are removed by the database cascade, and vaults and
+	// organizations the user is the only member of are removed in the same
+	// transaction.
+	// Returns ErrUserNotFound if no user is found.
+	Delete(ctx context.Context, userID int64) error
+
+	// IncrementLoginFailures atomically increments the failed login counter of
+	// the user with the given login, unless the account is locked at now, and
+	// returns the user ID, the updated counter and the number of lockouts so
+	// far. It costs the same single statement whether or not the user exists.
+	//
+	// Returns ErrUserNotFound if no unlocked user has this login.
+	IncrementLoginFailures(ctx context.Context, login string, now time.Time) (userID int64, failedAttempts int, lockoutCount int, err error)
+
+	// LockAccount locks the user until the given time, resets the failed login
+	// counter and increments the lockout counter.
+	LockAccount(ctx context.Context, userID int64, until time.Time) error
+
+	// ResetLoginFailures clears failed login and lockout counters after a
+	// successful login.
+	ResetLoginFailures(ctx context.Context, userID int64) error
 }
@@ -1,26 +1,48 @@
 package user
 
+import (
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+)
+
 // ValidateLogin checks whether the login meets length requirements.
 //
 // Login must be more than 3 characters and less than or equal to 64 characters.
 // Returns ErrLoginTooShort or ErrLoginTooLong if invalid.
 func ValidateLogin(l string) error {
-	if len(l) <= 3 {
+	n := utf8.RuneCountInString(l)
+	if n <= 3 {
 		return ErrLoginTooShort
 	}
-	if len(l) > 64 {
+	if n > 64 {
 		return ErrLoginTooLong
 	}
 	return nil
 }
 
-// ValidatePassword checks whether the password meets length requirements.
+// maxEmailLength is the longest address accepted by SMTP (RFC 5321).
+const maxEmailLength = 254
+
+// NormalizeEmail trims surrounding space and lowercases the address, so the
+// same mailbox is always stored and looked up the same way.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidateEmail checks that email is a bare address such as user@example.com.
 //
-// Password must be at least 8 characters long.
-// Returns ErrPasswordTooShort if invalid.
-func ValidatePassword(p string) error {
-	if len(p) < 8 {
-		return ErrPasswordTooShort
+// Display names, comments and addresses longer than 254 characters are
+// rejected with ErrInvalidEmail.
+func ValidateEmail(email string) error {
+	if len(email) > maxEmailLength {
+		return ErrInvalidEmail
 	}
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Name != "" || addr.Address != email {
+		return ErrInvalidEmail
+	}
+
 	return nil
 }
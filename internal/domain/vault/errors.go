@@ -0,0 +1,24 @@
+package vault
+
+import (
+	"errors"
+	"github.com/thxhix/passKeeper/internal/apperr"
+)
+
+var (
+	// ErrNotFound is also returned for vaults the user is not a member of,
+	// so their existence is not disclosed.
+	ErrNotFound             = errors.New("vault not found")
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrMemberNotFound       = errors.New("member not found")
+
+	ErrInsufficientRole = apperr.NewForbiddenError("your role does not allow this")
+	ErrLastOwner        = apperr.NewValidationError("the last owner cannot be removed or demoted")
+
+	// ErrSoleOwner refuses to delete an account that would leave a vault or
+	// organization with members but without an owner.
+	ErrSoleOwner = errors.New("you are the only owner of a shared vault or organization, give ownership to another member or remove the other members first")
+
+	ErrInvalidRole = apperr.NewValidationError("role must be one of owner, admin, editor, viewer")
+	ErrInvalidName = apperr.NewValidationError("name must be between 1 and 64 characters")
+)
@@ -0,0 +1,119 @@
+// Package vault describes shared vaults, which hold keychain entries for a
+// team, and the organizations that group them.
+package vault
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Role is the role of a member in an organization or a vault.
+type Role string
+
+const (
+	// RoleOwner may do anything, including granting and revoking ownership.
+	RoleOwner Role = "owner"
+	// RoleAdmin manages members with a lower role and edits entries.
+	RoleAdmin Role = "admin"
+	// RoleEditor reads, adds and deletes entries.
+	RoleEditor Role = "editor"
+	// RoleViewer only reads entries.
+	RoleViewer Role = "viewer"
+)
+
+// String returns the string representation of Role.
+func (r Role) String() string { return string(r) }
+
+// rank orders roles from the weakest to the strongest; unknown roles rank 0.
+func (r Role) rank() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleEditor:
+		return 2
+	case RoleAdmin:
+		return 3
+	case RoleOwner:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// CanRead reports whether the role allows reading entries.
+func (r Role) CanRead() bool { return r.rank() >= RoleViewer.rank() }
+
+// CanWrite reports whether the role allows adding and deleting entries.
+func (r Role) CanWrite() bool { return r.rank() >= RoleEditor.rank() }
+
+// CanManage reports whether the role allows managing members.
+func (r Role) CanManage() bool { return r.rank() >= RoleAdmin.rank() }
+
+// CanAssign reports whether a member with role r may give target to someone,
+// or change the role of a member who has target. Owners may assign any role,
+// admins only the roles below their own.
+func (r Role) CanAssign(target Role) bool {
+	if r == RoleOwner {
+		return true
+	}
+	return r.CanManage() && target.rank() < r.rank()
+}
+
+// Stronger returns the stronger of the two roles.
+func Stronger(a, b Role) Role {
+	if b.rank() > a.rank() {
+		return b
+	}
+	return a
+}
+
+// ParseRole converts a string to a Role.
+//
+// Returns the Role and true if the string is valid, or empty string and false otherwise.
+func ParseRole(s string) (Role, bool) {
+	switch Role(s) {
+	case RoleOwner, RoleAdmin, RoleEditor, RoleViewer:
+		return Role(s), true
+	default:
+		return "", false
+	}
+}
+
+// Organization groups vaults and the people working with them. A member of an
+// organization has their organization role in each of its vaults, unless the
+// vault gives them a stronger one.
+//
+// Role is the role of the user the organization was loaded for.
+type Organization struct {
+	ID        int64
+	OrgUUID   uuid.UUID
+	Name      string
+	Role      Role
+	CreatedAt time.Time
+}
+
+// Vault is a shared collection of keychain entries.
+//
+// OrgID and OrgUUID are nil for a vault outside any organization. Role is the
+// effective role of the user the vault was loaded for.
+type Vault struct {
+	ID        int64
+	VaultUUID uuid.UUID
+	OrgID     *int64
+	OrgUUID   *uuid.UUID
+	Name      string
+	Role      Role
+	CreatedAt time.Time
+}
+
+// Member is a user with a role in an organization or a vault.
+//
+// Inherited is set for a vault member whose role comes from the organization
+// of the vault.
+type Member struct {
+	UserID    int64
+	Login     string
+	Role      Role
+	Inherited bool
+	CreatedAt time.Time
+}
@@ -0,0 +1,56 @@
+package vault
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// VaultRepository defines storage operations for organizations, vaults and
+// their members.
+type VaultRepository interface {
+	// CreateOrganization stores a new organization with ownerID as its owner.
+	CreateOrganization(ctx context.Context, ownerID int64, name string) (*Organization, error)
+
+	// GetOrganization returns the organization with the role userID has in it.
+	//
+	// Returns ErrOrganizationNotFound if userID is not a member.
+	GetOrganization(ctx context.Context, userID int64, orgUUID uuid.UUID) (*Organization, error)
+
+	// ListOrganizations returns the organizations userID is a member of.
+	ListOrganizations(ctx context.Context, userID int64) ([]*Organization, error)
+
+	// SetOrganizationMember adds userID to the organization or changes the role.
+	SetOrganizationMember(ctx context.Context, orgID int64, userID int64, role Role) error
+
+	// RemoveOrganizationMember removes userID from the organization.
+	//
+	// Returns ErrMemberNotFound if userID is not a member.
+	RemoveOrganizationMember(ctx context.Context, orgID int64, userID int64) error
+
+	// ListOrganizationMembers returns the members of the organization.
+	ListOrganizationMembers(ctx context.Context, orgID int64) ([]*Member, error)
+
+	// CreateVault stores a new vault with ownerID as its owner. orgID is nil
+	// for a vault outside any organization.
+	CreateVault(ctx context.Context, ownerID int64, orgID *int64, name string) (*Vault, error)
+
+	// GetVault returns the vault with the effective role userID has in it,
+	// the stronger of the vault and the organization role.
+	//
+	// Returns ErrNotFound if userID has no role in the vault.
+	GetVault(ctx context.Context, userID int64, vaultUUID uuid.UUID) (*Vault, error)
+
+	// ListVaults returns the vaults userID has a role in.
+	ListVaults(ctx context.Context, userID int64) ([]*Vault, error)
+
+	// SetVaultMember adds userID to the vault or changes the role.
+	SetVaultMember(ctx context.Context, vaultID int64, userID int64, role Role) error
+
+	// RemoveVaultMember removes userID from the vault.
+	//
+	// Returns ErrMemberNotFound if userID is not a direct member.
+	RemoveVaultMember(ctx context.Context, vaultID int64, userID int64) error
+
+	// ListVaultMembers returns the direct members of the vault.
+	ListVaultMembers(ctx context.Context, vaultID int64) ([]*Member, error)
+}
@@ -0,0 +1,19 @@
+package vault
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxNameLength is the maximum length of an organization or vault name.
+const MaxNameLength = 64
+
+// ValidateName checks that an organization or vault name is not blank and
+// at most MaxNameLength characters long.
+func ValidateName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return ErrInvalidName
+	}
+	return nil
+}
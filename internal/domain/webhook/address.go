@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// internalPrefixes are the ranges, besides those recognized by netip, that
+// do not reach the public internet.
+var internalPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, may embed an internal IPv4 address
+}
+
+// IsPublicIP reports whether deliveries may be sent to ip. Loopback,
+// private, link-local, carrier-grade NAT, multicast and unspecified
+// addresses are internal.
+func IsPublicIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, p := range internalPrefixes {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// isInternalHost reports whether host is an internal IP literal or a name
+// that always resolves to the local machine.
+func isInternalHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return !IsPublicIP(ip)
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package webhook
+
+import (
+	"errors"
+	"github.com/thxhix/passKeeper/internal/apperr"
+)
+
+var (
+	ErrNotFound = errors.New("webhook subscription not found")
+
+	// ErrBlockedAddress is returned by a sender when the host of a
+	// subscription resolves to an internal address.
+	ErrBlockedAddress = errors.New("webhook host resolves to an internal address")
+
+	ErrInvalidURL      = apperr.NewValidationError("webhook url must be an absolute https url")
+	ErrInternalURL     = apperr.NewValidationError("webhook url must not point to an internal address")
+	ErrNoEvents        = apperr.NewValidationError("at least one event is required")
+	ErrInvalidEvent    = apperr.NewValidationError("unknown event, expected login.locked, refresh.replayed, key.read, key.delete, emergency.requested, emergency.approved or emergency.rejected")
+	ErrTooManyWebhooks = apperr.NewValidationError("webhook subscription limit reached")
+)
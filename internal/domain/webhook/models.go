@@ -0,0 +1,169 @@
+// Package webhook describes outbound webhooks: subscriptions of a user to
+// security and vault events, and the outbox of signed deliveries that are
+// retried with backoff until the receiver accepts them.
+package webhook
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// EventType is the kind of event a subscription can receive.
+type EventType string
+
+const (
+	// EventLoginLocked is sent when repeated failed logins lock the account.
+	EventLoginLocked EventType = "login.locked"
+	// EventRefreshReplayed is sent when an already rotated refresh token is
+	// presented again, which usually means it was stolen.
+	EventRefreshReplayed EventType = "refresh.replayed"
+	// EventKeyRead is sent when an entry is read.
+	EventKeyRead EventType = "key.read"
+	// EventKeyDelete is sent when an entry is deleted.
+	EventKeyDelete EventType = "key.delete"
+	// EventEmergencyRequested is sent to the grantor when an emergency
+	// contact requests access, EventEmergencyApproved and
+	// EventEmergencyRejected to the contact when the grantor decides early.
+	EventEmergencyRequested EventType = "emergency.requested"
+	EventEmergencyApproved  EventType = "emergency.approved"
+	EventEmergencyRejected  EventType = "emergency.rejected"
+)
+
+// SecretPrefix starts every signing secret, which makes leaked secrets easy to scan for.
+const SecretPrefix = "whsec_"
+
+// MaxSubscriptions is the number of subscriptions a user may have.
+const MaxSubscriptions = 10
+
+// Delivery retry policy: a failed delivery is retried after RetryBaseDelay,
+// doubling up to RetryMaxDelay, and given up after MaxAttempts attempts.
+const (
+	MaxAttempts    = 8
+	RetryBaseDelay = 30 * time.Second
+	RetryMaxDelay  = 6 * time.Hour
+)
+
+// GlobalOwner is the owner of global subscriptions. They are managed by
+// administrators and receive the matching events of every user, so a
+// security team can watch the whole server from one endpoint.
+const GlobalOwner int64 = 0
+
+// Subscription is a webhook endpoint of a user, or a global one when UserID
+// is GlobalOwner.
+//
+// Events lists the event types sent to URL. Tags, if not empty, restricts
+// entry events to entries carrying at least one of them. The signing secret
+// is stored encrypted and shown once on creation.
+type Subscription struct {
+	ID              int64
+	SubscriptionID  uuid.UUID
+	UserID          int64
+	URL             string
+	Events          []EventType
+	Tags            []string
+	SecretNonce     []byte
+	SecretEncrypted []byte
+	CreatedAt       time.Time
+}
+
+// Matches reports whether e should be delivered to the subscription.
+func (s *Subscription) Matches(e Event) bool {
+	subscribed := false
+	for _, t := range s.Events {
+		if t == e.Type {
+			subscribed = true
+			break
+		}
+	}
+	if !subscribed {
+		return false
+	}
+
+	if len(s.Tags) == 0 || (e.Type != EventKeyRead && e.Type != EventKeyDelete) {
+		return true
+	}
+	for _, want := range s.Tags {
+		for _, tag := range e.Tags {
+			if tag == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Event is a security or vault event of a user, serialized as the delivery payload.
+//
+// KeyUUID and Tags are only set for entry events, Attempts only for
+// EventLoginLocked and ContactUUID only for emergency access events.
+type Event struct {
+	ID          uuid.UUID  `json:"id"`
+	Type        EventType  `json:"type"`
+	UserID      int64      `json:"user_id"`
+	KeyUUID     *uuid.UUID `json:"key_uuid,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	Attempts    int        `json:"attempts,omitempty"`
+	ContactUUID *uuid.UUID `json:"contact_uuid,omitempty"`
+	IP          string     `json:"ip,omitempty"`
+	OccurredAt  time.Time  `json:"occurred_at"`
+}
+
+// Payload returns the body posted to the subscriber.
+func (e Event) Payload() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// Status is the state of a delivery in the outbox.
+type Status string
+
+const (
+	// StatusPending deliveries are waiting for their next attempt.
+	StatusPending Status = "pending"
+	// StatusDelivered deliveries were accepted with a 2xx response.
+	StatusDelivered Status = "delivered"
+	// StatusFailed deliveries were given up after MaxAttempts attempts.
+	StatusFailed Status = "failed"
+)
+
+// Reasons of a failed attempt stored in Delivery.LastError. Errors are kept
+// this coarse on purpose: the delivery history is shown to the subscriber and
+// must not reveal how hosts other than the receiver answer.
+const (
+	ErrorUnexpectedStatus = "unexpected_status"
+	ErrorBlockedAddress   = "blocked_address"
+	ErrorTimeout          = "timeout"
+	ErrorConnection       = "connection_failed"
+	ErrorInternal         = "internal_error"
+)
+
+// Delivery is an event queued for a subscription in the durable outbox.
+//
+// LastStatusCode is zero when no response was received. LastError is one of
+// the Error reasons above when the last attempt failed.
+type Delivery struct {
+	ID             int64
+	DeliveryID     uuid.UUID
+	SubscriptionID int64
+	EventType      EventType
+	Payload        []byte
+	Status         Status
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// RetryDelay returns the delay before the next attempt after attempts failed attempts.
+func RetryDelay(attempts int) time.Duration {
+	d := RetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= RetryMaxDelay {
+			return RetryMaxDelay
+		}
+	}
+	return d
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// WebhookRepository defines the interface for managing webhook subscriptions
+// and their delivery outbox in storage.
+type WebhookRepository interface {
+	// Create stores a new subscription and returns the created record.
+	Create(ctx context.Context, sub *Subscription) (*Subscription, error)
+
+	// ListByUser returns every subscription of the user, or the global ones
+	// for GlobalOwner, newest first.
+	ListByUser(ctx context.Context, userID int64) ([]*Subscription, error)
+
+	// ListForEvents returns the subscriptions that receive the events of the
+	// user: their own and the global ones.
+	ListForEvents(ctx context.Context, userID int64) ([]*Subscription, error)
+
+	// Get retrieves a subscription of the user, or a global one for
+	// GlobalOwner.
+	//
+	// Returns ErrNotFound if the user has no such subscription.
+	Get(ctx context.Context, userID int64, subscriptionID uuid.UUID) (*Subscription, error)
+
+	// Delete removes a subscription of the user, or a global one for
+	// GlobalOwner, together with its deliveries.
+	//
+	// Returns ErrNotFound if the user has no such subscription.
+	Delete(ctx context.Context, userID int64, subscriptionID uuid.UUID) error
+
+	// Enqueue adds deliveries to the outbox.
+	Enqueue(ctx context.Context, deliveries []*Delivery) error
+
+	// ClaimDue returns up to limit pending deliveries due at now together with
+	// their subscriptions and postpones them by lease, so concurrent workers
+	// do not send the same delivery twice.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Delivery, map[int64]*Subscription, error)
+
+	// SaveAttempt stores the outcome of a delivery attempt: the status,
+	// attempt count, next attempt time and last response of d.
+	SaveAttempt(ctx context.Context, d *Delivery) error
+
+	// ListDeliveries returns the latest deliveries of a subscription, newest first.
+	ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]*Delivery, error)
+}
@@ -0,0 +1,32 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Headers set on every delivery.
+const (
+	HeaderSignature = "X-PassKeeper-Signature"
+	HeaderEvent     = "X-PassKeeper-Event"
+	HeaderDelivery  = "X-PassKeeper-Delivery"
+)
+
+// Sign returns the value of HeaderSignature for a payload sent at ts:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<payload>">".
+//
+// Receivers recompute the HMAC with their secret, compare it in constant time
+// and reject old timestamps to prevent replays.
+func Sign(secret string, ts time.Time, payload []byte) string {
+	unix := strconv.FormatInt(ts.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,43 @@
+package webhook
+
+import "net/url"
+
+// ValidateURL checks that the endpoint is an absolute https URL that does not
+// name an internal address. Host names are resolved, and checked again, only
+// when a delivery connects.
+func ValidateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return ErrInvalidURL
+	}
+	if isInternalHost(u.Hostname()) {
+		return ErrInternalURL
+	}
+	return nil
+}
+
+// ParseEvents parses the subscribed event types, dropping duplicates.
+func ParseEvents(raw []string) ([]EventType, error) {
+	if len(raw) == 0 {
+		return nil, ErrNoEvents
+	}
+
+	events := make([]EventType, 0, len(raw))
+	seen := make(map[EventType]struct{}, len(raw))
+	for _, r := range raw {
+		t := EventType(r)
+		switch t {
+		case EventLoginLocked, EventRefreshReplayed, EventKeyRead, EventKeyDelete,
+			EventEmergencyRequested, EventEmergencyApproved, EventEmergencyRejected:
+		default:
+			return nil, ErrInvalidEvent
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		events = append(events, t)
+	}
+
+	return events, nil
+}
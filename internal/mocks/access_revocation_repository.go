@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type AccessRevocationRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AccessRevocationRepositoryMock) RevokeBefore(ctx context.Context, userID int64, before time.Time, expiresAt time.Time) error {
+	args := m.Called(ctx, userID, before, expiresAt)
+	return args.Error(0)
+}
+
+func (m *AccessRevocationRepositoryMock) GetRevokedBefore(ctx context.Context, userID int64) (*time.Time, error) {
+	args := m.Called(ctx, userID)
+	if v := args.Get(0); v != nil {
+		return v.(*time.Time), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *AccessRevocationRepositoryMock) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
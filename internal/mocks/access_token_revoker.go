@@ -0,0 +1,15 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type AccessTokenRevokerMock struct {
+	mock.Mock
+}
+
+func (m *AccessTokenRevokerMock) RevokeUser(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
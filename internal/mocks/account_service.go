@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/account"
+)
+
+type AccountServiceMock struct {
+	mock.Mock
+}
+
+func (m *AccountServiceMock) Export(ctx context.Context, userID int64, password string) (*account.Export, error) {
+	args := m.Called(ctx, userID, password)
+	return args.Get(0).(*account.Export), args.Error(1)
+}
+
+func (m *AccountServiceMock) Delete(ctx context.Context, userID int64, password string) error {
+	args := m.Called(ctx, userID, password)
+	return args.Error(0)
+}
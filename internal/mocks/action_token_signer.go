@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type ActionTokenSignerMock struct {
+	mock.Mock
+}
+
+func (m *ActionTokenSignerMock) Sign(purpose string, userID int64, state string, expiresAt time.Time) (string, error) {
+	args := m.Called(purpose, userID, state, expiresAt)
+	return args.String(0), args.Error(1)
+}
+
+func (m *ActionTokenSignerMock) Verify(token string, purpose string) (int64, string, error) {
+	args := m.Called(token, purpose)
+	return args.Get(0).(int64), args.String(1), args.Error(2)
+}
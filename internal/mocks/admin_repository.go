@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/admin"
+	"time"
+)
+
+type AdminRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AdminRepositoryMock) ListUsers(ctx context.Context, limit int, offset int) ([]*admin.UserSummary, error) {
+	args := m.Called(ctx, limit, offset)
+	if v := args.Get(0); v != nil {
+		return v.([]*admin.UserSummary), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *AdminRepositoryMock) StorageStats(ctx context.Context, userID int64) (*admin.StorageStats, error) {
+	args := m.Called(ctx, userID)
+	if v := args.Get(0); v != nil {
+		return v.(*admin.StorageStats), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *AdminRepositoryMock) SetDisabled(ctx context.Context, userID int64, at *time.Time) error {
+	args := m.Called(ctx, userID, at)
+	return args.Error(0)
+}
+
+func (m *AdminRepositoryMock) SetAdmin(ctx context.Context, login string, isAdmin bool) error {
+	args := m.Called(ctx, login, isAdmin)
+	return args.Error(0)
+}
+
+func (m *AdminRepositoryMock) GrantFirstAdmin(ctx context.Context, login string) error {
+	args := m.Called(ctx, login)
+	return args.Error(0)
+}
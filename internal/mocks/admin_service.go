@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/admin"
+)
+
+type AdminServiceMock struct {
+	mock.Mock
+}
+
+func (m *AdminServiceMock) IsAdmin(ctx context.Context, userID int64) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *AdminServiceMock) ListUsers(ctx context.Context, limit int, offset int) ([]*admin.UserSummary, error) {
+	args := m.Called(ctx, limit, offset)
+	if v := args.Get(0); v != nil {
+		return v.([]*admin.UserSummary), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *AdminServiceMock) Disable(ctx context.Context, adminID int64, userID int64) error {
+	args := m.Called(ctx, adminID, userID)
+	return args.Error(0)
+}
+
+func (m *AdminServiceMock) Enable(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *AdminServiceMock) ForceLogout(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *AdminServiceMock) ResetSecondFactor(ctx context.Context, userID int64) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *AdminServiceMock) StorageStats(ctx context.Context, userID int64) (*admin.StorageStats, error) {
+	args := m.Called(ctx, userID)
+	if v := args.Get(0); v != nil {
+		return v.(*admin.StorageStats), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *AdminServiceMock) Bootstrap(ctx context.Context, login string) error {
+	args := m.Called(ctx, login)
+	return args.Error(0)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+)
+
+type AuditRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AuditRepositoryMock) Append(ctx context.Context, e *audit.Event) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *AuditRepositoryMock) List(ctx context.Context, userID int64, filter audit.Filter) ([]*audit.Event, error) {
+	args := m.Called(ctx, userID, filter)
+	if v := args.Get(0); v != nil {
+		return v.([]*audit.Event), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *AuditRepositoryMock) Chain(ctx context.Context, afterID int64, limit int) ([]*audit.Event, error) {
+	args := m.Called(ctx, afterID, limit)
+	if v := args.Get(0); v != nil {
+		return v.([]*audit.Event), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
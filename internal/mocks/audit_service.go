@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+)
+
+type AuditServiceMock struct {
+	mock.Mock
+}
+
+func (m *AuditServiceMock) Record(ctx context.Context, e audit.Event) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *AuditServiceMock) List(ctx context.Context, userID int64, filter audit.Filter) ([]*audit.Event, error) {
+	args := m.Called(ctx, userID, filter)
+	if v := args.Get(0); v != nil {
+		return v.([]*audit.Event), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *AuditServiceMock) Verify(ctx context.Context) (audit.Verification, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(audit.Verification), args.Error(1)
+}
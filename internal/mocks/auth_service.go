@@ -3,19 +3,25 @@ package mocks
 import (
 	"context"
 	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/device"
 )
 
 type AuthServiceMock struct {
 	mock.Mock
 }
 
-func (m *AuthServiceMock) Register(ctx context.Context, login string, password string) (userId int64, accessToken string, refreshToken string, err error) {
-	args := m.Called(ctx, login, password)
+func (m *AuthServiceMock) Register(ctx context.Context, login string, password string, email string, inviteCode string, dev device.Identity) (userId int64, accessToken string, refreshToken string, err error) {
+	args := m.Called(ctx, login, password, email, inviteCode, dev)
 	return args.Get(0).(int64), args.String(1), args.String(2), args.Error(3)
 }
 
-func (m *AuthServiceMock) Login(ctx context.Context, login string, password string) (userId int64, accessToken string, refreshToken string, err error) {
-	args := m.Called(ctx, login, password)
+func (m *AuthServiceMock) RegisterSilently(ctx context.Context, login string, password string, email string, inviteCode string, dev device.Identity) error {
+	args := m.Called(ctx, login, password, email, inviteCode, dev)
+	return args.Error(0)
+}
+
+func (m *AuthServiceMock) Login(ctx context.Context, login string, password string, dev device.Identity) (userId int64, accessToken string, refreshToken string, err error) {
+	args := m.Called(ctx, login, password, dev)
 	return args.Get(0).(int64), args.String(1), args.String(2), args.Error(3)
 }
 
@@ -23,3 +29,13 @@ func (m *AuthServiceMock) Refresh(ctx context.Context, incomingRefreshToken stri
 	args := m.Called(ctx, incomingRefreshToken)
 	return args.String(0), args.String(1), args.Error(2)
 }
+
+func (m *AuthServiceMock) ChangePassword(ctx context.Context, userID int64, currentPassword string, newPassword string, keyMaterial []byte, dev device.Identity) (accessToken string, refreshToken string, err error) {
+	args := m.Called(ctx, userID, currentPassword, newPassword, keyMaterial, dev)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *AuthServiceMock) Logout(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
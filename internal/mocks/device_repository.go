@@ -0,0 +1,77 @@
+package mocks
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/device"
+	"time"
+)
+
+type DeviceRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *DeviceRepositoryMock) Create(ctx context.Context, userID int64, ident device.Identity, trusted bool) (*device.Device, error) {
+	args := m.Called(ctx, userID, ident, trusted)
+	if v := args.Get(0); v != nil {
+		return v.(*device.Device), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *DeviceRepositoryMock) Get(ctx context.Context, userID int64, deviceUUID uuid.UUID) (*device.Device, error) {
+	args := m.Called(ctx, userID, deviceUUID)
+	if v := args.Get(0); v != nil {
+		return v.(*device.Device), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *DeviceRepositoryMock) ListByUser(ctx context.Context, userID int64) ([]*device.Device, error) {
+	args := m.Called(ctx, userID)
+	if v := args.Get(0); v != nil {
+		return v.([]*device.Device), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *DeviceRepositoryMock) CountTrusted(ctx context.Context, userID int64) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *DeviceRepositoryMock) SetApprovalCode(ctx context.Context, id int64, codeHash string, expiresAt time.Time) error {
+	args := m.Called(ctx, id, codeHash, expiresAt)
+	return args.Error(0)
+}
+
+func (m *DeviceRepositoryMock) Approve(ctx context.Context, userID int64, deviceUUID uuid.UUID) error {
+	args := m.Called(ctx, userID, deviceUUID)
+	return args.Error(0)
+}
+
+func (m *DeviceRepositoryMock) TouchLastSeen(ctx context.Context, id int64, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *DeviceRepositoryMock) Delete(ctx context.Context, userID int64, deviceUUID uuid.UUID) error {
+	args := m.Called(ctx, userID, deviceUUID)
+	return args.Error(0)
+}
+
+func (m *DeviceRepositoryMock) DeleteAllByUser(ctx context.Context, userID int64) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *DeviceRepositoryMock) CreateChallenge(ctx context.Context, deviceUUID uuid.UUID, nonceHash string, expiresAt time.Time) error {
+	args := m.Called(ctx, deviceUUID, nonceHash, expiresAt)
+	return args.Error(0)
+}
+
+func (m *DeviceRepositoryMock) ConsumeChallenge(ctx context.Context, deviceUUID uuid.UUID, nonceHash string, now time.Time) (bool, error) {
+	args := m.Called(ctx, deviceUUID, nonceHash, now)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/device"
+	"time"
+)
+
+type DeviceServiceMock struct {
+	mock.Mock
+}
+
+func (m *DeviceServiceMock) Prove(ctx context.Context, ident device.Identity) error {
+	args := m.Called(ctx, ident)
+	return args.Error(0)
+}
+
+func (m *DeviceServiceMock) Challenge(ctx context.Context, deviceUUID string) (string, time.Time, error) {
+	args := m.Called(ctx, deviceUUID)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
+
+func (m *DeviceServiceMock) Enroll(ctx context.Context, userID int64, ident device.Identity) (int64, error) {
+	args := m.Called(ctx, userID, ident)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *DeviceServiceMock) Admit(ctx context.Context, userID int64, ident device.Identity) (int64, error) {
+	args := m.Called(ctx, userID, ident)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *DeviceServiceMock) List(ctx context.Context, userID int64) ([]*device.Device, error) {
+	args := m.Called(ctx, userID)
+	if v := args.Get(0); v != nil {
+		return v.([]*device.Device), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *DeviceServiceMock) Approve(ctx context.Context, userID int64, deviceUUID string) error {
+	args := m.Called(ctx, userID, deviceUUID)
+	return args.Error(0)
+}
+
+func (m *DeviceServiceMock) Remove(ctx context.Context, userID int64, deviceUUID string) error {
+	args := m.Called(ctx, userID, deviceUUID)
+	return args.Error(0)
+}
+
+type DeviceCodeSenderMock struct {
+	mock.Mock
+}
+
+func (m *DeviceCodeSenderMock) SendDeviceCode(ctx context.Context, userID int64, d *device.Device, code string) error {
+	args := m.Called(ctx, userID, d, code)
+	return args.Error(0)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type EmailServiceMock struct {
+	mock.Mock
+}
+
+func (m *EmailServiceMock) SendVerification(ctx context.Context, userID int64, email string) error {
+	args := m.Called(ctx, userID, email)
+	return args.Error(0)
+}
+
+func (m *EmailServiceMock) ChangeEmail(ctx context.Context, userID int64, email string) error {
+	args := m.Called(ctx, userID, email)
+	return args.Error(0)
+}
+
+func (m *EmailServiceMock) VerifyEmail(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *EmailServiceMock) RequestPasswordReset(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *EmailServiceMock) ResetPassword(ctx context.Context, token string, newPassword string, discardVaultKey bool) (bool, error) {
+	args := m.Called(ctx, token, newPassword, discardVaultKey)
+	return args.Bool(0), args.Error(1)
+}
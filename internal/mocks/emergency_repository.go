@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/emergency"
+	"time"
+)
+
+type EmergencyRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *EmergencyRepositoryMock) Upsert(ctx context.Context, c *emergency.Contact) (*emergency.Contact, error) {
+	args := m.Called(ctx, c)
+	if v := args.Get(0); v != nil {
+		return v.(*emergency.Contact), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *EmergencyRepositoryMock) Get(ctx context.Context, contactUUID uuid.UUID) (*emergency.Contact, error) {
+	args := m.Called(ctx, contactUUID)
+	if v := args.Get(0); v != nil {
+		return v.(*emergency.Contact), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *EmergencyRepositoryMock) ListByGrantor(ctx context.Context, grantorID int64) ([]*emergency.Contact, error) {
+	args := m.Called(ctx, grantorID)
+	return args.Get(0).([]*emergency.Contact), args.Error(1)
+}
+
+func (m *EmergencyRepositoryMock) ListByGrantee(ctx context.Context, granteeID int64) ([]*emergency.Contact, error) {
+	args := m.Called(ctx, granteeID)
+	return args.Get(0).([]*emergency.Contact), args.Error(1)
+}
+
+func (m *EmergencyRepositoryMock) UpdateStatus(ctx context.Context, id int64, from emergency.Status, to emergency.Status, requestedAt *time.Time) (*emergency.Contact, error) {
+	args := m.Called(ctx, id, from, to, requestedAt)
+	if v := args.Get(0); v != nil {
+		return v.(*emergency.Contact), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *EmergencyRepositoryMock) Delete(ctx context.Context, userID int64, contactUUID uuid.UUID) error {
+	args := m.Called(ctx, userID, contactUUID)
+	return args.Error(0)
+}
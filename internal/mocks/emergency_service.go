@@ -0,0 +1,78 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/emergency"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"time"
+)
+
+type EmergencyServiceMock struct {
+	mock.Mock
+}
+
+func (m *EmergencyServiceMock) Nominate(ctx context.Context, grantorID int64, login string, access string, wait time.Duration) (*emergency.Contact, error) {
+	args := m.Called(ctx, grantorID, login, access, wait)
+	if v := args.Get(0); v != nil {
+		return v.(*emergency.Contact), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *EmergencyServiceMock) Contacts(ctx context.Context, grantorID int64) ([]*emergency.Contact, error) {
+	args := m.Called(ctx, grantorID)
+	return args.Get(0).([]*emergency.Contact), args.Error(1)
+}
+
+func (m *EmergencyServiceMock) Approve(ctx context.Context, grantorID int64, contactUUID string) (*emergency.Contact, error) {
+	args := m.Called(ctx, grantorID, contactUUID)
+	if v := args.Get(0); v != nil {
+		return v.(*emergency.Contact), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *EmergencyServiceMock) Reject(ctx context.Context, grantorID int64, contactUUID string) (*emergency.Contact, error) {
+	args := m.Called(ctx, grantorID, contactUUID)
+	if v := args.Get(0); v != nil {
+		return v.(*emergency.Contact), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *EmergencyServiceMock) Grantors(ctx context.Context, granteeID int64) ([]*emergency.Contact, error) {
+	args := m.Called(ctx, granteeID)
+	return args.Get(0).([]*emergency.Contact), args.Error(1)
+}
+
+func (m *EmergencyServiceMock) Request(ctx context.Context, granteeID int64, contactUUID string) (*emergency.Contact, error) {
+	args := m.Called(ctx, granteeID, contactUUID)
+	if v := args.Get(0); v != nil {
+		return v.(*emergency.Contact), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *EmergencyServiceMock) Keys(ctx context.Context, granteeID int64, contactUUID string, keyType *keychain.KeyType) ([]*keychain.KeyRecord, error) {
+	args := m.Called(ctx, granteeID, contactUUID, keyType)
+	return args.Get(0).([]*keychain.KeyRecord), args.Error(1)
+}
+
+func (m *EmergencyServiceMock) Key(ctx context.Context, granteeID int64, contactUUID string, keyUUID string) (*keychain.KeyRecord, []byte, error) {
+	args := m.Called(ctx, granteeID, contactUUID, keyUUID)
+	if v := args.Get(0); v != nil {
+		return v.(*keychain.KeyRecord), args.Get(1).([]byte), args.Error(2)
+	}
+	return nil, nil, args.Error(2)
+}
+
+func (m *EmergencyServiceMock) Takeover(ctx context.Context, granteeID int64, contactUUID string, newPassword string, discardVaultKey bool) (bool, error) {
+	args := m.Called(ctx, granteeID, contactUUID, newPassword, discardVaultKey)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *EmergencyServiceMock) Remove(ctx context.Context, userID int64, contactUUID string) error {
+	args := m.Called(ctx, userID, contactUUID)
+	return args.Error(0)
+}
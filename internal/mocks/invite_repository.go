@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/invite"
+	"time"
+)
+
+type InviteRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *InviteRepositoryMock) Create(ctx context.Context, inv *invite.Invite) (*invite.Invite, error) {
+	args := m.Called(ctx, inv)
+	if v := args.Get(0); v != nil {
+		return v.(*invite.Invite), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *InviteRepositoryMock) Redeem(ctx context.Context, codeHash string, login string, email string, now time.Time) (*invite.Invite, error) {
+	args := m.Called(ctx, codeHash, login, email, now)
+	if v := args.Get(0); v != nil {
+		return v.(*invite.Invite), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *InviteRepositoryMock) Release(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *InviteRepositoryMock) ListByCreator(ctx context.Context, userID int64) ([]*invite.Invite, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*invite.Invite), args.Error(1)
+}
+
+func (m *InviteRepositoryMock) Delete(ctx context.Context, userID int64, inviteUUID uuid.UUID) error {
+	args := m.Called(ctx, userID, inviteUUID)
+	return args.Error(0)
+}
@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/invite"
+	"time"
+)
+
+type InviteServiceMock struct {
+	mock.Mock
+}
+
+func (m *InviteServiceMock) Create(ctx context.Context, userID int64, login string, email string, maxUses int, ttl time.Duration) (*invite.Invite, string, error) {
+	args := m.Called(ctx, userID, login, email, maxUses, ttl)
+	if v := args.Get(0); v != nil {
+		return v.(*invite.Invite), args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (m *InviteServiceMock) List(ctx context.Context, userID int64) ([]*invite.Invite, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*invite.Invite), args.Error(1)
+}
+
+func (m *InviteServiceMock) Revoke(ctx context.Context, userID int64, inviteUUID string) error {
+	args := m.Called(ctx, userID, inviteUUID)
+	return args.Error(0)
+}
+
+func (m *InviteServiceMock) Redeem(ctx context.Context, code string, login string, email string) (int64, error) {
+	args := m.Called(ctx, code, login, email)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *InviteServiceMock) Release(ctx context.Context, inviteID int64) error {
+	args := m.Called(ctx, inviteID)
+	return args.Error(0)
+}
@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+)
+
+type KeychainEventServiceMock struct {
+	mock.Mock
+}
+
+func (m *KeychainEventServiceMock) Subscribe(ctx context.Context, userID int64) (<-chan keychain.Event, error) {
+	args := m.Called(ctx, userID)
+	if v := args.Get(0); v != nil {
+		return v.(chan keychain.Event), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -20,8 +20,8 @@ func (m *KeychainRepositoryMock) GetUserKey(ctx context.Context, userID int64, k
 	return args.Get(0).(*keychain.KeyRecord), args.Error(1)
 }
 
-func (m *KeychainRepositoryMock) AddKey(ctx context.Context, userID int64, keyType keychain.KeyType, title string, data []byte, nonce []byte) (string, error) {
-	args := m.Called(ctx, userID, keyType, title, data, nonce)
+func (m *KeychainRepositoryMock) AddKey(ctx context.Context, userID int64, keyType keychain.KeyType, title string, tags []string, data []byte, nonce []byte) (string, error) {
+	args := m.Called(ctx, userID, keyType, title, tags, data, nonce)
 	return args.String(0), args.Error(1)
 }
 
@@ -29,3 +29,33 @@ func (m *KeychainRepositoryMock) DeleteKey(ctx context.Context, userID int64, ke
 	args := m.Called(ctx, userID, keyUUID)
 	return args.Error(0)
 }
+
+func (m *KeychainRepositoryMock) GetVaultKeys(ctx context.Context, vaultID int64, keyType *string) ([]*keychain.KeyRecord, error) {
+	args := m.Called(ctx, vaultID, keyType)
+	return args.Get(0).([]*keychain.KeyRecord), args.Error(1)
+}
+
+func (m *KeychainRepositoryMock) GetVaultKey(ctx context.Context, vaultID int64, keyUUID string) (*keychain.KeyRecord, error) {
+	args := m.Called(ctx, vaultID, keyUUID)
+	return args.Get(0).(*keychain.KeyRecord), args.Error(1)
+}
+
+func (m *KeychainRepositoryMock) AddVaultKey(ctx context.Context, vaultID int64, keyType keychain.KeyType, title string, tags []string, data []byte, nonce []byte) (string, error) {
+	args := m.Called(ctx, vaultID, keyType, title, tags, data, nonce)
+	return args.String(0), args.Error(1)
+}
+
+func (m *KeychainRepositoryMock) DeleteVaultKey(ctx context.Context, vaultID int64, keyUUID string) error {
+	args := m.Called(ctx, vaultID, keyUUID)
+	return args.Error(0)
+}
+
+func (m *KeychainRepositoryMock) WriteBatch(ctx context.Context, userID int64, writes []*keychain.BatchWrite, atomic bool) error {
+	args := m.Called(ctx, userID, writes, atomic)
+	return args.Error(0)
+}
+
+func (m *KeychainRepositoryMock) GetUserChanges(ctx context.Context, userID int64, since int64, limit int, withData bool) ([]*keychain.KeyRecord, int64, error) {
+	args := m.Called(ctx, userID, since, limit, withData)
+	return args.Get(0).([]*keychain.KeyRecord), args.Get(1).(int64), args.Error(2)
+}
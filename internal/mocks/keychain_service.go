@@ -26,6 +26,22 @@ func (m *KeychainServiceMock) DeleteKey(ctx context.Context, userID int64, keyUU
 	return args.Error(0)
 }
 
+func (m *KeychainServiceMock) GetChanges(ctx context.Context, userID int64, since int64, withData bool) (*keychain.ChangeSet, error) {
+	args := m.Called(ctx, userID, since, withData)
+	if v := args.Get(0); v != nil {
+		return v.(*keychain.ChangeSet), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *KeychainServiceMock) Batch(ctx context.Context, userID int64, mode keychain.BatchMode, ops []*dto.BatchOperation) ([]keychain.BatchResult, error) {
+	args := m.Called(ctx, userID, mode, ops)
+	if v := args.Get(0); v != nil {
+		return v.([]keychain.BatchResult), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *KeychainServiceMock) AddCredential(ctx context.Context, userID int64, in dto.AddCredentialsDTO) (string, error) {
 	args := m.Called(ctx, userID, in)
 	return args.String(0), args.Error(1)
@@ -45,3 +61,18 @@ func (m *KeychainServiceMock) AddFile(ctx context.Context, userID int64, in dto.
 	args := m.Called(ctx, userID, in)
 	return args.String(0), args.Error(1)
 }
+
+func (m *KeychainServiceMock) GetVaultKeys(ctx context.Context, userID int64, vaultUUID string, keyType *keychain.KeyType) (list []*keychain.KeyRecord, err error) {
+	args := m.Called(ctx, userID, vaultUUID, keyType)
+	return args.Get(0).([]*keychain.KeyRecord), args.Error(1)
+}
+
+func (m *KeychainServiceMock) GetVaultKey(ctx context.Context, userID int64, vaultUUID string, keyUUID string) (record *keychain.KeyRecord, decryptedData []byte, err error) {
+	args := m.Called(ctx, userID, vaultUUID, keyUUID)
+	return args.Get(0).(*keychain.KeyRecord), args.Get(1).([]byte), args.Error(2)
+}
+
+func (m *KeychainServiceMock) DeleteVaultKey(ctx context.Context, userID int64, vaultUUID string, keyUUID string) error {
+	args := m.Called(ctx, userID, vaultUUID, keyUUID)
+	return args.Error(0)
+}
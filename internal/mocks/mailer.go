@@ -0,0 +1,15 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type MailerMock struct {
+	mock.Mock
+}
+
+func (m *MailerMock) Send(ctx context.Context, to, subject, body string) error {
+	args := m.Called(ctx, to, subject, body)
+	return args.Error(0)
+}
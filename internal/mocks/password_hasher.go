@@ -11,7 +11,12 @@ func (m *PasswordHasherMock) HashPassword(password string) (string, error) {
 	return args.Get(0).(string), args.Error(1)
 }
 
-func (m *PasswordHasherMock) CheckPasswordHash(password, hash string) bool {
+func (m *PasswordHasherMock) CheckPasswordHash(password, hash string) (bool, error) {
 	args := m.Called(password, hash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *PasswordHasherMock) NeedsRehash(hash string) bool {
+	args := m.Called(hash)
 	return args.Bool(0)
 }
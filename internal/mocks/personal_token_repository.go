@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"time"
+)
+
+type PersonalTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *PersonalTokenRepositoryMock) Create(ctx context.Context, userID int64, name string, tokenHash string, scopes []string, expiresAt *time.Time) (*pat.PersonalTokenRecord, error) {
+	args := m.Called(ctx, userID, name, tokenHash, scopes, expiresAt)
+	if v := args.Get(0); v != nil {
+		return v.(*pat.PersonalTokenRecord), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PersonalTokenRepositoryMock) GetByHash(ctx context.Context, tokenHash string) (*pat.PersonalTokenRecord, error) {
+	args := m.Called(ctx, tokenHash)
+	if v := args.Get(0); v != nil {
+		return v.(*pat.PersonalTokenRecord), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PersonalTokenRepositoryMock) ListByUser(ctx context.Context, userID int64) ([]*pat.PersonalTokenRecord, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*pat.PersonalTokenRecord), args.Error(1)
+}
+
+func (m *PersonalTokenRepositoryMock) Revoke(ctx context.Context, userID int64, tokenUUID uuid.UUID) error {
+	args := m.Called(ctx, userID, tokenUUID)
+	return args.Error(0)
+}
+
+func (m *PersonalTokenRepositoryMock) TouchLastUsed(ctx context.Context, id int64, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
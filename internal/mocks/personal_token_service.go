@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"time"
+)
+
+type PersonalTokenServiceMock struct {
+	mock.Mock
+}
+
+func (m *PersonalTokenServiceMock) Create(ctx context.Context, userID int64, name string, scopes []string, expiresAt *time.Time) (*pat.PersonalTokenRecord, string, error) {
+	args := m.Called(ctx, userID, name, scopes, expiresAt)
+	if v := args.Get(0); v != nil {
+		return v.(*pat.PersonalTokenRecord), args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (m *PersonalTokenServiceMock) List(ctx context.Context, userID int64) ([]*pat.PersonalTokenRecord, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*pat.PersonalTokenRecord), args.Error(1)
+}
+
+func (m *PersonalTokenServiceMock) Revoke(ctx context.Context, userID int64, tokenUUID string) error {
+	args := m.Called(ctx, userID, tokenUUID)
+	return args.Error(0)
+}
+
+func (m *PersonalTokenServiceMock) Authenticate(ctx context.Context, token string) (int64, pat.Scopes, error) {
+	args := m.Called(ctx, token)
+	if v := args.Get(1); v != nil {
+		return args.Get(0).(int64), v.(pat.Scopes), args.Error(2)
+	}
+	return args.Get(0).(int64), nil, args.Error(2)
+}
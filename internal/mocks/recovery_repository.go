@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/recovery"
+)
+
+type RecoveryRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RecoveryRepositoryMock) Replace(ctx context.Context, setup *recovery.Setup, shares []*recovery.Share) (*recovery.Setup, error) {
+	args := m.Called(ctx, setup, shares)
+	if v := args.Get(0); v != nil {
+		return v.(*recovery.Setup), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *RecoveryRepositoryMock) Get(ctx context.Context, userID int64) (*recovery.Setup, error) {
+	args := m.Called(ctx, userID)
+	if v := args.Get(0); v != nil {
+		return v.(*recovery.Setup), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *RecoveryRepositoryMock) Delete(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *RecoveryRepositoryMock) ListHeld(ctx context.Context, holderID int64) ([]*recovery.Share, error) {
+	args := m.Called(ctx, holderID)
+	return args.Get(0).([]*recovery.Share), args.Error(1)
+}
+
+func (m *RecoveryRepositoryMock) GetHeld(ctx context.Context, holderID int64, shareUUID uuid.UUID) (*recovery.Share, error) {
+	args := m.Called(ctx, holderID, shareUUID)
+	if v := args.Get(0); v != nil {
+		return v.(*recovery.Share), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/recovery"
+)
+
+type RecoveryServiceMock struct {
+	mock.Mock
+}
+
+func (m *RecoveryServiceMock) Setup(ctx context.Context, userID int64, publicKey []byte, keyMaterial []byte, threshold int, shares int, held []recovery.HeldShare) (*recovery.Setup, error) {
+	args := m.Called(ctx, userID, publicKey, keyMaterial, threshold, shares, held)
+	if v := args.Get(0); v != nil {
+		return v.(*recovery.Setup), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *RecoveryServiceMock) Get(ctx context.Context, userID int64) (*recovery.Setup, error) {
+	args := m.Called(ctx, userID)
+	if v := args.Get(0); v != nil {
+		return v.(*recovery.Setup), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *RecoveryServiceMock) Remove(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *RecoveryServiceMock) Held(ctx context.Context, userID int64) ([]*recovery.Share, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*recovery.Share), args.Error(1)
+}
+
+func (m *RecoveryServiceMock) GetHeld(ctx context.Context, userID int64, shareUUID string) (*recovery.Share, error) {
+	args := m.Called(ctx, userID, shareUUID)
+	if v := args.Get(0); v != nil {
+		return v.(*recovery.Share), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/send"
+	"time"
+)
+
+type SendRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *SendRepositoryMock) Create(ctx context.Context, s *send.Send) (*send.Send, error) {
+	args := m.Called(ctx, s)
+	if v := args.Get(0); v != nil {
+		return v.(*send.Send), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *SendRepositoryMock) Consume(ctx context.Context, sendUUID uuid.UUID, now time.Time) (*send.Send, error) {
+	args := m.Called(ctx, sendUUID, now)
+	if v := args.Get(0); v != nil {
+		return v.(*send.Send), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *SendRepositoryMock) ListByOwner(ctx context.Context, userID int64) ([]*send.Send, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*send.Send), args.Error(1)
+}
+
+func (m *SendRepositoryMock) Delete(ctx context.Context, userID int64, sendUUID uuid.UUID) error {
+	args := m.Called(ctx, userID, sendUUID)
+	return args.Error(0)
+}
+
+func (m *SendRepositoryMock) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/send"
+	"time"
+)
+
+type SendServiceMock struct {
+	mock.Mock
+}
+
+func (m *SendServiceMock) Create(ctx context.Context, userID int64, nonce []byte, data []byte, maxViews int, ttl time.Duration) (*send.Send, error) {
+	args := m.Called(ctx, userID, nonce, data, maxViews, ttl)
+	if v := args.Get(0); v != nil {
+		return v.(*send.Send), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *SendServiceMock) Open(ctx context.Context, sendUUID string) (*send.Send, error) {
+	args := m.Called(ctx, sendUUID)
+	if v := args.Get(0); v != nil {
+		return v.(*send.Send), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *SendServiceMock) List(ctx context.Context, userID int64) ([]*send.Send, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*send.Send), args.Error(1)
+}
+
+func (m *SendServiceMock) Revoke(ctx context.Context, userID int64, sendUUID string) error {
+	args := m.Called(ctx, userID, sendUUID)
+	return args.Error(0)
+}
@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/serviceaccount"
+	"time"
+)
+
+type ServiceAccountRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ServiceAccountRepositoryMock) Create(ctx context.Context, ownerID int64, name string, secretHash string, publicKey string) (*serviceaccount.ServiceAccount, error) {
+	args := m.Called(ctx, ownerID, name, secretHash, publicKey)
+	if v := args.Get(0); v != nil {
+		return v.(*serviceaccount.ServiceAccount), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ServiceAccountRepositoryMock) GetByClientID(ctx context.Context, clientID uuid.UUID) (*serviceaccount.ServiceAccount, error) {
+	args := m.Called(ctx, clientID)
+	if v := args.Get(0); v != nil {
+		return v.(*serviceaccount.ServiceAccount), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ServiceAccountRepositoryMock) ListByOwner(ctx context.Context, ownerID int64) ([]*serviceaccount.ServiceAccount, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).([]*serviceaccount.ServiceAccount), args.Error(1)
+}
+
+func (m *ServiceAccountRepositoryMock) Delete(ctx context.Context, ownerID int64, clientID uuid.UUID) error {
+	args := m.Called(ctx, ownerID, clientID)
+	return args.Error(0)
+}
+
+func (m *ServiceAccountRepositoryMock) TouchLastUsed(ctx context.Context, id int64, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *ServiceAccountRepositoryMock) Grant(ctx context.Context, ownerID int64, clientID uuid.UUID, keyUUID uuid.UUID) error {
+	args := m.Called(ctx, ownerID, clientID, keyUUID)
+	return args.Error(0)
+}
+
+func (m *ServiceAccountRepositoryMock) RevokeGrant(ctx context.Context, ownerID int64, clientID uuid.UUID, keyUUID uuid.UUID) error {
+	args := m.Called(ctx, ownerID, clientID, keyUUID)
+	return args.Error(0)
+}
+
+func (m *ServiceAccountRepositoryMock) ListGrants(ctx context.Context, ownerID int64, clientID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, ownerID, clientID)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *ServiceAccountRepositoryMock) GrantedKeyIDs(ctx context.Context, clientID uuid.UUID) ([]int64, error) {
+	args := m.Called(ctx, clientID)
+	return args.Get(0).([]int64), args.Error(1)
+}
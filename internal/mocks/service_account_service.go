@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/serviceaccount"
+	"time"
+)
+
+type ServiceAccountServiceMock struct {
+	mock.Mock
+}
+
+func (m *ServiceAccountServiceMock) Create(ctx context.Context, ownerID int64, name string, publicKey string) (*serviceaccount.ServiceAccount, string, error) {
+	args := m.Called(ctx, ownerID, name, publicKey)
+	if v := args.Get(0); v != nil {
+		return v.(*serviceaccount.ServiceAccount), args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (m *ServiceAccountServiceMock) List(ctx context.Context, ownerID int64) ([]*serviceaccount.ServiceAccount, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).([]*serviceaccount.ServiceAccount), args.Error(1)
+}
+
+func (m *ServiceAccountServiceMock) Delete(ctx context.Context, ownerID int64, clientID string) error {
+	args := m.Called(ctx, ownerID, clientID)
+	return args.Error(0)
+}
+
+func (m *ServiceAccountServiceMock) Grant(ctx context.Context, ownerID int64, clientID string, keyUUID string) error {
+	args := m.Called(ctx, ownerID, clientID, keyUUID)
+	return args.Error(0)
+}
+
+func (m *ServiceAccountServiceMock) RevokeGrant(ctx context.Context, ownerID int64, clientID string, keyUUID string) error {
+	args := m.Called(ctx, ownerID, clientID, keyUUID)
+	return args.Error(0)
+}
+
+func (m *ServiceAccountServiceMock) ListGrants(ctx context.Context, ownerID int64, clientID string) ([]uuid.UUID, error) {
+	args := m.Called(ctx, ownerID, clientID)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *ServiceAccountServiceMock) ExchangeClientSecret(ctx context.Context, clientID string, clientSecret string) (string, time.Duration, error) {
+	args := m.Called(ctx, clientID, clientSecret)
+	return args.String(0), args.Get(1).(time.Duration), args.Error(2)
+}
+
+func (m *ServiceAccountServiceMock) ExchangeAssertion(ctx context.Context, assertion string) (string, time.Duration, error) {
+	args := m.Called(ctx, assertion)
+	return args.String(0), args.Get(1).(time.Duration), args.Error(2)
+}
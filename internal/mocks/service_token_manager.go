@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type ServiceTokenManagerMock struct {
+	mock.Mock
+}
+
+func (m *ServiceTokenManagerMock) GenerateServiceAccessToken(ownerID int64, clientID string) (string, time.Duration, error) {
+	args := m.Called(ownerID, clientID)
+	return args.String(0), args.Get(1).(time.Duration), args.Error(2)
+}
+
+func (m *ServiceTokenManagerMock) ParseClientAssertion(assertion string, lookupKey func(clientID string) (string, error)) (string, string, time.Time, error) {
+	args := m.Called(assertion, lookupKey)
+	return args.String(0), args.String(1), args.Get(2).(time.Time), args.Error(3)
+}
+
+func (m *ServiceTokenManagerMock) ValidateAssertionKey(publicKeyPEM string) error {
+	args := m.Called(publicKeyPEM)
+	return args.Error(0)
+}
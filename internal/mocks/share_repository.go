@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/share"
+)
+
+type ShareRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ShareRepositoryMock) Upsert(ctx context.Context, s *share.Share) (*share.Share, error) {
+	args := m.Called(ctx, s)
+	return args.Get(0).(*share.Share), args.Error(1)
+}
+
+func (m *ShareRepositoryMock) ListByKey(ctx context.Context, keyID int64) ([]*share.Share, error) {
+	args := m.Called(ctx, keyID)
+	return args.Get(0).([]*share.Share), args.Error(1)
+}
+
+func (m *ShareRepositoryMock) ListForRecipient(ctx context.Context, recipientID int64) ([]*share.Share, error) {
+	args := m.Called(ctx, recipientID)
+	return args.Get(0).([]*share.Share), args.Error(1)
+}
+
+func (m *ShareRepositoryMock) GetForRecipient(ctx context.Context, recipientID int64, shareUUID uuid.UUID) (*share.Share, error) {
+	args := m.Called(ctx, recipientID, shareUUID)
+	return args.Get(0).(*share.Share), args.Error(1)
+}
+
+func (m *ShareRepositoryMock) DeleteByKey(ctx context.Context, keyID int64, recipientID int64) error {
+	args := m.Called(ctx, keyID, recipientID)
+	return args.Error(0)
+}
+
+func (m *ShareRepositoryMock) DeleteForRecipient(ctx context.Context, recipientID int64, shareUUID uuid.UUID) error {
+	args := m.Called(ctx, recipientID, shareUUID)
+	return args.Error(0)
+}
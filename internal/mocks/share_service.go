@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/share"
+)
+
+type ShareServiceMock struct {
+	mock.Mock
+}
+
+func (m *ShareServiceMock) SetKeyPair(ctx context.Context, userID int64, publicKey []byte, keyMaterial []byte) error {
+	args := m.Called(ctx, userID, publicKey, keyMaterial)
+	return args.Error(0)
+}
+
+func (m *ShareServiceMock) KeyPair(ctx context.Context, userID int64) ([]byte, []byte, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]byte), args.Get(1).([]byte), args.Error(2)
+}
+
+func (m *ShareServiceMock) PublicKey(ctx context.Context, login string) ([]byte, error) {
+	args := m.Called(ctx, login)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *ShareServiceMock) Share(ctx context.Context, userID int64, keyUUID string, login string, wrappedKey []byte, nonce []byte, data []byte) (*share.Share, error) {
+	args := m.Called(ctx, userID, keyUUID, login, wrappedKey, nonce, data)
+	return args.Get(0).(*share.Share), args.Error(1)
+}
+
+func (m *ShareServiceMock) Recipients(ctx context.Context, userID int64, keyUUID string) ([]*share.Share, error) {
+	args := m.Called(ctx, userID, keyUUID)
+	return args.Get(0).([]*share.Share), args.Error(1)
+}
+
+func (m *ShareServiceMock) Revoke(ctx context.Context, userID int64, keyUUID string, login string) error {
+	args := m.Called(ctx, userID, keyUUID, login)
+	return args.Error(0)
+}
+
+func (m *ShareServiceMock) SharedWithMe(ctx context.Context, userID int64) ([]*share.Share, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*share.Share), args.Error(1)
+}
+
+func (m *ShareServiceMock) GetShared(ctx context.Context, userID int64, shareUUID string) (*share.Share, error) {
+	args := m.Called(ctx, userID, shareUUID)
+	return args.Get(0).(*share.Share), args.Error(1)
+}
+
+func (m *ShareServiceMock) Dismiss(ctx context.Context, userID int64, shareUUID string) error {
+	args := m.Called(ctx, userID, shareUUID)
+	return args.Error(0)
+}
@@ -12,8 +12,8 @@ type TokenRepositoryMock struct {
 	mock.Mock
 }
 
-func (m *TokenRepositoryMock) Create(ctx context.Context, userID int64, jti uuid.UUID, tokenHash string, issuedAt time.Time, expiresAt time.Time) error {
-	args := m.Called(ctx, userID, jti, tokenHash, issuedAt, expiresAt)
+func (m *TokenRepositoryMock) Create(ctx context.Context, userID int64, deviceID *int64, jti uuid.UUID, tokenHash string, issuedAt time.Time, expiresAt time.Time) error {
+	args := m.Called(ctx, userID, deviceID, jti, tokenHash, issuedAt, expiresAt)
 	return args.Error(0)
 }
 
@@ -26,3 +26,8 @@ func (m *TokenRepositoryMock) GetByJTI(ctx context.Context, jti uuid.UUID) (*tok
 	args := m.Called(ctx, jti)
 	return args.Get(0).(*token.RefreshTokenRecord), args.Error(1)
 }
+
+func (m *TokenRepositoryMock) RevokeAllByUser(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
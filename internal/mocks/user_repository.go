@@ -4,14 +4,15 @@ import (
 	"context"
 	"github.com/stretchr/testify/mock"
 	"github.com/thxhix/passKeeper/internal/domain/user"
+	"time"
 )
 
 type UserRepositoryMock struct {
 	mock.Mock
 }
 
-func (m *UserRepositoryMock) Create(ctx context.Context, login string, passwordHash string) (int64, error) {
-	args := m.Called(ctx, login, passwordHash)
+func (m *UserRepositoryMock) Create(ctx context.Context, login string, passwordHash string, email string) (int64, error) {
+	args := m.Called(ctx, login, passwordHash, email)
 	return int64(args.Int(0)), args.Error(1)
 }
 
@@ -20,3 +21,65 @@ func (m *UserRepositoryMock) GetByLogin(ctx context.Context, login string) (*use
 
 	return args.Get(0).(*user.UserRecord), args.Error(1)
 }
+
+func (m *UserRepositoryMock) GetByEmail(ctx context.Context, email string) (*user.UserRecord, error) {
+	args := m.Called(ctx, email)
+
+	return args.Get(0).(*user.UserRecord), args.Error(1)
+}
+
+func (m *UserRepositoryMock) GetByID(ctx context.Context, id int64) (*user.UserRecord, error) {
+	args := m.Called(ctx, id)
+
+	return args.Get(0).(*user.UserRecord), args.Error(1)
+}
+
+func (m *UserRepositoryMock) UpdateCredentials(ctx context.Context, userID int64, passwordHash string, keyMaterial []byte) error {
+	args := m.Called(ctx, userID, passwordHash, keyMaterial)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) ResetCredentials(ctx context.Context, userID int64, passwordHash string) error {
+	args := m.Called(ctx, userID, passwordHash)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) SetKeyPair(ctx context.Context, userID int64, publicKey []byte, keyMaterial []byte) error {
+	args := m.Called(ctx, userID, publicKey, keyMaterial)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) SetEmail(ctx context.Context, userID int64, email string) error {
+	args := m.Called(ctx, userID, email)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) MarkEmailVerified(ctx context.Context, userID int64, email string, at time.Time) error {
+	args := m.Called(ctx, userID, email, at)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) Delete(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) IncrementLoginFailures(ctx context.Context, login string, now time.Time) (int64, int, int, error) {
+	args := m.Called(ctx, login, now)
+	return args.Get(0).(int64), args.Int(1), args.Int(2), args.Error(3)
+}
+
+func (m *UserRepositoryMock) LockAccount(ctx context.Context, userID int64, until time.Time) error {
+	args := m.Called(ctx, userID, until)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) ResetLoginFailures(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) UpdatePasswordHash(ctx context.Context, userID int64, passwordHash string) error {
+	args := m.Called(ctx, userID, passwordHash)
+	return args.Error(0)
+}
@@ -0,0 +1,72 @@
+package mocks
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+)
+
+type VaultRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *VaultRepositoryMock) CreateOrganization(ctx context.Context, ownerID int64, name string) (*vault.Organization, error) {
+	args := m.Called(ctx, ownerID, name)
+	return args.Get(0).(*vault.Organization), args.Error(1)
+}
+
+func (m *VaultRepositoryMock) GetOrganization(ctx context.Context, userID int64, orgUUID uuid.UUID) (*vault.Organization, error) {
+	args := m.Called(ctx, userID, orgUUID)
+	return args.Get(0).(*vault.Organization), args.Error(1)
+}
+
+func (m *VaultRepositoryMock) ListOrganizations(ctx context.Context, userID int64) ([]*vault.Organization, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*vault.Organization), args.Error(1)
+}
+
+func (m *VaultRepositoryMock) SetOrganizationMember(ctx context.Context, orgID int64, userID int64, role vault.Role) error {
+	args := m.Called(ctx, orgID, userID, role)
+	return args.Error(0)
+}
+
+func (m *VaultRepositoryMock) RemoveOrganizationMember(ctx context.Context, orgID int64, userID int64) error {
+	args := m.Called(ctx, orgID, userID)
+	return args.Error(0)
+}
+
+func (m *VaultRepositoryMock) ListOrganizationMembers(ctx context.Context, orgID int64) ([]*vault.Member, error) {
+	args := m.Called(ctx, orgID)
+	return args.Get(0).([]*vault.Member), args.Error(1)
+}
+
+func (m *VaultRepositoryMock) CreateVault(ctx context.Context, ownerID int64, orgID *int64, name string) (*vault.Vault, error) {
+	args := m.Called(ctx, ownerID, orgID, name)
+	return args.Get(0).(*vault.Vault), args.Error(1)
+}
+
+func (m *VaultRepositoryMock) GetVault(ctx context.Context, userID int64, vaultUUID uuid.UUID) (*vault.Vault, error) {
+	args := m.Called(ctx, userID, vaultUUID)
+	return args.Get(0).(*vault.Vault), args.Error(1)
+}
+
+func (m *VaultRepositoryMock) ListVaults(ctx context.Context, userID int64) ([]*vault.Vault, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*vault.Vault), args.Error(1)
+}
+
+func (m *VaultRepositoryMock) SetVaultMember(ctx context.Context, vaultID int64, userID int64, role vault.Role) error {
+	args := m.Called(ctx, vaultID, userID, role)
+	return args.Error(0)
+}
+
+func (m *VaultRepositoryMock) RemoveVaultMember(ctx context.Context, vaultID int64, userID int64) error {
+	args := m.Called(ctx, vaultID, userID)
+	return args.Error(0)
+}
+
+func (m *VaultRepositoryMock) ListVaultMembers(ctx context.Context, vaultID int64) ([]*vault.Member, error) {
+	args := m.Called(ctx, vaultID)
+	return args.Get(0).([]*vault.Member), args.Error(1)
+}
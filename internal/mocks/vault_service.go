@@ -0,0 +1,61 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+)
+
+type VaultServiceMock struct {
+	mock.Mock
+}
+
+func (m *VaultServiceMock) CreateOrganization(ctx context.Context, userID int64, name string) (*vault.Organization, error) {
+	args := m.Called(ctx, userID, name)
+	return args.Get(0).(*vault.Organization), args.Error(1)
+}
+
+func (m *VaultServiceMock) ListOrganizations(ctx context.Context, userID int64) ([]*vault.Organization, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*vault.Organization), args.Error(1)
+}
+
+func (m *VaultServiceMock) OrganizationMembers(ctx context.Context, userID int64, orgUUID string) ([]*vault.Member, error) {
+	args := m.Called(ctx, userID, orgUUID)
+	return args.Get(0).([]*vault.Member), args.Error(1)
+}
+
+func (m *VaultServiceMock) SetOrganizationMember(ctx context.Context, userID int64, orgUUID string, login string, role string) error {
+	args := m.Called(ctx, userID, orgUUID, login, role)
+	return args.Error(0)
+}
+
+func (m *VaultServiceMock) RemoveOrganizationMember(ctx context.Context, userID int64, orgUUID string, login string) error {
+	args := m.Called(ctx, userID, orgUUID, login)
+	return args.Error(0)
+}
+
+func (m *VaultServiceMock) CreateVault(ctx context.Context, userID int64, name string, orgUUID string) (*vault.Vault, error) {
+	args := m.Called(ctx, userID, name, orgUUID)
+	return args.Get(0).(*vault.Vault), args.Error(1)
+}
+
+func (m *VaultServiceMock) ListVaults(ctx context.Context, userID int64) ([]*vault.Vault, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*vault.Vault), args.Error(1)
+}
+
+func (m *VaultServiceMock) Members(ctx context.Context, userID int64, vaultUUID string) ([]*vault.Member, error) {
+	args := m.Called(ctx, userID, vaultUUID)
+	return args.Get(0).([]*vault.Member), args.Error(1)
+}
+
+func (m *VaultServiceMock) Share(ctx context.Context, userID int64, vaultUUID string, login string, role string) error {
+	args := m.Called(ctx, userID, vaultUUID, login, role)
+	return args.Error(0)
+}
+
+func (m *VaultServiceMock) Unshare(ctx context.Context, userID int64, vaultUUID string, login string) error {
+	args := m.Called(ctx, userID, vaultUUID, login)
+	return args.Error(0)
+}
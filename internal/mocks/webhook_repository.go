@@ -0,0 +1,81 @@
+package mocks
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
+	"time"
+)
+
+type WebhookRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *WebhookRepositoryMock) Create(ctx context.Context, sub *webhook.Subscription) (*webhook.Subscription, error) {
+	args := m.Called(ctx, sub)
+	if v := args.Get(0); v != nil {
+		return v.(*webhook.Subscription), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) ListByUser(ctx context.Context, userID int64) ([]*webhook.Subscription, error) {
+	args := m.Called(ctx, userID)
+	if v := args.Get(0); v != nil {
+		return v.([]*webhook.Subscription), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) ListForEvents(ctx context.Context, userID int64) ([]*webhook.Subscription, error) {
+	args := m.Called(ctx, userID)
+	if v := args.Get(0); v != nil {
+		return v.([]*webhook.Subscription), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) Get(ctx context.Context, userID int64, subscriptionID uuid.UUID) (*webhook.Subscription, error) {
+	args := m.Called(ctx, userID, subscriptionID)
+	if v := args.Get(0); v != nil {
+		return v.(*webhook.Subscription), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) Delete(ctx context.Context, userID int64, subscriptionID uuid.UUID) error {
+	args := m.Called(ctx, userID, subscriptionID)
+	return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) Enqueue(ctx context.Context, deliveries []*webhook.Delivery) error {
+	args := m.Called(ctx, deliveries)
+	return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*webhook.Delivery, map[int64]*webhook.Subscription, error) {
+	args := m.Called(ctx, now, lease, limit)
+	var list []*webhook.Delivery
+	if v := args.Get(0); v != nil {
+		list = v.([]*webhook.Delivery)
+	}
+	var subs map[int64]*webhook.Subscription
+	if v := args.Get(1); v != nil {
+		subs = v.(map[int64]*webhook.Subscription)
+	}
+	return list, subs, args.Error(2)
+}
+
+func (m *WebhookRepositoryMock) SaveAttempt(ctx context.Context, d *webhook.Delivery) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]*webhook.Delivery, error) {
+	args := m.Called(ctx, subscriptionID, limit)
+	if v := args.Get(0); v != nil {
+		return v.([]*webhook.Delivery), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
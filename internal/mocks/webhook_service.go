@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
+)
+
+type WebhookServiceMock struct {
+	mock.Mock
+}
+
+func (m *WebhookServiceMock) Publish(ctx context.Context, e webhook.Event) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *WebhookServiceMock) Create(ctx context.Context, userID int64, url string, events []string, tags []string) (*webhook.Subscription, string, error) {
+	args := m.Called(ctx, userID, url, events, tags)
+	if v := args.Get(0); v != nil {
+		return v.(*webhook.Subscription), args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (m *WebhookServiceMock) List(ctx context.Context, userID int64) ([]*webhook.Subscription, error) {
+	args := m.Called(ctx, userID)
+	if v := args.Get(0); v != nil {
+		return v.([]*webhook.Subscription), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *WebhookServiceMock) Delete(ctx context.Context, userID int64, subscriptionID string) error {
+	args := m.Called(ctx, userID, subscriptionID)
+	return args.Error(0)
+}
+
+func (m *WebhookServiceMock) Deliveries(ctx context.Context, userID int64, subscriptionID string) ([]*webhook.Delivery, error) {
+	args := m.Called(ctx, userID, subscriptionID)
+	if v := args.Get(0); v != nil {
+		return v.([]*webhook.Delivery), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *WebhookServiceMock) DeliverDue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+type WebhookSenderMock struct {
+	mock.Mock
+}
+
+func (m *WebhookSenderMock) Send(ctx context.Context, url string, d *webhook.Delivery, signature string) (int, error) {
+	args := m.Called(ctx, url, d, signature)
+	return args.Int(0), args.Error(1)
+}
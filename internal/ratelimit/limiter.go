@@ -0,0 +1,152 @@
+// Package ratelimit provides an in-memory limiter with exponential backoff
+// used to slow down brute-force attempts against the auth endpoints.
+package ratelimit
+
+import (
+	"github.com/thxhix/passKeeper/internal/config"
+	"sync"
+	"time"
+)
+
+// Clock returns the current time. Tests substitute it with a fake clock.
+type Clock func() time.Time
+
+// sweepEvery defines how many recorded failures trigger a cleanup of idle keys.
+const sweepEvery = 1024
+
+// Policy describes how failures are converted into waiting time.
+//
+// The first FreeAttempts failures of a key are not penalized. Every further
+// failure blocks the key for BaseDelay * 2^(n-1), capped at MaxDelay. A key
+// without failures for Window is forgotten.
+type Policy struct {
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	Window       time.Duration
+}
+
+// NewPolicy builds a Policy from the rate limit configuration.
+func NewPolicy(cfg *config.Config) Policy {
+	return Policy{
+		FreeAttempts: cfg.AuthFreeAttempts,
+		BaseDelay:    time.Duration(cfg.AuthBackoffBaseSeconds) * time.Second,
+		MaxDelay:     time.Duration(cfg.AuthBackoffMaxSeconds) * time.Second,
+		Window:       time.Duration(cfg.AuthWindowSeconds) * time.Second,
+	}
+}
+
+type entry struct {
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time
+}
+
+// Limiter tracks failures per arbitrary key (for example "ip:10.0.0.1" or
+// "login:alice") and tells whether a key is currently allowed to try again.
+//
+// Limiter is safe for concurrent use. State is kept in memory only.
+type Limiter struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	policy  Policy
+	now     Clock
+	sweeps  int
+}
+
+// NewLimiter creates a Limiter with the given policy. If now is nil time.Now is used.
+func NewLimiter(policy Policy, now Clock) *Limiter {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Limiter{
+		entries: make(map[string]*entry),
+		policy:  policy,
+		now:     now,
+	}
+}
+
+// Allow reports whether key may attempt now. If not, it returns the time left
+// until the key is unblocked.
+func (l *Limiter) Allow(key string) (retryAfter time.Duration, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, found := l.entries[key]
+	if !found {
+		return 0, true
+	}
+
+	now := l.now()
+	if now.Before(e.blockedUntil) {
+		return e.blockedUntil.Sub(now), false
+	}
+
+	return 0, true
+}
+
+// Failure records a failed attempt for key and extends its block if the
+// number of failures exceeds the free attempts.
+func (l *Limiter) Failure(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	e, found := l.entries[key]
+	if !found || l.expired(e, now) {
+		e = &entry{}
+		l.entries[key] = e
+	}
+
+	e.failures++
+	e.lastFailure = now
+
+	if over := e.failures - l.policy.FreeAttempts; over > 0 {
+		e.blockedUntil = now.Add(l.delay(over))
+	}
+
+	l.sweeps++
+	if l.sweeps >= sweepEvery {
+		l.sweeps = 0
+		l.sweep(now)
+	}
+}
+
+// Reset forgets all failures recorded for key.
+func (l *Limiter) Reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.entries, key)
+}
+
+// delay returns the backoff for the n-th failure over the free attempts.
+func (l *Limiter) delay(n int) time.Duration {
+	d := l.policy.BaseDelay
+	for i := 1; i < n; i++ {
+		d *= 2
+		if d >= l.policy.MaxDelay {
+			return l.policy.MaxDelay
+		}
+	}
+	if d > l.policy.MaxDelay {
+		return l.policy.MaxDelay
+	}
+	return d
+}
+
+// expired reports whether e is idle long enough to be forgotten.
+func (l *Limiter) expired(e *entry, now time.Time) bool {
+	return now.After(e.blockedUntil) && now.Sub(e.lastFailure) > l.policy.Window
+}
+
+// sweep removes idle keys so the map does not grow without bounds.
+func (l *Limiter) sweep(now time.Time) {
+	for key, e := range l.entries {
+		if l.expired(e, now) {
+			delete(l.entries, key)
+		}
+	}
+}
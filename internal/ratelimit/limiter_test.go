@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.t }
+
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestLimiter() (*Limiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)}
+	l := NewLimiter(Policy{
+		FreeAttempts: 2,
+		BaseDelay:    time.Second,
+		MaxDelay:     10 * time.Second,
+		Window:       time.Minute,
+	}, clock.Now)
+	return l, clock
+}
+
+func TestLimiter_FreeAttempts(t *testing.T) {
+	l, _ := newTestLimiter()
+
+	l.Failure("ip:1")
+	l.Failure("ip:1")
+
+	_, ok := l.Allow("ip:1")
+	require.True(t, ok, "free attempts must not block")
+}
+
+func TestLimiter_ExponentialBackoff(t *testing.T) {
+	l, clock := newTestLimiter()
+
+	l.Failure("login:alice")
+	l.Failure("login:alice")
+
+	expected := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for _, want := range expected {
+		l.Failure("login:alice")
+
+		retryAfter, ok := l.Allow("login:alice")
+		require.False(t, ok)
+		require.Equal(t, want, retryAfter)
+
+		clock.Advance(want - time.Millisecond)
+		_, ok = l.Allow("login:alice")
+		require.False(t, ok, "must stay blocked until the delay elapses")
+
+		clock.Advance(time.Millisecond)
+		_, ok = l.Allow("login:alice")
+		require.True(t, ok, "must be unblocked once the delay elapses")
+	}
+}
+
+func TestLimiter_KeysAreIndependent(t *testing.T) {
+	l, _ := newTestLimiter()
+
+	for i := 0; i < 3; i++ {
+		l.Failure("ip:1")
+	}
+
+	_, ok := l.Allow("ip:1")
+	require.False(t, ok)
+
+	_, ok = l.Allow("ip:2")
+	require.True(t, ok)
+}
+
+func TestLimiter_Reset(t *testing.T) {
+	l, _ := newTestLimiter()
+
+	for i := 0; i < 3; i++ {
+		l.Failure("login:bob")
+	}
+
+	l.Reset("login:bob")
+
+	_, ok := l.Allow("login:bob")
+	require.True(t, ok)
+}
+
+func TestLimiter_WindowForgetsIdleKeys(t *testing.T) {
+	l, clock := newTestLimiter()
+
+	for i := 0; i < 3; i++ {
+		l.Failure("ip:1")
+	}
+
+	clock.Advance(2 * time.Minute)
+
+	l.Failure("ip:1")
+
+	_, ok := l.Allow("ip:1")
+	require.True(t, ok, "counter must restart after the window of inactivity")
+}
@@ -0,0 +1,82 @@
+package security
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+	"strconv"
+	"time"
+)
+
+// actionClaims are the claims of an action token. Purpose is the audience, so
+// a token issued for one action is rejected by every other one, and State
+// binds the token to the account state it was issued for.
+type actionClaims struct {
+	State string `json:"state"`
+	jwt.RegisteredClaims
+}
+
+// ActionTokenSigner issues and verifies short-lived, single-purpose tokens
+// that are mailed to users, such as email verification and password reset
+// links. Tokens are HS256 JWTs signed with a dedicated secret.
+type ActionTokenSigner struct {
+	secret []byte
+	issuer string
+}
+
+// NewActionTokenSigner creates an ActionTokenSigner. The secret must be at
+// least 32 bytes long, otherwise ErrSecretTooShort is returned.
+func NewActionTokenSigner(secret string, issuer string) (*ActionTokenSigner, error) {
+	if !IsValidSecretKey(secret) {
+		return nil, ErrSecretTooShort
+	}
+
+	return &ActionTokenSigner{
+		secret: []byte(secret),
+		issuer: issuer,
+	}, nil
+}
+
+// Sign issues a token for purpose on behalf of userID that expires at
+// expiresAt. state is returned unchanged by Verify; callers use it to make a
+// token single-use by binding it to data the action changes.
+func (s *ActionTokenSigner) Sign(purpose string, userID int64, state string, expiresAt time.Time) (string, error) {
+	claims := actionClaims{
+		State: state,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatInt(userID, 10),
+			Issuer:    s.issuer,
+			Audience:  []string{purpose},
+			IssuedAt:  jwt.NewNumericDate(time.Now().UTC()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+}
+
+// Verify checks the signature, purpose and expiry of token and returns the
+// user ID and state it was issued with, or ErrInvalidActionToken.
+func (s *ActionTokenSigner) Verify(token string, purpose string) (userID int64, state string, err error) {
+	keyFunc := func(t *jwt.Token) (any, error) {
+		if t.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, ErrUnexpectedSigningMethod
+		}
+		return s.secret, nil
+	}
+
+	var claims actionClaims
+	tkn, err := jwt.ParseWithClaims(token, &claims, keyFunc,
+		jwt.WithIssuer(s.issuer),
+		jwt.WithAudience(purpose),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || !tkn.Valid {
+		return 0, "", ErrInvalidActionToken
+	}
+
+	userID, err = strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return 0, "", ErrInvalidActionToken
+	}
+
+	return userID, claims.State, nil
+}
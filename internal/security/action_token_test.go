@@ -0,0 +1,53 @@
+package security
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestActionTokenSigner_SignVerify(t *testing.T) {
+	s, err := NewActionTokenSigner(RightSecret, "test-issuer")
+	require.NoError(t, err)
+
+	token, err := s.Sign("reset-password", 42, "state", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	userID, state, err := s.Verify(token, "reset-password")
+	require.NoError(t, err)
+	require.Equal(t, int64(42), userID)
+	require.Equal(t, "state", state)
+}
+
+func TestActionTokenSigner_Rejects(t *testing.T) {
+	s, err := NewActionTokenSigner(RightSecret, "test-issuer")
+	require.NoError(t, err)
+
+	token, err := s.Sign("verify-email", 42, "state", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	// wrong purpose
+	_, _, err = s.Verify(token, "reset-password")
+	require.ErrorIs(t, err, ErrInvalidActionToken)
+
+	// tampered
+	_, _, err = s.Verify(token+"x", "verify-email")
+	require.ErrorIs(t, err, ErrInvalidActionToken)
+
+	// other secret
+	other, err := NewActionTokenSigner(RightSecret+"other", "test-issuer")
+	require.NoError(t, err)
+	_, _, err = other.Verify(token, "verify-email")
+	require.ErrorIs(t, err, ErrInvalidActionToken)
+
+	// expired
+	expired, err := s.Sign("verify-email", 42, "state", time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	_, _, err = s.Verify(expired, "verify-email")
+	require.ErrorIs(t, err, ErrInvalidActionToken)
+}
+
+func TestActionTokenSigner_ShortSecret(t *testing.T) {
+	_, err := NewActionTokenSigner("short", "test-issuer")
+	require.ErrorIs(t, err, ErrSecretTooShort)
+}
@@ -0,0 +1,109 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v5"
+	"time"
+)
+
+// maxAssertionLifetime bounds how far in the future a client assertion may
+// expire, which keeps the replay window short.
+const maxAssertionLifetime = 5 * time.Minute
+
+// ParsePublicKeyPEM parses a PKIX PEM-encoded Ed25519 or P-256 public key.
+func ParsePublicKeyPEM(data string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, ErrInvalidPublicKey
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, ErrInvalidPublicKey
+	}
+
+	switch k := parsed.(type) {
+	case ed25519.PublicKey:
+		return k, nil
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return nil, ErrUnsupportedSigningKey
+		}
+		return k, nil
+	default:
+		return nil, ErrUnsupportedSigningKey
+	}
+}
+
+// ValidateAssertionKey checks that publicKeyPEM can verify client assertions.
+func (j *JWTManager) ValidateAssertionKey(publicKeyPEM string) error {
+	_, err := ParsePublicKeyPEM(publicKeyPEM)
+	return err
+}
+
+// ParseClientAssertion validates a JWT assertion signed by a service account
+// (RFC 7523).
+//
+// The `iss` and `sub` claims must both hold the client ID; lookupKey returns
+// the PEM public key registered for it. The assertion must be addressed to
+// this server's audience, carry a `jti` and expire within five minutes.
+// Returns the client ID, the assertion ID and its expiry, or the error of
+// lookupKey, or ErrInvalidAssertion.
+func (j *JWTManager) ParseClientAssertion(assertion string, lookupKey func(clientID string) (string, error)) (clientID, jti string, expiresAt time.Time, err error) {
+	var lookupErr error
+
+	keyFunc := func(t *jwt.Token) (any, error) {
+		claims := t.Claims.(*Claims)
+		if claims.Issuer == "" || claims.Subject != claims.Issuer {
+			return nil, ErrInvalidAssertion
+		}
+
+		keyPEM, err := lookupKey(claims.Issuer)
+		if err != nil {
+			lookupErr = err
+			return nil, err
+		}
+
+		key, err := ParsePublicKeyPEM(keyPEM)
+		if err != nil {
+			return nil, err
+		}
+
+		switch key.(type) {
+		case ed25519.PublicKey:
+			if t.Method.Alg() != AlgEdDSA {
+				return nil, ErrUnexpectedSigningMethod
+			}
+		case *ecdsa.PublicKey:
+			if t.Method.Alg() != AlgES256 {
+				return nil, ErrUnexpectedSigningMethod
+			}
+		}
+
+		return key, nil
+	}
+
+	var claims Claims
+	tkn, err := jwt.ParseWithClaims(assertion, &claims, keyFunc,
+		jwt.WithAudience(j.cfg.JWTAudience),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30*time.Second),
+		jwt.WithValidMethods([]string{AlgEdDSA, AlgES256}),
+	)
+	if lookupErr != nil {
+		return "", "", time.Time{}, lookupErr
+	}
+	if err != nil || !tkn.Valid {
+		return "", "", time.Time{}, ErrInvalidAssertion
+	}
+	if claims.ID == "" || claims.ExpiresAt.Time.After(time.Now().Add(maxAssertionLifetime)) {
+		return "", "", time.Time{}, ErrInvalidAssertion
+	}
+
+	return claims.Issuer, claims.ID, claims.ExpiresAt.Time, nil
+}
@@ -0,0 +1,130 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func publicKeyPEM(t *testing.T, pub crypto.PublicKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func signAssertion(t *testing.T, method jwt.SigningMethod, key crypto.Signer, claims jwt.RegisteredClaims) string {
+	t.Helper()
+
+	s, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func assertionClaims(clientID string, lifetime time.Duration) jwt.RegisteredClaims {
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		Issuer:    clientID,
+		Subject:   clientID,
+		Audience:  []string{"test-audience"},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)),
+		ID:        uuid.NewString(),
+	}
+}
+
+func TestParsePublicKeyPEM(t *testing.T) {
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, err = ParsePublicKeyPEM(publicKeyPEM(t, edPub))
+	require.NoError(t, err)
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, err = ParsePublicKeyPEM(publicKeyPEM(t, &p384.PublicKey))
+	require.ErrorIs(t, err, ErrUnsupportedSigningKey)
+
+	_, err = ParsePublicKeyPEM("not a key")
+	require.ErrorIs(t, err, ErrInvalidPublicKey)
+}
+
+func TestJWTManager_ParseClientAssertion(t *testing.T) {
+	jm := NewJWTManager(testJWTConfig(), newTestKeyRing(t, AlgEdDSA))
+
+	clientID := uuid.NewString()
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keys := map[string]string{clientID: publicKeyPEM(t, edPub)}
+	lookup := func(id string) (string, error) {
+		if k, ok := keys[id]; ok {
+			return k, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	claims := assertionClaims(clientID, time.Minute)
+	gotID, jti, exp, err := jm.ParseClientAssertion(signAssertion(t, jwt.SigningMethodEdDSA, edKey, claims), lookup)
+	require.NoError(t, err)
+	require.Equal(t, clientID, gotID)
+	require.Equal(t, claims.ID, jti)
+	require.WithinDuration(t, claims.ExpiresAt.Time, exp, time.Second)
+
+	// signed with a key other than the registered one
+	_, _, _, err = jm.ParseClientAssertion(signAssertion(t, jwt.SigningMethodES256, ecKey, claims), lookup)
+	require.ErrorIs(t, err, ErrInvalidAssertion)
+
+	// expiry too far in the future
+	_, _, _, err = jm.ParseClientAssertion(signAssertion(t, jwt.SigningMethodEdDSA, edKey, assertionClaims(clientID, time.Hour)), lookup)
+	require.ErrorIs(t, err, ErrInvalidAssertion)
+
+	// wrong audience
+	wrongAud := assertionClaims(clientID, time.Minute)
+	wrongAud.Audience = []string{"other"}
+	_, _, _, err = jm.ParseClientAssertion(signAssertion(t, jwt.SigningMethodEdDSA, edKey, wrongAud), lookup)
+	require.ErrorIs(t, err, ErrInvalidAssertion)
+
+	// missing jti
+	noID := assertionClaims(clientID, time.Minute)
+	noID.ID = ""
+	_, _, _, err = jm.ParseClientAssertion(signAssertion(t, jwt.SigningMethodEdDSA, edKey, noID), lookup)
+	require.ErrorIs(t, err, ErrInvalidAssertion)
+
+	// lookup errors are returned as is
+	unknown := assertionClaims(uuid.NewString(), time.Minute)
+	_, _, _, err = jm.ParseClientAssertion(signAssertion(t, jwt.SigningMethodEdDSA, edKey, unknown), lookup)
+	require.EqualError(t, err, "not found")
+}
+
+func TestJWTManager_ServiceAccessToken(t *testing.T) {
+	jm := NewJWTManager(testJWTConfig(), newTestKeyRing(t, AlgEdDSA))
+
+	clientID := uuid.NewString()
+	token, ttl, err := jm.GenerateServiceAccessToken(7, clientID)
+	require.NoError(t, err)
+	require.Positive(t, ttl)
+
+	sub, _, _, gotClientID, err := jm.ParseAccessToken(token)
+	require.NoError(t, err)
+	require.Equal(t, "7", sub)
+	require.Equal(t, clientID, gotClientID)
+
+	userToken, err := jm.GenerateAccessToken(7)
+	require.NoError(t, err)
+	_, _, _, gotClientID, err = jm.ParseAccessToken(userToken)
+	require.NoError(t, err)
+	require.Empty(t, gotClientID)
+}
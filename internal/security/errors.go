@@ -3,6 +3,7 @@ package security
 import (
 	"errors"
 	"github.com/thxhix/passKeeper/internal/apperr"
+	"time"
 )
 
 var (
@@ -13,7 +14,20 @@ var (
 	ErrRefreshInvalidClaims = apperr.NewAuthError("invalid refresh claims")
 
 	ErrUnexpectedSigningMethod = apperr.NewAuthError("unexpected signing method")
+	ErrUnknownSigningKey       = apperr.NewAuthError("unknown signing key")
+	ErrInvalidAssertion        = apperr.NewAuthError("invalid client assertion")
+	ErrInvalidActionToken      = apperr.NewAuthError("invalid or expired action token")
+
+	ErrInvalidSigningKey     = errors.New("invalid signing key")
+	ErrUnsupportedSigningKey = errors.New("unsupported signing key type")
+	ErrActiveKeyNotFound     = errors.New("active signing key not found")
+	ErrInvalidPublicKey      = errors.New("invalid PEM public key")
 
 	ErrSecretTooShort  = errors.New("secret too short")
 	ErrAEADWrongLength = errors.New("invalid AEAD length, expect 32 bytes")
+
+	ErrInvalidPasswordHash     = errors.New("invalid password hash format")
+	ErrIncompatibleHashVersion = errors.New("incompatible argon2 version")
+
+	ErrHasherBusy = apperr.NewUnavailableError("too many password checks in progress, try again later", time.Second)
 )
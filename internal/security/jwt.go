@@ -12,22 +12,36 @@ import (
 
 // JWTManager handles generation and validation of access and refresh JWT tokens.
 //
-// It relies on secret keys defined in the config and supports HS256 signing.
+// Access tokens are signed with the active asymmetric key of the key ring
+// (EdDSA or ES256) and carry its ID in the `kid` header, so other services can
+// verify them with the published JWKS. Refresh tokens are only ever verified by
+// this server and are signed with HS256 using the secret from the config.
 type JWTManager struct {
-	cfg *config.Config
+	cfg  *config.Config
+	keys *KeyRing
 }
 
-// NewJWTManager creates a new JWTManager with the given configuration.
-func NewJWTManager(cfg *config.Config) JWTManager {
+// NewJWTManager creates a new JWTManager with the given configuration and key ring.
+func NewJWTManager(cfg *config.Config, keys *KeyRing) JWTManager {
 	return JWTManager{
-		cfg: cfg,
+		cfg:  cfg,
+		keys: keys,
 	}
 }
 
 // Claims defines JWT claims used for both access and refresh tokens.
 // It embeds the standard RegisteredClaims (sub, iss, aud, exp, iat, nbf, jti).
+//
+// ServiceAccount is set on access tokens issued to a service account: it holds
+// its client ID, while `sub` is the ID of the owning user.
 type Claims struct {
 	jwt.RegisteredClaims
+	ServiceAccount string `json:"sa,omitempty"`
+}
+
+// JWKS returns the public keys accepted for access token verification.
+func (j *JWTManager) JWKS() []JWK {
+	return j.keys.JWKS()
 }
 
 // IsValidSecretKey validates the provided secret length.
@@ -42,13 +56,31 @@ func IsValidSecretKey(key string) bool {
 //
 // The access token has a short lifespan (configured via
 // cfg.JWTAccessExpTimeMinute) and should be included in the
-// Authorization header for every API request.
+// Authorization header for every API request. It is signed with the active
+// key of the key ring.
 //
-// Returns the signed token string or an error if the secret is too short.
+// Returns the signed token string or an error if signing fails.
 func (j *JWTManager) GenerateAccessToken(userID int64) (string, error) {
-	if !IsValidSecretKey(j.cfg.JWTAccessSecretKey) {
-		return "", ErrSecretTooShort
+	return j.signAccessToken(userID, "")
+}
+
+// GenerateServiceAccessToken issues an access JWT for a service account of
+// the owner. Service accounts get no refresh token: they exchange their
+// credentials again once the access token expires.
+//
+// Returns the signed token string and its lifetime.
+func (j *JWTManager) GenerateServiceAccessToken(ownerID int64, clientID string) (token string, ttl time.Duration, err error) {
+	token, err = j.signAccessToken(ownerID, clientID)
+	if err != nil {
+		return "", 0, err
 	}
+	return token, time.Duration(j.cfg.JWTAccessExpTimeMinute) * time.Minute, nil
+}
+
+// signAccessToken signs an access token for userID, acting as the service
+// account clientID when it is not empty.
+func (j *JWTManager) signAccessToken(userID int64, clientID string) (string, error) {
+	key := j.keys.Active()
 
 	now := time.Now().UTC()
 	claims := Claims{
@@ -61,37 +93,54 @@ func (j *JWTManager) GenerateAccessToken(userID int64) (string, error) {
 			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(j.cfg.JWTAccessExpTimeMinute) * time.Minute)),
 			ID:        uuid.NewString(),
 		},
+		ServiceAccount: clientID,
 	}
 
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return t.SignedString([]byte(j.cfg.JWTAccessSecretKey))
+	t := jwt.NewWithClaims(key.method(), claims)
+	t.Header["kid"] = key.ID
+	return t.SignedString(key.signer)
 }
 
 // ParseAccessToken validates and parses an access JWT string.
 //
-// It checks the signing algorithm, issuer, audience and expiration time.
-// Returns the userID (from the `sub` claim) or an error if the token is invalid.
-func (j *JWTManager) ParseAccessToken(tokenStr string) (userID string, err error) {
+// It looks up the verification key by the `kid` header and checks the signing
+// algorithm, issuer, audience and expiration time.
+// Returns the userID (from the `sub` claim), the issue and expiry times (from
+// the `iat` and `exp` claims, zero if absent), the client ID of the service
+// account the token was issued to (empty for user tokens) or an error if the
+// token is invalid.
+func (j *JWTManager) ParseAccessToken(tokenStr string) (userID string, issuedAt time.Time, expiresAt time.Time, clientID string, err error) {
 	keyFunc := func(t *jwt.Token) (any, error) {
-		if t.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := j.keys.Lookup(kid)
+		if !ok {
+			return nil, ErrUnknownSigningKey
+		}
+		if t.Method.Alg() != key.Algorithm {
 			return nil, ErrUnexpectedSigningMethod
 		}
-		return []byte(j.cfg.JWTAccessSecretKey), nil
+		return key.PublicKey(), nil
 	}
 	var claims Claims
 	tkn, err := jwt.ParseWithClaims(tokenStr, &claims, keyFunc,
 		jwt.WithIssuer(j.cfg.JWTIssuer),
 		jwt.WithAudience(j.cfg.JWTAudience),
 		jwt.WithLeeway(30*time.Second),
-		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithValidMethods([]string{AlgEdDSA, AlgES256}),
 	)
 	if err != nil || !tkn.Valid {
-		return "", ErrAccessExpiredToken
+		return "", time.Time{}, time.Time{}, "", ErrAccessExpiredToken
 	}
 	if claims.Subject == "" {
-		return "", ErrAccessInvalidSubject
+		return "", time.Time{}, time.Time{}, "", ErrAccessInvalidSubject
+	}
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
 	}
-	return claims.Subject, nil
+	return claims.Subject, issuedAt, expiresAt, claims.ServiceAccount, nil
 }
 
 // GenerateRefreshToken issues a new refresh JWT for the given user ID.
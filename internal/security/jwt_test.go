@@ -1,15 +1,16 @@
 package security
 
 import (
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/thxhix/passKeeper/internal/config"
 	"testing"
+	"time"
 )
 
 func TestJWTManager_AccessToken(t *testing.T) {
 	jwtCfg := config.JWTConfig{
-		JWTAccessSecretKey:     RightSecret,
 		JWTRefreshSecretKey:    RightSecret,
 		JWTIssuer:              "test-issuer",
 		JWTAudience:            "test-audience",
@@ -19,7 +20,7 @@ func TestJWTManager_AccessToken(t *testing.T) {
 		JWTConfig: jwtCfg,
 	}
 
-	jm := NewJWTManager(cfg)
+	jm := NewJWTManager(cfg, newTestKeyRing(t, AlgEdDSA))
 	userID := int64(42)
 
 	// Generate access token
@@ -28,14 +29,14 @@ func TestJWTManager_AccessToken(t *testing.T) {
 	require.NotEmpty(t, token)
 
 	// Parse access token
-	sub, err := jm.ParseAccessToken(token)
+	sub, _, expiresAt, _, err := jm.ParseAccessToken(token)
 	require.NoError(t, err)
 	require.Equal(t, "42", sub)
+	require.WithinDuration(t, time.Now().Add(5*time.Minute), expiresAt, time.Minute)
 }
 
 func TestJWTManager_RefreshToken(t *testing.T) {
 	jwtCfg := config.JWTConfig{
-		JWTAccessSecretKey:     RightSecret,
 		JWTRefreshSecretKey:    RightSecret,
 		JWTIssuer:              "test-issuer",
 		JWTAudience:            "test-audience",
@@ -46,7 +47,7 @@ func TestJWTManager_RefreshToken(t *testing.T) {
 		JWTConfig: jwtCfg,
 	}
 
-	jm := NewJWTManager(cfg)
+	jm := NewJWTManager(cfg, newTestKeyRing(t, AlgEdDSA))
 	userID := int64(100)
 
 	// Generate refresh token
@@ -74,7 +75,6 @@ func TestJWTManager_CheckSecretKey(t *testing.T) {
 
 func TestJWTManager_InvalidAccessToken(t *testing.T) {
 	jwtCfg := config.JWTConfig{
-		JWTAccessSecretKey:     RightSecret,
 		JWTRefreshSecretKey:    RightSecret,
 		JWTIssuer:              "test-issuer",
 		JWTAudience:            "test-audience",
@@ -83,15 +83,14 @@ func TestJWTManager_InvalidAccessToken(t *testing.T) {
 	cfg := &config.Config{
 		JWTConfig: jwtCfg,
 	}
-	jm := NewJWTManager(cfg)
+	jm := NewJWTManager(cfg, newTestKeyRing(t, AlgEdDSA))
 
-	_, err := jm.ParseAccessToken("invalid.token.here")
+	_, _, _, _, err := jm.ParseAccessToken("invalid.token.here")
 	require.Error(t, err)
 }
 
 func TestJWTManager_InvalidRefreshToken(t *testing.T) {
 	jwtCfg := config.JWTConfig{
-		JWTAccessSecretKey:     RightSecret,
 		JWTRefreshSecretKey:    RightSecret,
 		JWTIssuer:              "test-issuer",
 		JWTAudience:            "test-audience",
@@ -100,8 +99,99 @@ func TestJWTManager_InvalidRefreshToken(t *testing.T) {
 	cfg := &config.Config{
 		JWTConfig: jwtCfg,
 	}
-	jm := NewJWTManager(cfg)
+	jm := NewJWTManager(cfg, newTestKeyRing(t, AlgEdDSA))
 
 	_, _, err := jm.ParseRefreshToken("invalid.token.here")
 	require.Error(t, err)
 }
+
+func newTestKeyRing(t *testing.T, alg string) *KeyRing {
+	t.Helper()
+
+	key, err := GenerateSigningKey(alg)
+	require.NoError(t, err)
+
+	return NewKeyRing(key, time.Minute, nil)
+}
+
+func testJWTConfig() *config.Config {
+	return &config.Config{
+		JWTConfig: config.JWTConfig{
+			JWTRefreshSecretKey:    RightSecret,
+			JWTIssuer:              "test-issuer",
+			JWTAudience:            "test-audience",
+			JWTAccessExpTimeMinute: 5,
+		},
+	}
+}
+
+func TestJWTManager_AccessToken_ES256(t *testing.T) {
+	ring := newTestKeyRing(t, AlgES256)
+	jm := NewJWTManager(testJWTConfig(), ring)
+
+	token, err := jm.GenerateAccessToken(7)
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+	require.NoError(t, err)
+	require.Equal(t, AlgES256, parsed.Method.Alg())
+	require.Equal(t, ring.Active().ID, parsed.Header["kid"])
+
+	sub, _, _, _, err := jm.ParseAccessToken(token)
+	require.NoError(t, err)
+	require.Equal(t, "7", sub)
+}
+
+func TestJWTManager_AccessToken_RejectsHS256(t *testing.T) {
+	jm := NewJWTManager(testJWTConfig(), newTestKeyRing(t, AlgEdDSA))
+
+	// a token signed with a shared secret must not be accepted even if it
+	// references a known key ID
+	claims := Claims{RegisteredClaims: jwt.RegisteredClaims{
+		Subject:   "1",
+		Issuer:    "test-issuer",
+		Audience:  []string{"test-audience"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}}
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	forged.Header["kid"] = jm.keys.Active().ID
+	token, err := forged.SignedString([]byte(RightSecret))
+	require.NoError(t, err)
+
+	_, _, _, _, err = jm.ParseAccessToken(token)
+	require.ErrorIs(t, err, ErrAccessExpiredToken)
+}
+
+func TestJWTManager_AccessToken_Rotation(t *testing.T) {
+	now := time.Date(2025, 10, 22, 10, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	first, err := GenerateSigningKey(AlgEdDSA)
+	require.NoError(t, err)
+	ring := NewKeyRing(first, 10*time.Minute, clock)
+	jm := NewJWTManager(testJWTConfig(), ring)
+
+	oldToken, err := jm.GenerateAccessToken(1)
+	require.NoError(t, err)
+
+	second, err := GenerateSigningKey(AlgES256)
+	require.NoError(t, err)
+	ring.Rotate(second)
+
+	newToken, err := jm.GenerateAccessToken(1)
+	require.NoError(t, err)
+
+	// both keys verify and are published during the overlap window
+	_, _, _, _, err = jm.ParseAccessToken(oldToken)
+	require.NoError(t, err)
+	_, _, _, _, err = jm.ParseAccessToken(newToken)
+	require.NoError(t, err)
+	require.Len(t, jm.JWKS(), 2)
+
+	now = now.Add(10 * time.Minute)
+
+	_, ok := ring.Lookup(first.ID)
+	require.False(t, ok, "retired key must be rejected after the overlap window")
+	require.Len(t, jm.JWKS(), 1)
+	require.Equal(t, second.ID, jm.JWKS()[0].Kid)
+}
@@ -0,0 +1,446 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/thxhix/passKeeper/internal/config"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Supported asymmetric signing algorithms for access tokens.
+const (
+	AlgEdDSA = "EdDSA"
+	AlgES256 = "ES256"
+)
+
+// keyFileExt is the extension of PEM files loaded from the keys directory.
+const keyFileExt = ".pem"
+
+// activeKeyFile is the file in the keys directory naming the active key ID.
+// It is read on every load, so the active key can be switched with SIGHUP.
+const activeKeyFile = "active"
+
+// JWK is the public part of a signing key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string
+	Crv string
+	X   string
+	Y   string
+	Kid string
+	Alg string
+	Use string
+}
+
+// SigningKey is an asymmetric key used to sign access tokens.
+//
+// ID is published in the `kid` header of every token signed with the key.
+type SigningKey struct {
+	ID        string
+	Algorithm string
+	signer    crypto.Signer
+	retireAt  time.Time
+}
+
+// NewSigningKey wraps a private key into a SigningKey.
+//
+// The key type must match the algorithm: ed25519.PrivateKey for EdDSA and an
+// *ecdsa.PrivateKey on the P-256 curve for ES256.
+func NewSigningKey(id string, signer crypto.Signer) (*SigningKey, error) {
+	if id == "" {
+		return nil, ErrInvalidSigningKey
+	}
+
+	switch k := signer.(type) {
+	case ed25519.PrivateKey:
+		return &SigningKey{ID: id, Algorithm: AlgEdDSA, signer: k}, nil
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, ErrUnsupportedSigningKey
+		}
+		return &SigningKey{ID: id, Algorithm: AlgES256, signer: k}, nil
+	default:
+		return nil, ErrUnsupportedSigningKey
+	}
+}
+
+// GenerateSigningKey creates a new random key for the given algorithm.
+//
+// The key ID is the RFC 7638 thumbprint of its public key.
+func GenerateSigningKey(alg string) (*SigningKey, error) {
+	var (
+		signer crypto.Signer
+		err    error
+	)
+
+	switch alg {
+	case AlgEdDSA:
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	case AlgES256:
+		signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, ErrUnsupportedSigningKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := NewSigningKey("pending", signer)
+	if err != nil {
+		return nil, err
+	}
+	key.ID = key.thumbprint()
+
+	return key, nil
+}
+
+// method returns the JWT signing method of the key.
+func (k *SigningKey) method() jwt.SigningMethod {
+	if k.Algorithm == AlgES256 {
+		return jwt.SigningMethodES256
+	}
+	return jwt.SigningMethodEdDSA
+}
+
+// PublicKey returns the public half of the key.
+func (k *SigningKey) PublicKey() crypto.PublicKey {
+	return k.signer.Public()
+}
+
+// JWK returns the public key in JSON Web Key format.
+func (k *SigningKey) JWK() JWK {
+	jwk := JWK{Kid: k.ID, Alg: k.Algorithm, Use: "sig"}
+
+	switch pub := k.PublicKey().(type) {
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	case *ecdsa.PublicKey:
+		jwk.Kty = "EC"
+		jwk.Crv = "P-256"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, 32)))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, 32)))
+	}
+
+	return jwk
+}
+
+// thumbprint computes the RFC 7638 JWK thumbprint of the public key.
+func (k *SigningKey) thumbprint() string {
+	jwk := k.JWK()
+
+	var members string
+	if jwk.Kty == "EC" {
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q,"y":%q}`, jwk.Crv, jwk.Kty, jwk.X, jwk.Y)
+	} else {
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, jwk.Crv, jwk.Kty, jwk.X)
+	}
+
+	sum := sha256.Sum256([]byte(members))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// KeyRing holds the key used to sign new access tokens and the keys that are
+// still accepted for verification.
+//
+// When the active key is rotated out, or a key disappears from the keys
+// directory, it keeps verifying tokens for the overlap window so tokens issued
+// just before the rotation stay valid until they expire. KeyRing is safe for
+// concurrent use.
+type KeyRing struct {
+	mu      sync.RWMutex
+	active  *SigningKey
+	keys    map[string]*SigningKey
+	overlap time.Duration
+	now     func() time.Time
+}
+
+// NewKeyRing creates a KeyRing with the given active key.
+// If now is nil time.Now is used.
+func NewKeyRing(active *SigningKey, overlap time.Duration, now func() time.Time) *KeyRing {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &KeyRing{
+		active:  active,
+		keys:    map[string]*SigningKey{active.ID: active},
+		overlap: overlap,
+		now:     now,
+	}
+}
+
+// LoadKeyRing builds a KeyRing from the JWT configuration.
+//
+// Keys are read from cfg.JWTKeysDir, one PKCS#8 PEM file per key named
+// <kid>.pem. New tokens are signed with the key named in the file "active" of
+// the directory, else with cfg.JWTActiveKeyID, else with the key with the
+// greatest ID (for example date-named files); the other keys are only used for
+// verification. When no directory is configured, an ephemeral
+// key is generated with cfg.JWTSigningAlgorithm; tokens signed with it do not
+// survive a restart, which is only suitable for development.
+func LoadKeyRing(cfg *config.Config) (*KeyRing, error) {
+	overlap := time.Duration(cfg.JWTKeyOverlapMinute) * time.Minute
+
+	if cfg.JWTKeysDir == "" {
+		key, err := GenerateSigningKey(cfg.JWTSigningAlgorithm)
+		if err != nil {
+			return nil, err
+		}
+		return NewKeyRing(key, overlap, nil), nil
+	}
+
+	keys, err := loadKeysDir(cfg.JWTKeysDir)
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := activeKeyID(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := activeKey(keys, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	ring := NewKeyRing(active, overlap, nil)
+	for _, key := range keys {
+		ring.Add(key)
+	}
+
+	return ring, nil
+}
+
+// Reload re-reads the keys directory and applies the differences: a changed
+// active key, including one switched in the active file, is rotated in, new keys are added and removed keys are retired
+// after the overlap window. It is a no-op for an ephemeral key ring.
+func (r *KeyRing) Reload(cfg *config.Config) error {
+	if cfg.JWTKeysDir == "" {
+		return nil
+	}
+
+	keys, err := loadKeysDir(cfg.JWTKeysDir)
+	if err != nil {
+		return err
+	}
+
+	kid, err := activeKeyID(cfg)
+	if err != nil {
+		return err
+	}
+
+	active, err := activeKey(keys, kid)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+
+	for id, key := range r.keys {
+		if _, found := keys[id]; !found && key.retireAt.IsZero() {
+			key.retireAt = now.Add(r.overlap)
+		}
+	}
+
+	for id, key := range keys {
+		if _, found := r.keys[id]; !found {
+			r.keys[id] = key
+		}
+	}
+
+	if r.active.ID != active.ID {
+		r.rotateLocked(r.keys[active.ID], now)
+	}
+
+	return nil
+}
+
+// Add registers a key that is accepted for verification only.
+func (r *KeyRing) Add(key *SigningKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.keys[key.ID]; !found {
+		r.keys[key.ID] = key
+	}
+}
+
+// Rotate makes next the active key. The previous active key keeps verifying
+// tokens until the overlap window elapses.
+func (r *KeyRing) Rotate(next *SigningKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rotateLocked(next, r.now())
+}
+
+func (r *KeyRing) rotateLocked(next *SigningKey, now time.Time) {
+	previous := r.active
+	if previous.ID == next.ID {
+		return
+	}
+
+	next.retireAt = time.Time{}
+	r.keys[next.ID] = next
+	r.active = next
+
+	if previous.retireAt.IsZero() {
+		previous.retireAt = now.Add(r.overlap)
+	}
+}
+
+// Active returns the key used to sign new tokens.
+func (r *KeyRing) Active() *SigningKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.active
+}
+
+// Lookup returns the verification key with the given ID. Keys whose overlap
+// window has elapsed are no longer returned. They stay in the ring, so a
+// reload does not bring a rotated-out key back while its file still exists.
+func (r *KeyRing) Lookup(kid string) (*SigningKey, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[kid]
+	if !ok || r.retired(key, r.now()) {
+		return nil, false
+	}
+
+	return key, true
+}
+
+// JWKS returns the public keys currently accepted for verification, sorted
+// by key ID.
+func (r *KeyRing) JWKS() []JWK {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := r.now()
+
+	set := make([]JWK, 0, len(r.keys))
+	for _, key := range r.keys {
+		if !r.retired(key, now) {
+			set = append(set, key.JWK())
+		}
+	}
+
+	sort.Slice(set, func(i, j int) bool { return set[i].Kid < set[j].Kid })
+
+	return set
+}
+
+// retired reports whether the overlap window of key has elapsed.
+func (r *KeyRing) retired(key *SigningKey, now time.Time) bool {
+	return !key.retireAt.IsZero() && !now.Before(key.retireAt)
+}
+
+// activeKeyID returns the key ID named in the active file of the keys
+// directory, or cfg.JWTActiveKeyID when there is no such file. An empty result
+// selects the greatest key ID.
+func activeKeyID(cfg *config.Config) (string, error) {
+	data, err := os.ReadFile(filepath.Join(cfg.JWTKeysDir, activeKeyFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg.JWTActiveKeyID, nil
+		}
+		return "", err
+	}
+
+	if kid := strings.TrimSpace(string(data)); kid != "" {
+		return kid, nil
+	}
+
+	return cfg.JWTActiveKeyID, nil
+}
+
+// activeKey selects the signing key: kid if set, otherwise the greatest key ID.
+func activeKey(keys map[string]*SigningKey, kid string) (*SigningKey, error) {
+	if kid == "" {
+		for id := range keys {
+			if id > kid {
+				kid = id
+			}
+		}
+	}
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrActiveKeyNotFound, kid)
+	}
+
+	return key, nil
+}
+
+// loadKeysDir reads every <kid>.pem file in dir.
+func loadKeysDir(dir string) (map[string]*SigningKey, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*SigningKey)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != keyFileExt {
+			continue
+		}
+
+		id := strings.TrimSuffix(entry.Name(), keyFileExt)
+
+		key, err := loadKeyFile(filepath.Join(dir, entry.Name()), id)
+		if err != nil {
+			return nil, fmt.Errorf("load signing key %q: %w", id, err)
+		}
+
+		keys[id] = key
+	}
+
+	return keys, nil
+}
+
+// loadKeyFile parses a PKCS#8 PEM-encoded private key.
+func loadKeyFile(path, id string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidSigningKey
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedSigningKey
+	}
+
+	return NewSigningKey(id, signer)
+}
@@ -0,0 +1,150 @@
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/stretchr/testify/require"
+	"github.com/thxhix/passKeeper/internal/config"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeKeyFile(t *testing.T, dir, kid string, key any) {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0o600))
+}
+
+func TestSigningKey_JWK(t *testing.T) {
+	ed, err := GenerateSigningKey(AlgEdDSA)
+	require.NoError(t, err)
+
+	jwk := ed.JWK()
+	require.Equal(t, "OKP", jwk.Kty)
+	require.Equal(t, "Ed25519", jwk.Crv)
+	require.Equal(t, ed.ID, jwk.Kid)
+	require.Len(t, jwk.X, 43)
+	require.Empty(t, jwk.Y)
+
+	ec, err := GenerateSigningKey(AlgES256)
+	require.NoError(t, err)
+
+	jwk = ec.JWK()
+	require.Equal(t, "EC", jwk.Kty)
+	require.Equal(t, "P-256", jwk.Crv)
+	require.Equal(t, AlgES256, jwk.Alg)
+	require.Len(t, jwk.X, 43)
+	require.Len(t, jwk.Y, 43)
+}
+
+func TestNewSigningKey_RejectsUnsupportedCurve(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = NewSigningKey("p384", key)
+	require.ErrorIs(t, err, ErrUnsupportedSigningKey)
+}
+
+func TestLoadKeyRing_Ephemeral(t *testing.T) {
+	ring, err := LoadKeyRing(&config.Config{JWTConfig: config.JWTConfig{JWTSigningAlgorithm: AlgES256}})
+	require.NoError(t, err)
+	require.Equal(t, AlgES256, ring.Active().Algorithm)
+
+	_, err = LoadKeyRing(&config.Config{JWTConfig: config.JWTConfig{JWTSigningAlgorithm: "HS256"}})
+	require.ErrorIs(t, err, ErrUnsupportedSigningKey)
+}
+
+func TestLoadKeyRing_DirAndReload(t *testing.T) {
+	dir := t.TempDir()
+
+	_, first, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	second, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	writeKeyFile(t, dir, "2025-09", first)
+	writeKeyFile(t, dir, "2025-10", first)
+
+	cfg := &config.Config{JWTConfig: config.JWTConfig{
+		JWTKeysDir:          dir,
+		JWTKeyOverlapMinute: 5,
+	}}
+
+	ring, err := LoadKeyRing(cfg)
+	require.NoError(t, err)
+
+	now := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	ring.now = func() time.Time { return now }
+
+	require.Equal(t, "2025-10", ring.Active().ID)
+	require.Equal(t, AlgEdDSA, ring.Active().Algorithm)
+	require.Len(t, ring.JWKS(), 2)
+
+	// rotate: a newer key is added and the old file is removed
+	writeKeyFile(t, dir, "2025-11", second)
+	require.NoError(t, os.Remove(filepath.Join(dir, "2025-10.pem")))
+	require.NoError(t, ring.Reload(cfg))
+
+	require.Equal(t, "2025-11", ring.Active().ID)
+	require.Equal(t, AlgES256, ring.Active().Algorithm)
+	_, ok := ring.Lookup("2025-10")
+	require.True(t, ok, "old key must verify during the overlap window")
+
+	now = now.Add(5 * time.Minute)
+	_, ok = ring.Lookup("2025-10")
+	require.False(t, ok)
+	_, ok = ring.Lookup("2025-09")
+	require.True(t, ok, "keys still present in the directory keep verifying")
+
+	// reloading again must not revive a retired key
+	require.NoError(t, ring.Reload(cfg))
+	_, ok = ring.Lookup("2025-10")
+	require.False(t, ok)
+
+	cfg.JWTActiveKeyID = "missing"
+	require.ErrorIs(t, ring.Reload(cfg), ErrActiveKeyNotFound)
+	require.Equal(t, "2025-11", ring.Active().ID)
+}
+
+func TestKeyRing_ReloadActiveFile(t *testing.T) {
+	dir := t.TempDir()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	writeKeyFile(t, dir, "a", key)
+	writeKeyFile(t, dir, "b", key)
+	writeKeyFile(t, dir, "c", key)
+
+	cfg := &config.Config{JWTConfig: config.JWTConfig{
+		JWTKeysDir:     dir,
+		JWTActiveKeyID: "a",
+	}}
+
+	ring, err := LoadKeyRing(cfg)
+	require.NoError(t, err)
+	require.Equal(t, "a", ring.Active().ID)
+
+	// the pinned key is switched by the active file without a restart
+	require.NoError(t, os.WriteFile(filepath.Join(dir, activeKeyFile), []byte("b\n"), 0o600))
+	require.NoError(t, ring.Reload(cfg))
+	require.Equal(t, "b", ring.Active().ID)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, activeKeyFile), []byte("missing"), 0o600))
+	require.ErrorIs(t, ring.Reload(cfg), ErrActiveKeyNotFound)
+	require.Equal(t, "b", ring.Active().ID)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, activeKeyFile)))
+	require.NoError(t, ring.Reload(cfg))
+	require.Equal(t, "a", ring.Active().ID)
+}
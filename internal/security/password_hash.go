@@ -1,42 +1,233 @@
 package security
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"time"
 )
 
-// Hasher provides utilities for hashing and verifying passwords using bcrypt.
-type Hasher struct{}
+const argon2idPrefix = "$argon2id$"
 
-// NewHasher creates a new Hasher instance.
-func NewHasher() Hasher {
-	return Hasher{}
+// Argon2Params holds the cost parameters of the Argon2id key derivation.
+//
+// Memory is expressed in KiB. The parameters are stored next to every hash in
+// PHC string format, so they can be raised later without breaking existing hashes.
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
 }
 
-// HashPassword returns the bcrypt hash of the given plain-text password.
+// DefaultArgon2Params follows the second recommended option of RFC 9106
+// (64 MiB of memory, 3 passes) with 4 lanes.
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Hasher provides utilities for hashing and verifying passwords.
+//
+// New hashes are produced with Argon2id. Legacy bcrypt hashes are still
+// accepted by CheckPasswordHash and reported by NeedsRehash so they can be
+// upgraded on the next successful login.
 //
-// It uses bcrypt.DefaultCost as the work factor. The returned string
-// can be safely stored in the database and later verified using
-// CheckPasswordHash.
+// A Hasher made by NewHasherWithLimit computes at most a fixed number of
+// hashes at once, so that a burst of logins cannot exhaust memory or CPU.
+// Copies of a Hasher share the limit.
+type Hasher struct {
+	params Argon2Params
+	slots  chan struct{}
+	wait   time.Duration
+}
+
+// NewHasher creates a new Hasher instance using DefaultArgon2Params.
+func NewHasher() Hasher {
+	return NewHasherWithParams(DefaultArgon2Params)
+}
+
+// NewHasherWithParams creates a new Hasher instance with custom Argon2id parameters.
+func NewHasherWithParams(params Argon2Params) Hasher {
+	return Hasher{
+		params: params,
+	}
+}
+
+// NewHasherWithLimit creates a new Hasher with custom Argon2id parameters that
+// computes at most limit hashes at once. A call finding every slot taken
+// waits up to wait for one and then fails with ErrHasherBusy. A limit of zero
+// or less disables the limit.
+func NewHasherWithLimit(params Argon2Params, limit int, wait time.Duration) Hasher {
+	h := NewHasherWithParams(params)
+	if limit > 0 {
+		h.slots = make(chan struct{}, limit)
+		h.wait = wait
+	}
+	return h
+}
+
+// acquire takes a hashing slot and returns the function releasing it, or
+// ErrHasherBusy if none frees up in time.
+func (h *Hasher) acquire() (func(), error) {
+	if h.slots == nil {
+		return func() {}, nil
+	}
+	release := func() { <-h.slots }
+
+	select {
+	case h.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+	if h.wait <= 0 {
+		return nil, ErrHasherBusy
+	}
+
+	timer := time.NewTimer(h.wait)
+	defer timer.Stop()
+
+	select {
+	case h.slots <- struct{}{}:
+		return release, nil
+	case <-timer.C:
+		return nil, ErrHasherBusy
+	}
+}
+
+// HashPassword returns the Argon2id hash of the given plain-text password
+// encoded in PHC string format.
 //
 // Example:
 //
 //	hash, _ := HashPassword("secret")
-//	fmt.Println(hash) // $2a$10$...
+//	fmt.Println(hash) // $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>
 func (h *Hasher) HashPassword(password string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	return string(hash), err
+	release, err := h.acquire()
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		h.params.Memory,
+		h.params.Iterations,
+		h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
 }
 
-// CheckPasswordHash compares a plain-text password with its bcrypt hash.
+// CheckPasswordHash compares a plain-text password with its hash.
 //
-// It returns true if the password matches the hash, and false otherwise.
-// This should be used during authentication to validate user credentials.
+// The algorithm is selected by the hash prefix: Argon2id hashes are verified
+// with the parameters encoded in the hash, bcrypt hashes with bcrypt.
+// It returns true if the password matches the hash, and false otherwise,
+// including for malformed or unknown hashes. The error is ErrHasherBusy when
+// the hash could not be checked because the limit is reached.
 //
 // Example:
 //
-//	match := CheckPasswordHash("secret", hash)
+//	match, _ := CheckPasswordHash("secret", hash)
 //	fmt.Println(match) // true
-func (h *Hasher) CheckPasswordHash(password string, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+func (h *Hasher) CheckPasswordHash(password string, hash string) (bool, error) {
+	release, err := h.acquire()
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	switch {
+	case strings.HasPrefix(hash, argon2idPrefix):
+		params, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, nil
+		}
+		other := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+		return subtle.ConstantTimeCompare(key, other) == 1, nil
+
+	case isBcryptHash(hash):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		return err == nil, nil
+
+	default:
+		return false, nil
+	}
+}
+
+// NeedsRehash reports whether hash was produced by a legacy algorithm or with
+// parameters different from the current ones.
+func (h *Hasher) NeedsRehash(hash string) bool {
+	if !strings.HasPrefix(hash, argon2idPrefix) {
+		return true
+	}
+
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return true
+	}
+
+	return params.Memory != h.params.Memory ||
+		params.Iterations != h.params.Iterations ||
+		params.Parallelism != h.params.Parallelism ||
+		uint32(len(salt)) != h.params.SaltLength ||
+		uint32(len(key)) != h.params.KeyLength
+}
+
+// decodeArgon2id parses a PHC-formatted Argon2id hash.
+func decodeArgon2id(hash string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+	if version != argon2.Version {
+		return params, nil, nil, ErrIncompatibleHashVersion
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
+
+// isBcryptHash reports whether hash looks like a bcrypt hash.
+func isBcryptHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
 }
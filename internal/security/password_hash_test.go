@@ -2,9 +2,20 @@ package security
 
 import (
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
 	"testing"
+	"time"
 )
 
+// mustCheck returns the result of h.CheckPasswordHash, which must not fail.
+func mustCheck(t *testing.T, h *Hasher, password string, hash string) bool {
+	t.Helper()
+	match, err := h.CheckPasswordHash(password, hash)
+	require.NoError(t, err)
+	return match
+}
+
 func TestHasher_HashAndCheckPassword(t *testing.T) {
 	h := NewHasher()
 
@@ -16,11 +27,11 @@ func TestHasher_HashAndCheckPassword(t *testing.T) {
 	require.NotEmpty(t, hash)
 
 	// Проверка правильного пароля
-	match := h.CheckPasswordHash(password, hash)
+	match := mustCheck(t, &h, password, hash)
 	require.True(t, match, "expected password to match hash")
 
 	// Проверка неправильного пароля
-	wrongMatch := h.CheckPasswordHash("wrongPassword", hash)
+	wrongMatch := mustCheck(t, &h, "wrongPassword", hash)
 	require.False(t, wrongMatch, "expected wrong password not to match hash")
 }
 
@@ -42,6 +53,89 @@ func TestHasher_CheckPasswordHash_InvalidHash(t *testing.T) {
 	h := NewHasher()
 
 	// Некорректный хэш должен вернуть false
-	match := h.CheckPasswordHash("password", "invalidHash")
+	match := mustCheck(t, &h, "password", "invalidHash")
 	require.False(t, match)
 }
+
+// fastParams keeps Argon2id cheap in tests.
+var fastParams = Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestHasher_HashPassword_Argon2idPHCFormat(t *testing.T) {
+	h := NewHasherWithParams(fastParams)
+
+	hash, err := h.HashPassword("secret")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"), hash)
+	require.False(t, h.NeedsRehash(hash))
+}
+
+func TestHasher_CheckPasswordHash_Bcrypt(t *testing.T) {
+	h := NewHasherWithParams(fastParams)
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	require.True(t, mustCheck(t, &h, "secret", string(legacy)))
+	require.False(t, mustCheck(t, &h, "other", string(legacy)))
+	require.True(t, h.NeedsRehash(string(legacy)))
+}
+
+func TestHasher_NeedsRehash_OutdatedParams(t *testing.T) {
+	old := NewHasherWithParams(fastParams)
+	hash, err := old.HashPassword("secret")
+	require.NoError(t, err)
+
+	stronger := fastParams
+	stronger.Iterations = 2
+	h := NewHasherWithParams(stronger)
+
+	// hashes made with older parameters still verify, but must be upgraded
+	require.True(t, mustCheck(t, &h, "secret", hash))
+	require.True(t, h.NeedsRehash(hash))
+}
+
+func TestHasher_Argon2id_NoTruncation(t *testing.T) {
+	h := NewHasherWithParams(fastParams)
+
+	// bcrypt ignores everything after 72 bytes, Argon2id must not
+	prefix := strings.Repeat("a", 72)
+	hash, err := h.HashPassword(prefix + "1")
+	require.NoError(t, err)
+
+	require.False(t, mustCheck(t, &h, prefix+"2", hash))
+}
+
+func TestHasher_CheckPasswordHash_MalformedArgon2id(t *testing.T) {
+	h := NewHasherWithParams(fastParams)
+
+	for _, hash := range []string{
+		"$argon2id$",
+		"$argon2id$v=19$m=1024,t=1,p=1$salt",
+		"$argon2id$v=18$m=1024,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5",
+		"$argon2id$v=19$m=x,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$!!!$a2V5",
+	} {
+		require.False(t, mustCheck(t, &h, "password", hash), hash)
+		require.True(t, h.NeedsRehash(hash), hash)
+	}
+}
+
+func TestHasher_Limit(t *testing.T) {
+	h := NewHasherWithLimit(fastParams, 1, 20*time.Millisecond)
+	hash, err := h.HashPassword("secret")
+	require.NoError(t, err)
+
+	// a copy shares the slots of the original
+	held := h
+	release, err := held.acquire()
+	require.NoError(t, err)
+
+	_, err = h.HashPassword("secret")
+	require.ErrorIs(t, err, ErrHasherBusy)
+	_, err = h.CheckPasswordHash("secret", hash)
+	require.ErrorIs(t, err, ErrHasherBusy)
+
+	// a slot freed while waiting is taken
+	time.AfterFunc(5*time.Millisecond, release)
+	require.True(t, mustCheck(t, &h, "secret", hash))
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"github.com/thxhix/passKeeper/internal/config"
+	"github.com/thxhix/passKeeper/internal/domain/token"
+	"sync"
+	"time"
+)
+
+// accessTokenLeeway covers the clock skew tolerated when access tokens are parsed.
+const accessTokenLeeway = time.Minute
+
+// revocationSweepEvery defines how many cache fills trigger a cleanup of stale entries.
+const revocationSweepEvery = 1024
+
+// AccessTokenRevoker revokes every access token issued to a user so far.
+type AccessTokenRevoker interface {
+	RevokeUser(ctx context.Context, userID int64) error
+}
+
+// revokeSessions revokes all refresh tokens of the user in tokenRepo and all
+// access tokens through revoker.
+func revokeSessions(ctx context.Context, tokenRepo token.TokenRepository, revoker AccessTokenRevoker, userID int64) error {
+	if err := tokenRepo.RevokeAllByUser(ctx, userID); err != nil {
+		return err
+	}
+
+	return revoker.RevokeUser(ctx, userID)
+}
+
+type revocationCacheEntry struct {
+	revokedBefore *time.Time
+	fetchedAt     time.Time
+}
+
+// AccessRevocationService implements immediate access token revocation.
+//
+// Revoking a user records the current moment; every access token of the user
+// issued before it is rejected afterwards. The moment is truncated to whole
+// seconds, the precision of the `iat` claim, so tokens issued right after the
+// revocation stay valid. Entries are persisted with an expiry equal to the
+// access token lifetime and cached in memory for cacheTTL, which bounds how
+// long a revocation made by another server instance can go unnoticed.
+// Revocations made by this instance take effect immediately.
+type AccessRevocationService struct {
+	repo token.AccessRevocationRepository
+
+	tokenTTL time.Duration
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	mu     sync.Mutex
+	cache  map[int64]revocationCacheEntry
+	sweeps int
+}
+
+// NewAccessRevocationService constructs a new AccessRevocationService from the JWT configuration.
+func NewAccessRevocationService(repo token.AccessRevocationRepository, cfg *config.Config) *AccessRevocationService {
+	return &AccessRevocationService{
+		repo: repo,
+
+		tokenTTL: time.Duration(cfg.JWTAccessExpTimeMinute)*time.Minute + accessTokenLeeway,
+		cacheTTL: time.Duration(cfg.JWTRevocationCacheSeconds) * time.Second,
+		now:      time.Now,
+
+		cache: make(map[int64]revocationCacheEntry),
+	}
+}
+
+// RevokeUser rejects all access tokens issued to userID up to now.
+//
+// Expired revocations are cleaned up along the way.
+func (s *AccessRevocationService) RevokeUser(ctx context.Context, userID int64) error {
+	now := s.now().UTC()
+	before := now.Truncate(time.Second)
+
+	if err := s.repo.RevokeBefore(ctx, userID, before, before.Add(s.tokenTTL)); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.cache[userID] = revocationCacheEntry{revokedBefore: &before, fetchedAt: now}
+	s.mu.Unlock()
+
+	_, _ = s.repo.DeleteExpired(ctx, now)
+
+	return nil
+}
+
+// IsRevoked reports whether an access token of userID issued at issuedAt has
+// been revoked.
+func (s *AccessRevocationService) IsRevoked(ctx context.Context, userID int64, issuedAt time.Time) (bool, error) {
+	before, err := s.revokedBefore(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return before != nil && issuedAt.Before(*before), nil
+}
+
+// revokedBefore returns the revocation moment of the user from the cache,
+// falling back to the repository once the cached value is stale.
+func (s *AccessRevocationService) revokedBefore(ctx context.Context, userID int64) (*time.Time, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	entry, ok := s.cache[userID]
+	s.mu.Unlock()
+
+	if ok && now.Sub(entry.fetchedAt) < s.cacheTTL {
+		return entry.revokedBefore, nil
+	}
+
+	before, err := s.repo.GetRevokedBefore(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cache[userID] = revocationCacheEntry{revokedBefore: before, fetchedAt: now}
+
+	s.sweeps++
+	if s.sweeps >= revocationSweepEvery {
+		s.sweeps = 0
+		for id, e := range s.cache {
+			if now.Sub(e.fetchedAt) >= s.cacheTTL {
+				delete(s.cache, id)
+			}
+		}
+	}
+
+	return before, nil
+}
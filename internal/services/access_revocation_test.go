@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/config"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"testing"
+	"time"
+)
+
+func newTestRevocationService(repo *mocks.AccessRevocationRepositoryMock, now *time.Time) *AccessRevocationService {
+	s := NewAccessRevocationService(repo, &config.Config{
+		JWTConfig: config.JWTConfig{
+			JWTAccessExpTimeMinute:    15,
+			JWTRevocationCacheSeconds: 5,
+		},
+	})
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func TestAccessRevocationService_RevokeUser(t *testing.T) {
+	repo := new(mocks.AccessRevocationRepositoryMock)
+	now := time.Date(2025, 10, 22, 10, 0, 0, 500, time.UTC)
+	s := newTestRevocationService(repo, &now)
+
+	before := time.Date(2025, 10, 22, 10, 0, 0, 0, time.UTC)
+	repo.On("RevokeBefore", mock.Anything, int64(1), before, before.Add(16*time.Minute)).Return(nil)
+	repo.On("DeleteExpired", mock.Anything, now).Return(int64(0), nil)
+
+	assert.NoError(t, s.RevokeUser(context.Background(), 1))
+
+	// tokens issued before the revocation are rejected without hitting the repository
+	revoked, err := s.IsRevoked(context.Background(), 1, before.Add(-time.Second))
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	// a token issued in the same second, e.g. by the password change itself, stays valid
+	revoked, err = s.IsRevoked(context.Background(), 1, before)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "GetRevokedBefore", mock.Anything, mock.Anything)
+}
+
+func TestAccessRevocationService_IsRevoked_Cache(t *testing.T) {
+	repo := new(mocks.AccessRevocationRepositoryMock)
+	now := time.Date(2025, 10, 22, 10, 0, 0, 0, time.UTC)
+	s := newTestRevocationService(repo, &now)
+
+	issuedAt := now.Add(-time.Minute)
+
+	repo.On("GetRevokedBefore", mock.Anything, int64(2)).Return(nil, nil).Once()
+
+	revoked, err := s.IsRevoked(context.Background(), 2, issuedAt)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	// cached negative answer
+	revoked, err = s.IsRevoked(context.Background(), 2, issuedAt)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	// another instance revoked the user; visible once the cache entry is stale
+	revokedBefore := now
+	repo.On("GetRevokedBefore", mock.Anything, int64(2)).Return(&revokedBefore, nil).Once()
+	now = now.Add(5 * time.Second)
+
+	revoked, err = s.IsRevoked(context.Background(), 2, issuedAt)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	repo.AssertExpectations(t)
+}
+
+func TestAccessRevocationService_IsRevoked_Error(t *testing.T) {
+	repo := new(mocks.AccessRevocationRepositoryMock)
+	now := time.Date(2025, 10, 22, 10, 0, 0, 0, time.UTC)
+	s := newTestRevocationService(repo, &now)
+
+	repo.On("GetRevokedBefore", mock.Anything, int64(3)).Return(nil, errors.New("db down"))
+
+	_, err := s.IsRevoked(context.Background(), 3, now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/thxhix/passKeeper/internal/domain/account"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/send"
+	"github.com/thxhix/passKeeper/internal/domain/share"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+	"time"
+)
+
+type IAccountService interface {
+	Export(ctx context.Context, userID int64, password string) (*account.Export, error)
+	Delete(ctx context.Context, userID int64, password string) error
+}
+
+// AccountService implements personal data workflows: full export of a user's
+// vault and account deletion.
+//
+// Both operations are destructive or disclose every secret of the user, so
+// each of them re-checks the account password before doing anything.
+type AccountService struct {
+	userRepo     user.UserRepository
+	keychainRepo keychain.KeychainRepository
+	vaultRepo    vault.VaultRepository
+	shareRepo    share.ShareRepository
+	sendRepo     send.SendRepository
+
+	hasher       PasswordHasher
+	cryptManager CryptManager
+	revoker      AccessTokenRevoker
+	auditor      AuditRecorder
+	webhooks     WebhookPublisher
+	lockout      LockoutPolicy
+	now          func() time.Time
+}
+
+// NewAccountService constructs a new AccountService with given dependencies.
+func NewAccountService(userRepo user.UserRepository, keychainRepo keychain.KeychainRepository, vaultRepo vault.VaultRepository, shareRepo share.ShareRepository, sendRepo send.SendRepository, hasher PasswordHasher, cManager CryptManager, revoker AccessTokenRevoker, auditor AuditRecorder, webhooks WebhookPublisher, lockout LockoutPolicy) AccountService {
+	return AccountService{
+		userRepo:     userRepo,
+		keychainRepo: keychainRepo,
+		vaultRepo:    vaultRepo,
+		shareRepo:    shareRepo,
+		sendRepo:     sendRepo,
+
+		hasher:       hasher,
+		cryptManager: cManager,
+		revoker:      revoker,
+		auditor:      auditor,
+		webhooks:     webhooks,
+		lockout:      lockout,
+		now:          time.Now,
+	}
+}
+
+// Export returns all personal data of the user: the personal entries and
+// the entries of the user's vaults with decrypted payloads, the user's sends
+// and the shares made by and with the user.
+//
+// Every export is recorded in the audit log, including the ones refused for a
+// wrong password.
+//
+// Returns user.ErrInvalidCredentials if the password does not match.
+func (s *AccountService) Export(ctx context.Context, userID int64, password string) (export *account.Export, err error) {
+	defer func() {
+		err = recordAudit(ctx, s.auditor, audit.Event{UserID: userID, Action: audit.ActionAccountExport}, err)
+		if err != nil {
+			export = nil
+		}
+	}()
+
+	au, err := s.verifyPassword(ctx, userID, password)
+	if err != nil {
+		return nil, err
+	}
+
+	export = &account.Export{
+		Login:      au.Login,
+		ExportedAt: s.now().UTC(),
+	}
+
+	if err := s.exportPersonal(ctx, userID, export); err != nil {
+		return nil, err
+	}
+
+	if err := s.exportVaults(ctx, userID, export); err != nil {
+		return nil, err
+	}
+
+	export.Sends, err = s.sendRepo.ListByOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	received, err := s.shareRepo.ListForRecipient(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export.SharesReceived = make([]*share.Share, 0, len(received))
+	for _, item := range received {
+		sh, err := s.shareRepo.GetForRecipient(ctx, userID, item.ShareUUID)
+		if err != nil {
+			if errors.Is(err, share.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		export.SharesReceived = append(export.SharesReceived, sh)
+	}
+
+	return export, nil
+}
+
+// exportPersonal adds the personal entries of the user to export together
+// with the shares made of them.
+func (s *AccountService) exportPersonal(ctx context.Context, userID int64, export *account.Export) error {
+	list, err := s.keychainRepo.GetUserKeys(ctx, userID, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list {
+		record, err := s.keychainRepo.GetUserKey(ctx, userID, item.KeyUUID.String())
+		if err != nil {
+			return err
+		}
+
+		if err := s.addEntry(export, record, nil); err != nil {
+			return err
+		}
+
+		given, err := s.shareRepo.ListByKey(ctx, record.ID)
+		if err != nil {
+			return err
+		}
+		for _, sh := range given {
+			sh.WrappedKey, sh.Nonce, sh.Data = nil, nil, nil
+		}
+		export.SharesGiven = append(export.SharesGiven, given...)
+	}
+
+	return nil
+}
+
+// exportVaults adds the entries of every vault the user has a role in to
+// export.
+func (s *AccountService) exportVaults(ctx context.Context, userID int64, export *account.Export) error {
+	vaults, err := s.vaultRepo.ListVaults(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range vaults {
+		list, err := s.keychainRepo.GetVaultKeys(ctx, v.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range list {
+			record, err := s.keychainRepo.GetVaultKey(ctx, v.ID, item.KeyUUID.String())
+			if err != nil {
+				return err
+			}
+
+			if err := s.addEntry(export, record, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// addEntry decrypts record and appends it to export.
+func (s *AccountService) addEntry(export *account.Export, record *keychain.KeyRecord, v *vault.Vault) error {
+	plain, err := s.cryptManager.Decrypt(record.Nonce, record.Data)
+	if err != nil {
+		return err
+	}
+
+	export.Entries = append(export.Entries, &account.ExportEntry{
+		Record: record,
+		Data:   plain,
+		Vault:  v,
+	})
+
+	return nil
+}
+
+// Delete permanently removes the user account.
+//
+// The user row holds the wrapped key material, so removing it crypto-shreds
+// the vault; keychain entries and refresh tokens are removed by the cascade.
+// Shared vaults and organizations the user is the only member of are removed
+// with the account. Access tokens still in flight are revoked as well.
+//
+// Returns user.ErrInvalidCredentials if the password does not match and
+// vault.ErrSoleOwner if the user is the only owner of a vault or organization
+// that has other members.
+func (s *AccountService) Delete(ctx context.Context, userID int64, password string) error {
+	if _, err := s.verifyPassword(ctx, userID, password); err != nil {
+		return err
+	}
+
+	if err := s.checkSoleOwnership(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.revoker.RevokeUser(ctx, userID)
+}
+
+// checkSoleOwnership returns vault.ErrSoleOwner if deleting the user would
+// leave an organization, or a vault outside any organization, with members
+// but without an owner. Vaults of an organization are owned through it, so
+// their other members count for the organization.
+func (s *AccountService) checkSoleOwnership(ctx context.Context, userID int64) error {
+	vaults, err := s.vaultRepo.ListVaults(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range vaults {
+		if v.OrgID != nil || v.Role != vault.RoleOwner {
+			continue
+		}
+
+		members, err := s.vaultRepo.ListVaultMembers(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		if leftWithoutOwner(members, userID) {
+			return vault.ErrSoleOwner
+		}
+	}
+
+	orgs, err := s.vaultRepo.ListOrganizations(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, org := range orgs {
+		if org.Role != vault.RoleOwner {
+			continue
+		}
+
+		members, err := s.vaultRepo.ListOrganizationMembers(ctx, org.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range vaults {
+			if v.OrgID == nil || *v.OrgID != org.ID {
+				continue
+			}
+
+			vaultMembers, err := s.vaultRepo.ListVaultMembers(ctx, v.ID)
+			if err != nil {
+				return err
+			}
+			members = append(members, vaultMembers...)
+		}
+
+		if leftWithoutOwner(members, userID) {
+			return vault.ErrSoleOwner
+		}
+	}
+
+	return nil
+}
+
+// leftWithoutOwner reports whether members would have no owner, but someone
+// else, once userID is gone.
+func leftWithoutOwner(members []*vault.Member, userID int64) bool {
+	others := false
+	for _, m := range members {
+		if m.UserID == userID {
+			continue
+		}
+		if m.Role == vault.RoleOwner {
+			return false
+		}
+		others = true
+	}
+
+	return others
+}
+
+// verifyPassword loads the user and checks the provided password against the
+// stored hash.
+//
+// A wrong password counts toward the account lockout like a failed login, and
+// a locked account is refused like a wrong password, so the check cannot be
+// used to guess the password with a stolen access token.
+func (s *AccountService) verifyPassword(ctx context.Context, userID int64, password string) (*user.UserRecord, error) {
+	au, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, user.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	valid, err := s.hasher.CheckPasswordHash(password, au.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	locked := au.LockedUntil != nil && now.Before(*au.LockedUntil)
+
+	if !valid || locked {
+		if err := registerLoginFailure(ctx, s.userRepo, s.webhooks, s.lockout, au.Login, now); err != nil {
+			return nil, err
+		}
+		return nil, user.ErrInvalidCredentials
+	}
+
+	if au.FailedLoginAttempts > 0 || au.LockoutCount > 0 || au.LockedUntil != nil {
+		if err := s.userRepo.ResetLoginFailures(ctx, au.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return au, nil
+}
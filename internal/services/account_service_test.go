@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/send"
+	"github.com/thxhix/passKeeper/internal/domain/share"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"testing"
+	"time"
+)
+
+func TestAccountService_Export_Success(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	keychainRepo := new(mocks.KeychainRepositoryMock)
+	vaultRepo := new(mocks.VaultRepositoryMock)
+	shareRepo := new(mocks.ShareRepositoryMock)
+	sendRepo := new(mocks.SendRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	cryptManager := new(mocks.CryptManager)
+	auditor := new(mocks.AuditServiceMock)
+
+	s := NewAccountService(userRepo, keychainRepo, vaultRepo, shareRepo, sendRepo, passHasher, cryptManager, nil, auditor, nopPublisher(), LockoutPolicy{})
+
+	ctx := context.Background()
+	keyUUID := uuid.New()
+	vaultKeyUUID := uuid.New()
+	givenUUID := uuid.New()
+	receivedUUID := uuid.New()
+	sendUUID := uuid.New()
+	v := &vault.Vault{ID: 7, VaultUUID: uuid.New(), Name: "team"}
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "hash"}, nil)
+	passHasher.On("CheckPasswordHash", "password", "hash").Return(true, nil)
+	keychainRepo.On("GetUserKeys", mock.Anything, int64(1), (*string)(nil)).Return([]*keychain.KeyRecord{
+		{KeyUUID: keyUUID, KeyType: keychain.KeyText, Title: "title"},
+	}, nil)
+	keychainRepo.On("GetUserKey", mock.Anything, int64(1), keyUUID.String()).Return(&keychain.KeyRecord{
+		ID:      10,
+		KeyUUID: keyUUID,
+		KeyType: keychain.KeyText,
+		Title:   "title",
+		Data:    []byte{4, 5, 6},
+		Nonce:   []byte{1, 2, 3},
+	}, nil)
+	cryptManager.On("Decrypt", []byte{1, 2, 3}, []byte{4, 5, 6}).Return([]byte(`{"text":"secret"}`), nil)
+	shareRepo.On("ListByKey", mock.Anything, int64(10)).Return([]*share.Share{
+		{ShareUUID: givenUUID, KeyUUID: keyUUID, RecipientLogin: "bob", WrappedKey: []byte{1}, Nonce: []byte{2}, Data: []byte{3}},
+	}, nil)
+
+	vaultRepo.On("ListVaults", mock.Anything, int64(1)).Return([]*vault.Vault{v}, nil)
+	keychainRepo.On("GetVaultKeys", mock.Anything, int64(7), (*string)(nil)).Return([]*keychain.KeyRecord{
+		{KeyUUID: vaultKeyUUID, KeyType: keychain.KeyText, Title: "shared"},
+	}, nil)
+	keychainRepo.On("GetVaultKey", mock.Anything, int64(7), vaultKeyUUID.String()).Return(&keychain.KeyRecord{
+		ID:      11,
+		KeyUUID: vaultKeyUUID,
+		KeyType: keychain.KeyText,
+		Title:   "shared",
+		Data:    []byte{7, 8},
+		Nonce:   []byte{9},
+	}, nil)
+	cryptManager.On("Decrypt", []byte{9}, []byte{7, 8}).Return([]byte(`{"text":"team secret"}`), nil)
+
+	sendRepo.On("ListByOwner", mock.Anything, int64(1)).Return([]*send.Send{{SendUUID: sendUUID, MaxViews: 1}}, nil)
+	shareRepo.On("ListForRecipient", mock.Anything, int64(1)).Return([]*share.Share{{ShareUUID: receivedUUID}}, nil)
+	shareRepo.On("GetForRecipient", mock.Anything, int64(1), receivedUUID).Return(&share.Share{
+		ShareUUID: receivedUUID, OwnerLogin: "alice", WrappedKey: []byte{1}, Nonce: []byte{2}, Data: []byte{3},
+	}, nil)
+	auditor.On("Record", mock.Anything, audit.Event{UserID: 1, Action: audit.ActionAccountExport, Result: audit.ResultSuccess}).Return(nil)
+
+	export, err := s.Export(ctx, 1, "password")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "test", export.Login)
+	assert.Len(t, export.Entries, 2)
+	assert.Equal(t, keyUUID, export.Entries[0].Record.KeyUUID)
+	assert.Nil(t, export.Entries[0].Vault)
+	assert.JSONEq(t, `{"text":"secret"}`, string(export.Entries[0].Data))
+	assert.Equal(t, vaultKeyUUID, export.Entries[1].Record.KeyUUID)
+	assert.Equal(t, v, export.Entries[1].Vault)
+	assert.JSONEq(t, `{"text":"team secret"}`, string(export.Entries[1].Data))
+
+	assert.Len(t, export.Sends, 1)
+	assert.Equal(t, sendUUID, export.Sends[0].SendUUID)
+
+	assert.Len(t, export.SharesGiven, 1)
+	assert.Equal(t, givenUUID, export.SharesGiven[0].ShareUUID)
+	assert.Nil(t, export.SharesGiven[0].Data)
+
+	assert.Len(t, export.SharesReceived, 1)
+	assert.Equal(t, []byte{3}, export.SharesReceived[0].Data)
+
+	userRepo.AssertExpectations(t)
+	keychainRepo.AssertExpectations(t)
+	vaultRepo.AssertExpectations(t)
+	shareRepo.AssertExpectations(t)
+	sendRepo.AssertExpectations(t)
+	passHasher.AssertExpectations(t)
+	cryptManager.AssertExpectations(t)
+	auditor.AssertExpectations(t)
+}
+
+func TestAccountService_Export_Wrong_Password(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	keychainRepo := new(mocks.KeychainRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	cryptManager := new(mocks.CryptManager)
+	auditor := new(mocks.AuditServiceMock)
+
+	s := NewAccountService(userRepo, keychainRepo, nil, nil, nil, passHasher, cryptManager, nil, auditor, nopPublisher(), LockoutPolicy{})
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "hash"}, nil)
+	passHasher.On("CheckPasswordHash", "wrong", "hash").Return(false, nil)
+	auditor.On("Record", mock.Anything, audit.Event{UserID: 1, Action: audit.ActionAccountExport, Result: audit.ResultFailure}).Return(nil)
+
+	_, err := s.Export(context.Background(), 1, "wrong")
+
+	assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	keychainRepo.AssertNotCalled(t, "GetUserKeys", mock.Anything, mock.Anything, mock.Anything)
+	auditor.AssertExpectations(t)
+}
+
+func TestAccountService_Export_Wrong_Password_Locks_Account(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	keychainRepo := new(mocks.KeychainRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+
+	s := NewAccountService(userRepo, keychainRepo, nil, nil, nil, passHasher, nil, nil, nopAuditor(), nopPublisher(), LockoutPolicy{
+		Threshold:    3,
+		BaseDuration: 15 * time.Minute,
+		MaxDuration:  time.Hour,
+	})
+	s.now = func() time.Time { return now }
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "hash"}, nil)
+	passHasher.On("CheckPasswordHash", "wrong", "hash").Return(false, nil)
+	userRepo.On("IncrementLoginFailures", mock.Anything, "test", now).Return(int64(1), 3, 0, nil)
+	userRepo.On("LockAccount", mock.Anything, int64(1), now.Add(15*time.Minute)).Return(nil)
+
+	_, err := s.Export(context.Background(), 1, "wrong")
+
+	assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	userRepo.AssertExpectations(t)
+	keychainRepo.AssertNotCalled(t, "GetUserKeys", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAccountService_Delete_Locked_Account(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	lockedUntil := now.Add(10 * time.Minute)
+
+	s := NewAccountService(userRepo, nil, nil, nil, nil, passHasher, nil, nil, nil, nopPublisher(), LockoutPolicy{Threshold: 3, BaseDuration: time.Minute, MaxDuration: time.Hour})
+	s.now = func() time.Time { return now }
+
+	// the right password does not get through while the account is locked
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "hash", LockedUntil: &lockedUntil}, nil)
+	passHasher.On("CheckPasswordHash", "password", "hash").Return(true, nil)
+	userRepo.On("IncrementLoginFailures", mock.Anything, "test", now).Return(int64(1), 1, 1, nil)
+
+	err := s.Delete(context.Background(), 1, "password")
+
+	assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	userRepo.AssertExpectations(t)
+	userRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestAccountService_Delete_Success(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	keychainRepo := new(mocks.KeychainRepositoryMock)
+	vaultRepo := new(mocks.VaultRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	cryptManager := new(mocks.CryptManager)
+
+	revoker := new(mocks.AccessTokenRevokerMock)
+
+	s := NewAccountService(userRepo, keychainRepo, vaultRepo, nil, nil, passHasher, cryptManager, revoker, nil, nopPublisher(), LockoutPolicy{})
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "hash"}, nil)
+	passHasher.On("CheckPasswordHash", "password", "hash").Return(true, nil)
+	vaultRepo.On("ListVaults", mock.Anything, int64(1)).Return([]*vault.Vault{}, nil)
+	vaultRepo.On("ListOrganizations", mock.Anything, int64(1)).Return([]*vault.Organization{}, nil)
+	userRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+	revoker.On("RevokeUser", mock.Anything, int64(1)).Return(nil)
+
+	err := s.Delete(context.Background(), 1, "password")
+
+	assert.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	revoker.AssertExpectations(t)
+}
+
+func TestAccountService_Delete_Sole_Owner_Of_Shared_Vault(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	vaultRepo := new(mocks.VaultRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+
+	s := NewAccountService(userRepo, nil, vaultRepo, nil, nil, passHasher, nil, nil, nil, nopPublisher(), LockoutPolicy{})
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "hash"}, nil)
+	passHasher.On("CheckPasswordHash", "password", "hash").Return(true, nil)
+	vaultRepo.On("ListVaults", mock.Anything, int64(1)).Return([]*vault.Vault{{ID: 7, Role: vault.RoleOwner}}, nil)
+	vaultRepo.On("ListVaultMembers", mock.Anything, int64(7)).Return([]*vault.Member{
+		{UserID: 1, Role: vault.RoleOwner},
+		{UserID: 2, Role: vault.RoleAdmin},
+	}, nil)
+
+	err := s.Delete(context.Background(), 1, "password")
+
+	assert.ErrorIs(t, err, vault.ErrSoleOwner)
+	userRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestAccountService_Delete_Sole_Owner_Of_Shared_Organization(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	vaultRepo := new(mocks.VaultRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+
+	s := NewAccountService(userRepo, nil, vaultRepo, nil, nil, passHasher, nil, nil, nil, nopPublisher(), LockoutPolicy{})
+
+	orgID := int64(3)
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "hash"}, nil)
+	passHasher.On("CheckPasswordHash", "password", "hash").Return(true, nil)
+	vaultRepo.On("ListVaults", mock.Anything, int64(1)).Return([]*vault.Vault{{ID: 7, OrgID: &orgID, Role: vault.RoleOwner}}, nil)
+	vaultRepo.On("ListOrganizations", mock.Anything, int64(1)).Return([]*vault.Organization{{ID: orgID, Role: vault.RoleOwner}}, nil)
+	vaultRepo.On("ListOrganizationMembers", mock.Anything, orgID).Return([]*vault.Member{{UserID: 1, Role: vault.RoleOwner}}, nil)
+	// nobody else is in the organization, but a vault of it is shared directly
+	vaultRepo.On("ListVaultMembers", mock.Anything, int64(7)).Return([]*vault.Member{{UserID: 2, Role: vault.RoleViewer}}, nil)
+
+	err := s.Delete(context.Background(), 1, "password")
+
+	assert.ErrorIs(t, err, vault.ErrSoleOwner)
+	userRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestAccountService_Delete_Unshared_Or_Co_Owned(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	vaultRepo := new(mocks.VaultRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	revoker := new(mocks.AccessTokenRevokerMock)
+
+	s := NewAccountService(userRepo, nil, vaultRepo, nil, nil, passHasher, nil, revoker, nil, nopPublisher(), LockoutPolicy{})
+
+	orgID := int64(3)
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "hash"}, nil)
+	passHasher.On("CheckPasswordHash", "password", "hash").Return(true, nil)
+	vaultRepo.On("ListVaults", mock.Anything, int64(1)).Return([]*vault.Vault{
+		{ID: 7, Role: vault.RoleOwner},
+		{ID: 8, Role: vault.RoleOwner},
+		{ID: 9, OrgID: &orgID, Role: vault.RoleOwner},
+	}, nil)
+	// vault 7 is only used by the user, vault 8 has another owner
+	vaultRepo.On("ListVaultMembers", mock.Anything, int64(7)).Return([]*vault.Member{{UserID: 1, Role: vault.RoleOwner}}, nil)
+	vaultRepo.On("ListVaultMembers", mock.Anything, int64(8)).Return([]*vault.Member{
+		{UserID: 1, Role: vault.RoleOwner},
+		{UserID: 2, Role: vault.RoleOwner},
+	}, nil)
+	vaultRepo.On("ListVaultMembers", mock.Anything, int64(9)).Return([]*vault.Member{}, nil)
+	vaultRepo.On("ListOrganizations", mock.Anything, int64(1)).Return([]*vault.Organization{{ID: orgID, Role: vault.RoleOwner}}, nil)
+	vaultRepo.On("ListOrganizationMembers", mock.Anything, orgID).Return([]*vault.Member{{UserID: 1, Role: vault.RoleOwner}}, nil)
+	userRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+	revoker.On("RevokeUser", mock.Anything, int64(1)).Return(nil)
+
+	err := s.Delete(context.Background(), 1, "password")
+
+	assert.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	vaultRepo.AssertExpectations(t)
+}
+
+func TestAccountService_Delete_Wrong_Password(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	keychainRepo := new(mocks.KeychainRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	cryptManager := new(mocks.CryptManager)
+
+	s := NewAccountService(userRepo, keychainRepo, nil, nil, nil, passHasher, cryptManager, nil, nil, nopPublisher(), LockoutPolicy{})
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "hash"}, nil)
+	passHasher.On("CheckPasswordHash", "wrong", "hash").Return(false, nil)
+
+	err := s.Delete(context.Background(), 1, "wrong")
+
+	assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	userRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestAccountService_Delete_Repo_Error(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	keychainRepo := new(mocks.KeychainRepositoryMock)
+	vaultRepo := new(mocks.VaultRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	cryptManager := new(mocks.CryptManager)
+
+	s := NewAccountService(userRepo, keychainRepo, vaultRepo, nil, nil, passHasher, cryptManager, nil, nil, nopPublisher(), LockoutPolicy{})
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "hash"}, nil)
+	passHasher.On("CheckPasswordHash", "password", "hash").Return(true, nil)
+	vaultRepo.On("ListVaults", mock.Anything, int64(1)).Return([]*vault.Vault{}, nil)
+	vaultRepo.On("ListOrganizations", mock.Anything, int64(1)).Return([]*vault.Organization{}, nil)
+	userRepo.On("Delete", mock.Anything, int64(1)).Return(errors.New("db down"))
+
+	err := s.Delete(context.Background(), 1, "password")
+
+	assert.Error(t, err)
+}
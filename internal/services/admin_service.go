@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"github.com/thxhix/passKeeper/internal/domain/admin"
+	"github.com/thxhix/passKeeper/internal/domain/device"
+	"github.com/thxhix/passKeeper/internal/domain/token"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"time"
+)
+
+type IAdminService interface {
+	IsAdmin(ctx context.Context, userID int64) (bool, error)
+	ListUsers(ctx context.Context, limit int, offset int) ([]*admin.UserSummary, error)
+	Disable(ctx context.Context, adminID int64, userID int64) error
+	Enable(ctx context.Context, userID int64) error
+	ForceLogout(ctx context.Context, userID int64) error
+	ResetSecondFactor(ctx context.Context, userID int64) (int64, error)
+	StorageStats(ctx context.Context, userID int64) (*admin.StorageStats, error)
+	Bootstrap(ctx context.Context, login string) error
+}
+
+// AdminService implements user management for administrators.
+//
+// It never touches key material: an administrator can lock a user out and end
+// their sessions, but cannot read or recover their vault.
+type AdminService struct {
+	repo      admin.AdminRepository
+	userRepo  user.UserRepository
+	tokenRepo token.TokenRepository
+	devices   device.DeviceRepository
+	revoker   AccessTokenRevoker
+	now       func() time.Time
+}
+
+// NewAdminService constructs a new AdminService with given dependencies.
+func NewAdminService(repo admin.AdminRepository, userRepo user.UserRepository, tokenRepo token.TokenRepository, devices device.DeviceRepository, revoker AccessTokenRevoker) AdminService {
+	return AdminService{
+		repo:      repo,
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		devices:   devices,
+		revoker:   revoker,
+		now:       time.Now,
+	}
+}
+
+// IsAdmin tells whether the user holds the administrator role. Disabled
+// administrators are not considered administrators.
+func (s *AdminService) IsAdmin(ctx context.Context, userID int64) (bool, error) {
+	au, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return au.IsAdmin && au.DisabledAt == nil, nil
+}
+
+// ListUsers returns a page of users ordered by ID. A zero limit selects
+// admin.DefaultListLimit.
+//
+// Returns admin.ErrInvalidLimit or admin.ErrInvalidPage for out of range values.
+func (s *AdminService) ListUsers(ctx context.Context, limit int, offset int) ([]*admin.UserSummary, error) {
+	if limit == 0 {
+		limit = admin.DefaultListLimit
+	}
+	if limit < 0 || limit > admin.MaxListLimit {
+		return nil, admin.ErrInvalidLimit
+	}
+	if offset < 0 {
+		return nil, admin.ErrInvalidPage
+	}
+
+	return s.repo.ListUsers(ctx, limit, offset)
+}
+
+// Disable prevents the user from logging in and ends all their sessions.
+// Personal access tokens of the user are rejected from then on and their
+// service accounts cannot obtain new tokens; service account tokens already
+// issued run out with their short lifetime.
+//
+// Returns admin.ErrSelfDisable if an administrator disables their own account
+// and user.ErrUserNotFound if no user is found.
+func (s *AdminService) Disable(ctx context.Context, adminID int64, userID int64) error {
+	if adminID == userID {
+		return admin.ErrSelfDisable
+	}
+
+	now := s.now().UTC()
+	if err := s.repo.SetDisabled(ctx, userID, &now); err != nil {
+		return err
+	}
+
+	return revokeSessions(ctx, s.tokenRepo, s.revoker, userID)
+}
+
+// Enable allows a disabled user to log in again.
+//
+// Returns user.ErrUserNotFound if no user is found.
+func (s *AdminService) Enable(ctx context.Context, userID int64) error {
+	return s.repo.SetDisabled(ctx, userID, nil)
+}
+
+// ForceLogout revokes every refresh and access token of the user.
+//
+// Returns user.ErrUserNotFound if no user is found.
+func (s *AdminService) ForceLogout(ctx context.Context, userID int64) error {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return err
+	}
+
+	return revokeSessions(ctx, s.tokenRepo, s.revoker, userID)
+}
+
+// ResetSecondFactor removes every device of the user and ends all their
+// sessions, for a user who lost the devices used to approve new logins. The
+// device of the next successful login becomes the first trusted one.
+//
+// Returns the number of removed devices, or user.ErrUserNotFound if no user
+// is found.
+func (s *AdminService) ResetSecondFactor(ctx context.Context, userID int64) (int64, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return 0, err
+	}
+
+	removed, err := s.devices.DeleteAllByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := revokeSessions(ctx, s.tokenRepo, s.revoker, userID); err != nil {
+		return 0, err
+	}
+
+	return removed, nil
+}
+
+// StorageStats reports how many entries, devices and tokens the user has.
+//
+// Returns user.ErrUserNotFound if no user is found.
+func (s *AdminService) StorageStats(ctx context.Context, userID int64) (*admin.StorageStats, error) {
+	return s.repo.StorageStats(ctx, userID)
+}
+
+// Bootstrap grants the administrator role to the user with the given login.
+// It is meant for the first administrator only and runs directly against the
+// database; later administrators are promoted the same way by an operator
+// with database access.
+//
+// The check for an existing administrator and the grant are a single step of
+// the repository, so concurrent bootstraps cannot both create an
+// administrator.
+//
+// Returns admin.ErrAlreadyBootstrapped if an administrator already exists and
+// user.ErrUserNotFound if no user is found.
+func (s *AdminService) Bootstrap(ctx context.Context, login string) error {
+	return s.repo.GrantFirstAdmin(ctx, login)
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/admin"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"testing"
+	"time"
+)
+
+type adminTestDeps struct {
+	repo      *mocks.AdminRepositoryMock
+	userRepo  *mocks.UserRepositoryMock
+	tokenRepo *mocks.TokenRepositoryMock
+	devices   *mocks.DeviceRepositoryMock
+	revoker   *mocks.AccessTokenRevokerMock
+}
+
+func newTestAdminService(now time.Time) (AdminService, adminTestDeps) {
+	d := adminTestDeps{
+		repo:      new(mocks.AdminRepositoryMock),
+		userRepo:  new(mocks.UserRepositoryMock),
+		tokenRepo: new(mocks.TokenRepositoryMock),
+		devices:   new(mocks.DeviceRepositoryMock),
+		revoker:   new(mocks.AccessTokenRevokerMock),
+	}
+
+	s := NewAdminService(d.repo, d.userRepo, d.tokenRepo, d.devices, d.revoker)
+	s.now = func() time.Time { return now }
+	return s, d
+}
+
+func TestAdminService_IsAdmin(t *testing.T) {
+	s, d := newTestAdminService(time.Now())
+	ctx := context.Background()
+	disabledAt := time.Now()
+
+	d.userRepo.On("GetByID", ctx, int64(1)).Return(&user.UserRecord{ID: 1, IsAdmin: true}, nil)
+	d.userRepo.On("GetByID", ctx, int64(2)).Return(&user.UserRecord{ID: 2}, nil)
+	d.userRepo.On("GetByID", ctx, int64(3)).Return(&user.UserRecord{ID: 3, IsAdmin: true, DisabledAt: &disabledAt}, nil)
+
+	ok, err := s.IsAdmin(ctx, 1)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = s.IsAdmin(ctx, 2)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = s.IsAdmin(ctx, 3)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestAdminService_ListUsers_Limits(t *testing.T) {
+	s, d := newTestAdminService(time.Now())
+	ctx := context.Background()
+
+	d.repo.On("ListUsers", ctx, admin.DefaultListLimit, 0).Return([]*admin.UserSummary{{ID: 1}}, nil)
+
+	list, err := s.ListUsers(ctx, 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	_, err = s.ListUsers(ctx, admin.MaxListLimit+1, 0)
+	assert.ErrorIs(t, err, admin.ErrInvalidLimit)
+
+	_, err = s.ListUsers(ctx, 10, -1)
+	assert.ErrorIs(t, err, admin.ErrInvalidPage)
+}
+
+func TestAdminService_Disable_Revokes_Sessions(t *testing.T) {
+	now := time.Date(2025, 10, 27, 9, 0, 0, 0, time.UTC)
+	s, d := newTestAdminService(now)
+	ctx := context.Background()
+
+	d.repo.On("SetDisabled", ctx, int64(2), mock.MatchedBy(func(at *time.Time) bool {
+		return at != nil && at.Equal(now)
+	})).Return(nil)
+	d.tokenRepo.On("RevokeAllByUser", ctx, int64(2)).Return(nil)
+	d.revoker.On("RevokeUser", ctx, int64(2)).Return(nil)
+
+	assert.NoError(t, s.Disable(ctx, 1, 2))
+
+	d.repo.AssertExpectations(t)
+	d.tokenRepo.AssertExpectations(t)
+	d.revoker.AssertExpectations(t)
+}
+
+func TestAdminService_Disable_Self(t *testing.T) {
+	s, d := newTestAdminService(time.Now())
+
+	err := s.Disable(context.Background(), 1, 1)
+
+	assert.ErrorIs(t, err, admin.ErrSelfDisable)
+	d.repo.AssertNotCalled(t, "SetDisabled", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAdminService_Enable(t *testing.T) {
+	s, d := newTestAdminService(time.Now())
+	ctx := context.Background()
+
+	d.repo.On("SetDisabled", ctx, int64(2), (*time.Time)(nil)).Return(user.ErrUserNotFound)
+
+	assert.ErrorIs(t, s.Enable(ctx, 2), user.ErrUserNotFound)
+}
+
+func TestAdminService_ForceLogout_Unknown_User(t *testing.T) {
+	s, d := newTestAdminService(time.Now())
+	ctx := context.Background()
+
+	d.userRepo.On("GetByID", ctx, int64(2)).Return((*user.UserRecord)(nil), user.ErrUserNotFound)
+
+	assert.ErrorIs(t, s.ForceLogout(ctx, 2), user.ErrUserNotFound)
+	d.tokenRepo.AssertNotCalled(t, "RevokeAllByUser", mock.Anything, mock.Anything)
+}
+
+func TestAdminService_ResetSecondFactor(t *testing.T) {
+	s, d := newTestAdminService(time.Now())
+	ctx := context.Background()
+
+	d.userRepo.On("GetByID", ctx, int64(2)).Return(&user.UserRecord{ID: 2}, nil)
+	d.devices.On("DeleteAllByUser", ctx, int64(2)).Return(int64(3), nil)
+	d.tokenRepo.On("RevokeAllByUser", ctx, int64(2)).Return(nil)
+	d.revoker.On("RevokeUser", ctx, int64(2)).Return(nil)
+
+	removed, err := s.ResetSecondFactor(ctx, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), removed)
+	d.revoker.AssertExpectations(t)
+}
+
+func TestAdminService_Bootstrap(t *testing.T) {
+	s, d := newTestAdminService(time.Now())
+	ctx := context.Background()
+
+	d.repo.On("GrantFirstAdmin", ctx, "root").Return(nil)
+	d.repo.On("GrantFirstAdmin", ctx, "other").Return(admin.ErrAlreadyBootstrapped)
+
+	assert.NoError(t, s.Bootstrap(ctx, "root"))
+	assert.ErrorIs(t, s.Bootstrap(ctx, "other"), admin.ErrAlreadyBootstrapped)
+	d.repo.AssertExpectations(t)
+}
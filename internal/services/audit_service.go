@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/principal"
+	"time"
+)
+
+// auditVerifyBatch is the number of events read at once while verifying the chain.
+const auditVerifyBatch = 1000
+
+// AuditRecorder appends events to the audit log. The request client and the
+// acting principal are taken from ctx.
+type AuditRecorder interface {
+	Record(ctx context.Context, e audit.Event) error
+}
+
+type IAuditService interface {
+	AuditRecorder
+	List(ctx context.Context, userID int64, filter audit.Filter) ([]*audit.Event, error)
+	Verify(ctx context.Context) (audit.Verification, error)
+}
+
+// AuditService writes and reads the hash-chained audit log.
+type AuditService struct {
+	repo audit.AuditRepository
+	now  func() time.Time
+}
+
+// NewAuditService constructs a new AuditService with given dependencies.
+func NewAuditService(repo audit.AuditRepository) AuditService {
+	return AuditService{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// Record completes e with the request client, the actor and the current time
+// and appends it to the log.
+func (s *AuditService) Record(ctx context.Context, e audit.Event) error {
+	info := audit.RequestInfoFromContext(ctx)
+	e.IP = info.IP
+	e.UserAgent = info.UserAgent
+	e.Actor = auditActor(ctx)
+	e.CreatedAt = s.now().UTC().Truncate(time.Microsecond)
+
+	return s.repo.Append(ctx, &e)
+}
+
+// List returns the events of the user matching the filter, newest first.
+func (s *AuditService) List(ctx context.Context, userID int64, filter audit.Filter) ([]*audit.Event, error) {
+	if err := audit.ValidateFilter(filter); err != nil {
+		return nil, err
+	}
+
+	return s.repo.List(ctx, userID, filter)
+}
+
+// Verify walks the whole log in chain order and recomputes every hash.
+//
+// Returns an error wrapping audit.ErrChainBroken with the ID of the first
+// event that does not match its predecessor or its own content.
+func (s *AuditService) Verify(ctx context.Context) (audit.Verification, error) {
+	var (
+		result audit.Verification
+		lastID int64
+	)
+
+	for {
+		batch, err := s.repo.Chain(ctx, lastID, auditVerifyBatch)
+		if err != nil {
+			return result, err
+		}
+
+		for _, e := range batch {
+			if e.PrevHash != result.HeadHash || e.Hash != audit.ComputeHash(e.PrevHash, e) {
+				return result, fmt.Errorf("%w at event %d", audit.ErrChainBroken, e.ID)
+			}
+			result.HeadHash = e.Hash
+			result.Events++
+			lastID = e.ID
+		}
+
+		if len(batch) < auditVerifyBatch {
+			return result, nil
+		}
+	}
+}
+
+// recordAudit appends an event for an operation that finished with opErr.
+//
+// A successful operation is only reported as such once it is recorded, so a
+// failed append is returned. For a failed operation the append is best effort
+// and opErr is returned unchanged.
+func recordAudit(ctx context.Context, recorder AuditRecorder, e audit.Event, opErr error) error {
+	e.Result = auditResult(opErr)
+
+	recErr := recorder.Record(ctx, e)
+	if opErr != nil {
+		return opErr
+	}
+
+	return recErr
+}
+
+// auditResult classifies the error of an operation.
+func auditResult(err error) audit.Result {
+	if err == nil {
+		return audit.ResultSuccess
+	}
+
+	var fe *apperr.ForbiddenError
+	if errors.As(err, &fe) {
+		return audit.ResultDenied
+	}
+
+	return audit.ResultFailure
+}
+
+// auditActor describes the principal of the request. Requests without one,
+// such as logins, are made by the user.
+func auditActor(ctx context.Context) string {
+	p, ok := principal.FromContext(ctx)
+	if !ok {
+		return string(principal.KindUser)
+	}
+	switch p.Kind {
+	case principal.KindServiceAccount:
+		return string(p.Kind) + ":" + p.ClientID.String()
+	case principal.KindEmergencyContact:
+		return string(p.Kind) + ":" + p.ContactUUID.String()
+	}
+	return string(p.Kind)
+}
+
+// parseAuditKey returns the UUID of an entry for an audit event, or uuid.Nil
+// if keyUUID is not a valid UUID.
+func parseAuditKey(keyUUID string) uuid.UUID {
+	id, err := uuid.Parse(keyUUID)
+	if err != nil {
+		return uuid.Nil
+	}
+	return id
+}
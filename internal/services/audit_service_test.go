@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/principal"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"testing"
+	"time"
+)
+
+// nopAuditor accepts every audit event.
+func nopAuditor() *mocks.AuditServiceMock {
+	auditor := new(mocks.AuditServiceMock)
+	auditor.On("Record", mock.Anything, mock.Anything).Return(nil)
+	return auditor
+}
+
+func newTestAuditService(repo *mocks.AuditRepositoryMock, now time.Time) AuditService {
+	s := NewAuditService(repo)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+// chainOf links events the way the repository does.
+func chainOf(events ...*audit.Event) []*audit.Event {
+	prev := ""
+	for i, e := range events {
+		e.ID = int64(i + 1)
+		e.PrevHash = prev
+		e.Hash = audit.ComputeHash(prev, e)
+		prev = e.Hash
+	}
+	return events
+}
+
+func TestAuditService_Record_Fills_Request_Info(t *testing.T) {
+	repo := new(mocks.AuditRepositoryMock)
+	now := time.Date(2025, 10, 25, 9, 0, 0, 123456789, time.UTC)
+	s := newTestAuditService(repo, now)
+
+	clientID := uuid.New()
+	ctx := audit.WithRequestInfo(context.Background(), audit.RequestInfo{IP: "10.0.0.1", UserAgent: "passKeeper-cli"})
+	ctx = principal.WithPrincipal(ctx, principal.ServiceAccount(1, clientID))
+
+	repo.On("Append", ctx, &audit.Event{
+		UserID:    1,
+		Actor:     "service_account:" + clientID.String(),
+		Action:    audit.ActionKeyRead,
+		IP:        "10.0.0.1",
+		UserAgent: "passKeeper-cli",
+		Result:    audit.ResultSuccess,
+		CreatedAt: now.Truncate(time.Microsecond),
+	}).Return(nil)
+
+	err := s.Record(ctx, audit.Event{UserID: 1, Action: audit.ActionKeyRead, Result: audit.ResultSuccess})
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestAuditService_List_Validate_Error(t *testing.T) {
+	s := NewAuditService(new(mocks.AuditRepositoryMock))
+
+	_, err := s.List(context.Background(), 1, audit.Filter{Action: "nope"})
+
+	assert.ErrorIs(t, err, audit.ErrInvalidAction)
+}
+
+func TestAuditService_Verify_Success(t *testing.T) {
+	repo := new(mocks.AuditRepositoryMock)
+	s := NewAuditService(repo)
+
+	ctx := context.Background()
+	at := time.Date(2025, 10, 25, 9, 0, 0, 0, time.UTC)
+	events := chainOf(
+		&audit.Event{UserID: 1, Actor: "user", Action: audit.ActionLogin, Result: audit.ResultSuccess, CreatedAt: at},
+		&audit.Event{UserID: 1, Actor: "user", Action: audit.ActionKeyRead, KeyUUID: uuid.New(), Result: audit.ResultSuccess, CreatedAt: at},
+	)
+	repo.On("Chain", ctx, int64(0), auditVerifyBatch).Return(events, nil)
+
+	res, err := s.Verify(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), res.Events)
+	assert.Equal(t, events[1].Hash, res.HeadHash)
+}
+
+func TestAuditService_Verify_Detects_Tampering(t *testing.T) {
+	repo := new(mocks.AuditRepositoryMock)
+	s := NewAuditService(repo)
+
+	ctx := context.Background()
+	at := time.Date(2025, 10, 25, 9, 0, 0, 0, time.UTC)
+	events := chainOf(
+		&audit.Event{UserID: 1, Actor: "user", Action: audit.ActionLogin, Result: audit.ResultFailure, CreatedAt: at},
+		&audit.Event{UserID: 1, Actor: "user", Action: audit.ActionLogin, Result: audit.ResultSuccess, CreatedAt: at},
+	)
+	events[0].Result = audit.ResultSuccess
+	repo.On("Chain", ctx, int64(0), auditVerifyBatch).Return(events, nil)
+
+	_, err := s.Verify(ctx)
+
+	assert.ErrorIs(t, err, audit.ErrChainBroken)
+	assert.Contains(t, err.Error(), "event 1")
+}
+
+func TestRecordAudit_Result(t *testing.T) {
+	auditor := new(mocks.AuditServiceMock)
+	ctx := context.Background()
+	opErr := errors.New("db down")
+
+	auditor.On("Record", ctx, audit.Event{Action: audit.ActionLogin, Result: audit.ResultFailure}).Return(errors.New("audit down"))
+
+	err := recordAudit(ctx, auditor, audit.Event{Action: audit.ActionLogin}, opErr)
+
+	assert.Equal(t, opErr, err)
+	auditor.AssertExpectations(t)
+}
+
+func TestRecordAudit_Success_Fails_Without_Record(t *testing.T) {
+	auditor := new(mocks.AuditServiceMock)
+	ctx := context.Background()
+	recErr := errors.New("audit down")
+
+	auditor.On("Record", ctx, audit.Event{Action: audit.ActionKeyRead, Result: audit.ResultSuccess}).Return(recErr)
+
+	err := recordAudit(ctx, auditor, audit.Event{Action: audit.ActionKeyRead}, nil)
+
+	assert.Equal(t, recErr, err)
+}
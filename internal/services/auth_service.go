@@ -4,19 +4,28 @@ import (
 	"context"
 	"errors"
 	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/config"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/device"
 	"github.com/thxhix/passKeeper/internal/domain/token"
 	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
 	"strconv"
+	"sync"
 	"time"
 )
 
 // PasswordHasher defines password hashing operations required by AuthService.
 //
-// Implementations must provide a secure, one-way hash for passwords and a method
-// to verify a plain password against the stored hash.
+// Implementations must provide a secure, one-way hash for passwords, a method
+// to verify a plain password against the stored hash and a way to tell whether
+// a stored hash should be upgraded to the current algorithm or parameters.
+// HashPassword and CheckPasswordHash fail with an *apperr.UnavailableError
+// while the hasher is saturated.
 type PasswordHasher interface {
 	HashPassword(password string) (string, error)
-	CheckPasswordHash(password, hash string) bool
+	CheckPasswordHash(password, hash string) (bool, error)
+	NeedsRehash(hash string) bool
 }
 
 // TokenManager describes token-related helper operations used by AuthService.
@@ -30,10 +39,45 @@ type TokenManager interface {
 	Sha256Hex(s string) string
 }
 
+// LockoutPolicy configures temporary account lockout after repeated failed logins.
+//
+// After Threshold consecutive failures the account is locked for BaseDuration;
+// every following lockout doubles the duration up to MaxDuration. A zero
+// Threshold disables lockout.
+type LockoutPolicy struct {
+	Threshold    int
+	BaseDuration time.Duration
+	MaxDuration  time.Duration
+}
+
+// NewLockoutPolicy builds a LockoutPolicy from the rate limit configuration.
+func NewLockoutPolicy(cfg *config.Config) LockoutPolicy {
+	return LockoutPolicy{
+		Threshold:    cfg.LockoutThreshold,
+		BaseDuration: time.Duration(cfg.LockoutBaseMinute) * time.Minute,
+		MaxDuration:  time.Duration(cfg.LockoutMaxMinute) * time.Minute,
+	}
+}
+
+// duration returns the lock duration for an account locked lockoutCount times before.
+func (p LockoutPolicy) duration(lockoutCount int) time.Duration {
+	d := p.BaseDuration
+	for i := 0; i < lockoutCount; i++ {
+		d *= 2
+		if d >= p.MaxDuration {
+			return p.MaxDuration
+		}
+	}
+	return d
+}
+
 type IAuthService interface {
-	Register(ctx context.Context, login string, password string) (userId int64, accessToken string, refreshToken string, err error)
-	Login(ctx context.Context, login string, password string) (userId int64, accessToken string, refreshToken string, err error)
+	Register(ctx context.Context, login string, password string, email string, inviteCode string, dev device.Identity) (userId int64, accessToken string, refreshToken string, err error)
+	RegisterSilently(ctx context.Context, login string, password string, email string, inviteCode string, dev device.Identity) error
+	Login(ctx context.Context, login string, password string, dev device.Identity) (userId int64, accessToken string, refreshToken string, err error)
 	Refresh(ctx context.Context, incomingRefreshToken string) (accessToken string, refreshToken string, err error)
+	ChangePassword(ctx context.Context, userID int64, currentPassword string, newPassword string, keyMaterial []byte, dev device.Identity) (accessToken string, refreshToken string, err error)
+	Logout(ctx context.Context, userID int64) error
 }
 
 // AuthService provides registration, login and refresh workflows.
@@ -48,16 +92,63 @@ type AuthService struct {
 
 	hasher       PasswordHasher
 	tokenManager TokenManager
+	revoker      AccessTokenRevoker
+	devices      DeviceGate
+	auditor      AuditRecorder
+	webhooks     WebhookPublisher
+	invites      InviteGate
+	emails       EmailVerifier
+
+	lockout   LockoutPolicy
+	passwords user.PasswordPolicy
+	dummy     *dummyPasswordHash
+	now       func() time.Time
+}
+
+// dummyPasswordHash is checked instead of a stored hash when a login is
+// unknown, so that a failed login costs the same whether or not the user
+// exists. It is made on first use with the hasher's current parameters.
+type dummyPasswordHash struct {
+	mu   sync.Mutex
+	hash string
+}
+
+// get returns the dummy hash, making it with hasher on first use.
+func (d *dummyPasswordHash) get(hasher PasswordHasher) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.hash == "" {
+		hash, err := hasher.HashPassword(strconv.FormatInt(time.Now().UnixNano(), 10))
+		if err != nil {
+			return "", err
+		}
+		d.hash = hash
+	}
+	return d.hash, nil
 }
 
 // NewAuthService constructs a new AuthService with given dependencies.
-func NewAuthService(userRepo user.UserRepository, tokenRepo token.TokenRepository, hasher PasswordHasher, tokenManager TokenManager) AuthService {
+// A nil invites gate leaves registration open to everyone; a nil emails
+// verifier sends no verification mails.
+func NewAuthService(userRepo user.UserRepository, tokenRepo token.TokenRepository, hasher PasswordHasher, tokenManager TokenManager, revoker AccessTokenRevoker, devices DeviceGate, auditor AuditRecorder, webhooks WebhookPublisher, invites InviteGate, emails EmailVerifier, lockout LockoutPolicy, passwords user.PasswordPolicy) AuthService {
 	return AuthService{
 		userRepo:  userRepo,
 		tokenRepo: tokenRepo,
 
 		hasher:       hasher,
 		tokenManager: tokenManager,
+		revoker:      revoker,
+		devices:      devices,
+		auditor:      auditor,
+		webhooks:     webhooks,
+		invites:      invites,
+		emails:       emails,
+
+		lockout:   lockout,
+		passwords: passwords,
+		dummy:     &dummyPasswordHash{},
+		now:       time.Now,
 	}
 }
 
@@ -65,100 +156,218 @@ func NewAuthService(userRepo user.UserRepository, tokenRepo token.TokenRepositor
 //
 // It validates input (login and password), hashes the password, creates a user
 // record in the user repository and then generates both access and refresh tokens.
+// The device the account is created from becomes its first trusted device
+// once it proves it holds its key.
+// When the service has an InviteGate, inviteCode must be a valid invite for
+// login, otherwise invite.ErrInvalidInvite is returned. email is optional; a
+// verification token is mailed to it once the account exists.
 // The refresh token is stored in the token repository (hashed) together with its
-// JTI and TTL.
+// JTI, TTL and device.
 //
 // Returns the created user id, an access token, a refresh token and an error.
 // On validation or repository error, the returned error describes the failure.
-func (s *AuthService) Register(ctx context.Context, login string, password string) (userId int64, accessToken string, refreshToken string, err error) {
-	if err := user.ValidateLogin(login); err != nil {
+func (s *AuthService) Register(ctx context.Context, login string, password string, email string, inviteCode string, dev device.Identity) (userId int64, accessToken string, refreshToken string, err error) {
+	userId, deviceID, err := s.createAccount(ctx, login, password, email, inviteCode, dev)
+	if err != nil {
 		return 0, "", "", err
 	}
 
-	if err := user.ValidatePassword(password); err != nil {
+	accessToken, refreshToken, err = s.issueTokens(ctx, userId, &deviceID)
+	if err != nil {
 		return 0, "", "", err
 	}
 
+	return userId, accessToken, refreshToken, nil
+}
+
+// RegisterSilently creates a user account like Register but does not sign
+// the user in, and reports a login or email that is already taken as
+// success. It backs the uniform registration response, which must not tell
+// whether an account exists; the client logs in afterwards.
+//
+// Validation, password policy and invite errors are still returned, they
+// depend on the request alone.
+func (s *AuthService) RegisterSilently(ctx context.Context, login string, password string, email string, inviteCode string, dev device.Identity) error {
+	_, _, err := s.createAccount(ctx, login, password, email, inviteCode, dev)
+	if errors.Is(err, user.ErrDuplicateLogin) || errors.Is(err, user.ErrDuplicateEmail) {
+		return nil
+	}
+	return err
+}
+
+// createAccount validates the input, stores the new user, enrolls dev as
+// its first trusted device and mails the email verification token.
+func (s *AuthService) createAccount(ctx context.Context, login string, password string, email string, inviteCode string, dev device.Identity) (userId int64, deviceID int64, err error) {
+	if err := user.ValidateLogin(login); err != nil {
+		return 0, 0, err
+	}
+
+	if err := s.passwords.Check(login, password); err != nil {
+		return 0, 0, err
+	}
+
+	email = user.NormalizeEmail(email)
+	if email != "" {
+		if err := user.ValidateEmail(email); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if err := device.ValidateIdentity(dev); err != nil {
+		return 0, 0, err
+	}
+	if err := s.devices.Prove(ctx, dev); err != nil {
+		return 0, 0, err
+	}
+
 	// Generate password hash for security store in storage
 	passwordHash, err := s.hasher.HashPassword(password)
 	if err != nil {
-		return 0, "", "", err
+		return 0, 0, err
 	}
 
-	userId, err = s.userRepo.Create(ctx, login, passwordHash)
-	if err != nil {
-		return 0, "", "", err
+	// In invite-only mode a use of the invite is taken before the account is
+	// created and given back if creating it fails.
+	var inviteID int64
+	if s.invites != nil {
+		inviteID, err = s.invites.Redeem(ctx, inviteCode, login, email)
+		if err != nil {
+			return 0, 0, err
+		}
 	}
 
-	accessToken, err = s.tokenManager.GenerateAccessToken(userId)
+	userId, err = s.userRepo.Create(ctx, login, passwordHash, email)
 	if err != nil {
-		return 0, "", "", err
+		if s.invites != nil {
+			_ = s.invites.Release(ctx, inviteID)
+		}
+		return 0, 0, err
 	}
 
-	refreshToken, refreshJTI, refreshTTL, err := s.tokenManager.GenerateRefreshToken(userId)
+	deviceID, err = s.devices.Enroll(ctx, userId, dev)
 	if err != nil {
-		return 0, "", "", err
+		return 0, 0, err
 	}
 
-	issuedAt := time.Now().UTC()
-	expiresAt := issuedAt.Add(refreshTTL)
-	refreshHash := s.tokenManager.Sha256Hex(refreshToken)
-
-	if err := s.tokenRepo.Create(ctx, userId, refreshJTI, refreshHash, issuedAt, expiresAt); err != nil {
-		return 0, "", "", err
+	// The verification mail is best effort: the account exists either way and
+	// the user can request another one by setting the email again.
+	if email != "" && s.emails != nil {
+		_ = s.emails.SendVerification(ctx, userId, email)
 	}
 
-	return userId, accessToken, refreshToken, nil
+	return userId, deviceID, nil
 }
 
 // Login authenticates a user by login and password and returns tokens.
 //
 // It validates the login format, retrieves the user by login, verifies the
 // provided password against stored hash and — on success — generates and stores
-// a new refresh token while returning a fresh access token as well.
+// a new refresh token while returning a fresh access token as well. Hashes made
+// with a legacy algorithm or outdated parameters are transparently upgraded.
+//
+// Consecutive failures are counted in storage; once the lockout threshold is
+// reached the account is locked and further logins are rejected until the
+// lock expires, even with a valid password.
+//
+// Tokens are only issued to a trusted device: after the password is verified
+// the device is checked by the DeviceGate, which returns
+// device.ErrApprovalRequired for a device that still needs approval.
+//
+// Every attempt is recorded in the audit log; tokens are only returned once
+// the successful login is recorded.
+//
+// An unknown login is checked against a dummy hash and counted like an
+// existing one, so that it takes as long to reject as a wrong password.
 //
 // Returns the user id, an access token, a refresh token and an error. If the
-// credentials are invalid, user.ErrInvalidCredentials is returned.
-func (s *AuthService) Login(ctx context.Context, login string, password string) (userId int64, accessToken string, refreshToken string, err error) {
+// credentials are invalid or the account is locked, user.ErrInvalidCredentials
+// is returned, so that a lock does not reveal the login; a disabled account
+// gets user.ErrAccountDisabled.
+func (s *AuthService) Login(ctx context.Context, login string, password string, dev device.Identity) (userId int64, accessToken string, refreshToken string, err error) {
+	var subject int64
+	defer func() {
+		err = recordAudit(ctx, s.auditor, audit.Event{UserID: subject, Action: audit.ActionLogin}, err)
+		if err != nil {
+			userId, accessToken, refreshToken = 0, "", ""
+		}
+	}()
+
 	if err := user.ValidateLogin(login); err != nil {
 		return 0, "", "", err
 	}
 
 	au, err := s.userRepo.GetByLogin(ctx, login)
+	if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+		return 0, "", "", err
+	}
+
+	// the password is checked before anything else, against a dummy hash for
+	// an unknown login, so that neither the response nor its timing tells
+	// which logins exist or are locked
+	var valid bool
+	if au != nil {
+		subject = au.ID
+		valid, err = s.hasher.CheckPasswordHash(password, au.PasswordHash)
+	} else {
+		err = s.checkDummyPassword(password)
+	}
 	if err != nil {
-		if errors.Is(err, user.ErrUserNotFound) {
-			return 0, "", "", user.ErrInvalidCredentials
-		}
 		return 0, "", "", err
 	}
 
-	if !s.hasher.CheckPasswordHash(password, au.PasswordHash) {
+	now := s.now().UTC()
+	locked := au != nil && au.LockedUntil != nil && now.Before(*au.LockedUntil)
+
+	if !valid || locked {
+		if err := registerLoginFailure(ctx, s.userRepo, s.webhooks, s.lockout, login, now); err != nil {
+			return 0, "", "", err
+		}
 		return 0, "", "", user.ErrInvalidCredentials
 	}
 
+	// checked only after the password, so that being disabled is not
+	// disclosed to someone who does not know it
+	if au.DisabledAt != nil {
+		return 0, "", "", user.ErrAccountDisabled
+	}
+
 	userId = au.ID
 
-	accessToken, err = s.tokenManager.GenerateAccessToken(userId)
-	if err != nil {
-		return 0, "", "", err
+	if au.FailedLoginAttempts > 0 || au.LockoutCount > 0 || au.LockedUntil != nil {
+		if err := s.userRepo.ResetLoginFailures(ctx, userId); err != nil {
+			return 0, "", "", err
+		}
 	}
 
-	refreshToken, refreshJTI, refreshTTL, err := s.tokenManager.GenerateRefreshToken(userId)
+	if s.hasher.NeedsRehash(au.PasswordHash) {
+		s.upgradePasswordHash(ctx, userId, password)
+	}
+
+	deviceID, err := s.devices.Admit(ctx, userId, dev)
 	if err != nil {
 		return 0, "", "", err
 	}
 
-	issuedAt := time.Now().UTC()
-	expiresAt := issuedAt.Add(refreshTTL)
-	refreshHash := s.tokenManager.Sha256Hex(refreshToken)
-
-	if err := s.tokenRepo.Create(ctx, userId, refreshJTI, refreshHash, issuedAt, expiresAt); err != nil {
+	accessToken, refreshToken, err = s.issueTokens(ctx, userId, &deviceID)
+	if err != nil {
 		return 0, "", "", err
 	}
 
 	return userId, accessToken, refreshToken, nil
 }
 
+// checkDummyPassword spends the same work on password as checking it against
+// a stored hash would and discards the result. It fails like a real check
+// when the hasher is saturated.
+func (s *AuthService) checkDummyPassword(password string) error {
+	hash, err := s.dummy.get(s.hasher)
+	if err != nil {
+		return err
+	}
+	_, err = s.hasher.CheckPasswordHash(password, hash)
+	return err
+}
+
 // Refresh validates an incoming refresh token and rotates it.
 //
 // The method parses the incoming refresh token to extract user id and JTI,
@@ -168,8 +377,17 @@ func (s *AuthService) Login(ctx context.Context, login string, password string)
 //
 // Returns the new access token, the new refresh token and an error. If the
 // incoming token is invalid or does not match stored state, user.ErrInvalidRefreshCredentials
-// is returned.
+// is returned, and user.ErrAccountDisabled once the account has been disabled.
+// Every attempt is recorded in the audit log.
 func (s *AuthService) Refresh(ctx context.Context, incomingRefreshToken string) (accessToken string, refreshToken string, err error) {
+	var subject int64
+	defer func() {
+		err = recordAudit(ctx, s.auditor, audit.Event{UserID: subject, Action: audit.ActionRefresh}, err)
+		if err != nil {
+			accessToken, refreshToken = "", ""
+		}
+	}()
+
 	userIdStr, jtiStr, err := s.tokenManager.ParseRefreshToken(incomingRefreshToken)
 	if err != nil {
 		return "", "", err
@@ -183,6 +401,7 @@ func (s *AuthService) Refresh(ctx context.Context, incomingRefreshToken string)
 	if err != nil {
 		return "", "", err
 	}
+	subject = userId
 
 	incomingTokenHash := s.tokenManager.Sha256Hex(incomingRefreshToken)
 
@@ -197,9 +416,25 @@ func (s *AuthService) Refresh(ctx context.Context, incomingRefreshToken string)
 	now := time.Now().UTC()
 
 	if err := token.ValidateToken(now, userId, incomingTokenHash, tokenRecord); err != nil {
+		if tokenRecord.ReplacedBy != nil && tokenRecord.UserID == userId {
+			// a rotated token was presented again: either the client retried
+			// or the token was stolen and one of the parties already used it
+			publishWebhook(ctx, s.webhooks, webhook.Event{Type: webhook.EventRefreshReplayed, UserID: userId})
+		}
 		return "", "", user.ErrInvalidRefreshCredentials
 	}
 
+	au, err := s.userRepo.GetByID(ctx, userId)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return "", "", user.ErrInvalidRefreshCredentials
+		}
+		return "", "", err
+	}
+	if au.DisabledAt != nil {
+		return "", "", user.ErrAccountDisabled
+	}
+
 	accessToken, err = s.tokenManager.GenerateAccessToken(userId)
 	if err != nil {
 		return "", "", err
@@ -217,3 +452,153 @@ func (s *AuthService) Refresh(ctx context.Context, incomingRefreshToken string)
 
 	return accessToken, newRefreshToken, nil
 }
+
+// ChangePassword replaces the password of an authenticated user.
+//
+// The current password is verified first and a wrong one counts towards the
+// lockout policy like a failed login; while the account is locked the change
+// is rejected. Then the new password is validated and the device of the
+// caller is checked by the DeviceGate, as on login, before the new password
+// is hashed and stored. keyMaterial carries the vault key re-wrapped by a
+// zero-knowledge client under the new password; nil leaves the stored key
+// material untouched.
+// All existing refresh and access tokens of the user are revoked and a fresh
+// token pair bound to dev is issued, so only the caller stays signed in.
+//
+// Returns the new access token, the new refresh token and an error. If the
+// current password does not match or the account is locked,
+// user.ErrInvalidCredentials is returned.
+func (s *AuthService) ChangePassword(ctx context.Context, userID int64, currentPassword string, newPassword string, keyMaterial []byte, dev device.Identity) (accessToken string, refreshToken string, err error) {
+	au, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return "", "", user.ErrInvalidCredentials
+		}
+		return "", "", err
+	}
+
+	valid, err := s.hasher.CheckPasswordHash(currentPassword, au.PasswordHash)
+	if err != nil {
+		return "", "", err
+	}
+
+	now := s.now().UTC()
+	locked := au.LockedUntil != nil && now.Before(*au.LockedUntil)
+
+	if !valid || locked {
+		if err := registerLoginFailure(ctx, s.userRepo, s.webhooks, s.lockout, au.Login, now); err != nil {
+			return "", "", err
+		}
+		return "", "", user.ErrInvalidCredentials
+	}
+
+	if err := s.passwords.Check(au.Login, newPassword); err != nil {
+		return "", "", err
+	}
+	if newPassword == currentPassword {
+		return "", "", user.ErrPasswordUnchanged
+	}
+
+	deviceID, err := s.devices.Admit(ctx, userID, dev)
+	if err != nil {
+		return "", "", err
+	}
+
+	passwordHash, err := s.hasher.HashPassword(newPassword)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := s.userRepo.UpdateCredentials(ctx, userID, passwordHash, keyMaterial); err != nil {
+		return "", "", err
+	}
+
+	if au.FailedLoginAttempts > 0 || au.LockoutCount > 0 || au.LockedUntil != nil {
+		if err := s.userRepo.ResetLoginFailures(ctx, userID); err != nil {
+			return "", "", err
+		}
+	}
+
+	if err := revokeSessions(ctx, s.tokenRepo, s.revoker, userID); err != nil {
+		return "", "", err
+	}
+
+	return s.issueTokens(ctx, userID, &deviceID)
+}
+
+// Logout terminates every session of the user: all refresh tokens are removed
+// and access tokens issued so far are rejected immediately.
+func (s *AuthService) Logout(ctx context.Context, userID int64) error {
+	return revokeSessions(ctx, s.tokenRepo, s.revoker, userID)
+}
+
+// upgradePasswordHash re-hashes a verified password with the current algorithm
+// and parameters. Errors are deliberately ignored: the login itself succeeded
+// and the upgrade is retried on the next one.
+func (s *AuthService) upgradePasswordHash(ctx context.Context, userID int64, password string) {
+	passwordHash, err := s.hasher.HashPassword(password)
+	if err != nil {
+		return
+	}
+
+	_ = s.userRepo.UpdatePasswordHash(ctx, userID, passwordHash)
+}
+
+// registerLoginFailure counts a failed password check for login and locks
+// the account once the threshold of lockout is reached. Unknown and already
+// locked logins are counted by the same statement, which leaves them
+// unchanged.
+//
+// Every check of the account password goes through it, not only logins, so a
+// stolen access token cannot be used to guess the password.
+func registerLoginFailure(ctx context.Context, userRepo user.UserRepository, webhooks WebhookPublisher, lockout LockoutPolicy, login string, now time.Time) error {
+	if lockout.Threshold <= 0 {
+		return nil
+	}
+
+	userID, failedAttempts, lockoutCount, err := userRepo.IncrementLoginFailures(ctx, login, now)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if failedAttempts < lockout.Threshold {
+		return nil
+	}
+
+	if err := userRepo.LockAccount(ctx, userID, now.Add(lockout.duration(lockoutCount))); err != nil {
+		return err
+	}
+
+	publishWebhook(ctx, webhooks, webhook.Event{Type: webhook.EventLoginLocked, UserID: userID, Attempts: failedAttempts})
+
+	return nil
+}
+
+// issueTokens generates a new access token and a new refresh token for the
+// user and stores the hashed refresh token together with its JTI and TTL.
+// deviceID binds the refresh token to a device and is nil when the device of
+// the session is not known.
+func (s *AuthService) issueTokens(ctx context.Context, userID int64, deviceID *int64) (accessToken string, refreshToken string, err error) {
+	accessToken, err = s.tokenManager.GenerateAccessToken(userID)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, refreshJTI, refreshTTL, err := s.tokenManager.GenerateRefreshToken(userID)
+	if err != nil {
+		return "", "", err
+	}
+
+	issuedAt := time.Now().UTC()
+	expiresAt := issuedAt.Add(refreshTTL)
+	refreshHash := s.tokenManager.Sha256Hex(refreshToken)
+
+	if err := s.tokenRepo.Create(ctx, userID, deviceID, refreshJTI, refreshHash, issuedAt, expiresAt); err != nil {
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
+}
@@ -6,13 +6,27 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/device"
 	"github.com/thxhix/passKeeper/internal/domain/token"
 	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
 	"github.com/thxhix/passKeeper/internal/mocks"
 	"testing"
 	"time"
 )
 
+var testDevice = device.Identity{ID: uuid.New(), Name: "laptop", PublicKey: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="}
+
+// allowDevices returns a device gate that admits every device.
+func allowDevices() *mocks.DeviceServiceMock {
+	devices := new(mocks.DeviceServiceMock)
+	devices.On("Prove", mock.Anything, mock.Anything).Return(nil)
+	devices.On("Enroll", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	devices.On("Admit", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	return devices
+}
+
 func TestAuthService_Register_Success(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 	tokenRepo := new(mocks.TokenRepositoryMock)
@@ -21,16 +35,16 @@ func TestAuthService_Register_Success(t *testing.T) {
 
 	ctx := context.Background()
 
-	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager)
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
 
-	tokenRepo.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	userRepo.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(1, nil)
+	tokenRepo.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	userRepo.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(1, nil)
 	passHasher.On("HashPassword", mock.Anything, mock.Anything).Return("123456", nil)
 	tokenManager.On("GenerateAccessToken", mock.Anything).Return("accessToken", nil)
 	tokenManager.On("GenerateRefreshToken", mock.Anything).Return("refreshToken", uuid.New(), time.Duration(1), nil)
 	tokenManager.On("Sha256Hex", mock.Anything).Return("password_hash")
 
-	_, access, refresh, err := s.Register(ctx, "login", "password")
+	_, access, refresh, err := s.Register(ctx, "login", "password", "", "", testDevice)
 
 	assert.NoError(t, err)
 
@@ -51,17 +65,33 @@ func TestAuthService_Register_Error(t *testing.T) {
 
 	ctx := context.Background()
 
-	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager)
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
 
 	passHasher.On("HashPassword", mock.Anything, mock.Anything).Return("123456", errors.New("some error"))
 
-	_, _, _, err := s.Register(ctx, "login", "password")
+	_, _, _, err := s.Register(ctx, "login", "password", "", "", testDevice)
 
 	assert.Error(t, err)
 
 	passHasher.AssertExpectations(t)
 }
 
+func TestAuthService_Register_Device_Without_Proof(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	devices := new(mocks.DeviceServiceMock)
+
+	s := NewAuthService(userRepo, new(mocks.TokenRepositoryMock), passHasher, new(mocks.TokenManagerMock), nil, devices, nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	devices.On("Prove", mock.Anything, testDevice).Return(device.ErrInvalidProof)
+
+	_, _, _, err := s.Register(context.Background(), "login", "password", "", "", testDevice)
+
+	assert.ErrorIs(t, err, device.ErrInvalidProof)
+	userRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	passHasher.AssertNotCalled(t, "HashPassword", mock.Anything, mock.Anything)
+}
+
 func TestAuthService_Register_Validate_Error(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 	tokenRepo := new(mocks.TokenRepositoryMock)
@@ -70,9 +100,9 @@ func TestAuthService_Register_Validate_Error(t *testing.T) {
 
 	ctx := context.Background()
 
-	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager)
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
 
-	_, _, _, err := s.Register(ctx, "l", "pass")
+	_, _, _, err := s.Register(ctx, "l", "pass", "", "", testDevice)
 
 	assert.Error(t, err)
 }
@@ -85,16 +115,17 @@ func TestAuthService_Login_Success(t *testing.T) {
 
 	ctx := context.Background()
 
-	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager)
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
 
-	tokenRepo.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	tokenRepo.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	userRepo.On("GetByLogin", mock.Anything, mock.Anything).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "password"}, nil)
-	passHasher.On("CheckPasswordHash", mock.Anything, mock.Anything).Return(true)
+	passHasher.On("CheckPasswordHash", mock.Anything, mock.Anything).Return(true, nil)
+	passHasher.On("NeedsRehash", "password").Return(false)
 	tokenManager.On("GenerateAccessToken", mock.Anything).Return("accessToken", nil)
 	tokenManager.On("GenerateRefreshToken", mock.Anything).Return("refreshToken", uuid.New(), time.Duration(1), nil)
 	tokenManager.On("Sha256Hex", mock.Anything).Return("password_hash")
 
-	_, access, refresh, err := s.Login(ctx, "login", "password")
+	_, access, refresh, err := s.Login(ctx, "login", "password", testDevice)
 
 	assert.NoError(t, err)
 
@@ -107,6 +138,60 @@ func TestAuthService_Login_Success(t *testing.T) {
 	tokenManager.AssertExpectations(t)
 }
 
+func TestAuthService_Login_Upgrades_Legacy_Hash(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+
+	ctx := context.Background()
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	userRepo.On("GetByLogin", mock.Anything, "login").Return(&user.UserRecord{ID: 1, Login: "login", PasswordHash: "$2a$10$legacy"}, nil)
+	passHasher.On("CheckPasswordHash", "password", "$2a$10$legacy").Return(true, nil)
+	passHasher.On("NeedsRehash", "$2a$10$legacy").Return(true)
+	passHasher.On("HashPassword", "password").Return("$argon2id$new", nil)
+	userRepo.On("UpdatePasswordHash", mock.Anything, int64(1), "$argon2id$new").Return(nil)
+	tokenRepo.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	tokenManager.On("GenerateAccessToken", mock.Anything).Return("accessToken", nil)
+	tokenManager.On("GenerateRefreshToken", mock.Anything).Return("refreshToken", uuid.New(), time.Duration(1), nil)
+	tokenManager.On("Sha256Hex", mock.Anything).Return("hash")
+
+	_, access, _, err := s.Login(ctx, "login", "password", testDevice)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "accessToken", access)
+	userRepo.AssertExpectations(t)
+	passHasher.AssertExpectations(t)
+}
+
+func TestAuthService_Login_Upgrade_Failure_Does_Not_Fail_Login(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+
+	ctx := context.Background()
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	userRepo.On("GetByLogin", mock.Anything, "login").Return(&user.UserRecord{ID: 1, Login: "login", PasswordHash: "old"}, nil)
+	passHasher.On("CheckPasswordHash", "password", "old").Return(true, nil)
+	passHasher.On("NeedsRehash", "old").Return(true)
+	passHasher.On("HashPassword", "password").Return("new", nil)
+	userRepo.On("UpdatePasswordHash", mock.Anything, int64(1), "new").Return(errors.New("db down"))
+	tokenRepo.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	tokenManager.On("GenerateAccessToken", mock.Anything).Return("accessToken", nil)
+	tokenManager.On("GenerateRefreshToken", mock.Anything).Return("refreshToken", uuid.New(), time.Duration(1), nil)
+	tokenManager.On("Sha256Hex", mock.Anything).Return("hash")
+
+	_, _, _, err := s.Login(ctx, "login", "password", testDevice)
+
+	assert.NoError(t, err)
+	userRepo.AssertExpectations(t)
+}
+
 func TestAuthService_Login_Wrong_User(t *testing.T) {
 	userRepo := new(mocks.UserRepositoryMock)
 	tokenRepo := new(mocks.TokenRepositoryMock)
@@ -115,11 +200,11 @@ func TestAuthService_Login_Wrong_User(t *testing.T) {
 
 	ctx := context.Background()
 
-	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager)
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
 
 	userRepo.On("GetByLogin", mock.Anything, mock.Anything).Return(&user.UserRecord{}, errors.New("cant find user"))
 
-	_, _, _, err := s.Login(ctx, "login", "password")
+	_, _, _, err := s.Login(ctx, "login", "password", testDevice)
 
 	assert.Error(t, err)
 
@@ -134,9 +219,9 @@ func TestAuthService_Login_Validate_Error(t *testing.T) {
 
 	ctx := context.Background()
 
-	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager)
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
 
-	_, _, _, err := s.Login(ctx, "l", "pass")
+	_, _, _, err := s.Login(ctx, "l", "pass", testDevice)
 
 	assert.Error(t, err)
 }
@@ -149,7 +234,7 @@ func TestAuthService_Refresh_Success(t *testing.T) {
 
 	ctx := context.Background()
 
-	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager)
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
 
 	tokenRepo.On("Rotate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	tokenRepo.On("GetByJTI", mock.Anything, mock.Anything).Return(&token.RefreshTokenRecord{
@@ -164,6 +249,7 @@ func TestAuthService_Refresh_Success(t *testing.T) {
 	tokenManager.On("GenerateAccessToken", mock.Anything).Return("accessToken", nil)
 	tokenManager.On("GenerateRefreshToken", mock.Anything).Return("refreshToken", uuid.New(), time.Duration(1), nil)
 	tokenManager.On("Sha256Hex", mock.Anything).Return("hash")
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test"}, nil)
 
 	access, refresh, err := s.Refresh(ctx, "refreshToken")
 
@@ -184,7 +270,7 @@ func TestAuthService_Refresh_Wrong_Refresh(t *testing.T) {
 
 	ctx := context.Background()
 
-	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager)
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
 
 	tokenRepo.On("GetByJTI", mock.Anything, mock.Anything).Return(&token.RefreshTokenRecord{
 		JTI:       uuid.New(),
@@ -204,3 +290,459 @@ func TestAuthService_Refresh_Wrong_Refresh(t *testing.T) {
 	tokenRepo.AssertExpectations(t)
 	tokenManager.AssertExpectations(t)
 }
+
+func TestAuthService_Refresh_Replay_Publishes_Webhook(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+	publisher := new(mocks.WebhookServiceMock)
+
+	ctx := context.Background()
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), publisher, nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	replacedBy := uuid.New()
+	tokenRepo.On("GetByJTI", mock.Anything, mock.Anything).Return(&token.RefreshTokenRecord{
+		JTI:        uuid.New(),
+		UserID:     1,
+		TokenHash:  "hash",
+		IssuedAt:   time.Now(),
+		ExpiresAt:  time.Now().Add(time.Hour * 10),
+		ReplacedBy: &replacedBy,
+	}, nil)
+
+	tokenManager.On("ParseRefreshToken", mock.Anything).Return("1", uuid.NewString(), nil)
+	tokenManager.On("Sha256Hex", mock.Anything).Return("hash")
+	publisher.On("Publish", ctx, webhook.Event{Type: webhook.EventRefreshReplayed, UserID: 1}).Return(nil)
+
+	_, _, err := s.Refresh(ctx, "refreshToken")
+
+	assert.ErrorIs(t, err, user.ErrInvalidRefreshCredentials)
+	publisher.AssertExpectations(t)
+}
+
+func TestAuthService_ChangePassword_Success(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+
+	ctx := context.Background()
+
+	revoker := new(mocks.AccessTokenRevokerMock)
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, revoker, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	keyMaterial := []byte("rewrapped")
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "old_hash"}, nil)
+	passHasher.On("CheckPasswordHash", "old_password", "old_hash").Return(true, nil)
+	passHasher.On("HashPassword", "new_password").Return("new_hash", nil)
+	userRepo.On("UpdateCredentials", mock.Anything, int64(1), "new_hash", keyMaterial).Return(nil)
+	tokenRepo.On("RevokeAllByUser", mock.Anything, int64(1)).Return(nil)
+	revoker.On("RevokeUser", mock.Anything, int64(1)).Return(nil)
+	// the new refresh token is bound to the device of the caller
+	deviceID := int64(1)
+	tokenRepo.On("Create", mock.Anything, int64(1), &deviceID, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	tokenManager.On("GenerateAccessToken", mock.Anything).Return("accessToken", nil)
+	tokenManager.On("GenerateRefreshToken", mock.Anything).Return("refreshToken", uuid.New(), time.Duration(1), nil)
+	tokenManager.On("Sha256Hex", mock.Anything).Return("hash")
+
+	access, refresh, err := s.ChangePassword(ctx, 1, "old_password", "new_password", keyMaterial, testDevice)
+
+	assert.NoError(t, err)
+
+	assert.Equal(t, "accessToken", access)
+	assert.Equal(t, "refreshToken", refresh)
+
+	tokenRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+	passHasher.AssertExpectations(t)
+	tokenManager.AssertExpectations(t)
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+	revoker := new(mocks.AccessTokenRevokerMock)
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, revoker, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	tokenRepo.On("RevokeAllByUser", mock.Anything, int64(1)).Return(nil)
+	revoker.On("RevokeUser", mock.Anything, int64(1)).Return(nil)
+
+	err := s.Logout(context.Background(), 1)
+
+	assert.NoError(t, err)
+	tokenRepo.AssertExpectations(t)
+	revoker.AssertExpectations(t)
+}
+
+func TestAuthService_ChangePassword_Wrong_Current(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+
+	ctx := context.Background()
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "old_hash"}, nil)
+	passHasher.On("CheckPasswordHash", "wrong_password", "old_hash").Return(false, nil)
+
+	_, _, err := s.ChangePassword(ctx, 1, "wrong_password", "new_password", nil, testDevice)
+
+	assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+
+	userRepo.AssertExpectations(t)
+	passHasher.AssertExpectations(t)
+	tokenRepo.AssertNotCalled(t, "RevokeAllByUser", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ChangePassword_Wrong_Current_Locks_Account(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+
+	ctx := context.Background()
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{
+		Threshold:    3,
+		BaseDuration: 15 * time.Minute,
+		MaxDuration:  time.Hour,
+	}, user.PasswordPolicy{})
+	s.now = func() time.Time { return now }
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "old_hash"}, nil)
+	passHasher.On("CheckPasswordHash", "wrong_password", "old_hash").Return(false, nil)
+	userRepo.On("IncrementLoginFailures", mock.Anything, "test", now).Return(int64(1), 3, 1, nil)
+	userRepo.On("LockAccount", mock.Anything, int64(1), now.Add(30*time.Minute)).Return(nil)
+
+	_, _, err := s.ChangePassword(ctx, 1, "wrong_password", "new_password", nil, testDevice)
+
+	assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	userRepo.AssertExpectations(t)
+}
+
+func TestAuthService_ChangePassword_Locked_Account(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+
+	ctx := context.Background()
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	lockedUntil := now.Add(10 * time.Minute)
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{Threshold: 3, BaseDuration: time.Minute, MaxDuration: time.Hour}, user.PasswordPolicy{})
+	s.now = func() time.Time { return now }
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "old_hash", LockedUntil: &lockedUntil}, nil)
+	passHasher.On("CheckPasswordHash", "old_password", "old_hash").Return(true, nil)
+	// a locked account is left unchanged by the failure counter
+	userRepo.On("IncrementLoginFailures", mock.Anything, "test", now).Return(int64(0), 0, 0, user.ErrUserNotFound)
+
+	_, _, err := s.ChangePassword(ctx, 1, "old_password", "new_password", nil, testDevice)
+
+	assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	userRepo.AssertNotCalled(t, "UpdateCredentials", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	passHasher.AssertNotCalled(t, "HashPassword", mock.Anything)
+}
+
+func TestAuthService_ChangePassword_Unchanged(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+
+	ctx := context.Background()
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", PasswordHash: "old_hash"}, nil)
+	passHasher.On("CheckPasswordHash", "same_password", "old_hash").Return(true, nil)
+
+	_, _, err := s.ChangePassword(ctx, 1, "same_password", "same_password", nil, testDevice)
+
+	assert.ErrorIs(t, err, user.ErrPasswordUnchanged)
+}
+
+func TestAuthService_Login_Locks_Account_After_Threshold(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+
+	ctx := context.Background()
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{
+		Threshold:    3,
+		BaseDuration: 15 * time.Minute,
+		MaxDuration:  time.Hour,
+	}, user.PasswordPolicy{})
+	s.now = func() time.Time { return now }
+
+	userRepo.On("GetByLogin", mock.Anything, "login").Return(&user.UserRecord{ID: 1, Login: "login", PasswordHash: "hash"}, nil)
+	passHasher.On("CheckPasswordHash", "wrong", "hash").Return(false, nil)
+	userRepo.On("IncrementLoginFailures", mock.Anything, "login", now).Return(int64(1), 3, 1, nil)
+	userRepo.On("LockAccount", mock.Anything, int64(1), now.Add(30*time.Minute)).Return(nil)
+
+	_, _, _, err := s.Login(ctx, "login", "wrong", testDevice)
+
+	assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	userRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Login_Locked_Account(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+
+	ctx := context.Background()
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	lockedUntil := now.Add(10 * time.Minute)
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{Threshold: 3, BaseDuration: time.Minute, MaxDuration: time.Hour}, user.PasswordPolicy{})
+	s.now = func() time.Time { return now }
+
+	userRepo.On("GetByLogin", mock.Anything, "login").Return(&user.UserRecord{ID: 1, Login: "login", PasswordHash: "hash", LockedUntil: &lockedUntil}, nil)
+	passHasher.On("CheckPasswordHash", "password", "hash").Return(true, nil)
+	// a locked account is left unchanged by the failure counter
+	userRepo.On("IncrementLoginFailures", mock.Anything, "login", now).Return(int64(0), 0, 0, user.ErrUserNotFound).Once()
+
+	// even the right password is answered like a wrong one, so a lock does not
+	// reveal that the login exists
+	_, _, _, err := s.Login(ctx, "login", "password", testDevice)
+
+	assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	tokenManager.AssertNotCalled(t, "GenerateAccessToken", mock.Anything)
+
+	// once the lock expires the password is accepted again and counters are reset
+	now = lockedUntil.Add(time.Second)
+	passHasher.On("NeedsRehash", "hash").Return(false)
+	userRepo.On("ResetLoginFailures", mock.Anything, int64(1)).Return(nil)
+	tokenRepo.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	tokenManager.On("GenerateAccessToken", mock.Anything).Return("accessToken", nil)
+	tokenManager.On("GenerateRefreshToken", mock.Anything).Return("refreshToken", uuid.New(), time.Duration(1), nil)
+	tokenManager.On("Sha256Hex", mock.Anything).Return("hash")
+
+	_, access, _, err := s.Login(ctx, "login", "password", testDevice)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "accessToken", access)
+	userRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Login_Disabled_Account(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+
+	ctx := context.Background()
+	disabledAt := time.Now().Add(-time.Hour)
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	userRepo.On("GetByLogin", mock.Anything, "login").Return(&user.UserRecord{ID: 1, Login: "login", PasswordHash: "hash", DisabledAt: &disabledAt}, nil)
+	passHasher.On("CheckPasswordHash", "password", "hash").Return(true, nil)
+
+	_, access, refresh, err := s.Login(ctx, "login", "password", testDevice)
+
+	assert.ErrorIs(t, err, user.ErrAccountDisabled)
+	assert.Empty(t, access)
+	assert.Empty(t, refresh)
+	tokenManager.AssertNotCalled(t, "GenerateAccessToken", mock.Anything)
+}
+
+func TestAuthService_Refresh_Disabled_Account(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+
+	ctx := context.Background()
+	disabledAt := time.Now().Add(-time.Hour)
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	tokenRepo.On("GetByJTI", mock.Anything, mock.Anything).Return(&token.RefreshTokenRecord{
+		JTI:       uuid.New(),
+		UserID:    1,
+		TokenHash: "hash",
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour * 10),
+	}, nil)
+	tokenManager.On("ParseRefreshToken", mock.Anything).Return("1", uuid.NewString(), nil)
+	tokenManager.On("Sha256Hex", mock.Anything).Return("hash")
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&user.UserRecord{ID: 1, Login: "test", DisabledAt: &disabledAt}, nil)
+
+	_, _, err := s.Refresh(ctx, "refreshToken")
+
+	assert.ErrorIs(t, err, user.ErrAccountDisabled)
+	tokenRepo.AssertNotCalled(t, "Rotate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLockoutPolicy_Duration(t *testing.T) {
+	p := LockoutPolicy{Threshold: 1, BaseDuration: 15 * time.Minute, MaxDuration: time.Hour}
+
+	assert.Equal(t, 15*time.Minute, p.duration(0))
+	assert.Equal(t, 30*time.Minute, p.duration(1))
+	assert.Equal(t, time.Hour, p.duration(2))
+	assert.Equal(t, time.Hour, p.duration(10))
+}
+
+func TestAuthService_Login_Device_Approval_Required(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+	devices := new(mocks.DeviceServiceMock)
+
+	ctx := context.Background()
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, devices, nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	userRepo.On("GetByLogin", mock.Anything, "login").Return(&user.UserRecord{ID: 1, Login: "login", PasswordHash: "hash"}, nil)
+	passHasher.On("CheckPasswordHash", "password", "hash").Return(true, nil)
+	passHasher.On("NeedsRehash", "hash").Return(false)
+	devices.On("Admit", mock.Anything, int64(1), testDevice).Return(int64(0), device.ErrApprovalRequired)
+
+	_, _, _, err := s.Login(ctx, "login", "password", testDevice)
+
+	assert.ErrorIs(t, err, device.ErrApprovalRequired)
+	tokenManager.AssertNotCalled(t, "GenerateAccessToken", mock.Anything)
+	tokenRepo.AssertNotCalled(t, "Create")
+}
+
+func TestAuthService_Login_Binds_Refresh_Token_To_Device(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+	devices := new(mocks.DeviceServiceMock)
+
+	ctx := context.Background()
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, devices, nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	deviceID := int64(42)
+	userRepo.On("GetByLogin", mock.Anything, "login").Return(&user.UserRecord{ID: 1, Login: "login", PasswordHash: "hash"}, nil)
+	passHasher.On("CheckPasswordHash", "password", "hash").Return(true, nil)
+	passHasher.On("NeedsRehash", "hash").Return(false)
+	devices.On("Admit", mock.Anything, int64(1), testDevice).Return(deviceID, nil)
+	tokenManager.On("GenerateAccessToken", int64(1)).Return("accessToken", nil)
+	tokenManager.On("GenerateRefreshToken", int64(1)).Return("refreshToken", uuid.New(), time.Hour, nil)
+	tokenManager.On("Sha256Hex", "refreshToken").Return("hash")
+	tokenRepo.On("Create", mock.Anything, int64(1), &deviceID, mock.Anything, "hash", mock.Anything, mock.Anything).Return(nil)
+
+	_, _, _, err := s.Login(ctx, "login", "password", testDevice)
+
+	assert.NoError(t, err)
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestAuthService_RegisterSilently(t *testing.T) {
+	t.Run("taken login is reported as success", func(t *testing.T) {
+		userRepo := new(mocks.UserRepositoryMock)
+		tokenRepo := new(mocks.TokenRepositoryMock)
+		passHasher := new(mocks.PasswordHasherMock)
+		tokenManager := new(mocks.TokenManagerMock)
+
+		s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+		passHasher.On("HashPassword", "password").Return("hash", nil)
+		userRepo.On("Create", mock.Anything, "login", "hash", "").Return(0, user.ErrDuplicateLogin)
+
+		err := s.RegisterSilently(context.Background(), "login", "password", "", "", testDevice)
+
+		assert.NoError(t, err)
+		userRepo.AssertExpectations(t)
+		tokenManager.AssertNotCalled(t, "GenerateAccessToken", mock.Anything)
+	})
+
+	t.Run("new account gets no tokens", func(t *testing.T) {
+		userRepo := new(mocks.UserRepositoryMock)
+		tokenRepo := new(mocks.TokenRepositoryMock)
+		passHasher := new(mocks.PasswordHasherMock)
+		tokenManager := new(mocks.TokenManagerMock)
+		devices := allowDevices()
+
+		s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, devices, nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+		passHasher.On("HashPassword", "password").Return("hash", nil)
+		userRepo.On("Create", mock.Anything, "login", "hash", "").Return(1, nil)
+
+		err := s.RegisterSilently(context.Background(), "login", "password", "", "", testDevice)
+
+		assert.NoError(t, err)
+		devices.AssertCalled(t, "Enroll", mock.Anything, int64(1), testDevice)
+		tokenManager.AssertNotCalled(t, "GenerateAccessToken", mock.Anything)
+		tokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("validation errors are returned", func(t *testing.T) {
+		s := NewAuthService(new(mocks.UserRepositoryMock), new(mocks.TokenRepositoryMock), new(mocks.PasswordHasherMock), new(mocks.TokenManagerMock), nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+		err := s.RegisterSilently(context.Background(), "", "password", "", "", testDevice)
+
+		var ve *apperr.ValidationError
+		assert.ErrorAs(t, err, &ve)
+	})
+}
+
+func TestAuthService_Login_UnknownLogin_ChecksDummyHash(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+
+	s := NewAuthService(userRepo, new(mocks.TokenRepositoryMock), passHasher, new(mocks.TokenManagerMock), nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	userRepo.On("GetByLogin", mock.Anything, "ghost").Return((*user.UserRecord)(nil), user.ErrUserNotFound)
+	passHasher.On("HashPassword", mock.Anything).Return("dummy", nil).Once()
+	passHasher.On("CheckPasswordHash", "password", "dummy").Return(false, nil)
+
+	for i := 0; i < 2; i++ {
+		_, _, _, err := s.Login(context.Background(), "ghost", "password", testDevice)
+		assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	}
+
+	// the dummy hash is made once and reused
+	passHasher.AssertNumberOfCalls(t, "HashPassword", 1)
+	passHasher.AssertNumberOfCalls(t, "CheckPasswordHash", 2)
+}
+
+func TestAuthService_Login_UnknownLogin_Like_Existing(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+
+	now := time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)
+	s := NewAuthService(userRepo, new(mocks.TokenRepositoryMock), passHasher, new(mocks.TokenManagerMock), nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{Threshold: 5, BaseDuration: time.Minute, MaxDuration: time.Hour}, user.PasswordPolicy{})
+	s.now = func() time.Time { return now }
+
+	userRepo.On("GetByLogin", mock.Anything, "alice").Return(&user.UserRecord{ID: 1, Login: "alice", PasswordHash: "hash"}, nil)
+	userRepo.On("GetByLogin", mock.Anything, "ghost").Return((*user.UserRecord)(nil), user.ErrUserNotFound)
+	userRepo.On("IncrementLoginFailures", mock.Anything, "alice", now).Return(int64(1), 1, 0, nil)
+	userRepo.On("IncrementLoginFailures", mock.Anything, "ghost", now).Return(int64(0), 0, 0, user.ErrUserNotFound)
+	passHasher.On("HashPassword", mock.Anything).Return("dummy", nil)
+	passHasher.On("CheckPasswordHash", "wrong", "hash").Return(false, nil)
+	passHasher.On("CheckPasswordHash", "wrong", "dummy").Return(false, nil)
+
+	_, _, _, existingErr := s.Login(context.Background(), "alice", "wrong", testDevice)
+	_, _, _, unknownErr := s.Login(context.Background(), "ghost", "wrong", testDevice)
+
+	// both logins get the same error after a password verification and a
+	// failure count each
+	assert.ErrorIs(t, existingErr, user.ErrInvalidCredentials)
+	assert.Equal(t, existingErr, unknownErr)
+	passHasher.AssertCalled(t, "CheckPasswordHash", "wrong", "hash")
+	passHasher.AssertCalled(t, "CheckPasswordHash", "wrong", "dummy")
+	userRepo.AssertExpectations(t)
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/device"
+	"math/big"
+	"time"
+)
+
+// approvalCodeDigits is the length of a one-time device approval code.
+const approvalCodeDigits = 6
+
+// DeviceCodeSender delivers one-time device approval codes to the user.
+type DeviceCodeSender interface {
+	SendDeviceCode(ctx context.Context, userID int64, d *device.Device, code string) error
+}
+
+// DeviceGate decides whether a client device may receive tokens. It is used
+// by AuthService on registration and login.
+type DeviceGate interface {
+	Prove(ctx context.Context, ident device.Identity) error
+	Enroll(ctx context.Context, userID int64, ident device.Identity) (deviceID int64, err error)
+	Admit(ctx context.Context, userID int64, ident device.Identity) (deviceID int64, err error)
+}
+
+type IDeviceService interface {
+	DeviceGate
+	Challenge(ctx context.Context, deviceUUID string) (nonce string, expiresAt time.Time, err error)
+	List(ctx context.Context, userID int64) ([]*device.Device, error)
+	Approve(ctx context.Context, userID int64, deviceUUID string) error
+	Remove(ctx context.Context, userID int64, deviceUUID string) error
+}
+
+// DeviceService keeps track of the devices of a user and approves new ones.
+//
+// A device proves that it holds the private key of its identity by signing a
+// single-use challenge issued by Challenge; the device ID and public key
+// alone are not secret enough to be trusted.
+//
+// The first device of a user is trusted automatically. A login from any other
+// unknown device stores it as pending and sends a one-time code; the device
+// becomes trusted once it logs in again with the code or is approved from a
+// trusted session.
+type DeviceService struct {
+	repo   device.DeviceRepository
+	sender DeviceCodeSender
+	now    func() time.Time
+}
+
+// NewDeviceService constructs a new DeviceService with given dependencies.
+func NewDeviceService(repo device.DeviceRepository, sender DeviceCodeSender) DeviceService {
+	return DeviceService{
+		repo:   repo,
+		sender: sender,
+		now:    time.Now,
+	}
+}
+
+// Challenge issues a single-use nonce for the device deviceUUID, valid for
+// device.ChallengeTTL. The device signs it to log in or register. Only the
+// hash of the nonce is stored, and a new challenge replaces the previous one.
+//
+// Returns device.ErrIDEmpty if deviceUUID is not a UUID.
+func (s *DeviceService) Challenge(ctx context.Context, deviceUUID string) (string, time.Time, error) {
+	id, err := uuid.Parse(deviceUUID)
+	if err != nil || id == uuid.Nil {
+		return "", time.Time{}, device.ErrIDEmpty
+	}
+
+	raw := make([]byte, device.ChallengeBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", time.Time{}, err
+	}
+	nonce := base64.RawURLEncoding.EncodeToString(raw)
+
+	expiresAt := s.now().UTC().Add(device.ChallengeTTL)
+	if err := s.repo.CreateChallenge(ctx, id, hashSecretToken(nonce), expiresAt); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return nonce, expiresAt, nil
+}
+
+// Prove checks that ident answers its challenge: the nonce must be the
+// unexpired one issued for ident.ID, and the signature must verify with
+// ident.PublicKey. The challenge is used up either way.
+//
+// Returns device.ErrInvalidProof otherwise.
+func (s *DeviceService) Prove(ctx context.Context, ident device.Identity) error {
+	if ident.Nonce == "" || ident.Signature == "" {
+		return device.ErrInvalidProof
+	}
+
+	ok, err := s.repo.ConsumeChallenge(ctx, ident.ID, hashSecretToken(ident.Nonce), s.now().UTC())
+	if err != nil {
+		return err
+	}
+	if !ok || !device.VerifyProof(ident) {
+		return device.ErrInvalidProof
+	}
+
+	return nil
+}
+
+// Enroll registers the device a new account was created from as trusted.
+// ident must have been checked with Prove.
+//
+// Returns the ID of the stored device.
+func (s *DeviceService) Enroll(ctx context.Context, userID int64, ident device.Identity) (int64, error) {
+	if err := device.ValidateIdentity(ident); err != nil {
+		return 0, err
+	}
+
+	d, err := s.repo.Create(ctx, userID, ident, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return d.ID, nil
+}
+
+// Admit checks the device of a user who has just proven their password.
+//
+// The device must prove it holds its key, see Prove; a known device must
+// do so with the public key it was registered with, otherwise it is
+// rejected with device.ErrKeyMismatch. Trusted devices are then admitted
+// right away. For an unknown or pending device without a code a new
+// one-time code is sent and device.ErrApprovalRequired is returned; with a
+// valid code the device becomes trusted.
+//
+// Returns the ID of the admitted device.
+func (s *DeviceService) Admit(ctx context.Context, userID int64, ident device.Identity) (int64, error) {
+	if err := device.ValidateIdentity(ident); err != nil {
+		return 0, err
+	}
+
+	d, err := s.repo.Get(ctx, userID, ident.ID)
+	if err != nil && !errors.Is(err, device.ErrNotFound) {
+		return 0, err
+	}
+	if d != nil && subtle.ConstantTimeCompare([]byte(d.PublicKey), []byte(ident.PublicKey)) != 1 {
+		return 0, device.ErrKeyMismatch
+	}
+
+	if err := s.Prove(ctx, ident); err != nil {
+		return 0, err
+	}
+
+	if d == nil {
+		trusted, err := s.repo.CountTrusted(ctx, userID)
+		if err != nil {
+			return 0, err
+		}
+		d, err = s.repo.Create(ctx, userID, ident, trusted == 0)
+		if err != nil {
+			return 0, err
+		}
+		// a concurrent login may have stored the device with another key
+		if subtle.ConstantTimeCompare([]byte(d.PublicKey), []byte(ident.PublicKey)) != 1 {
+			return 0, device.ErrKeyMismatch
+		}
+	}
+
+	now := s.now().UTC()
+
+	if !d.Trusted {
+		if ident.ApprovalCode == "" {
+			if err := s.sendApprovalCode(ctx, d, now); err != nil {
+				return 0, err
+			}
+			return 0, device.ErrApprovalRequired
+		}
+
+		if !approvalCodeValid(d, ident.ApprovalCode, now) {
+			return 0, device.ErrInvalidCode
+		}
+		if err := s.repo.Approve(ctx, userID, d.DeviceUUID); err != nil {
+			return 0, err
+		}
+	}
+
+	// A failed update only loses usage information, it must not reject the login.
+	_ = s.repo.TouchLastSeen(ctx, d.ID, now)
+
+	return d.ID, nil
+}
+
+// List returns every device of the user.
+func (s *DeviceService) List(ctx context.Context, userID int64) ([]*device.Device, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// Approve marks a pending device of the user as trusted.
+//
+// Returns device.ErrNotFound if the user has no such pending device.
+func (s *DeviceService) Approve(ctx context.Context, userID int64, deviceUUID string) error {
+	id, err := uuid.Parse(deviceUUID)
+	if err != nil {
+		return device.ErrNotFound
+	}
+
+	return s.repo.Approve(ctx, userID, id)
+}
+
+// Remove deletes a device of the user. Its refresh tokens are deleted with
+// it, so the device is signed out once its current access token expires.
+//
+// Returns device.ErrNotFound if the user has no such device.
+func (s *DeviceService) Remove(ctx context.Context, userID int64, deviceUUID string) error {
+	id, err := uuid.Parse(deviceUUID)
+	if err != nil {
+		return device.ErrNotFound
+	}
+
+	return s.repo.Delete(ctx, userID, id)
+}
+
+// sendApprovalCode generates a new one-time code for a pending device, stores
+// its hash and delivers it to the user.
+func (s *DeviceService) sendApprovalCode(ctx context.Context, d *device.Device, now time.Time) error {
+	code, err := generateApprovalCode()
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.SetApprovalCode(ctx, d.ID, hashSecretToken(code), now.Add(device.ApprovalCodeTTL)); err != nil {
+		return err
+	}
+
+	return s.sender.SendDeviceCode(ctx, d.UserID, d, code)
+}
+
+// approvalCodeValid reports whether code is the current, unexpired approval code of the device.
+func approvalCodeValid(d *device.Device, code string, now time.Time) bool {
+	if d.ApprovalCodeHash == "" || d.ApprovalExpiresAt == nil || !now.Before(*d.ApprovalExpiresAt) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(d.ApprovalCodeHash), []byte(hashSecretToken(code))) == 1
+}
+
+// generateApprovalCode returns a random numeric code of approvalCodeDigits digits.
+func generateApprovalCode() (string, error) {
+	limit := big.NewInt(1)
+	for i := 0; i < approvalCodeDigits; i++ {
+		limit.Mul(limit, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", approvalCodeDigits, n), nil
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/device"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"testing"
+	"time"
+)
+
+func newTestDeviceService(repo *mocks.DeviceRepositoryMock, sender *mocks.DeviceCodeSenderMock, now time.Time) DeviceService {
+	s := NewDeviceService(repo, sender)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+// testDeviceKey is the private key of the identities built by provenDevice.
+var testDeviceKey = ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+
+// provenDevice returns the identity of testDevice held by testDeviceKey,
+// answering the challenge nonce.
+func provenDevice(nonce string) device.Identity {
+	ident := testDevice
+	ident.PublicKey = base64.StdEncoding.EncodeToString(testDeviceKey.Public().(ed25519.PublicKey))
+	ident.Nonce = nonce
+	ident.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(testDeviceKey, device.ProofMessage(ident.ID, nonce)))
+	return ident
+}
+
+func TestDeviceService_Challenge(t *testing.T) {
+	repo := new(mocks.DeviceRepositoryMock)
+	now := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	s := newTestDeviceService(repo, new(mocks.DeviceCodeSenderMock), now)
+
+	ctx := context.Background()
+	var hash string
+	repo.On("CreateChallenge", ctx, testDevice.ID, mock.AnythingOfType("string"), now.Add(device.ChallengeTTL)).
+		Run(func(args mock.Arguments) { hash = args.String(2) }).
+		Return(nil)
+
+	nonce, expiresAt, err := s.Challenge(ctx, testDevice.ID.String())
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, nonce)
+	assert.Equal(t, now.Add(device.ChallengeTTL), expiresAt)
+	assert.Equal(t, hashSecretToken(nonce), hash)
+
+	_, _, err = s.Challenge(ctx, "bad")
+	assert.ErrorIs(t, err, device.ErrIDEmpty)
+}
+
+func TestDeviceService_Prove(t *testing.T) {
+	repo := new(mocks.DeviceRepositoryMock)
+	now := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	s := newTestDeviceService(repo, new(mocks.DeviceCodeSenderMock), now)
+
+	ctx := context.Background()
+	repo.On("ConsumeChallenge", ctx, testDevice.ID, hashSecretToken("issued"), now).Return(true, nil)
+	repo.On("ConsumeChallenge", ctx, testDevice.ID, hashSecretToken("unknown"), now).Return(false, nil)
+
+	assert.NoError(t, s.Prove(ctx, provenDevice("issued")))
+
+	// a nonce that was never issued, or already used, is rejected
+	assert.ErrorIs(t, s.Prove(ctx, provenDevice("unknown")), device.ErrInvalidProof)
+
+	// a signature made with another key is rejected
+	forged := provenDevice("issued")
+	forged.PublicKey = testDevice.PublicKey
+	assert.ErrorIs(t, s.Prove(ctx, forged), device.ErrInvalidProof)
+
+	// a signature over another nonce is rejected
+	swapped := provenDevice("issued")
+	swapped.Signature = provenDevice("other").Signature
+	assert.ErrorIs(t, s.Prove(ctx, swapped), device.ErrInvalidProof)
+
+	// without an answer the repository is not asked at all
+	unsigned := provenDevice("issued")
+	unsigned.Signature = ""
+	assert.ErrorIs(t, s.Prove(ctx, unsigned), device.ErrInvalidProof)
+	repo.AssertNumberOfCalls(t, "ConsumeChallenge", 4)
+}
+
+func TestDeviceService_Admit_First_Device_Is_Trusted(t *testing.T) {
+	repo := new(mocks.DeviceRepositoryMock)
+	now := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	s := newTestDeviceService(repo, new(mocks.DeviceCodeSenderMock), now)
+
+	ctx := context.Background()
+	ident := provenDevice("nonce")
+	stored := &device.Device{ID: 5, DeviceUUID: ident.ID, UserID: 1, PublicKey: ident.PublicKey, Trusted: true}
+
+	repo.On("Get", ctx, int64(1), ident.ID).Return(nil, device.ErrNotFound)
+	repo.On("ConsumeChallenge", ctx, ident.ID, hashSecretToken("nonce"), now).Return(true, nil)
+	repo.On("CountTrusted", ctx, int64(1)).Return(0, nil)
+	repo.On("Create", ctx, int64(1), ident, true).Return(stored, nil)
+	repo.On("TouchLastSeen", ctx, int64(5), now).Return(nil)
+
+	id, err := s.Admit(ctx, 1, ident)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), id)
+	repo.AssertExpectations(t)
+}
+
+func TestDeviceService_Admit_Without_Proof(t *testing.T) {
+	repo := new(mocks.DeviceRepositoryMock)
+	now := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	s := newTestDeviceService(repo, new(mocks.DeviceCodeSenderMock), now)
+
+	ctx := context.Background()
+	ident := provenDevice("nonce")
+	stored := &device.Device{ID: 5, DeviceUUID: ident.ID, UserID: 1, PublicKey: ident.PublicKey, Trusted: true}
+
+	repo.On("Get", ctx, int64(1), ident.ID).Return(stored, nil)
+	repo.On("ConsumeChallenge", ctx, ident.ID, hashSecretToken("nonce"), now).Return(false, nil)
+
+	// knowing the device ID and public key of a trusted device is not enough
+	_, err := s.Admit(ctx, 1, ident)
+
+	assert.ErrorIs(t, err, device.ErrInvalidProof)
+	repo.AssertNotCalled(t, "TouchLastSeen", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeviceService_Admit_Unknown_Device_Requires_Approval(t *testing.T) {
+	repo := new(mocks.DeviceRepositoryMock)
+	sender := new(mocks.DeviceCodeSenderMock)
+	now := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	s := newTestDeviceService(repo, sender, now)
+
+	ctx := context.Background()
+	ident := provenDevice("nonce")
+	pending := &device.Device{ID: 6, DeviceUUID: ident.ID, UserID: 1, PublicKey: ident.PublicKey}
+
+	var code string
+	repo.On("Get", ctx, int64(1), ident.ID).Return(nil, device.ErrNotFound)
+	repo.On("ConsumeChallenge", ctx, ident.ID, hashSecretToken("nonce"), now).Return(true, nil)
+	repo.On("CountTrusted", ctx, int64(1)).Return(1, nil)
+	repo.On("Create", ctx, int64(1), ident, false).Return(pending, nil)
+	repo.On("SetApprovalCode", ctx, int64(6), mock.AnythingOfType("string"), now.Add(device.ApprovalCodeTTL)).Return(nil)
+	sender.On("SendDeviceCode", ctx, int64(1), pending, mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { code = args.String(3) }).
+		Return(nil)
+
+	_, err := s.Admit(ctx, 1, ident)
+
+	assert.ErrorIs(t, err, device.ErrApprovalRequired)
+	assert.Len(t, code, approvalCodeDigits)
+	repo.AssertCalled(t, "SetApprovalCode", ctx, int64(6), hashSecretToken(code), now.Add(device.ApprovalCodeTTL))
+}
+
+func TestDeviceService_Admit_With_Code(t *testing.T) {
+	repo := new(mocks.DeviceRepositoryMock)
+	now := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	s := newTestDeviceService(repo, new(mocks.DeviceCodeSenderMock), now)
+
+	ctx := context.Background()
+	ident := provenDevice("nonce")
+	expiresAt := now.Add(time.Minute)
+	pending := &device.Device{ID: 6, DeviceUUID: ident.ID, UserID: 1, PublicKey: ident.PublicKey, ApprovalCodeHash: hashSecretToken("123456"), ApprovalExpiresAt: &expiresAt}
+
+	repo.On("Get", ctx, int64(1), ident.ID).Return(pending, nil)
+	repo.On("ConsumeChallenge", ctx, ident.ID, hashSecretToken("nonce"), mock.Anything).Return(true, nil)
+	repo.On("Approve", ctx, int64(1), ident.ID).Return(nil)
+	repo.On("TouchLastSeen", ctx, int64(6), now).Return(nil)
+
+	withCode := ident
+	withCode.ApprovalCode = "654321"
+	_, err := s.Admit(ctx, 1, withCode)
+	assert.ErrorIs(t, err, device.ErrInvalidCode)
+
+	withCode.ApprovalCode = "123456"
+	id, err := s.Admit(ctx, 1, withCode)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), id)
+	repo.AssertExpectations(t)
+
+	// an expired code is rejected
+	s.now = func() time.Time { return expiresAt }
+	_, err = s.Admit(ctx, 1, withCode)
+	assert.ErrorIs(t, err, device.ErrInvalidCode)
+}
+
+func TestDeviceService_Admit_Key_Mismatch(t *testing.T) {
+	repo := new(mocks.DeviceRepositoryMock)
+	s := newTestDeviceService(repo, new(mocks.DeviceCodeSenderMock), time.Now())
+
+	ctx := context.Background()
+	ident := provenDevice("nonce")
+	stored := &device.Device{ID: 5, DeviceUUID: ident.ID, UserID: 1, PublicKey: testDevice.PublicKey, Trusted: true}
+	repo.On("Get", ctx, int64(1), ident.ID).Return(stored, nil)
+
+	_, err := s.Admit(ctx, 1, ident)
+
+	assert.ErrorIs(t, err, device.ErrKeyMismatch)
+	repo.AssertNotCalled(t, "ConsumeChallenge", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "TouchLastSeen", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeviceService_Admit_Validate_Error(t *testing.T) {
+	s := newTestDeviceService(new(mocks.DeviceRepositoryMock), new(mocks.DeviceCodeSenderMock), time.Now())
+
+	_, err := s.Admit(context.Background(), 1, device.Identity{PublicKey: testDevice.PublicKey})
+	assert.ErrorIs(t, err, device.ErrIDEmpty)
+
+	_, err = s.Admit(context.Background(), 1, device.Identity{ID: uuid.New(), PublicKey: "short"})
+	assert.ErrorIs(t, err, device.ErrInvalidPublicKey)
+}
+
+func TestDeviceService_Remove(t *testing.T) {
+	repo := new(mocks.DeviceRepositoryMock)
+	s := newTestDeviceService(repo, new(mocks.DeviceCodeSenderMock), time.Now())
+
+	ctx := context.Background()
+	repo.On("Delete", ctx, int64(1), testDevice.ID).Return(nil)
+
+	assert.NoError(t, s.Remove(ctx, 1, testDevice.ID.String()))
+	assert.ErrorIs(t, s.Remove(ctx, 1, "bad"), device.ErrNotFound)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/thxhix/passKeeper/internal/domain/device"
+	"github.com/thxhix/passKeeper/internal/domain/token"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"time"
+)
+
+// Lifetimes of the tokens mailed to users.
+const (
+	emailVerificationTTL = 24 * time.Hour
+	passwordResetTTL     = 30 * time.Minute
+)
+
+// Purposes of the action tokens issued by EmailService.
+const (
+	purposeVerifyEmail   = "verify-email"
+	purposeResetPassword = "reset-password"
+)
+
+// Mailer sends a plain text email to a single recipient.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// ActionTokenSigner issues and verifies signed, expiring single-purpose tokens.
+// state is returned by Verify as it was passed to Sign.
+type ActionTokenSigner interface {
+	Sign(purpose string, userID int64, state string, expiresAt time.Time) (string, error)
+	Verify(token string, purpose string) (userID int64, state string, err error)
+}
+
+// EmailVerifier mails a verification token for a newly set email address.
+type EmailVerifier interface {
+	SendVerification(ctx context.Context, userID int64, email string) error
+}
+
+type IEmailService interface {
+	EmailVerifier
+	ChangeEmail(ctx context.Context, userID int64, email string) error
+	VerifyEmail(ctx context.Context, token string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, newPassword string, discardVaultKey bool) (vaultKeyDiscarded bool, err error)
+}
+
+// EmailService manages the email address of users: it verifies addresses,
+// lets users who forgot their password reset it through a mailed token and
+// delivers device approval codes.
+//
+// Tokens are signed and expiring rather than stored. A verification token is
+// bound to the address it was sent to and a reset token to the current
+// password hash, so a reset token stops working once it has been used.
+type EmailService struct {
+	userRepo  user.UserRepository
+	tokenRepo token.TokenRepository
+
+	hasher    PasswordHasher
+	passwords user.PasswordPolicy
+	revoker   AccessTokenRevoker
+	signer    ActionTokenSigner
+	mailer    Mailer
+
+	now func() time.Time
+}
+
+// NewEmailService constructs a new EmailService with given dependencies.
+func NewEmailService(userRepo user.UserRepository, tokenRepo token.TokenRepository, hasher PasswordHasher, passwords user.PasswordPolicy, revoker AccessTokenRevoker, signer ActionTokenSigner, mailer Mailer) EmailService {
+	return EmailService{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+
+		hasher:    hasher,
+		passwords: passwords,
+		revoker:   revoker,
+		signer:    signer,
+		mailer:    mailer,
+
+		now: time.Now,
+	}
+}
+
+// ChangeEmail sets a new, unverified email address for the user and mails a
+// verification token to it.
+//
+// Returns user.ErrInvalidEmail or user.ErrDuplicateEmail.
+func (s *EmailService) ChangeEmail(ctx context.Context, userID int64, email string) error {
+	email = user.NormalizeEmail(email)
+	if err := user.ValidateEmail(email); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.SetEmail(ctx, userID, email); err != nil {
+		return err
+	}
+
+	return s.SendVerification(ctx, userID, email)
+}
+
+// SendVerification mails a token proving that the user controls email.
+func (s *EmailService) SendVerification(ctx context.Context, userID int64, email string) error {
+	tkn, err := s.signer.Sign(purposeVerifyEmail, userID, email, s.now().Add(emailVerificationTTL))
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(verificationMailBody, tkn)
+
+	return s.mailer.Send(ctx, email, "Verify your passKeeper email", body)
+}
+
+// SendDeviceCode mails the approval code of the pending device d to the
+// verified address of the user. Users without one get no mail; they approve
+// the device from a trusted session instead.
+func (s *EmailService) SendDeviceCode(ctx context.Context, userID int64, d *device.Device, code string) error {
+	au, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if au.EmailVerifiedAt == nil {
+		return nil
+	}
+
+	name := d.Name
+	if name == "" {
+		name = d.DeviceUUID.String()
+	}
+	body := fmt.Sprintf(deviceCodeMailBody, name, device.Fingerprint(d.PublicKey), code, au.Login)
+
+	return s.mailer.Send(ctx, au.Email, "Approve a new passKeeper device", body)
+}
+
+// VerifyEmail marks the email a verification token was sent to as verified,
+// provided it is still the address of the user.
+//
+// Returns user.ErrInvalidEmailToken for invalid, expired or outdated tokens.
+func (s *EmailService) VerifyEmail(ctx context.Context, tkn string) error {
+	userID, email, err := s.signer.Verify(tkn, purposeVerifyEmail)
+	if err != nil {
+		return user.ErrInvalidEmailToken
+	}
+
+	if err := s.userRepo.MarkEmailVerified(ctx, userID, email, s.now()); err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return user.ErrInvalidEmailToken
+		}
+		return err
+	}
+
+	return nil
+}
+
+// RequestPasswordReset mails a password reset token to email if it is the
+// verified address of an enabled user.
+//
+// Unknown and unverified addresses are ignored without an error, so the
+// response does not reveal which addresses are registered. Users of
+// zero-knowledge clients are warned in the mail that the vault cannot be
+// recovered.
+func (s *EmailService) RequestPasswordReset(ctx context.Context, email string) error {
+	email = user.NormalizeEmail(email)
+	if err := user.ValidateEmail(email); err != nil {
+		return err
+	}
+
+	au, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if au.EmailVerifiedAt == nil || au.DisabledAt != nil {
+		return nil
+	}
+
+	tkn, err := s.signer.Sign(purposeResetPassword, au.ID, resetState(au), s.now().Add(passwordResetTTL))
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(resetMailBody, au.Login, tkn)
+	if au.KeyMaterial != nil {
+		body += zeroKnowledgeResetWarning
+	}
+
+	return s.mailer.Send(ctx, au.Email, "Reset your passKeeper password", body)
+}
+
+// ResetPassword sets a new password for the user a reset token was issued to.
+//
+// The stored key material is removed, because a zero-knowledge vault key
+// wrapped with the forgotten password cannot be re-wrapped. For such users
+// discardVaultKey must be set, otherwise user.ErrVaultKeyWillBeLost is
+// returned and nothing changes. All sessions of the user are ended and a
+// lockout is lifted.
+//
+// Returns whether a vault key was discarded, or user.ErrInvalidEmailToken for
+// invalid, expired or already used tokens.
+func (s *EmailService) ResetPassword(ctx context.Context, tkn string, newPassword string, discardVaultKey bool) (bool, error) {
+	userID, state, err := s.signer.Verify(tkn, purposeResetPassword)
+	if err != nil {
+		return false, user.ErrInvalidEmailToken
+	}
+
+	au, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return false, user.ErrInvalidEmailToken
+		}
+		return false, err
+	}
+
+	if state != resetState(au) {
+		return false, user.ErrInvalidEmailToken
+	}
+	if au.DisabledAt != nil {
+		return false, user.ErrAccountDisabled
+	}
+
+	if err := s.passwords.Check(au.Login, newPassword); err != nil {
+		return false, err
+	}
+
+	vaultKey := au.KeyMaterial != nil
+	if vaultKey && !discardVaultKey {
+		return false, user.ErrVaultKeyWillBeLost
+	}
+
+	passwordHash, err := s.hasher.HashPassword(newPassword)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.userRepo.ResetCredentials(ctx, userID, passwordHash); err != nil {
+		return false, err
+	}
+
+	if err := s.tokenRepo.RevokeAllByUser(ctx, userID); err != nil {
+		return false, err
+	}
+	if err := s.revoker.RevokeUser(ctx, userID); err != nil {
+		return false, err
+	}
+
+	if err := s.userRepo.ResetLoginFailures(ctx, userID); err != nil {
+		return false, err
+	}
+
+	return vaultKey, nil
+}
+
+// resetState binds a reset token to the current password hash of the user, so
+// it is rejected once the password has changed.
+func resetState(au *user.UserRecord) string {
+	return hashSecretToken(au.PasswordHash)
+}
+
+const verificationMailBody = `Confirm this address for your passKeeper account by running:
+
+  passKeeper verify-email %s
+
+The token expires in 24 hours. If you did not add this address, ignore this email.
+`
+
+const deviceCodeMailBody = `A new device %q (key fingerprint %s) is signing in to your passKeeper account. Approve it by running on that device:
+
+  passKeeper login --code %s %s <password>
+
+The code expires in 10 minutes. If this was not you, change your password; the device stays locked out without the code.
+`
+
+const resetMailBody = `A password reset was requested for your passKeeper account %q. Choose a new password by running:
+
+  passKeeper reset-password --token %s
+
+The token expires in 30 minutes and works once. If you did not request a reset, ignore this email; your password stays unchanged.
+`
+
+const zeroKnowledgeResetWarning = `
+WARNING: your vault is end-to-end encrypted with a key protected by your current password.
+A reset cannot recover that key: the secrets stored in your vault will become unreadable.
+Only reset your password if it is truly lost.
+`
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/device"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"testing"
+	"time"
+)
+
+type emailServiceDeps struct {
+	userRepo  *mocks.UserRepositoryMock
+	tokenRepo *mocks.TokenRepositoryMock
+	hasher    *mocks.PasswordHasherMock
+	revoker   *mocks.AccessTokenRevokerMock
+	signer    *mocks.ActionTokenSignerMock
+	mailer    *mocks.MailerMock
+}
+
+func newTestEmailService(now time.Time) (EmailService, emailServiceDeps) {
+	d := emailServiceDeps{
+		userRepo:  new(mocks.UserRepositoryMock),
+		tokenRepo: new(mocks.TokenRepositoryMock),
+		hasher:    new(mocks.PasswordHasherMock),
+		revoker:   new(mocks.AccessTokenRevokerMock),
+		signer:    new(mocks.ActionTokenSignerMock),
+		mailer:    new(mocks.MailerMock),
+	}
+
+	s := NewEmailService(d.userRepo, d.tokenRepo, d.hasher, user.PasswordPolicy{}, d.revoker, d.signer, d.mailer)
+	s.now = func() time.Time { return now }
+	return s, d
+}
+
+func TestEmailService_ChangeEmail(t *testing.T) {
+	now := time.Date(2025, 10, 29, 9, 0, 0, 0, time.UTC)
+	s, d := newTestEmailService(now)
+	ctx := context.Background()
+
+	d.userRepo.On("SetEmail", ctx, int64(1), "alice@example.com").Return(nil)
+	d.signer.On("Sign", purposeVerifyEmail, int64(1), "alice@example.com", now.Add(emailVerificationTTL)).Return("tkn", nil)
+	d.mailer.On("Send", ctx, "alice@example.com", mock.Anything, mock.MatchedBy(func(body string) bool {
+		return assert.Contains(t, body, "passKeeper verify-email tkn")
+	})).Return(nil)
+
+	assert.NoError(t, s.ChangeEmail(ctx, 1, " Alice@Example.com "))
+
+	d.userRepo.AssertExpectations(t)
+	d.mailer.AssertExpectations(t)
+}
+
+func TestEmailService_ChangeEmail_Invalid(t *testing.T) {
+	s, d := newTestEmailService(time.Now())
+	ctx := context.Background()
+
+	for _, email := range []string{"", "not-an-email", "Alice <alice@example.com>"} {
+		assert.ErrorIs(t, s.ChangeEmail(ctx, 1, email), user.ErrInvalidEmail, email)
+	}
+
+	d.userRepo.AssertNotCalled(t, "SetEmail")
+}
+
+func TestEmailService_VerifyEmail(t *testing.T) {
+	now := time.Now()
+	s, d := newTestEmailService(now)
+	ctx := context.Background()
+
+	d.signer.On("Verify", "good", purposeVerifyEmail).Return(int64(1), "alice@example.com", nil)
+	d.signer.On("Verify", "stale", purposeVerifyEmail).Return(int64(1), "old@example.com", nil)
+	d.signer.On("Verify", "bad", purposeVerifyEmail).Return(int64(0), "", errors.New("invalid"))
+	d.userRepo.On("MarkEmailVerified", ctx, int64(1), "alice@example.com", now).Return(nil)
+	d.userRepo.On("MarkEmailVerified", ctx, int64(1), "old@example.com", now).Return(user.ErrUserNotFound)
+
+	assert.NoError(t, s.VerifyEmail(ctx, "good"))
+	assert.ErrorIs(t, s.VerifyEmail(ctx, "stale"), user.ErrInvalidEmailToken)
+	assert.ErrorIs(t, s.VerifyEmail(ctx, "bad"), user.ErrInvalidEmailToken)
+}
+
+func TestEmailService_RequestPasswordReset(t *testing.T) {
+	now := time.Now()
+	verified := now.Add(-time.Hour)
+
+	cases := []struct {
+		name     string
+		record   *user.UserRecord
+		err      error
+		sent     bool
+		zkNotice bool
+	}{
+		{"unknown email", nil, user.ErrUserNotFound, false, false},
+		{"unverified email", &user.UserRecord{ID: 1, Email: "alice@example.com"}, nil, false, false},
+		{"verified email", &user.UserRecord{ID: 1, Login: "alice", Email: "alice@example.com", EmailVerifiedAt: &verified, PasswordHash: "hash"}, nil, true, false},
+		{"zero-knowledge account", &user.UserRecord{ID: 1, Login: "alice", Email: "alice@example.com", EmailVerifiedAt: &verified, PasswordHash: "hash", KeyMaterial: []byte{1}}, nil, true, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, d := newTestEmailService(now)
+			ctx := context.Background()
+
+			d.userRepo.On("GetByEmail", ctx, "alice@example.com").Return(tc.record, tc.err)
+			d.signer.On("Sign", purposeResetPassword, int64(1), hashSecretToken("hash"), now.Add(passwordResetTTL)).Return("tkn", nil)
+
+			var body string
+			d.mailer.On("Send", ctx, "alice@example.com", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { body = args.String(3) }).
+				Return(nil)
+
+			assert.NoError(t, s.RequestPasswordReset(ctx, "alice@example.com"))
+
+			if !tc.sent {
+				d.mailer.AssertNotCalled(t, "Send")
+				return
+			}
+			assert.Contains(t, body, "passKeeper reset-password --token tkn")
+			if tc.zkNotice {
+				assert.Contains(t, body, "WARNING")
+			} else {
+				assert.NotContains(t, body, "WARNING")
+			}
+		})
+	}
+}
+
+func TestEmailService_ResetPassword(t *testing.T) {
+	s, d := newTestEmailService(time.Now())
+	ctx := context.Background()
+
+	d.signer.On("Verify", "tkn", purposeResetPassword).Return(int64(1), hashSecretToken("old-hash"), nil)
+	d.userRepo.On("GetByID", ctx, int64(1)).Return(&user.UserRecord{ID: 1, Login: "alice", PasswordHash: "old-hash"}, nil)
+	d.hasher.On("HashPassword", "new-password").Return("new-hash", nil)
+	d.userRepo.On("ResetCredentials", ctx, int64(1), "new-hash").Return(nil)
+	d.tokenRepo.On("RevokeAllByUser", ctx, int64(1)).Return(nil)
+	d.revoker.On("RevokeUser", ctx, int64(1)).Return(nil)
+	d.userRepo.On("ResetLoginFailures", ctx, int64(1)).Return(nil)
+
+	discarded, err := s.ResetPassword(ctx, "tkn", "new-password", false)
+
+	assert.NoError(t, err)
+	assert.False(t, discarded)
+	d.userRepo.AssertExpectations(t)
+	d.tokenRepo.AssertExpectations(t)
+	d.revoker.AssertExpectations(t)
+}
+
+func TestEmailService_ResetPassword_UsedToken(t *testing.T) {
+	s, d := newTestEmailService(time.Now())
+	ctx := context.Background()
+
+	// the password changed since the token was issued
+	d.signer.On("Verify", "tkn", purposeResetPassword).Return(int64(1), hashSecretToken("old-hash"), nil)
+	d.userRepo.On("GetByID", ctx, int64(1)).Return(&user.UserRecord{ID: 1, Login: "alice", PasswordHash: "new-hash"}, nil)
+
+	_, err := s.ResetPassword(ctx, "tkn", "another-password", false)
+
+	assert.ErrorIs(t, err, user.ErrInvalidEmailToken)
+	d.userRepo.AssertNotCalled(t, "ResetCredentials")
+}
+
+func TestEmailService_ResetPassword_ZeroKnowledge(t *testing.T) {
+	s, d := newTestEmailService(time.Now())
+	ctx := context.Background()
+
+	d.signer.On("Verify", "tkn", purposeResetPassword).Return(int64(1), hashSecretToken("old-hash"), nil)
+	d.userRepo.On("GetByID", ctx, int64(1)).Return(&user.UserRecord{ID: 1, Login: "alice", PasswordHash: "old-hash", KeyMaterial: []byte{1}}, nil)
+
+	_, err := s.ResetPassword(ctx, "tkn", "new-password", false)
+	assert.ErrorIs(t, err, user.ErrVaultKeyWillBeLost)
+	d.userRepo.AssertNotCalled(t, "ResetCredentials")
+
+	d.hasher.On("HashPassword", "new-password").Return("new-hash", nil)
+	d.userRepo.On("ResetCredentials", ctx, int64(1), "new-hash").Return(nil)
+	d.tokenRepo.On("RevokeAllByUser", ctx, int64(1)).Return(nil)
+	d.revoker.On("RevokeUser", ctx, int64(1)).Return(nil)
+	d.userRepo.On("ResetLoginFailures", ctx, int64(1)).Return(nil)
+
+	discarded, err := s.ResetPassword(ctx, "tkn", "new-password", true)
+	assert.NoError(t, err)
+	assert.True(t, discarded)
+}
+
+func TestAuthService_Register_SendsVerification(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	tokenRepo := new(mocks.TokenRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	tokenManager := new(mocks.TokenManagerMock)
+	emails := new(mocks.EmailServiceMock)
+
+	ctx := context.Background()
+
+	s := NewAuthService(userRepo, tokenRepo, passHasher, tokenManager, nil, allowDevices(), nopAuditor(), nopPublisher(), nil, emails, LockoutPolicy{}, user.PasswordPolicy{})
+
+	passHasher.On("HashPassword", "password").Return("hash", nil)
+	userRepo.On("Create", ctx, "login", "hash", "alice@example.com").Return(1, nil)
+	tokenManager.On("GenerateAccessToken", mock.Anything).Return("accessToken", nil)
+	tokenManager.On("GenerateRefreshToken", mock.Anything).Return("refreshToken", uuid.New(), time.Duration(1), nil)
+	tokenManager.On("Sha256Hex", mock.Anything).Return("hash")
+	tokenRepo.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	emails.On("SendVerification", ctx, int64(1), "alice@example.com").Return(errors.New("smtp down"))
+
+	_, _, _, err := s.Register(ctx, "login", "password", "Alice@example.com", "", testDevice)
+
+	assert.NoError(t, err)
+	emails.AssertExpectations(t)
+
+	_, _, _, err = s.Register(ctx, "login", "password", "nope", "", testDevice)
+	assert.ErrorIs(t, err, user.ErrInvalidEmail)
+}
+
+func TestEmailService_SendDeviceCode(t *testing.T) {
+	s, d := newTestEmailService(time.Now())
+	ctx := context.Background()
+
+	verifiedAt := time.Now()
+	pending := &device.Device{DeviceUUID: uuid.New(), Name: "laptop", PublicKey: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="}
+	d.userRepo.On("GetByID", ctx, int64(1)).Return(&user.UserRecord{ID: 1, Login: "alice", Email: "alice@example.com", EmailVerifiedAt: &verifiedAt}, nil)
+	d.userRepo.On("GetByID", ctx, int64(2)).Return(&user.UserRecord{ID: 2, Login: "bob", Email: "bob@example.com"}, nil)
+	d.mailer.On("Send", ctx, "alice@example.com", mock.Anything, mock.MatchedBy(func(body string) bool {
+		return assert.Contains(t, body, "passKeeper login --code 123456 alice") &&
+			assert.Contains(t, body, device.Fingerprint(pending.PublicKey))
+	})).Return(nil)
+
+	assert.NoError(t, s.SendDeviceCode(ctx, 1, pending, "123456"))
+
+	// an unverified address gets no code
+	assert.NoError(t, s.SendDeviceCode(ctx, 2, pending, "123456"))
+	d.mailer.AssertNumberOfCalls(t, "Send", 1)
+}
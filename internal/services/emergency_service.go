@@ -0,0 +1,412 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/emergency"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/principal"
+	"github.com/thxhix/passKeeper/internal/domain/token"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
+	"time"
+)
+
+// KeyReader reads the personal entries of a user on behalf of the principal
+// stored in ctx.
+type KeyReader interface {
+	GetKeys(ctx context.Context, userID int64, keyType *keychain.KeyType) ([]*keychain.KeyRecord, error)
+	GetKey(ctx context.Context, userID int64, keyUUID string) (*keychain.KeyRecord, []byte, error)
+}
+
+type IEmergencyService interface {
+	Nominate(ctx context.Context, grantorID int64, login string, access string, wait time.Duration) (*emergency.Contact, error)
+	Contacts(ctx context.Context, grantorID int64) ([]*emergency.Contact, error)
+	Approve(ctx context.Context, grantorID int64, contactUUID string) (*emergency.Contact, error)
+	Reject(ctx context.Context, grantorID int64, contactUUID string) (*emergency.Contact, error)
+
+	Grantors(ctx context.Context, granteeID int64) ([]*emergency.Contact, error)
+	Request(ctx context.Context, granteeID int64, contactUUID string) (*emergency.Contact, error)
+	Keys(ctx context.Context, granteeID int64, contactUUID string, keyType *keychain.KeyType) ([]*keychain.KeyRecord, error)
+	Key(ctx context.Context, granteeID int64, contactUUID string, keyUUID string) (*keychain.KeyRecord, []byte, error)
+	Takeover(ctx context.Context, granteeID int64, contactUUID string, newPassword string, discardVaultKey bool) (vaultKeyDiscarded bool, err error)
+
+	Remove(ctx context.Context, userID int64, contactUUID string) error
+}
+
+// EmergencyService lets users name trusted contacts who can get access to
+// their personal entries when the user cannot act anymore.
+//
+// A contact requests access, which is granted automatically once the waiting
+// period of the contact has passed. Until then the grantor is notified by
+// webhook and mail and can approve the request early or reject it. Granted
+// view access reads the personal entries of the grantor; takeover access can
+// additionally set a new password for the account.
+type EmergencyService struct {
+	repo      emergency.ContactRepository
+	userRepo  user.UserRepository
+	tokenRepo token.TokenRepository
+	keys      KeyReader
+
+	hasher    PasswordHasher
+	passwords user.PasswordPolicy
+	revoker   AccessTokenRevoker
+	auditor   AuditRecorder
+	webhooks  WebhookPublisher
+	mailer    Mailer
+
+	now func() time.Time
+}
+
+// NewEmergencyService constructs a new EmergencyService with given dependencies.
+func NewEmergencyService(repo emergency.ContactRepository, userRepo user.UserRepository, tokenRepo token.TokenRepository, keys KeyReader, hasher PasswordHasher, passwords user.PasswordPolicy, revoker AccessTokenRevoker, auditor AuditRecorder, webhooks WebhookPublisher, mailer Mailer) EmergencyService {
+	return EmergencyService{
+		repo:      repo,
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		keys:      keys,
+
+		hasher:    hasher,
+		passwords: passwords,
+		revoker:   revoker,
+		auditor:   auditor,
+		webhooks:  webhooks,
+		mailer:    mailer,
+
+		now: time.Now,
+	}
+}
+
+// Nominate names the user with the given login as emergency contact of the
+// grantor, or changes the access and waiting period of an existing contact.
+// A zero wait uses emergency.DefaultWaitPeriod.
+func (s *EmergencyService) Nominate(ctx context.Context, grantorID int64, login string, access string, wait time.Duration) (*emergency.Contact, error) {
+	acc, ok := emergency.ParseAccess(access)
+	if !ok {
+		return nil, emergency.ErrInvalidAccess
+	}
+
+	if wait == 0 {
+		wait = emergency.DefaultWaitPeriod
+	}
+	if err := emergency.ValidateWaitPeriod(wait); err != nil {
+		return nil, err
+	}
+
+	grantee, err := s.userRepo.GetByLogin(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	if grantee.ID == grantorID {
+		return nil, emergency.ErrContactWithSelf
+	}
+
+	list, err := s.repo.ListByGrantor(ctx, grantorID)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) >= emergency.MaxContacts && !hasGrantee(list, grantee.ID) {
+		return nil, emergency.ErrTooManyContacts
+	}
+
+	return s.repo.Upsert(ctx, &emergency.Contact{
+		GrantorID:  grantorID,
+		GranteeID:  grantee.ID,
+		Access:     acc,
+		WaitPeriod: wait,
+	})
+}
+
+// Contacts returns the emergency contacts named by the grantor.
+func (s *EmergencyService) Contacts(ctx context.Context, grantorID int64) ([]*emergency.Contact, error) {
+	return s.repo.ListByGrantor(ctx, grantorID)
+}
+
+// Approve grants a pending request of a contact of the grantor before its
+// waiting period has passed.
+func (s *EmergencyService) Approve(ctx context.Context, grantorID int64, contactUUID string) (*emergency.Contact, error) {
+	c, err := s.contact(ctx, grantorID, contactUUID)
+	if err != nil {
+		return nil, err
+	}
+	if c.GrantorID != grantorID {
+		return nil, emergency.ErrNotGrantor
+	}
+	if c.Status != emergency.StatusRequested {
+		return nil, emergency.ErrNoPendingAccess
+	}
+
+	c, err = s.repo.UpdateStatus(ctx, c.ID, c.Status, emergency.StatusApproved, c.RequestedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, c.GranteeID, webhook.EventEmergencyApproved, c, "Emergency access approved",
+		fmt.Sprintf(emergencyApprovedMailBody, c.GrantorLogin, c.ContactUUID))
+
+	return c, nil
+}
+
+// Reject rejects a pending request of a contact of the grantor, or withdraws
+// access that was already granted. The contact may request access again.
+func (s *EmergencyService) Reject(ctx context.Context, grantorID int64, contactUUID string) (*emergency.Contact, error) {
+	c, err := s.contact(ctx, grantorID, contactUUID)
+	if err != nil {
+		return nil, err
+	}
+	if c.GrantorID != grantorID {
+		return nil, emergency.ErrNotGrantor
+	}
+	if c.Status != emergency.StatusRequested && c.Status != emergency.StatusApproved {
+		return nil, emergency.ErrNoPendingAccess
+	}
+
+	c, err = s.repo.UpdateStatus(ctx, c.ID, c.Status, emergency.StatusRejected, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, c.GranteeID, webhook.EventEmergencyRejected, c, "Emergency access rejected",
+		fmt.Sprintf(emergencyRejectedMailBody, c.GrantorLogin))
+
+	return c, nil
+}
+
+// Grantors returns the contacts the user was named as.
+func (s *EmergencyService) Grantors(ctx context.Context, granteeID int64) ([]*emergency.Contact, error) {
+	return s.repo.ListByGrantee(ctx, granteeID)
+}
+
+// Request starts the waiting period of a contact the user was named as. The
+// grantor is notified and can approve or reject the request until it passes.
+func (s *EmergencyService) Request(ctx context.Context, granteeID int64, contactUUID string) (c *emergency.Contact, err error) {
+	c, err = s.contact(ctx, granteeID, contactUUID)
+	if err != nil {
+		return nil, err
+	}
+	if c.GranteeID != granteeID {
+		return nil, emergency.ErrNotGrantee
+	}
+
+	grantorID := c.GrantorID
+	defer func() {
+		err = recordAudit(ctx, s.auditor, audit.Event{
+			UserID: grantorID,
+			Action: audit.ActionEmergencyRequest,
+		}, err)
+		if err != nil {
+			c = nil
+		}
+	}()
+
+	if c.Status == emergency.StatusRequested || c.Status == emergency.StatusApproved {
+		return nil, emergency.ErrAlreadyPending
+	}
+
+	now := s.now().UTC()
+	c, err = s.repo.UpdateStatus(ctx, c.ID, c.Status, emergency.StatusRequested, &now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, c.GrantorID, webhook.EventEmergencyRequested, c, "Emergency access requested",
+		fmt.Sprintf(emergencyRequestedMailBody, c.GranteeLogin, c.Access, c.GrantedAt().Format(time.RFC1123), c.ContactUUID))
+
+	return c, nil
+}
+
+// Keys returns the personal entries of the grantor of a contact with granted
+// access.
+func (s *EmergencyService) Keys(ctx context.Context, granteeID int64, contactUUID string, keyType *keychain.KeyType) ([]*keychain.KeyRecord, error) {
+	c, err := s.granted(ctx, granteeID, contactUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.keys.GetKeys(s.asContact(ctx, c), c.GrantorID, keyType)
+}
+
+// Key returns a personal entry of the grantor of a contact with granted
+// access with its decrypted data. The read is audited for the grantor.
+func (s *EmergencyService) Key(ctx context.Context, granteeID int64, contactUUID string, keyUUID string) (*keychain.KeyRecord, []byte, error) {
+	c, err := s.granted(ctx, granteeID, contactUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.keys.GetKey(s.asContact(ctx, c), c.GrantorID, keyUUID)
+}
+
+// Takeover sets a new password for the account of the grantor of a contact
+// with granted takeover access, ending all sessions of the grantor.
+//
+// As on a password reset the key material of the grantor is removed, which
+// requires discardVaultKey when there is a zero-knowledge vault key. The
+// contact goes back to idle, so another takeover needs a new request.
+func (s *EmergencyService) Takeover(ctx context.Context, granteeID int64, contactUUID string, newPassword string, discardVaultKey bool) (vaultKey bool, err error) {
+	c, err := s.granted(ctx, granteeID, contactUUID)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		err = recordAudit(s.asContact(ctx, c), s.auditor, audit.Event{
+			UserID: c.GrantorID,
+			Action: audit.ActionEmergencyTakeover,
+		}, err)
+	}()
+
+	if c.Access != emergency.AccessTakeover {
+		return false, emergency.ErrTakeoverDenied
+	}
+
+	au, err := s.userRepo.GetByID(ctx, c.GrantorID)
+	if err != nil {
+		return false, err
+	}
+	if au.DisabledAt != nil {
+		return false, user.ErrAccountDisabled
+	}
+
+	if err := s.passwords.Check(au.Login, newPassword); err != nil {
+		return false, err
+	}
+
+	vaultKey = au.KeyMaterial != nil
+	if vaultKey && !discardVaultKey {
+		return false, user.ErrVaultKeyWillBeLost
+	}
+
+	passwordHash, err := s.hasher.HashPassword(newPassword)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := s.repo.UpdateStatus(ctx, c.ID, c.Status, emergency.StatusIdle, nil); err != nil {
+		return false, err
+	}
+
+	if err := s.userRepo.ResetCredentials(ctx, au.ID, passwordHash); err != nil {
+		return false, err
+	}
+
+	if err := s.tokenRepo.RevokeAllByUser(ctx, au.ID); err != nil {
+		return false, err
+	}
+	if err := s.revoker.RevokeUser(ctx, au.ID); err != nil {
+		return false, err
+	}
+
+	if err := s.userRepo.ResetLoginFailures(ctx, au.ID); err != nil {
+		return false, err
+	}
+
+	s.mail(ctx, au, "Your passKeeper password was changed", fmt.Sprintf(emergencyTakeoverMailBody, c.GranteeLogin))
+
+	return vaultKey, nil
+}
+
+// Remove deletes a contact; the grantor removes a contact, the grantee steps
+// down as one.
+func (s *EmergencyService) Remove(ctx context.Context, userID int64, contactUUID string) error {
+	id, err := uuid.Parse(contactUUID)
+	if err != nil {
+		return emergency.ErrNotFound
+	}
+
+	return s.repo.Delete(ctx, userID, id)
+}
+
+// contact loads a contact the user is the grantor or the grantee of.
+func (s *EmergencyService) contact(ctx context.Context, userID int64, contactUUID string) (*emergency.Contact, error) {
+	id, err := uuid.Parse(contactUUID)
+	if err != nil {
+		return nil, emergency.ErrNotFound
+	}
+
+	c, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.GrantorID != userID && c.GranteeID != userID {
+		return nil, emergency.ErrNotFound
+	}
+
+	return c, nil
+}
+
+// granted loads a contact the user is the grantee of and checks that access
+// has been granted.
+func (s *EmergencyService) granted(ctx context.Context, granteeID int64, contactUUID string) (*emergency.Contact, error) {
+	c, err := s.contact(ctx, granteeID, contactUUID)
+	if err != nil {
+		return nil, err
+	}
+	if c.GranteeID != granteeID {
+		return nil, emergency.ErrNotGrantee
+	}
+	if !c.Granted(s.now()) {
+		return nil, emergency.ErrNotGranted
+	}
+
+	return c, nil
+}
+
+// asContact returns a copy of ctx acting as the contact c on the account of
+// its grantor.
+func (s *EmergencyService) asContact(ctx context.Context, c *emergency.Contact) context.Context {
+	return principal.WithPrincipal(ctx, principal.EmergencyContact(c.GrantorID, c.ContactUUID))
+}
+
+// notify publishes an emergency access event to the user and mails them.
+// Both are best effort: a failed notification does not undo the change.
+func (s *EmergencyService) notify(ctx context.Context, userID int64, t webhook.EventType, c *emergency.Contact, subject string, body string) {
+	contactUUID := c.ContactUUID
+	publishWebhook(ctx, s.webhooks, webhook.Event{Type: t, UserID: userID, ContactUUID: &contactUUID})
+
+	au, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+	s.mail(ctx, au, subject, body)
+}
+
+// mail sends a notification to the verified email of the user, if any.
+func (s *EmergencyService) mail(ctx context.Context, au *user.UserRecord, subject string, body string) {
+	if au.EmailVerifiedAt == nil {
+		return
+	}
+	_ = s.mailer.Send(ctx, au.Email, subject, body)
+}
+
+// hasGrantee reports whether the grantee is one of the contacts.
+func hasGrantee(list []*emergency.Contact, granteeID int64) bool {
+	for _, c := range list {
+		if c.GranteeID == granteeID {
+			return true
+		}
+	}
+	return false
+}
+
+const emergencyRequestedMailBody = `Your emergency contact %q requested %s access to your passKeeper account.
+
+Access will be granted automatically on %s. If you did not expect this, reject the request before then by running:
+
+  passKeeper emergency reject %s
+`
+
+const emergencyApprovedMailBody = `%q approved your emergency access request. You can now run:
+
+  passKeeper emergency keys %s
+`
+
+const emergencyRejectedMailBody = `%q rejected or withdrew your emergency access to their passKeeper account.
+`
+
+const emergencyTakeoverMailBody = `Your emergency contact %q set a new password for your passKeeper account and all your sessions were ended.
+
+If you did not expect this, contact your administrator.
+`
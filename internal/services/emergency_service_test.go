@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/emergency"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/principal"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"testing"
+	"time"
+)
+
+type emergencyServiceDeps struct {
+	repo      *mocks.EmergencyRepositoryMock
+	userRepo  *mocks.UserRepositoryMock
+	tokenRepo *mocks.TokenRepositoryMock
+	keys      *mocks.KeychainServiceMock
+	hasher    *mocks.PasswordHasherMock
+	revoker   *mocks.AccessTokenRevokerMock
+	auditor   *mocks.AuditServiceMock
+	webhooks  *mocks.WebhookServiceMock
+	mailer    *mocks.MailerMock
+}
+
+func newTestEmergencyService(now time.Time) (EmergencyService, emergencyServiceDeps) {
+	d := emergencyServiceDeps{
+		repo:      new(mocks.EmergencyRepositoryMock),
+		userRepo:  new(mocks.UserRepositoryMock),
+		tokenRepo: new(mocks.TokenRepositoryMock),
+		keys:      new(mocks.KeychainServiceMock),
+		hasher:    new(mocks.PasswordHasherMock),
+		revoker:   new(mocks.AccessTokenRevokerMock),
+		auditor:   new(mocks.AuditServiceMock),
+		webhooks:  new(mocks.WebhookServiceMock),
+		mailer:    new(mocks.MailerMock),
+	}
+
+	s := NewEmergencyService(d.repo, d.userRepo, d.tokenRepo, d.keys, d.hasher, user.PasswordPolicy{}, d.revoker, d.auditor, d.webhooks, d.mailer)
+	s.now = func() time.Time { return now }
+	return s, d
+}
+
+func testContact(status emergency.Status, requestedAt *time.Time) *emergency.Contact {
+	return &emergency.Contact{
+		ID:           7,
+		ContactUUID:  uuid.New(),
+		GrantorID:    1,
+		GrantorLogin: "alice",
+		GranteeID:    2,
+		GranteeLogin: "bob",
+		Access:       emergency.AccessTakeover,
+		WaitPeriod:   48 * time.Hour,
+		Status:       status,
+		RequestedAt:  requestedAt,
+	}
+}
+
+func TestEmergencyService_Nominate(t *testing.T) {
+	s, d := newTestEmergencyService(time.Now())
+	ctx := context.Background()
+
+	stored := testContact(emergency.StatusIdle, nil)
+	var got *emergency.Contact
+	d.userRepo.On("GetByLogin", ctx, "bob").Return(&user.UserRecord{ID: 2, Login: "bob"}, nil)
+	d.repo.On("ListByGrantor", ctx, int64(1)).Return([]*emergency.Contact{}, nil)
+	d.repo.On("Upsert", ctx, mock.AnythingOfType("*emergency.Contact")).
+		Run(func(args mock.Arguments) { got = args.Get(1).(*emergency.Contact) }).
+		Return(stored, nil)
+
+	c, err := s.Nominate(ctx, 1, "bob", "view", 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, stored, c)
+	assert.Equal(t, int64(2), got.GranteeID)
+	assert.Equal(t, emergency.AccessView, got.Access)
+	assert.Equal(t, emergency.DefaultWaitPeriod, got.WaitPeriod)
+	d.repo.AssertExpectations(t)
+}
+
+func TestEmergencyService_Nominate_Errors(t *testing.T) {
+	s, d := newTestEmergencyService(time.Now())
+	ctx := context.Background()
+
+	full := make([]*emergency.Contact, emergency.MaxContacts)
+	for i := range full {
+		full[i] = &emergency.Contact{GranteeID: int64(10 + i)}
+	}
+	d.userRepo.On("GetByLogin", ctx, "alice").Return(&user.UserRecord{ID: 1, Login: "alice"}, nil)
+	d.userRepo.On("GetByLogin", ctx, "bob").Return(&user.UserRecord{ID: 2, Login: "bob"}, nil)
+	d.repo.On("ListByGrantor", ctx, int64(1)).Return(full, nil)
+
+	_, err := s.Nominate(ctx, 1, "bob", "owner", time.Hour)
+	assert.ErrorIs(t, err, emergency.ErrInvalidAccess)
+
+	_, err = s.Nominate(ctx, 1, "bob", "view", time.Minute)
+	assert.ErrorIs(t, err, emergency.ErrInvalidWaitPeriod)
+
+	_, err = s.Nominate(ctx, 1, "bob", "view", emergency.MaxWaitPeriod+time.Hour)
+	assert.ErrorIs(t, err, emergency.ErrInvalidWaitPeriod)
+
+	_, err = s.Nominate(ctx, 1, "alice", "view", time.Hour)
+	assert.ErrorIs(t, err, emergency.ErrContactWithSelf)
+
+	_, err = s.Nominate(ctx, 1, "bob", "view", time.Hour)
+	assert.ErrorIs(t, err, emergency.ErrTooManyContacts)
+
+	d.repo.AssertNotCalled(t, "Upsert")
+}
+
+func TestEmergencyService_Request(t *testing.T) {
+	now := time.Date(2025, 11, 2, 9, 0, 0, 0, time.UTC)
+	s, d := newTestEmergencyService(now)
+	ctx := context.Background()
+
+	c := testContact(emergency.StatusIdle, nil)
+	requested := testContact(emergency.StatusRequested, &now)
+	requested.ContactUUID = c.ContactUUID
+	verified := now.Add(-time.Hour)
+
+	d.repo.On("Get", ctx, c.ContactUUID).Return(c, nil)
+	d.repo.On("UpdateStatus", ctx, c.ID, emergency.StatusIdle, emergency.StatusRequested, &now).Return(requested, nil)
+	d.auditor.On("Record", ctx, mock.MatchedBy(func(e audit.Event) bool {
+		return e.UserID == 1 && e.Action == audit.ActionEmergencyRequest && e.Result == audit.ResultSuccess
+	})).Return(nil)
+	d.webhooks.On("Publish", ctx, mock.MatchedBy(func(e webhook.Event) bool {
+		return e.Type == webhook.EventEmergencyRequested && e.UserID == 1 && *e.ContactUUID == c.ContactUUID
+	})).Return(nil)
+	d.userRepo.On("GetByID", ctx, int64(1)).Return(&user.UserRecord{ID: 1, Login: "alice", Email: "alice@example.com", EmailVerifiedAt: &verified}, nil)
+	d.mailer.On("Send", ctx, "alice@example.com", mock.Anything, mock.MatchedBy(func(body string) bool {
+		return assert.Contains(t, body, "passKeeper emergency reject "+c.ContactUUID.String())
+	})).Return(nil)
+
+	got, err := s.Request(ctx, 2, c.ContactUUID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, requested, got)
+	d.repo.AssertExpectations(t)
+	d.auditor.AssertExpectations(t)
+	d.webhooks.AssertExpectations(t)
+	d.mailer.AssertExpectations(t)
+}
+
+func TestEmergencyService_Request_Errors(t *testing.T) {
+	now := time.Date(2025, 11, 2, 9, 0, 0, 0, time.UTC)
+	s, d := newTestEmergencyService(now)
+	ctx := context.Background()
+
+	pending := testContact(emergency.StatusRequested, &now)
+	d.repo.On("Get", ctx, pending.ContactUUID).Return(pending, nil)
+	d.auditor.On("Record", ctx, mock.Anything).Return(nil)
+
+	_, err := s.Request(ctx, 2, pending.ContactUUID.String())
+	assert.ErrorIs(t, err, emergency.ErrAlreadyPending)
+
+	_, err = s.Request(ctx, 1, pending.ContactUUID.String())
+	assert.ErrorIs(t, err, emergency.ErrNotGrantee)
+
+	_, err = s.Request(ctx, 3, pending.ContactUUID.String())
+	assert.ErrorIs(t, err, emergency.ErrNotFound)
+
+	_, err = s.Request(ctx, 2, "not-a-uuid")
+	assert.ErrorIs(t, err, emergency.ErrNotFound)
+
+	d.repo.AssertNotCalled(t, "UpdateStatus")
+}
+
+func TestEmergencyService_Approve_Reject(t *testing.T) {
+	now := time.Date(2025, 11, 2, 9, 0, 0, 0, time.UTC)
+	s, d := newTestEmergencyService(now)
+	ctx := context.Background()
+
+	requestedAt := now.Add(-time.Hour)
+	c := testContact(emergency.StatusRequested, &requestedAt)
+	d.repo.On("Get", ctx, c.ContactUUID).Return(c, nil)
+	d.repo.On("UpdateStatus", ctx, c.ID, emergency.StatusRequested, emergency.StatusApproved, &requestedAt).
+		Return(testContact(emergency.StatusApproved, &requestedAt), nil)
+	d.repo.On("UpdateStatus", ctx, c.ID, emergency.StatusRequested, emergency.StatusRejected, (*time.Time)(nil)).
+		Return(testContact(emergency.StatusRejected, nil), nil)
+	d.webhooks.On("Publish", ctx, mock.MatchedBy(func(e webhook.Event) bool { return e.UserID == 2 })).Return(nil)
+	d.userRepo.On("GetByID", ctx, int64(2)).Return(&user.UserRecord{ID: 2, Login: "bob"}, nil)
+
+	_, err := s.Approve(ctx, 2, c.ContactUUID.String())
+	assert.ErrorIs(t, err, emergency.ErrNotGrantor)
+
+	approved, err := s.Approve(ctx, 1, c.ContactUUID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, emergency.StatusApproved, approved.Status)
+
+	rejected, err := s.Reject(ctx, 1, c.ContactUUID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, emergency.StatusRejected, rejected.Status)
+
+	d.webhooks.AssertNumberOfCalls(t, "Publish", 2)
+	// bob has no verified email
+	d.mailer.AssertNotCalled(t, "Send")
+}
+
+func TestEmergencyService_Keys(t *testing.T) {
+	now := time.Date(2025, 11, 2, 9, 0, 0, 0, time.UTC)
+	s, d := newTestEmergencyService(now)
+	ctx := context.Background()
+
+	waiting := now.Add(-47 * time.Hour)
+	pending := testContact(emergency.StatusRequested, &waiting)
+	d.repo.On("Get", ctx, pending.ContactUUID).Return(pending, nil)
+
+	_, err := s.Keys(ctx, 2, pending.ContactUUID.String(), nil)
+	assert.ErrorIs(t, err, emergency.ErrNotGranted)
+
+	passed := now.Add(-48 * time.Hour)
+	granted := testContact(emergency.StatusRequested, &passed)
+	list := []*keychain.KeyRecord{{ID: 1, Title: "bank"}}
+	d.repo.On("Get", ctx, granted.ContactUUID).Return(granted, nil)
+	d.keys.On("GetKeys", mock.MatchedBy(func(ctx context.Context) bool {
+		p, ok := principal.FromContext(ctx)
+		return ok && p.Kind == principal.KindEmergencyContact && p.UserID == 1 && p.ContactUUID == granted.ContactUUID
+	}), int64(1), (*keychain.KeyType)(nil)).Return(list, nil)
+
+	_, err = s.Keys(ctx, 1, granted.ContactUUID.String(), nil)
+	assert.ErrorIs(t, err, emergency.ErrNotGrantee)
+
+	got, err := s.Keys(ctx, 2, granted.ContactUUID.String(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, list, got)
+	d.keys.AssertExpectations(t)
+}
+
+func TestEmergencyService_Takeover(t *testing.T) {
+	now := time.Date(2025, 11, 2, 9, 0, 0, 0, time.UTC)
+	s, d := newTestEmergencyService(now)
+	ctx := context.Background()
+
+	requestedAt := now.Add(-time.Hour)
+	c := testContact(emergency.StatusApproved, &requestedAt)
+	verified := now.Add(-time.Hour)
+	d.repo.On("Get", ctx, c.ContactUUID).Return(c, nil)
+	d.userRepo.On("GetByID", ctx, int64(1)).Return(&user.UserRecord{
+		ID: 1, Login: "alice", Email: "alice@example.com", EmailVerifiedAt: &verified, KeyMaterial: []byte("wrapped"),
+	}, nil)
+	d.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e audit.Event) bool {
+		return e.UserID == 1 && e.Action == audit.ActionEmergencyTakeover
+	})).Return(nil)
+
+	_, err := s.Takeover(ctx, 2, c.ContactUUID.String(), "new-password", false)
+	assert.ErrorIs(t, err, user.ErrVaultKeyWillBeLost)
+
+	d.hasher.On("HashPassword", "new-password").Return("hash", nil)
+	d.repo.On("UpdateStatus", ctx, c.ID, emergency.StatusApproved, emergency.StatusIdle, (*time.Time)(nil)).
+		Return(testContact(emergency.StatusIdle, nil), nil)
+	d.userRepo.On("ResetCredentials", ctx, int64(1), "hash").Return(nil)
+	d.tokenRepo.On("RevokeAllByUser", ctx, int64(1)).Return(nil)
+	d.revoker.On("RevokeUser", ctx, int64(1)).Return(nil)
+	d.userRepo.On("ResetLoginFailures", ctx, int64(1)).Return(nil)
+	d.mailer.On("Send", ctx, "alice@example.com", mock.Anything, mock.Anything).Return(nil)
+
+	discarded, err := s.Takeover(ctx, 2, c.ContactUUID.String(), "new-password", true)
+
+	assert.NoError(t, err)
+	assert.True(t, discarded)
+	d.userRepo.AssertExpectations(t)
+	d.tokenRepo.AssertExpectations(t)
+	d.revoker.AssertExpectations(t)
+	d.mailer.AssertExpectations(t)
+}
+
+func TestEmergencyService_Takeover_ViewOnly(t *testing.T) {
+	now := time.Date(2025, 11, 2, 9, 0, 0, 0, time.UTC)
+	s, d := newTestEmergencyService(now)
+	ctx := context.Background()
+
+	requestedAt := now.Add(-time.Hour)
+	c := testContact(emergency.StatusApproved, &requestedAt)
+	c.Access = emergency.AccessView
+	d.repo.On("Get", ctx, c.ContactUUID).Return(c, nil)
+	d.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e audit.Event) bool {
+		return e.Action == audit.ActionEmergencyTakeover && e.Result == audit.ResultDenied
+	})).Return(nil)
+
+	_, err := s.Takeover(ctx, 2, c.ContactUUID.String(), "new-password", true)
+
+	assert.ErrorIs(t, err, emergency.ErrTakeoverDenied)
+	d.auditor.AssertExpectations(t)
+	d.userRepo.AssertNotCalled(t, "ResetCredentials")
+}
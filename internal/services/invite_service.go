@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/invite"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"strings"
+	"time"
+)
+
+// inviteCodeBytes is the amount of randomness in an invite code.
+const inviteCodeBytes = 24
+
+// InviteGate decides whether a registration is admitted in invite-only mode.
+//
+// Redeem takes one use of the invite for login and email and returns its ID;
+// Release gives that use back when the registration fails afterwards.
+type InviteGate interface {
+	Redeem(ctx context.Context, code string, login string, email string) (inviteID int64, err error)
+	Release(ctx context.Context, inviteID int64) error
+}
+
+type IInviteService interface {
+	InviteGate
+	Create(ctx context.Context, userID int64, login string, email string, maxUses int, ttl time.Duration) (inv *invite.Invite, code string, err error)
+	List(ctx context.Context, userID int64) ([]*invite.Invite, error)
+	Revoke(ctx context.Context, userID int64, inviteUUID string) error
+}
+
+// InviteService manages invite codes, which every existing member can create
+// and which are required to register when the server runs in invite-only mode.
+//
+// Codes are random, prefixed with invite.CodePrefix and stored as SHA-256
+// hashes only, so the plain value is returned exactly once by Create.
+type InviteService struct {
+	repo invite.InviteRepository
+	now  func() time.Time
+}
+
+// NewInviteService constructs a new InviteService with given dependencies.
+func NewInviteService(repo invite.InviteRepository) InviteService {
+	return InviteService{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// Create issues a new invite code on behalf of the user.
+//
+// login and email, when not empty, are the only login and the only email the
+// invite can register. A zero ttl selects invite.DefaultTTL. Returns the
+// stored invite and the plain code.
+func (s *InviteService) Create(ctx context.Context, userID int64, login string, email string, maxUses int, ttl time.Duration) (inv *invite.Invite, code string, err error) {
+	login = strings.TrimSpace(login)
+	if login != "" {
+		if err := user.ValidateLogin(login); err != nil {
+			return nil, "", err
+		}
+	}
+
+	email = user.NormalizeEmail(email)
+	if email != "" {
+		if err := user.ValidateEmail(email); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := invite.ValidateMaxUses(maxUses); err != nil {
+		return nil, "", err
+	}
+
+	if ttl == 0 {
+		ttl = invite.DefaultTTL
+	}
+	if err := invite.ValidateTTL(ttl); err != nil {
+		return nil, "", err
+	}
+
+	raw := make([]byte, inviteCodeBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", err
+	}
+	code = invite.CodePrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	inv, err = s.repo.Create(ctx, &invite.Invite{
+		CreatedBy: userID,
+		CodeHash:  hashSecretToken(code),
+		Login:     login,
+		Email:     email,
+		MaxUses:   maxUses,
+		ExpiresAt: s.now().Add(ttl),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return inv, code, nil
+}
+
+// List returns every invite created by the user.
+func (s *InviteService) List(ctx context.Context, userID int64) ([]*invite.Invite, error) {
+	return s.repo.ListByCreator(ctx, userID)
+}
+
+// Revoke deletes an invite created by the user; its code stops being accepted
+// immediately.
+//
+// Returns invite.ErrNotFound if the user has no invite with that UUID.
+func (s *InviteService) Revoke(ctx context.Context, userID int64, inviteUUID string) error {
+	id, err := uuid.Parse(inviteUUID)
+	if err != nil {
+		return invite.ErrNotFound
+	}
+
+	return s.repo.Delete(ctx, userID, id)
+}
+
+// Redeem takes one use of the invite code for registering login with email.
+//
+// Returns invite.ErrInvalidInvite for missing, unknown, expired or used up
+// codes and for codes bound to another login or email.
+func (s *InviteService) Redeem(ctx context.Context, code string, login string, email string) (int64, error) {
+	if !strings.HasPrefix(code, invite.CodePrefix) {
+		return 0, invite.ErrInvalidInvite
+	}
+
+	inv, err := s.repo.Redeem(ctx, hashSecretToken(code), login, user.NormalizeEmail(email), s.now())
+	if err != nil {
+		return 0, err
+	}
+
+	return inv.ID, nil
+}
+
+// Release gives back a use taken by Redeem.
+func (s *InviteService) Release(ctx context.Context, inviteID int64) error {
+	return s.repo.Release(ctx, inviteID)
+}
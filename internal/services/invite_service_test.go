@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/invite"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestInviteService(repo *mocks.InviteRepositoryMock, now time.Time) InviteService {
+	s := NewInviteService(repo)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestInviteService_Create_Success(t *testing.T) {
+	repo := new(mocks.InviteRepositoryMock)
+	now := time.Date(2025, 10, 28, 9, 0, 0, 0, time.UTC)
+	s := newTestInviteService(repo, now)
+
+	ctx := context.Background()
+	stored := &invite.Invite{ID: 1, CreatedBy: 1, Login: "alice", MaxUses: 1}
+
+	var got *invite.Invite
+	repo.On("Create", ctx, mock.AnythingOfType("*invite.Invite")).
+		Run(func(args mock.Arguments) { got = args.Get(1).(*invite.Invite) }).
+		Return(stored, nil)
+
+	inv, code, err := s.Create(ctx, 1, " alice ", " Alice@Example.com ", 1, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, stored, inv)
+	assert.True(t, strings.HasPrefix(code, invite.CodePrefix))
+	assert.Equal(t, hashSecretToken(code), got.CodeHash)
+	assert.Equal(t, "alice", got.Login)
+	assert.Equal(t, "alice@example.com", got.Email)
+	assert.Equal(t, now.Add(invite.DefaultTTL), got.ExpiresAt)
+	repo.AssertExpectations(t)
+}
+
+func TestInviteService_Create_Validate_Error(t *testing.T) {
+	repo := new(mocks.InviteRepositoryMock)
+	s := newTestInviteService(repo, time.Now())
+
+	ctx := context.Background()
+
+	_, _, err := s.Create(ctx, 1, "", "", 0, time.Hour)
+	assert.ErrorIs(t, err, invite.ErrInvalidMaxUses)
+
+	_, _, err = s.Create(ctx, 1, "", "", invite.MaxUsesLimit+1, time.Hour)
+	assert.ErrorIs(t, err, invite.ErrInvalidMaxUses)
+
+	_, _, err = s.Create(ctx, 1, "", "", 1, invite.MaxTTL+time.Hour)
+	assert.ErrorIs(t, err, invite.ErrInvalidTTL)
+
+	_, _, err = s.Create(ctx, 1, "", "", 1, -time.Hour)
+	assert.ErrorIs(t, err, invite.ErrInvalidTTL)
+
+	_, _, err = s.Create(ctx, 1, "a", "", 1, time.Hour)
+	assert.ErrorIs(t, err, user.ErrLoginTooShort)
+
+	_, _, err = s.Create(ctx, 1, "", "not-an-email", 1, time.Hour)
+	assert.ErrorIs(t, err, user.ErrInvalidEmail)
+
+	repo.AssertNotCalled(t, "Create")
+}
+
+func TestInviteService_Redeem(t *testing.T) {
+	repo := new(mocks.InviteRepositoryMock)
+	now := time.Date(2025, 10, 28, 9, 0, 0, 0, time.UTC)
+	s := newTestInviteService(repo, now)
+
+	ctx := context.Background()
+	code := invite.CodePrefix + "abc"
+	repo.On("Redeem", ctx, hashSecretToken(code), "alice", "alice@example.com", now).Return(&invite.Invite{ID: 7}, nil)
+
+	id, err := s.Redeem(ctx, code, "alice", "Alice@Example.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+	repo.AssertExpectations(t)
+}
+
+func TestInviteService_Redeem_Invalid(t *testing.T) {
+	repo := new(mocks.InviteRepositoryMock)
+	now := time.Now()
+	s := newTestInviteService(repo, now)
+
+	ctx := context.Background()
+
+	_, err := s.Redeem(ctx, "", "alice", "")
+	assert.ErrorIs(t, err, invite.ErrInvalidInvite)
+
+	_, err = s.Redeem(ctx, "pat_abc", "alice", "")
+	assert.ErrorIs(t, err, invite.ErrInvalidInvite)
+
+	code := invite.CodePrefix + "abc"
+	repo.On("Redeem", ctx, hashSecretToken(code), "bob", "bob@example.com", now).Return(nil, invite.ErrInvalidInvite)
+
+	_, err = s.Redeem(ctx, code, "bob", "bob@example.com")
+	assert.ErrorIs(t, err, invite.ErrInvalidInvite)
+	repo.AssertExpectations(t)
+}
+
+func TestInviteService_Revoke(t *testing.T) {
+	repo := new(mocks.InviteRepositoryMock)
+	s := newTestInviteService(repo, time.Now())
+
+	ctx := context.Background()
+	id := uuid.New()
+	repo.On("Delete", ctx, int64(1), id).Return(nil)
+
+	assert.NoError(t, s.Revoke(ctx, 1, id.String()))
+	assert.ErrorIs(t, s.Revoke(ctx, 1, "not-a-uuid"), invite.ErrNotFound)
+	repo.AssertExpectations(t)
+}
+
+func TestAuthService_Register_Invite_Required(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	invites := new(mocks.InviteServiceMock)
+
+	ctx := context.Background()
+
+	s := NewAuthService(userRepo, new(mocks.TokenRepositoryMock), passHasher, new(mocks.TokenManagerMock), nil, allowDevices(), nopAuditor(), nopPublisher(), invites, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	passHasher.On("HashPassword", "password").Return("hash", nil)
+	invites.On("Redeem", ctx, "", "login", "").Return(int64(0), invite.ErrInvalidInvite)
+
+	_, _, _, err := s.Register(ctx, "login", "password", "", "", testDevice)
+
+	assert.ErrorIs(t, err, invite.ErrInvalidInvite)
+	userRepo.AssertNotCalled(t, "Create")
+	invites.AssertExpectations(t)
+}
+
+func TestAuthService_Register_Invite_ReleasedOnFailure(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+	invites := new(mocks.InviteServiceMock)
+
+	ctx := context.Background()
+	code := invite.CodePrefix + "abc"
+
+	s := NewAuthService(userRepo, new(mocks.TokenRepositoryMock), passHasher, new(mocks.TokenManagerMock), nil, allowDevices(), nopAuditor(), nopPublisher(), invites, nil, LockoutPolicy{}, user.PasswordPolicy{})
+
+	passHasher.On("HashPassword", "password").Return("hash", nil)
+	invites.On("Redeem", ctx, code, "login", "").Return(int64(7), nil)
+	invites.On("Release", ctx, int64(7)).Return(nil)
+	userRepo.On("Create", ctx, "login", "hash", "").Return(0, user.ErrDuplicateLogin)
+
+	_, _, _, err := s.Register(ctx, "login", "password", "", code, testDevice)
+
+	assert.ErrorIs(t, err, user.ErrDuplicateLogin)
+	invites.AssertExpectations(t)
+}
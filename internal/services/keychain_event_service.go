@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"github.com/thxhix/passKeeper/internal/domain/principal"
+	"sync"
+)
+
+// keyEventBuffer is how many events a subscriber may fall behind.
+const keyEventBuffer = 64
+
+type IKeychainEventService interface {
+	Subscribe(ctx context.Context, userID int64) (<-chan keychain.Event, error)
+}
+
+// KeychainEventService fans the changes of entries out to the event
+// streams open on this server instance. It is fed by Dispatch with the
+// changes received from the database, which announces the changes made
+// through every instance.
+//
+// Subscribers see the events of the entries they may read, like GetChanges
+// of KeychainService; the grants of a service account are read once when it
+// subscribes. A subscriber that falls keyEventBuffer events behind is
+// dropped: its channel is closed and the client is expected to reconnect and
+// catch up with the delta sync.
+type KeychainEventService struct {
+	grants KeyGrantReader
+
+	mu   sync.Mutex
+	subs map[int64]map[*keySubscriber]struct{}
+}
+
+type keySubscriber struct {
+	access *keyAccess
+	events chan keychain.Event
+}
+
+// NewKeychainEventService constructs a new KeychainEventService with given dependencies.
+func NewKeychainEventService(grants KeyGrantReader) *KeychainEventService {
+	return &KeychainEventService{
+		grants: grants,
+		subs:   make(map[int64]map[*keySubscriber]struct{}),
+	}
+}
+
+// Subscribe returns the events of the entries the user sees, personal ones
+// and those of their vaults, that the principal in ctx may read. The channel is closed once ctx is done or the
+// subscriber is dropped for falling behind.
+func (s *KeychainEventService) Subscribe(ctx context.Context, userID int64) (<-chan keychain.Event, error) {
+	access, err := resolveKeyAccess(ctx, s.grants)
+	if err != nil {
+		return nil, err
+	}
+	if access.p.Kind == principal.KindPersonalToken && !access.p.Scopes.AllowsAction(pat.ActionRead) {
+		return nil, pat.ErrInsufficientScope
+	}
+
+	sub := &keySubscriber{access: access, events: make(chan keychain.Event, keyEventBuffer)}
+
+	s.mu.Lock()
+	if s.subs[userID] == nil {
+		s.subs[userID] = make(map[*keySubscriber]struct{})
+	}
+	s.subs[userID][sub] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.drop(userID, sub)
+	}()
+
+	return sub.events, nil
+}
+
+// Dispatch passes event to the subscribers of its user, or to every
+// subscriber for an EventResync. It never blocks.
+func (s *KeychainEventService) Dispatch(event keychain.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if event.Type == keychain.EventResync {
+		for userID, subs := range s.subs {
+			for sub := range subs {
+				s.send(userID, sub, event)
+			}
+		}
+		return
+	}
+
+	rec := event.Record()
+	for sub := range s.subs[event.UserID] {
+		if sub.access.check(pat.ActionRead, rec) == nil {
+			s.send(event.UserID, sub, event)
+		}
+	}
+}
+
+// send delivers event to sub, dropping sub if it is full. s.mu must be held.
+func (s *KeychainEventService) send(userID int64, sub *keySubscriber, event keychain.Event) {
+	select {
+	case sub.events <- event:
+	default:
+		s.drop(userID, sub)
+	}
+}
+
+// drop unsubscribes sub and closes its channel, once. s.mu must be held.
+func (s *KeychainEventService) drop(userID int64, sub *keySubscriber) {
+	subs := s.subs[userID]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(s.subs, userID)
+	}
+	close(sub.events)
+}
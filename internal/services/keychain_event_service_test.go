@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"github.com/thxhix/passKeeper/internal/domain/principal"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"testing"
+)
+
+func keyEventOf(t keychain.EventType, userID int64, keyType keychain.KeyType, tags ...string) keychain.Event {
+	return keychain.Event{Type: t, UserID: userID, KeyUUID: uuid.New(), KeyType: keyType, Tags: tags}
+}
+
+func TestKeychainEventService_Dispatch(t *testing.T) {
+	s := NewKeychainEventService(new(mocks.ServiceAccountRepositoryMock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := s.Subscribe(ctx, 1)
+	require.NoError(t, err)
+
+	mine := keyEventOf(keychain.EventAdd, 1, keychain.KeyText)
+	s.Dispatch(keyEventOf(keychain.EventAdd, 2, keychain.KeyText))
+	s.Dispatch(mine)
+	s.Dispatch(keychain.Event{Type: keychain.EventResync})
+
+	assert.Equal(t, mine, <-events)
+	assert.Equal(t, keychain.EventResync, (<-events).Type)
+
+	cancel()
+	_, open := <-events
+	assert.False(t, open)
+}
+
+func TestKeychainEventService_FiltersByScope(t *testing.T) {
+	s := NewKeychainEventService(new(mocks.ServiceAccountRepositoryMock))
+
+	_, err := s.Subscribe(scopedContext(t, "write"), 1)
+	assert.ErrorIs(t, err, pat.ErrInsufficientScope)
+
+	ctx, cancel := context.WithCancel(scopedContext(t, "read:tag:prod"))
+	defer cancel()
+	events, err := s.Subscribe(ctx, 1)
+	require.NoError(t, err)
+
+	prod := keyEventOf(keychain.EventDelete, 1, keychain.KeyText, "prod")
+	s.Dispatch(keyEventOf(keychain.EventAdd, 1, keychain.KeyText, "dev"))
+	s.Dispatch(prod)
+
+	assert.Equal(t, prod, <-events)
+	assert.Empty(t, events)
+}
+
+func TestKeychainEventService_ServiceAccount(t *testing.T) {
+	mockGrants := new(mocks.ServiceAccountRepositoryMock)
+	s := NewKeychainEventService(mockGrants)
+
+	clientID := uuid.New()
+	ctx, cancel := context.WithCancel(principal.WithPrincipal(context.Background(), principal.ServiceAccount(1, clientID)))
+	defer cancel()
+	mockGrants.On("GrantedKeyIDs", ctx, clientID).Return([]int64{1}, nil)
+
+	events, err := s.Subscribe(ctx, 1)
+	require.NoError(t, err)
+
+	granted := keyEventOf(keychain.EventUpdate, 1, keychain.KeyText)
+	granted.KeyID = 1
+	other := keyEventOf(keychain.EventUpdate, 1, keychain.KeyText)
+	other.KeyID = 2
+
+	s.Dispatch(other)
+	s.Dispatch(granted)
+
+	assert.Equal(t, granted, <-events)
+	assert.Empty(t, events)
+}
+
+func TestKeychainEventService_VaultEntries(t *testing.T) {
+	mockGrants := new(mocks.ServiceAccountRepositoryMock)
+	s := NewKeychainEventService(mockGrants)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := s.Subscribe(ctx, 1)
+	require.NoError(t, err)
+
+	clientID := uuid.New()
+	saCtx, saCancel := context.WithCancel(principal.WithPrincipal(context.Background(), principal.ServiceAccount(1, clientID)))
+	defer saCancel()
+	mockGrants.On("GrantedKeyIDs", saCtx, clientID).Return([]int64{1}, nil)
+	saEvents, err := s.Subscribe(saCtx, 1)
+	require.NoError(t, err)
+
+	vaultUUID := uuid.New()
+	shared := keyEventOf(keychain.EventAdd, 1, keychain.KeyText)
+	shared.KeyID = 1
+	shared.VaultUUID = &vaultUUID
+
+	s.Dispatch(shared)
+
+	assert.Equal(t, shared, <-events)
+	assert.Empty(t, saEvents)
+}
+
+func TestKeychainEventService_DropsSlowSubscriber(t *testing.T) {
+	s := NewKeychainEventService(new(mocks.ServiceAccountRepositoryMock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := s.Subscribe(ctx, 1)
+	require.NoError(t, err)
+
+	for i := 0; i <= keyEventBuffer; i++ {
+		s.Dispatch(keyEventOf(keychain.EventAdd, 1, keychain.KeyText))
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	assert.Equal(t, keyEventBuffer, received)
+}
@@ -2,9 +2,22 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/emergency"
 	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"github.com/thxhix/passKeeper/internal/domain/principal"
+	"github.com/thxhix/passKeeper/internal/domain/serviceaccount"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
 	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"runtime"
+	"sync"
 )
 
 type CryptManager interface {
@@ -16,20 +29,55 @@ type IKeychainService interface {
 	GetKeys(ctx context.Context, userID int64, keyType *keychain.KeyType) (list []*keychain.KeyRecord, err error)
 	GetKey(ctx context.Context, userID int64, keyUUID string) (record *keychain.KeyRecord, decryptedData []byte, err error)
 	DeleteKey(ctx context.Context, userID int64, keyUUID string) error
+	GetChanges(ctx context.Context, userID int64, since int64, withData bool) (*keychain.ChangeSet, error)
 	AddCredential(ctx context.Context, userID int64, in dto.AddCredentialsDTO) (string, error)
 	AddCard(ctx context.Context, userID int64, in dto.AddCardDTO) (string, error)
 	AddText(ctx context.Context, userID int64, in dto.AddTextDTO) (string, error)
 	AddFile(ctx context.Context, userID int64, in dto.AddFileDTO) (string, error)
+	Batch(ctx context.Context, userID int64, mode keychain.BatchMode, ops []*dto.BatchOperation) ([]keychain.BatchResult, error)
+	GetVaultKeys(ctx context.Context, userID int64, vaultUUID string, keyType *keychain.KeyType) (list []*keychain.KeyRecord, err error)
+	GetVaultKey(ctx context.Context, userID int64, vaultUUID string, keyUUID string) (record *keychain.KeyRecord, decryptedData []byte, err error)
+	DeleteVaultKey(ctx context.Context, userID int64, vaultUUID string, keyUUID string) error
 }
 
+// KeyGrantReader lists the entries granted to a service account.
+type KeyGrantReader interface {
+	GrantedKeyIDs(ctx context.Context, clientID uuid.UUID) ([]int64, error)
+}
+
+// KeychainService stores and reads encrypted keychain entries.
+//
+// Every operation is authorized against the principal of the request stored
+// in ctx by the auth middleware: a session user may do anything with their
+// entries, a personal access token is limited by its scopes and a service
+// account may only read the entries granted to it.
+//
+// Entries of a shared vault additionally require a role in the vault: any
+// role to read, editor or above to add and delete. Service accounts are
+// only granted personal entries and cannot reach shared vaults. The entry
+// operations without a vault work on the personal entries of the user; the
+// Add methods store into the vault named in their input, if any.
+//
+// Reading, adding, updating and deleting an entry is recorded in the audit
+// log, including denied attempts. A successful operation whose event cannot
+// be recorded fails. Successful reads and deletions are also published to
+// the webhooks of the user.
 type KeychainService struct {
 	keychainRepo keychain.KeychainRepository
+	grants       KeyGrantReader
+	vaults       VaultResolver
+	auditor      AuditRecorder
+	webhooks     WebhookPublisher
 	cryptManager CryptManager
 }
 
-func NewKeychainService(keychainRepo keychain.KeychainRepository, cManager CryptManager) KeychainService {
+func NewKeychainService(keychainRepo keychain.KeychainRepository, grants KeyGrantReader, vaults VaultResolver, auditor AuditRecorder, webhooks WebhookPublisher, cManager CryptManager) KeychainService {
 	return KeychainService{
 		keychainRepo: keychainRepo,
+		grants:       grants,
+		vaults:       vaults,
+		auditor:      auditor,
+		webhooks:     webhooks,
 		cryptManager: cManager,
 	}
 }
@@ -40,32 +88,171 @@ func (s *KeychainService) GetKeys(ctx context.Context, userID int64, keyType *ke
 		t := string(*keyType)
 		typ = &t
 	}
-	return s.keychainRepo.GetUserKeys(ctx, userID, typ)
+
+	access, err := s.access(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if access.p.Kind == principal.KindPersonalToken && !access.p.Scopes.AllowsAction(pat.ActionRead) {
+		return nil, pat.ErrInsufficientScope
+	}
+
+	list, err = s.keychainRepo.GetUserKeys(ctx, userID, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.p.Interactive() {
+		return list, nil
+	}
+
+	// tokens and service accounts only see the entries they are allowed to read
+	allowed := make([]*keychain.KeyRecord, 0, len(list))
+	for _, rec := range list {
+		if access.check(pat.ActionRead, rec) == nil {
+			allowed = append(allowed, rec)
+		}
+	}
+
+	return allowed, nil
 }
 
+// GetKey returns the entry and its decrypted data.
+//
+// An entry the principal may not read is reported as sql.ErrNoRows like a
+// missing one, as GetKeys leaves it out, so the answer does not tell which
+// entries exist. The audit log still records the denial.
 func (s *KeychainService) GetKey(ctx context.Context, userID int64, keyUUID string) (record *keychain.KeyRecord, decryptedData []byte, err error) {
+	defer func() {
+		err = s.audit(ctx, userID, audit.ActionKeyRead, keyUUID, err)
+		if err != nil {
+			record, decryptedData = nil, nil
+		}
+
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			err = sql.ErrNoRows
+		}
+	}()
+
 	keyRecord, err := s.keychainRepo.GetUserKey(ctx, userID, keyUUID)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	access, err := s.access(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := access.check(pat.ActionRead, keyRecord); err != nil {
+		return nil, nil, err
+	}
+
 	decryptedData, err = s.cryptManager.Decrypt(keyRecord.Nonce, keyRecord.Data)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	publishWebhook(ctx, s.webhooks, keyEvent(webhook.EventKeyRead, userID, keyRecord))
+
 	return keyRecord, decryptedData, nil
 }
 
-func (s *KeychainService) DeleteKey(ctx context.Context, userID int64, keyUUID string) error {
-	return s.keychainRepo.DeleteKey(ctx, userID, keyUUID)
+func (s *KeychainService) DeleteKey(ctx context.Context, userID int64, keyUUID string) (err error) {
+	defer func() {
+		err = s.audit(ctx, userID, audit.ActionKeyDelete, keyUUID, err)
+	}()
+
+	access, err := s.access(ctx)
+	if err != nil {
+		return err
+	}
+
+	keyRecord, err := s.keychainRepo.GetUserKey(ctx, userID, keyUUID)
+	if err != nil {
+		return err
+	}
+	if err := access.check(pat.ActionWrite, keyRecord); err != nil {
+		return err
+	}
+
+	if err := s.keychainRepo.DeleteKey(ctx, userID, keyUUID); err != nil {
+		return err
+	}
+
+	publishWebhook(ctx, s.webhooks, keyEvent(webhook.EventKeyDelete, userID, keyRecord))
+
+	return nil
+}
+
+// GetChanges returns the changes of the entries the user sees after the
+// revision since, at most keychain.MaxChanges of them: personal entries and
+// entries of the vaults the user has a role in. With withData the live
+// entries carry their decrypted data, and each of them is audited and
+// published as a read like GetKey does.
+//
+// Tokens and service accounts only see the changes of entries they may read;
+// vault entries are left out for service accounts and emergency contacts.
+func (s *KeychainService) GetChanges(ctx context.Context, userID int64, since int64, withData bool) (*keychain.ChangeSet, error) {
+	access, err := s.access(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if access.p.Kind == principal.KindPersonalToken && !access.p.Scopes.AllowsAction(pat.ActionRead) {
+		return nil, pat.ErrInsufficientScope
+	}
+
+	// one more than returned tells whether there are more
+	records, current, err := s.keychainRepo.GetUserChanges(ctx, userID, since, keychain.MaxChanges+1, withData)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &keychain.ChangeSet{Revision: current}
+	if len(records) > keychain.MaxChanges {
+		records = records[:keychain.MaxChanges]
+		set.More = true
+		set.Revision = records[len(records)-1].Revision
+	}
+
+	set.Changes = make([]keychain.Change, 0, len(records))
+	for _, rec := range records {
+		if access.check(pat.ActionRead, rec) != nil {
+			continue
+		}
+
+		change := keychain.Change{Record: rec}
+		if withData && !rec.Deleted {
+			change.Data, err = s.cryptManager.Decrypt(rec.Nonce, rec.Data)
+			if err = s.audit(ctx, userID, audit.ActionKeyRead, rec.KeyUUID.String(), err); err != nil {
+				return nil, err
+			}
+
+			publishWebhook(ctx, s.webhooks, keyEvent(webhook.EventKeyRead, userID, rec))
+		}
+
+		set.Changes = append(set.Changes, change)
+	}
+
+	return set, nil
 }
 
-func (s *KeychainService) AddCredential(ctx context.Context, userID int64, in dto.AddCredentialsDTO) (string, error) {
-	if err := keychain.ValidateTitle(in.Title); err != nil {
+func (s *KeychainService) AddCredential(ctx context.Context, userID int64, in dto.AddCredentialsDTO) (keyUUID string, err error) {
+	defer func() {
+		err = s.audit(ctx, userID, audit.ActionKeyAdd, keyUUID, err)
+		if err != nil {
+			keyUUID = ""
+		}
+	}()
+
+	if err = keychain.ValidateTitle(in.Title); err != nil {
 		return "", err
 	}
-	if err := keychain.ValidateCredential(in.Login); err != nil {
+	if err = keychain.ValidateCredential(in.Login); err != nil {
+		return "", err
+	}
+	tags, err := s.prepareTags(ctx, keychain.KeyCredential, in.Tags)
+	if err != nil {
 		return "", err
 	}
 
@@ -86,7 +273,7 @@ func (s *KeychainService) AddCredential(ctx context.Context, userID int64, in dt
 		return "", err
 	}
 
-	uuid, err := s.keychainRepo.AddKey(ctx, userID, keychain.KeyCredential, in.Title, ct, nonce)
+	uuid, err := s.storeKey(ctx, userID, in.Vault, keychain.KeyCredential, in.Title, tags, ct, nonce)
 	if err != nil {
 		return "", err
 	}
@@ -94,11 +281,22 @@ func (s *KeychainService) AddCredential(ctx context.Context, userID int64, in dt
 	return uuid, nil
 }
 
-func (s *KeychainService) AddCard(ctx context.Context, userID int64, in dto.AddCardDTO) (string, error) {
-	if err := keychain.ValidateTitle(in.Title); err != nil {
+func (s *KeychainService) AddCard(ctx context.Context, userID int64, in dto.AddCardDTO) (keyUUID string, err error) {
+	defer func() {
+		err = s.audit(ctx, userID, audit.ActionKeyAdd, keyUUID, err)
+		if err != nil {
+			keyUUID = ""
+		}
+	}()
+
+	if err = keychain.ValidateTitle(in.Title); err != nil {
 		return "", err
 	}
-	if err := keychain.ValidateCard(in.Number, in.CVV); err != nil {
+	if err = keychain.ValidateCard(in.Number, in.CVV); err != nil {
+		return "", err
+	}
+	tags, err := s.prepareTags(ctx, keychain.KeyBankCard, in.Tags)
+	if err != nil {
 		return "", err
 	}
 
@@ -121,7 +319,7 @@ func (s *KeychainService) AddCard(ctx context.Context, userID int64, in dto.AddC
 		return "", err
 	}
 
-	uuid, err := s.keychainRepo.AddKey(ctx, userID, keychain.KeyBankCard, in.Title, ct, nonce)
+	uuid, err := s.storeKey(ctx, userID, in.Vault, keychain.KeyBankCard, in.Title, tags, ct, nonce)
 	if err != nil {
 		return "", err
 	}
@@ -129,11 +327,22 @@ func (s *KeychainService) AddCard(ctx context.Context, userID int64, in dto.AddC
 	return uuid, nil
 }
 
-func (s *KeychainService) AddText(ctx context.Context, userID int64, in dto.AddTextDTO) (string, error) {
-	if err := keychain.ValidateTitle(in.Title); err != nil {
+func (s *KeychainService) AddText(ctx context.Context, userID int64, in dto.AddTextDTO) (keyUUID string, err error) {
+	defer func() {
+		err = s.audit(ctx, userID, audit.ActionKeyAdd, keyUUID, err)
+		if err != nil {
+			keyUUID = ""
+		}
+	}()
+
+	if err = keychain.ValidateTitle(in.Title); err != nil {
+		return "", err
+	}
+	if err = keychain.ValidateText(in.Text); err != nil {
 		return "", err
 	}
-	if err := keychain.ValidateText(in.Text); err != nil {
+	tags, err := s.prepareTags(ctx, keychain.KeyText, in.Tags)
+	if err != nil {
 		return "", err
 	}
 
@@ -152,7 +361,7 @@ func (s *KeychainService) AddText(ctx context.Context, userID int64, in dto.AddT
 		return "", err
 	}
 
-	uuid, err := s.keychainRepo.AddKey(ctx, userID, keychain.KeyText, in.Title, ct, nonce)
+	uuid, err := s.storeKey(ctx, userID, in.Vault, keychain.KeyText, in.Title, tags, ct, nonce)
 	if err != nil {
 		return "", err
 	}
@@ -160,8 +369,19 @@ func (s *KeychainService) AddText(ctx context.Context, userID int64, in dto.AddT
 	return uuid, nil
 }
 
-func (s *KeychainService) AddFile(ctx context.Context, userID int64, in dto.AddFileDTO) (string, error) {
-	if err := keychain.ValidateTitle(in.Title); err != nil {
+func (s *KeychainService) AddFile(ctx context.Context, userID int64, in dto.AddFileDTO) (keyUUID string, err error) {
+	defer func() {
+		err = s.audit(ctx, userID, audit.ActionKeyAdd, keyUUID, err)
+		if err != nil {
+			keyUUID = ""
+		}
+	}()
+
+	if err = keychain.ValidateTitle(in.Title); err != nil {
+		return "", err
+	}
+	tags, err := s.prepareTags(ctx, keychain.KeyFile, in.Tags)
+	if err != nil {
 		return "", err
 	}
 
@@ -180,10 +400,488 @@ func (s *KeychainService) AddFile(ctx context.Context, userID int64, in dto.AddF
 		return "", err
 	}
 
-	uuid, err := s.keychainRepo.AddKey(ctx, userID, keychain.KeyFile, in.Title, ct, nonce)
+	uuid, err := s.storeKey(ctx, userID, in.Vault, keychain.KeyFile, in.Title, tags, ct, nonce)
 	if err != nil {
 		return "", err
 	}
 
 	return uuid, nil
 }
+
+// Batch runs ops on the personal entries of the user in one transaction
+// and returns the result of each, in order. In atomic mode nothing is
+// written if any operation fails; in best effort mode the others are still
+// written.
+//
+// Every operation is checked and authorized like the single operations, and
+// encrypted, before anything is written; the items are prepared in parallel.
+// Each operation is audited on its own and deletions are published to the
+// webhooks like DeleteKey does. Only a malformed batch as a whole fails with
+// an error.
+func (s *KeychainService) Batch(ctx context.Context, userID int64, mode keychain.BatchMode, ops []*dto.BatchOperation) ([]keychain.BatchResult, error) {
+	if len(ops) == 0 {
+		return nil, keychain.ErrBatchEmpty
+	}
+	if len(ops) > keychain.MaxBatchOperations {
+		return nil, keychain.ErrBatchTooLarge
+	}
+	if mode != keychain.BatchAtomic && mode != keychain.BatchBestEffort {
+		return nil, keychain.ErrBatchMode
+	}
+	atomic := mode == keychain.BatchAtomic
+
+	access, err := s.access(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]keychain.BatchResult, len(ops))
+	writes := make([]*keychain.BatchWrite, len(ops))
+	records := make([]*keychain.KeyRecord, len(ops))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for n := min(runtime.GOMAXPROCS(0), len(ops)); n > 0; n-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				writes[i], records[i], results[i].Err = s.prepareBatchOp(ctx, access, userID, ops[i])
+			}
+		}()
+	}
+	for i := range ops {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	failed := false
+	for i, op := range ops {
+		results[i].Op = op.Op
+		if op.Op != keychain.BatchAdd {
+			results[i].KeyUUID = op.UUID
+		}
+		failed = failed || results[i].Err != nil
+	}
+
+	if !atomic || !failed {
+		pending := make([]*keychain.BatchWrite, 0, len(writes))
+		for _, w := range writes {
+			if w != nil {
+				pending = append(pending, w)
+			}
+		}
+
+		if len(pending) > 0 {
+			if err := s.keychainRepo.WriteBatch(ctx, userID, pending, atomic); err != nil {
+				for _, w := range pending {
+					w.Err = err
+				}
+			}
+		}
+
+		for i, w := range writes {
+			if w == nil {
+				continue
+			}
+			results[i].Err = w.Err
+			failed = failed || w.Err != nil
+			if w.Err == nil && w.Op == keychain.BatchAdd {
+				results[i].KeyUUID = w.KeyUUID.String()
+			}
+		}
+	}
+
+	for i := range results {
+		res := &results[i]
+		if atomic && failed && res.Err == nil {
+			// rolled back, so an added entry does not exist
+			res.Err = keychain.ErrBatchAborted
+			if res.Op == keychain.BatchAdd {
+				res.KeyUUID = ""
+			}
+		}
+
+		// The writes are committed at this point, so a failed audit record
+		// is reported next to the result instead of failing the operation.
+		var action audit.Action
+		switch res.Op {
+		case keychain.BatchAdd:
+			action = audit.ActionKeyAdd
+		case keychain.BatchUpdate:
+			action = audit.ActionKeyUpdate
+		case keychain.BatchDelete:
+			action = audit.ActionKeyDelete
+		default:
+			continue
+		}
+
+		if err := s.audit(ctx, userID, action, res.KeyUUID, res.Err); res.Err == nil {
+			res.AuditErr = err
+		}
+
+		if res.Op == keychain.BatchDelete && res.Err == nil {
+			publishWebhook(ctx, s.webhooks, keyEvent(webhook.EventKeyDelete, userID, records[i]))
+		}
+	}
+
+	return results, nil
+}
+
+// prepareBatchOp checks and authorizes op and encrypts its entry. It returns
+// the write to make and, for an update or a delete, the entry as stored.
+func (s *KeychainService) prepareBatchOp(ctx context.Context, access *keyAccess, userID int64, op *dto.BatchOperation) (*keychain.BatchWrite, *keychain.KeyRecord, error) {
+	w := &keychain.BatchWrite{Op: op.Op}
+
+	var rec *keychain.KeyRecord
+	switch op.Op {
+	case keychain.BatchAdd:
+	case keychain.BatchUpdate, keychain.BatchDelete:
+		keyUUID, err := uuid.Parse(op.UUID)
+		if err != nil {
+			return nil, nil, keychain.ErrBatchKeyUUID
+		}
+		w.KeyUUID = keyUUID
+
+		rec, err = s.keychainRepo.GetUserKey(ctx, userID, op.UUID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := access.check(pat.ActionWrite, rec); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, keychain.ErrBatchOp
+	}
+
+	if op.Op == keychain.BatchDelete {
+		return w, rec, nil
+	}
+
+	plain, err := batchEntry(op, w)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rec != nil && rec.KeyType != w.KeyType {
+		return nil, nil, keychain.ErrBatchTypeChange
+	}
+
+	if w.Tags, err = keychain.NormalizeTags(w.Tags); err != nil {
+		return nil, nil, err
+	}
+	if err := access.check(pat.ActionWrite, &keychain.KeyRecord{KeyType: w.KeyType, Tags: w.Tags}); err != nil {
+		return nil, nil, err
+	}
+
+	if w.Nonce, w.Data, err = s.cryptManager.Encrypt(plain); err != nil {
+		return nil, nil, err
+	}
+
+	return w, rec, nil
+}
+
+// batchEntry validates the single payload of an add or an update, like the
+// Add methods do, and fills the type, title and tags of w from it. It
+// returns the entry data to encrypt.
+func batchEntry(op *dto.BatchOperation, w *keychain.BatchWrite) ([]byte, error) {
+	payloads := 0
+	for _, set := range []bool{op.Credential != nil, op.Card != nil, op.Text != nil, op.File != nil} {
+		if set {
+			payloads++
+		}
+	}
+	if payloads != 1 {
+		return nil, keychain.ErrBatchPayload
+	}
+
+	var vaultUUID string
+	var data any
+	switch {
+	case op.Credential != nil:
+		in := op.Credential
+		if err := keychain.ValidateCredential(in.Login); err != nil {
+			return nil, err
+		}
+		vaultUUID, w.KeyType, w.Title, w.Tags = in.Vault, keychain.KeyCredential, in.Title, in.Tags
+		data = keychain.CredentialData{Login: in.Login, Password: in.Password, Site: in.Site, Note: in.Note}
+	case op.Card != nil:
+		in := op.Card
+		if err := keychain.ValidateCard(in.Number, in.CVV); err != nil {
+			return nil, err
+		}
+		vaultUUID, w.KeyType, w.Title, w.Tags = in.Vault, keychain.KeyBankCard, in.Title, in.Tags
+		data = keychain.CardData{Number: in.Number, ExpDate: in.ExpDate, CVV: in.CVV, Holder: in.Holder, Bank: in.Bank, Note: in.Note}
+	case op.Text != nil:
+		in := op.Text
+		if err := keychain.ValidateText(in.Text); err != nil {
+			return nil, err
+		}
+		vaultUUID, w.KeyType, w.Title, w.Tags = in.Vault, keychain.KeyText, in.Title, in.Tags
+		data = keychain.TextData{Text: in.Text, Note: in.Note}
+	default:
+		in := op.File
+		w.KeyType, w.Title, w.Tags = keychain.KeyFile, in.Title, in.Tags
+		data = keychain.FileData{File: in.Content, Note: in.Note}
+	}
+
+	if vaultUUID != "" {
+		return nil, keychain.ErrBatchVault
+	}
+	if err := keychain.ValidateTitle(w.Title); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(data)
+}
+
+func (s *KeychainService) GetVaultKeys(ctx context.Context, userID int64, vaultUUID string, keyType *keychain.KeyType) (list []*keychain.KeyRecord, err error) {
+	var typ *string
+	if keyType != nil {
+		t := string(*keyType)
+		typ = &t
+	}
+
+	access, err := s.access(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if access.p.Kind == principal.KindPersonalToken && !access.p.Scopes.AllowsAction(pat.ActionRead) {
+		return nil, pat.ErrInsufficientScope
+	}
+
+	v, err := s.vaultFor(ctx, access, userID, vaultUUID, pat.ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err = s.keychainRepo.GetVaultKeys(ctx, v.ID, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.p.Interactive() {
+		return list, nil
+	}
+
+	allowed := make([]*keychain.KeyRecord, 0, len(list))
+	for _, rec := range list {
+		if access.check(pat.ActionRead, rec) == nil {
+			allowed = append(allowed, rec)
+		}
+	}
+
+	return allowed, nil
+}
+
+func (s *KeychainService) GetVaultKey(ctx context.Context, userID int64, vaultUUID string, keyUUID string) (record *keychain.KeyRecord, decryptedData []byte, err error) {
+	defer func() {
+		err = s.audit(ctx, userID, audit.ActionKeyRead, keyUUID, err)
+		if err != nil {
+			record, decryptedData = nil, nil
+		}
+	}()
+
+	access, err := s.access(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v, err := s.vaultFor(ctx, access, userID, vaultUUID, pat.ActionRead)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyRecord, err := s.keychainRepo.GetVaultKey(ctx, v.ID, keyUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := access.check(pat.ActionRead, keyRecord); err != nil {
+		return nil, nil, err
+	}
+
+	decryptedData, err = s.cryptManager.Decrypt(keyRecord.Nonce, keyRecord.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publishWebhook(ctx, s.webhooks, keyEvent(webhook.EventKeyRead, userID, keyRecord))
+
+	return keyRecord, decryptedData, nil
+}
+
+func (s *KeychainService) DeleteVaultKey(ctx context.Context, userID int64, vaultUUID string, keyUUID string) (err error) {
+	defer func() {
+		err = s.audit(ctx, userID, audit.ActionKeyDelete, keyUUID, err)
+	}()
+
+	access, err := s.access(ctx)
+	if err != nil {
+		return err
+	}
+
+	v, err := s.vaultFor(ctx, access, userID, vaultUUID, pat.ActionWrite)
+	if err != nil {
+		return err
+	}
+
+	keyRecord, err := s.keychainRepo.GetVaultKey(ctx, v.ID, keyUUID)
+	if err != nil {
+		return err
+	}
+	if err := access.check(pat.ActionWrite, keyRecord); err != nil {
+		return err
+	}
+
+	if err := s.keychainRepo.DeleteVaultKey(ctx, v.ID, keyUUID); err != nil {
+		return err
+	}
+
+	publishWebhook(ctx, s.webhooks, keyEvent(webhook.EventKeyDelete, userID, keyRecord))
+
+	return nil
+}
+
+// storeKey saves a new encrypted entry among the personal entries of the
+// user or, with vaultUUID set, in that vault.
+func (s *KeychainService) storeKey(ctx context.Context, userID int64, vaultUUID string, keyType keychain.KeyType, title string, tags []string, data []byte, nonce []byte) (string, error) {
+	if vaultUUID == "" {
+		return s.keychainRepo.AddKey(ctx, userID, keyType, title, tags, data, nonce)
+	}
+
+	access, err := s.access(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	v, err := s.vaultFor(ctx, access, userID, vaultUUID, pat.ActionWrite)
+	if err != nil {
+		return "", err
+	}
+
+	return s.keychainRepo.AddVaultKey(ctx, v.ID, keyType, title, tags, data, nonce)
+}
+
+// vaultFor resolves the vault vaultUUID of the user and checks that the role
+// of the user in it allows action.
+func (s *KeychainService) vaultFor(ctx context.Context, access *keyAccess, userID int64, vaultUUID string, action pat.Action) (*vault.Vault, error) {
+	switch access.p.Kind {
+	case principal.KindServiceAccount:
+		return nil, serviceaccount.ErrNotGranted
+	case principal.KindEmergencyContact:
+		return nil, emergency.ErrReadOnly
+	}
+
+	id, err := uuid.Parse(vaultUUID)
+	if err != nil {
+		return nil, vault.ErrNotFound
+	}
+
+	v, err := s.vaults.GetVault(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := v.Role.CanRead()
+	if action != pat.ActionRead {
+		allowed = v.Role.CanWrite()
+	}
+	if !allowed {
+		return nil, vault.ErrInsufficientRole
+	}
+
+	return v, nil
+}
+
+// audit records an operation on the entry keyUUID that finished with opErr
+// and returns the error to report to the caller.
+func (s *KeychainService) audit(ctx context.Context, userID int64, action audit.Action, keyUUID string, opErr error) error {
+	return recordAudit(ctx, s.auditor, audit.Event{
+		UserID:  userID,
+		Action:  action,
+		KeyUUID: parseAuditKey(keyUUID),
+	}, opErr)
+}
+
+// keyEvent describes an operation on the entry rec of the user for webhooks.
+func keyEvent(t webhook.EventType, userID int64, rec *keychain.KeyRecord) webhook.Event {
+	keyUUID := rec.KeyUUID
+	return webhook.Event{Type: t, UserID: userID, KeyUUID: &keyUUID, Tags: rec.Tags}
+}
+
+// prepareTags normalizes the tags of a new entry and checks that the
+// principal may write an entry of that type with those tags.
+func (s *KeychainService) prepareTags(ctx context.Context, keyType keychain.KeyType, raw []string) ([]string, error) {
+	tags, err := keychain.NormalizeTags(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := s.access(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := access.check(pat.ActionWrite, &keychain.KeyRecord{KeyType: keyType, Tags: tags}); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// keyAccess decides what the principal of a request may do with entries.
+type keyAccess struct {
+	p       principal.Principal
+	granted map[int64]struct{}
+}
+
+// access resolves the principal stored in ctx. Calls without one are treated
+// as made by the user; service accounts get their grants loaded.
+func (s *KeychainService) access(ctx context.Context) (*keyAccess, error) {
+	return resolveKeyAccess(ctx, s.grants)
+}
+
+func resolveKeyAccess(ctx context.Context, grants KeyGrantReader) (*keyAccess, error) {
+	p, ok := principal.FromContext(ctx)
+	if !ok {
+		p = principal.Principal{Kind: principal.KindUser}
+	}
+
+	access := &keyAccess{p: p}
+	if p.Kind != principal.KindServiceAccount {
+		return access, nil
+	}
+
+	ids, err := grants.GrantedKeyIDs(ctx, p.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	access.granted = make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		access.granted[id] = struct{}{}
+	}
+
+	return access, nil
+}
+
+// check reports whether action on rec is allowed.
+func (a *keyAccess) check(action pat.Action, rec *keychain.KeyRecord) error {
+	switch a.p.Kind {
+	case principal.KindPersonalToken:
+		if !a.p.Scopes.Allows(action, rec.KeyType, rec.Tags) {
+			return pat.ErrInsufficientScope
+		}
+	case principal.KindServiceAccount:
+		if action != pat.ActionRead {
+			return serviceaccount.ErrReadOnly
+		}
+		if _, ok := a.granted[rec.ID]; !ok || rec.InVault() {
+			return serviceaccount.ErrNotGranted
+		}
+	case principal.KindEmergencyContact:
+		if action != pat.ActionRead || rec.InVault() {
+			return emergency.ErrReadOnly
+		}
+	}
+	return nil
+}
@@ -2,11 +2,19 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/emergency"
 	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"github.com/thxhix/passKeeper/internal/domain/principal"
+	"github.com/thxhix/passKeeper/internal/domain/serviceaccount"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
 	"github.com/thxhix/passKeeper/internal/mocks"
 	"github.com/thxhix/passKeeper/internal/transport/http/dto"
 	"testing"
@@ -16,7 +24,7 @@ import (
 func TestKeychainService_GetKeys_Success(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -45,7 +53,7 @@ func TestKeychainService_GetKeys_Success(t *testing.T) {
 func TestKeychainService_GetKeys_Empty(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -64,7 +72,7 @@ func TestKeychainService_GetKeys_Empty(t *testing.T) {
 func TestKeychainService_GetKeys_Error(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -83,7 +91,7 @@ func TestKeychainService_GetKeys_Error(t *testing.T) {
 func TestKeychainService_GetKey_Success(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -110,10 +118,54 @@ func TestKeychainService_GetKey_Success(t *testing.T) {
 	mockCryptManager.AssertExpectations(t)
 }
 
+func TestKeychainService_GetKey_Audited(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	auditor := new(mocks.AuditServiceMock)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), auditor, nopPublisher(), mockCryptManager)
+
+	ctx := context.Background()
+	keyUUID := uuid.New()
+	retObj := &keychain.KeyRecord{ID: 1, KeyUUID: keyUUID, UserID: 1, Data: []byte{1, 2, 3}, Nonce: []byte{4, 5, 6}}
+
+	mockKeychainRepo.On("GetUserKey", ctx, int64(1), keyUUID.String()).Return(retObj, nil)
+	mockCryptManager.On("Decrypt", retObj.Nonce, retObj.Data).Return(retObj.Data, nil)
+	auditor.On("Record", ctx, audit.Event{UserID: 1, Action: audit.ActionKeyRead, KeyUUID: keyUUID, Result: audit.ResultSuccess}).
+		Return(errors.New("audit down"))
+
+	record, data, err := s.GetKey(ctx, 1, keyUUID.String())
+
+	assert.Error(t, err)
+	assert.Nil(t, record)
+	assert.Nil(t, data)
+	auditor.AssertExpectations(t)
+}
+
+func TestKeychainService_GetKey_Denied_Is_Audited(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	auditor := new(mocks.AuditServiceMock)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), auditor, nopPublisher(), new(mocks.CryptManager))
+
+	scopes, _ := pat.ParseScopes([]string{"read:card"})
+	ctx := principal.WithPrincipal(context.Background(), principal.PersonalToken(1, scopes))
+	keyUUID := uuid.New()
+	retObj := &keychain.KeyRecord{ID: 1, KeyUUID: keyUUID, UserID: 1, KeyType: keychain.KeyCredential}
+
+	mockKeychainRepo.On("GetUserKey", ctx, int64(1), keyUUID.String()).Return(retObj, nil)
+	auditor.On("Record", ctx, audit.Event{UserID: 1, Action: audit.ActionKeyRead, KeyUUID: keyUUID, Result: audit.ResultDenied}).
+		Return(nil)
+
+	_, _, err := s.GetKey(ctx, 1, keyUUID.String())
+
+	// reported like a missing entry
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	auditor.AssertExpectations(t)
+}
+
 func TestKeychainService_GetKey_Decrypt_Error(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -142,7 +194,7 @@ func TestKeychainService_GetKey_Decrypt_Error(t *testing.T) {
 func TestKeychainService_GetKey_Get_Error(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -169,26 +221,33 @@ func TestKeychainService_GetKey_Get_Error(t *testing.T) {
 func TestKeychainService_DeleteKey_Success(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	publisher := new(mocks.WebhookServiceMock)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), publisher, mockCryptManager)
 
 	ctx := context.Background()
+	keyUUID := uuid.New()
+	retObj := &keychain.KeyRecord{ID: 1, KeyUUID: keyUUID, UserID: 1, Tags: []string{"prod"}}
 
+	mockKeychainRepo.On("GetUserKey", ctx, int64(1), "12345").Return(retObj, nil)
 	mockKeychainRepo.On("DeleteKey", ctx, int64(1), mock.Anything).Return(nil)
+	publisher.On("Publish", ctx, webhook.Event{Type: webhook.EventKeyDelete, UserID: 1, KeyUUID: &keyUUID, Tags: []string{"prod"}}).Return(nil)
 
 	err := s.DeleteKey(ctx, 1, "12345")
 
 	assert.NoError(t, err)
 	mockKeychainRepo.AssertExpectations(t)
 	mockCryptManager.AssertExpectations(t)
+	publisher.AssertExpectations(t)
 }
 
 func TestKeychainService_DeleteKey_Error(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
+	mockKeychainRepo.On("GetUserKey", ctx, int64(1), "12345").Return(&keychain.KeyRecord{ID: 1, UserID: 1}, nil)
 	mockKeychainRepo.On("DeleteKey", ctx, int64(1), mock.Anything).Return(errors.New("some error"))
 
 	err := s.DeleteKey(ctx, 1, "12345")
@@ -201,7 +260,7 @@ func TestKeychainService_DeleteKey_Error(t *testing.T) {
 func TestKeychainService_AddCredential_Success(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -220,6 +279,7 @@ func TestKeychainService_AddCredential_Success(t *testing.T) {
 		int64(1),
 		kt,
 		"Title",
+		[]string{},
 		mock.AnythingOfType("[]uint8"),
 		mock.AnythingOfType("[]uint8"),
 	).Return("1", nil)
@@ -243,7 +303,7 @@ func TestKeychainService_AddCredential_Success(t *testing.T) {
 func TestKeychainService_AddCredential_Error(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -262,6 +322,7 @@ func TestKeychainService_AddCredential_Error(t *testing.T) {
 		int64(1),
 		kt,
 		"Title",
+		[]string{},
 		mock.AnythingOfType("[]uint8"),
 		mock.AnythingOfType("[]uint8"),
 	).Return("", errors.New("some error"))
@@ -285,7 +346,7 @@ func TestKeychainService_AddCredential_Error(t *testing.T) {
 func TestKeychainService_AddCredential_Validate_Error(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -306,7 +367,7 @@ func TestKeychainService_AddCredential_Validate_Error(t *testing.T) {
 func TestKeychainService_AddCard_Success(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -325,6 +386,7 @@ func TestKeychainService_AddCard_Success(t *testing.T) {
 		int64(1),
 		kt,
 		"Title",
+		[]string{},
 		mock.AnythingOfType("[]uint8"),
 		mock.AnythingOfType("[]uint8"),
 	).Return("1", nil)
@@ -350,7 +412,7 @@ func TestKeychainService_AddCard_Success(t *testing.T) {
 func TestKeychainService_AddCard_Error(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -369,6 +431,7 @@ func TestKeychainService_AddCard_Error(t *testing.T) {
 		int64(1),
 		kt,
 		"Title",
+		[]string{},
 		mock.AnythingOfType("[]uint8"),
 		mock.AnythingOfType("[]uint8"),
 	).Return("", errors.New("some error"))
@@ -394,7 +457,7 @@ func TestKeychainService_AddCard_Error(t *testing.T) {
 func TestKeychainService_AddCard_Validate_Error(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -417,7 +480,7 @@ func TestKeychainService_AddCard_Validate_Error(t *testing.T) {
 func TestKeychainService_AddText_Success(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -436,6 +499,7 @@ func TestKeychainService_AddText_Success(t *testing.T) {
 		int64(1),
 		kt,
 		"Title",
+		[]string{},
 		mock.AnythingOfType("[]uint8"),
 		mock.AnythingOfType("[]uint8"),
 	).Return("1", nil)
@@ -457,7 +521,7 @@ func TestKeychainService_AddText_Success(t *testing.T) {
 func TestKeychainService_AddText_Error(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -476,6 +540,7 @@ func TestKeychainService_AddText_Error(t *testing.T) {
 		int64(1),
 		kt,
 		"Title",
+		[]string{},
 		mock.AnythingOfType("[]uint8"),
 		mock.AnythingOfType("[]uint8"),
 	).Return("", errors.New("some error"))
@@ -497,7 +562,7 @@ func TestKeychainService_AddText_Error(t *testing.T) {
 func TestKeychainService_AddText_Validate_Error(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -516,7 +581,7 @@ func TestKeychainService_AddText_Validate_Error(t *testing.T) {
 func TestKeychainService_AddFile_Success(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -535,6 +600,7 @@ func TestKeychainService_AddFile_Success(t *testing.T) {
 		int64(1),
 		kt,
 		"Title",
+		[]string{},
 		mock.AnythingOfType("[]uint8"),
 		mock.AnythingOfType("[]uint8"),
 	).Return("1", nil)
@@ -556,7 +622,7 @@ func TestKeychainService_AddFile_Success(t *testing.T) {
 func TestKeychainService_AddFile_Error(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -575,6 +641,7 @@ func TestKeychainService_AddFile_Error(t *testing.T) {
 		int64(1),
 		kt,
 		"Title",
+		[]string{},
 		mock.AnythingOfType("[]uint8"),
 		mock.AnythingOfType("[]uint8"),
 	).Return("", errors.New("some error"))
@@ -596,7 +663,7 @@ func TestKeychainService_AddFile_Error(t *testing.T) {
 func TestKeychainService_AddFile_Validate_Error(t *testing.T) {
 	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
 	mockCryptManager := new(mocks.CryptManager)
-	s := NewKeychainService(mockKeychainRepo, mockCryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
 
 	ctx := context.Background()
 
@@ -611,3 +678,508 @@ func TestKeychainService_AddFile_Validate_Error(t *testing.T) {
 	mockKeychainRepo.AssertExpectations(t)
 	mockCryptManager.AssertExpectations(t)
 }
+
+func TestKeychainService_AddText_NormalizesTags(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	ctx := context.Background()
+
+	mockCryptManager.On("Encrypt", mock.Anything).Return([]byte{1, 2, 3}, []byte{4, 5, 6}, nil)
+	mockKeychainRepo.On("AddKey", ctx, int64(1), keychain.KeyText, "Title", []string{"prod", "ci"}, mock.Anything, mock.Anything).Return("1", nil)
+
+	id, err := s.AddText(ctx, 1, dto.AddTextDTO{Title: "Title", Text: "text", Tags: []string{" Prod", "ci", "prod"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", id)
+	mockKeychainRepo.AssertExpectations(t)
+}
+
+func TestKeychainService_AddText_InvalidTag(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	_, err := s.AddText(context.Background(), 1, dto.AddTextDTO{Title: "Title", Text: "text", Tags: []string{"bad tag"}})
+
+	assert.ErrorIs(t, err, keychain.ErrTagInvalid)
+	mockKeychainRepo.AssertNotCalled(t, "AddKey")
+}
+
+func scopedContext(t *testing.T, raw ...string) context.Context {
+	t.Helper()
+
+	scopes, err := pat.ParseScopes(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return principal.WithPrincipal(context.Background(), principal.PersonalToken(1, scopes))
+}
+
+func TestKeychainService_GetKeys_FiltersByScope(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	ctx := scopedContext(t, "read:tag:prod")
+
+	prod := &keychain.KeyRecord{ID: 1, KeyType: keychain.KeyText, Tags: []string{"prod"}}
+	dev := &keychain.KeyRecord{ID: 2, KeyType: keychain.KeyText, Tags: []string{"dev"}}
+	mockKeychainRepo.On("GetUserKeys", ctx, int64(1), mock.Anything).Return([]*keychain.KeyRecord{prod, dev}, nil)
+
+	list, err := s.GetKeys(ctx, 1, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*keychain.KeyRecord{prod}, list)
+}
+
+func TestKeychainService_GetKeys_WriteOnlyScope(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	ctx := scopedContext(t, "write")
+	mockKeychainRepo.On("GetUserKeys", ctx, int64(1), mock.Anything).Return([]*keychain.KeyRecord{}, nil)
+
+	_, err := s.GetKeys(ctx, 1, nil)
+
+	assert.ErrorIs(t, err, pat.ErrInsufficientScope)
+}
+
+func TestKeychainService_GetKey_OutOfScope(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	ctx := scopedContext(t, "read:credential")
+	rec := &keychain.KeyRecord{ID: 1, KeyType: keychain.KeyText}
+	mockKeychainRepo.On("GetUserKey", ctx, int64(1), "uuid").Return(rec, nil)
+
+	_, _, err := s.GetKey(ctx, 1, "uuid")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	mockCryptManager.AssertNotCalled(t, "Decrypt", mock.Anything, mock.Anything)
+}
+
+func TestKeychainService_DeleteKey_Scoped(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	ctx := scopedContext(t, "read", "write:tag:ci")
+	mockKeychainRepo.On("GetUserKey", ctx, int64(1), "ci").Return(&keychain.KeyRecord{KeyType: keychain.KeyText, Tags: []string{"ci"}}, nil)
+	mockKeychainRepo.On("GetUserKey", ctx, int64(1), "prod").Return(&keychain.KeyRecord{KeyType: keychain.KeyText, Tags: []string{"prod"}}, nil)
+	mockKeychainRepo.On("DeleteKey", ctx, int64(1), "ci").Return(nil)
+
+	assert.NoError(t, s.DeleteKey(ctx, 1, "ci"))
+	assert.ErrorIs(t, s.DeleteKey(ctx, 1, "prod"), pat.ErrInsufficientScope)
+	mockKeychainRepo.AssertNotCalled(t, "DeleteKey", ctx, int64(1), "prod")
+}
+
+func TestKeychainService_AddText_ScopeRequiresTag(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	ctx := scopedContext(t, "write:tag:ci")
+
+	_, err := s.AddText(ctx, 1, dto.AddTextDTO{Title: "Title", Text: "text"})
+
+	assert.ErrorIs(t, err, pat.ErrInsufficientScope)
+	mockCryptManager.AssertNotCalled(t, "Encrypt", mock.Anything)
+}
+
+func TestKeychainService_ServiceAccount(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockGrants := new(mocks.ServiceAccountRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, mockGrants, new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	clientID := uuid.New()
+	ctx := principal.WithPrincipal(context.Background(), principal.ServiceAccount(1, clientID))
+
+	granted := &keychain.KeyRecord{ID: 1, KeyType: keychain.KeyText, Data: []byte{1}, Nonce: []byte{2}}
+	other := &keychain.KeyRecord{ID: 2, KeyType: keychain.KeyText}
+
+	mockGrants.On("GrantedKeyIDs", ctx, clientID).Return([]int64{1}, nil)
+	mockKeychainRepo.On("GetUserKeys", ctx, int64(1), mock.Anything).Return([]*keychain.KeyRecord{granted, other}, nil)
+	mockKeychainRepo.On("GetUserKey", ctx, int64(1), "granted").Return(granted, nil)
+	mockKeychainRepo.On("GetUserKey", ctx, int64(1), "other").Return(other, nil)
+	mockCryptManager.On("Decrypt", granted.Nonce, granted.Data).Return([]byte("plain"), nil)
+
+	list, err := s.GetKeys(ctx, 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*keychain.KeyRecord{granted}, list)
+
+	_, plain, err := s.GetKey(ctx, 1, "granted")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("plain"), plain)
+
+	_, _, err = s.GetKey(ctx, 1, "other")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	assert.ErrorIs(t, s.DeleteKey(ctx, 1, "granted"), serviceaccount.ErrReadOnly)
+	mockKeychainRepo.AssertNotCalled(t, "DeleteKey", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err = s.AddText(ctx, 1, dto.AddTextDTO{Title: "Title", Text: "text"})
+	assert.ErrorIs(t, err, serviceaccount.ErrReadOnly)
+}
+
+func TestKeychainService_EmergencyContact_ReadOnly(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	ctx := principal.WithPrincipal(context.Background(), principal.EmergencyContact(1, uuid.New()))
+
+	rec := &keychain.KeyRecord{ID: 1, KeyType: keychain.KeyText, Data: []byte{1}, Nonce: []byte{2}}
+
+	mockKeychainRepo.On("GetUserKeys", ctx, int64(1), mock.Anything).Return([]*keychain.KeyRecord{rec}, nil)
+	mockKeychainRepo.On("GetUserKey", ctx, int64(1), "key").Return(rec, nil)
+	mockCryptManager.On("Decrypt", rec.Nonce, rec.Data).Return([]byte("plain"), nil)
+
+	list, err := s.GetKeys(ctx, 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*keychain.KeyRecord{rec}, list)
+
+	_, plain, err := s.GetKey(ctx, 1, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("plain"), plain)
+
+	assert.ErrorIs(t, s.DeleteKey(ctx, 1, "key"), emergency.ErrReadOnly)
+	mockKeychainRepo.AssertNotCalled(t, "DeleteKey", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err = s.GetVaultKeys(ctx, 1, uuid.NewString(), nil)
+	assert.ErrorIs(t, err, emergency.ErrReadOnly)
+}
+
+func TestKeychainService_Vault_Viewer(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockVaults := new(mocks.VaultRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), mockVaults, nopAuditor(), nopPublisher(), mockCryptManager)
+
+	ctx := context.Background()
+	vaultUUID := uuid.New()
+	keyUUID := uuid.NewString()
+	rec := &keychain.KeyRecord{ID: 1, KeyUUID: uuid.MustParse(keyUUID), KeyType: keychain.KeyText, Data: []byte{1}, Nonce: []byte{2}}
+
+	mockVaults.On("GetVault", ctx, int64(1), vaultUUID).Return(&vault.Vault{ID: 5, Role: vault.RoleViewer}, nil)
+	mockKeychainRepo.On("GetVaultKeys", ctx, int64(5), (*string)(nil)).Return([]*keychain.KeyRecord{rec}, nil)
+	mockKeychainRepo.On("GetVaultKey", ctx, int64(5), keyUUID).Return(rec, nil)
+	mockCryptManager.On("Decrypt", rec.Nonce, rec.Data).Return([]byte("plain"), nil)
+
+	list, err := s.GetVaultKeys(ctx, 1, vaultUUID.String(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*keychain.KeyRecord{rec}, list)
+
+	_, plain, err := s.GetVaultKey(ctx, 1, vaultUUID.String(), keyUUID)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("plain"), plain)
+
+	assert.ErrorIs(t, s.DeleteVaultKey(ctx, 1, vaultUUID.String(), keyUUID), vault.ErrInsufficientRole)
+	mockKeychainRepo.AssertNotCalled(t, "DeleteVaultKey", mock.Anything, mock.Anything, mock.Anything)
+
+	mockCryptManager.On("Encrypt", mock.Anything).Return([]byte{1}, []byte{2}, nil)
+	_, err = s.AddText(ctx, 1, dto.AddTextDTO{Vault: vaultUUID.String(), Title: "Title", Text: "text"})
+	assert.ErrorIs(t, err, vault.ErrInsufficientRole)
+	mockKeychainRepo.AssertNotCalled(t, "AddVaultKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestKeychainService_Vault_Editor_AddText(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockVaults := new(mocks.VaultRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), mockVaults, nopAuditor(), nopPublisher(), mockCryptManager)
+
+	ctx := context.Background()
+	vaultUUID := uuid.New()
+
+	mockVaults.On("GetVault", ctx, int64(1), vaultUUID).Return(&vault.Vault{ID: 5, Role: vault.RoleEditor}, nil)
+	mockCryptManager.On("Encrypt", mock.Anything).Return([]byte{1}, []byte{2}, nil)
+	mockKeychainRepo.On("AddVaultKey", ctx, int64(5), keychain.KeyText, "Title", []string{}, mock.AnythingOfType("[]uint8"), mock.AnythingOfType("[]uint8")).
+		Return("key", nil)
+
+	keyUUID, err := s.AddText(ctx, 1, dto.AddTextDTO{Vault: vaultUUID.String(), Title: "Title", Text: "text"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "key", keyUUID)
+	mockKeychainRepo.AssertNotCalled(t, "AddKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestKeychainService_Vault_NotFound(t *testing.T) {
+	mockVaults := new(mocks.VaultRepositoryMock)
+	s := NewKeychainService(new(mocks.KeychainRepositoryMock), new(mocks.ServiceAccountRepositoryMock), mockVaults, nopAuditor(), nopPublisher(), new(mocks.CryptManager))
+
+	ctx := context.Background()
+	vaultUUID := uuid.New()
+
+	mockVaults.On("GetVault", ctx, int64(1), vaultUUID).Return((*vault.Vault)(nil), vault.ErrNotFound)
+
+	_, err := s.GetVaultKeys(ctx, 1, vaultUUID.String(), nil)
+	assert.ErrorIs(t, err, vault.ErrNotFound)
+
+	_, err = s.GetVaultKeys(ctx, 1, "not-a-uuid", nil)
+	assert.ErrorIs(t, err, vault.ErrNotFound)
+}
+
+func TestKeychainService_Vault_ServiceAccount(t *testing.T) {
+	mockGrants := new(mocks.ServiceAccountRepositoryMock)
+	mockVaults := new(mocks.VaultRepositoryMock)
+	s := NewKeychainService(new(mocks.KeychainRepositoryMock), mockGrants, mockVaults, nopAuditor(), nopPublisher(), new(mocks.CryptManager))
+
+	clientID := uuid.New()
+	ctx := principal.WithPrincipal(context.Background(), principal.ServiceAccount(1, clientID))
+
+	mockGrants.On("GrantedKeyIDs", ctx, clientID).Return([]int64{1}, nil)
+
+	_, err := s.GetVaultKeys(ctx, 1, uuid.NewString(), nil)
+
+	assert.ErrorIs(t, err, serviceaccount.ErrNotGranted)
+	mockVaults.AssertNotCalled(t, "GetVault", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestKeychainService_GetChanges_WithData(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	ctx := context.Background()
+	live := &keychain.KeyRecord{ID: 1, KeyUUID: uuid.New(), KeyType: keychain.KeyText, Data: []byte{1}, Nonce: []byte{2}, Revision: 3}
+	gone := &keychain.KeyRecord{ID: 2, KeyUUID: uuid.New(), KeyType: keychain.KeyText, Revision: 4, Deleted: true}
+
+	mockKeychainRepo.On("GetUserChanges", ctx, int64(1), int64(2), keychain.MaxChanges+1, true).Return([]*keychain.KeyRecord{live, gone}, int64(5), nil)
+	mockCryptManager.On("Decrypt", live.Nonce, live.Data).Return([]byte(`{"text":"t"}`), nil)
+
+	set, err := s.GetChanges(ctx, 1, 2, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), set.Revision)
+	assert.False(t, set.More)
+	assert.Equal(t, []keychain.Change{{Record: live, Data: []byte(`{"text":"t"}`)}, {Record: gone}}, set.Changes)
+	mockCryptManager.AssertNumberOfCalls(t, "Decrypt", 1)
+}
+
+func TestKeychainService_GetChanges_More(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	ctx := context.Background()
+	records := make([]*keychain.KeyRecord, keychain.MaxChanges+1)
+	for i := range records {
+		records[i] = &keychain.KeyRecord{ID: int64(i + 1), Revision: int64(i + 1)}
+	}
+	mockKeychainRepo.On("GetUserChanges", ctx, int64(1), int64(0), keychain.MaxChanges+1, false).Return(records, int64(900), nil)
+
+	set, err := s.GetChanges(ctx, 1, 0, false)
+
+	assert.NoError(t, err)
+	assert.True(t, set.More)
+	assert.Equal(t, int64(keychain.MaxChanges), set.Revision)
+	assert.Len(t, set.Changes, keychain.MaxChanges)
+	mockCryptManager.AssertNotCalled(t, "Decrypt", mock.Anything, mock.Anything)
+}
+
+func TestKeychainService_GetChanges_FiltersByScope(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	ctx := scopedContext(t, "read:tag:prod")
+	prod := &keychain.KeyRecord{ID: 1, KeyType: keychain.KeyText, Tags: []string{"prod"}, Revision: 1}
+	dev := &keychain.KeyRecord{ID: 2, KeyType: keychain.KeyText, Tags: []string{"dev"}, Revision: 2, Deleted: true}
+	mockKeychainRepo.On("GetUserChanges", ctx, int64(1), int64(0), keychain.MaxChanges+1, false).Return([]*keychain.KeyRecord{prod, dev}, int64(2), nil)
+
+	set, err := s.GetChanges(ctx, 1, 0, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []keychain.Change{{Record: prod}}, set.Changes)
+	assert.Equal(t, int64(2), set.Revision)
+
+	_, err = s.GetChanges(scopedContext(t, "write"), 1, 0, false)
+	assert.ErrorIs(t, err, pat.ErrInsufficientScope)
+}
+
+func TestKeychainService_GetChanges_VaultEntries(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	vaultID, vaultUUID := int64(7), uuid.New()
+	personal := &keychain.KeyRecord{ID: 1, KeyUUID: uuid.New(), UserID: 1, KeyType: keychain.KeyText, Data: []byte{1}, Nonce: []byte{2}, Revision: 3}
+	// a write to a shared vault comes back with the revision recorded for the user
+	shared := &keychain.KeyRecord{ID: 2, KeyUUID: uuid.New(), VaultID: &vaultID, VaultUUID: &vaultUUID, KeyType: keychain.KeyText, Data: []byte{3}, Nonce: []byte{4}, Revision: 4}
+
+	mockKeychainRepo.On("GetUserChanges", mock.Anything, int64(1), int64(2), keychain.MaxChanges+1, true).Return([]*keychain.KeyRecord{personal, shared}, int64(4), nil)
+	mockCryptManager.On("Decrypt", personal.Nonce, personal.Data).Return([]byte(`{"text":"mine"}`), nil)
+	mockCryptManager.On("Decrypt", shared.Nonce, shared.Data).Return([]byte(`{"text":"team"}`), nil)
+
+	set, err := s.GetChanges(context.Background(), 1, 2, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), set.Revision)
+	assert.Equal(t, []keychain.Change{
+		{Record: personal, Data: []byte(`{"text":"mine"}`)},
+		{Record: shared, Data: []byte(`{"text":"team"}`)},
+	}, set.Changes)
+
+	// emergency contacts cannot reach shared vaults
+	ctx := principal.WithPrincipal(context.Background(), principal.EmergencyContact(1, uuid.New()))
+	set, err = s.GetChanges(ctx, 1, 2, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []keychain.Change{{Record: personal, Data: []byte(`{"text":"mine"}`)}}, set.Changes)
+}
+
+func TestKeychainService_Batch_BestEffort(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	publisher := new(mocks.WebhookServiceMock)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), publisher, mockCryptManager)
+
+	ctx := context.Background()
+	stored := &keychain.KeyRecord{ID: 1, KeyUUID: uuid.New(), KeyType: keychain.KeyText, Tags: []string{"old"}}
+	added := uuid.New()
+
+	mockKeychainRepo.On("GetUserKey", ctx, int64(1), stored.KeyUUID.String()).Return(stored, nil)
+	mockCryptManager.On("Encrypt", mock.Anything).Return([]byte{1}, []byte{2}, nil)
+	mockKeychainRepo.On("WriteBatch", ctx, int64(1), mock.Anything, false).Return(nil).Run(func(args mock.Arguments) {
+		writes := args.Get(2).([]*keychain.BatchWrite)
+		assert.Len(t, writes, 2)
+		assert.Equal(t, keychain.BatchAdd, writes[0].Op)
+		assert.Equal(t, []byte{2}, writes[0].Data)
+		writes[0].KeyUUID = added
+		assert.Equal(t, keychain.BatchDelete, writes[1].Op)
+	})
+	publisher.On("Publish", ctx, webhook.Event{Type: webhook.EventKeyDelete, UserID: 1, KeyUUID: &stored.KeyUUID, Tags: []string{"old"}}).Return(nil)
+
+	results, err := s.Batch(ctx, 1, keychain.BatchBestEffort, []*dto.BatchOperation{
+		{Op: keychain.BatchAdd, Text: &dto.AddTextDTO{Title: "note", Text: "text"}},
+		{Op: keychain.BatchAdd, Credential: &dto.AddCredentialsDTO{Title: "site"}},
+		{Op: keychain.BatchUpdate, UUID: stored.KeyUUID.String(), Card: &dto.AddCardDTO{Title: "card", Number: "4111111111111111", CVV: "123"}},
+		{Op: keychain.BatchDelete, UUID: stored.KeyUUID.String()},
+		{Op: keychain.BatchAdd, Text: &dto.AddTextDTO{Title: "two", Text: "text"}, File: &dto.BatchFileDTO{Title: "two"}},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, results, 5) {
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, added.String(), results[0].KeyUUID)
+		assert.ErrorIs(t, results[1].Err, keychain.ErrCredentialEmptyLogin)
+		assert.ErrorIs(t, results[2].Err, keychain.ErrBatchTypeChange)
+		assert.NoError(t, results[3].Err)
+		assert.ErrorIs(t, results[4].Err, keychain.ErrBatchPayload)
+	}
+
+	mockKeychainRepo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestKeychainService_Batch_AuditFailure(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	auditor := new(mocks.AuditServiceMock)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), auditor, nopPublisher(), mockCryptManager)
+
+	ctx := context.Background()
+	added := uuid.New()
+	auditErr := errors.New("audit down")
+
+	mockCryptManager.On("Encrypt", mock.Anything).Return([]byte{1}, []byte{2}, nil)
+	mockKeychainRepo.On("WriteBatch", ctx, int64(1), mock.Anything, true).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(2).([]*keychain.BatchWrite)[0].KeyUUID = added
+	})
+	auditor.On("Record", ctx, mock.Anything).Return(auditErr)
+
+	results, err := s.Batch(ctx, 1, keychain.BatchAtomic, []*dto.BatchOperation{
+		{Op: keychain.BatchAdd, Text: &dto.AddTextDTO{Title: "note", Text: "text"}},
+	})
+
+	// the entry was written, so a retry must not add it again
+	assert.NoError(t, err)
+	if assert.Len(t, results, 1) {
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, added.String(), results[0].KeyUUID)
+		assert.ErrorIs(t, results[0].AuditErr, auditErr)
+	}
+	auditor.AssertExpectations(t)
+}
+
+func TestKeychainService_Batch_Atomic(t *testing.T) {
+	t.Run("invalid operation aborts the rest", func(t *testing.T) {
+		mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+		mockCryptManager := new(mocks.CryptManager)
+		s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+		mockCryptManager.On("Encrypt", mock.Anything).Return([]byte{1}, []byte{2}, nil)
+
+		results, err := s.Batch(context.Background(), 1, keychain.BatchAtomic, []*dto.BatchOperation{
+			{Op: keychain.BatchAdd, Text: &dto.AddTextDTO{Title: "note", Text: "text"}},
+			{Op: keychain.BatchDelete, UUID: "not-a-uuid"},
+		})
+		assert.NoError(t, err)
+		if assert.Len(t, results, 2) {
+			assert.ErrorIs(t, results[0].Err, keychain.ErrBatchAborted)
+			assert.ErrorIs(t, results[1].Err, keychain.ErrBatchKeyUUID)
+		}
+		mockKeychainRepo.AssertNotCalled(t, "WriteBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("failed write rolls back the rest", func(t *testing.T) {
+		mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+		mockCryptManager := new(mocks.CryptManager)
+		s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+		ctx := context.Background()
+		gone := &keychain.KeyRecord{KeyUUID: uuid.New(), KeyType: keychain.KeyText}
+
+		mockCryptManager.On("Encrypt", mock.Anything).Return([]byte{1}, []byte{2}, nil)
+		mockKeychainRepo.On("GetUserKey", ctx, int64(1), gone.KeyUUID.String()).Return(gone, nil)
+		mockKeychainRepo.On("WriteBatch", ctx, int64(1), mock.Anything, true).Return(nil).Run(func(args mock.Arguments) {
+			writes := args.Get(2).([]*keychain.BatchWrite)
+			writes[0].KeyUUID = uuid.New()
+			writes[1].Err = errors.New("deleted meanwhile")
+		})
+
+		results, err := s.Batch(ctx, 1, keychain.BatchAtomic, []*dto.BatchOperation{
+			{Op: keychain.BatchAdd, Text: &dto.AddTextDTO{Title: "note", Text: "text"}},
+			{Op: keychain.BatchDelete, UUID: gone.KeyUUID.String()},
+		})
+		assert.NoError(t, err)
+		if assert.Len(t, results, 2) {
+			assert.ErrorIs(t, results[0].Err, keychain.ErrBatchAborted)
+			assert.Empty(t, results[0].KeyUUID)
+			assert.EqualError(t, results[1].Err, "deleted meanwhile")
+		}
+	})
+}
+
+func TestKeychainService_Batch_Checks(t *testing.T) {
+	mockKeychainRepo := new(mocks.KeychainRepositoryMock)
+	mockCryptManager := new(mocks.CryptManager)
+	s := NewKeychainService(mockKeychainRepo, new(mocks.ServiceAccountRepositoryMock), new(mocks.VaultRepositoryMock), nopAuditor(), nopPublisher(), mockCryptManager)
+
+	ctx := context.Background()
+	_, err := s.Batch(ctx, 1, keychain.BatchAtomic, nil)
+	assert.ErrorIs(t, err, keychain.ErrBatchEmpty)
+
+	_, err = s.Batch(ctx, 1, keychain.BatchAtomic, make([]*dto.BatchOperation, keychain.MaxBatchOperations+1))
+	assert.ErrorIs(t, err, keychain.ErrBatchTooLarge)
+
+	_, err = s.Batch(ctx, 1, "later", []*dto.BatchOperation{{Op: keychain.BatchAdd}})
+	assert.ErrorIs(t, err, keychain.ErrBatchMode)
+
+	results, err := s.Batch(scopedContext(t, "write:tag:prod"), 1, keychain.BatchBestEffort, []*dto.BatchOperation{
+		{Op: keychain.BatchAdd, Text: &dto.AddTextDTO{Title: "note", Text: "text"}},
+		{Op: keychain.BatchAdd, Text: &dto.AddTextDTO{Vault: uuid.NewString(), Title: "note", Text: "text"}},
+		{Op: "rename"},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, results, 3) {
+		assert.ErrorIs(t, results[0].Err, pat.ErrInsufficientScope)
+		assert.ErrorIs(t, results[1].Err, keychain.ErrBatchVault)
+		assert.ErrorIs(t, results[2].Err, keychain.ErrBatchOp)
+	}
+	mockCryptManager.AssertNotCalled(t, "Encrypt", mock.Anything)
+	mockKeychainRepo.AssertNotCalled(t, "WriteBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
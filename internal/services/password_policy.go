@@ -0,0 +1,58 @@
+package services
+
+import (
+	"bufio"
+	"github.com/thxhix/passKeeper/internal/config"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"os"
+	"strings"
+)
+
+// NewPasswordPolicy builds a user.PasswordPolicy from the configuration,
+// loading the banned password list when a path is set.
+func NewPasswordPolicy(cfg *config.Config) (user.PasswordPolicy, error) {
+	policy := user.PasswordPolicy{
+		MinLength:      cfg.PasswordMinLength,
+		MinClasses:     cfg.PasswordMinClasses,
+		MinEntropyBits: cfg.PasswordMinEntropyBits,
+	}
+
+	if cfg.PasswordBannedListPath == "" {
+		return policy, nil
+	}
+
+	banned, err := loadBannedPasswords(cfg.PasswordBannedListPath)
+	if err != nil {
+		return user.PasswordPolicy{}, err
+	}
+	policy.Banned = banned
+
+	return policy, nil
+}
+
+// loadBannedPasswords reads one password per line, lowercased. Empty lines
+// and lines starting with # are skipped.
+func loadBannedPasswords(path string) (map[string]struct{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	banned := make(map[string]struct{})
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		banned[strings.ToLower(line)] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return banned, nil
+}
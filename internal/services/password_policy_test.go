@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/config"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func violatedRules(t *testing.T, err error) []string {
+	t.Helper()
+
+	var ve *apperr.ValidationError
+	require.ErrorAs(t, err, &ve)
+
+	rules := make([]string, 0, len(ve.Violations))
+	for _, v := range ve.Violations {
+		rules = append(rules, v.Rule)
+	}
+	return rules
+}
+
+func TestNewPasswordPolicy_Loads_Banned_List(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "banned.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# top passwords\nPassword\n\nletmein\ndragon\n"), 0o600))
+
+	cfg := &config.Config{PasswordPolicyConfig: config.PasswordPolicyConfig{
+		PasswordMinLength:      8,
+		PasswordMinClasses:     2,
+		PasswordBannedListPath: path,
+		PasswordMinEntropyBits: 30,
+	}}
+
+	policy, err := NewPasswordPolicy(cfg)
+	require.NoError(t, err)
+	assert.Len(t, policy.Banned, 3)
+
+	assert.Equal(t, []string{user.RuleBanned}, violatedRules(t, policy.Check("alice", "Password2024!")))
+	assert.Equal(t, []string{user.RuleBanned, user.RuleEntropy}, violatedRules(t, policy.Check("alice", "Dragon12")))
+	assert.NoError(t, policy.Check("alice", "Tangerine-Orbit-42"))
+
+	cfg.PasswordBannedListPath = filepath.Join(t.TempDir(), "missing.txt")
+	_, err = NewPasswordPolicy(cfg)
+	assert.Error(t, err)
+}
+
+func TestPasswordPolicy_Check_Reports_Every_Rule(t *testing.T) {
+	policy := user.PasswordPolicy{MinLength: 12, MinClasses: 3, MinEntropyBits: 30}
+
+	rules := violatedRules(t, policy.Check("alice", "alice123"))
+
+	assert.Equal(t, []string{user.RuleMinLength, user.RuleCharClasses, user.RuleContainsLogin}, rules)
+}
+
+func TestPasswordPolicy_Check_Counts_Characters(t *testing.T) {
+	policy := user.PasswordPolicy{MinLength: 8}
+
+	// eight characters but sixteen bytes
+	assert.NoError(t, policy.Check("alice", "пароль!!"))
+	assert.Equal(t, []string{user.RuleMinLength}, violatedRules(t, policy.Check("alice", "пароль!")))
+}
+
+func TestEstimateEntropy_Patterns(t *testing.T) {
+	random := user.EstimateEntropy("k7#Qz!p2Lm", nil)
+
+	assert.Less(t, user.EstimateEntropy("aaaaaaaaaa", nil), 15.0)
+	assert.Less(t, user.EstimateEntropy("1234567890", nil), 15.0)
+	assert.Less(t, user.EstimateEntropy("qwertyuiop", nil), 15.0)
+	assert.Less(t, user.EstimateEntropy("sunshine", map[string]struct{}{"sunshine": {}}), 5.0)
+	assert.Greater(t, random, 60.0)
+}
+
+func TestAuthService_Register_Rejects_Weak_Password(t *testing.T) {
+	userRepo := new(mocks.UserRepositoryMock)
+	passHasher := new(mocks.PasswordHasherMock)
+
+	policy := user.PasswordPolicy{MinLength: 8, MinClasses: 2}
+	s := NewAuthService(userRepo, new(mocks.TokenRepositoryMock), passHasher, new(mocks.TokenManagerMock), nil, allowDevices(), nopAuditor(), nopPublisher(), nil, nil, LockoutPolicy{}, policy)
+
+	_, _, _, err := s.Register(context.Background(), "login", "password", "", "", testDevice)
+
+	assert.Equal(t, []string{user.RuleCharClasses}, violatedRules(t, err))
+	passHasher.AssertNotCalled(t, "HashPassword", "password")
+	userRepo.AssertNotCalled(t, "Create")
+}
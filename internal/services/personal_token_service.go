@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"strings"
+	"time"
+)
+
+// personalTokenBytes is the amount of randomness in a personal access token.
+const personalTokenBytes = 32
+
+// lastUsedResolution limits how often the last-used time of a token is written.
+const lastUsedResolution = time.Minute
+
+type IPersonalTokenService interface {
+	Create(ctx context.Context, userID int64, name string, scopes []string, expiresAt *time.Time) (record *pat.PersonalTokenRecord, token string, err error)
+	List(ctx context.Context, userID int64) ([]*pat.PersonalTokenRecord, error)
+	Revoke(ctx context.Context, userID int64, tokenUUID string) error
+	Authenticate(ctx context.Context, token string) (userID int64, scopes pat.Scopes, err error)
+}
+
+// PersonalTokenService manages personal access tokens: long-lived, scoped
+// bearer tokens for automation such as CI jobs.
+//
+// Tokens are random, prefixed with pat.TokenPrefix and stored as SHA-256
+// hashes only, so the plain value is returned exactly once by Create.
+type PersonalTokenService struct {
+	repo pat.PersonalTokenRepository
+	now  func() time.Time
+}
+
+// NewPersonalTokenService constructs a new PersonalTokenService with given dependencies.
+func NewPersonalTokenService(repo pat.PersonalTokenRepository) PersonalTokenService {
+	return PersonalTokenService{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// Create issues a new personal access token for the user.
+//
+// The scopes are validated and stored in canonical form; expiresAt may be nil
+// for a token that never expires. Returns the stored record and the plain
+// token value.
+func (s *PersonalTokenService) Create(ctx context.Context, userID int64, name string, scopes []string, expiresAt *time.Time) (record *pat.PersonalTokenRecord, token string, err error) {
+	name = strings.TrimSpace(name)
+	if err := pat.ValidateName(name); err != nil {
+		return nil, "", err
+	}
+
+	parsed, err := pat.ParseScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := pat.ValidateExpiry(s.now(), expiresAt); err != nil {
+		return nil, "", err
+	}
+
+	raw := make([]byte, personalTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", err
+	}
+	token = pat.TokenPrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	record, err = s.repo.Create(ctx, userID, name, hashSecretToken(token), parsed.Strings(), expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return record, token, nil
+}
+
+// List returns every personal access token of the user.
+func (s *PersonalTokenService) List(ctx context.Context, userID int64) ([]*pat.PersonalTokenRecord, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// Revoke deletes a personal access token of the user. The token stops being
+// accepted immediately.
+//
+// Returns pat.ErrTokenNotFound if the user has no token with that UUID.
+func (s *PersonalTokenService) Revoke(ctx context.Context, userID int64, tokenUUID string) error {
+	id, err := uuid.Parse(tokenUUID)
+	if err != nil {
+		return pat.ErrTokenNotFound
+	}
+
+	return s.repo.Revoke(ctx, userID, id)
+}
+
+// Authenticate resolves a personal access token to its owner and scopes and
+// records its use.
+//
+// Returns pat.ErrInvalidToken for unknown, malformed or expired tokens.
+func (s *PersonalTokenService) Authenticate(ctx context.Context, token string) (userID int64, scopes pat.Scopes, err error) {
+	if !strings.HasPrefix(token, pat.TokenPrefix) {
+		return 0, nil, pat.ErrInvalidToken
+	}
+
+	record, err := s.repo.GetByHash(ctx, hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, pat.ErrTokenNotFound) {
+			return 0, nil, pat.ErrInvalidToken
+		}
+		return 0, nil, err
+	}
+
+	now := s.now()
+	if record.Expired(now) {
+		return 0, nil, pat.ErrInvalidToken
+	}
+
+	scopes, err = pat.ParseScopes(record.Scopes)
+	if err != nil {
+		return 0, nil, pat.ErrInvalidToken
+	}
+
+	// A failed update only loses usage information, it must not reject the request.
+	if record.LastUsedAt == nil || now.Sub(*record.LastUsedAt) >= lastUsedResolution {
+		_ = s.repo.TouchLastUsed(ctx, record.ID, now)
+	}
+
+	return record.UserID, scopes, nil
+}
+
+// hashSecretToken returns the hex-encoded SHA-256 hash stored for a random
+// token or client secret. Their entropy makes a slow password hash unnecessary.
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestPersonalTokenService(repo *mocks.PersonalTokenRepositoryMock, now time.Time) PersonalTokenService {
+	s := NewPersonalTokenService(repo)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestPersonalTokenService_Create_Success(t *testing.T) {
+	repo := new(mocks.PersonalTokenRepositoryMock)
+	now := time.Date(2025, 10, 23, 9, 0, 0, 0, time.UTC)
+	s := newTestPersonalTokenService(repo, now)
+
+	ctx := context.Background()
+	expires := now.Add(24 * time.Hour)
+	record := &pat.PersonalTokenRecord{ID: 1, UserID: 1, Name: "ci"}
+
+	var storedHash string
+	repo.On("Create", ctx, int64(1), "ci", mock.AnythingOfType("string"), []string{"read:tag:prod", "write:text"}, &expires).
+		Run(func(args mock.Arguments) { storedHash = args.String(3) }).
+		Return(record, nil)
+
+	got, token, err := s.Create(ctx, 1, " ci ", []string{"READ:tag:prod", "write:text", "read:tag:prod"}, &expires)
+
+	assert.NoError(t, err)
+	assert.Equal(t, record, got)
+	assert.True(t, strings.HasPrefix(token, pat.TokenPrefix))
+	assert.Equal(t, hashSecretToken(token), storedHash)
+	assert.NotContains(t, storedHash, token)
+	repo.AssertExpectations(t)
+}
+
+func TestPersonalTokenService_Create_Validate_Error(t *testing.T) {
+	repo := new(mocks.PersonalTokenRepositoryMock)
+	now := time.Date(2025, 10, 23, 9, 0, 0, 0, time.UTC)
+	s := newTestPersonalTokenService(repo, now)
+
+	ctx := context.Background()
+	past := now.Add(-time.Hour)
+
+	_, _, err := s.Create(ctx, 1, "", []string{"read"}, nil)
+	assert.ErrorIs(t, err, pat.ErrNameEmpty)
+
+	_, _, err = s.Create(ctx, 1, "ci", nil, nil)
+	assert.ErrorIs(t, err, pat.ErrNoScopes)
+
+	_, _, err = s.Create(ctx, 1, "ci", []string{"admin"}, nil)
+	assert.ErrorIs(t, err, pat.ErrInvalidScope)
+
+	_, _, err = s.Create(ctx, 1, "ci", []string{"read"}, &past)
+	assert.ErrorIs(t, err, pat.ErrExpiresInPast)
+
+	repo.AssertNotCalled(t, "Create")
+}
+
+func TestPersonalTokenService_Revoke(t *testing.T) {
+	repo := new(mocks.PersonalTokenRepositoryMock)
+	s := newTestPersonalTokenService(repo, time.Now())
+
+	ctx := context.Background()
+	id := uuid.New()
+	repo.On("Revoke", ctx, int64(1), id).Return(nil)
+
+	assert.NoError(t, s.Revoke(ctx, 1, id.String()))
+	assert.ErrorIs(t, s.Revoke(ctx, 1, "not-a-uuid"), pat.ErrTokenNotFound)
+	repo.AssertExpectations(t)
+}
+
+func TestPersonalTokenService_Authenticate_Success(t *testing.T) {
+	repo := new(mocks.PersonalTokenRepositoryMock)
+	now := time.Date(2025, 10, 23, 9, 0, 0, 0, time.UTC)
+	s := newTestPersonalTokenService(repo, now)
+
+	ctx := context.Background()
+	token := pat.TokenPrefix + "secret"
+	record := &pat.PersonalTokenRecord{ID: 7, UserID: 3, Scopes: []string{"read:tag:prod"}}
+
+	repo.On("GetByHash", ctx, hashSecretToken(token)).Return(record, nil)
+	repo.On("TouchLastUsed", ctx, int64(7), now).Return(errors.New("db down"))
+
+	userID, scopes, err := s.Authenticate(ctx, token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), userID)
+	assert.Equal(t, []string{"read:tag:prod"}, scopes.Strings())
+	repo.AssertExpectations(t)
+}
+
+func TestPersonalTokenService_Authenticate_SkipsRecentTouch(t *testing.T) {
+	repo := new(mocks.PersonalTokenRepositoryMock)
+	now := time.Date(2025, 10, 23, 9, 0, 0, 0, time.UTC)
+	s := newTestPersonalTokenService(repo, now)
+
+	ctx := context.Background()
+	token := pat.TokenPrefix + "secret"
+	lastUsed := now.Add(-10 * time.Second)
+	record := &pat.PersonalTokenRecord{ID: 7, UserID: 3, Scopes: []string{"read"}, LastUsedAt: &lastUsed}
+
+	repo.On("GetByHash", ctx, hashSecretToken(token)).Return(record, nil)
+
+	_, _, err := s.Authenticate(ctx, token)
+
+	assert.NoError(t, err)
+	repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPersonalTokenService_Authenticate_Invalid(t *testing.T) {
+	repo := new(mocks.PersonalTokenRepositoryMock)
+	now := time.Date(2025, 10, 23, 9, 0, 0, 0, time.UTC)
+	s := newTestPersonalTokenService(repo, now)
+
+	ctx := context.Background()
+	expired := now.Add(-time.Minute)
+
+	repo.On("GetByHash", ctx, hashSecretToken(pat.TokenPrefix+"unknown")).Return(nil, pat.ErrTokenNotFound)
+	repo.On("GetByHash", ctx, hashSecretToken(pat.TokenPrefix+"expired")).
+		Return(&pat.PersonalTokenRecord{ID: 1, UserID: 1, Scopes: []string{"read"}, ExpiresAt: &expired}, nil)
+
+	_, _, err := s.Authenticate(ctx, "eyJhbGciOi")
+	assert.ErrorIs(t, err, pat.ErrInvalidToken)
+
+	_, _, err = s.Authenticate(ctx, pat.TokenPrefix+"unknown")
+	assert.ErrorIs(t, err, pat.ErrInvalidToken)
+
+	_, _, err = s.Authenticate(ctx, pat.TokenPrefix+"expired")
+	assert.ErrorIs(t, err, pat.ErrInvalidToken)
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/recovery"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+)
+
+type IRecoveryService interface {
+	Setup(ctx context.Context, userID int64, publicKey []byte, keyMaterial []byte, threshold int, shares int, held []recovery.HeldShare) (*recovery.Setup, error)
+	Get(ctx context.Context, userID int64) (*recovery.Setup, error)
+	Remove(ctx context.Context, userID int64) error
+
+	Held(ctx context.Context, userID int64) ([]*recovery.Share, error)
+	GetHeld(ctx context.Context, userID int64, shareUUID string) (*recovery.Share, error)
+}
+
+// RecoveryService stores Shamir-shared recovery setups of zero-knowledge
+// vault keys.
+//
+// The client splits the recovery key and seals the shares for their holders;
+// the service only checks the setup is consistent and keeps the ciphertext.
+// Recovering combines the shares on the client, so a setup is of no use to
+// the server.
+type RecoveryService struct {
+	repo     recovery.RecoveryRepository
+	userRepo user.UserRepository
+	auditor  AuditRecorder
+}
+
+// NewRecoveryService constructs a new RecoveryService with given dependencies.
+func NewRecoveryService(repo recovery.RecoveryRepository, userRepo user.UserRepository, auditor AuditRecorder) RecoveryService {
+	return RecoveryService{
+		repo:     repo,
+		userRepo: userRepo,
+		auditor:  auditor,
+	}
+}
+
+// Setup replaces the recovery setup of the user. publicKey must be the
+// current sharing key of the user, and every holder must have a sharing key
+// the share was sealed for.
+func (s *RecoveryService) Setup(ctx context.Context, userID int64, publicKey []byte, keyMaterial []byte, threshold int, shares int, held []recovery.HeldShare) (setup *recovery.Setup, err error) {
+	defer func() {
+		err = recordAudit(ctx, s.auditor, audit.Event{
+			UserID: userID,
+			Action: audit.ActionRecoverySetup,
+		}, err)
+		if err != nil {
+			setup = nil
+		}
+	}()
+
+	if err := recovery.ValidateSetup(publicKey, keyMaterial, threshold, shares, held); err != nil {
+		return nil, err
+	}
+
+	au, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if au.PublicKey == nil {
+		return nil, recovery.ErrNoKeyPair
+	}
+	if !bytes.Equal(au.PublicKey, publicKey) {
+		return nil, recovery.ErrKeyMismatch
+	}
+
+	holders := make([]string, 0, len(held))
+	stored := make([]*recovery.Share, 0, len(held))
+	for _, h := range held {
+		holder, err := s.userRepo.GetByLogin(ctx, h.Login)
+		if err != nil {
+			return nil, err
+		}
+		if holder.ID == userID {
+			return nil, recovery.ErrHolderIsSelf
+		}
+		if holder.PublicKey == nil {
+			return nil, recovery.ErrNoPublicKey
+		}
+
+		holders = append(holders, holder.Login)
+		stored = append(stored, &recovery.Share{
+			OwnerID:    userID,
+			HolderID:   holder.ID,
+			WrappedKey: h.WrappedKey,
+			Nonce:      h.Nonce,
+			Data:       h.Data,
+		})
+	}
+
+	setup, err = s.repo.Replace(ctx, &recovery.Setup{
+		UserID:      userID,
+		PublicKey:   publicKey,
+		KeyMaterial: keyMaterial,
+		Threshold:   threshold,
+		Shares:      shares,
+	}, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	setup.Holders = holders
+	return setup, nil
+}
+
+// Get returns the recovery setup of the user, or recovery.ErrNotSetUp. It is
+// available after a password reset, which is when it is needed.
+func (s *RecoveryService) Get(ctx context.Context, userID int64) (*recovery.Setup, error) {
+	return s.repo.Get(ctx, userID)
+}
+
+// Remove deletes the recovery setup of the user and the shares held by
+// others.
+func (s *RecoveryService) Remove(ctx context.Context, userID int64) error {
+	return s.repo.Delete(ctx, userID)
+}
+
+// Held returns the recovery shares the user holds for others, without their
+// sealed payload.
+func (s *RecoveryService) Held(ctx context.Context, userID int64) ([]*recovery.Share, error) {
+	return s.repo.ListHeld(ctx, userID)
+}
+
+// GetHeld returns a recovery share the user holds with its sealed payload.
+// Fetching it is recorded in the audit log of the owner of the share.
+func (s *RecoveryService) GetHeld(ctx context.Context, userID int64, shareUUID string) (sh *recovery.Share, err error) {
+	event := audit.Event{
+		UserID: userID,
+		Action: audit.ActionRecoveryReveal,
+	}
+	defer func() {
+		err = recordAudit(ctx, s.auditor, event, err)
+		if err != nil {
+			sh = nil
+		}
+	}()
+
+	id, err := uuid.Parse(shareUUID)
+	if err != nil {
+		return nil, recovery.ErrNotFound
+	}
+
+	sh, err = s.repo.GetHeld(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	event.UserID = sh.OwnerID
+	return sh, nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/recovery"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"testing"
+)
+
+type recoveryTestDeps struct {
+	repo    *mocks.RecoveryRepositoryMock
+	users   *mocks.UserRepositoryMock
+	auditor *mocks.AuditServiceMock
+}
+
+func newTestRecoveryService() (RecoveryService, recoveryTestDeps) {
+	deps := recoveryTestDeps{
+		repo:    new(mocks.RecoveryRepositoryMock),
+		users:   new(mocks.UserRepositoryMock),
+		auditor: nopAuditor(),
+	}
+	return NewRecoveryService(deps.repo, deps.users, deps.auditor), deps
+}
+
+func heldShare(login string) recovery.HeldShare {
+	return recovery.HeldShare{Login: login, WrappedKey: []byte("wrapped"), Nonce: []byte("nonce"), Data: []byte("data")}
+}
+
+func TestRecoveryService_Setup_Success(t *testing.T) {
+	s, deps := newTestRecoveryService()
+	ctx := context.Background()
+
+	publicKey := make([]byte, 32)
+	deps.users.On("GetByID", ctx, int64(1)).Return(&user.UserRecord{ID: 1, Login: "alice", PublicKey: publicKey}, nil)
+	deps.users.On("GetByLogin", ctx, "bob").Return(&user.UserRecord{ID: 2, Login: "bob", PublicKey: make([]byte, 32)}, nil)
+
+	var got []*recovery.Share
+	deps.repo.On("Replace", ctx, mock.AnythingOfType("*recovery.Setup"), mock.Anything).
+		Run(func(args mock.Arguments) { got = args.Get(2).([]*recovery.Share) }).
+		Return(&recovery.Setup{UserID: 1, Threshold: 2, Shares: 3}, nil)
+
+	setup, err := s.Setup(ctx, 1, publicKey, []byte{1}, 2, 3, []recovery.HeldShare{heldShare("bob")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bob"}, setup.Holders)
+	assert.Len(t, got, 1)
+	assert.Equal(t, int64(2), got[0].HolderID)
+	assert.Equal(t, []byte("data"), got[0].Data)
+	deps.auditor.AssertCalled(t, "Record", ctx, mock.MatchedBy(func(e audit.Event) bool {
+		return e.Action == audit.ActionRecoverySetup && e.Result == audit.ResultSuccess && e.UserID == 1
+	}))
+}
+
+func TestRecoveryService_Setup_Errors(t *testing.T) {
+	publicKey := make([]byte, 32)
+	otherKey := append(make([]byte, 31), 1)
+
+	tests := []struct {
+		name      string
+		threshold int
+		shares    int
+		held      []recovery.HeldShare
+		owner     *user.UserRecord
+		holder    *user.UserRecord
+		holderErr error
+		want      error
+	}{
+		{name: "threshold too low", threshold: 1, shares: 3, want: recovery.ErrInvalidThreshold},
+		{name: "threshold above shares", threshold: 4, shares: 3, want: recovery.ErrInvalidThreshold},
+		{name: "too many shares", threshold: 2, shares: recovery.MaxShares + 1, want: recovery.ErrInvalidThreshold},
+		{name: "too many holders", threshold: 2, shares: 2, held: []recovery.HeldShare{heldShare("bob"), heldShare("carol"), heldShare("dave")}, want: recovery.ErrTooManyHolders},
+		{name: "duplicate holder", threshold: 2, shares: 3, held: []recovery.HeldShare{heldShare("bob"), heldShare("bob")}, want: recovery.ErrDuplicateHolder},
+		{name: "no key pair", threshold: 2, shares: 3, owner: &user.UserRecord{ID: 1}, want: recovery.ErrNoKeyPair},
+		{name: "other key pair", threshold: 2, shares: 3, owner: &user.UserRecord{ID: 1, PublicKey: otherKey}, want: recovery.ErrKeyMismatch},
+		{name: "unknown holder", threshold: 2, shares: 3, held: []recovery.HeldShare{heldShare("bob")}, holderErr: user.ErrUserNotFound, want: user.ErrUserNotFound},
+		{name: "self", threshold: 2, shares: 3, held: []recovery.HeldShare{heldShare("bob")}, holder: &user.UserRecord{ID: 1, PublicKey: publicKey}, want: recovery.ErrHolderIsSelf},
+		{name: "holder without key", threshold: 2, shares: 3, held: []recovery.HeldShare{heldShare("bob")}, holder: &user.UserRecord{ID: 2}, want: recovery.ErrNoPublicKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, deps := newTestRecoveryService()
+			ctx := context.Background()
+
+			owner := tt.owner
+			if owner == nil {
+				owner = &user.UserRecord{ID: 1, PublicKey: publicKey}
+			}
+			deps.users.On("GetByID", ctx, int64(1)).Return(owner, nil)
+			deps.users.On("GetByLogin", ctx, "bob").Return(tt.holder, tt.holderErr)
+
+			_, err := s.Setup(ctx, 1, publicKey, []byte{1}, tt.threshold, tt.shares, tt.held)
+
+			assert.ErrorIs(t, err, tt.want)
+			deps.repo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRecoveryService_GetHeld(t *testing.T) {
+	s, deps := newTestRecoveryService()
+	ctx := context.Background()
+
+	shareUUID := uuid.New()
+	deps.repo.On("GetHeld", ctx, int64(2), shareUUID).Return(&recovery.Share{ShareUUID: shareUUID, OwnerID: 1, HolderID: 2}, nil)
+
+	sh, err := s.GetHeld(ctx, 2, shareUUID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, shareUUID, sh.ShareUUID)
+	deps.auditor.AssertCalled(t, "Record", ctx, mock.MatchedBy(func(e audit.Event) bool {
+		return e.Action == audit.ActionRecoveryReveal && e.Result == audit.ResultSuccess && e.UserID == 1
+	}))
+
+	_, err = s.GetHeld(ctx, 2, "not-a-uuid")
+	assert.ErrorIs(t, err, recovery.ErrNotFound)
+
+	other := uuid.New()
+	deps.repo.On("GetHeld", ctx, int64(2), other).Return(nil, errors.New("db down"))
+	_, err = s.GetHeld(ctx, 2, other.String())
+	assert.EqualError(t, err, "db down")
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/send"
+	"time"
+)
+
+type ISendService interface {
+	Create(ctx context.Context, userID int64, nonce []byte, data []byte, maxViews int, ttl time.Duration) (*send.Send, error)
+	Open(ctx context.Context, sendUUID string) (*send.Send, error)
+	List(ctx context.Context, userID int64) ([]*send.Send, error)
+	Revoke(ctx context.Context, userID int64, sendUUID string) error
+}
+
+// SendService manages one-time secret links.
+//
+// The client encrypts the secret and keeps the key in the URL fragment, so
+// the service stores and hands out ciphertext only. It enforces the view
+// limit and the expiry and removes sends as soon as they cannot be read
+// anymore.
+type SendService struct {
+	repo    send.SendRepository
+	auditor AuditRecorder
+	now     func() time.Time
+}
+
+// NewSendService constructs a new SendService with given dependencies.
+func NewSendService(repo send.SendRepository, auditor AuditRecorder) SendService {
+	return SendService{
+		repo:    repo,
+		auditor: auditor,
+		now:     time.Now,
+	}
+}
+
+// Create stores the ciphertext of a secret on behalf of the user. A zero ttl
+// selects send.DefaultTTL.
+//
+// Expired sends are purged along the way.
+func (s *SendService) Create(ctx context.Context, userID int64, nonce []byte, data []byte, maxViews int, ttl time.Duration) (sn *send.Send, err error) {
+	defer func() {
+		e := audit.Event{UserID: userID, Action: audit.ActionSendCreate}
+		err = recordAudit(ctx, s.auditor, e, err)
+		if err != nil {
+			sn = nil
+		}
+	}()
+
+	if err := send.ValidatePayload(nonce, data); err != nil {
+		return nil, err
+	}
+
+	if err := send.ValidateMaxViews(maxViews); err != nil {
+		return nil, err
+	}
+
+	if ttl == 0 {
+		ttl = send.DefaultTTL
+	}
+	if err := send.ValidateTTL(ttl); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	_, _ = s.repo.DeleteExpired(ctx, now)
+
+	return s.repo.Create(ctx, &send.Send{
+		OwnerID:   userID,
+		Nonce:     nonce,
+		Data:      data,
+		MaxViews:  maxViews,
+		ExpiresAt: now.Add(ttl),
+	})
+}
+
+// Open takes one view of the send and returns its ciphertext. Anyone with the
+// link may open it; the read is recorded in the audit log of the owner.
+//
+// Returns send.ErrNotFound for unknown, expired and used up sends.
+func (s *SendService) Open(ctx context.Context, sendUUID string) (*send.Send, error) {
+	id, err := uuid.Parse(sendUUID)
+	if err != nil {
+		return nil, send.ErrNotFound
+	}
+
+	sn, err := s.repo.Consume(ctx, id, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	// The view is already taken and possibly the last one, so failing to
+	// record it must not withhold the ciphertext.
+	_ = s.auditor.Record(ctx, audit.Event{
+		UserID: sn.OwnerID,
+		Action: audit.ActionSendView,
+		Result: audit.ResultSuccess,
+	})
+
+	return sn, nil
+}
+
+// List returns the sends of the user without their ciphertext.
+func (s *SendService) List(ctx context.Context, userID int64) ([]*send.Send, error) {
+	return s.repo.ListByOwner(ctx, userID)
+}
+
+// Revoke deletes a send of the user; its link stops working immediately.
+//
+// Returns send.ErrNotFound if the user has no send with that UUID.
+func (s *SendService) Revoke(ctx context.Context, userID int64, sendUUID string) error {
+	id, err := uuid.Parse(sendUUID)
+	if err != nil {
+		return send.ErrNotFound
+	}
+
+	return s.repo.Delete(ctx, userID, id)
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/send"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"testing"
+	"time"
+)
+
+func newTestSendService(repo *mocks.SendRepositoryMock, auditor AuditRecorder, now time.Time) SendService {
+	s := NewSendService(repo, auditor)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestSendService_Create_Success(t *testing.T) {
+	repo := new(mocks.SendRepositoryMock)
+	now := time.Date(2025, 11, 1, 9, 0, 0, 0, time.UTC)
+	auditor := new(mocks.AuditServiceMock)
+	s := newTestSendService(repo, auditor, now)
+
+	ctx := context.Background()
+	stored := &send.Send{ID: 1, SendUUID: uuid.New(), OwnerID: 1, MaxViews: 1}
+
+	var got *send.Send
+	repo.On("DeleteExpired", ctx, now).Return(int64(2), nil)
+	repo.On("Create", ctx, mock.AnythingOfType("*send.Send")).
+		Run(func(args mock.Arguments) { got = args.Get(1).(*send.Send) }).
+		Return(stored, nil)
+	auditor.On("Record", ctx, mock.MatchedBy(func(e audit.Event) bool {
+		return e.UserID == 1 && e.Action == audit.ActionSendCreate && e.Result == audit.ResultSuccess
+	})).Return(nil)
+
+	sn, err := s.Create(ctx, 1, []byte("nonce"), []byte("data"), 1, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, stored, sn)
+	assert.Equal(t, []byte("data"), got.Data)
+	assert.Equal(t, now.Add(send.DefaultTTL), got.ExpiresAt)
+	repo.AssertExpectations(t)
+	auditor.AssertExpectations(t)
+}
+
+func TestSendService_Create_Validate_Error(t *testing.T) {
+	repo := new(mocks.SendRepositoryMock)
+	s := newTestSendService(repo, nopAuditor(), time.Now())
+
+	ctx := context.Background()
+
+	_, err := s.Create(ctx, 1, nil, []byte("data"), 1, time.Hour)
+	assert.ErrorIs(t, err, send.ErrInvalidPayload)
+
+	_, err = s.Create(ctx, 1, []byte("nonce"), make([]byte, send.MaxDataSize+1), 1, time.Hour)
+	assert.ErrorIs(t, err, send.ErrInvalidPayload)
+
+	_, err = s.Create(ctx, 1, []byte("nonce"), []byte("data"), 0, time.Hour)
+	assert.ErrorIs(t, err, send.ErrInvalidMaxViews)
+
+	_, err = s.Create(ctx, 1, []byte("nonce"), []byte("data"), send.MaxViewsLimit+1, time.Hour)
+	assert.ErrorIs(t, err, send.ErrInvalidMaxViews)
+
+	_, err = s.Create(ctx, 1, []byte("nonce"), []byte("data"), 1, send.MaxTTL+time.Hour)
+	assert.ErrorIs(t, err, send.ErrInvalidTTL)
+
+	repo.AssertNotCalled(t, "Create")
+}
+
+func TestSendService_Open(t *testing.T) {
+	repo := new(mocks.SendRepositoryMock)
+	now := time.Date(2025, 11, 1, 9, 0, 0, 0, time.UTC)
+	auditor := new(mocks.AuditServiceMock)
+	s := newTestSendService(repo, auditor, now)
+
+	ctx := context.Background()
+	id := uuid.New()
+	gone := uuid.New()
+
+	repo.On("Consume", ctx, id, now).Return(&send.Send{SendUUID: id, OwnerID: 3, Data: []byte("data")}, nil)
+	repo.On("Consume", ctx, gone, now).Return(nil, send.ErrNotFound)
+	auditor.On("Record", ctx, mock.MatchedBy(func(e audit.Event) bool {
+		return e.UserID == 3 && e.Action == audit.ActionSendView
+	})).Return(errors.New("audit down"))
+
+	sn, err := s.Open(ctx, id.String())
+	assert.NoError(t, err, "a consumed view must be returned even if auditing fails")
+	assert.Equal(t, []byte("data"), sn.Data)
+
+	_, err = s.Open(ctx, gone.String())
+	assert.ErrorIs(t, err, send.ErrNotFound)
+
+	_, err = s.Open(ctx, "not-a-uuid")
+	assert.ErrorIs(t, err, send.ErrNotFound)
+
+	repo.AssertExpectations(t)
+	auditor.AssertExpectations(t)
+}
+
+func TestSendService_Revoke(t *testing.T) {
+	repo := new(mocks.SendRepositoryMock)
+	s := newTestSendService(repo, nopAuditor(), time.Now())
+
+	ctx := context.Background()
+	id := uuid.New()
+	repo.On("Delete", ctx, int64(1), id).Return(nil)
+
+	assert.NoError(t, s.Revoke(ctx, 1, id.String()))
+	assert.ErrorIs(t, s.Revoke(ctx, 1, "bad"), send.ErrNotFound)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/serviceaccount"
+	"strings"
+	"sync"
+	"time"
+)
+
+// clientSecretBytes is the amount of randomness in a service account client secret.
+const clientSecretBytes = 32
+
+// ServiceTokenManager describes the token operations used by ServiceAccountService.
+type ServiceTokenManager interface {
+	GenerateServiceAccessToken(ownerID int64, clientID string) (token string, ttl time.Duration, err error)
+	ParseClientAssertion(assertion string, lookupKey func(clientID string) (string, error)) (clientID, jti string, expiresAt time.Time, err error)
+	ValidateAssertionKey(publicKeyPEM string) error
+}
+
+type IServiceAccountService interface {
+	Create(ctx context.Context, ownerID int64, name string, publicKey string) (account *serviceaccount.ServiceAccount, clientSecret string, err error)
+	List(ctx context.Context, ownerID int64) ([]*serviceaccount.ServiceAccount, error)
+	Delete(ctx context.Context, ownerID int64, clientID string) error
+	Grant(ctx context.Context, ownerID int64, clientID string, keyUUID string) error
+	RevokeGrant(ctx context.Context, ownerID int64, clientID string, keyUUID string) error
+	ListGrants(ctx context.Context, ownerID int64, clientID string) ([]uuid.UUID, error)
+	ExchangeClientSecret(ctx context.Context, clientID string, clientSecret string) (accessToken string, ttl time.Duration, err error)
+	ExchangeAssertion(ctx context.Context, assertion string) (accessToken string, ttl time.Duration, err error)
+}
+
+// ServiceAccountService manages service accounts and exchanges their
+// credentials for access tokens.
+//
+// A service account authenticates either with its client ID and secret or
+// with a JWT assertion signed by the key registered for it. The access token
+// it receives acts for the owner but only reads entries granted to the
+// account (see KeychainService). Assertion IDs are remembered until the
+// assertion expires, so an assertion can be exchanged only once per server
+// instance.
+type ServiceAccountService struct {
+	repo         serviceaccount.ServiceAccountRepository
+	tokenManager ServiceTokenManager
+	now          func() time.Time
+
+	mu       sync.Mutex
+	usedJTIs map[string]time.Time
+}
+
+// NewServiceAccountService constructs a new ServiceAccountService with given dependencies.
+func NewServiceAccountService(repo serviceaccount.ServiceAccountRepository, tokenManager ServiceTokenManager) *ServiceAccountService {
+	return &ServiceAccountService{
+		repo:         repo,
+		tokenManager: tokenManager,
+		now:          time.Now,
+		usedJTIs:     make(map[string]time.Time),
+	}
+}
+
+// Create registers a new service account of the owner.
+//
+// publicKey is an optional PEM-encoded Ed25519 or P-256 public key used to
+// verify JWT assertions. Returns the stored account and the plain client
+// secret, which is not retrievable later.
+func (s *ServiceAccountService) Create(ctx context.Context, ownerID int64, name string, publicKey string) (account *serviceaccount.ServiceAccount, clientSecret string, err error) {
+	name = strings.TrimSpace(name)
+	if err := serviceaccount.ValidateName(name); err != nil {
+		return nil, "", err
+	}
+
+	publicKey = strings.TrimSpace(publicKey)
+	if publicKey != "" {
+		if err := s.tokenManager.ValidateAssertionKey(publicKey); err != nil {
+			return nil, "", serviceaccount.ErrInvalidPublicKey
+		}
+	}
+
+	raw := make([]byte, clientSecretBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", err
+	}
+	clientSecret = serviceaccount.SecretPrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	account, err = s.repo.Create(ctx, ownerID, name, hashSecretToken(clientSecret), publicKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return account, clientSecret, nil
+}
+
+// List returns every service account of the owner.
+func (s *ServiceAccountService) List(ctx context.Context, ownerID int64) ([]*serviceaccount.ServiceAccount, error) {
+	return s.repo.ListByOwner(ctx, ownerID)
+}
+
+// Delete removes a service account of the owner with all its grants.
+//
+// Returns serviceaccount.ErrNotFound if the owner has no such account.
+func (s *ServiceAccountService) Delete(ctx context.Context, ownerID int64, clientID string) error {
+	id, err := uuid.Parse(clientID)
+	if err != nil {
+		return serviceaccount.ErrNotFound
+	}
+
+	return s.repo.Delete(ctx, ownerID, id)
+}
+
+// Exists reports whether the service account clientID still exists and its
+// owner is enabled, so that its access tokens may be used.
+func (s *ServiceAccountService) Exists(ctx context.Context, clientID uuid.UUID) (bool, error) {
+	_, err := s.repo.GetByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, serviceaccount.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Grant allows a service account of the owner to read one of the owner's entries.
+//
+// Returns serviceaccount.ErrGrantNotFound if the account or entry does not exist.
+func (s *ServiceAccountService) Grant(ctx context.Context, ownerID int64, clientID string, keyUUID string) error {
+	id, key, err := parseGrant(clientID, keyUUID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Grant(ctx, ownerID, id, key)
+}
+
+// RevokeGrant withdraws the read access of a service account to an entry.
+//
+// Returns serviceaccount.ErrGrantNotFound if the entry is not granted.
+func (s *ServiceAccountService) RevokeGrant(ctx context.Context, ownerID int64, clientID string, keyUUID string) error {
+	id, key, err := parseGrant(clientID, keyUUID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.RevokeGrant(ctx, ownerID, id, key)
+}
+
+// ListGrants returns the UUIDs of the entries granted to a service account of the owner.
+func (s *ServiceAccountService) ListGrants(ctx context.Context, ownerID int64, clientID string) ([]uuid.UUID, error) {
+	id, err := uuid.Parse(clientID)
+	if err != nil {
+		return nil, serviceaccount.ErrNotFound
+	}
+
+	return s.repo.ListGrants(ctx, ownerID, id)
+}
+
+// ExchangeClientSecret issues an access token for a service account
+// authenticated with its client ID and secret.
+//
+// Returns serviceaccount.ErrInvalidCredentials for unknown accounts or wrong secrets.
+func (s *ServiceAccountService) ExchangeClientSecret(ctx context.Context, clientID string, clientSecret string) (accessToken string, ttl time.Duration, err error) {
+	id, err := uuid.Parse(clientID)
+	if err != nil {
+		return "", 0, serviceaccount.ErrInvalidCredentials
+	}
+
+	account, err := s.repo.GetByClientID(ctx, id)
+	if err != nil {
+		if errors.Is(err, serviceaccount.ErrNotFound) {
+			return "", 0, serviceaccount.ErrInvalidCredentials
+		}
+		return "", 0, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(account.SecretHash), []byte(hashSecretToken(clientSecret))) != 1 {
+		return "", 0, serviceaccount.ErrInvalidCredentials
+	}
+
+	return s.issue(ctx, account)
+}
+
+// ExchangeAssertion issues an access token for a service account
+// authenticated with a JWT assertion signed by its registered key.
+//
+// Returns serviceaccount.ErrInvalidAssertion for invalid, unknown or replayed assertions.
+func (s *ServiceAccountService) ExchangeAssertion(ctx context.Context, assertion string) (accessToken string, ttl time.Duration, err error) {
+	var account *serviceaccount.ServiceAccount
+
+	lookupKey := func(clientID string) (string, error) {
+		id, err := uuid.Parse(clientID)
+		if err != nil {
+			return "", serviceaccount.ErrNotFound
+		}
+
+		account, err = s.repo.GetByClientID(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		if account.PublicKey == "" {
+			return "", serviceaccount.ErrNoAssertionKey
+		}
+		return account.PublicKey, nil
+	}
+
+	_, jti, expiresAt, err := s.tokenManager.ParseClientAssertion(assertion, lookupKey)
+	if err != nil {
+		var ae *apperr.AuthError
+		if errors.Is(err, serviceaccount.ErrNotFound) || errors.Is(err, serviceaccount.ErrNoAssertionKey) || errors.As(err, &ae) {
+			return "", 0, serviceaccount.ErrInvalidAssertion
+		}
+		return "", 0, err
+	}
+
+	if !s.markAssertionUsed(account.ClientID.String()+":"+jti, expiresAt) {
+		return "", 0, serviceaccount.ErrInvalidAssertion
+	}
+
+	return s.issue(ctx, account)
+}
+
+// issue signs an access token for the account and records its use.
+func (s *ServiceAccountService) issue(ctx context.Context, account *serviceaccount.ServiceAccount) (string, time.Duration, error) {
+	accessToken, ttl, err := s.tokenManager.GenerateServiceAccessToken(account.OwnerID, account.ClientID.String())
+	if err != nil {
+		return "", 0, err
+	}
+
+	// A failed update only loses usage information, it must not reject the exchange.
+	_ = s.repo.TouchLastUsed(ctx, account.ID, s.now())
+
+	return accessToken, ttl, nil
+}
+
+// markAssertionUsed remembers an assertion ID until it expires. It returns
+// false if the ID has already been used.
+func (s *ServiceAccountService) markAssertionUsed(key string, expiresAt time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, exp := range s.usedJTIs {
+		if !now.Before(exp) {
+			delete(s.usedJTIs, k)
+		}
+	}
+
+	if _, used := s.usedJTIs[key]; used {
+		return false
+	}
+	s.usedJTIs[key] = expiresAt
+
+	return true
+}
+
+// parseGrant parses the client ID and entry UUID of a grant.
+func parseGrant(clientID string, keyUUID string) (uuid.UUID, uuid.UUID, error) {
+	id, err := uuid.Parse(clientID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, serviceaccount.ErrGrantNotFound
+	}
+	key, err := uuid.Parse(keyUUID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, serviceaccount.ErrGrantNotFound
+	}
+	return id, key, nil
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/serviceaccount"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestServiceAccountService(repo *mocks.ServiceAccountRepositoryMock, tm *mocks.ServiceTokenManagerMock, now time.Time) *ServiceAccountService {
+	s := NewServiceAccountService(repo, tm)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestServiceAccountService_Create(t *testing.T) {
+	repo := new(mocks.ServiceAccountRepositoryMock)
+	tm := new(mocks.ServiceTokenManagerMock)
+	s := newTestServiceAccountService(repo, tm, time.Now())
+
+	ctx := context.Background()
+	account := &serviceaccount.ServiceAccount{ID: 1, ClientID: uuid.New(), OwnerID: 1, Name: "deployer"}
+
+	var storedHash string
+	tm.On("ValidateAssertionKey", "PEM").Return(nil)
+	repo.On("Create", ctx, int64(1), "deployer", mock.AnythingOfType("string"), "PEM").
+		Run(func(args mock.Arguments) { storedHash = args.String(3) }).
+		Return(account, nil)
+
+	got, secret, err := s.Create(ctx, 1, " deployer ", " PEM\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, account, got)
+	assert.True(t, strings.HasPrefix(secret, serviceaccount.SecretPrefix))
+	assert.Equal(t, hashSecretToken(secret), storedHash)
+	repo.AssertExpectations(t)
+}
+
+func TestServiceAccountService_Create_Validate_Error(t *testing.T) {
+	repo := new(mocks.ServiceAccountRepositoryMock)
+	tm := new(mocks.ServiceTokenManagerMock)
+	s := newTestServiceAccountService(repo, tm, time.Now())
+
+	ctx := context.Background()
+	tm.On("ValidateAssertionKey", "bad").Return(errors.New("invalid PEM public key"))
+
+	_, _, err := s.Create(ctx, 1, "", "")
+	assert.ErrorIs(t, err, serviceaccount.ErrNameEmpty)
+
+	_, _, err = s.Create(ctx, 1, "deployer", "bad")
+	assert.ErrorIs(t, err, serviceaccount.ErrInvalidPublicKey)
+
+	repo.AssertNotCalled(t, "Create")
+}
+
+func TestServiceAccountService_ExchangeClientSecret(t *testing.T) {
+	repo := new(mocks.ServiceAccountRepositoryMock)
+	tm := new(mocks.ServiceTokenManagerMock)
+	now := time.Date(2025, 10, 24, 9, 0, 0, 0, time.UTC)
+	s := newTestServiceAccountService(repo, tm, now)
+
+	ctx := context.Background()
+	secret := serviceaccount.SecretPrefix + "secret"
+	account := &serviceaccount.ServiceAccount{ID: 3, ClientID: uuid.New(), OwnerID: 7, SecretHash: hashSecretToken(secret)}
+
+	repo.On("GetByClientID", ctx, account.ClientID).Return(account, nil)
+	repo.On("TouchLastUsed", ctx, int64(3), now).Return(nil)
+	tm.On("GenerateServiceAccessToken", int64(7), account.ClientID.String()).Return("access", 15*time.Minute, nil)
+
+	token, ttl, err := s.ExchangeClientSecret(ctx, account.ClientID.String(), secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "access", token)
+	assert.Equal(t, 15*time.Minute, ttl)
+
+	_, _, err = s.ExchangeClientSecret(ctx, account.ClientID.String(), "wrong")
+	assert.ErrorIs(t, err, serviceaccount.ErrInvalidCredentials)
+
+	_, _, err = s.ExchangeClientSecret(ctx, "not-a-uuid", secret)
+	assert.ErrorIs(t, err, serviceaccount.ErrInvalidCredentials)
+
+	unknown := uuid.New()
+	repo.On("GetByClientID", ctx, unknown).Return(nil, serviceaccount.ErrNotFound)
+	_, _, err = s.ExchangeClientSecret(ctx, unknown.String(), secret)
+	assert.ErrorIs(t, err, serviceaccount.ErrInvalidCredentials)
+}
+
+func TestServiceAccountService_ExchangeAssertion(t *testing.T) {
+	repo := new(mocks.ServiceAccountRepositoryMock)
+	tm := new(mocks.ServiceTokenManagerMock)
+	now := time.Date(2025, 10, 24, 9, 0, 0, 0, time.UTC)
+	s := newTestServiceAccountService(repo, tm, now)
+
+	ctx := context.Background()
+	account := &serviceaccount.ServiceAccount{ID: 3, ClientID: uuid.New(), OwnerID: 7, PublicKey: "PEM"}
+
+	repo.On("GetByClientID", ctx, account.ClientID).Return(account, nil)
+	repo.On("TouchLastUsed", ctx, int64(3), now).Return(nil)
+	tm.On("GenerateServiceAccessToken", int64(7), account.ClientID.String()).Return("access", 15*time.Minute, nil)
+	tm.On("ParseClientAssertion", "assertion", mock.Anything).
+		Run(func(args mock.Arguments) {
+			lookup := args.Get(1).(func(string) (string, error))
+			key, err := lookup(account.ClientID.String())
+			assert.NoError(t, err)
+			assert.Equal(t, "PEM", key)
+		}).
+		Return(account.ClientID.String(), "jti-1", now.Add(time.Minute), nil)
+	tm.On("ParseClientAssertion", "forged", mock.Anything).
+		Return("", "", time.Time{}, apperr.NewAuthError("invalid client assertion"))
+
+	token, _, err := s.ExchangeAssertion(ctx, "assertion")
+	assert.NoError(t, err)
+	assert.Equal(t, "access", token)
+
+	// the same assertion cannot be exchanged twice
+	_, _, err = s.ExchangeAssertion(ctx, "assertion")
+	assert.ErrorIs(t, err, serviceaccount.ErrInvalidAssertion)
+
+	_, _, err = s.ExchangeAssertion(ctx, "forged")
+	assert.ErrorIs(t, err, serviceaccount.ErrInvalidAssertion)
+}
+
+func TestServiceAccountService_Exists(t *testing.T) {
+	repo := new(mocks.ServiceAccountRepositoryMock)
+	s := newTestServiceAccountService(repo, new(mocks.ServiceTokenManagerMock), time.Now())
+
+	ctx := context.Background()
+	live, deleted, broken := uuid.New(), uuid.New(), uuid.New()
+	repo.On("GetByClientID", ctx, live).Return(&serviceaccount.ServiceAccount{ID: 1, ClientID: live}, nil)
+	repo.On("GetByClientID", ctx, deleted).Return(nil, serviceaccount.ErrNotFound)
+	repo.On("GetByClientID", ctx, broken).Return(nil, errors.New("db down"))
+
+	ok, err := s.Exists(ctx, live)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = s.Exists(ctx, deleted)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = s.Exists(ctx, broken)
+	assert.Error(t, err)
+}
+
+func TestServiceAccountService_Grant(t *testing.T) {
+	repo := new(mocks.ServiceAccountRepositoryMock)
+	s := newTestServiceAccountService(repo, new(mocks.ServiceTokenManagerMock), time.Now())
+
+	ctx := context.Background()
+	clientID, keyUUID := uuid.New(), uuid.New()
+	repo.On("Grant", ctx, int64(1), clientID, keyUUID).Return(nil)
+	repo.On("RevokeGrant", ctx, int64(1), clientID, keyUUID).Return(serviceaccount.ErrGrantNotFound)
+
+	assert.NoError(t, s.Grant(ctx, 1, clientID.String(), keyUUID.String()))
+	assert.ErrorIs(t, s.RevokeGrant(ctx, 1, clientID.String(), keyUUID.String()), serviceaccount.ErrGrantNotFound)
+	assert.ErrorIs(t, s.Grant(ctx, 1, clientID.String(), "bad"), serviceaccount.ErrGrantNotFound)
+	repo.AssertExpectations(t)
+}
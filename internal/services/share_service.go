@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/share"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+)
+
+type IShareService interface {
+	SetKeyPair(ctx context.Context, userID int64, publicKey []byte, keyMaterial []byte) error
+	KeyPair(ctx context.Context, userID int64) (publicKey []byte, keyMaterial []byte, err error)
+	PublicKey(ctx context.Context, login string) ([]byte, error)
+
+	Share(ctx context.Context, userID int64, keyUUID string, login string, wrappedKey []byte, nonce []byte, data []byte) (*share.Share, error)
+	Recipients(ctx context.Context, userID int64, keyUUID string) ([]*share.Share, error)
+	Revoke(ctx context.Context, userID int64, keyUUID string, login string) error
+
+	SharedWithMe(ctx context.Context, userID int64) ([]*share.Share, error)
+	GetShared(ctx context.Context, userID int64, shareUUID string) (*share.Share, error)
+	Dismiss(ctx context.Context, userID int64, shareUUID string) error
+}
+
+// ShareService shares single personal entries end-to-end encrypted with
+// other users.
+//
+// Every user who receives shares has an X25519 key pair: the public key is
+// stored as is, the private key only wrapped by the client as key material.
+// The sharing client seals the entry for the public key of the recipient, so
+// the service handles nothing but opaque ciphertext.
+type ShareService struct {
+	repo         share.ShareRepository
+	keychainRepo keychain.KeychainRepository
+	userRepo     user.UserRepository
+	auditor      AuditRecorder
+}
+
+// NewShareService constructs a new ShareService with given dependencies.
+func NewShareService(repo share.ShareRepository, keychainRepo keychain.KeychainRepository, userRepo user.UserRepository, auditor AuditRecorder) ShareService {
+	return ShareService{
+		repo:         repo,
+		keychainRepo: keychainRepo,
+		userRepo:     userRepo,
+		auditor:      auditor,
+	}
+}
+
+// SetKeyPair stores the public key and the wrapped private key of the user.
+// Entries shared with the user for a different public key can no longer be
+// opened and are removed.
+func (s *ShareService) SetKeyPair(ctx context.Context, userID int64, publicKey []byte, keyMaterial []byte) error {
+	if err := share.ValidateKeyPair(publicKey, keyMaterial); err != nil {
+		return err
+	}
+
+	return s.userRepo.SetKeyPair(ctx, userID, publicKey, keyMaterial)
+}
+
+// KeyPair returns the public key and the wrapped private key of the user, or
+// share.ErrNoKeyPair if the user has none.
+func (s *ShareService) KeyPair(ctx context.Context, userID int64) ([]byte, []byte, error) {
+	au, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if au.PublicKey == nil || au.KeyMaterial == nil {
+		return nil, nil, share.ErrNoKeyPair
+	}
+
+	return au.PublicKey, au.KeyMaterial, nil
+}
+
+// PublicKey returns the public key of the user with the given login, or
+// share.ErrNoPublicKey if the user has none.
+func (s *ShareService) PublicKey(ctx context.Context, login string) ([]byte, error) {
+	au, err := s.userRepo.GetByLogin(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	if au.PublicKey == nil {
+		return nil, share.ErrNoPublicKey
+	}
+
+	return au.PublicKey, nil
+}
+
+// Share stores a personal entry of the user sealed for the user with the
+// given login, replacing an earlier share of the entry with them.
+func (s *ShareService) Share(ctx context.Context, userID int64, keyUUID string, login string, wrappedKey []byte, nonce []byte, data []byte) (sh *share.Share, err error) {
+	defer func() {
+		err = recordAudit(ctx, s.auditor, audit.Event{
+			UserID:  userID,
+			Action:  audit.ActionKeyShare,
+			KeyUUID: parseAuditKey(keyUUID),
+		}, err)
+		if err != nil {
+			sh = nil
+		}
+	}()
+
+	if err := share.ValidatePayload(wrappedKey, nonce, data); err != nil {
+		return nil, err
+	}
+
+	rec, err := s.entry(ctx, userID, keyUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient, err := s.userRepo.GetByLogin(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	if recipient.ID == userID {
+		return nil, share.ErrShareWithSelf
+	}
+	if recipient.PublicKey == nil {
+		return nil, share.ErrNoPublicKey
+	}
+
+	return s.repo.Upsert(ctx, &share.Share{
+		KeyID:       rec.ID,
+		OwnerID:     userID,
+		RecipientID: recipient.ID,
+		WrappedKey:  wrappedKey,
+		Nonce:       nonce,
+		Data:        data,
+	})
+}
+
+// Recipients returns the shares of a personal entry of the user.
+func (s *ShareService) Recipients(ctx context.Context, userID int64, keyUUID string) ([]*share.Share, error) {
+	rec, err := s.entry(ctx, userID, keyUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListByKey(ctx, rec.ID)
+}
+
+// Revoke removes the share of a personal entry of the user with the user
+// with the given login.
+func (s *ShareService) Revoke(ctx context.Context, userID int64, keyUUID string, login string) (err error) {
+	defer func() {
+		err = recordAudit(ctx, s.auditor, audit.Event{
+			UserID:  userID,
+			Action:  audit.ActionKeyUnshare,
+			KeyUUID: parseAuditKey(keyUUID),
+		}, err)
+	}()
+
+	rec, err := s.entry(ctx, userID, keyUUID)
+	if err != nil {
+		return err
+	}
+
+	recipient, err := s.userRepo.GetByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return share.ErrNotFound
+		}
+		return err
+	}
+
+	return s.repo.DeleteByKey(ctx, rec.ID, recipient.ID)
+}
+
+// SharedWithMe returns the entries shared with the user, without their
+// sealed payload.
+func (s *ShareService) SharedWithMe(ctx context.Context, userID int64) ([]*share.Share, error) {
+	return s.repo.ListForRecipient(ctx, userID)
+}
+
+// GetShared returns an entry shared with the user with its sealed payload.
+func (s *ShareService) GetShared(ctx context.Context, userID int64, shareUUID string) (*share.Share, error) {
+	id, err := uuid.Parse(shareUUID)
+	if err != nil {
+		return nil, share.ErrNotFound
+	}
+
+	return s.repo.GetForRecipient(ctx, userID, id)
+}
+
+// Dismiss removes an entry shared with the user from their list.
+func (s *ShareService) Dismiss(ctx context.Context, userID int64, shareUUID string) error {
+	id, err := uuid.Parse(shareUUID)
+	if err != nil {
+		return share.ErrNotFound
+	}
+
+	return s.repo.DeleteForRecipient(ctx, userID, id)
+}
+
+// entry loads a personal entry of the user.
+func (s *ShareService) entry(ctx context.Context, userID int64, keyUUID string) (*keychain.KeyRecord, error) {
+	if _, err := uuid.Parse(keyUUID); err != nil {
+		return nil, share.ErrEntryNotFound
+	}
+
+	rec, err := s.keychainRepo.GetUserKey(ctx, userID, keyUUID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, share.ErrEntryNotFound
+		}
+		return nil, err
+	}
+
+	return rec, nil
+}
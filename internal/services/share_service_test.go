@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/share"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"testing"
+)
+
+type shareTestDeps struct {
+	repo     *mocks.ShareRepositoryMock
+	keychain *mocks.KeychainRepositoryMock
+	users    *mocks.UserRepositoryMock
+	auditor  *mocks.AuditServiceMock
+}
+
+func newTestShareService() (ShareService, shareTestDeps) {
+	deps := shareTestDeps{
+		repo:     new(mocks.ShareRepositoryMock),
+		keychain: new(mocks.KeychainRepositoryMock),
+		users:    new(mocks.UserRepositoryMock),
+		auditor:  nopAuditor(),
+	}
+	return NewShareService(deps.repo, deps.keychain, deps.users, deps.auditor), deps
+}
+
+func TestShareService_SetKeyPair_Validate(t *testing.T) {
+	s, deps := newTestShareService()
+	ctx := context.Background()
+
+	assert.ErrorIs(t, s.SetKeyPair(ctx, 1, make([]byte, 31), []byte{1}), share.ErrInvalidPublicKey)
+	assert.ErrorIs(t, s.SetKeyPair(ctx, 1, make([]byte, 32), nil), share.ErrInvalidKeyMaterial)
+	deps.users.AssertNotCalled(t, "SetKeyPair", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	deps.users.On("SetKeyPair", ctx, int64(1), make([]byte, 32), []byte{1}).Return(nil)
+	assert.NoError(t, s.SetKeyPair(ctx, 1, make([]byte, 32), []byte{1}))
+}
+
+func TestShareService_KeyPair_None(t *testing.T) {
+	s, deps := newTestShareService()
+	ctx := context.Background()
+
+	deps.users.On("GetByID", ctx, int64(1)).Return(&user.UserRecord{ID: 1}, nil)
+
+	_, _, err := s.KeyPair(ctx, 1)
+
+	assert.ErrorIs(t, err, share.ErrNoKeyPair)
+}
+
+func TestShareService_Share_Success(t *testing.T) {
+	s, deps := newTestShareService()
+	ctx := context.Background()
+
+	keyUUID := uuid.NewString()
+	stored := &share.Share{ShareUUID: uuid.New()}
+
+	deps.keychain.On("GetUserKey", ctx, int64(1), keyUUID).Return(&keychain.KeyRecord{ID: 10}, nil)
+	deps.users.On("GetByLogin", ctx, "bob").Return(&user.UserRecord{ID: 2, PublicKey: make([]byte, 32)}, nil)
+
+	var got *share.Share
+	deps.repo.On("Upsert", ctx, mock.AnythingOfType("*share.Share")).
+		Run(func(args mock.Arguments) { got = args.Get(1).(*share.Share) }).
+		Return(stored, nil)
+
+	sh, err := s.Share(ctx, 1, keyUUID, "bob", []byte("wrapped"), []byte("nonce"), []byte("data"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, stored, sh)
+	assert.Equal(t, int64(10), got.KeyID)
+	assert.Equal(t, int64(1), got.OwnerID)
+	assert.Equal(t, int64(2), got.RecipientID)
+	assert.Equal(t, []byte("data"), got.Data)
+	deps.auditor.AssertCalled(t, "Record", ctx, mock.MatchedBy(func(e audit.Event) bool {
+		return e.Action == audit.ActionKeyShare && e.Result == audit.ResultSuccess && e.KeyUUID.String() == keyUUID
+	}))
+}
+
+func TestShareService_Share_Errors(t *testing.T) {
+	keyUUID := uuid.NewString()
+	payload := [][]byte{[]byte("wrapped"), []byte("nonce"), []byte("data")}
+
+	tests := []struct {
+		name    string
+		keyUUID string
+		login   string
+		payload [][]byte
+		wantErr error
+	}{
+		{name: "empty payload", keyUUID: keyUUID, login: "bob", payload: [][]byte{nil, []byte("n"), []byte("d")}, wantErr: share.ErrInvalidPayload},
+		{name: "invalid entry uuid", keyUUID: "nope", login: "bob", payload: payload, wantErr: share.ErrEntryNotFound},
+		{name: "unknown entry", keyUUID: uuid.NewString(), login: "bob", payload: payload, wantErr: share.ErrEntryNotFound},
+		{name: "unknown recipient", keyUUID: keyUUID, login: "ghost", payload: payload, wantErr: user.ErrUserNotFound},
+		{name: "recipient without key", keyUUID: keyUUID, login: "carol", payload: payload, wantErr: share.ErrNoPublicKey},
+		{name: "with self", keyUUID: keyUUID, login: "alice", payload: payload, wantErr: share.ErrShareWithSelf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, deps := newTestShareService()
+			ctx := context.Background()
+
+			deps.keychain.On("GetUserKey", ctx, int64(1), keyUUID).Return(&keychain.KeyRecord{ID: 10}, nil)
+			deps.keychain.On("GetUserKey", ctx, int64(1), mock.Anything).Return((*keychain.KeyRecord)(nil), sql.ErrNoRows)
+			deps.users.On("GetByLogin", ctx, "ghost").Return((*user.UserRecord)(nil), user.ErrUserNotFound)
+			deps.users.On("GetByLogin", ctx, "carol").Return(&user.UserRecord{ID: 3}, nil)
+			deps.users.On("GetByLogin", ctx, "alice").Return(&user.UserRecord{ID: 1, PublicKey: make([]byte, 32)}, nil)
+
+			_, err := s.Share(ctx, 1, tt.keyUUID, tt.login, tt.payload[0], tt.payload[1], tt.payload[2])
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			deps.repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestShareService_Revoke(t *testing.T) {
+	s, deps := newTestShareService()
+	ctx := context.Background()
+
+	keyUUID := uuid.NewString()
+
+	deps.keychain.On("GetUserKey", ctx, int64(1), keyUUID).Return(&keychain.KeyRecord{ID: 10}, nil)
+	deps.users.On("GetByLogin", ctx, "bob").Return(&user.UserRecord{ID: 2}, nil)
+	deps.users.On("GetByLogin", ctx, "ghost").Return((*user.UserRecord)(nil), user.ErrUserNotFound)
+	deps.repo.On("DeleteByKey", ctx, int64(10), int64(2)).Return(nil)
+
+	assert.NoError(t, s.Revoke(ctx, 1, keyUUID, "bob"))
+	assert.ErrorIs(t, s.Revoke(ctx, 1, keyUUID, "ghost"), share.ErrNotFound)
+	deps.repo.AssertNumberOfCalls(t, "DeleteByKey", 1)
+	deps.auditor.AssertCalled(t, "Record", ctx, mock.MatchedBy(func(e audit.Event) bool {
+		return e.Action == audit.ActionKeyUnshare && e.Result == audit.ResultSuccess
+	}))
+}
+
+func TestShareService_GetShared(t *testing.T) {
+	s, deps := newTestShareService()
+	ctx := context.Background()
+
+	shareUUID := uuid.New()
+	stored := &share.Share{ShareUUID: shareUUID, Data: []byte("data")}
+
+	deps.repo.On("GetForRecipient", ctx, int64(2), shareUUID).Return(stored, nil)
+
+	sh, err := s.GetShared(ctx, 2, shareUUID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, stored, sh)
+
+	_, err = s.GetShared(ctx, 2, "nope")
+	assert.ErrorIs(t, err, share.ErrNotFound)
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+	"sort"
+	"strings"
+)
+
+// VaultResolver looks up a shared vault together with the effective role
+// the user has in it. It returns vault.ErrNotFound when the user has none.
+type VaultResolver interface {
+	GetVault(ctx context.Context, userID int64, vaultUUID uuid.UUID) (*vault.Vault, error)
+}
+
+type IVaultService interface {
+	CreateOrganization(ctx context.Context, userID int64, name string) (*vault.Organization, error)
+	ListOrganizations(ctx context.Context, userID int64) ([]*vault.Organization, error)
+	OrganizationMembers(ctx context.Context, userID int64, orgUUID string) ([]*vault.Member, error)
+	SetOrganizationMember(ctx context.Context, userID int64, orgUUID string, login string, role string) error
+	RemoveOrganizationMember(ctx context.Context, userID int64, orgUUID string, login string) error
+
+	CreateVault(ctx context.Context, userID int64, name string, orgUUID string) (*vault.Vault, error)
+	ListVaults(ctx context.Context, userID int64) ([]*vault.Vault, error)
+	Members(ctx context.Context, userID int64, vaultUUID string) ([]*vault.Member, error)
+	Share(ctx context.Context, userID int64, vaultUUID string, login string, role string) error
+	Unshare(ctx context.Context, userID int64, vaultUUID string, login string) error
+}
+
+// VaultService manages organizations, shared vaults and their members.
+//
+// Owners may assign any role, admins only editor and viewer; anyone may
+// leave. An organization and a vault outside any organization always keep at
+// least one owner. The entries of a vault are read and written through
+// KeychainService, which checks the role of the user in the vault.
+type VaultService struct {
+	repo     vault.VaultRepository
+	userRepo user.UserRepository
+}
+
+// NewVaultService constructs a new VaultService with given dependencies.
+func NewVaultService(repo vault.VaultRepository, userRepo user.UserRepository) VaultService {
+	return VaultService{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+// CreateOrganization creates an organization owned by the user.
+func (s *VaultService) CreateOrganization(ctx context.Context, userID int64, name string) (*vault.Organization, error) {
+	if err := vault.ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateOrganization(ctx, userID, strings.TrimSpace(name))
+}
+
+// ListOrganizations returns the organizations the user is a member of.
+func (s *VaultService) ListOrganizations(ctx context.Context, userID int64) ([]*vault.Organization, error) {
+	return s.repo.ListOrganizations(ctx, userID)
+}
+
+// OrganizationMembers returns the members of an organization of the user.
+func (s *VaultService) OrganizationMembers(ctx context.Context, userID int64, orgUUID string) ([]*vault.Member, error) {
+	org, err := s.organization(ctx, userID, orgUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListOrganizationMembers(ctx, org.ID)
+}
+
+// SetOrganizationMember adds the user with the given login to the
+// organization with role, or changes the role of a member.
+func (s *VaultService) SetOrganizationMember(ctx context.Context, userID int64, orgUUID string, login string, role string) error {
+	next, ok := vault.ParseRole(role)
+	if !ok {
+		return vault.ErrInvalidRole
+	}
+
+	org, err := s.organization(ctx, userID, orgUUID)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.userRepo.GetByLogin(ctx, login)
+	if err != nil {
+		return err
+	}
+
+	members, err := s.repo.ListOrganizationMembers(ctx, org.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeMemberChange(org.Role, userID, members, target.ID, next, true); err != nil {
+		return err
+	}
+
+	return s.repo.SetOrganizationMember(ctx, org.ID, target.ID, next)
+}
+
+// RemoveOrganizationMember removes the user with the given login from the
+// organization.
+func (s *VaultService) RemoveOrganizationMember(ctx context.Context, userID int64, orgUUID string, login string) error {
+	org, err := s.organization(ctx, userID, orgUUID)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.userRepo.GetByLogin(ctx, login)
+	if err != nil {
+		return err
+	}
+
+	members, err := s.repo.ListOrganizationMembers(ctx, org.ID)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := memberRole(members, target.ID); !ok {
+		return vault.ErrMemberNotFound
+	}
+	if err := authorizeMemberChange(org.Role, userID, members, target.ID, "", true); err != nil {
+		return err
+	}
+
+	return s.repo.RemoveOrganizationMember(ctx, org.ID, target.ID)
+}
+
+// CreateVault creates a vault owned by the user. With orgUUID set the vault
+// belongs to that organization, which requires an admin or owner role in it.
+func (s *VaultService) CreateVault(ctx context.Context, userID int64, name string, orgUUID string) (*vault.Vault, error) {
+	if err := vault.ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	var orgID *int64
+	if orgUUID != "" {
+		org, err := s.organization(ctx, userID, orgUUID)
+		if err != nil {
+			return nil, err
+		}
+		if !org.Role.CanManage() {
+			return nil, vault.ErrInsufficientRole
+		}
+		orgID = &org.ID
+	}
+
+	return s.repo.CreateVault(ctx, userID, orgID, strings.TrimSpace(name))
+}
+
+// ListVaults returns the vaults the user has a role in.
+func (s *VaultService) ListVaults(ctx context.Context, userID int64) ([]*vault.Vault, error) {
+	return s.repo.ListVaults(ctx, userID)
+}
+
+// Members returns everyone with a role in a vault of the user: the direct
+// members and, for a vault of an organization, the organization members.
+// Each user is listed once with the effective role.
+func (s *VaultService) Members(ctx context.Context, userID int64, vaultUUID string) ([]*vault.Member, error) {
+	v, err := s.vault(ctx, userID, vaultUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.ListVaultMembers(ctx, v.ID)
+	if err != nil {
+		return nil, err
+	}
+	if v.OrgID == nil {
+		return members, nil
+	}
+
+	orgMembers, err := s.repo.ListOrganizationMembers(ctx, *v.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := make(map[int64]*vault.Member, len(members))
+	for _, m := range members {
+		byUser[m.UserID] = m
+	}
+	for _, om := range orgMembers {
+		m, ok := byUser[om.UserID]
+		if !ok {
+			inherited := *om
+			inherited.Inherited = true
+			members = append(members, &inherited)
+			continue
+		}
+		if vault.Stronger(m.Role, om.Role) != m.Role {
+			m.Role = om.Role
+			m.Inherited = true
+		}
+	}
+
+	sort.Slice(members, func(i, j int) bool { return members[i].Login < members[j].Login })
+
+	return members, nil
+}
+
+// Share gives the user with the given login role in a vault of the user, or
+// changes the role they have in it directly.
+func (s *VaultService) Share(ctx context.Context, userID int64, vaultUUID string, login string, role string) error {
+	next, ok := vault.ParseRole(role)
+	if !ok {
+		return vault.ErrInvalidRole
+	}
+
+	v, err := s.vault(ctx, userID, vaultUUID)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.userRepo.GetByLogin(ctx, login)
+	if err != nil {
+		return err
+	}
+
+	members, err := s.repo.ListVaultMembers(ctx, v.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeMemberChange(v.Role, userID, members, target.ID, next, v.OrgID == nil); err != nil {
+		return err
+	}
+
+	return s.repo.SetVaultMember(ctx, v.ID, target.ID, next)
+}
+
+// Unshare removes the direct role of the user with the given login from a
+// vault of the user. A role inherited from the organization stays.
+func (s *VaultService) Unshare(ctx context.Context, userID int64, vaultUUID string, login string) error {
+	v, err := s.vault(ctx, userID, vaultUUID)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.userRepo.GetByLogin(ctx, login)
+	if err != nil {
+		return err
+	}
+
+	members, err := s.repo.ListVaultMembers(ctx, v.ID)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := memberRole(members, target.ID); !ok {
+		return vault.ErrMemberNotFound
+	}
+	if err := authorizeMemberChange(v.Role, userID, members, target.ID, "", v.OrgID == nil); err != nil {
+		return err
+	}
+
+	return s.repo.RemoveVaultMember(ctx, v.ID, target.ID)
+}
+
+// organization loads an organization the user is a member of.
+func (s *VaultService) organization(ctx context.Context, userID int64, orgUUID string) (*vault.Organization, error) {
+	id, err := uuid.Parse(orgUUID)
+	if err != nil {
+		return nil, vault.ErrOrganizationNotFound
+	}
+
+	return s.repo.GetOrganization(ctx, userID, id)
+}
+
+// vault loads a vault the user has a role in.
+func (s *VaultService) vault(ctx context.Context, userID int64, vaultUUID string) (*vault.Vault, error) {
+	id, err := uuid.Parse(vaultUUID)
+	if err != nil {
+		return nil, vault.ErrNotFound
+	}
+
+	return s.repo.GetVault(ctx, userID, id)
+}
+
+// authorizeMemberChange checks that actorID, who has role actor, may change
+// the role of targetID among members to next; an empty next removes the
+// member. Anyone may remove themselves. With keepOwner set the last owner
+// can be neither removed nor demoted.
+func authorizeMemberChange(actor vault.Role, actorID int64, members []*vault.Member, targetID int64, next vault.Role, keepOwner bool) error {
+	current, isMember := memberRole(members, targetID)
+
+	leaving := next == "" && targetID == actorID
+	if !leaving {
+		if next != "" && !actor.CanAssign(next) {
+			return vault.ErrInsufficientRole
+		}
+		if isMember && !actor.CanAssign(current) {
+			return vault.ErrInsufficientRole
+		}
+	}
+
+	if keepOwner && current == vault.RoleOwner && next != vault.RoleOwner {
+		owners := 0
+		for _, m := range members {
+			if m.Role == vault.RoleOwner {
+				owners++
+			}
+		}
+		if owners == 1 {
+			return vault.ErrLastOwner
+		}
+	}
+
+	return nil
+}
+
+// memberRole returns the role of userID among members.
+func memberRole(members []*vault.Member, userID int64) (vault.Role, bool) {
+	for _, m := range members {
+		if m.UserID == userID {
+			return m.Role, true
+		}
+	}
+	return "", false
+}
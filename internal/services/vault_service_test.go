@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"testing"
+)
+
+func TestVaultService_CreateVault_InOrganization(t *testing.T) {
+	repo := new(mocks.VaultRepositoryMock)
+	s := NewVaultService(repo, new(mocks.UserRepositoryMock))
+
+	ctx := context.Background()
+	orgUUID := uuid.New()
+	org := &vault.Organization{ID: 7, OrgUUID: orgUUID, Role: vault.RoleAdmin}
+	created := &vault.Vault{ID: 1, Name: "Ops", Role: vault.RoleOwner}
+
+	repo.On("GetOrganization", ctx, int64(1), orgUUID).Return(org, nil)
+	repo.On("CreateVault", ctx, int64(1), &org.ID, "Ops").Return(created, nil)
+
+	v, err := s.CreateVault(ctx, 1, " Ops ", orgUUID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, created, v)
+	repo.AssertExpectations(t)
+}
+
+func TestVaultService_CreateVault_EditorInOrganization(t *testing.T) {
+	repo := new(mocks.VaultRepositoryMock)
+	s := NewVaultService(repo, new(mocks.UserRepositoryMock))
+
+	ctx := context.Background()
+	orgUUID := uuid.New()
+
+	repo.On("GetOrganization", ctx, int64(1), orgUUID).
+		Return(&vault.Organization{ID: 7, Role: vault.RoleEditor}, nil)
+
+	_, err := s.CreateVault(ctx, 1, "Ops", orgUUID.String())
+
+	assert.ErrorIs(t, err, vault.ErrInsufficientRole)
+	repo.AssertNotCalled(t, "CreateVault", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVaultService_CreateVault_InvalidName(t *testing.T) {
+	s := NewVaultService(new(mocks.VaultRepositoryMock), new(mocks.UserRepositoryMock))
+
+	_, err := s.CreateVault(context.Background(), 1, "  ", "")
+
+	assert.ErrorIs(t, err, vault.ErrInvalidName)
+}
+
+func TestVaultService_Share(t *testing.T) {
+	vaultUUID := uuid.New()
+	owner := &vault.Member{UserID: 1, Login: "alice", Role: vault.RoleOwner}
+	admin := &vault.Member{UserID: 2, Login: "bob", Role: vault.RoleAdmin}
+
+	tests := []struct {
+		name    string
+		actor   int64
+		role    vault.Role
+		target  string
+		next    string
+		wantErr error
+	}{
+		{name: "owner shares as editor", actor: 1, role: vault.RoleOwner, target: "carol", next: "editor"},
+		{name: "owner grants admin", actor: 1, role: vault.RoleOwner, target: "carol", next: "admin"},
+		{name: "admin shares as viewer", actor: 2, role: vault.RoleAdmin, target: "carol", next: "viewer"},
+		{name: "admin cannot grant admin", actor: 2, role: vault.RoleAdmin, target: "carol", next: "admin", wantErr: vault.ErrInsufficientRole},
+		{name: "admin cannot demote owner", actor: 2, role: vault.RoleAdmin, target: "alice", next: "viewer", wantErr: vault.ErrInsufficientRole},
+		{name: "editor cannot share", actor: 3, role: vault.RoleEditor, target: "carol", next: "viewer", wantErr: vault.ErrInsufficientRole},
+		{name: "last owner cannot step down", actor: 1, role: vault.RoleOwner, target: "alice", next: "admin", wantErr: vault.ErrLastOwner},
+		{name: "unknown role", actor: 1, role: vault.RoleOwner, target: "carol", next: "root", wantErr: vault.ErrInvalidRole},
+	}
+
+	users := map[string]int64{"alice": 1, "bob": 2, "carol": 3}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.VaultRepositoryMock)
+			userRepo := new(mocks.UserRepositoryMock)
+			s := NewVaultService(repo, userRepo)
+
+			ctx := context.Background()
+			v := &vault.Vault{ID: 5, VaultUUID: vaultUUID, Role: tt.role}
+
+			repo.On("GetVault", ctx, tt.actor, vaultUUID).Return(v, nil)
+			userRepo.On("GetByLogin", ctx, tt.target).Return(&user.UserRecord{ID: users[tt.target], Login: tt.target}, nil)
+			repo.On("ListVaultMembers", ctx, int64(5)).Return([]*vault.Member{owner, admin}, nil)
+			repo.On("SetVaultMember", ctx, int64(5), users[tt.target], vault.Role(tt.next)).Return(nil)
+
+			err := s.Share(ctx, tt.actor, vaultUUID.String(), tt.target, tt.next)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				repo.AssertNotCalled(t, "SetVaultMember", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			repo.AssertCalled(t, "SetVaultMember", ctx, int64(5), users[tt.target], vault.Role(tt.next))
+		})
+	}
+}
+
+func TestVaultService_Share_UnknownVault(t *testing.T) {
+	s := NewVaultService(new(mocks.VaultRepositoryMock), new(mocks.UserRepositoryMock))
+
+	err := s.Share(context.Background(), 1, "not-a-uuid", "bob", "viewer")
+
+	assert.ErrorIs(t, err, vault.ErrNotFound)
+}
+
+func TestVaultService_Unshare(t *testing.T) {
+	repo := new(mocks.VaultRepositoryMock)
+	userRepo := new(mocks.UserRepositoryMock)
+	s := NewVaultService(repo, userRepo)
+
+	ctx := context.Background()
+	vaultUUID := uuid.New()
+	members := []*vault.Member{
+		{UserID: 1, Login: "alice", Role: vault.RoleOwner},
+		{UserID: 3, Login: "carol", Role: vault.RoleViewer},
+	}
+
+	// carol leaves the vault on her own
+	repo.On("GetVault", ctx, int64(3), vaultUUID).Return(&vault.Vault{ID: 5, Role: vault.RoleViewer}, nil)
+	// alice is the only owner and cannot leave
+	repo.On("GetVault", ctx, int64(1), vaultUUID).Return(&vault.Vault{ID: 5, Role: vault.RoleOwner}, nil)
+	userRepo.On("GetByLogin", ctx, "carol").Return(&user.UserRecord{ID: 3}, nil)
+	userRepo.On("GetByLogin", ctx, "alice").Return(&user.UserRecord{ID: 1}, nil)
+	userRepo.On("GetByLogin", ctx, "dave").Return(&user.UserRecord{ID: 4}, nil)
+	repo.On("ListVaultMembers", ctx, int64(5)).Return(members, nil)
+	repo.On("RemoveVaultMember", ctx, int64(5), int64(3)).Return(nil)
+
+	assert.NoError(t, s.Unshare(ctx, 3, vaultUUID.String(), "carol"))
+	assert.ErrorIs(t, s.Unshare(ctx, 1, vaultUUID.String(), "alice"), vault.ErrLastOwner)
+	assert.ErrorIs(t, s.Unshare(ctx, 1, vaultUUID.String(), "dave"), vault.ErrMemberNotFound)
+	repo.AssertNumberOfCalls(t, "RemoveVaultMember", 1)
+}
+
+func TestVaultService_Members_MergesOrganization(t *testing.T) {
+	repo := new(mocks.VaultRepositoryMock)
+	s := NewVaultService(repo, new(mocks.UserRepositoryMock))
+
+	ctx := context.Background()
+	vaultUUID := uuid.New()
+	orgID := int64(7)
+
+	repo.On("GetVault", ctx, int64(1), vaultUUID).Return(&vault.Vault{ID: 5, OrgID: &orgID, Role: vault.RoleAdmin}, nil)
+	repo.On("ListVaultMembers", ctx, int64(5)).Return([]*vault.Member{
+		{UserID: 2, Login: "bob", Role: vault.RoleAdmin},
+		{UserID: 3, Login: "carol", Role: vault.RoleViewer},
+	}, nil)
+	repo.On("ListOrganizationMembers", ctx, orgID).Return([]*vault.Member{
+		{UserID: 1, Login: "alice", Role: vault.RoleOwner},
+		{UserID: 2, Login: "bob", Role: vault.RoleViewer},
+		{UserID: 3, Login: "carol", Role: vault.RoleEditor},
+	}, nil)
+
+	members, err := s.Members(ctx, 1, vaultUUID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*vault.Member{
+		{UserID: 1, Login: "alice", Role: vault.RoleOwner, Inherited: true},
+		{UserID: 2, Login: "bob", Role: vault.RoleAdmin},
+		{UserID: 3, Login: "carol", Role: vault.RoleEditor, Inherited: true},
+	}, members)
+}
+
+func TestVaultService_SetOrganizationMember_LastOwner(t *testing.T) {
+	repo := new(mocks.VaultRepositoryMock)
+	userRepo := new(mocks.UserRepositoryMock)
+	s := NewVaultService(repo, userRepo)
+
+	ctx := context.Background()
+	orgUUID := uuid.New()
+
+	repo.On("GetOrganization", ctx, int64(1), orgUUID).Return(&vault.Organization{ID: 7, Role: vault.RoleOwner}, nil)
+	userRepo.On("GetByLogin", ctx, "alice").Return(&user.UserRecord{ID: 1}, nil)
+	repo.On("ListOrganizationMembers", ctx, int64(7)).Return([]*vault.Member{{UserID: 1, Role: vault.RoleOwner}}, nil)
+
+	err := s.SetOrganizationMember(ctx, 1, orgUUID.String(), "alice", "viewer")
+
+	assert.ErrorIs(t, err, vault.ErrLastOwner)
+	repo.AssertNotCalled(t, "SetOrganizationMember", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
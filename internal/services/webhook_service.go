@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
+	"net"
+	"time"
+)
+
+const (
+	// webhookSecretBytes is the amount of randomness in a signing secret.
+	webhookSecretBytes = 32
+	// webhookBatch is the number of deliveries sent per dispatcher run.
+	webhookBatch = 50
+	// webhookSendTimeout bounds a single delivery attempt.
+	webhookSendTimeout = 10 * time.Second
+	// webhookLease postpones claimed deliveries, so a crashed dispatcher only
+	// delays them instead of losing them. It outlasts a batch of attempts that
+	// all time out, so no delivery is claimed again while still being sent.
+	webhookLease = webhookBatch*webhookSendTimeout + 2*time.Minute
+	// webhookHistoryLimit is the number of deliveries shown per subscription.
+	webhookHistoryLimit = 50
+)
+
+// WebhookPublisher queues an event for the matching subscriptions of its user.
+type WebhookPublisher interface {
+	Publish(ctx context.Context, e webhook.Event) error
+}
+
+// WebhookSender posts a signed delivery to a subscriber and returns the HTTP
+// status code of the response, or zero if none was received.
+type WebhookSender interface {
+	Send(ctx context.Context, url string, d *webhook.Delivery, signature string) (statusCode int, err error)
+}
+
+type IWebhookService interface {
+	WebhookPublisher
+	Create(ctx context.Context, userID int64, url string, events []string, tags []string) (sub *webhook.Subscription, secret string, err error)
+	List(ctx context.Context, userID int64) ([]*webhook.Subscription, error)
+	Delete(ctx context.Context, userID int64, subscriptionID string) error
+	Deliveries(ctx context.Context, userID int64, subscriptionID string) ([]*webhook.Delivery, error)
+	DeliverDue(ctx context.Context) (int, error)
+}
+
+// WebhookService manages webhook subscriptions and delivers their events.
+//
+// Events are not sent while the request is served: Publish writes one
+// delivery per matching subscription to the outbox and DeliverDue, called
+// periodically by the server, sends them signed with the subscription secret.
+// Failed deliveries are retried with exponential backoff until
+// webhook.MaxAttempts attempts were made.
+//
+// The management methods take webhook.GlobalOwner as the user to manage the
+// global subscriptions, which is left to administrators.
+type WebhookService struct {
+	repo         webhook.WebhookRepository
+	cryptManager CryptManager
+	sender       WebhookSender
+	now          func() time.Time
+}
+
+// NewWebhookService constructs a new WebhookService with given dependencies.
+func NewWebhookService(repo webhook.WebhookRepository, cManager CryptManager, sender WebhookSender) WebhookService {
+	return WebhookService{
+		repo:         repo,
+		cryptManager: cManager,
+		sender:       sender,
+		now:          time.Now,
+	}
+}
+
+// Create subscribes the user to events sent to url.
+//
+// tags restricts entry events to entries carrying one of them. Returns the
+// stored subscription and its signing secret, which is not retrievable later.
+func (s *WebhookService) Create(ctx context.Context, userID int64, url string, events []string, tags []string) (sub *webhook.Subscription, secret string, err error) {
+	if err := webhook.ValidateURL(url); err != nil {
+		return nil, "", err
+	}
+	parsed, err := webhook.ParseEvents(events)
+	if err != nil {
+		return nil, "", err
+	}
+	tags, err = keychain.NormalizeTags(tags)
+	if err != nil {
+		return nil, "", err
+	}
+
+	existing, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(existing) >= webhook.MaxSubscriptions {
+		return nil, "", webhook.ErrTooManyWebhooks
+	}
+
+	raw := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", err
+	}
+	secret = webhook.SecretPrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	nonce, ct, err := s.cryptManager.Encrypt([]byte(secret))
+	if err != nil {
+		return nil, "", err
+	}
+
+	sub, err = s.repo.Create(ctx, &webhook.Subscription{
+		UserID:          userID,
+		URL:             url,
+		Events:          parsed,
+		Tags:            tags,
+		SecretNonce:     nonce,
+		SecretEncrypted: ct,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return sub, secret, nil
+}
+
+// List returns the subscriptions of the user.
+func (s *WebhookService) List(ctx context.Context, userID int64) ([]*webhook.Subscription, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// Delete removes a subscription of the user and its pending deliveries.
+//
+// Returns webhook.ErrNotFound if the user has no such subscription.
+func (s *WebhookService) Delete(ctx context.Context, userID int64, subscriptionID string) error {
+	id, err := uuid.Parse(subscriptionID)
+	if err != nil {
+		return webhook.ErrNotFound
+	}
+	return s.repo.Delete(ctx, userID, id)
+}
+
+// Deliveries returns the delivery history of a subscription of the user, newest first.
+//
+// Returns webhook.ErrNotFound if the user has no such subscription.
+func (s *WebhookService) Deliveries(ctx context.Context, userID int64, subscriptionID string) ([]*webhook.Delivery, error) {
+	id, err := uuid.Parse(subscriptionID)
+	if err != nil {
+		return nil, webhook.ErrNotFound
+	}
+
+	sub, err := s.repo.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListDeliveries(ctx, sub.ID, webhookHistoryLimit)
+}
+
+// Publish queues e for every subscription of its user, and every global
+// subscription, that matches it.
+//
+// The event ID, time and client IP are filled in here.
+func (s *WebhookService) Publish(ctx context.Context, e webhook.Event) error {
+	subs, err := s.repo.ListForEvents(ctx, e.UserID)
+	if err != nil {
+		return err
+	}
+
+	now := s.now().UTC()
+	e.ID = uuid.New()
+	e.OccurredAt = now
+	e.IP = audit.RequestInfoFromContext(ctx).IP
+
+	payload, err := e.Payload()
+	if err != nil {
+		return err
+	}
+
+	var deliveries []*webhook.Delivery
+	for _, sub := range subs {
+		if !sub.Matches(e) {
+			continue
+		}
+		deliveries = append(deliveries, &webhook.Delivery{
+			DeliveryID:     uuid.New(),
+			SubscriptionID: sub.ID,
+			EventType:      e.Type,
+			Payload:        payload,
+			Status:         webhook.StatusPending,
+			NextAttemptAt:  now,
+		})
+	}
+
+	return s.repo.Enqueue(ctx, deliveries)
+}
+
+// DeliverDue sends the deliveries whose attempt is due and records the
+// outcome of every attempt. A 2xx response marks a delivery as delivered.
+//
+// Returns the number of attempted deliveries.
+func (s *WebhookService) DeliverDue(ctx context.Context) (int, error) {
+	now := s.now().UTC()
+
+	due, subs, err := s.repo.ClaimDue(ctx, now, webhookLease, webhookBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, d := range due {
+		sub, ok := subs[d.SubscriptionID]
+		if !ok {
+			// the subscription was deleted after the delivery was claimed
+			continue
+		}
+
+		statusCode, reason := s.send(ctx, sub, d)
+
+		d.Attempts++
+		d.LastStatusCode = statusCode
+		d.LastError = reason
+
+		switch {
+		case reason == "" && statusCode >= 200 && statusCode < 300:
+			deliveredAt := s.now().UTC()
+			d.Status = webhook.StatusDelivered
+			d.DeliveredAt = &deliveredAt
+		default:
+			if reason == "" {
+				d.LastError = webhook.ErrorUnexpectedStatus
+			}
+
+			if d.Attempts >= webhook.MaxAttempts {
+				d.Status = webhook.StatusFailed
+			} else {
+				d.NextAttemptAt = now.Add(webhook.RetryDelay(d.Attempts))
+			}
+		}
+
+		if err := s.repo.SaveAttempt(ctx, d); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(due), nil
+}
+
+// send decrypts the subscription secret, signs the payload and posts it. It
+// returns the response status code, or the reason no response was received.
+func (s *WebhookService) send(ctx context.Context, sub *webhook.Subscription, d *webhook.Delivery) (int, string) {
+	secret, err := s.cryptManager.Decrypt(sub.SecretNonce, sub.SecretEncrypted)
+	if err != nil {
+		return 0, webhook.ErrorInternal
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookSendTimeout)
+	defer cancel()
+
+	statusCode, err := s.sender.Send(ctx, sub.URL, d, webhook.Sign(string(secret), s.now(), d.Payload))
+	if err != nil {
+		return 0, deliveryError(err)
+	}
+	return statusCode, ""
+}
+
+// deliveryError maps a send error to the coarse reason stored with the
+// delivery.
+func deliveryError(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, webhook.ErrBlockedAddress):
+		return webhook.ErrorBlockedAddress
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return webhook.ErrorTimeout
+	default:
+		return webhook.ErrorConnection
+	}
+}
+
+// publishWebhook queues e for delivery. Notifications are best effort: a
+// failure to queue them does not fail the operation, which is recorded in the
+// audit log either way.
+func publishWebhook(ctx context.Context, publisher WebhookPublisher, e webhook.Event) {
+	_ = publisher.Publish(ctx, e)
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// nopPublisher accepts every webhook event.
+func nopPublisher() *mocks.WebhookServiceMock {
+	publisher := new(mocks.WebhookServiceMock)
+	publisher.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	return publisher
+}
+
+func newTestWebhookService(repo *mocks.WebhookRepositoryMock, crypt *mocks.CryptManager, sender *mocks.WebhookSenderMock, now time.Time) WebhookService {
+	s := NewWebhookService(repo, crypt, sender)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestWebhookService_Create_Success(t *testing.T) {
+	repo := new(mocks.WebhookRepositoryMock)
+	crypt := new(mocks.CryptManager)
+	s := NewWebhookService(repo, crypt, new(mocks.WebhookSenderMock))
+
+	ctx := context.Background()
+	var stored *webhook.Subscription
+
+	repo.On("ListByUser", ctx, int64(1)).Return(nil, nil)
+	crypt.On("Encrypt", mock.Anything).Return([]byte("nonce"), []byte("ct"), nil)
+	repo.On("Create", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*webhook.Subscription) }).
+		Return(&webhook.Subscription{ID: 3, SubscriptionID: uuid.New(), UserID: 1}, nil)
+
+	sub, secret, err := s.Create(ctx, 1, "https://soc.example.com/hook", []string{"key.read", "key.read", "login.locked"}, []string{"Prod"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), sub.ID)
+	assert.True(t, strings.HasPrefix(secret, webhook.SecretPrefix))
+	assert.Equal(t, []webhook.EventType{webhook.EventKeyRead, webhook.EventLoginLocked}, stored.Events)
+	assert.Equal(t, []string{"prod"}, stored.Tags)
+	assert.Equal(t, []byte("ct"), stored.SecretEncrypted)
+}
+
+func TestWebhookService_Create_Validate_Error(t *testing.T) {
+	s := NewWebhookService(new(mocks.WebhookRepositoryMock), new(mocks.CryptManager), new(mocks.WebhookSenderMock))
+	ctx := context.Background()
+
+	_, _, err := s.Create(ctx, 1, "http://soc.example.com/hook", []string{"key.read"}, nil)
+	assert.ErrorIs(t, err, webhook.ErrInvalidURL)
+
+	for _, url := range []string{
+		"https://169.254.169.254/latest/meta-data",
+		"https://10.0.0.5/hook",
+		"https://127.0.0.1:8080/hook",
+		"https://[::1]/hook",
+		"https://100.64.1.1/hook",
+		"https://0.0.0.0/hook",
+		"https://localhost/hook",
+		"https://db.localhost./hook",
+	} {
+		_, _, err = s.Create(ctx, 1, url, []string{"key.read"}, nil)
+		assert.ErrorIs(t, err, webhook.ErrInternalURL, url)
+	}
+
+	_, _, err = s.Create(ctx, 1, "https://soc.example.com/hook", []string{"key.write"}, nil)
+	assert.ErrorIs(t, err, webhook.ErrInvalidEvent)
+
+	_, _, err = s.Create(ctx, 1, "https://soc.example.com/hook", nil, nil)
+	assert.ErrorIs(t, err, webhook.ErrNoEvents)
+}
+
+func TestWebhookService_Publish_Matches_Tags(t *testing.T) {
+	repo := new(mocks.WebhookRepositoryMock)
+	now := time.Date(2025, 10, 26, 10, 0, 0, 0, time.UTC)
+	s := newTestWebhookService(repo, new(mocks.CryptManager), new(mocks.WebhookSenderMock), now)
+
+	ctx := audit.WithRequestInfo(context.Background(), audit.RequestInfo{IP: "10.0.0.1"})
+	keyUUID := uuid.New()
+	subs := []*webhook.Subscription{
+		{ID: 1, UserID: 1, Events: []webhook.EventType{webhook.EventKeyRead}, Tags: []string{"prod"}},
+		{ID: 2, UserID: 1, Events: []webhook.EventType{webhook.EventKeyRead}, Tags: []string{"dev"}},
+		{ID: 3, UserID: 1, Events: []webhook.EventType{webhook.EventKeyDelete}},
+		{ID: 4, UserID: 1, Events: []webhook.EventType{webhook.EventKeyRead, webhook.EventKeyDelete}},
+		{ID: 5, UserID: webhook.GlobalOwner, Events: []webhook.EventType{webhook.EventKeyRead}},
+	}
+
+	var queued []*webhook.Delivery
+	repo.On("ListForEvents", ctx, int64(1)).Return(subs, nil)
+	repo.On("Enqueue", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { queued = args.Get(1).([]*webhook.Delivery) }).
+		Return(nil)
+
+	err := s.Publish(ctx, webhook.Event{Type: webhook.EventKeyRead, UserID: 1, KeyUUID: &keyUUID, Tags: []string{"prod", "db"}})
+
+	assert.NoError(t, err)
+	if assert.Len(t, queued, 3) {
+		assert.Equal(t, int64(1), queued[0].SubscriptionID)
+		assert.Equal(t, int64(4), queued[1].SubscriptionID)
+		assert.Equal(t, int64(5), queued[2].SubscriptionID)
+		assert.Equal(t, webhook.StatusPending, queued[0].Status)
+		assert.Equal(t, now, queued[0].NextAttemptAt)
+		assert.Contains(t, string(queued[0].Payload), `"ip":"10.0.0.1"`)
+		assert.Contains(t, string(queued[0].Payload), keyUUID.String())
+	}
+}
+
+func TestWebhookService_DeliverDue(t *testing.T) {
+	repo := new(mocks.WebhookRepositoryMock)
+	crypt := new(mocks.CryptManager)
+	sender := new(mocks.WebhookSenderMock)
+	now := time.Date(2025, 10, 26, 10, 0, 0, 0, time.UTC)
+	s := newTestWebhookService(repo, crypt, sender, now)
+
+	ctx := context.Background()
+	sub := &webhook.Subscription{ID: 7, URL: "https://soc.example.com/hook", SecretNonce: []byte("n"), SecretEncrypted: []byte("c")}
+	ok := &webhook.Delivery{ID: 1, SubscriptionID: 7, Payload: []byte(`{"type":"key.read"}`), Status: webhook.StatusPending}
+	retry := &webhook.Delivery{ID: 2, SubscriptionID: 7, Payload: []byte(`{}`), Status: webhook.StatusPending, Attempts: 2}
+	last := &webhook.Delivery{ID: 3, SubscriptionID: 7, Payload: []byte(`{}`), Status: webhook.StatusPending, Attempts: webhook.MaxAttempts - 1}
+
+	repo.On("ClaimDue", ctx, now, webhookLease, webhookBatch).
+		Return([]*webhook.Delivery{ok, retry, last}, map[int64]*webhook.Subscription{7: sub}, nil)
+	crypt.On("Decrypt", sub.SecretNonce, sub.SecretEncrypted).Return([]byte("whsec_test"), nil)
+	sender.On("Send", mock.Anything, sub.URL, ok, webhook.Sign("whsec_test", now, ok.Payload)).Return(204, nil)
+	sender.On("Send", mock.Anything, sub.URL, retry, mock.Anything).Return(500, nil)
+	sender.On("Send", mock.Anything, sub.URL, last, mock.Anything).Return(0, errors.New("dial tcp 10.0.0.5:443: connection refused"))
+	repo.On("SaveAttempt", ctx, mock.Anything).Return(nil)
+
+	n, err := s.DeliverDue(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	assert.Equal(t, webhook.StatusDelivered, ok.Status)
+	assert.Equal(t, 1, ok.Attempts)
+	assert.NotNil(t, ok.DeliveredAt)
+
+	assert.Equal(t, webhook.StatusPending, retry.Status)
+	assert.Equal(t, 3, retry.Attempts)
+	assert.Equal(t, now.Add(webhook.RetryDelay(3)), retry.NextAttemptAt)
+	assert.Equal(t, 500, retry.LastStatusCode)
+	assert.Equal(t, webhook.ErrorUnexpectedStatus, retry.LastError)
+
+	assert.Equal(t, webhook.StatusFailed, last.Status)
+	assert.Equal(t, webhook.ErrorConnection, last.LastError)
+	repo.AssertNumberOfCalls(t, "SaveAttempt", 3)
+}
+
+func TestWebhookService_DeliverDue_Bounds_Each_Send(t *testing.T) {
+	repo := new(mocks.WebhookRepositoryMock)
+	crypt := new(mocks.CryptManager)
+	sender := new(mocks.WebhookSenderMock)
+	now := time.Date(2025, 10, 26, 10, 0, 0, 0, time.UTC)
+	s := newTestWebhookService(repo, crypt, sender, now)
+
+	ctx := context.Background()
+	sub := &webhook.Subscription{ID: 7, URL: "https://soc.example.com/hook"}
+	d := &webhook.Delivery{ID: 1, SubscriptionID: 7, Payload: []byte(`{}`), Status: webhook.StatusPending}
+
+	// a whole batch of attempts running into the timeout ends before the lease
+	assert.Greater(t, webhookLease, webhookBatch*webhookSendTimeout)
+
+	repo.On("ClaimDue", ctx, now, webhookLease, webhookBatch).
+		Return([]*webhook.Delivery{d}, map[int64]*webhook.Subscription{7: sub}, nil)
+	crypt.On("Decrypt", mock.Anything, mock.Anything).Return([]byte("whsec_test"), nil)
+	sender.On("Send", mock.MatchedBy(func(ctx context.Context) bool {
+		deadline, ok := ctx.Deadline()
+		return ok && !deadline.After(time.Now().Add(webhookSendTimeout))
+	}), sub.URL, d, mock.Anything).Return(204, nil)
+	repo.On("SaveAttempt", ctx, d).Return(nil)
+
+	_, err := s.DeliverDue(ctx)
+
+	assert.NoError(t, err)
+	sender.AssertExpectations(t)
+}
+
+func TestWebhookService_Deliveries_Not_Found(t *testing.T) {
+	repo := new(mocks.WebhookRepositoryMock)
+	s := NewWebhookService(repo, new(mocks.CryptManager), new(mocks.WebhookSenderMock))
+
+	ctx := context.Background()
+	id := uuid.New()
+	repo.On("Get", ctx, int64(1), id).Return(nil, webhook.ErrNotFound)
+
+	_, err := s.Deliveries(ctx, 1, id.String())
+	assert.ErrorIs(t, err, webhook.ErrNotFound)
+
+	_, err = s.Deliveries(ctx, 1, "not-a-uuid")
+	assert.ErrorIs(t, err, webhook.ErrNotFound)
+}
+
+func TestDeliveryError(t *testing.T) {
+	assert.Equal(t, webhook.ErrorBlockedAddress, deliveryError(fmt.Errorf("post: %w", webhook.ErrBlockedAddress)))
+	assert.Equal(t, webhook.ErrorTimeout, deliveryError(context.DeadlineExceeded))
+	assert.Equal(t, webhook.ErrorTimeout, deliveryError(&net.DNSError{IsTimeout: true}))
+	assert.Equal(t, webhook.ErrorConnection, deliveryError(errors.New("tls: bad certificate")))
+}
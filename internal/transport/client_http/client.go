@@ -27,6 +27,7 @@ type Client struct {
 	http         *http.Client
 	accessToken  string
 	refreshToken string
+	anonymous    bool
 	logger       *zap.Logger
 	mu           sync.RWMutex
 }
@@ -75,6 +76,55 @@ func NewHttpClient(baseURL string, logger *zap.Logger) (*Client, error) {
 	}, nil
 }
 
+// SetAccessToken makes the client authenticate every request with a fixed
+// bearer token, such as a personal access token, instead of the session
+// tokens stored in the OS keyring.
+func (c *Client) SetAccessToken(accessToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.accessToken = accessToken
+}
+
+// DisableAuthorization makes the client send every request without a bearer
+// token, for talking to servers the stored session does not belong to.
+func (c *Client) DisableAuthorization() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.anonymous = true
+}
+
+// BaseURL returns the server address the client is pointed to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// setAuthorization adds the bearer token to req: the fixed token if one is
+// set, otherwise the access token of the stored session.
+func (c *Client) setAuthorization(req *http.Request) {
+	c.mu.RLock()
+	accessToken := c.accessToken
+	anonymous := c.anonymous
+	c.mu.RUnlock()
+
+	if anonymous {
+		return
+	}
+
+	if accessToken == "" {
+		keyRingTokensStorage, err := token.LoadTokens()
+		if err != nil {
+			c.logger.Error("load tokens failed", zap.Error(err))
+		}
+		accessToken = keyRingTokensStorage.Access
+	}
+
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+}
+
 // Do send a JSON request (if body != nil, it will be encoded as JSON) and decodes a JSON response into result.
 // - body can be any value that encoding/json can encode.
 // - result must be a pointer where JSON will be unmarshaled, or nil if empty body expected.
@@ -110,14 +160,7 @@ func (c *Client) Do(ctx context.Context, method, path string, body any, result a
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	keyRingTokensStorage, err := token.LoadTokens()
-	if err != nil {
-		c.logger.Error("load tokens failed", zap.Error(err))
-	}
-
-	if keyRingTokensStorage.Access != "" {
-		req.Header.Set("Authorization", "Bearer "+keyRingTokensStorage.Access)
-	}
+	c.setAuthorization(req)
 
 	resp, err := c.http.Do(req)
 	if err != nil {
@@ -146,7 +189,7 @@ func (c *Client) Do(ctx context.Context, method, path string, body any, result a
 		var srvErr dto.ErrorResponse
 		if err := json.Unmarshal(respBytes, &srvErr); err == nil && srvErr.ErrorText != "" {
 			c.logger.Warn("server returned error", zap.Int("code", resp.StatusCode), zap.Any("error", srvErr))
-			return &HTTPError{StatusCode: resp.StatusCode, Body: srvErr.ErrorText}
+			return &HTTPError{StatusCode: resp.StatusCode, Body: srvErr.ErrorText, Violations: srvErr.Violations}
 		}
 
 		c.logger.Warn("server returned non-json error", zap.Int("code", resp.StatusCode), zap.String("body", string(respBytes)))
@@ -189,14 +232,7 @@ func (c *Client) DoMultiPart(ctx context.Context, method, path string, body io.R
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", contentType)
 
-	keyRingTokensStorage, err := token.LoadTokens()
-	if err != nil {
-		c.logger.Error("load tokens failed", zap.Error(err))
-	}
-
-	if keyRingTokensStorage.Access != "" {
-		req.Header.Set("Authorization", "Bearer "+keyRingTokensStorage.Access)
-	}
+	c.setAuthorization(req)
 
 	resp, err := c.http.Do(req)
 	if err != nil {
@@ -242,3 +278,110 @@ func (c *Client) DoMultiPart(ctx context.Context, method, path string, body io.R
 	}
 	return nil
 }
+
+// DoStream sends a JSON request (if body != nil) and copies a successful
+// response body into dst without buffering or size limits. It is intended for
+// binary downloads such as archives.
+// Returns *HTTPError for non-2xx responses.
+func (c *Client) DoStream(ctx context.Context, method, path string, body any, dst io.Writer) error {
+	if path == "" {
+		return ErrPathIsEmpty
+	}
+	if method == "" {
+		return ErrEmptyMethod
+	}
+
+	url := c.baseURL + path
+
+	var bodyReader io.Reader
+	if body != nil {
+		buf := &bytes.Buffer{}
+		if err := json.NewEncoder(buf).Encode(body); err != nil {
+			c.logger.Error("marshal request body failed", zap.Error(err))
+			return fmt.Errorf("%w: %v", ErrCantMarshalBody, err)
+		}
+		bodyReader = buf
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		c.logger.Error("create request failed", zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrClientRequest, err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	c.setAuthorization(req)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		c.logger.Error("http do failed", zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrClientRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return streamError(resp)
+	}
+
+	if _, err := io.Copy(dst, resp.Body); err != nil {
+		c.logger.Error("read response failed", zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrCantReadBody, err)
+	}
+
+	return nil
+}
+
+// OpenStream sends a GET request accepting the given content type and returns
+// the body of a successful response for the caller to read and close. Unlike
+// the other methods it has no overall timeout, so it suits long-lived
+// streams such as server-sent events; cancel ctx to end the stream.
+// Returns *HTTPError for non-2xx responses.
+func (c *Client) OpenStream(ctx context.Context, path string, accept string) (io.ReadCloser, error) {
+	if path == "" {
+		return nil, ErrPathIsEmpty
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		c.logger.Error("create request failed", zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrClientRequest, err)
+	}
+
+	req.Header.Set("Accept", accept)
+
+	c.setAuthorization(req)
+
+	streaming := *c.http
+	streaming.Timeout = 0
+
+	resp, err := streaming.Do(req)
+	if err != nil {
+		c.logger.Error("http do failed", zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrClientRequestFailed, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, streamError(resp)
+	}
+
+	return resp.Body, nil
+}
+
+// streamError reads the error of a non-2xx response of a streamed request.
+func streamError(resp *http.Response) error {
+	const maxErrorSize = 1 << 20
+	respBytes, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorSize))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrCantReadBody, err)
+	}
+
+	var srvErr dto.ErrorResponse
+	if err := json.Unmarshal(respBytes, &srvErr); err == nil && srvErr.ErrorText != "" {
+		return &HTTPError{StatusCode: resp.StatusCode, Body: srvErr.ErrorText, Violations: srvErr.Violations}
+	}
+	return &HTTPError{StatusCode: resp.StatusCode, Body: string(respBytes)}
+}
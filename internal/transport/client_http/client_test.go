@@ -130,3 +130,49 @@ func TestClient_DoMultiPart_Success(t *testing.T) {
 		t.Fatalf("unexpected upload response: %#v", out)
 	}
 }
+
+// Test that a fixed access token is sent instead of the stored session
+func TestClient_SetAccessToken(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c, err := NewHttpClient(srv.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient failed: %v", err)
+	}
+	c.SetAccessToken("pkp_static")
+
+	if err := c.Do(context.Background(), http.MethodGet, "/", nil, nil); err != nil {
+		t.Fatalf("Do expected nil err, got %v", err)
+	}
+	if gotAuth != "Bearer pkp_static" {
+		t.Fatalf("unexpected Authorization header %q", gotAuth)
+	}
+}
+
+func TestClient_DisableAuthorization(t *testing.T) {
+	gotAuth := "unset"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c, err := NewHttpClient(srv.URL, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHttpClient failed: %v", err)
+	}
+	c.SetAccessToken("pkp_static")
+	c.DisableAuthorization()
+
+	if err := c.Do(context.Background(), http.MethodGet, "/", nil, nil); err != nil {
+		t.Fatalf("Do expected nil err, got %v", err)
+	}
+	if gotAuth != "" {
+		t.Fatalf("expected no Authorization header, got %q", gotAuth)
+	}
+}
@@ -3,11 +3,15 @@ package client_http
 import (
 	"errors"
 	"fmt"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
 )
 
+// HTTPError is a non-2xx response. Body is the error text of the server;
+// Violations list the failed rules of a validation error, if any.
 type HTTPError struct {
 	StatusCode int
 	Body       string
+	Violations []*dto.ErrorViolation
 }
 
 func (e *HTTPError) Error() string {
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"time"
+)
+
+//go:generate easyjson -all account.go
+
+type AccountPasswordRequest struct {
+	Password string `json:"password"`
+}
+
+type ExportManifest struct {
+	Login          string                 `json:"login"`
+	ExportedAt     time.Time              `json:"exported_at"`
+	Entries        []*ExportManifestEntry `json:"entries"`
+	Sends          []*ExportManifestSend  `json:"sends"`
+	SharesGiven    []*ExportManifestShare `json:"shares_given"`
+	SharesReceived []*ExportManifestShare `json:"shares_received"`
+}
+
+type ExportManifestEntry struct {
+	KeyUUID   uuid.UUID        `json:"uuid"`
+	KeyType   keychain.KeyType `json:"type"`
+	Title     string           `json:"title"`
+	Data      json.RawMessage  `json:"data"`
+	File      string           `json:"file,omitempty"`
+	Vault     *uuid.UUID       `json:"vault,omitempty"`
+	VaultName string           `json:"vault_name,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+type ExportManifestSend struct {
+	SendUUID  uuid.UUID `json:"uuid"`
+	MaxViews  int       `json:"max_views"`
+	Views     int       `json:"views"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ExportManifestShare struct {
+	ShareUUID  uuid.UUID        `json:"uuid"`
+	KeyUUID    uuid.UUID        `json:"key_uuid"`
+	KeyType    keychain.KeyType `json:"type"`
+	Title      string           `json:"title"`
+	Owner      string           `json:"owner"`
+	Recipient  string           `json:"recipient"`
+	WrappedKey []byte           `json:"wrapped_key,omitempty"`
+	Nonce      []byte           `json:"nonce,omitempty"`
+	Data       []byte           `json:"data,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
@@ -0,0 +1,843 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	uuid "github.com/google/uuid"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	keychain "github.com/thxhix/passKeeper/internal/domain/keychain"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *ExportManifestShare) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.ShareUUID).UnmarshalText(data))
+				}
+			}
+		case "key_uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.KeyUUID).UnmarshalText(data))
+				}
+			}
+		case "type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.KeyType = keychain.KeyType(in.String())
+			}
+		case "title":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Title = string(in.String())
+			}
+		case "owner":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Owner = string(in.String())
+			}
+		case "recipient":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Recipient = string(in.String())
+			}
+		case "wrapped_key":
+			if in.IsNull() {
+				in.Skip()
+				out.WrappedKey = nil
+			} else {
+				out.WrappedKey = in.Bytes()
+			}
+		case "nonce":
+			if in.IsNull() {
+				in.Skip()
+				out.Nonce = nil
+			} else {
+				out.Nonce = in.Bytes()
+			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+				out.Data = nil
+			} else {
+				out.Data = in.Bytes()
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in ExportManifestShare) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ShareUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"key_uuid\":"
+		out.RawString(prefix)
+		out.RawText((in.KeyUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.KeyType))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"owner\":"
+		out.RawString(prefix)
+		out.String(string(in.Owner))
+	}
+	{
+		const prefix string = ",\"recipient\":"
+		out.RawString(prefix)
+		out.String(string(in.Recipient))
+	}
+	if len(in.WrappedKey) != 0 {
+		const prefix string = ",\"wrapped_key\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.WrappedKey)
+	}
+	if len(in.Nonce) != 0 {
+		const prefix string = ",\"nonce\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Nonce)
+	}
+	if len(in.Data) != 0 {
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Data)
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ExportManifestShare) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ExportManifestShare) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ExportManifestShare) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ExportManifestShare) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *ExportManifestSend) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.SendUUID).UnmarshalText(data))
+				}
+			}
+		case "max_views":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxViews = int(in.Int())
+			}
+		case "views":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Views = int(in.Int())
+			}
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.ExpiresAt).UnmarshalJSON(data))
+				}
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in ExportManifestSend) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.SendUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"max_views\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxViews))
+	}
+	{
+		const prefix string = ",\"views\":"
+		out.RawString(prefix)
+		out.Int(int(in.Views))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpiresAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ExportManifestSend) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ExportManifestSend) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ExportManifestSend) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ExportManifestSend) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
+}
+func easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *ExportManifestEntry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.KeyUUID).UnmarshalText(data))
+				}
+			}
+		case "type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.KeyType = keychain.KeyType(in.String())
+			}
+		case "title":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Title = string(in.String())
+			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.Data).UnmarshalJSON(data))
+				}
+			}
+		case "file":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.File = string(in.String())
+			}
+		case "vault":
+			if in.IsNull() {
+				in.Skip()
+				out.Vault = nil
+			} else {
+				if out.Vault == nil {
+					out.Vault = new(uuid.UUID)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.UnsafeBytes(); in.Ok() {
+						in.AddError((*out.Vault).UnmarshalText(data))
+					}
+				}
+			}
+		case "vault_name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.VaultName = string(in.String())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		case "updated_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.UpdatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in ExportManifestEntry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.KeyUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.KeyType))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		out.Raw((in.Data).MarshalJSON())
+	}
+	if in.File != "" {
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	if in.Vault != nil {
+		const prefix string = ",\"vault\":"
+		out.RawString(prefix)
+		out.RawText((*in.Vault).MarshalText())
+	}
+	if in.VaultName != "" {
+		const prefix string = ",\"vault_name\":"
+		out.RawString(prefix)
+		out.String(string(in.VaultName))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"updated_at\":"
+		out.RawString(prefix)
+		out.Raw((in.UpdatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ExportManifestEntry) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ExportManifestEntry) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ExportManifestEntry) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ExportManifestEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
+}
+func easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *ExportManifest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "login":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Login = string(in.String())
+			}
+		case "exported_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.ExportedAt).UnmarshalJSON(data))
+				}
+			}
+		case "entries":
+			if in.IsNull() {
+				in.Skip()
+				out.Entries = nil
+			} else {
+				in.Delim('[')
+				if out.Entries == nil {
+					if !in.IsDelim(']') {
+						out.Entries = make([]*ExportManifestEntry, 0, 8)
+					} else {
+						out.Entries = []*ExportManifestEntry{}
+					}
+				} else {
+					out.Entries = (out.Entries)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 *ExportManifestEntry
+					if in.IsNull() {
+						in.Skip()
+						v10 = nil
+					} else {
+						if v10 == nil {
+							v10 = new(ExportManifestEntry)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v10).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Entries = append(out.Entries, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "sends":
+			if in.IsNull() {
+				in.Skip()
+				out.Sends = nil
+			} else {
+				in.Delim('[')
+				if out.Sends == nil {
+					if !in.IsDelim(']') {
+						out.Sends = make([]*ExportManifestSend, 0, 8)
+					} else {
+						out.Sends = []*ExportManifestSend{}
+					}
+				} else {
+					out.Sends = (out.Sends)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 *ExportManifestSend
+					if in.IsNull() {
+						in.Skip()
+						v11 = nil
+					} else {
+						if v11 == nil {
+							v11 = new(ExportManifestSend)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v11).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Sends = append(out.Sends, v11)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "shares_given":
+			if in.IsNull() {
+				in.Skip()
+				out.SharesGiven = nil
+			} else {
+				in.Delim('[')
+				if out.SharesGiven == nil {
+					if !in.IsDelim(']') {
+						out.SharesGiven = make([]*ExportManifestShare, 0, 8)
+					} else {
+						out.SharesGiven = []*ExportManifestShare{}
+					}
+				} else {
+					out.SharesGiven = (out.SharesGiven)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v12 *ExportManifestShare
+					if in.IsNull() {
+						in.Skip()
+						v12 = nil
+					} else {
+						if v12 == nil {
+							v12 = new(ExportManifestShare)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v12).UnmarshalEasyJSON(in)
+						}
+					}
+					out.SharesGiven = append(out.SharesGiven, v12)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "shares_received":
+			if in.IsNull() {
+				in.Skip()
+				out.SharesReceived = nil
+			} else {
+				in.Delim('[')
+				if out.SharesReceived == nil {
+					if !in.IsDelim(']') {
+						out.SharesReceived = make([]*ExportManifestShare, 0, 8)
+					} else {
+						out.SharesReceived = []*ExportManifestShare{}
+					}
+				} else {
+					out.SharesReceived = (out.SharesReceived)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 *ExportManifestShare
+					if in.IsNull() {
+						in.Skip()
+						v13 = nil
+					} else {
+						if v13 == nil {
+							v13 = new(ExportManifestShare)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v13).UnmarshalEasyJSON(in)
+						}
+					}
+					out.SharesReceived = append(out.SharesReceived, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in ExportManifest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"login\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Login))
+	}
+	{
+		const prefix string = ",\"exported_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExportedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"entries\":"
+		out.RawString(prefix)
+		if in.Entries == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.Entries {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				if v15 == nil {
+					out.RawString("null")
+				} else {
+					(*v15).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"sends\":"
+		out.RawString(prefix)
+		if in.Sends == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v16, v17 := range in.Sends {
+				if v16 > 0 {
+					out.RawByte(',')
+				}
+				if v17 == nil {
+					out.RawString("null")
+				} else {
+					(*v17).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"shares_given\":"
+		out.RawString(prefix)
+		if in.SharesGiven == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v18, v19 := range in.SharesGiven {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				if v19 == nil {
+					out.RawString("null")
+				} else {
+					(*v19).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"shares_received\":"
+		out.RawString(prefix)
+		if in.SharesReceived == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v20, v21 := range in.SharesReceived {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				if v21 == nil {
+					out.RawString("null")
+				} else {
+					(*v21).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ExportManifest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ExportManifest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ExportManifest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ExportManifest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+}
+func easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in *jlexer.Lexer, out *AccountPasswordRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "password":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Password = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(out *jwriter.Writer, in AccountPasswordRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"password\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Password))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AccountPasswordRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AccountPasswordRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson349b126bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AccountPasswordRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AccountPasswordRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson349b126bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(l, v)
+}
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+//go:generate easyjson -all admin.go
+
+type AdminUserRecord struct {
+	ID          int64      `json:"id"`
+	Login       string     `json:"login"`
+	IsAdmin     bool       `json:"is_admin"`
+	DisabledAt  *time.Time `json:"disabled_at,omitempty"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type AdminUsersResponse struct {
+	Users []*AdminUserRecord `json:"users"`
+}
+
+// AdminUserStatsResponse describes what a user stores. data_bytes is the size
+// of the encrypted entries.
+type AdminUserStatsResponse struct {
+	UserID          int64            `json:"user_id"`
+	Entries         int64            `json:"entries"`
+	EntriesByType   map[string]int64 `json:"entries_by_type"`
+	DataBytes       int64            `json:"data_bytes"`
+	Devices         int64            `json:"devices"`
+	PersonalTokens  int64            `json:"personal_tokens"`
+	ServiceAccounts int64            `json:"service_accounts"`
+}
+
+type AdminResetSecondFactorResponse struct {
+	RemovedDevices int64 `json:"removed_devices"`
+}
@@ -0,0 +1,497 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson9280440fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *AdminUsersResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "users":
+			if in.IsNull() {
+				in.Skip()
+				out.Users = nil
+			} else {
+				in.Delim('[')
+				if out.Users == nil {
+					if !in.IsDelim(']') {
+						out.Users = make([]*AdminUserRecord, 0, 8)
+					} else {
+						out.Users = []*AdminUserRecord{}
+					}
+				} else {
+					out.Users = (out.Users)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *AdminUserRecord
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(AdminUserRecord)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v1).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Users = append(out.Users, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9280440fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in AdminUsersResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix[1:])
+		if in.Users == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Users {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					(*v3).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AdminUsersResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9280440fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AdminUsersResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9280440fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AdminUsersResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9280440fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AdminUsersResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9280440fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjson9280440fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *AdminUserStatsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "user_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UserID = int64(in.Int64())
+			}
+		case "entries":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Entries = int64(in.Int64())
+			}
+		case "entries_by_type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.EntriesByType = make(map[string]int64)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v4 int64
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v4 = int64(in.Int64())
+					}
+					(out.EntriesByType)[key] = v4
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "data_bytes":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.DataBytes = int64(in.Int64())
+			}
+		case "devices":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Devices = int64(in.Int64())
+			}
+		case "personal_tokens":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PersonalTokens = int64(in.Int64())
+			}
+		case "service_accounts":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ServiceAccounts = int64(in.Int64())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9280440fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in AdminUserStatsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.UserID))
+	}
+	{
+		const prefix string = ",\"entries\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Entries))
+	}
+	{
+		const prefix string = ",\"entries_by_type\":"
+		out.RawString(prefix)
+		if in.EntriesByType == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v5First := true
+			for v5Name, v5Value := range in.EntriesByType {
+				if v5First {
+					v5First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v5Name))
+				out.RawByte(':')
+				out.Int64(int64(v5Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"data_bytes\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.DataBytes))
+	}
+	{
+		const prefix string = ",\"devices\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Devices))
+	}
+	{
+		const prefix string = ",\"personal_tokens\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.PersonalTokens))
+	}
+	{
+		const prefix string = ",\"service_accounts\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ServiceAccounts))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AdminUserStatsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9280440fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AdminUserStatsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9280440fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AdminUserStatsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9280440fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AdminUserStatsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9280440fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
+}
+func easyjson9280440fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *AdminUserRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ID = int64(in.Int64())
+			}
+		case "login":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Login = string(in.String())
+			}
+		case "is_admin":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.IsAdmin = bool(in.Bool())
+			}
+		case "disabled_at":
+			if in.IsNull() {
+				in.Skip()
+				out.DisabledAt = nil
+			} else {
+				if out.DisabledAt == nil {
+					out.DisabledAt = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.DisabledAt).UnmarshalJSON(data))
+					}
+				}
+			}
+		case "locked_until":
+			if in.IsNull() {
+				in.Skip()
+				out.LockedUntil = nil
+			} else {
+				if out.LockedUntil == nil {
+					out.LockedUntil = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.LockedUntil).UnmarshalJSON(data))
+					}
+				}
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9280440fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in AdminUserRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"login\":"
+		out.RawString(prefix)
+		out.String(string(in.Login))
+	}
+	{
+		const prefix string = ",\"is_admin\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsAdmin))
+	}
+	if in.DisabledAt != nil {
+		const prefix string = ",\"disabled_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.DisabledAt).MarshalJSON())
+	}
+	if in.LockedUntil != nil {
+		const prefix string = ",\"locked_until\":"
+		out.RawString(prefix)
+		out.Raw((*in.LockedUntil).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AdminUserRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9280440fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AdminUserRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9280440fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AdminUserRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9280440fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AdminUserRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9280440fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
+}
+func easyjson9280440fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *AdminResetSecondFactorResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "removed_devices":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RemovedDevices = int64(in.Int64())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9280440fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in AdminResetSecondFactorResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"removed_devices\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.RemovedDevices))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AdminResetSecondFactorResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9280440fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AdminResetSecondFactorResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9280440fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AdminResetSecondFactorResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9280440fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AdminResetSecondFactorResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9280440fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+}
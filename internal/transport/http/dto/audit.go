@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all audit.go
+
+// AuditEventRecord is an audit log entry. key_uuid is omitted for events not
+// related to an entry.
+type AuditEventRecord struct {
+	ID        int64      `json:"id"`
+	Actor     string     `json:"actor"`
+	Action    string     `json:"action"`
+	KeyUUID   *uuid.UUID `json:"key_uuid,omitempty"`
+	IP        string     `json:"ip,omitempty"`
+	UserAgent string     `json:"user_agent,omitempty"`
+	Result    string     `json:"result"`
+	CreatedAt time.Time  `json:"created_at"`
+	Hash      string     `json:"hash"`
+}
+
+type AuditEventsResponse struct {
+	Events []*AuditEventRecord `json:"events"`
+}
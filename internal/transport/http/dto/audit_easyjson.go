@@ -0,0 +1,294 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	uuid "github.com/google/uuid"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF2c44427DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *AuditEventsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "events":
+			if in.IsNull() {
+				in.Skip()
+				out.Events = nil
+			} else {
+				in.Delim('[')
+				if out.Events == nil {
+					if !in.IsDelim(']') {
+						out.Events = make([]*AuditEventRecord, 0, 8)
+					} else {
+						out.Events = []*AuditEventRecord{}
+					}
+				} else {
+					out.Events = (out.Events)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *AuditEventRecord
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(AuditEventRecord)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v1).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Events = append(out.Events, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF2c44427EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in AuditEventsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"events\":"
+		out.RawString(prefix[1:])
+		if in.Events == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Events {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					(*v3).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuditEventsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF2c44427EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuditEventsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF2c44427EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuditEventsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF2c44427DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuditEventsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF2c44427DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjsonF2c44427DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *AuditEventRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ID = int64(in.Int64())
+			}
+		case "actor":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Actor = string(in.String())
+			}
+		case "action":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Action = string(in.String())
+			}
+		case "key_uuid":
+			if in.IsNull() {
+				in.Skip()
+				out.KeyUUID = nil
+			} else {
+				if out.KeyUUID == nil {
+					out.KeyUUID = new(uuid.UUID)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.UnsafeBytes(); in.Ok() {
+						in.AddError((*out.KeyUUID).UnmarshalText(data))
+					}
+				}
+			}
+		case "ip":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.IP = string(in.String())
+			}
+		case "user_agent":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UserAgent = string(in.String())
+			}
+		case "result":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Result = string(in.String())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		case "hash":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Hash = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF2c44427EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in AuditEventRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"actor\":"
+		out.RawString(prefix)
+		out.String(string(in.Actor))
+	}
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.String(string(in.Action))
+	}
+	if in.KeyUUID != nil {
+		const prefix string = ",\"key_uuid\":"
+		out.RawString(prefix)
+		out.RawText((*in.KeyUUID).MarshalText())
+	}
+	if in.IP != "" {
+		const prefix string = ",\"ip\":"
+		out.RawString(prefix)
+		out.String(string(in.IP))
+	}
+	if in.UserAgent != "" {
+		const prefix string = ",\"user_agent\":"
+		out.RawString(prefix)
+		out.String(string(in.UserAgent))
+	}
+	{
+		const prefix string = ",\"result\":"
+		out.RawString(prefix)
+		out.String(string(in.Result))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"hash\":"
+		out.RawString(prefix)
+		out.String(string(in.Hash))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuditEventRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF2c44427EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuditEventRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF2c44427EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuditEventRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF2c44427DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuditEventRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF2c44427DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
+}
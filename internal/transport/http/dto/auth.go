@@ -3,13 +3,24 @@ package dto
 //go:generate easyjson -all auth.go
 
 type RegisterRequest struct {
-	Login    string `json:"login"`
-	Password string `json:"password"`
+	Login    string     `json:"login"`
+	Password string     `json:"password"`
+	Email    string     `json:"email,omitempty"`
+	Invite   string     `json:"invite,omitempty"`
+	Device   DeviceInfo `json:"device"`
+}
+
+// RegisterAcceptedResponse is the answer to registration in uniform response
+// mode. It does not tell whether an account was created; the client logs in
+// to find out.
+type RegisterAcceptedResponse struct {
+	Message string `json:"message"`
 }
 
 type LoginRequest struct {
-	Login    string `json:"login"`
-	Password string `json:"password"`
+	Login    string     `json:"login"`
+	Password string     `json:"password"`
+	Device   DeviceInfo `json:"device"`
 }
 
 type RefreshRequest struct {
@@ -26,3 +37,10 @@ type RefreshedTokenResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 }
+
+type ChangePasswordRequest struct {
+	CurrentPassword string     `json:"current_password"`
+	NewPassword     string     `json:"new_password"`
+	KeyMaterial     []byte     `json:"key_material,omitempty"`
+	Device          DeviceInfo `json:"device"`
+}
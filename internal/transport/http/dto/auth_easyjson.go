@@ -130,6 +130,24 @@ func easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in
 			} else {
 				out.Password = string(in.String())
 			}
+		case "email":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Email = string(in.String())
+			}
+		case "invite":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Invite = string(in.String())
+			}
+		case "device":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(out.Device).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -154,6 +172,21 @@ func easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(ou
 		out.RawString(prefix)
 		out.String(string(in.Password))
 	}
+	if in.Email != "" {
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	if in.Invite != "" {
+		const prefix string = ",\"invite\":"
+		out.RawString(prefix)
+		out.String(string(in.Invite))
+	}
+	{
+		const prefix string = ",\"device\":"
+		out.RawString(prefix)
+		(in.Device).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -180,7 +213,72 @@ func (v *RegisterRequest) UnmarshalJSON(data []byte) error {
 func (v *RegisterRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
 }
-func easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *RefreshedTokenResponse) {
+func easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *RegisterAcceptedResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "message":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Message = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in RegisterAcceptedResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegisterAcceptedResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegisterAcceptedResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegisterAcceptedResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegisterAcceptedResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
+}
+func easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *RefreshedTokenResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -216,7 +314,7 @@ func easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in
 		in.Consumed()
 	}
 }
-func easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in RefreshedTokenResponse) {
+func easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in RefreshedTokenResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -236,27 +334,27 @@ func easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(ou
 // MarshalJSON supports json.Marshaler interface
 func (v RefreshedTokenResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
+	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RefreshedTokenResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
+	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RefreshedTokenResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
+	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RefreshedTokenResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
+	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
 }
-func easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *RefreshRequest) {
+func easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in *jlexer.Lexer, out *RefreshRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -286,7 +384,7 @@ func easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in
 		in.Consumed()
 	}
 }
-func easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in RefreshRequest) {
+func easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(out *jwriter.Writer, in RefreshRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -301,27 +399,27 @@ func easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(ou
 // MarshalJSON supports json.Marshaler interface
 func (v RefreshRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RefreshRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RefreshRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RefreshRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(l, v)
 }
-func easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in *jlexer.Lexer, out *LoginRequest) {
+func easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(in *jlexer.Lexer, out *LoginRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -347,6 +445,12 @@ func easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in
 			} else {
 				out.Password = string(in.String())
 			}
+		case "device":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(out.Device).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -357,7 +461,7 @@ func easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in
 		in.Consumed()
 	}
 }
-func easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(out *jwriter.Writer, in LoginRequest) {
+func easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(out *jwriter.Writer, in LoginRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -371,29 +475,133 @@ func easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(ou
 		out.RawString(prefix)
 		out.String(string(in.Password))
 	}
+	{
+		const prefix string = ",\"device\":"
+		out.RawString(prefix)
+		(in.Device).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v LoginRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&w, v)
+	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v LoginRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(w, v)
+	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *LoginRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&r, v)
+	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *LoginRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(l, v)
+	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(l, v)
+}
+func easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(in *jlexer.Lexer, out *ChangePasswordRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "current_password":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.CurrentPassword = string(in.String())
+			}
+		case "new_password":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.NewPassword = string(in.String())
+			}
+		case "key_material":
+			if in.IsNull() {
+				in.Skip()
+				out.KeyMaterial = nil
+			} else {
+				out.KeyMaterial = in.Bytes()
+			}
+		case "device":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(out.Device).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(out *jwriter.Writer, in ChangePasswordRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"current_password\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.CurrentPassword))
+	}
+	{
+		const prefix string = ",\"new_password\":"
+		out.RawString(prefix)
+		out.String(string(in.NewPassword))
+	}
+	if len(in.KeyMaterial) != 0 {
+		const prefix string = ",\"key_material\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.KeyMaterial)
+	}
+	{
+		const prefix string = ",\"device\":"
+		out.RawString(prefix)
+		(in.Device).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChangePasswordRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChangePasswordRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4a0f95aaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChangePasswordRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChangePasswordRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4a0f95aaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(l, v)
 }
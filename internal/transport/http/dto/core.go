@@ -2,7 +2,15 @@ package dto
 
 //go:generate easyjson -all core.go
 
+// ErrorResponse is the body of every error response. Violations list the
+// failed rules of a validation error, when it has them.
 type ErrorResponse struct {
-	Code      int    `json:"code"`
-	ErrorText string `json:"error"`
+	Code       int               `json:"code"`
+	ErrorText  string            `json:"error"`
+	Violations []*ErrorViolation `json:"violations,omitempty"`
+}
+
+type ErrorViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
 }
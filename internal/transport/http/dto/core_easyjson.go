@@ -17,7 +17,83 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson3d34c335DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *ErrorResponse) {
+func easyjson3d34c335DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *ErrorViolation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "rule":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Rule = string(in.String())
+			}
+		case "message":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Message = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3d34c335EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in ErrorViolation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"rule\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Rule))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ErrorViolation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3d34c335EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ErrorViolation) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3d34c335EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ErrorViolation) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3d34c335DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ErrorViolation) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3d34c335DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjson3d34c335DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *ErrorResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -43,6 +119,41 @@ func easyjson3d34c335DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in
 			} else {
 				out.ErrorText = string(in.String())
 			}
+		case "violations":
+			if in.IsNull() {
+				in.Skip()
+				out.Violations = nil
+			} else {
+				in.Delim('[')
+				if out.Violations == nil {
+					if !in.IsDelim(']') {
+						out.Violations = make([]*ErrorViolation, 0, 8)
+					} else {
+						out.Violations = []*ErrorViolation{}
+					}
+				} else {
+					out.Violations = (out.Violations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *ErrorViolation
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(ErrorViolation)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v1).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Violations = append(out.Violations, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -53,7 +164,7 @@ func easyjson3d34c335DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in
 		in.Consumed()
 	}
 }
-func easyjson3d34c335EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in ErrorResponse) {
+func easyjson3d34c335EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in ErrorResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -67,29 +178,47 @@ func easyjson3d34c335EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out
 		out.RawString(prefix)
 		out.String(string(in.ErrorText))
 	}
+	if len(in.Violations) != 0 {
+		const prefix string = ",\"violations\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.Violations {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					(*v3).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ErrorResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3d34c335EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	easyjson3d34c335EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ErrorResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3d34c335EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+	easyjson3d34c335EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ErrorResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3d34c335DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	easyjson3d34c335DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ErrorResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3d34c335DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+	easyjson3d34c335DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
 }
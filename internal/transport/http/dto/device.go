@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all device.go
+
+// DeviceInfo identifies the client device on registration and login.
+// nonce is the challenge issued for the device and signature the base64
+// Ed25519 signature over it. approval_code is only sent by a pending device.
+type DeviceInfo struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name,omitempty"`
+	PublicKey    string    `json:"public_key"`
+	Nonce        string    `json:"nonce"`
+	Signature    string    `json:"signature"`
+	ApprovalCode string    `json:"approval_code,omitempty"`
+}
+
+type DeviceChallengeRequest struct {
+	DeviceID string `json:"device_id"`
+}
+
+type DeviceChallengeResponse struct {
+	Nonce     string    `json:"nonce"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type DeviceRecord struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Fingerprint string     `json:"fingerprint"`
+	Trusted     bool       `json:"trusted"`
+	LastSeenAt  *time.Time `json:"last_seen_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type DevicesResponse struct {
+	Devices []*DeviceRecord `json:"devices"`
+}
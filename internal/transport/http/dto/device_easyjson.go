@@ -0,0 +1,528 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *DevicesResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "devices":
+			if in.IsNull() {
+				in.Skip()
+				out.Devices = nil
+			} else {
+				in.Delim('[')
+				if out.Devices == nil {
+					if !in.IsDelim(']') {
+						out.Devices = make([]*DeviceRecord, 0, 8)
+					} else {
+						out.Devices = []*DeviceRecord{}
+					}
+				} else {
+					out.Devices = (out.Devices)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *DeviceRecord
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(DeviceRecord)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v1).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Devices = append(out.Devices, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in DevicesResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"devices\":"
+		out.RawString(prefix[1:])
+		if in.Devices == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Devices {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					(*v3).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DevicesResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DevicesResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DevicesResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DevicesResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *DeviceRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.ID).UnmarshalText(data))
+				}
+			}
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		case "fingerprint":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Fingerprint = string(in.String())
+			}
+		case "trusted":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Trusted = bool(in.Bool())
+			}
+		case "last_seen_at":
+			if in.IsNull() {
+				in.Skip()
+				out.LastSeenAt = nil
+			} else {
+				if out.LastSeenAt == nil {
+					out.LastSeenAt = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.LastSeenAt).UnmarshalJSON(data))
+					}
+				}
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in DeviceRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"fingerprint\":"
+		out.RawString(prefix)
+		out.String(string(in.Fingerprint))
+	}
+	{
+		const prefix string = ",\"trusted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Trusted))
+	}
+	if in.LastSeenAt != nil {
+		const prefix string = ",\"last_seen_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.LastSeenAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DeviceRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DeviceRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DeviceRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DeviceRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
+}
+func easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *DeviceInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.ID).UnmarshalText(data))
+				}
+			}
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		case "public_key":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PublicKey = string(in.String())
+			}
+		case "nonce":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Nonce = string(in.String())
+			}
+		case "signature":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Signature = string(in.String())
+			}
+		case "approval_code":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ApprovalCode = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in DeviceInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	if in.Name != "" {
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"public_key\":"
+		out.RawString(prefix)
+		out.String(string(in.PublicKey))
+	}
+	{
+		const prefix string = ",\"nonce\":"
+		out.RawString(prefix)
+		out.String(string(in.Nonce))
+	}
+	{
+		const prefix string = ",\"signature\":"
+		out.RawString(prefix)
+		out.String(string(in.Signature))
+	}
+	if in.ApprovalCode != "" {
+		const prefix string = ",\"approval_code\":"
+		out.RawString(prefix)
+		out.String(string(in.ApprovalCode))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DeviceInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DeviceInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DeviceInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DeviceInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
+}
+func easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *DeviceChallengeResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "nonce":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Nonce = string(in.String())
+			}
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.ExpiresAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in DeviceChallengeResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"nonce\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Nonce))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpiresAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DeviceChallengeResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DeviceChallengeResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DeviceChallengeResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DeviceChallengeResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+}
+func easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in *jlexer.Lexer, out *DeviceChallengeRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "device_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.DeviceID = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(out *jwriter.Writer, in DeviceChallengeRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"device_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.DeviceID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DeviceChallengeRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DeviceChallengeRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3073ac56EncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DeviceChallengeRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DeviceChallengeRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3073ac56DecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(l, v)
+}
@@ -0,0 +1,25 @@
+package dto
+
+//go:generate easyjson -all email.go
+
+type ChangeEmailRequest struct {
+	Email string `json:"email"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token           string `json:"token"`
+	NewPassword     string `json:"new_password"`
+	DiscardVaultKey bool   `json:"discard_vault_key,omitempty"`
+}
+
+type ResetPasswordResponse struct {
+	VaultKeyDiscarded bool `json:"vault_key_discarded"`
+}
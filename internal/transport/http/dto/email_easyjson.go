@@ -0,0 +1,366 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *VerifyEmailRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "token":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Token = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in VerifyEmailRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"token\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Token))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v VerifyEmailRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v VerifyEmailRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *VerifyEmailRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *VerifyEmailRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *ResetPasswordResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "vault_key_discarded":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.VaultKeyDiscarded = bool(in.Bool())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in ResetPasswordResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"vault_key_discarded\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.VaultKeyDiscarded))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ResetPasswordResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ResetPasswordResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ResetPasswordResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ResetPasswordResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
+}
+func easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *ResetPasswordRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "token":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Token = string(in.String())
+			}
+		case "new_password":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.NewPassword = string(in.String())
+			}
+		case "discard_vault_key":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.DiscardVaultKey = bool(in.Bool())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in ResetPasswordRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"token\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Token))
+	}
+	{
+		const prefix string = ",\"new_password\":"
+		out.RawString(prefix)
+		out.String(string(in.NewPassword))
+	}
+	if in.DiscardVaultKey {
+		const prefix string = ",\"discard_vault_key\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.DiscardVaultKey))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ResetPasswordRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ResetPasswordRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ResetPasswordRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ResetPasswordRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
+}
+func easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *ForgotPasswordRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "email":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Email = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in ForgotPasswordRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Email))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ForgotPasswordRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ForgotPasswordRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ForgotPasswordRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ForgotPasswordRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+}
+func easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in *jlexer.Lexer, out *ChangeEmailRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "email":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Email = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(out *jwriter.Writer, in ChangeEmailRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Email))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChangeEmailRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChangeEmailRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonFc263e4eEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChangeEmailRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChangeEmailRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonFc263e4eDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(l, v)
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/emergency"
+	"time"
+)
+
+//go:generate easyjson -all emergency.go
+
+type NominateEmergencyContactRequest struct {
+	Login       string `json:"login"`
+	Access      string `json:"access"`
+	WaitSeconds int64  `json:"wait_seconds,omitempty"`
+}
+
+type EmergencyContactRecord struct {
+	ContactUUID uuid.UUID        `json:"uuid"`
+	Grantor     string           `json:"grantor"`
+	Grantee     string           `json:"grantee"`
+	Access      emergency.Access `json:"access"`
+	WaitSeconds int64            `json:"wait_seconds"`
+	Status      emergency.Status `json:"status"`
+	RequestedAt *time.Time       `json:"requested_at,omitempty"`
+	GrantedAt   *time.Time       `json:"granted_at,omitempty"`
+	Granted     bool             `json:"granted"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+type EmergencyContactsResponse struct {
+	Contacts []*EmergencyContactRecord `json:"contacts"`
+}
+
+type EmergencyTakeoverRequest struct {
+	NewPassword     string `json:"new_password"`
+	DiscardVaultKey bool   `json:"discard_vault_key,omitempty"`
+}
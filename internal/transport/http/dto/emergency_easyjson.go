@@ -0,0 +1,481 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	emergency "github.com/thxhix/passKeeper/internal/domain/emergency"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson12b3e211DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *NominateEmergencyContactRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "login":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Login = string(in.String())
+			}
+		case "access":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Access = string(in.String())
+			}
+		case "wait_seconds":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.WaitSeconds = int64(in.Int64())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson12b3e211EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in NominateEmergencyContactRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"login\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Login))
+	}
+	{
+		const prefix string = ",\"access\":"
+		out.RawString(prefix)
+		out.String(string(in.Access))
+	}
+	if in.WaitSeconds != 0 {
+		const prefix string = ",\"wait_seconds\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.WaitSeconds))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NominateEmergencyContactRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson12b3e211EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NominateEmergencyContactRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson12b3e211EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NominateEmergencyContactRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson12b3e211DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NominateEmergencyContactRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson12b3e211DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjson12b3e211DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *EmergencyTakeoverRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "new_password":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.NewPassword = string(in.String())
+			}
+		case "discard_vault_key":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.DiscardVaultKey = bool(in.Bool())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson12b3e211EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in EmergencyTakeoverRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"new_password\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.NewPassword))
+	}
+	if in.DiscardVaultKey {
+		const prefix string = ",\"discard_vault_key\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.DiscardVaultKey))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EmergencyTakeoverRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson12b3e211EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EmergencyTakeoverRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson12b3e211EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EmergencyTakeoverRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson12b3e211DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EmergencyTakeoverRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson12b3e211DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
+}
+func easyjson12b3e211DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *EmergencyContactsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "contacts":
+			if in.IsNull() {
+				in.Skip()
+				out.Contacts = nil
+			} else {
+				in.Delim('[')
+				if out.Contacts == nil {
+					if !in.IsDelim(']') {
+						out.Contacts = make([]*EmergencyContactRecord, 0, 8)
+					} else {
+						out.Contacts = []*EmergencyContactRecord{}
+					}
+				} else {
+					out.Contacts = (out.Contacts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *EmergencyContactRecord
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(EmergencyContactRecord)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v1).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Contacts = append(out.Contacts, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson12b3e211EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in EmergencyContactsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"contacts\":"
+		out.RawString(prefix[1:])
+		if in.Contacts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Contacts {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					(*v3).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EmergencyContactsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson12b3e211EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EmergencyContactsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson12b3e211EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EmergencyContactsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson12b3e211DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EmergencyContactsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson12b3e211DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
+}
+func easyjson12b3e211DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *EmergencyContactRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.ContactUUID).UnmarshalText(data))
+				}
+			}
+		case "grantor":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Grantor = string(in.String())
+			}
+		case "grantee":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Grantee = string(in.String())
+			}
+		case "access":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Access = emergency.Access(in.String())
+			}
+		case "wait_seconds":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.WaitSeconds = int64(in.Int64())
+			}
+		case "status":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Status = emergency.Status(in.String())
+			}
+		case "requested_at":
+			if in.IsNull() {
+				in.Skip()
+				out.RequestedAt = nil
+			} else {
+				if out.RequestedAt == nil {
+					out.RequestedAt = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.RequestedAt).UnmarshalJSON(data))
+					}
+				}
+			}
+		case "granted_at":
+			if in.IsNull() {
+				in.Skip()
+				out.GrantedAt = nil
+			} else {
+				if out.GrantedAt == nil {
+					out.GrantedAt = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.GrantedAt).UnmarshalJSON(data))
+					}
+				}
+			}
+		case "granted":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Granted = bool(in.Bool())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson12b3e211EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in EmergencyContactRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ContactUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"grantor\":"
+		out.RawString(prefix)
+		out.String(string(in.Grantor))
+	}
+	{
+		const prefix string = ",\"grantee\":"
+		out.RawString(prefix)
+		out.String(string(in.Grantee))
+	}
+	{
+		const prefix string = ",\"access\":"
+		out.RawString(prefix)
+		out.String(string(in.Access))
+	}
+	{
+		const prefix string = ",\"wait_seconds\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.WaitSeconds))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	if in.RequestedAt != nil {
+		const prefix string = ",\"requested_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.RequestedAt).MarshalJSON())
+	}
+	if in.GrantedAt != nil {
+		const prefix string = ",\"granted_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.GrantedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"granted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Granted))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EmergencyContactRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson12b3e211EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EmergencyContactRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson12b3e211EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EmergencyContactRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson12b3e211DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EmergencyContactRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson12b3e211DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all invite.go
+
+type CreateInviteRequest struct {
+	Login            string `json:"login,omitempty"`
+	Email            string `json:"email,omitempty"`
+	MaxUses          int    `json:"max_uses"`
+	ExpiresInSeconds int64  `json:"expires_in_seconds,omitempty"`
+}
+
+type InviteRecord struct {
+	InviteUUID uuid.UUID `json:"uuid"`
+	Login      string    `json:"login,omitempty"`
+	Email      string    `json:"email,omitempty"`
+	MaxUses    int       `json:"max_uses"`
+	Uses       int       `json:"uses"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type CreateInviteResponse struct {
+	InviteRecord
+	Code string `json:"code"`
+}
+
+type InvitesResponse struct {
+	Invites []*InviteRecord `json:"invites"`
+}
@@ -0,0 +1,522 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson1812283bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *InvitesResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "invites":
+			if in.IsNull() {
+				in.Skip()
+				out.Invites = nil
+			} else {
+				in.Delim('[')
+				if out.Invites == nil {
+					if !in.IsDelim(']') {
+						out.Invites = make([]*InviteRecord, 0, 8)
+					} else {
+						out.Invites = []*InviteRecord{}
+					}
+				} else {
+					out.Invites = (out.Invites)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *InviteRecord
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(InviteRecord)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v1).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Invites = append(out.Invites, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1812283bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in InvitesResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"invites\":"
+		out.RawString(prefix[1:])
+		if in.Invites == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Invites {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					(*v3).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v InvitesResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson1812283bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v InvitesResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson1812283bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *InvitesResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson1812283bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *InvitesResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson1812283bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjson1812283bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *InviteRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.InviteUUID).UnmarshalText(data))
+				}
+			}
+		case "login":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Login = string(in.String())
+			}
+		case "email":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Email = string(in.String())
+			}
+		case "max_uses":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxUses = int(in.Int())
+			}
+		case "uses":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Uses = int(in.Int())
+			}
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.ExpiresAt).UnmarshalJSON(data))
+				}
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1812283bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in InviteRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.InviteUUID).MarshalText())
+	}
+	if in.Login != "" {
+		const prefix string = ",\"login\":"
+		out.RawString(prefix)
+		out.String(string(in.Login))
+	}
+	if in.Email != "" {
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"max_uses\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxUses))
+	}
+	{
+		const prefix string = ",\"uses\":"
+		out.RawString(prefix)
+		out.Int(int(in.Uses))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpiresAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v InviteRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson1812283bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v InviteRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson1812283bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *InviteRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson1812283bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *InviteRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson1812283bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
+}
+func easyjson1812283bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *CreateInviteResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "code":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Code = string(in.String())
+			}
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.InviteUUID).UnmarshalText(data))
+				}
+			}
+		case "login":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Login = string(in.String())
+			}
+		case "email":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Email = string(in.String())
+			}
+		case "max_uses":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxUses = int(in.Int())
+			}
+		case "uses":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Uses = int(in.Int())
+			}
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.ExpiresAt).UnmarshalJSON(data))
+				}
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1812283bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in CreateInviteResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix)
+		out.RawText((in.InviteUUID).MarshalText())
+	}
+	if in.Login != "" {
+		const prefix string = ",\"login\":"
+		out.RawString(prefix)
+		out.String(string(in.Login))
+	}
+	if in.Email != "" {
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"max_uses\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxUses))
+	}
+	{
+		const prefix string = ",\"uses\":"
+		out.RawString(prefix)
+		out.Int(int(in.Uses))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpiresAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateInviteResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson1812283bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateInviteResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson1812283bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateInviteResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson1812283bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateInviteResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson1812283bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
+}
+func easyjson1812283bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *CreateInviteRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "login":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Login = string(in.String())
+			}
+		case "email":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Email = string(in.String())
+			}
+		case "max_uses":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxUses = int(in.Int())
+			}
+		case "expires_in_seconds":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ExpiresInSeconds = int64(in.Int64())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1812283bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in CreateInviteRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Login != "" {
+		const prefix string = ",\"login\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Login))
+	}
+	if in.Email != "" {
+		const prefix string = ",\"email\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"max_uses\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.MaxUses))
+	}
+	if in.ExpiresInSeconds != 0 {
+		const prefix string = ",\"expires_in_seconds\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ExpiresInSeconds))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateInviteRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson1812283bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateInviteRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson1812283bEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateInviteRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson1812283bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateInviteRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson1812283bDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+}
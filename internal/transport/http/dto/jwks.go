@@ -0,0 +1,17 @@
+package dto
+
+//go:generate easyjson -all jwks.go
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
@@ -0,0 +1,247 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonA454815fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *JWKSet) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "keys":
+			if in.IsNull() {
+				in.Skip()
+				out.Keys = nil
+			} else {
+				in.Delim('[')
+				if out.Keys == nil {
+					if !in.IsDelim(']') {
+						out.Keys = make([]JWK, 0, 0)
+					} else {
+						out.Keys = []JWK{}
+					}
+				} else {
+					out.Keys = (out.Keys)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 JWK
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v1).UnmarshalEasyJSON(in)
+					}
+					out.Keys = append(out.Keys, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA454815fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in JWKSet) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"keys\":"
+		out.RawString(prefix[1:])
+		if in.Keys == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Keys {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JWKSet) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA454815fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JWKSet) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA454815fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JWKSet) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA454815fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JWKSet) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA454815fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjsonA454815fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *JWK) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "kty":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Kty = string(in.String())
+			}
+		case "crv":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Crv = string(in.String())
+			}
+		case "x":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.X = string(in.String())
+			}
+		case "y":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Y = string(in.String())
+			}
+		case "kid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Kid = string(in.String())
+			}
+		case "alg":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Alg = string(in.String())
+			}
+		case "use":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Use = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA454815fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in JWK) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"kty\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Kty))
+	}
+	{
+		const prefix string = ",\"crv\":"
+		out.RawString(prefix)
+		out.String(string(in.Crv))
+	}
+	{
+		const prefix string = ",\"x\":"
+		out.RawString(prefix)
+		out.String(string(in.X))
+	}
+	if in.Y != "" {
+		const prefix string = ",\"y\":"
+		out.RawString(prefix)
+		out.String(string(in.Y))
+	}
+	{
+		const prefix string = ",\"kid\":"
+		out.RawString(prefix)
+		out.String(string(in.Kid))
+	}
+	{
+		const prefix string = ",\"alg\":"
+		out.RawString(prefix)
+		out.String(string(in.Alg))
+	}
+	{
+		const prefix string = ",\"use\":"
+		out.RawString(prefix)
+		out.String(string(in.Use))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JWK) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA454815fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JWK) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA454815fEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JWK) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA454815fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JWK) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA454815fDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
+}
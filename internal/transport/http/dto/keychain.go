@@ -13,6 +13,7 @@ type GetKeysRecord struct {
 	KeyUUID   uuid.UUID        `json:"uuid"`
 	KeyType   keychain.KeyType `json:"type"`
 	Title     string           `json:"title"`
+	Tags      []string         `json:"tags,omitempty"`
 	CreatedAt time.Time        `json:"created_at"`
 	UpdatedAt time.Time        `json:"updated_at"`
 }
@@ -25,21 +26,61 @@ type GetKeyResponse struct {
 	KeyUUID   uuid.UUID        `json:"uuid"`
 	KeyType   keychain.KeyType `json:"type"`
 	Title     string           `json:"title"`
+	Tags      []string         `json:"tags,omitempty"`
 	Data      json.RawMessage  `json:"data"`
 	CreatedAt time.Time        `json:"created_at"`
 	UpdatedAt time.Time        `json:"updated_at"`
 }
 
+// KeyChange is a changed entry in ChangesResponse. A deleted entry is a
+// tombstone without data. Vault is set on the entries of shared vaults; such
+// an entry is also reported deleted once the user loses access to its vault.
+type KeyChange struct {
+	KeyUUID   uuid.UUID        `json:"uuid"`
+	Vault     *uuid.UUID       `json:"vault,omitempty"`
+	KeyType   keychain.KeyType `json:"type"`
+	Title     string           `json:"title"`
+	Tags      []string         `json:"tags,omitempty"`
+	Revision  int64            `json:"revision"`
+	Deleted   bool             `json:"deleted,omitempty"`
+	Data      json.RawMessage  `json:"data,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// ChangesResponse lists the changes after a revision. Revision is the one to
+// ask from next; More is set when there are further changes after it.
+type ChangesResponse struct {
+	Revision int64        `json:"revision"`
+	More     bool         `json:"more,omitempty"`
+	Changes  []*KeyChange `json:"changes"`
+}
+
+// KeyEvent is the data of a server-sent event of the keychain event stream.
+// A resync event only carries Event; Vault is set for entries of shared
+// vaults.
+type KeyEvent struct {
+	Event    keychain.EventType `json:"event"`
+	KeyUUID  *uuid.UUID         `json:"uuid,omitempty"`
+	Vault    *uuid.UUID         `json:"vault,omitempty"`
+	KeyType  keychain.KeyType   `json:"type,omitempty"`
+	Title    string             `json:"title,omitempty"`
+	Tags     []string           `json:"tags,omitempty"`
+	Revision int64              `json:"revision,omitempty"`
+}
+
 type AddSuccessResponse struct {
 	UUID string `json:"key_uuid"`
 }
 
 type AddCredentialsDTO struct {
-	Title    string `json:"title"`
-	Login    string `json:"login"`
-	Password string `json:"password"`
-	Site     string `json:"site,omitempty"`
-	Note     string `json:"note,omitempty"`
+	Vault    string   `json:"vault,omitempty"`
+	Title    string   `json:"title"`
+	Tags     []string `json:"tags,omitempty"`
+	Login    string   `json:"login"`
+	Password string   `json:"password"`
+	Site     string   `json:"site,omitempty"`
+	Note     string   `json:"note,omitempty"`
 }
 
 type CredentialsResponseDTO struct {
@@ -50,13 +91,15 @@ type CredentialsResponseDTO struct {
 }
 
 type AddCardDTO struct {
-	Title   string `json:"title"`
-	Number  string `json:"number"`
-	ExpDate string `json:"exp_date"`
-	CVV     string `json:"cvv"`
-	Holder  string `json:"holder"`
-	Bank    string `json:"bank,omitempty"`
-	Note    string `json:"note,omitempty"`
+	Vault   string   `json:"vault,omitempty"`
+	Title   string   `json:"title"`
+	Tags    []string `json:"tags,omitempty"`
+	Number  string   `json:"number"`
+	ExpDate string   `json:"exp_date"`
+	CVV     string   `json:"cvv"`
+	Holder  string   `json:"holder"`
+	Bank    string   `json:"bank,omitempty"`
+	Note    string   `json:"note,omitempty"`
 }
 
 type CardResponseDTO struct {
@@ -69,9 +112,11 @@ type CardResponseDTO struct {
 }
 
 type AddTextDTO struct {
-	Title string `json:"title"`
-	Text  string `json:"text"`
-	Note  string `json:"note,omitempty"`
+	Vault string   `json:"vault,omitempty"`
+	Title string   `json:"title"`
+	Tags  []string `json:"tags,omitempty"`
+	Text  string   `json:"text"`
+	Note  string   `json:"note,omitempty"`
 }
 
 type TextResponseDTO struct {
@@ -80,12 +125,62 @@ type TextResponseDTO struct {
 }
 
 type AddFileDTO struct {
-	Title string `json:"title"`
-	File  []byte `json:"-"`
-	Note  string `json:"note,omitempty"`
+	Vault string   `json:"vault,omitempty"`
+	Title string   `json:"title"`
+	Tags  []string `json:"tags,omitempty"`
+	File  []byte   `json:"-"`
+	Note  string   `json:"note,omitempty"`
 }
 
 type FileResponseDTO struct {
 	File []byte `json:"-"`
 	Note string `json:"note,omitempty"`
 }
+
+// BatchFileDTO is a file entry of a batch operation. Unlike AddFileDTO it
+// carries the file content inline, base64-encoded.
+type BatchFileDTO struct {
+	Title   string   `json:"title"`
+	Tags    []string `json:"tags,omitempty"`
+	Content []byte   `json:"content"`
+	Note    string   `json:"note,omitempty"`
+}
+
+// BatchOperation is one operation of BatchRequest. An add or an update
+// carries exactly one payload, of the type of the entry; an update or a
+// delete names its entry with UUID. An update replaces the title, tags and
+// data of the entry.
+type BatchOperation struct {
+	Op         keychain.BatchOp   `json:"op"`
+	UUID       string             `json:"uuid,omitempty"`
+	Credential *AddCredentialsDTO `json:"credential,omitempty"`
+	Card       *AddCardDTO        `json:"card,omitempty"`
+	Text       *AddTextDTO        `json:"text,omitempty"`
+	File       *BatchFileDTO      `json:"file,omitempty"`
+}
+
+// BatchRequest runs Operations on the personal entries in one go. Mode is
+// atomic, the default, or best_effort.
+type BatchRequest struct {
+	Mode       string            `json:"mode,omitempty"`
+	Operations []*BatchOperation `json:"operations"`
+}
+
+// BatchItemResult is the outcome of the operation at Index of a batch.
+// Status is applied, failed or aborted; Error tells why a failed operation
+// failed, with the status code the single operation would have answered.
+type BatchItemResult struct {
+	Index  int              `json:"index"`
+	Op     keychain.BatchOp `json:"op"`
+	UUID   string           `json:"uuid,omitempty"`
+	Status string           `json:"status"`
+	Error  *ErrorResponse   `json:"error,omitempty"`
+}
+
+// BatchResponse lists the results of a batch in the order of its operations.
+type BatchResponse struct {
+	Mode    keychain.BatchMode `json:"mode"`
+	Applied int                `json:"applied"`
+	Failed  int                `json:"failed"`
+	Results []*BatchItemResult `json:"results"`
+}
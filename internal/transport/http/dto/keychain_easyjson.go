@@ -4,6 +4,7 @@ package dto
 
 import (
 	json "encoding/json"
+	uuid "github.com/google/uuid"
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
@@ -94,7 +95,7 @@ func (v *TextResponseDTO) UnmarshalJSON(data []byte) error {
 func (v *TextResponseDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
 }
-func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *GetKeysResponse) {
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *KeyEvent) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -108,41 +109,89 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
-		case "keys":
+		case "event":
 			if in.IsNull() {
 				in.Skip()
-				out.Keys = nil
+			} else {
+				out.Event = keychain.EventType(in.String())
+			}
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+				out.KeyUUID = nil
+			} else {
+				if out.KeyUUID == nil {
+					out.KeyUUID = new(uuid.UUID)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.UnsafeBytes(); in.Ok() {
+						in.AddError((*out.KeyUUID).UnmarshalText(data))
+					}
+				}
+			}
+		case "vault":
+			if in.IsNull() {
+				in.Skip()
+				out.Vault = nil
+			} else {
+				if out.Vault == nil {
+					out.Vault = new(uuid.UUID)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.UnsafeBytes(); in.Ok() {
+						in.AddError((*out.Vault).UnmarshalText(data))
+					}
+				}
+			}
+		case "type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.KeyType = keychain.KeyType(in.String())
+			}
+		case "title":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Title = string(in.String())
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
 			} else {
 				in.Delim('[')
-				if out.Keys == nil {
+				if out.Tags == nil {
 					if !in.IsDelim(']') {
-						out.Keys = make([]*GetKeysRecord, 0, 8)
+						out.Tags = make([]string, 0, 4)
 					} else {
-						out.Keys = []*GetKeysRecord{}
+						out.Tags = []string{}
 					}
 				} else {
-					out.Keys = (out.Keys)[:0]
+					out.Tags = (out.Tags)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v1 *GetKeysRecord
+					var v1 string
 					if in.IsNull() {
 						in.Skip()
-						v1 = nil
 					} else {
-						if v1 == nil {
-							v1 = new(GetKeysRecord)
-						}
-						if in.IsNull() {
-							in.Skip()
-						} else {
-							(*v1).UnmarshalEasyJSON(in)
-						}
+						v1 = string(in.String())
 					}
-					out.Keys = append(out.Keys, v1)
+					out.Tags = append(out.Tags, v1)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "revision":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Revision = int64(in.Int64())
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -153,57 +202,81 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in
 		in.Consumed()
 	}
 }
-func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in GetKeysResponse) {
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in KeyEvent) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"keys\":"
+		const prefix string = ",\"event\":"
 		out.RawString(prefix[1:])
-		if in.Keys == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
+		out.String(string(in.Event))
+	}
+	if in.KeyUUID != nil {
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix)
+		out.RawText((*in.KeyUUID).MarshalText())
+	}
+	if in.Vault != nil {
+		const prefix string = ",\"vault\":"
+		out.RawString(prefix)
+		out.RawText((*in.Vault).MarshalText())
+	}
+	if in.KeyType != "" {
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.KeyType))
+	}
+	if in.Title != "" {
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
 			out.RawByte('[')
-			for v2, v3 := range in.Keys {
+			for v2, v3 := range in.Tags {
 				if v2 > 0 {
 					out.RawByte(',')
 				}
-				if v3 == nil {
-					out.RawString("null")
-				} else {
-					(*v3).MarshalEasyJSON(out)
-				}
+				out.String(string(v3))
 			}
 			out.RawByte(']')
 		}
 	}
+	if in.Revision != 0 {
+		const prefix string = ",\"revision\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Revision))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v GetKeysResponse) MarshalJSON() ([]byte, error) {
+func (v KeyEvent) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v GetKeysResponse) MarshalEasyJSON(w *jwriter.Writer) {
+func (v KeyEvent) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *GetKeysResponse) UnmarshalJSON(data []byte) error {
+func (v *KeyEvent) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *GetKeysResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *KeyEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
 }
-func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *GetKeysRecord) {
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *KeyChange) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -225,6 +298,22 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in
 					in.AddError((out.KeyUUID).UnmarshalText(data))
 				}
 			}
+		case "vault":
+			if in.IsNull() {
+				in.Skip()
+				out.Vault = nil
+			} else {
+				if out.Vault == nil {
+					out.Vault = new(uuid.UUID)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.UnsafeBytes(); in.Ok() {
+						in.AddError((*out.Vault).UnmarshalText(data))
+					}
+				}
+			}
 		case "type":
 			if in.IsNull() {
 				in.Skip()
@@ -237,6 +326,53 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in
 			} else {
 				out.Title = string(in.String())
 			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v4 = string(in.String())
+					}
+					out.Tags = append(out.Tags, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "revision":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Revision = int64(in.Int64())
+			}
+		case "deleted":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Deleted = bool(in.Bool())
+			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.Data).UnmarshalJSON(data))
+				}
+			}
 		case "created_at":
 			if in.IsNull() {
 				in.Skip()
@@ -263,7 +399,7 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in
 		in.Consumed()
 	}
 }
-func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in GetKeysRecord) {
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in KeyChange) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -272,6 +408,11 @@ func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(ou
 		out.RawString(prefix[1:])
 		out.RawText((in.KeyUUID).MarshalText())
 	}
+	if in.Vault != nil {
+		const prefix string = ",\"vault\":"
+		out.RawString(prefix)
+		out.RawText((*in.Vault).MarshalText())
+	}
 	{
 		const prefix string = ",\"type\":"
 		out.RawString(prefix)
@@ -282,6 +423,35 @@ func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(ou
 		out.RawString(prefix)
 		out.String(string(in.Title))
 	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v5, v6 := range in.Tags {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"revision\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Revision))
+	}
+	if in.Deleted {
+		const prefix string = ",\"deleted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Deleted))
+	}
+	if len(in.Data) != 0 {
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		out.Raw((in.Data).MarshalJSON())
+	}
 	{
 		const prefix string = ",\"created_at\":"
 		out.RawString(prefix)
@@ -296,29 +466,138 @@ func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(ou
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v GetKeysRecord) MarshalJSON() ([]byte, error) {
+func (v KeyChange) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v GetKeysRecord) MarshalEasyJSON(w *jwriter.Writer) {
+func (v KeyChange) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *GetKeysRecord) UnmarshalJSON(data []byte) error {
+func (v *KeyChange) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *GetKeysRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *KeyChange) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
 }
-func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *GetKeyResponse) {
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *GetKeysResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "keys":
+			if in.IsNull() {
+				in.Skip()
+				out.Keys = nil
+			} else {
+				in.Delim('[')
+				if out.Keys == nil {
+					if !in.IsDelim(']') {
+						out.Keys = make([]*GetKeysRecord, 0, 8)
+					} else {
+						out.Keys = []*GetKeysRecord{}
+					}
+				} else {
+					out.Keys = (out.Keys)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 *GetKeysRecord
+					if in.IsNull() {
+						in.Skip()
+						v7 = nil
+					} else {
+						if v7 == nil {
+							v7 = new(GetKeysRecord)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v7).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Keys = append(out.Keys, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in GetKeysResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"keys\":"
+		out.RawString(prefix[1:])
+		if in.Keys == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Keys {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				if v9 == nil {
+					out.RawString("null")
+				} else {
+					(*v9).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GetKeysResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GetKeysResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GetKeysResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GetKeysResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+}
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in *jlexer.Lexer, out *GetKeysRecord) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -352,13 +631,32 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in
 			} else {
 				out.Title = string(in.String())
 			}
-		case "data":
+		case "tags":
 			if in.IsNull() {
 				in.Skip()
+				out.Tags = nil
 			} else {
-				if data := in.Raw(); in.Ok() {
-					in.AddError((out.Data).UnmarshalJSON(data))
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v10 = string(in.String())
+					}
+					out.Tags = append(out.Tags, v10)
+					in.WantComma()
 				}
+				in.Delim(']')
 			}
 		case "created_at":
 			if in.IsNull() {
@@ -386,7 +684,7 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in
 		in.Consumed()
 	}
 }
-func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in GetKeyResponse) {
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(out *jwriter.Writer, in GetKeysRecord) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -405,8 +703,186 @@ func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(ou
 		out.RawString(prefix)
 		out.String(string(in.Title))
 	}
-	{
-		const prefix string = ",\"data\":"
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v11, v12 := range in.Tags {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v12))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"updated_at\":"
+		out.RawString(prefix)
+		out.Raw((in.UpdatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GetKeysRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GetKeysRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GetKeysRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GetKeysRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(l, v)
+}
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(in *jlexer.Lexer, out *GetKeyResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.KeyUUID).UnmarshalText(data))
+				}
+			}
+		case "type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.KeyType = keychain.KeyType(in.String())
+			}
+		case "title":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Title = string(in.String())
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v13 = string(in.String())
+					}
+					out.Tags = append(out.Tags, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.Data).UnmarshalJSON(data))
+				}
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		case "updated_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.UpdatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(out *jwriter.Writer, in GetKeyResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.KeyUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.KeyType))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v14, v15 := range in.Tags {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v15))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"data\":"
 		out.RawString(prefix)
 		out.Raw((in.Data).MarshalJSON())
 	}
@@ -426,27 +902,27 @@ func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(ou
 // MarshalJSON supports json.Marshaler interface
 func (v GetKeyResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v GetKeyResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *GetKeyResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *GetKeyResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(l, v)
 }
-func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in *jlexer.Lexer, out *FileResponseDTO) {
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(in *jlexer.Lexer, out *FileResponseDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -476,7 +952,7 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in
 		in.Consumed()
 	}
 }
-func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(out *jwriter.Writer, in FileResponseDTO) {
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(out *jwriter.Writer, in FileResponseDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -496,27 +972,27 @@ func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(ou
 // MarshalJSON supports json.Marshaler interface
 func (v FileResponseDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v FileResponseDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *FileResponseDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&r, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *FileResponseDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(l, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(l, v)
 }
-func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(in *jlexer.Lexer, out *CredentialsResponseDTO) {
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto7(in *jlexer.Lexer, out *CredentialsResponseDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -564,7 +1040,7 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(in
 		in.Consumed()
 	}
 }
-func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(out *jwriter.Writer, in CredentialsResponseDTO) {
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto7(out *jwriter.Writer, in CredentialsResponseDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -581,40 +1057,828 @@ func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(ou
 	if in.Site != "" {
 		const prefix string = ",\"site\":"
 		out.RawString(prefix)
-		out.String(string(in.Site))
+		out.String(string(in.Site))
+	}
+	if in.Note != "" {
+		const prefix string = ",\"note\":"
+		out.RawString(prefix)
+		out.String(string(in.Note))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CredentialsResponseDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CredentialsResponseDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CredentialsResponseDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CredentialsResponseDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto7(l, v)
+}
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto8(in *jlexer.Lexer, out *ChangesResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "revision":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Revision = int64(in.Int64())
+			}
+		case "more":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.More = bool(in.Bool())
+			}
+		case "changes":
+			if in.IsNull() {
+				in.Skip()
+				out.Changes = nil
+			} else {
+				in.Delim('[')
+				if out.Changes == nil {
+					if !in.IsDelim(']') {
+						out.Changes = make([]*KeyChange, 0, 8)
+					} else {
+						out.Changes = []*KeyChange{}
+					}
+				} else {
+					out.Changes = (out.Changes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v16 *KeyChange
+					if in.IsNull() {
+						in.Skip()
+						v16 = nil
+					} else {
+						if v16 == nil {
+							v16 = new(KeyChange)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v16).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Changes = append(out.Changes, v16)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto8(out *jwriter.Writer, in ChangesResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"revision\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Revision))
+	}
+	if in.More {
+		const prefix string = ",\"more\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.More))
+	}
+	{
+		const prefix string = ",\"changes\":"
+		out.RawString(prefix)
+		if in.Changes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v17, v18 := range in.Changes {
+				if v17 > 0 {
+					out.RawByte(',')
+				}
+				if v18 == nil {
+					out.RawString("null")
+				} else {
+					(*v18).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChangesResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChangesResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChangesResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChangesResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto8(l, v)
+}
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto9(in *jlexer.Lexer, out *CardResponseDTO) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "number":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Number = string(in.String())
+			}
+		case "exp_date":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ExpDate = string(in.String())
+			}
+		case "cvv":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.CVV = string(in.String())
+			}
+		case "holder":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Holder = string(in.String())
+			}
+		case "bank":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Bank = string(in.String())
+			}
+		case "note":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Note = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto9(out *jwriter.Writer, in CardResponseDTO) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"number\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Number))
+	}
+	{
+		const prefix string = ",\"exp_date\":"
+		out.RawString(prefix)
+		out.String(string(in.ExpDate))
+	}
+	{
+		const prefix string = ",\"cvv\":"
+		out.RawString(prefix)
+		out.String(string(in.CVV))
+	}
+	{
+		const prefix string = ",\"holder\":"
+		out.RawString(prefix)
+		out.String(string(in.Holder))
+	}
+	if in.Bank != "" {
+		const prefix string = ",\"bank\":"
+		out.RawString(prefix)
+		out.String(string(in.Bank))
+	}
+	if in.Note != "" {
+		const prefix string = ",\"note\":"
+		out.RawString(prefix)
+		out.String(string(in.Note))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CardResponseDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CardResponseDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CardResponseDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CardResponseDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto9(l, v)
+}
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto10(in *jlexer.Lexer, out *BatchResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "mode":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Mode = keychain.BatchMode(in.String())
+			}
+		case "applied":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Applied = int(in.Int())
+			}
+		case "failed":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Failed = int(in.Int())
+			}
+		case "results":
+			if in.IsNull() {
+				in.Skip()
+				out.Results = nil
+			} else {
+				in.Delim('[')
+				if out.Results == nil {
+					if !in.IsDelim(']') {
+						out.Results = make([]*BatchItemResult, 0, 8)
+					} else {
+						out.Results = []*BatchItemResult{}
+					}
+				} else {
+					out.Results = (out.Results)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v19 *BatchItemResult
+					if in.IsNull() {
+						in.Skip()
+						v19 = nil
+					} else {
+						if v19 == nil {
+							v19 = new(BatchItemResult)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v19).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Results = append(out.Results, v19)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto10(out *jwriter.Writer, in BatchResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"mode\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Mode))
+	}
+	{
+		const prefix string = ",\"applied\":"
+		out.RawString(prefix)
+		out.Int(int(in.Applied))
+	}
+	{
+		const prefix string = ",\"failed\":"
+		out.RawString(prefix)
+		out.Int(int(in.Failed))
+	}
+	{
+		const prefix string = ",\"results\":"
+		out.RawString(prefix)
+		if in.Results == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v20, v21 := range in.Results {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				if v21 == nil {
+					out.RawString("null")
+				} else {
+					(*v21).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BatchResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BatchResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BatchResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BatchResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto10(l, v)
+}
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto11(in *jlexer.Lexer, out *BatchRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "mode":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Mode = string(in.String())
+			}
+		case "operations":
+			if in.IsNull() {
+				in.Skip()
+				out.Operations = nil
+			} else {
+				in.Delim('[')
+				if out.Operations == nil {
+					if !in.IsDelim(']') {
+						out.Operations = make([]*BatchOperation, 0, 8)
+					} else {
+						out.Operations = []*BatchOperation{}
+					}
+				} else {
+					out.Operations = (out.Operations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 *BatchOperation
+					if in.IsNull() {
+						in.Skip()
+						v22 = nil
+					} else {
+						if v22 == nil {
+							v22 = new(BatchOperation)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v22).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Operations = append(out.Operations, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto11(out *jwriter.Writer, in BatchRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Mode != "" {
+		const prefix string = ",\"mode\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Mode))
+	}
+	{
+		const prefix string = ",\"operations\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Operations == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v23, v24 := range in.Operations {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				if v24 == nil {
+					out.RawString("null")
+				} else {
+					(*v24).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BatchRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BatchRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BatchRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BatchRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto11(l, v)
+}
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto12(in *jlexer.Lexer, out *BatchOperation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "op":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Op = keychain.BatchOp(in.String())
+			}
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UUID = string(in.String())
+			}
+		case "credential":
+			if in.IsNull() {
+				in.Skip()
+				out.Credential = nil
+			} else {
+				if out.Credential == nil {
+					out.Credential = new(AddCredentialsDTO)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					(*out.Credential).UnmarshalEasyJSON(in)
+				}
+			}
+		case "card":
+			if in.IsNull() {
+				in.Skip()
+				out.Card = nil
+			} else {
+				if out.Card == nil {
+					out.Card = new(AddCardDTO)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					(*out.Card).UnmarshalEasyJSON(in)
+				}
+			}
+		case "text":
+			if in.IsNull() {
+				in.Skip()
+				out.Text = nil
+			} else {
+				if out.Text == nil {
+					out.Text = new(AddTextDTO)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					(*out.Text).UnmarshalEasyJSON(in)
+				}
+			}
+		case "file":
+			if in.IsNull() {
+				in.Skip()
+				out.File = nil
+			} else {
+				if out.File == nil {
+					out.File = new(BatchFileDTO)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					(*out.File).UnmarshalEasyJSON(in)
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto12(out *jwriter.Writer, in BatchOperation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"op\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Op))
+	}
+	if in.UUID != "" {
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix)
+		out.String(string(in.UUID))
+	}
+	if in.Credential != nil {
+		const prefix string = ",\"credential\":"
+		out.RawString(prefix)
+		(*in.Credential).MarshalEasyJSON(out)
+	}
+	if in.Card != nil {
+		const prefix string = ",\"card\":"
+		out.RawString(prefix)
+		(*in.Card).MarshalEasyJSON(out)
+	}
+	if in.Text != nil {
+		const prefix string = ",\"text\":"
+		out.RawString(prefix)
+		(*in.Text).MarshalEasyJSON(out)
+	}
+	if in.File != nil {
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		(*in.File).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BatchOperation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BatchOperation) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BatchOperation) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BatchOperation) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto12(l, v)
+}
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto13(in *jlexer.Lexer, out *BatchItemResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "index":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Index = int(in.Int())
+			}
+		case "op":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Op = keychain.BatchOp(in.String())
+			}
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UUID = string(in.String())
+			}
+		case "status":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Status = string(in.String())
+			}
+		case "error":
+			if in.IsNull() {
+				in.Skip()
+				out.Error = nil
+			} else {
+				if out.Error == nil {
+					out.Error = new(ErrorResponse)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					(*out.Error).UnmarshalEasyJSON(in)
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto13(out *jwriter.Writer, in BatchItemResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"index\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Index))
+	}
+	{
+		const prefix string = ",\"op\":"
+		out.RawString(prefix)
+		out.String(string(in.Op))
+	}
+	if in.UUID != "" {
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix)
+		out.String(string(in.UUID))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
 	}
-	if in.Note != "" {
-		const prefix string = ",\"note\":"
+	if in.Error != nil {
+		const prefix string = ",\"error\":"
 		out.RawString(prefix)
-		out.String(string(in.Note))
+		(*in.Error).MarshalEasyJSON(out)
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v CredentialsResponseDTO) MarshalJSON() ([]byte, error) {
+func (v BatchItemResult) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto13(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CredentialsResponseDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(w, v)
+func (v BatchItemResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto13(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *CredentialsResponseDTO) UnmarshalJSON(data []byte) error {
+func (v *BatchItemResult) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&r, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto13(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CredentialsResponseDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(l, v)
+func (v *BatchItemResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto13(l, v)
 }
-func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(in *jlexer.Lexer, out *CardResponseDTO) {
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto14(in *jlexer.Lexer, out *BatchFileDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -628,35 +1892,45 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(in
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
-		case "number":
-			if in.IsNull() {
-				in.Skip()
-			} else {
-				out.Number = string(in.String())
-			}
-		case "exp_date":
-			if in.IsNull() {
-				in.Skip()
-			} else {
-				out.ExpDate = string(in.String())
-			}
-		case "cvv":
+		case "title":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.CVV = string(in.String())
+				out.Title = string(in.String())
 			}
-		case "holder":
+		case "tags":
 			if in.IsNull() {
 				in.Skip()
+				out.Tags = nil
 			} else {
-				out.Holder = string(in.String())
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v25 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v25 = string(in.String())
+					}
+					out.Tags = append(out.Tags, v25)
+					in.WantComma()
+				}
+				in.Delim(']')
 			}
-		case "bank":
+		case "content":
 			if in.IsNull() {
 				in.Skip()
+				out.Content = nil
 			} else {
-				out.Bank = string(in.String())
+				out.Content = in.Bytes()
 			}
 		case "note":
 			if in.IsNull() {
@@ -674,34 +1948,33 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(in
 		in.Consumed()
 	}
 }
-func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(out *jwriter.Writer, in CardResponseDTO) {
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto14(out *jwriter.Writer, in BatchFileDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"number\":"
+		const prefix string = ",\"title\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Number))
-	}
-	{
-		const prefix string = ",\"exp_date\":"
-		out.RawString(prefix)
-		out.String(string(in.ExpDate))
+		out.String(string(in.Title))
 	}
-	{
-		const prefix string = ",\"cvv\":"
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
 		out.RawString(prefix)
-		out.String(string(in.CVV))
+		{
+			out.RawByte('[')
+			for v27, v28 := range in.Tags {
+				if v27 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v28))
+			}
+			out.RawByte(']')
+		}
 	}
 	{
-		const prefix string = ",\"holder\":"
-		out.RawString(prefix)
-		out.String(string(in.Holder))
-	}
-	if in.Bank != "" {
-		const prefix string = ",\"bank\":"
+		const prefix string = ",\"content\":"
 		out.RawString(prefix)
-		out.String(string(in.Bank))
+		out.Base64Bytes(in.Content)
 	}
 	if in.Note != "" {
 		const prefix string = ",\"note\":"
@@ -712,29 +1985,29 @@ func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(ou
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v CardResponseDTO) MarshalJSON() ([]byte, error) {
+func (v BatchFileDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(&w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto14(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CardResponseDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(w, v)
+func (v BatchFileDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto14(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *CardResponseDTO) UnmarshalJSON(data []byte) error {
+func (v *BatchFileDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(&r, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto14(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CardResponseDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(l, v)
+func (v *BatchFileDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto14(l, v)
 }
-func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto7(in *jlexer.Lexer, out *AddTextDTO) {
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto15(in *jlexer.Lexer, out *AddTextDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -748,12 +2021,45 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto7(in
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
+		case "vault":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Vault = string(in.String())
+			}
 		case "title":
 			if in.IsNull() {
 				in.Skip()
 			} else {
 				out.Title = string(in.String())
 			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v31 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v31 = string(in.String())
+					}
+					out.Tags = append(out.Tags, v31)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		case "text":
 			if in.IsNull() {
 				in.Skip()
@@ -776,15 +2082,40 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto7(in
 		in.Consumed()
 	}
 }
-func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto7(out *jwriter.Writer, in AddTextDTO) {
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto15(out *jwriter.Writer, in AddTextDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
+	if in.Vault != "" {
+		const prefix string = ",\"vault\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Vault))
+	}
 	{
 		const prefix string = ",\"title\":"
-		out.RawString(prefix[1:])
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
 		out.String(string(in.Title))
 	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v32, v33 := range in.Tags {
+				if v32 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v33))
+			}
+			out.RawByte(']')
+		}
+	}
 	{
 		const prefix string = ",\"text\":"
 		out.RawString(prefix)
@@ -801,27 +2132,27 @@ func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto7(ou
 // MarshalJSON supports json.Marshaler interface
 func (v AddTextDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto7(&w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto15(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AddTextDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto7(w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto15(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AddTextDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto7(&r, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto15(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AddTextDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto7(l, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto15(l, v)
 }
-func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto8(in *jlexer.Lexer, out *AddSuccessResponse) {
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto16(in *jlexer.Lexer, out *AddSuccessResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -851,7 +2182,7 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto8(in
 		in.Consumed()
 	}
 }
-func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto8(out *jwriter.Writer, in AddSuccessResponse) {
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto16(out *jwriter.Writer, in AddSuccessResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -866,27 +2197,27 @@ func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto8(ou
 // MarshalJSON supports json.Marshaler interface
 func (v AddSuccessResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto8(&w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto16(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AddSuccessResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto8(w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto16(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AddSuccessResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto8(&r, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto16(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AddSuccessResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto8(l, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto16(l, v)
 }
-func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto9(in *jlexer.Lexer, out *AddFileDTO) {
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto17(in *jlexer.Lexer, out *AddFileDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -900,12 +2231,45 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto9(in
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
+		case "vault":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Vault = string(in.String())
+			}
 		case "title":
 			if in.IsNull() {
 				in.Skip()
 			} else {
 				out.Title = string(in.String())
 			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v34 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v34 = string(in.String())
+					}
+					out.Tags = append(out.Tags, v34)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		case "note":
 			if in.IsNull() {
 				in.Skip()
@@ -922,15 +2286,40 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto9(in
 		in.Consumed()
 	}
 }
-func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto9(out *jwriter.Writer, in AddFileDTO) {
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto17(out *jwriter.Writer, in AddFileDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
+	if in.Vault != "" {
+		const prefix string = ",\"vault\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Vault))
+	}
 	{
 		const prefix string = ",\"title\":"
-		out.RawString(prefix[1:])
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
 		out.String(string(in.Title))
 	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v35, v36 := range in.Tags {
+				if v35 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v36))
+			}
+			out.RawByte(']')
+		}
+	}
 	if in.Note != "" {
 		const prefix string = ",\"note\":"
 		out.RawString(prefix)
@@ -942,27 +2331,27 @@ func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto9(ou
 // MarshalJSON supports json.Marshaler interface
 func (v AddFileDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto9(&w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto17(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AddFileDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto9(w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto17(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AddFileDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto9(&r, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto17(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AddFileDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto9(l, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto17(l, v)
 }
-func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto10(in *jlexer.Lexer, out *AddCredentialsDTO) {
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto18(in *jlexer.Lexer, out *AddCredentialsDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -976,12 +2365,45 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto10(i
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
+		case "vault":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Vault = string(in.String())
+			}
 		case "title":
 			if in.IsNull() {
 				in.Skip()
 			} else {
 				out.Title = string(in.String())
 			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v37 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v37 = string(in.String())
+					}
+					out.Tags = append(out.Tags, v37)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		case "login":
 			if in.IsNull() {
 				in.Skip()
@@ -1016,15 +2438,40 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto10(i
 		in.Consumed()
 	}
 }
-func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto10(out *jwriter.Writer, in AddCredentialsDTO) {
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto18(out *jwriter.Writer, in AddCredentialsDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
+	if in.Vault != "" {
+		const prefix string = ",\"vault\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Vault))
+	}
 	{
 		const prefix string = ",\"title\":"
-		out.RawString(prefix[1:])
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
 		out.String(string(in.Title))
 	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v38, v39 := range in.Tags {
+				if v38 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v39))
+			}
+			out.RawByte(']')
+		}
+	}
 	{
 		const prefix string = ",\"login\":"
 		out.RawString(prefix)
@@ -1051,27 +2498,27 @@ func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto10(o
 // MarshalJSON supports json.Marshaler interface
 func (v AddCredentialsDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto10(&w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto18(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AddCredentialsDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto10(w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto18(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AddCredentialsDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto10(&r, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto18(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AddCredentialsDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto10(l, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto18(l, v)
 }
-func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto11(in *jlexer.Lexer, out *AddCardDTO) {
+func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto19(in *jlexer.Lexer, out *AddCardDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1085,12 +2532,45 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto11(i
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
+		case "vault":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Vault = string(in.String())
+			}
 		case "title":
 			if in.IsNull() {
 				in.Skip()
 			} else {
 				out.Title = string(in.String())
 			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v40 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v40 = string(in.String())
+					}
+					out.Tags = append(out.Tags, v40)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		case "number":
 			if in.IsNull() {
 				in.Skip()
@@ -1137,15 +2617,40 @@ func easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto11(i
 		in.Consumed()
 	}
 }
-func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto11(out *jwriter.Writer, in AddCardDTO) {
+func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto19(out *jwriter.Writer, in AddCardDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
+	if in.Vault != "" {
+		const prefix string = ",\"vault\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Vault))
+	}
 	{
 		const prefix string = ",\"title\":"
-		out.RawString(prefix[1:])
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
 		out.String(string(in.Title))
 	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v41, v42 := range in.Tags {
+				if v41 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v42))
+			}
+			out.RawByte(']')
+		}
+	}
 	{
 		const prefix string = ",\"number\":"
 		out.RawString(prefix)
@@ -1182,23 +2687,23 @@ func easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto11(o
 // MarshalJSON supports json.Marshaler interface
 func (v AddCardDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto11(&w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto19(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AddCardDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto11(w, v)
+	easyjsonE615605cEncodeGithubComThxhixPassKeeperInternalTransportHttpDto19(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AddCardDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto11(&r, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto19(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AddCardDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto11(l, v)
+	easyjsonE615605cDecodeGithubComThxhixPassKeeperInternalTransportHttpDto19(l, v)
 }
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all recovery.go
+
+type RecoveryShareRequest struct {
+	Login      string `json:"login"`
+	WrappedKey []byte `json:"wrapped_key"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+type SetRecoveryRequest struct {
+	PublicKey   []byte                  `json:"public_key"`
+	KeyMaterial []byte                  `json:"key_material"`
+	Threshold   int                     `json:"threshold"`
+	Shares      int                     `json:"shares"`
+	Held        []*RecoveryShareRequest `json:"held"`
+}
+
+type RecoveryResponse struct {
+	PublicKey   []byte    `json:"public_key"`
+	KeyMaterial []byte    `json:"key_material"`
+	Threshold   int       `json:"threshold"`
+	Shares      int       `json:"shares"`
+	Holders     []string  `json:"holders"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type RecoveryShareRecord struct {
+	ShareUUID uuid.UUID `json:"uuid"`
+	Owner     string    `json:"owner"`
+	Holder    string    `json:"holder"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RecoverySharesResponse struct {
+	Shares []*RecoveryShareRecord `json:"shares"`
+}
+
+type HeldRecoveryShareResponse struct {
+	RecoveryShareRecord
+	WrappedKey []byte `json:"wrapped_key"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
@@ -0,0 +1,780 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *SetRecoveryRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "public_key":
+			if in.IsNull() {
+				in.Skip()
+				out.PublicKey = nil
+			} else {
+				out.PublicKey = in.Bytes()
+			}
+		case "key_material":
+			if in.IsNull() {
+				in.Skip()
+				out.KeyMaterial = nil
+			} else {
+				out.KeyMaterial = in.Bytes()
+			}
+		case "threshold":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Threshold = int(in.Int())
+			}
+		case "shares":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Shares = int(in.Int())
+			}
+		case "held":
+			if in.IsNull() {
+				in.Skip()
+				out.Held = nil
+			} else {
+				in.Delim('[')
+				if out.Held == nil {
+					if !in.IsDelim(']') {
+						out.Held = make([]*RecoveryShareRequest, 0, 8)
+					} else {
+						out.Held = []*RecoveryShareRequest{}
+					}
+				} else {
+					out.Held = (out.Held)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 *RecoveryShareRequest
+					if in.IsNull() {
+						in.Skip()
+						v3 = nil
+					} else {
+						if v3 == nil {
+							v3 = new(RecoveryShareRequest)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v3).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Held = append(out.Held, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in SetRecoveryRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"public_key\":"
+		out.RawString(prefix[1:])
+		out.Base64Bytes(in.PublicKey)
+	}
+	{
+		const prefix string = ",\"key_material\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.KeyMaterial)
+	}
+	{
+		const prefix string = ",\"threshold\":"
+		out.RawString(prefix)
+		out.Int(int(in.Threshold))
+	}
+	{
+		const prefix string = ",\"shares\":"
+		out.RawString(prefix)
+		out.Int(int(in.Shares))
+	}
+	{
+		const prefix string = ",\"held\":"
+		out.RawString(prefix)
+		if in.Held == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Held {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				if v9 == nil {
+					out.RawString("null")
+				} else {
+					(*v9).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SetRecoveryRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SetRecoveryRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SetRecoveryRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SetRecoveryRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *RecoverySharesResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "shares":
+			if in.IsNull() {
+				in.Skip()
+				out.Shares = nil
+			} else {
+				in.Delim('[')
+				if out.Shares == nil {
+					if !in.IsDelim(']') {
+						out.Shares = make([]*RecoveryShareRecord, 0, 8)
+					} else {
+						out.Shares = []*RecoveryShareRecord{}
+					}
+				} else {
+					out.Shares = (out.Shares)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 *RecoveryShareRecord
+					if in.IsNull() {
+						in.Skip()
+						v10 = nil
+					} else {
+						if v10 == nil {
+							v10 = new(RecoveryShareRecord)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v10).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Shares = append(out.Shares, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in RecoverySharesResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"shares\":"
+		out.RawString(prefix[1:])
+		if in.Shares == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Shares {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				if v12 == nil {
+					out.RawString("null")
+				} else {
+					(*v12).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RecoverySharesResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RecoverySharesResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RecoverySharesResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RecoverySharesResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
+}
+func easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *RecoveryShareRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "login":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Login = string(in.String())
+			}
+		case "wrapped_key":
+			if in.IsNull() {
+				in.Skip()
+				out.WrappedKey = nil
+			} else {
+				out.WrappedKey = in.Bytes()
+			}
+		case "nonce":
+			if in.IsNull() {
+				in.Skip()
+				out.Nonce = nil
+			} else {
+				out.Nonce = in.Bytes()
+			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+				out.Data = nil
+			} else {
+				out.Data = in.Bytes()
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in RecoveryShareRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"login\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Login))
+	}
+	{
+		const prefix string = ",\"wrapped_key\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.WrappedKey)
+	}
+	{
+		const prefix string = ",\"nonce\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Nonce)
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Data)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RecoveryShareRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RecoveryShareRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RecoveryShareRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RecoveryShareRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
+}
+func easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *RecoveryShareRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.ShareUUID).UnmarshalText(data))
+				}
+			}
+		case "owner":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Owner = string(in.String())
+			}
+		case "holder":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Holder = string(in.String())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in RecoveryShareRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ShareUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"owner\":"
+		out.RawString(prefix)
+		out.String(string(in.Owner))
+	}
+	{
+		const prefix string = ",\"holder\":"
+		out.RawString(prefix)
+		out.String(string(in.Holder))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RecoveryShareRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RecoveryShareRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RecoveryShareRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RecoveryShareRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+}
+func easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in *jlexer.Lexer, out *RecoveryResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "public_key":
+			if in.IsNull() {
+				in.Skip()
+				out.PublicKey = nil
+			} else {
+				out.PublicKey = in.Bytes()
+			}
+		case "key_material":
+			if in.IsNull() {
+				in.Skip()
+				out.KeyMaterial = nil
+			} else {
+				out.KeyMaterial = in.Bytes()
+			}
+		case "threshold":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Threshold = int(in.Int())
+			}
+		case "shares":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Shares = int(in.Int())
+			}
+		case "holders":
+			if in.IsNull() {
+				in.Skip()
+				out.Holders = nil
+			} else {
+				in.Delim('[')
+				if out.Holders == nil {
+					if !in.IsDelim(']') {
+						out.Holders = make([]string, 0, 4)
+					} else {
+						out.Holders = []string{}
+					}
+				} else {
+					out.Holders = (out.Holders)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v24 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v24 = string(in.String())
+					}
+					out.Holders = append(out.Holders, v24)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(out *jwriter.Writer, in RecoveryResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"public_key\":"
+		out.RawString(prefix[1:])
+		out.Base64Bytes(in.PublicKey)
+	}
+	{
+		const prefix string = ",\"key_material\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.KeyMaterial)
+	}
+	{
+		const prefix string = ",\"threshold\":"
+		out.RawString(prefix)
+		out.Int(int(in.Threshold))
+	}
+	{
+		const prefix string = ",\"shares\":"
+		out.RawString(prefix)
+		out.Int(int(in.Shares))
+	}
+	{
+		const prefix string = ",\"holders\":"
+		out.RawString(prefix)
+		if in.Holders == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v29, v30 := range in.Holders {
+				if v29 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v30))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RecoveryResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RecoveryResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RecoveryResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RecoveryResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(l, v)
+}
+func easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(in *jlexer.Lexer, out *HeldRecoveryShareResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "wrapped_key":
+			if in.IsNull() {
+				in.Skip()
+				out.WrappedKey = nil
+			} else {
+				out.WrappedKey = in.Bytes()
+			}
+		case "nonce":
+			if in.IsNull() {
+				in.Skip()
+				out.Nonce = nil
+			} else {
+				out.Nonce = in.Bytes()
+			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+				out.Data = nil
+			} else {
+				out.Data = in.Bytes()
+			}
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.ShareUUID).UnmarshalText(data))
+				}
+			}
+		case "owner":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Owner = string(in.String())
+			}
+		case "holder":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Holder = string(in.String())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(out *jwriter.Writer, in HeldRecoveryShareResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"wrapped_key\":"
+		out.RawString(prefix[1:])
+		out.Base64Bytes(in.WrappedKey)
+	}
+	{
+		const prefix string = ",\"nonce\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Nonce)
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Data)
+	}
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix)
+		out.RawText((in.ShareUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"owner\":"
+		out.RawString(prefix)
+		out.String(string(in.Owner))
+	}
+	{
+		const prefix string = ",\"holder\":"
+		out.RawString(prefix)
+		out.String(string(in.Holder))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v HeldRecoveryShareResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v HeldRecoveryShareResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF23294b5EncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *HeldRecoveryShareResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *HeldRecoveryShareResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF23294b5DecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(l, v)
+}
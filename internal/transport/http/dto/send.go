@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all send.go
+
+type CreateSendRequest struct {
+	Nonce            []byte `json:"nonce"`
+	Data             []byte `json:"data"`
+	MaxViews         int    `json:"max_views"`
+	ExpiresInSeconds int64  `json:"expires_in_seconds,omitempty"`
+}
+
+type SendRecord struct {
+	SendUUID  uuid.UUID `json:"uuid"`
+	MaxViews  int       `json:"max_views"`
+	Views     int       `json:"views"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SendsResponse struct {
+	Sends []*SendRecord `json:"sends"`
+}
+
+type SendResponse struct {
+	Nonce     []byte    `json:"nonce"`
+	Data      []byte    `json:"data"`
+	ViewsLeft int       `json:"views_left"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,445 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson3232e6eaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *SendsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "sends":
+			if in.IsNull() {
+				in.Skip()
+				out.Sends = nil
+			} else {
+				in.Delim('[')
+				if out.Sends == nil {
+					if !in.IsDelim(']') {
+						out.Sends = make([]*SendRecord, 0, 8)
+					} else {
+						out.Sends = []*SendRecord{}
+					}
+				} else {
+					out.Sends = (out.Sends)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *SendRecord
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(SendRecord)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v1).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Sends = append(out.Sends, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3232e6eaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in SendsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"sends\":"
+		out.RawString(prefix[1:])
+		if in.Sends == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Sends {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					(*v3).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SendsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3232e6eaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SendsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3232e6eaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SendsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3232e6eaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SendsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3232e6eaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjson3232e6eaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *SendResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "nonce":
+			if in.IsNull() {
+				in.Skip()
+				out.Nonce = nil
+			} else {
+				out.Nonce = in.Bytes()
+			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+				out.Data = nil
+			} else {
+				out.Data = in.Bytes()
+			}
+		case "views_left":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ViewsLeft = int(in.Int())
+			}
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.ExpiresAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3232e6eaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in SendResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"nonce\":"
+		out.RawString(prefix[1:])
+		out.Base64Bytes(in.Nonce)
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Data)
+	}
+	{
+		const prefix string = ",\"views_left\":"
+		out.RawString(prefix)
+		out.Int(int(in.ViewsLeft))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpiresAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SendResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3232e6eaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SendResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3232e6eaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SendResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3232e6eaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SendResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3232e6eaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
+}
+func easyjson3232e6eaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *SendRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.SendUUID).UnmarshalText(data))
+				}
+			}
+		case "max_views":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxViews = int(in.Int())
+			}
+		case "views":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Views = int(in.Int())
+			}
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.ExpiresAt).UnmarshalJSON(data))
+				}
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3232e6eaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in SendRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.SendUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"max_views\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxViews))
+	}
+	{
+		const prefix string = ",\"views\":"
+		out.RawString(prefix)
+		out.Int(int(in.Views))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpiresAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SendRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3232e6eaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SendRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3232e6eaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SendRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3232e6eaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SendRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3232e6eaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
+}
+func easyjson3232e6eaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *CreateSendRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "nonce":
+			if in.IsNull() {
+				in.Skip()
+				out.Nonce = nil
+			} else {
+				out.Nonce = in.Bytes()
+			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+				out.Data = nil
+			} else {
+				out.Data = in.Bytes()
+			}
+		case "max_views":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxViews = int(in.Int())
+			}
+		case "expires_in_seconds":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ExpiresInSeconds = int64(in.Int64())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3232e6eaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in CreateSendRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"nonce\":"
+		out.RawString(prefix[1:])
+		out.Base64Bytes(in.Nonce)
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Data)
+	}
+	{
+		const prefix string = ",\"max_views\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxViews))
+	}
+	if in.ExpiresInSeconds != 0 {
+		const prefix string = ",\"expires_in_seconds\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ExpiresInSeconds))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateSendRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3232e6eaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateSendRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3232e6eaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateSendRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3232e6eaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateSendRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3232e6eaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+}
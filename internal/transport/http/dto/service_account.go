@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all service_account.go
+
+// ServiceTokenRequest is an OAuth 2.0 style token request of a service
+// account. client_secret is used with the client_credentials grant and
+// assertion with the jwt-bearer grant.
+type ServiceTokenRequest struct {
+	GrantType    string `json:"grant_type"`
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	Assertion    string `json:"assertion,omitempty"`
+}
+
+type ServiceTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+type CreateServiceAccountRequest struct {
+	Name      string `json:"name"`
+	PublicKey string `json:"public_key,omitempty"`
+}
+
+type ServiceAccountRecord struct {
+	ClientID   uuid.UUID  `json:"client_id"`
+	Name       string     `json:"name"`
+	PublicKey  string     `json:"public_key,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreateServiceAccountResponse struct {
+	ServiceAccountRecord
+	ClientSecret string `json:"client_secret"`
+}
+
+type ServiceAccountsResponse struct {
+	ServiceAccounts []*ServiceAccountRecord `json:"service_accounts"`
+}
+
+type ServiceAccountGrantsResponse struct {
+	Keys []uuid.UUID `json:"keys"`
+}
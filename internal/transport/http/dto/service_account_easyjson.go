@@ -0,0 +1,747 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	uuid "github.com/google/uuid"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *ServiceTokenResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "access_token":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.AccessToken = string(in.String())
+			}
+		case "token_type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.TokenType = string(in.String())
+			}
+		case "expires_in":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ExpiresIn = int64(in.Int64())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in ServiceTokenResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"access_token\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.AccessToken))
+	}
+	{
+		const prefix string = ",\"token_type\":"
+		out.RawString(prefix)
+		out.String(string(in.TokenType))
+	}
+	{
+		const prefix string = ",\"expires_in\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ExpiresIn))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ServiceTokenResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ServiceTokenResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ServiceTokenResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ServiceTokenResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *ServiceTokenRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "grant_type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.GrantType = string(in.String())
+			}
+		case "client_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ClientID = string(in.String())
+			}
+		case "client_secret":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ClientSecret = string(in.String())
+			}
+		case "assertion":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Assertion = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in ServiceTokenRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"grant_type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.GrantType))
+	}
+	if in.ClientID != "" {
+		const prefix string = ",\"client_id\":"
+		out.RawString(prefix)
+		out.String(string(in.ClientID))
+	}
+	if in.ClientSecret != "" {
+		const prefix string = ",\"client_secret\":"
+		out.RawString(prefix)
+		out.String(string(in.ClientSecret))
+	}
+	if in.Assertion != "" {
+		const prefix string = ",\"assertion\":"
+		out.RawString(prefix)
+		out.String(string(in.Assertion))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ServiceTokenRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ServiceTokenRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ServiceTokenRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ServiceTokenRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
+}
+func easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *ServiceAccountsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "service_accounts":
+			if in.IsNull() {
+				in.Skip()
+				out.ServiceAccounts = nil
+			} else {
+				in.Delim('[')
+				if out.ServiceAccounts == nil {
+					if !in.IsDelim(']') {
+						out.ServiceAccounts = make([]*ServiceAccountRecord, 0, 8)
+					} else {
+						out.ServiceAccounts = []*ServiceAccountRecord{}
+					}
+				} else {
+					out.ServiceAccounts = (out.ServiceAccounts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *ServiceAccountRecord
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(ServiceAccountRecord)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v1).UnmarshalEasyJSON(in)
+						}
+					}
+					out.ServiceAccounts = append(out.ServiceAccounts, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in ServiceAccountsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"service_accounts\":"
+		out.RawString(prefix[1:])
+		if in.ServiceAccounts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.ServiceAccounts {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					(*v3).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ServiceAccountsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ServiceAccountsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ServiceAccountsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ServiceAccountsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
+}
+func easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *ServiceAccountRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "client_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.ClientID).UnmarshalText(data))
+				}
+			}
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		case "public_key":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PublicKey = string(in.String())
+			}
+		case "last_used_at":
+			if in.IsNull() {
+				in.Skip()
+				out.LastUsedAt = nil
+			} else {
+				if out.LastUsedAt == nil {
+					out.LastUsedAt = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.LastUsedAt).UnmarshalJSON(data))
+					}
+				}
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in ServiceAccountRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"client_id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ClientID).MarshalText())
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	if in.PublicKey != "" {
+		const prefix string = ",\"public_key\":"
+		out.RawString(prefix)
+		out.String(string(in.PublicKey))
+	}
+	if in.LastUsedAt != nil {
+		const prefix string = ",\"last_used_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.LastUsedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ServiceAccountRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ServiceAccountRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ServiceAccountRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ServiceAccountRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+}
+func easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in *jlexer.Lexer, out *ServiceAccountGrantsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "keys":
+			if in.IsNull() {
+				in.Skip()
+				out.Keys = nil
+			} else {
+				in.Delim('[')
+				if out.Keys == nil {
+					if !in.IsDelim(']') {
+						out.Keys = make([]uuid.UUID, 0, 4)
+					} else {
+						out.Keys = []uuid.UUID{}
+					}
+				} else {
+					out.Keys = (out.Keys)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 uuid.UUID
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						if data := in.UnsafeBytes(); in.Ok() {
+							in.AddError((v4).UnmarshalText(data))
+						}
+					}
+					out.Keys = append(out.Keys, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(out *jwriter.Writer, in ServiceAccountGrantsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"keys\":"
+		out.RawString(prefix[1:])
+		if in.Keys == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Keys {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.RawText((v6).MarshalText())
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ServiceAccountGrantsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ServiceAccountGrantsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ServiceAccountGrantsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ServiceAccountGrantsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(l, v)
+}
+func easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(in *jlexer.Lexer, out *CreateServiceAccountResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "client_secret":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ClientSecret = string(in.String())
+			}
+		case "client_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.ClientID).UnmarshalText(data))
+				}
+			}
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		case "public_key":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PublicKey = string(in.String())
+			}
+		case "last_used_at":
+			if in.IsNull() {
+				in.Skip()
+				out.LastUsedAt = nil
+			} else {
+				if out.LastUsedAt == nil {
+					out.LastUsedAt = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.LastUsedAt).UnmarshalJSON(data))
+					}
+				}
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(out *jwriter.Writer, in CreateServiceAccountResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"client_secret\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ClientSecret))
+	}
+	{
+		const prefix string = ",\"client_id\":"
+		out.RawString(prefix)
+		out.RawText((in.ClientID).MarshalText())
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	if in.PublicKey != "" {
+		const prefix string = ",\"public_key\":"
+		out.RawString(prefix)
+		out.String(string(in.PublicKey))
+	}
+	if in.LastUsedAt != nil {
+		const prefix string = ",\"last_used_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.LastUsedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateServiceAccountResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateServiceAccountResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateServiceAccountResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateServiceAccountResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(l, v)
+}
+func easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(in *jlexer.Lexer, out *CreateServiceAccountRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		case "public_key":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PublicKey = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(out *jwriter.Writer, in CreateServiceAccountRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	if in.PublicKey != "" {
+		const prefix string = ",\"public_key\":"
+		out.RawString(prefix)
+		out.String(string(in.PublicKey))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateServiceAccountRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateServiceAccountRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3d7129e3EncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateServiceAccountRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateServiceAccountRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3d7129e3DecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(l, v)
+}
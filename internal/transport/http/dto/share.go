@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"time"
+)
+
+//go:generate easyjson -all share.go
+
+type SetKeyPairRequest struct {
+	PublicKey   []byte `json:"public_key"`
+	KeyMaterial []byte `json:"key_material"`
+}
+
+type KeyPairResponse struct {
+	PublicKey   []byte `json:"public_key"`
+	KeyMaterial []byte `json:"key_material"`
+}
+
+type PublicKeyResponse struct {
+	Login     string `json:"login"`
+	PublicKey []byte `json:"public_key"`
+}
+
+type ShareKeyRequest struct {
+	Login      string `json:"login"`
+	WrappedKey []byte `json:"wrapped_key"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+type ShareRecord struct {
+	ShareUUID uuid.UUID        `json:"uuid"`
+	KeyUUID   uuid.UUID        `json:"key_uuid"`
+	KeyType   keychain.KeyType `json:"type"`
+	Title     string           `json:"title"`
+	From      string           `json:"from"`
+	To        string           `json:"to"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+type SharesResponse struct {
+	Shares []*ShareRecord `json:"shares"`
+}
+
+type SharedKeyResponse struct {
+	ShareRecord
+	WrappedKey []byte `json:"wrapped_key"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
@@ -0,0 +1,773 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	keychain "github.com/thxhix/passKeeper/internal/domain/keychain"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *SharesResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "shares":
+			if in.IsNull() {
+				in.Skip()
+				out.Shares = nil
+			} else {
+				in.Delim('[')
+				if out.Shares == nil {
+					if !in.IsDelim(']') {
+						out.Shares = make([]*ShareRecord, 0, 8)
+					} else {
+						out.Shares = []*ShareRecord{}
+					}
+				} else {
+					out.Shares = (out.Shares)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *ShareRecord
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(ShareRecord)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v1).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Shares = append(out.Shares, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in SharesResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"shares\":"
+		out.RawString(prefix[1:])
+		if in.Shares == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Shares {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					(*v3).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SharesResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SharesResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SharesResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SharesResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *SharedKeyResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "wrapped_key":
+			if in.IsNull() {
+				in.Skip()
+				out.WrappedKey = nil
+			} else {
+				out.WrappedKey = in.Bytes()
+			}
+		case "nonce":
+			if in.IsNull() {
+				in.Skip()
+				out.Nonce = nil
+			} else {
+				out.Nonce = in.Bytes()
+			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+				out.Data = nil
+			} else {
+				out.Data = in.Bytes()
+			}
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.ShareUUID).UnmarshalText(data))
+				}
+			}
+		case "key_uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.KeyUUID).UnmarshalText(data))
+				}
+			}
+		case "type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.KeyType = keychain.KeyType(in.String())
+			}
+		case "title":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Title = string(in.String())
+			}
+		case "from":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.From = string(in.String())
+			}
+		case "to":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.To = string(in.String())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in SharedKeyResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"wrapped_key\":"
+		out.RawString(prefix[1:])
+		out.Base64Bytes(in.WrappedKey)
+	}
+	{
+		const prefix string = ",\"nonce\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Nonce)
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Data)
+	}
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix)
+		out.RawText((in.ShareUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"key_uuid\":"
+		out.RawString(prefix)
+		out.RawText((in.KeyUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.KeyType))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix)
+		out.String(string(in.From))
+	}
+	{
+		const prefix string = ",\"to\":"
+		out.RawString(prefix)
+		out.String(string(in.To))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SharedKeyResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SharedKeyResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SharedKeyResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SharedKeyResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
+}
+func easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *ShareRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.ShareUUID).UnmarshalText(data))
+				}
+			}
+		case "key_uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.KeyUUID).UnmarshalText(data))
+				}
+			}
+		case "type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.KeyType = keychain.KeyType(in.String())
+			}
+		case "title":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Title = string(in.String())
+			}
+		case "from":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.From = string(in.String())
+			}
+		case "to":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.To = string(in.String())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in ShareRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ShareUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"key_uuid\":"
+		out.RawString(prefix)
+		out.RawText((in.KeyUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.KeyType))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix)
+		out.String(string(in.From))
+	}
+	{
+		const prefix string = ",\"to\":"
+		out.RawString(prefix)
+		out.String(string(in.To))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ShareRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ShareRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ShareRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ShareRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
+}
+func easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *ShareKeyRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "login":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Login = string(in.String())
+			}
+		case "wrapped_key":
+			if in.IsNull() {
+				in.Skip()
+				out.WrappedKey = nil
+			} else {
+				out.WrappedKey = in.Bytes()
+			}
+		case "nonce":
+			if in.IsNull() {
+				in.Skip()
+				out.Nonce = nil
+			} else {
+				out.Nonce = in.Bytes()
+			}
+		case "data":
+			if in.IsNull() {
+				in.Skip()
+				out.Data = nil
+			} else {
+				out.Data = in.Bytes()
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in ShareKeyRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"login\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Login))
+	}
+	{
+		const prefix string = ",\"wrapped_key\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.WrappedKey)
+	}
+	{
+		const prefix string = ",\"nonce\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Nonce)
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Data)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ShareKeyRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ShareKeyRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ShareKeyRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ShareKeyRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+}
+func easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in *jlexer.Lexer, out *SetKeyPairRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "public_key":
+			if in.IsNull() {
+				in.Skip()
+				out.PublicKey = nil
+			} else {
+				out.PublicKey = in.Bytes()
+			}
+		case "key_material":
+			if in.IsNull() {
+				in.Skip()
+				out.KeyMaterial = nil
+			} else {
+				out.KeyMaterial = in.Bytes()
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(out *jwriter.Writer, in SetKeyPairRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"public_key\":"
+		out.RawString(prefix[1:])
+		out.Base64Bytes(in.PublicKey)
+	}
+	{
+		const prefix string = ",\"key_material\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.KeyMaterial)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SetKeyPairRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SetKeyPairRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SetKeyPairRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SetKeyPairRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(l, v)
+}
+func easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(in *jlexer.Lexer, out *PublicKeyResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "login":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Login = string(in.String())
+			}
+		case "public_key":
+			if in.IsNull() {
+				in.Skip()
+				out.PublicKey = nil
+			} else {
+				out.PublicKey = in.Bytes()
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(out *jwriter.Writer, in PublicKeyResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"login\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Login))
+	}
+	{
+		const prefix string = ",\"public_key\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.PublicKey)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PublicKeyResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PublicKeyResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PublicKeyResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PublicKeyResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(l, v)
+}
+func easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(in *jlexer.Lexer, out *KeyPairResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "public_key":
+			if in.IsNull() {
+				in.Skip()
+				out.PublicKey = nil
+			} else {
+				out.PublicKey = in.Bytes()
+			}
+		case "key_material":
+			if in.IsNull() {
+				in.Skip()
+				out.KeyMaterial = nil
+			} else {
+				out.KeyMaterial = in.Bytes()
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(out *jwriter.Writer, in KeyPairResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"public_key\":"
+		out.RawString(prefix[1:])
+		out.Base64Bytes(in.PublicKey)
+	}
+	{
+		const prefix string = ",\"key_material\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.KeyMaterial)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v KeyPairResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v KeyPairResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4f304ef7EncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *KeyPairResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *KeyPairResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4f304ef7DecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(l, v)
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all token.go
+
+type CreatePersonalTokenRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type PersonalTokenRecord struct {
+	TokenUUID  uuid.UUID  `json:"uuid"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreatePersonalTokenResponse struct {
+	PersonalTokenRecord
+	Token string `json:"token"`
+}
+
+type PersonalTokensResponse struct {
+	Tokens []*PersonalTokenRecord `json:"tokens"`
+}
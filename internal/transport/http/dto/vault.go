@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all vault.go
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+type OrganizationRecord struct {
+	OrgUUID   uuid.UUID `json:"uuid"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OrganizationsResponse struct {
+	Organizations []*OrganizationRecord `json:"organizations"`
+}
+
+type CreateVaultRequest struct {
+	Name string `json:"name"`
+	Org  string `json:"org,omitempty"`
+}
+
+type VaultRecord struct {
+	VaultUUID uuid.UUID  `json:"uuid"`
+	OrgUUID   *uuid.UUID `json:"org,omitempty"`
+	Name      string     `json:"name"`
+	Role      string     `json:"role"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type VaultsResponse struct {
+	Vaults []*VaultRecord `json:"vaults"`
+}
+
+type SetMemberRequest struct {
+	Role string `json:"role"`
+}
+
+type MemberRecord struct {
+	Login     string    `json:"login"`
+	Role      string    `json:"role"`
+	Inherited bool      `json:"inherited,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MembersResponse struct {
+	Members []*MemberRecord `json:"members"`
+}
@@ -0,0 +1,878 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	uuid "github.com/google/uuid"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(in *jlexer.Lexer, out *VaultsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "vaults":
+			if in.IsNull() {
+				in.Skip()
+				out.Vaults = nil
+			} else {
+				in.Delim('[')
+				if out.Vaults == nil {
+					if !in.IsDelim(']') {
+						out.Vaults = make([]*VaultRecord, 0, 8)
+					} else {
+						out.Vaults = []*VaultRecord{}
+					}
+				} else {
+					out.Vaults = (out.Vaults)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *VaultRecord
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(VaultRecord)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v1).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Vaults = append(out.Vaults, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(out *jwriter.Writer, in VaultsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"vaults\":"
+		out.RawString(prefix[1:])
+		if in.Vaults == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Vaults {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					(*v3).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v VaultsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v VaultsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *VaultsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *VaultsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto(l, v)
+}
+func easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(in *jlexer.Lexer, out *VaultRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.VaultUUID).UnmarshalText(data))
+				}
+			}
+		case "org":
+			if in.IsNull() {
+				in.Skip()
+				out.OrgUUID = nil
+			} else {
+				if out.OrgUUID == nil {
+					out.OrgUUID = new(uuid.UUID)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.UnsafeBytes(); in.Ok() {
+						in.AddError((*out.OrgUUID).UnmarshalText(data))
+					}
+				}
+			}
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		case "role":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Role = string(in.String())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(out *jwriter.Writer, in VaultRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.VaultUUID).MarshalText())
+	}
+	if in.OrgUUID != nil {
+		const prefix string = ",\"org\":"
+		out.RawString(prefix)
+		out.RawText((*in.OrgUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"role\":"
+		out.RawString(prefix)
+		out.String(string(in.Role))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v VaultRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v VaultRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *VaultRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *VaultRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto1(l, v)
+}
+func easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(in *jlexer.Lexer, out *SetMemberRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "role":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Role = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(out *jwriter.Writer, in SetMemberRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"role\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Role))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SetMemberRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SetMemberRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SetMemberRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SetMemberRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto2(l, v)
+}
+func easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(in *jlexer.Lexer, out *OrganizationsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "organizations":
+			if in.IsNull() {
+				in.Skip()
+				out.Organizations = nil
+			} else {
+				in.Delim('[')
+				if out.Organizations == nil {
+					if !in.IsDelim(']') {
+						out.Organizations = make([]*OrganizationRecord, 0, 8)
+					} else {
+						out.Organizations = []*OrganizationRecord{}
+					}
+				} else {
+					out.Organizations = (out.Organizations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 *OrganizationRecord
+					if in.IsNull() {
+						in.Skip()
+						v4 = nil
+					} else {
+						if v4 == nil {
+							v4 = new(OrganizationRecord)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v4).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Organizations = append(out.Organizations, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(out *jwriter.Writer, in OrganizationsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"organizations\":"
+		out.RawString(prefix[1:])
+		if in.Organizations == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Organizations {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				if v6 == nil {
+					out.RawString("null")
+				} else {
+					(*v6).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OrganizationsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OrganizationsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OrganizationsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OrganizationsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto3(l, v)
+}
+func easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(in *jlexer.Lexer, out *OrganizationRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "uuid":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((out.OrgUUID).UnmarshalText(data))
+				}
+			}
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		case "role":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Role = string(in.String())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(out *jwriter.Writer, in OrganizationRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"uuid\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.OrgUUID).MarshalText())
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"role\":"
+		out.RawString(prefix)
+		out.String(string(in.Role))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OrganizationRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OrganizationRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OrganizationRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OrganizationRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto4(l, v)
+}
+func easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(in *jlexer.Lexer, out *MembersResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "members":
+			if in.IsNull() {
+				in.Skip()
+				out.Members = nil
+			} else {
+				in.Delim('[')
+				if out.Members == nil {
+					if !in.IsDelim(']') {
+						out.Members = make([]*MemberRecord, 0, 8)
+					} else {
+						out.Members = []*MemberRecord{}
+					}
+				} else {
+					out.Members = (out.Members)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 *MemberRecord
+					if in.IsNull() {
+						in.Skip()
+						v7 = nil
+					} else {
+						if v7 == nil {
+							v7 = new(MemberRecord)
+						}
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							(*v7).UnmarshalEasyJSON(in)
+						}
+					}
+					out.Members = append(out.Members, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(out *jwriter.Writer, in MembersResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"members\":"
+		out.RawString(prefix[1:])
+		if in.Members == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Members {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				if v9 == nil {
+					out.RawString("null")
+				} else {
+					(*v9).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MembersResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MembersResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MembersResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MembersResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto5(l, v)
+}
+func easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(in *jlexer.Lexer, out *MemberRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "login":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Login = string(in.String())
+			}
+		case "role":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Role = string(in.String())
+			}
+		case "inherited":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Inherited = bool(in.Bool())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(out *jwriter.Writer, in MemberRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"login\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Login))
+	}
+	{
+		const prefix string = ",\"role\":"
+		out.RawString(prefix)
+		out.String(string(in.Role))
+	}
+	if in.Inherited {
+		const prefix string = ",\"inherited\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Inherited))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MemberRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MemberRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MemberRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MemberRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto6(l, v)
+}
+func easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto7(in *jlexer.Lexer, out *CreateVaultRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		case "org":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Org = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto7(out *jwriter.Writer, in CreateVaultRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	if in.Org != "" {
+		const prefix string = ",\"org\":"
+		out.RawString(prefix)
+		out.String(string(in.Org))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateVaultRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateVaultRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateVaultRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateVaultRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto7(l, v)
+}
+func easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto8(in *jlexer.Lexer, out *CreateOrganizationRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto8(out *jwriter.Writer, in CreateOrganizationRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreateOrganizationRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreateOrganizationRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3eb06cbaEncodeGithubComThxhixPassKeeperInternalTransportHttpDto8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreateOrganizationRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreateOrganizationRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3eb06cbaDecodeGithubComThxhixPassKeeperInternalTransportHttpDto8(l, v)
+}
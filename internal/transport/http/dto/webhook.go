@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all webhook.go
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+type WebhookRecord struct {
+	ID        uuid.UUID `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateWebhookResponse carries the signing secret, which is only returned on creation.
+type CreateWebhookResponse struct {
+	WebhookRecord
+	Secret string `json:"secret"`
+}
+
+type WebhooksResponse struct {
+	Webhooks []*WebhookRecord `json:"webhooks"`
+}
+
+// WebhookDeliveryRecord is a delivery attempt history entry. last_status_code
+// is omitted when no response was received; last_error is the coarse reason
+// of the last failed attempt, such as timeout or blocked_address.
+type WebhookDeliveryRecord struct {
+	ID             uuid.UUID  `json:"id"`
+	Event          string     `json:"event"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Deliveries []*WebhookDeliveryRecord `json:"deliveries"`
+}
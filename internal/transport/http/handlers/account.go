@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/domain/account"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/share"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+)
+
+// ExportAccount returns all personal data of the user as a zip archive.
+//
+// Body (JSON):
+//
+//	{
+//	  "password": "string"
+//	}
+//
+// The archive contains manifest.json with every personal and vault entry and
+// its decrypted data, the user's sends and the shares made by and with the
+// user, and the raw content of file entries under files/<uuid>. Sends are
+// listed without their ciphertext; received shares keep their sealed payload,
+// which only the user's private key opens.
+//
+// Status codes:
+//
+//	200 OK – the archive is streamed in the response body.
+//	400 BadRequest – invalid JSON.
+//	401 Unauthorized – user is not authenticated or password is wrong.
+//	429 TooManyRequests – too many attempts, see Retry-After.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) ExportAccount(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.AccountPasswordRequest{}
+	err = easyjson.Unmarshal(body, &reqObj)
+	if err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	export, err := h.accountService.Export(r.Context(), userId, reqObj.Password)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidCredentials) {
+			h.PublicError(w, http.StatusUnauthorized, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	fileName := fmt.Sprintf("passkeeper-export-%s.zip", export.ExportedAt.Format("20060102-150405"))
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeExportArchive(w, export); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// DeleteAccount permanently removes the user account with all its data.
+//
+// Body (JSON):
+//
+//	{
+//	  "password": "string"
+//	}
+//
+// Status codes:
+//
+//	204 NoContent – the account was deleted.
+//	400 BadRequest – invalid JSON.
+//	401 Unauthorized – user is not authenticated or password is wrong.
+//	409 Conflict – the user is the only owner of a vault or organization with other members.
+//	429 TooManyRequests – too many attempts, see Retry-After.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.AccountPasswordRequest{}
+	err = easyjson.Unmarshal(body, &reqObj)
+	if err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	err = h.accountService.Delete(r.Context(), userId, reqObj.Password)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidCredentials) {
+			h.PublicError(w, http.StatusUnauthorized, err)
+			return
+		}
+		if errors.Is(err, vault.ErrSoleOwner) {
+			h.PublicError(w, http.StatusConflict, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeExportArchive writes export as a zip archive into w.
+//
+// File entries are split: their content goes to files/<uuid> and the manifest
+// keeps only the remaining fields together with a reference to the file.
+func writeExportArchive(w io.Writer, export *account.Export) error {
+	zw := zip.NewWriter(w)
+
+	manifest := dto.ExportManifest{
+		Login:      export.Login,
+		ExportedAt: export.ExportedAt,
+		Entries:    make([]*dto.ExportManifestEntry, 0, len(export.Entries)),
+		Sends:      make([]*dto.ExportManifestSend, 0, len(export.Sends)),
+
+		SharesGiven:    exportShares(export.SharesGiven),
+		SharesReceived: exportShares(export.SharesReceived),
+	}
+
+	for _, entry := range export.Entries {
+		item := &dto.ExportManifestEntry{
+			KeyUUID:   entry.Record.KeyUUID,
+			KeyType:   entry.Record.KeyType,
+			Title:     entry.Record.Title,
+			Data:      entry.Data,
+			CreatedAt: entry.Record.CreatedAt,
+			UpdatedAt: entry.Record.UpdatedAt,
+		}
+		if entry.Vault != nil {
+			item.Vault = &entry.Vault.VaultUUID
+			item.VaultName = entry.Vault.Name
+		}
+
+		if entry.Record.KeyType == keychain.KeyFile {
+			var fd keychain.FileData
+			if err := json.Unmarshal(entry.Data, &fd); err != nil {
+				return err
+			}
+
+			item.File = "files/" + entry.Record.KeyUUID.String()
+			fw, err := zw.Create(item.File)
+			if err != nil {
+				return err
+			}
+			if _, err := fw.Write(fd.File); err != nil {
+				return err
+			}
+
+			meta, err := json.Marshal(keychain.FileData{Note: fd.Note})
+			if err != nil {
+				return err
+			}
+			item.Data = meta
+		}
+
+		manifest.Entries = append(manifest.Entries, item)
+	}
+
+	for _, sn := range export.Sends {
+		manifest.Sends = append(manifest.Sends, &dto.ExportManifestSend{
+			SendUUID:  sn.SendUUID,
+			MaxViews:  sn.MaxViews,
+			Views:     sn.Views,
+			ExpiresAt: sn.ExpiresAt,
+			CreatedAt: sn.CreatedAt,
+		})
+	}
+
+	mw, err := zw.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	if _, err := easyjson.MarshalToWriter(&manifest, mw); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// exportShares converts shares to their manifest form.
+func exportShares(list []*share.Share) []*dto.ExportManifestShare {
+	out := make([]*dto.ExportManifestShare, 0, len(list))
+	for _, sh := range list {
+		out = append(out, &dto.ExportManifestShare{
+			ShareUUID:  sh.ShareUUID,
+			KeyUUID:    sh.KeyUUID,
+			KeyType:    sh.KeyType,
+			Title:      sh.Title,
+			Owner:      sh.OwnerLogin,
+			Recipient:  sh.RecipientLogin,
+			WrappedKey: sh.WrappedKey,
+			Nonce:      sh.Nonce,
+			Data:       sh.Data,
+			CreatedAt:  sh.CreatedAt,
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/thxhix/passKeeper/internal/domain/account"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/send"
+	"github.com/thxhix/passKeeper/internal/domain/share"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func makeAccountHandlers(accountSvc *mocks.AccountServiceMock) *Handlers {
+	return &Handlers{
+		accountService: accountSvc,
+		logger:         zap.NewNop(),
+	}
+}
+
+func TestHandlers_ExportAccount(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		accountSvc := new(mocks.AccountServiceMock)
+		h := makeAccountHandlers(accountSvc)
+
+		textUUID := uuid.New()
+		fileUUID := uuid.New()
+		vaultUUID := uuid.New()
+		sendUUID := uuid.New()
+		shareUUID := uuid.New()
+		export := &account.Export{
+			Login:      "user",
+			ExportedAt: time.Now(),
+			Entries: []*account.ExportEntry{
+				{
+					Record: &keychain.KeyRecord{KeyUUID: textUUID, KeyType: keychain.KeyText, Title: "text"},
+					Data:   []byte(`{"text":"secret"}`),
+				},
+				{
+					Record: &keychain.KeyRecord{KeyUUID: fileUUID, KeyType: keychain.KeyFile, Title: "file"},
+					Data:   []byte(`{"file":"aGVsbG8=","note":"n"}`),
+				},
+				{
+					Record: &keychain.KeyRecord{KeyUUID: uuid.New(), KeyType: keychain.KeyText, Title: "team"},
+					Data:   []byte(`{"text":"team secret"}`),
+					Vault:  &vault.Vault{VaultUUID: vaultUUID, Name: "ops"},
+				},
+			},
+			Sends:          []*send.Send{{SendUUID: sendUUID, MaxViews: 3}},
+			SharesReceived: []*share.Share{{ShareUUID: shareUUID, OwnerLogin: "alice", Data: []byte("sealed")}},
+		}
+
+		accountSvc.On("Export", mock.Anything, int64(1), "password").Return(export, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/account/export", strings.NewReader(`{"password":"password"}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.ExportAccount(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/zip", res.Header.Get("Content-Type"))
+
+		b, _ := io.ReadAll(res.Body)
+		zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		require.NoError(t, err)
+
+		files := map[string]string{}
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			content, _ := io.ReadAll(rc)
+			_ = rc.Close()
+			files[f.Name] = string(content)
+		}
+
+		assert.Equal(t, "hello", files["files/"+fileUUID.String()])
+		assert.Contains(t, files["manifest.json"], textUUID.String())
+		assert.Contains(t, files["manifest.json"], `"secret"`)
+		assert.NotContains(t, files["manifest.json"], "aGVsbG8=")
+		assert.Contains(t, files["manifest.json"], `"vault":"`+vaultUUID.String()+`"`)
+		assert.Contains(t, files["manifest.json"], `"vault_name":"ops"`)
+		assert.Contains(t, files["manifest.json"], sendUUID.String())
+		assert.Contains(t, files["manifest.json"], shareUUID.String())
+		assert.Contains(t, files["manifest.json"], `"shares_given":[]`)
+
+		accountSvc.AssertExpectations(t)
+	})
+
+	t.Run("wrong password -> unauthorized", func(t *testing.T) {
+		accountSvc := new(mocks.AccountServiceMock)
+		h := makeAccountHandlers(accountSvc)
+
+		accountSvc.On("Export", mock.Anything, int64(1), "wrong").Return((*account.Export)(nil), user.ErrInvalidCredentials)
+
+		req := httptest.NewRequest(http.MethodPost, "/account/export", strings.NewReader(`{"password":"wrong"}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.ExportAccount(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		accountSvc.AssertExpectations(t)
+	})
+}
+
+func TestHandlers_DeleteAccount(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		accountSvc := new(mocks.AccountServiceMock)
+		h := makeAccountHandlers(accountSvc)
+
+		accountSvc.On("Delete", mock.Anything, int64(1), "password").Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/account", strings.NewReader(`{"password":"password"}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.DeleteAccount(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+		accountSvc.AssertExpectations(t)
+	})
+
+	t.Run("bad json", func(t *testing.T) {
+		accountSvc := new(mocks.AccountServiceMock)
+		h := makeAccountHandlers(accountSvc)
+
+		req := httptest.NewRequest(http.MethodDelete, "/account", strings.NewReader(`{"password":}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.DeleteAccount(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("sole owner -> conflict", func(t *testing.T) {
+		accountSvc := new(mocks.AccountServiceMock)
+		h := makeAccountHandlers(accountSvc)
+
+		accountSvc.On("Delete", mock.Anything, int64(1), "password").Return(vault.ErrSoleOwner)
+
+		req := httptest.NewRequest(http.MethodDelete, "/account", strings.NewReader(`{"password":"password"}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.DeleteAccount(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+		accountSvc.AssertExpectations(t)
+	})
+
+	t.Run("service error -> internal server error", func(t *testing.T) {
+		accountSvc := new(mocks.AccountServiceMock)
+		h := makeAccountHandlers(accountSvc)
+
+		accountSvc.On("Delete", mock.Anything, int64(1), "password").Return(errors.New("db down"))
+
+		req := httptest.NewRequest(http.MethodDelete, "/account", strings.NewReader(`{"password":"password"}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.DeleteAccount(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+		accountSvc.AssertExpectations(t)
+	})
+}
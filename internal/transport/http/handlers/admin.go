@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// GetAdminUsers returns a page of users ordered by ID. Available to
+// administrators only.
+//
+// Query parameters (all optional):
+//
+//	limit – the maximum number of users, 50 by default and at most 500.
+//	offset – the number of users to skip.
+//
+// Status codes:
+//
+//	200 OK – the users were returned successfully.
+//	400 BadRequest – invalid query parameters.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the caller is not an administrator.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetAdminUsers(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var limit, offset int
+	var err error
+	if raw := q.Get("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil {
+			h.PublicError(w, http.StatusBadRequest, ErrBadQuery)
+			return
+		}
+	}
+	if raw := q.Get("offset"); raw != "" {
+		if offset, err = strconv.Atoi(raw); err != nil {
+			h.PublicError(w, http.StatusBadRequest, ErrBadQuery)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.adminService.ListUsers(ctx, limit, offset)
+	if err != nil {
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.AdminUsersResponse{
+		Users: make([]*dto.AdminUserRecord, 0, len(list)),
+	}
+	for _, u := range list {
+		respObj.Users = append(respObj.Users, &dto.AdminUserRecord{
+			ID:          u.ID,
+			Login:       u.Login,
+			IsAdmin:     u.IsAdmin,
+			DisabledAt:  u.DisabledAt,
+			LockedUntil: u.LockedUntil,
+			CreatedAt:   u.CreatedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// DisableUser prevents a user from logging in and ends all their sessions.
+// Available to administrators only.
+//
+// URL parameters:
+//
+//	user_id – the user ID.
+//
+// Status codes:
+//
+//	204 NoContent – the user was disabled.
+//	400 BadRequest – an administrator tried to disable their own account.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the caller is not an administrator.
+//	404 NotFound – user not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) DisableUser(w http.ResponseWriter, r *http.Request) {
+	h.changeUser(w, r, h.adminService.Disable)
+}
+
+// EnableUser allows a disabled user to log in again. Available to
+// administrators only.
+//
+// URL parameters:
+//
+//	user_id – the user ID.
+//
+// Status codes:
+//
+//	204 NoContent – the user was enabled.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the caller is not an administrator.
+//	404 NotFound – user not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) EnableUser(w http.ResponseWriter, r *http.Request) {
+	h.changeUser(w, r, func(ctx context.Context, _ int64, userID int64) error {
+		return h.adminService.Enable(ctx, userID)
+	})
+}
+
+// LogoutUser revokes every session of a user. Available to administrators only.
+//
+// URL parameters:
+//
+//	user_id – the user ID.
+//
+// Status codes:
+//
+//	204 NoContent – the sessions were revoked.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the caller is not an administrator.
+//	404 NotFound – user not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) LogoutUser(w http.ResponseWriter, r *http.Request) {
+	h.changeUser(w, r, func(ctx context.Context, _ int64, userID int64) error {
+		return h.adminService.ForceLogout(ctx, userID)
+	})
+}
+
+// ResetUserSecondFactor removes every device of a user and revokes their
+// sessions, so the device of the next login becomes the first trusted one.
+// Available to administrators only.
+//
+// URL parameters:
+//
+//	user_id – the user ID.
+//
+// Status codes:
+//
+//	200 OK – the devices were removed.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the caller is not an administrator.
+//	404 NotFound – user not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) ResetUserSecondFactor(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(chi.URLParam(r, "user_id"), 10, 64)
+	if err != nil {
+		h.PublicError(w, http.StatusNotFound, ErrNotFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	removed, err := h.adminService.ResetSecondFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			h.PublicError(w, http.StatusNotFound, ErrNotFound)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.AdminResetSecondFactorResponse{RemovedDevices: removed}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetUserStats returns how many entries, devices and tokens a user has and
+// the size of their encrypted entries. Available to administrators only.
+//
+// URL parameters:
+//
+//	user_id – the user ID.
+//
+// Status codes:
+//
+//	200 OK – the stats were returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the caller is not an administrator.
+//	404 NotFound – user not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(chi.URLParam(r, "user_id"), 10, 64)
+	if err != nil {
+		h.PublicError(w, http.StatusNotFound, ErrNotFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	stats, err := h.adminService.StorageStats(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			h.PublicError(w, http.StatusNotFound, ErrNotFound)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.AdminUserStatsResponse{
+		UserID:          stats.UserID,
+		Entries:         stats.Entries,
+		EntriesByType:   stats.EntriesByType,
+		DataBytes:       stats.DataBytes,
+		Devices:         stats.Devices,
+		PersonalTokens:  stats.PersonalTokens,
+		ServiceAccounts: stats.ServiceAccounts,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// changeUser applies an administrative change to the user taken from the URL
+// parameters on behalf of the calling administrator.
+func (h *Handlers) changeUser(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, adminID int64, userID int64) error) {
+	adminID, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	userID, err := strconv.ParseInt(chi.URLParam(r, "user_id"), 10, 64)
+	if err != nil {
+		h.PublicError(w, http.StatusNotFound, ErrNotFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := change(ctx, adminID, userID); err != nil {
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		if errors.Is(err, user.ErrUserNotFound) {
+			h.PublicError(w, http.StatusNotFound, ErrNotFound)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/admin"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func makeAdminHandlers(adminSvc *mocks.AdminServiceMock) *Handlers {
+	return &Handlers{
+		adminService: adminSvc,
+		logger:       zap.NewNop(),
+	}
+}
+
+func withUserParam(ctx context.Context, userID string) context.Context {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("user_id", userID)
+	return context.WithValue(ctx, chi.RouteCtxKey, rctx)
+}
+
+func TestHandlers_GetAdminUsers(t *testing.T) {
+	adminSvc := new(mocks.AdminServiceMock)
+	h := makeAdminHandlers(adminSvc)
+
+	disabledAt := time.Now()
+	adminSvc.On("ListUsers", mock.Anything, 10, 20).Return([]*admin.UserSummary{
+		{ID: 21, Login: "alice", IsAdmin: true, CreatedAt: time.Now()},
+		{ID: 22, Login: "bob", DisabledAt: &disabledAt, CreatedAt: time.Now()},
+	}, nil)
+	adminSvc.On("ListUsers", mock.Anything, 1000, 0).Return(nil, admin.ErrInvalidLimit)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users?limit=10&offset=20", nil)
+	req = req.WithContext(contextWithUserID(1))
+	rec := httptest.NewRecorder()
+	h.GetAdminUsers(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.AdminUsersResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp.Users, 2)
+	assert.True(t, resp.Users[0].IsAdmin)
+	assert.NotNil(t, resp.Users[1].DisabledAt)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/admin/users?limit=1000", nil)
+	req = req.WithContext(contextWithUserID(1))
+	rec = httptest.NewRecorder()
+	h.GetAdminUsers(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/admin/users?offset=x", nil)
+	req = req.WithContext(contextWithUserID(1))
+	rec = httptest.NewRecorder()
+	h.GetAdminUsers(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandlers_DisableUser(t *testing.T) {
+	adminSvc := new(mocks.AdminServiceMock)
+	h := makeAdminHandlers(adminSvc)
+
+	adminSvc.On("Disable", mock.Anything, int64(1), int64(21)).Return(nil)
+	adminSvc.On("Disable", mock.Anything, int64(1), int64(1)).Return(admin.ErrSelfDisable)
+	adminSvc.On("Disable", mock.Anything, int64(1), int64(404)).Return(user.ErrUserNotFound)
+
+	cases := []struct {
+		userID string
+		code   int
+	}{
+		{"21", http.StatusNoContent},
+		{"1", http.StatusBadRequest},
+		{"404", http.StatusNotFound},
+		{"abc", http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/users/"+tc.userID+"/disable", nil)
+		req = req.WithContext(withUserParam(contextWithUserID(1), tc.userID))
+		rec := httptest.NewRecorder()
+		h.DisableUser(rec, req)
+		assert.Equal(t, tc.code, rec.Code, tc.userID)
+	}
+}
+
+func TestHandlers_EnableUser_And_LogoutUser(t *testing.T) {
+	adminSvc := new(mocks.AdminServiceMock)
+	h := makeAdminHandlers(adminSvc)
+
+	adminSvc.On("Enable", mock.Anything, int64(21)).Return(nil)
+	adminSvc.On("ForceLogout", mock.Anything, int64(21)).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/21/enable", nil)
+	req = req.WithContext(withUserParam(contextWithUserID(1), "21"))
+	rec := httptest.NewRecorder()
+	h.EnableUser(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/admin/users/21/logout", nil)
+	req = req.WithContext(withUserParam(contextWithUserID(1), "21"))
+	rec = httptest.NewRecorder()
+	h.LogoutUser(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	adminSvc.AssertExpectations(t)
+}
+
+func TestHandlers_ResetUserSecondFactor(t *testing.T) {
+	adminSvc := new(mocks.AdminServiceMock)
+	h := makeAdminHandlers(adminSvc)
+
+	adminSvc.On("ResetSecondFactor", mock.Anything, int64(21)).Return(int64(2), nil)
+	adminSvc.On("ResetSecondFactor", mock.Anything, int64(404)).Return(int64(0), user.ErrUserNotFound)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/21/reset-2fa", nil)
+	req = req.WithContext(withUserParam(contextWithUserID(1), "21"))
+	rec := httptest.NewRecorder()
+	h.ResetUserSecondFactor(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.AdminResetSecondFactorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, int64(2), resp.RemovedDevices)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/admin/users/404/reset-2fa", nil)
+	req = req.WithContext(withUserParam(contextWithUserID(1), "404"))
+	rec = httptest.NewRecorder()
+	h.ResetUserSecondFactor(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHandlers_GetUserStats(t *testing.T) {
+	adminSvc := new(mocks.AdminServiceMock)
+	h := makeAdminHandlers(adminSvc)
+
+	adminSvc.On("StorageStats", mock.Anything, int64(21)).Return(&admin.StorageStats{
+		UserID:        21,
+		Entries:       3,
+		EntriesByType: map[string]int64{"text": 2, "card": 1},
+		DataBytes:     512,
+		Devices:       1,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/21/stats", nil)
+	req = req.WithContext(withUserParam(contextWithUserID(1), "21"))
+	rec := httptest.NewRecorder()
+	h.GetUserStats(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.AdminUserStatsResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, int64(3), resp.Entries)
+	assert.Equal(t, int64(2), resp.EntriesByType["text"])
+	assert.Equal(t, int64(512), resp.DataBytes)
+}
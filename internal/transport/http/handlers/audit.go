@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// GetAuditEvents returns the audit log of the user, newest first.
+//
+// Query parameters (all optional):
+//
+//	action – login, refresh, key.read, key.add, key.update, key.delete, key.share, key.unshare, send.create, send.view, emergency.request, emergency.takeover, recovery.setup, recovery.reveal or account.export.
+//	key – the UUID of an entry.
+//	result – success, denied or failure.
+//	since, until – RFC 3339 timestamps bounding the event time.
+//	limit – the maximum number of events, 100 by default and at most 1000.
+//
+// Status codes:
+//
+//	200 OK – the events were returned successfully.
+//	400 BadRequest – invalid query parameters.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	filter, err := parseAuditFilter(r.URL.Query())
+	if err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadQuery)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.auditService.List(ctx, userId, filter)
+	if err != nil {
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.AuditEventsResponse{
+		Events: make([]*dto.AuditEventRecord, 0, len(list)),
+	}
+	for _, e := range list {
+		rec := &dto.AuditEventRecord{
+			ID:        e.ID,
+			Actor:     e.Actor,
+			Action:    string(e.Action),
+			IP:        e.IP,
+			UserAgent: e.UserAgent,
+			Result:    string(e.Result),
+			CreatedAt: e.CreatedAt,
+			Hash:      e.Hash,
+		}
+		if e.KeyUUID != uuid.Nil {
+			keyUUID := e.KeyUUID
+			rec.KeyUUID = &keyUUID
+		}
+		respObj.Events = append(respObj.Events, rec)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// parseAuditFilter reads the query parameters of GetAuditEvents. Values are
+// only parsed here; their ranges are checked by the service.
+func parseAuditFilter(q url.Values) (audit.Filter, error) {
+	filter := audit.Filter{
+		Action: audit.Action(q.Get("action")),
+		Result: audit.Result(q.Get("result")),
+	}
+
+	if raw := q.Get("key"); raw != "" {
+		keyUUID, err := uuid.Parse(raw)
+		if err != nil {
+			return filter, err
+		}
+		filter.KeyUUID = keyUUID
+	}
+
+	for name, dst := range map[string]**time.Time{"since": &filter.Since, "until": &filter.Until} {
+		raw := q.Get(name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return filter, err
+		}
+		*dst = &t
+	}
+
+	if raw := q.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			return filter, err
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func makeAuditHandlers(auditSvc *mocks.AuditServiceMock) *Handlers {
+	return &Handlers{
+		auditService: auditSvc,
+		logger:       zap.NewNop(),
+	}
+}
+
+func TestHandlers_GetAuditEvents(t *testing.T) {
+	auditSvc := new(mocks.AuditServiceMock)
+	h := makeAuditHandlers(auditSvc)
+
+	keyUUID := uuid.New()
+	since := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	filter := audit.Filter{Action: audit.ActionKeyRead, KeyUUID: keyUUID, Since: &since, Limit: 10}
+	events := []*audit.Event{
+		{ID: 2, UserID: 1, Actor: "user", Action: audit.ActionKeyRead, KeyUUID: keyUUID, IP: "10.0.0.1", Result: audit.ResultSuccess, Hash: "ab"},
+	}
+	auditSvc.On("List", mock.Anything, int64(1), filter).Return(events, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/audit?action=key.read&key="+keyUUID.String()+"&since=2025-10-01T00:00:00Z&limit=10", nil)
+	req = req.WithContext(contextWithUserID(1))
+	rec := httptest.NewRecorder()
+
+	h.GetAuditEvents(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.AuditEventsResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp.Events, 1)
+	assert.Equal(t, "key.read", resp.Events[0].Action)
+	assert.Equal(t, keyUUID, *resp.Events[0].KeyUUID)
+	auditSvc.AssertExpectations(t)
+}
+
+func TestHandlers_GetAuditEvents_Bad_Query(t *testing.T) {
+	auditSvc := new(mocks.AuditServiceMock)
+	h := makeAuditHandlers(auditSvc)
+
+	for _, query := range []string{"key=nope", "since=yesterday", "limit=many"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/audit?"+query, nil)
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.GetAuditEvents(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+	auditSvc.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandlers_GetAuditEvents_Validation_Error(t *testing.T) {
+	auditSvc := new(mocks.AuditServiceMock)
+	h := makeAuditHandlers(auditSvc)
+
+	auditSvc.On("List", mock.Anything, int64(1), mock.Anything).Return(nil, audit.ErrInvalidAction)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/audit?action=nope", nil)
+	req = req.WithContext(contextWithUserID(1))
+	rec := httptest.NewRecorder()
+
+	h.GetAuditEvents(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
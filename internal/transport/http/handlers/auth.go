@@ -7,6 +7,7 @@ import (
 	"github.com/thxhix/passKeeper/internal/domain/token"
 	"github.com/thxhix/passKeeper/internal/domain/user"
 	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
 	"go.uber.org/zap"
 	"io"
 	"net/http"
@@ -18,14 +19,27 @@ import (
 //
 //	{
 //	  "login": "string",
-//	  "password": "string"
+//	  "password": "string",
+//	  "email": "string, optional – a verification token is mailed to it",
+//	  "invite": "string, required in invite-only mode",
+//	  "device": {"id": "uuid", "name": "string", "public_key": "base64 Ed25519 key", "nonce": "string", "signature": "base64"}
 //	}
 //
+// nonce is taken from POST /api/auth/device/challenge and signature is made
+// over it with the device key. The device becomes the first trusted device of
+// the account.
+//
+// In uniform response mode no tokens are returned and a taken login or email
+// is answered exactly like a new account, with 202; the client logs in next.
+//
 // Status codes:
 //
 //	201 Created – the user was registered successfully, tokens returned.
+//	202 Accepted – uniform response mode, the request was accepted.
 //	400 BadRequest – invalid JSON or validation error.
-//	409 Conflict – login already exists.
+//	401 Unauthorized – the device did not prove it holds its key.
+//	403 Forbidden – a valid invite code is required.
+//	409 Conflict – login or email already exists.
 //	500 InternalServerError – internal service error.
 func (h *Handlers) Register(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
@@ -43,10 +57,15 @@ func (h *Handlers) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userId, accessToken, refreshToken, err := h.authService.Register(r.Context(), reqObj.Login, reqObj.Password)
+	if h.uniformRegistration {
+		h.registerUniform(w, r, reqObj)
+		return
+	}
+
+	userId, accessToken, refreshToken, err := h.authService.Register(r.Context(), reqObj.Login, reqObj.Password, reqObj.Email, reqObj.Invite, mapDeviceIdentity(reqObj.Device))
 
 	if err != nil {
-		if errors.Is(err, user.ErrDuplicateLogin) {
+		if errors.Is(err, user.ErrDuplicateLogin) || errors.Is(err, user.ErrDuplicateEmail) {
 			h.PublicError(w, http.StatusConflict, err)
 			return
 		}
@@ -55,6 +74,16 @@ func (h *Handlers) Register(w http.ResponseWriter, r *http.Request) {
 			h.PublicError(w, http.StatusBadRequest, err)
 			return
 		}
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
+			return
+		}
+		var pe *apperr.AuthError
+		if errors.As(err, &pe) {
+			h.PublicError(w, http.StatusUnauthorized, err)
+			return
+		}
 
 		h.InternalError(w, err)
 		return
@@ -76,21 +105,70 @@ func (h *Handlers) Register(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// registerUniform creates the account without signing the user in and answers
+// 202 whether or not the login or email was already taken.
+func (h *Handlers) registerUniform(w http.ResponseWriter, r *http.Request, reqObj dto.RegisterRequest) {
+	err := h.authService.RegisterSilently(r.Context(), reqObj.Login, reqObj.Password, reqObj.Email, reqObj.Invite, mapDeviceIdentity(reqObj.Device))
+	if err != nil {
+		var ae *apperr.ValidationError
+		if errors.As(err, &ae) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
+			return
+		}
+		var pe *apperr.AuthError
+		if errors.As(err, &pe) {
+			h.PublicError(w, http.StatusUnauthorized, err)
+			return
+		}
+
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.RegisterAcceptedResponse{
+		Message: "registration accepted, log in to continue",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
 // Login handles user authentication.
 //
 // Body (JSON):
 //
 //	{
 //	  "login": "string",
-//	  "password": "string"
+//	  "password": "string",
+//	  "device": {"id": "uuid", "name": "string", "public_key": "base64 Ed25519 key", "nonce": "string", "signature": "base64", "approval_code": "optional"}
 //	}
 //
+// The device signs a nonce from POST /api/auth/device/challenge. A device that is not trusted yet gets 403 and a one-time approval code is
+// sent to the user. The login succeeds once the device is approved from a
+// trusted one or is repeated with the code.
+//
+// A locked account deliberately answers 401 like a wrong password, so the
+// lockout does not tell which logins exist.
+//
 // Status codes:
 //
 //	200 OK – login successful, tokens returned.
 //	400 BadRequest – invalid JSON or validation error.
-//	401 Unauthorized – authentication failed.
+//	401 Unauthorized – authentication failed, the account is locked, wrong approval code, device key or signature.
+//	403 Forbidden – the device must be approved first.
+//	429 TooManyRequests – too many attempts from this address, see Retry-After.
 //	500 InternalServerError – internal service error.
+//	503 ServiceUnavailable – too many password checks in progress, see Retry-After.
 func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
@@ -107,7 +185,7 @@ func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userId, accessToken, refreshToken, err := h.authService.Login(r.Context(), reqObj.Login, reqObj.Password)
+	userId, accessToken, refreshToken, err := h.authService.Login(r.Context(), reqObj.Login, reqObj.Password, mapDeviceIdentity(reqObj.Device))
 
 	if err != nil {
 		var ae *apperr.AuthError
@@ -116,6 +194,12 @@ func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
+			return
+		}
+
 		var ve *apperr.ValidationError
 		if errors.As(err, &ve) {
 			h.PublicError(w, http.StatusBadRequest, err)
@@ -202,3 +286,112 @@ func (h *Handlers) Refresh(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// ChangePassword replaces the password of the authenticated user.
+//
+// Body (JSON):
+//
+//	{
+//	  "current_password": "string",
+//	  "new_password": "string",
+//	  "key_material": "base64 (optional)",
+//	  "device": {"id": "uuid", "name": "string", "public_key": "base64 Ed25519 key", "nonce": "string", "signature": "base64", "approval_code": "optional"}
+//	}
+//
+// All other sessions of the user are revoked and a new token pair bound to
+// the device is returned. A wrong current password counts as a failed login,
+// and a locked account deliberately answers 401 like a wrong password.
+//
+// Status codes:
+//
+//	200 OK – password changed, new tokens returned.
+//	400 BadRequest – invalid JSON or validation error.
+//	401 Unauthorized – user is not authenticated, current password is wrong, the account is locked or the device proof failed.
+//	403 Forbidden – the device must be approved first.
+//	500 InternalServerError – internal service error.
+//	503 ServiceUnavailable – too many password checks in progress, see Retry-After.
+func (h *Handlers) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.ChangePasswordRequest{}
+	err = easyjson.Unmarshal(body, &reqObj)
+	if err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	accessToken, refreshToken, err := h.authService.ChangePassword(r.Context(), userId, reqObj.CurrentPassword, reqObj.NewPassword, reqObj.KeyMaterial, mapDeviceIdentity(reqObj.Device))
+	if err != nil {
+		var ae *apperr.AuthError
+		if errors.As(err, &ae) {
+			h.PublicError(w, http.StatusUnauthorized, err)
+			return
+		}
+
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
+			return
+		}
+
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.RefreshedTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// Logout terminates every session of the authenticated user.
+//
+// All refresh tokens are revoked and access tokens issued so far stop being
+// accepted immediately, including the one used for this request.
+//
+// Status codes:
+//
+//	204 NoContent – all sessions terminated.
+//	401 Unauthorized – user is not authenticated.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) Logout(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	if err := h.authService.Logout(r.Context(), userId); err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
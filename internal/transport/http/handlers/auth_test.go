@@ -1,13 +1,19 @@
 package handlers
 
 import (
+	"encoding/json"
 	"errors"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/device"
+	"github.com/thxhix/passKeeper/internal/domain/invite"
 	"github.com/thxhix/passKeeper/internal/domain/user"
 	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/security"
 	"github.com/thxhix/passKeeper/internal/services"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
 	"go.uber.org/zap"
 	"io"
 	"net/http"
@@ -33,7 +39,7 @@ func TestHandlers_Register(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(body))
 		rec := httptest.NewRecorder()
 
-		authSvc.On("Register", mock.Anything, "user1", "pass1").Return(int64(1), "access-token", "refresh-token", nil)
+		authSvc.On("Register", mock.Anything, "user1", "pass1", "", "", mock.Anything).Return(int64(1), "access-token", "refresh-token", nil)
 
 		h.Register(rec, req)
 
@@ -77,7 +83,7 @@ func TestHandlers_Register(t *testing.T) {
 		rec := httptest.NewRecorder()
 
 		// service returns domain error DuplicateLogin
-		authSvc.On("Register", mock.Anything, "dup", "pass").
+		authSvc.On("Register", mock.Anything, "dup", "pass", "", "", mock.Anything).
 			Return(int64(0), "", "", user.ErrDuplicateLogin)
 
 		h.Register(rec, req)
@@ -99,7 +105,7 @@ func TestHandlers_Register(t *testing.T) {
 
 		// return a ValidationError (apperr.ValidationError) from service
 		ve := &apperr.ValidationError{Message: "invalid"}
-		authSvc.On("Register", mock.Anything, "bad", "p").Return(int64(0), "", "", ve)
+		authSvc.On("Register", mock.Anything, "bad", "p", "", "", mock.Anything).Return(int64(0), "", "", ve)
 
 		h.Register(rec, req)
 
@@ -109,6 +115,71 @@ func TestHandlers_Register(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
 		authSvc.AssertExpectations(t)
 	})
+
+	t.Run("invalid invite -> forbidden", func(t *testing.T) {
+		authSvc := new(mocks.AuthServiceMock)
+		h := makeHandlers(authSvc)
+
+		body := `{"login":"user1","password":"pass1","invite":"pki_wrong"}`
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		authSvc.On("Register", mock.Anything, "user1", "pass1", "", "pki_wrong", mock.Anything).
+			Return(int64(0), "", "", invite.ErrInvalidInvite)
+
+		h.Register(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		authSvc.AssertExpectations(t)
+	})
+}
+
+func TestHandlers_Register_Uniform(t *testing.T) {
+	t.Run("new and taken logins get the same answer", func(t *testing.T) {
+		authSvc := new(mocks.AuthServiceMock)
+		h := makeHandlers(authSvc)
+		h.uniformRegistration = true
+
+		// the service reports a taken login as success in this mode
+		authSvc.On("RegisterSilently", mock.Anything, mock.Anything, "pass1234", "", "", mock.Anything).Return(nil)
+
+		var bodies []string
+		for _, login := range []string{"fresh", "taken"} {
+			req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(`{"login":"`+login+`","password":"pass1234"}`))
+			rec := httptest.NewRecorder()
+
+			h.Register(rec, req)
+
+			res := rec.Result()
+			b, _ := io.ReadAll(res.Body)
+			res.Body.Close()
+
+			assert.Equal(t, http.StatusAccepted, res.StatusCode)
+			bodies = append(bodies, string(b))
+		}
+
+		assert.Equal(t, bodies[0], bodies[1])
+		assert.NotContains(t, bodies[0], "access_token")
+		authSvc.AssertNotCalled(t, "Register", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("validation error -> bad request", func(t *testing.T) {
+		authSvc := new(mocks.AuthServiceMock)
+		h := makeHandlers(authSvc)
+		h.uniformRegistration = true
+
+		authSvc.On("RegisterSilently", mock.Anything, "u", "p", "", "", mock.Anything).Return(&apperr.ValidationError{Message: "invalid"})
+
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(`{"login":"u","password":"p"}`))
+		rec := httptest.NewRecorder()
+
+		h.Register(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
 }
 
 func TestHandlers_Login(t *testing.T) {
@@ -120,7 +191,7 @@ func TestHandlers_Login(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body))
 		rec := httptest.NewRecorder()
 
-		authSvc.On("Login", mock.Anything, "user", "pass").
+		authSvc.On("Login", mock.Anything, "user", "pass", mock.Anything).
 			Return(int64(10), "access", "refresh", nil)
 
 		h.Login(rec, req)
@@ -161,7 +232,46 @@ func TestHandlers_Login(t *testing.T) {
 
 		// service returns apperr.AuthError (or any error that satisfies errors.As to apperr.AuthError)
 		authErr := &apperr.AuthError{Message: "invalid"}
-		authSvc.On("Login", mock.Anything, "wrong", "pwd").Return(int64(0), "", "", authErr)
+		authSvc.On("Login", mock.Anything, "wrong", "pwd", mock.Anything).Return(int64(0), "", "", authErr)
+
+		h.Login(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+		authSvc.AssertExpectations(t)
+	})
+
+	t.Run("hasher saturated -> service unavailable", func(t *testing.T) {
+		authSvc := new(mocks.AuthServiceMock)
+		h := makeHandlers(authSvc)
+
+		body := `{"login":"busy","password":"pwd"}`
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		authSvc.On("Login", mock.Anything, "busy", "pwd", mock.Anything).Return(int64(0), "", "", security.ErrHasherBusy)
+
+		h.Login(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		assert.Equal(t, "1", res.Header.Get("Retry-After"))
+
+		authSvc.AssertExpectations(t)
+	})
+
+	t.Run("wrong password -> unauthorized", func(t *testing.T) {
+		authSvc := new(mocks.AuthServiceMock)
+		h := makeHandlers(authSvc)
+
+		body := `{"login":"user","password":"wrong"}`
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		authSvc.On("Login", mock.Anything, "user", "wrong", mock.Anything).Return(int64(0), "", "", user.ErrInvalidCredentials)
 
 		h.Login(rec, req)
 
@@ -181,7 +291,7 @@ func TestHandlers_Login(t *testing.T) {
 		rec := httptest.NewRecorder()
 
 		ve := &apperr.ValidationError{Message: "invalid"}
-		authSvc.On("Login", mock.Anything, "bad", "p").Return(int64(0), "", "", ve)
+		authSvc.On("Login", mock.Anything, "bad", "p", mock.Anything).Return(int64(0), "", "", ve)
 
 		h.Login(rec, req)
 
@@ -191,6 +301,27 @@ func TestHandlers_Login(t *testing.T) {
 
 		authSvc.AssertExpectations(t)
 	})
+
+	t.Run("unapproved device -> forbidden", func(t *testing.T) {
+		authSvc := new(mocks.AuthServiceMock)
+		h := makeHandlers(authSvc)
+
+		deviceID := uuid.New()
+		body := `{"login":"user","password":"pass","device":{"id":"` + deviceID.String() + `","name":"laptop","public_key":"key","approval_code":"123456"}}`
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		ident := device.Identity{ID: deviceID, Name: "laptop", PublicKey: "key", ApprovalCode: "123456"}
+		authSvc.On("Login", mock.Anything, "user", "pass", ident).Return(int64(0), "", "", device.ErrApprovalRequired)
+
+		h.Login(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+		authSvc.AssertExpectations(t)
+	})
 }
 
 func TestHandlers_Refresh(t *testing.T) {
@@ -295,3 +426,132 @@ func TestHandlers_Refresh(t *testing.T) {
 		authSvc.AssertExpectations(t)
 	})
 }
+
+func TestHandlers_ChangePassword(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		authSvc := new(mocks.AuthServiceMock)
+		h := makeHandlers(authSvc)
+
+		body := `{"current_password":"old-password","new_password":"new-password"}`
+		req := httptest.NewRequest(http.MethodPost, "/password", strings.NewReader(body))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		authSvc.On("ChangePassword", mock.Anything, int64(1), "old-password", "new-password", []byte(nil), mock.Anything).
+			Return("new-access", "new-refresh", nil)
+
+		h.ChangePassword(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "no-store", res.Header.Get("Cache-Control"))
+
+		b, _ := io.ReadAll(res.Body)
+		assert.JSONEq(t, `{"access_token":"new-access","refresh_token":"new-refresh"}`, string(b))
+
+		authSvc.AssertExpectations(t)
+	})
+
+	t.Run("no user in context -> unauthorized", func(t *testing.T) {
+		authSvc := new(mocks.AuthServiceMock)
+		h := makeHandlers(authSvc)
+
+		body := `{"current_password":"old-password","new_password":"new-password"}`
+		req := httptest.NewRequest(http.MethodPost, "/password", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		h.ChangePassword(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("wrong current password -> unauthorized", func(t *testing.T) {
+		authSvc := new(mocks.AuthServiceMock)
+		h := makeHandlers(authSvc)
+
+		body := `{"current_password":"wrong","new_password":"new-password"}`
+		req := httptest.NewRequest(http.MethodPost, "/password", strings.NewReader(body))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		authSvc.On("ChangePassword", mock.Anything, int64(1), "wrong", "new-password", []byte(nil), mock.Anything).
+			Return("", "", user.ErrInvalidCredentials)
+
+		h.ChangePassword(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		authSvc.AssertExpectations(t)
+	})
+
+	t.Run("validation error -> bad request", func(t *testing.T) {
+		authSvc := new(mocks.AuthServiceMock)
+		h := makeHandlers(authSvc)
+
+		body := `{"current_password":"old-password","new_password":"short"}`
+		req := httptest.NewRequest(http.MethodPost, "/password", strings.NewReader(body))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		authSvc.On("ChangePassword", mock.Anything, int64(1), "old-password", "short", []byte(nil), mock.Anything).
+			Return("", "", user.PasswordPolicy{MinLength: 8}.Check("login", "short"))
+
+		h.ChangePassword(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+		var errResp dto.ErrorResponse
+		assert.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+		if assert.Len(t, errResp.Violations, 1) {
+			assert.Equal(t, user.RuleMinLength, errResp.Violations[0].Rule)
+		}
+		authSvc.AssertExpectations(t)
+	})
+}
+
+func TestHandlers_Logout(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		authSvc := new(mocks.AuthServiceMock)
+		h := makeHandlers(authSvc)
+
+		req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		authSvc.On("Logout", mock.Anything, int64(1)).Return(nil)
+
+		h.Logout(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+		authSvc.AssertExpectations(t)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		authSvc := new(mocks.AuthServiceMock)
+		h := makeHandlers(authSvc)
+
+		req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+		rec := httptest.NewRecorder()
+
+		h.Logout(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		authSvc.AssertNotCalled(t, "Logout", mock.Anything, mock.Anything)
+	})
+}
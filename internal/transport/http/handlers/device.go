@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/device"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DeviceChallenge issues the single-use nonce a device signs to register or
+// log in.
+//
+// Body (JSON):
+//
+//	{"device_id": "uuid"}
+//
+// The nonce is valid for a short time and replaces any earlier one of the
+// device.
+//
+// Status codes:
+//
+//	200 OK – the challenge was issued.
+//	400 BadRequest – invalid JSON or device ID.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) DeviceChallenge(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.DeviceChallengeRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	nonce, expiresAt, err := h.deviceService.Challenge(ctx, reqObj.DeviceID)
+	if err != nil {
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.DeviceChallengeResponse{
+		Nonce:     nonce,
+		ExpiresAt: expiresAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetDevices returns the trusted and pending devices of the user.
+//
+// Status codes:
+//
+//	200 OK – the device list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetDevices(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.deviceService.List(ctx, userId)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.DevicesResponse{
+		Devices: make([]*dto.DeviceRecord, 0, len(list)),
+	}
+	for _, d := range list {
+		respObj.Devices = append(respObj.Devices, &dto.DeviceRecord{
+			ID:          d.DeviceUUID,
+			Name:        d.Name,
+			Fingerprint: device.Fingerprint(d.PublicKey),
+			Trusted:     d.Trusted,
+			LastSeenAt:  d.LastSeenAt,
+			CreatedAt:   d.CreatedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// ApproveDevice trusts a pending device of the user, so its next login
+// receives tokens.
+//
+// URL parameters:
+//
+//	device_id – the device UUID.
+//
+// Status codes:
+//
+//	204 NoContent – the device was approved.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – no such pending device.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) ApproveDevice(w http.ResponseWriter, r *http.Request) {
+	h.changeDevice(w, r, h.deviceService.Approve)
+}
+
+// RemoveDevice deletes a device of the user and its refresh tokens.
+//
+// URL parameters:
+//
+//	device_id – the device UUID.
+//
+// Status codes:
+//
+//	204 NoContent – the device was removed.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – device not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) RemoveDevice(w http.ResponseWriter, r *http.Request) {
+	h.changeDevice(w, r, h.deviceService.Remove)
+}
+
+// changeDevice applies a change to the device taken from the URL parameters.
+func (h *Handlers) changeDevice(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, userID int64, deviceUUID string) error) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := change(ctx, userId, chi.URLParam(r, "device_id")); err != nil {
+		if errors.Is(err, device.ErrNotFound) {
+			h.PublicError(w, http.StatusNotFound, ErrNotFound)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// mapDeviceIdentity converts the device sent on registration or login.
+func mapDeviceIdentity(d dto.DeviceInfo) device.Identity {
+	return device.Identity{
+		ID:           d.ID,
+		Name:         d.Name,
+		PublicKey:    d.PublicKey,
+		Nonce:        d.Nonce,
+		Signature:    d.Signature,
+		ApprovalCode: d.ApprovalCode,
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/device"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func makeDeviceHandlers(deviceSvc *mocks.DeviceServiceMock) *Handlers {
+	return &Handlers{
+		deviceService: deviceSvc,
+		logger:        zap.NewNop(),
+	}
+}
+
+func withDeviceParam(ctx context.Context, deviceID string) context.Context {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("device_id", deviceID)
+	return context.WithValue(ctx, chi.RouteCtxKey, rctx)
+}
+
+func TestHandlers_DeviceChallenge(t *testing.T) {
+	deviceSvc := new(mocks.DeviceServiceMock)
+	h := makeDeviceHandlers(deviceSvc)
+
+	deviceID := uuid.New().String()
+	expiresAt := time.Date(2025, 11, 7, 9, 2, 0, 0, time.UTC)
+	deviceSvc.On("Challenge", mock.Anything, deviceID).Return("nonce", expiresAt, nil)
+	deviceSvc.On("Challenge", mock.Anything, "bad").Return("", time.Time{}, device.ErrIDEmpty)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/device/challenge", strings.NewReader(`{"device_id":"`+deviceID+`"}`))
+	rec := httptest.NewRecorder()
+	h.DeviceChallenge(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.DeviceChallengeResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "nonce", resp.Nonce)
+	assert.True(t, expiresAt.Equal(resp.ExpiresAt))
+
+	req = httptest.NewRequest(http.MethodPost, "/api/auth/device/challenge", strings.NewReader(`{"device_id":"bad"}`))
+	rec = httptest.NewRecorder()
+	h.DeviceChallenge(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandlers_GetDevices(t *testing.T) {
+	deviceSvc := new(mocks.DeviceServiceMock)
+	h := makeDeviceHandlers(deviceSvc)
+
+	d := &device.Device{DeviceUUID: uuid.New(), Name: "laptop", PublicKey: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=", Trusted: true, CreatedAt: time.Now()}
+	deviceSvc.On("List", mock.Anything, int64(1)).Return([]*device.Device{d}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/devices", nil)
+	req = req.WithContext(contextWithUserID(1))
+	rec := httptest.NewRecorder()
+
+	h.GetDevices(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.DevicesResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp.Devices, 1)
+	assert.Equal(t, d.DeviceUUID, resp.Devices[0].ID)
+	assert.Equal(t, device.Fingerprint(d.PublicKey), resp.Devices[0].Fingerprint)
+	assert.True(t, resp.Devices[0].Trusted)
+}
+
+func TestHandlers_ApproveDevice(t *testing.T) {
+	deviceSvc := new(mocks.DeviceServiceMock)
+	h := makeDeviceHandlers(deviceSvc)
+
+	deviceID := uuid.New().String()
+	deviceSvc.On("Approve", mock.Anything, int64(1), deviceID).Return(nil).Once()
+	deviceSvc.On("Approve", mock.Anything, int64(1), deviceID).Return(device.ErrNotFound).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/devices/"+deviceID+"/approve", nil)
+	req = req.WithContext(withDeviceParam(contextWithUserID(1), deviceID))
+
+	rec := httptest.NewRecorder()
+	h.ApproveDevice(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ApproveDevice(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHandlers_RemoveDevice(t *testing.T) {
+	deviceSvc := new(mocks.DeviceServiceMock)
+	h := makeDeviceHandlers(deviceSvc)
+
+	deviceID := uuid.New().String()
+	deviceSvc.On("Remove", mock.Anything, int64(1), deviceID).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/devices/"+deviceID, nil)
+	req = req.WithContext(withDeviceParam(contextWithUserID(1), deviceID))
+	rec := httptest.NewRecorder()
+
+	h.RemoveDevice(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	deviceSvc.AssertExpectations(t)
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ChangeEmail sets a new email address for the user and mails a verification
+// token to it. The address stays unverified until the token is used.
+//
+// Body (JSON):
+//
+//	{
+//	  "email": "string"
+//	}
+//
+// Status codes:
+//
+//	204 NoContent – the email was changed and the verification mail sent.
+//	400 BadRequest – invalid JSON or email.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	409 Conflict – the email is used by another account.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.ChangeEmailRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := h.emailService.ChangeEmail(ctx, userId, reqObj.Email); err != nil {
+		if errors.Is(err, user.ErrDuplicateEmail) {
+			h.PublicError(w, http.StatusConflict, err)
+			return
+		}
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// VerifyEmail marks the email address a verification token was mailed to as
+// verified.
+//
+// Body (JSON):
+//
+//	{
+//	  "token": "string"
+//	}
+//
+// Status codes:
+//
+//	204 NoContent – the email was verified.
+//	400 BadRequest – invalid JSON or an invalid, expired or outdated token.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.VerifyEmailRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.emailService.VerifyEmail(ctx, reqObj.Token); err != nil {
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ForgotPassword mails a password reset token to a verified email address.
+//
+// The response is the same whether or not the address belongs to an account.
+//
+// Body (JSON):
+//
+//	{
+//	  "email": "string"
+//	}
+//
+// Status codes:
+//
+//	202 Accepted – a reset token was mailed if the address is registered and verified.
+//	400 BadRequest – invalid JSON or email.
+//	429 TooManyRequests – too many attempts, see Retry-After.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.ForgotPasswordRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := h.emailService.RequestPasswordReset(ctx, reqObj.Email); err != nil {
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ResetPassword sets a new password with a mailed reset token and ends every
+// session of the user.
+//
+// A zero-knowledge vault key cannot be re-wrapped without the old password.
+// For such accounts "discard_vault_key" must be true to confirm that the
+// stored secrets become unreadable; without it the request is rejected with
+// 409 and nothing changes.
+//
+// Body (JSON):
+//
+//	{
+//	  "token": "string",
+//	  "new_password": "string",
+//	  "discard_vault_key": false
+//	}
+//
+// Status codes:
+//
+//	200 OK – the password was reset, the response tells whether a vault key was discarded.
+//	400 BadRequest – invalid JSON, an invalid, expired or used token, or a weak password.
+//	403 Forbidden – the account is disabled.
+//	409 Conflict – the reset would discard the vault key and was not confirmed.
+//	429 TooManyRequests – too many attempts, see Retry-After.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.ResetPasswordRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	discarded, err := h.emailService.ResetPassword(ctx, reqObj.Token, reqObj.NewPassword, reqObj.DiscardVaultKey)
+	if err != nil {
+		if errors.Is(err, user.ErrVaultKeyWillBeLost) {
+			h.PublicError(w, http.StatusConflict, err)
+			return
+		}
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.ResetPasswordResponse{VaultKeyDiscarded: discarded}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
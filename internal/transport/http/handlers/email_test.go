@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func makeEmailHandlers(emailSvc *mocks.EmailServiceMock) *Handlers {
+	return &Handlers{
+		emailService: emailSvc,
+		logger:       zap.NewNop(),
+	}
+}
+
+func TestHandlers_ChangeEmail(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"invalid email", user.ErrInvalidEmail, http.StatusBadRequest},
+		{"duplicate email", user.ErrDuplicateEmail, http.StatusConflict},
+		{"internal", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			emailSvc := new(mocks.EmailServiceMock)
+			h := makeEmailHandlers(emailSvc)
+
+			emailSvc.On("ChangeEmail", mock.Anything, int64(1), "alice@example.com").Return(tc.err)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/account/email", strings.NewReader(`{"email":"alice@example.com"}`))
+			req = req.WithContext(contextWithUserID(1))
+			rec := httptest.NewRecorder()
+
+			h.ChangeEmail(rec, req)
+
+			assert.Equal(t, tc.code, rec.Code)
+			emailSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandlers_VerifyEmail(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"invalid token", user.ErrInvalidEmailToken, http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			emailSvc := new(mocks.EmailServiceMock)
+			h := makeEmailHandlers(emailSvc)
+
+			emailSvc.On("VerifyEmail", mock.Anything, "tkn").Return(tc.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/email/verify", strings.NewReader(`{"token":"tkn"}`))
+			rec := httptest.NewRecorder()
+
+			h.VerifyEmail(rec, req)
+
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+}
+
+func TestHandlers_ForgotPassword(t *testing.T) {
+	emailSvc := new(mocks.EmailServiceMock)
+	h := makeEmailHandlers(emailSvc)
+
+	emailSvc.On("RequestPasswordReset", mock.Anything, "nobody@example.com").Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/password/forgot", strings.NewReader(`{"email":"nobody@example.com"}`))
+	rec := httptest.NewRecorder()
+
+	h.ForgotPassword(rec, req)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	emailSvc.AssertExpectations(t)
+}
+
+func TestHandlers_ResetPassword(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		emailSvc := new(mocks.EmailServiceMock)
+		h := makeEmailHandlers(emailSvc)
+
+		emailSvc.On("ResetPassword", mock.Anything, "tkn", "new-password", true).Return(true, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/password/reset", strings.NewReader(`{"token":"tkn","new_password":"new-password","discard_vault_key":true}`))
+		rec := httptest.NewRecorder()
+
+		h.ResetPassword(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp dto.ResetPasswordResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.True(t, resp.VaultKeyDiscarded)
+	})
+
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"vault key not confirmed", user.ErrVaultKeyWillBeLost, http.StatusConflict},
+		{"invalid token", user.ErrInvalidEmailToken, http.StatusBadRequest},
+		{"disabled", user.ErrAccountDisabled, http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			emailSvc := new(mocks.EmailServiceMock)
+			h := makeEmailHandlers(emailSvc)
+
+			emailSvc.On("ResetPassword", mock.Anything, "tkn", "new-password", false).Return(false, tc.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/password/reset", strings.NewReader(`{"token":"tkn","new_password":"new-password"}`))
+			rec := httptest.NewRecorder()
+
+			h.ResetPassword(rec, req)
+
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+}
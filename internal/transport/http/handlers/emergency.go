@@ -0,0 +1,488 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/emergency"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+// NominateEmergencyContact names a user as emergency contact of the user, or
+// changes the access and waiting period of an existing contact.
+//
+// Body (JSON):
+//
+//	{
+//	  "login": "string",
+//	  "access": "view" | "takeover",
+//	  "wait_seconds": 604800 – optional, defaults to 7 days
+//	}
+//
+// Status codes:
+//
+//	201 Created – the contact was saved.
+//	400 BadRequest – invalid JSON, access or waiting period, the user's own login, or too many contacts.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	404 NotFound – there is no user with that login.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) NominateEmergencyContact(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.NominateEmergencyContactRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.logger.Error(ErrBadRequest.Error(), zap.Error(err))
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	wait := time.Duration(reqObj.WaitSeconds) * time.Second
+	c, err := h.emergencyService.Nominate(ctx, userId, reqObj.Login, reqObj.Access, wait)
+	if err != nil {
+		h.emergencyError(w, err)
+		return
+	}
+
+	h.writeContact(w, http.StatusCreated, c)
+}
+
+// GetEmergencyContacts returns the emergency contacts named by the user.
+//
+// Status codes:
+//
+//	200 OK – the contact list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetEmergencyContacts(w http.ResponseWriter, r *http.Request) {
+	h.listContacts(w, r, h.emergencyService.Contacts)
+}
+
+// GetEmergencyGrantors returns the contacts the user was named as, i.e. the
+// accounts the user can request emergency access to.
+//
+// Status codes:
+//
+//	200 OK – the contact list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetEmergencyGrantors(w http.ResponseWriter, r *http.Request) {
+	h.listContacts(w, r, h.emergencyService.Grantors)
+}
+
+// ApproveEmergencyAccess grants a pending request of an emergency contact of
+// the user before its waiting period has passed.
+//
+// URL parameters:
+//
+//	uuid – the contact UUID.
+//
+// Status codes:
+//
+//	200 OK – the request was approved.
+//	400 BadRequest – there is no pending request.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the user is not the grantor, or the request was authenticated with a personal access token.
+//	404 NotFound – contact not found.
+//	409 Conflict – the contact changed concurrently.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) ApproveEmergencyAccess(w http.ResponseWriter, r *http.Request) {
+	h.updateContact(w, r, h.emergencyService.Approve)
+}
+
+// RejectEmergencyAccess rejects a pending request of an emergency contact of
+// the user, or withdraws access that was already granted.
+//
+// URL parameters:
+//
+//	uuid – the contact UUID.
+//
+// Status codes:
+//
+//	200 OK – the request was rejected.
+//	400 BadRequest – there is no pending or granted request.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the user is not the grantor, or the request was authenticated with a personal access token.
+//	404 NotFound – contact not found.
+//	409 Conflict – the contact changed concurrently.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) RejectEmergencyAccess(w http.ResponseWriter, r *http.Request) {
+	h.updateContact(w, r, h.emergencyService.Reject)
+}
+
+// RequestEmergencyAccess starts the waiting period after which the user gets
+// access to the account that named them as emergency contact. The grantor is
+// notified and can reject the request until then.
+//
+// URL parameters:
+//
+//	uuid – the contact UUID.
+//
+// Status codes:
+//
+//	200 OK – access was requested, "granted_at" tells when it is granted.
+//	400 BadRequest – access was already requested.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the user is not the emergency contact, or the request was authenticated with a personal access token.
+//	404 NotFound – contact not found.
+//	409 Conflict – the contact changed concurrently.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) RequestEmergencyAccess(w http.ResponseWriter, r *http.Request) {
+	h.updateContact(w, r, h.emergencyService.Request)
+}
+
+// RemoveEmergencyContact deletes an emergency contact. The grantor removes
+// the contact, the grantee steps down as one.
+//
+// URL parameters:
+//
+//	uuid – the contact UUID.
+//
+// Status codes:
+//
+//	204 NoContent – the contact was removed.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	404 NotFound – contact not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) RemoveEmergencyContact(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.emergencyService.Remove(ctx, userId, chi.URLParam(r, "uuid")); err != nil {
+		h.emergencyError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetEmergencyKeys returns the personal entries of the account that named the
+// user as emergency contact, once access has been granted.
+//
+// URL parameters:
+//
+//	uuid – the contact UUID.
+//
+// Query parameters:
+//
+//	type (optional) – only entries of this type.
+//
+// Status codes:
+//
+//	200 OK – the key list was returned successfully.
+//	400 BadRequest – invalid type.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – access has not been granted yet, or the user is not the emergency contact.
+//	404 NotFound – contact not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetEmergencyKeys(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	var typePtr *keychain.KeyType
+	if raw := r.URL.Query().Get("type"); raw != "" {
+		if t, ok := keychain.ParseKeyType(raw); ok {
+			typePtr = &t
+		} else {
+			h.PublicError(w, http.StatusBadRequest, ErrBadQuery)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.emergencyService.Keys(ctx, userId, chi.URLParam(r, "uuid"), typePtr)
+	if err != nil {
+		h.emergencyError(w, err)
+		return
+	}
+
+	respObj := dto.GetKeysResponse{}
+	for _, record := range list {
+		respObj.Keys = append(respObj.Keys, &dto.GetKeysRecord{
+			KeyUUID:   record.KeyUUID,
+			KeyType:   record.KeyType,
+			Title:     record.Title,
+			Tags:      record.Tags,
+			CreatedAt: record.CreatedAt,
+			UpdatedAt: record.UpdatedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetEmergencyKey returns a personal entry of the account that named the user
+// as emergency contact, once access has been granted. The read is recorded
+// in the audit log of the grantor.
+//
+// URL parameters:
+//
+//	uuid – the contact UUID.
+//	key_uuid – the key UUID.
+//
+// Status codes:
+//
+//	200 OK – the key was found and returned.
+//	400 BadRequest – invalid key UUID.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – access has not been granted yet, or the user is not the emergency contact.
+//	404 NotFound – contact or key not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetEmergencyKey(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	keyUUID := chi.URLParam(r, "key_uuid")
+	if _, err := uuid.Parse(keyUUID); err != nil {
+		h.logger.Error(ErrBadRequest.Error(), zap.Error(err))
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	rec, plain, err := h.emergencyService.Key(ctx, userId, chi.URLParam(r, "uuid"), keyUUID)
+	if err != nil {
+		h.emergencyError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	h.writeKey(w, rec, plain)
+}
+
+// EmergencyTakeover sets a new password for the account that named the user
+// as emergency contact with takeover access, once access has been granted.
+// Every session of the account is ended.
+//
+// As on a password reset, a zero-knowledge vault key of the account cannot
+// be kept; "discard_vault_key" must be true to confirm that the stored secrets
+// become unreadable, otherwise the request is rejected with 409.
+//
+// URL parameters:
+//
+//	uuid – the contact UUID.
+//
+// Body (JSON):
+//
+//	{
+//	  "new_password": "string",
+//	  "discard_vault_key": false
+//	}
+//
+// Status codes:
+//
+//	200 OK – the password was set, the response tells whether a vault key was discarded.
+//	400 BadRequest – invalid JSON or a weak password.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – access has not been granted, is view-only, or the account is disabled.
+//	404 NotFound – contact not found.
+//	409 Conflict – the takeover would discard the vault key and was not confirmed.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) EmergencyTakeover(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.EmergencyTakeoverRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	discarded, err := h.emergencyService.Takeover(ctx, userId, chi.URLParam(r, "uuid"), reqObj.NewPassword, reqObj.DiscardVaultKey)
+	if err != nil {
+		if errors.Is(err, user.ErrVaultKeyWillBeLost) {
+			h.PublicError(w, http.StatusConflict, err)
+			return
+		}
+		h.emergencyError(w, err)
+		return
+	}
+
+	respObj := dto.ResetPasswordResponse{VaultKeyDiscarded: discarded}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// listContacts writes the contacts returned by list for the user.
+func (h *Handlers) listContacts(w http.ResponseWriter, r *http.Request, list func(ctx context.Context, userID int64) ([]*emergency.Contact, error)) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	contacts, err := list(ctx, userId)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	now := time.Now()
+	respObj := dto.EmergencyContactsResponse{
+		Contacts: make([]*dto.EmergencyContactRecord, 0, len(contacts)),
+	}
+	for _, c := range contacts {
+		mapped := mapContact(c, now)
+		respObj.Contacts = append(respObj.Contacts, &mapped)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// updateContact applies update to the contact in the uuid URL parameter and
+// writes the updated contact.
+func (h *Handlers) updateContact(w http.ResponseWriter, r *http.Request, update func(ctx context.Context, userID int64, contactUUID string) (*emergency.Contact, error)) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	c, err := update(ctx, userId, chi.URLParam(r, "uuid"))
+	if err != nil {
+		h.emergencyError(w, err)
+		return
+	}
+
+	h.writeContact(w, http.StatusOK, c)
+}
+
+// writeContact writes a contact with the given status code.
+func (h *Handlers) writeContact(w http.ResponseWriter, code int, c *emergency.Contact) {
+	respObj := mapContact(c, time.Now())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// emergencyError writes the response for an error of the emergency service.
+func (h *Handlers) emergencyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, emergency.ErrNotFound), errors.Is(err, user.ErrUserNotFound):
+		h.PublicError(w, http.StatusNotFound, err)
+		return
+	case errors.Is(err, sql.ErrNoRows):
+		h.PublicError(w, http.StatusNotFound, ErrNotFound)
+		return
+	case errors.Is(err, emergency.ErrStatusChanged):
+		h.PublicError(w, http.StatusConflict, err)
+		return
+	}
+
+	var ve *apperr.ValidationError
+	if errors.As(err, &ve) {
+		h.PublicError(w, http.StatusBadRequest, err)
+		return
+	}
+	var fe *apperr.ForbiddenError
+	if errors.As(err, &fe) {
+		h.PublicError(w, http.StatusForbidden, err)
+		return
+	}
+
+	h.InternalError(w, err)
+}
+
+// mapContact converts a contact into its public representation at now.
+func mapContact(c *emergency.Contact, now time.Time) dto.EmergencyContactRecord {
+	return dto.EmergencyContactRecord{
+		ContactUUID: c.ContactUUID,
+		Grantor:     c.GrantorLogin,
+		Grantee:     c.GranteeLogin,
+		Access:      c.Access,
+		WaitSeconds: int64(c.WaitPeriod / time.Second),
+		Status:      c.Status,
+		RequestedAt: c.RequestedAt,
+		GrantedAt:   c.GrantedAt(),
+		Granted:     c.Granted(now),
+		CreatedAt:   c.CreatedAt,
+	}
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/emergency"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func makeEmergencyHandlers(emergencySvc *mocks.EmergencyServiceMock) *Handlers {
+	return &Handlers{
+		emergencyService: emergencySvc,
+		logger:           zap.NewNop(),
+	}
+}
+
+// withContactParams adds the contact UUID and further URL parameters to req.
+func withContactParams(req *http.Request, contactUUID string, params ...string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("uuid", contactUUID)
+	for i := 0; i+1 < len(params); i += 2 {
+		rctx.URLParams.Add(params[i], params[i+1])
+	}
+	return req.WithContext(context.WithValue(contextWithUserID(1), chi.RouteCtxKey, rctx))
+}
+
+func TestHandlers_NominateEmergencyContact(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"success", nil, http.StatusCreated},
+		{"invalid access", emergency.ErrInvalidAccess, http.StatusBadRequest},
+		{"with self", emergency.ErrContactWithSelf, http.StatusBadRequest},
+		{"user not found", user.ErrUserNotFound, http.StatusNotFound},
+		{"internal", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			emergencySvc := new(mocks.EmergencyServiceMock)
+			h := makeEmergencyHandlers(emergencySvc)
+
+			var c *emergency.Contact
+			if tc.err == nil {
+				c = &emergency.Contact{ContactUUID: uuid.New(), GrantorLogin: "alice", GranteeLogin: "bob", Access: emergency.AccessView, WaitPeriod: 2 * time.Hour, Status: emergency.StatusIdle}
+			}
+			emergencySvc.On("Nominate", mock.Anything, int64(1), "bob", "view", 2*time.Hour).Return(c, tc.err)
+
+			body := `{"login":"bob","access":"view","wait_seconds":7200}`
+			req := httptest.NewRequest(http.MethodPost, "/api/emergency/contacts", strings.NewReader(body))
+			req = req.WithContext(contextWithUserID(1))
+			rec := httptest.NewRecorder()
+
+			h.NominateEmergencyContact(rec, req)
+
+			assert.Equal(t, tc.code, rec.Code)
+			if tc.err == nil {
+				var resp dto.EmergencyContactRecord
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, "bob", resp.Grantee)
+				assert.Equal(t, int64(7200), resp.WaitSeconds)
+				assert.False(t, resp.Granted)
+			}
+		})
+	}
+}
+
+func TestHandlers_RequestEmergencyAccess(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"success", nil, http.StatusOK},
+		{"already pending", emergency.ErrAlreadyPending, http.StatusBadRequest},
+		{"not grantee", emergency.ErrNotGrantee, http.StatusForbidden},
+		{"not found", emergency.ErrNotFound, http.StatusNotFound},
+		{"changed", emergency.ErrStatusChanged, http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			emergencySvc := new(mocks.EmergencyServiceMock)
+			h := makeEmergencyHandlers(emergencySvc)
+
+			contactUUID := uuid.NewString()
+			requestedAt := time.Now()
+			var c *emergency.Contact
+			if tc.err == nil {
+				c = &emergency.Contact{Status: emergency.StatusRequested, RequestedAt: &requestedAt, WaitPeriod: time.Hour}
+			}
+			emergencySvc.On("Request", mock.Anything, int64(1), contactUUID).Return(c, tc.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/emergency/grantors/"+contactUUID+"/request", nil)
+			req = withContactParams(req, contactUUID)
+			rec := httptest.NewRecorder()
+
+			h.RequestEmergencyAccess(rec, req)
+
+			assert.Equal(t, tc.code, rec.Code)
+			if tc.err == nil {
+				var resp dto.EmergencyContactRecord
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.WithinDuration(t, requestedAt.Add(time.Hour), *resp.GrantedAt, time.Second)
+			}
+		})
+	}
+}
+
+func TestHandlers_GetEmergencyKey(t *testing.T) {
+	emergencySvc := new(mocks.EmergencyServiceMock)
+	h := makeEmergencyHandlers(emergencySvc)
+
+	contactUUID := uuid.NewString()
+	keyUUID := uuid.New()
+	missing := uuid.NewString()
+	record := &keychain.KeyRecord{KeyUUID: keyUUID, KeyType: keychain.KeyText, Title: "note"}
+	emergencySvc.On("Key", mock.Anything, int64(1), contactUUID, keyUUID.String()).Return(record, []byte(`{"text":"secret"}`), nil)
+	emergencySvc.On("Key", mock.Anything, int64(1), contactUUID, missing).Return(nil, nil, sql.ErrNoRows)
+
+	req := withContactParams(httptest.NewRequest(http.MethodGet, "/", nil), contactUUID, "key_uuid", keyUUID.String())
+	rec := httptest.NewRecorder()
+	h.GetEmergencyKey(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	var resp dto.GetKeyResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "note", resp.Title)
+
+	req = withContactParams(httptest.NewRequest(http.MethodGet, "/", nil), contactUUID, "key_uuid", missing)
+	rec = httptest.NewRecorder()
+	h.GetEmergencyKey(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	req = withContactParams(httptest.NewRequest(http.MethodGet, "/", nil), contactUUID, "key_uuid", "nope")
+	rec = httptest.NewRecorder()
+	h.GetEmergencyKey(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandlers_GetEmergencyKeys_NotGranted(t *testing.T) {
+	emergencySvc := new(mocks.EmergencyServiceMock)
+	h := makeEmergencyHandlers(emergencySvc)
+
+	contactUUID := uuid.NewString()
+	emergencySvc.On("Keys", mock.Anything, int64(1), contactUUID, (*keychain.KeyType)(nil)).
+		Return([]*keychain.KeyRecord(nil), emergency.ErrNotGranted)
+
+	req := withContactParams(httptest.NewRequest(http.MethodGet, "/", nil), contactUUID)
+	rec := httptest.NewRecorder()
+	h.GetEmergencyKeys(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestHandlers_EmergencyTakeover(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"success", nil, http.StatusOK},
+		{"view only", emergency.ErrTakeoverDenied, http.StatusForbidden},
+		{"vault key", user.ErrVaultKeyWillBeLost, http.StatusConflict},
+		{"internal", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			emergencySvc := new(mocks.EmergencyServiceMock)
+			h := makeEmergencyHandlers(emergencySvc)
+
+			contactUUID := uuid.NewString()
+			emergencySvc.On("Takeover", mock.Anything, int64(1), contactUUID, "new-password", true).Return(tc.err == nil, tc.err)
+
+			body := `{"new_password":"new-password","discard_vault_key":true}`
+			req := withContactParams(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), contactUUID)
+			rec := httptest.NewRecorder()
+
+			h.EmergencyTakeover(rec, req)
+
+			assert.Equal(t, tc.code, rec.Code)
+			if tc.err == nil {
+				assert.JSONEq(t, `{"vault_key_discarded":true}`, rec.Body.String())
+			}
+		})
+	}
+}
@@ -11,8 +11,12 @@ var (
 	ErrInternalServerError = errors.New("internal server error")
 	ErrNotFound            = errors.New("resource not found")
 
+	ErrStreamingUnsupported = errors.New("response streaming is not supported")
+	ErrBatchOpNotAudited    = errors.New("batch operation applied but not recorded in the audit log")
+
 	ErrPayloadFileLimit    = errors.New("payload file is too large, max 10Mb")
 	ErrPayloadFileNotFound = errors.New("payload file not found")
+	ErrPayloadBatchLimit   = errors.New("batch is too large, max 64Mb")
 
 	ErrInternalPublicError = errors.New("Something went wrong..")
 )
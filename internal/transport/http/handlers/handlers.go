@@ -1,21 +1,47 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/security"
 	"github.com/thxhix/passKeeper/internal/services"
 	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
 	"go.uber.org/zap"
 	"net/http"
 )
 
+// KeySetProvider exposes the public keys accepted for access token verification.
+type KeySetProvider interface {
+	JWKS() []security.JWK
+}
+
 // Handlers is a collection of HTTP handlers for authentication and key management.
 //
-// It holds references to the logger and underlying services for authentication
-// and keychain operations.
+// It holds references to the logger and underlying services for authentication,
+// account and keychain operations.
 type Handlers struct {
-	logger          *zap.Logger
-	authService     services.IAuthService
-	keychainService services.IKeychainService
+	logger                *zap.Logger
+	authService           services.IAuthService
+	accountService        services.IAccountService
+	keychainService       services.IKeychainService
+	keychainEventService  services.IKeychainEventService
+	tokenService          services.IPersonalTokenService
+	serviceAccountService services.IServiceAccountService
+	deviceService         services.IDeviceService
+	auditService          services.IAuditService
+	webhookService        services.IWebhookService
+	adminService          services.IAdminService
+	inviteService         services.IInviteService
+	emailService          services.IEmailService
+	vaultService          services.IVaultService
+	shareService          services.IShareService
+	sendService           services.ISendService
+	emergencyService      services.IEmergencyService
+	recoveryService       services.IRecoveryService
+	keySet                KeySetProvider
+	uniformRegistration   bool
 }
 
 // NewHandlers creates a new instance of Handlers.
@@ -24,16 +50,50 @@ type Handlers struct {
 //
 //	logger *zap.Logger – the logger instance to be used.
 //	authService services.IAuthService – the authentication service.
+//	accountService services.IAccountService – the account service.
 //	keychainService services.IKeychainService – the keychain service.
+//	keychainEventService services.IKeychainEventService – the keychain event stream service.
+//	tokenService services.IPersonalTokenService – the personal access token service.
+//	serviceAccountService services.IServiceAccountService – the service account service.
+//	deviceService services.IDeviceService – the device service.
+//	auditService services.IAuditService – the audit log service.
+//	webhookService services.IWebhookService – the webhook subscription service.
+//	adminService services.IAdminService – the user administration service.
+//	inviteService services.IInviteService – the invite code service.
+//	emailService services.IEmailService – the email verification and password reset service.
+//	vaultService services.IVaultService – the organization and shared vault service.
+//	shareService services.IShareService – the end-to-end encrypted entry sharing service.
+//	sendService services.ISendService – the one-time secret link service.
+//	emergencyService services.IEmergencyService – the emergency access service.
+//	recoveryService services.IRecoveryService – the account recovery service.
+//	keySet KeySetProvider – the public access token keys.
+//	uniformRegistration bool – answer registration without telling whether the login was free.
 //
 // Returns:
 //
 //	Handlers – a new Handlers instance.
-func NewHandlers(logger *zap.Logger, authService services.IAuthService, keychainService services.IKeychainService) Handlers {
+func NewHandlers(logger *zap.Logger, authService services.IAuthService, accountService services.IAccountService, keychainService services.IKeychainService, keychainEventService services.IKeychainEventService, tokenService services.IPersonalTokenService, serviceAccountService services.IServiceAccountService, deviceService services.IDeviceService, auditService services.IAuditService, webhookService services.IWebhookService, adminService services.IAdminService, inviteService services.IInviteService, emailService services.IEmailService, vaultService services.IVaultService, shareService services.IShareService, sendService services.ISendService, emergencyService services.IEmergencyService, recoveryService services.IRecoveryService, keySet KeySetProvider, uniformRegistration bool) Handlers {
 	return Handlers{
-		logger:          logger,
-		authService:     authService,
-		keychainService: keychainService,
+		logger:                logger,
+		authService:           authService,
+		accountService:        accountService,
+		keychainService:       keychainService,
+		keychainEventService:  keychainEventService,
+		tokenService:          tokenService,
+		serviceAccountService: serviceAccountService,
+		deviceService:         deviceService,
+		auditService:          auditService,
+		webhookService:        webhookService,
+		adminService:          adminService,
+		inviteService:         inviteService,
+		emailService:          emailService,
+		vaultService:          vaultService,
+		shareService:          shareService,
+		sendService:           sendService,
+		emergencyService:      emergencyService,
+		recoveryService:       recoveryService,
+		keySet:                keySet,
+		uniformRegistration:   uniformRegistration,
 	}
 }
 
@@ -69,7 +129,15 @@ func (h *Handlers) PublicError(w http.ResponseWriter, code int, err error) {
 //
 // The method logs the error, sets the response headers to "application/json",
 // writes HTTP status 500, and returns a generic internal error message to the client.
+// An *apperr.UnavailableError is answered with 503 and a Retry-After header instead.
 func (h *Handlers) InternalError(w http.ResponseWriter, err error) {
+	var ue *apperr.UnavailableError
+	if errors.As(err, &ue) {
+		w.Header().Set("Retry-After", middleware.RetryAfterSeconds(ue.RetryAfter))
+		h.PublicError(w, http.StatusServiceUnavailable, ue)
+		return
+	}
+
 	h.logger.Error(ErrInternalServerError.Error(), zap.Error(err))
 
 	w.Header().Set("Content-Type", "application/json")
@@ -87,14 +155,24 @@ func (h *Handlers) InternalError(w http.ResponseWriter, err error) {
 // Parameters:
 //
 //	code int – the HTTP status code.
-//	err error – the error to include in the response.
+//	err error – the error to include in the response; the violations of an
+//	*apperr.ValidationError are included as well.
 //
 // Returns:
 //
 //	dto.ErrorResponse – the structured error object.
 func writeResponseError(code int, err error) dto.ErrorResponse {
-	return dto.ErrorResponse{
+	resp := dto.ErrorResponse{
 		Code:      code,
 		ErrorText: err.Error(),
 	}
+
+	var ve *apperr.ValidationError
+	if errors.As(err, &ve) {
+		for _, v := range ve.Violations {
+			resp.Violations = append(resp.Violations, &dto.ErrorViolation{Rule: v.Rule, Message: v.Message})
+		}
+	}
+
+	return resp
 }
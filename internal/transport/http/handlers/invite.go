@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/invite"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+// CreateInvite issues a new invite code on behalf of the user.
+//
+// The plain code is returned only in this response; the server keeps its hash.
+//
+// Body (JSON):
+//
+//	{
+//	  "login": "string, optional – the only login the invite registers",
+//	  "email": "string, optional – the only email the invite registers",
+//	  "max_uses": 1,
+//	  "expires_in_seconds": 604800
+//	}
+//
+// Status codes:
+//
+//	201 Created – the invite was created.
+//	400 BadRequest – invalid JSON, login, email, usage limit or lifetime.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) CreateInvite(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.CreateInviteRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.logger.Error(ErrBadRequest.Error(), zap.Error(err))
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	ttl := time.Duration(reqObj.ExpiresInSeconds) * time.Second
+	inv, code, err := h.inviteService.Create(ctx, userId, reqObj.Login, reqObj.Email, reqObj.MaxUses, ttl)
+	if err != nil {
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.CreateInviteResponse{
+		InviteRecord: mapInvite(inv),
+		Code:         code,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetInvites returns the invites created by the user without their codes.
+//
+// Status codes:
+//
+//	200 OK – the invite list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetInvites(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.inviteService.List(ctx, userId)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.InvitesResponse{
+		Invites: make([]*dto.InviteRecord, 0, len(list)),
+	}
+	for _, inv := range list {
+		mapped := mapInvite(inv)
+		respObj.Invites = append(respObj.Invites, &mapped)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// RevokeInvite deletes an invite created by the user.
+//
+// URL parameters:
+//
+//	uuid – the invite UUID.
+//
+// Status codes:
+//
+//	204 NoContent – the invite was revoked.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	404 NotFound – invite not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) RevokeInvite(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.inviteService.Revoke(ctx, userId, chi.URLParam(r, "uuid")); err != nil {
+		if errors.Is(err, invite.ErrNotFound) {
+			h.PublicError(w, http.StatusNotFound, ErrNotFound)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// mapInvite converts a stored invite into its public representation.
+func mapInvite(inv *invite.Invite) dto.InviteRecord {
+	return dto.InviteRecord{
+		InviteUUID: inv.InviteUUID,
+		Login:      inv.Login,
+		Email:      inv.Email,
+		MaxUses:    inv.MaxUses,
+		Uses:       inv.Uses,
+		ExpiresAt:  inv.ExpiresAt,
+		CreatedAt:  inv.CreatedAt,
+	}
+}
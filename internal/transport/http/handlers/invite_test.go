@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/invite"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func makeInviteHandlers(inviteSvc *mocks.InviteServiceMock) *Handlers {
+	return &Handlers{
+		inviteService: inviteSvc,
+		logger:        zap.NewNop(),
+	}
+}
+
+func TestHandlers_CreateInvite(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		inviteSvc := new(mocks.InviteServiceMock)
+		h := makeInviteHandlers(inviteSvc)
+
+		inv := &invite.Invite{InviteUUID: uuid.New(), Login: "alice", Email: "alice@example.com", MaxUses: 1, CodeHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}
+		inviteSvc.On("Create", mock.Anything, int64(1), "alice", "alice@example.com", 1, time.Hour).
+			Return(inv, invite.CodePrefix+"secret", nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/invites", strings.NewReader(`{"login":"alice","email":"alice@example.com","max_uses":1,"expires_in_seconds":3600}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.CreateInvite(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		assert.NotContains(t, rec.Body.String(), "hash")
+
+		var resp dto.CreateInviteResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, invite.CodePrefix+"secret", resp.Code)
+		assert.Equal(t, inv.InviteUUID, resp.InviteUUID)
+		assert.Equal(t, "alice", resp.Login)
+		assert.Equal(t, "alice@example.com", resp.Email)
+		inviteSvc.AssertExpectations(t)
+	})
+
+	t.Run("invalid max uses", func(t *testing.T) {
+		inviteSvc := new(mocks.InviteServiceMock)
+		h := makeInviteHandlers(inviteSvc)
+
+		inviteSvc.On("Create", mock.Anything, int64(1), "", "", 0, time.Duration(0)).
+			Return(nil, "", invite.ErrInvalidMaxUses)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/invites", strings.NewReader(`{}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.CreateInvite(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("bad json", func(t *testing.T) {
+		h := makeInviteHandlers(new(mocks.InviteServiceMock))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/invites", strings.NewReader(`{`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.CreateInvite(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandlers_GetInvites(t *testing.T) {
+	inviteSvc := new(mocks.InviteServiceMock)
+	h := makeInviteHandlers(inviteSvc)
+
+	inviteSvc.On("List", mock.Anything, int64(1)).Return([]*invite.Invite{
+		{InviteUUID: uuid.New(), MaxUses: 5, Uses: 2, CodeHash: "hash"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/invites", nil)
+	req = req.WithContext(contextWithUserID(1))
+	rec := httptest.NewRecorder()
+
+	h.GetInvites(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "hash")
+
+	var resp dto.InvitesResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp.Invites, 1)
+	assert.Equal(t, 2, resp.Invites[0].Uses)
+}
+
+func TestHandlers_RevokeInvite(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"not found", invite.ErrNotFound, http.StatusNotFound},
+		{"internal", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			inviteSvc := new(mocks.InviteServiceMock)
+			h := makeInviteHandlers(inviteSvc)
+
+			inviteUUID := uuid.New().String()
+			inviteSvc.On("Revoke", mock.Anything, int64(1), inviteUUID).Return(tc.err)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/invites/"+inviteUUID, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("uuid", inviteUUID)
+			req = req.WithContext(context.WithValue(contextWithUserID(1), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			h.RevokeInvite(rec, req)
+
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// JWKS publishes the public keys used to verify access tokens.
+//
+// Method: GET
+// Path: /.well-known/jwks.json
+//
+// Response: JSON Web Key Set (RFC 7517). During a key rotation both the new
+// and the retiring key are listed, so verifiers can cache the set for a short
+// time.
+//
+// Status codes:
+//
+//	200 OK – key set returned
+func (h *Handlers) JWKS(w http.ResponseWriter, r *http.Request) {
+	keys := h.keySet.JWKS()
+
+	resp := dto.JWKSet{Keys: make([]dto.JWK, 0, len(keys))}
+	for _, key := range keys {
+		resp.Keys = append(resp.Keys, dto.JWK{
+			Kty: key.Kty,
+			Crv: key.Crv,
+			X:   key.X,
+			Y:   key.Y,
+			Kid: key.Kid,
+			Alg: key.Alg,
+			Use: key.Use,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&resp, w); err != nil {
+		h.logger.Error("write jwks response failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thxhix/passKeeper/internal/security"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandlers_JWKS(t *testing.T) {
+	key, err := security.GenerateSigningKey(security.AlgEdDSA)
+	require.NoError(t, err)
+
+	h := &Handlers{
+		logger: zap.NewNop(),
+		keySet: security.NewKeyRing(key, time.Minute, nil),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	rec := httptest.NewRecorder()
+
+	h.JWKS(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+	b, _ := io.ReadAll(res.Body)
+	jwk := key.JWK()
+	expectedJSON := `{"keys":[{"kty":"OKP","crv":"Ed25519","x":"` + jwk.X + `","kid":"` + key.ID + `","alg":"EdDSA","use":"sig"}]}`
+	assert.JSONEq(t, expectedJSON, string(b))
+}
@@ -11,11 +11,14 @@ import (
 	"github.com/mailru/easyjson"
 	"github.com/thxhix/passKeeper/internal/apperr"
 	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
 	"github.com/thxhix/passKeeper/internal/transport/http/dto"
 	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
 	"go.uber.org/zap"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,12 +27,15 @@ import (
 // Query parameters:
 //
 //	type (optional) – filters keys by type (credential, card, file, text).
+//	vault (optional) – UUID of a shared vault to list instead of the personal keys.
 //
 // Status codes:
 //
 //	200 OK – the key list was returned successfully.
-//	400 BadRequest – invalid 'type' query parameter.
+//	400 BadRequest – invalid 'type' or 'vault' query parameter.
 //	401 Unauthorized – if the user is not authenticated.
+//	403 Forbidden – the access token scope or the vault role does not allow reading keys.
+//	404 NotFound – vault not found.
 //	500 InternalServerError – internal service error.
 func (h *Handlers) GetKeys(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -49,11 +55,32 @@ func (h *Handlers) GetKeys(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	vaultUUID, ok := vaultParam(r)
+	if !ok {
+		h.PublicError(w, http.StatusBadRequest, ErrBadQuery)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	list, err := h.keychainService.GetKeys(ctx, userId, typePtr)
+	var list []*keychain.KeyRecord
+	var err error
+	if vaultUUID != "" {
+		list, err = h.keychainService.GetVaultKeys(ctx, userId, vaultUUID, typePtr)
+	} else {
+		list, err = h.keychainService.GetKeys(ctx, userId, typePtr)
+	}
 	if err != nil {
+		if errors.Is(err, vault.ErrNotFound) {
+			h.PublicError(w, http.StatusNotFound, err)
+			return
+		}
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
+			return
+		}
 		h.InternalError(w, err)
 		return
 	}
@@ -65,6 +92,7 @@ func (h *Handlers) GetKeys(w http.ResponseWriter, r *http.Request) {
 			KeyUUID:   record.KeyUUID,
 			KeyType:   record.KeyType,
 			Title:     record.Title,
+			Tags:      record.Tags,
 			CreatedAt: record.CreatedAt,
 			UpdatedAt: record.UpdatedAt,
 		}
@@ -86,12 +114,17 @@ func (h *Handlers) GetKeys(w http.ResponseWriter, r *http.Request) {
 //
 //	uuid – the key UUID.
 //
+// Query parameters:
+//
+//	vault (optional) – UUID of the shared vault holding the key.
+//
 // Status codes:
 //
 //	200 OK – the key was found and returned.
 //	400 BadRequest – invalid UUID.
 //	401 Unauthorized – user is not authenticated.
-//	404 NotFound – key not found.
+//	403 Forbidden – the access token scope or the vault role does not cover the vault key.
+//	404 NotFound – key or vault not found, or a personal key the caller may not read.
 //	500 InternalServerError – internal service error.
 func (h *Handlers) GetKey(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -108,69 +141,125 @@ func (h *Handlers) GetKey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	vaultUUID, ok := vaultParam(r)
+	if !ok {
+		h.PublicError(w, http.StatusBadRequest, ErrBadQuery)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	keyRecord, plainDecrypted, err := h.keychainService.GetKey(ctx, userId, keyUUID)
+	var keyRecord *keychain.KeyRecord
+	var plainDecrypted []byte
+	var err error
+	if vaultUUID != "" {
+		keyRecord, plainDecrypted, err = h.keychainService.GetVaultKey(ctx, userId, vaultUUID, keyUUID)
+	} else {
+		keyRecord, plainDecrypted, err = h.keychainService.GetKey(ctx, userId, keyUUID)
+	}
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, vault.ErrNotFound) {
 			h.PublicError(w, http.StatusNotFound, ErrNotFound)
 			return
 		}
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
+			return
+		}
 		h.InternalError(w, err)
 		return
 	}
 
-	var data json.RawMessage
+	h.writeKey(w, keyRecord, plainDecrypted)
+}
 
-	switch keyRecord.KeyType {
-	case keychain.KeyCredential:
-		var d dto.CredentialsResponseDTO
-		if err := json.Unmarshal(plainDecrypted, &d); err != nil {
-			h.InternalError(w, err)
-			return
-		}
-		b, _ := json.Marshal(d)
-		data = b
+// GetChanges returns the changes of the keys the user sees after a revision,
+// personal ones and those of their vaults: added keys and tombstones of
+// deleted ones, oldest first. The keys of a vault the user left come back as
+// tombstones.
+//
+// Query parameters:
+//
+//	since (optional) – the revision the client has seen, 0 by default.
+//	data (optional) – "true" to include the decrypted data of live keys.
+//
+// Status codes:
+//
+//	200 OK – the changes were returned; ask again from 'revision' while 'more' is set.
+//	400 BadRequest – invalid 'since' or 'data' query parameter.
+//	401 Unauthorized – if the user is not authenticated.
+//	403 Forbidden – the access token scope does not allow reading keys.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, ok := middleware.GetUserIDFromCtx(ctx)
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
 
-	case keychain.KeyBankCard:
-		var d dto.CardResponseDTO
-		if err := json.Unmarshal(plainDecrypted, &d); err != nil {
-			h.InternalError(w, err)
+	var since int64
+	if raw := r.URL.Query().Get("since"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || v < 0 {
+			h.PublicError(w, http.StatusBadRequest, ErrBadQuery)
 			return
 		}
-		b, _ := json.Marshal(d)
-		data = b
+		since = v
+	}
 
-	case keychain.KeyFile:
-		var d dto.FileResponseDTO
-		if err := json.Unmarshal(plainDecrypted, &d); err != nil {
-			h.InternalError(w, err)
+	var withData bool
+	if raw := r.URL.Query().Get("data"); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			h.PublicError(w, http.StatusBadRequest, ErrBadQuery)
 			return
 		}
-		b, _ := json.Marshal(d)
-		data = b
+		withData = v
+	}
 
-	case keychain.KeyText:
-		var d dto.TextResponseDTO
-		if err := json.Unmarshal(plainDecrypted, &d); err != nil {
-			h.InternalError(w, err)
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	set, err := h.keychainService.GetChanges(ctx, userId, since, withData)
+	if err != nil {
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
 			return
 		}
-		b, _ := json.Marshal(d)
-		data = b
-
-	default:
-		data = json.RawMessage(plainDecrypted)
+		h.InternalError(w, err)
+		return
 	}
 
-	respObj := dto.GetKeyResponse{
-		KeyUUID:   keyRecord.KeyUUID,
-		KeyType:   keyRecord.KeyType,
-		Title:     keyRecord.Title,
-		Data:      data,
-		CreatedAt: keyRecord.CreatedAt,
-		UpdatedAt: keyRecord.UpdatedAt,
+	respObj := dto.ChangesResponse{
+		Revision: set.Revision,
+		More:     set.More,
+		Changes:  make([]*dto.KeyChange, 0, len(set.Changes)),
+	}
+
+	for _, change := range set.Changes {
+		rec := change.Record
+		mapped := &dto.KeyChange{
+			KeyUUID:   rec.KeyUUID,
+			Vault:     rec.VaultUUID,
+			KeyType:   rec.KeyType,
+			Title:     rec.Title,
+			Tags:      rec.Tags,
+			Revision:  rec.Revision,
+			Deleted:   rec.Deleted,
+			CreatedAt: rec.CreatedAt,
+			UpdatedAt: rec.UpdatedAt,
+		}
+		if change.Data != nil {
+			if mapped.Data, err = keyData(rec.KeyType, change.Data); err != nil {
+				h.InternalError(w, err)
+				return
+			}
+		}
+		respObj.Changes = append(respObj.Changes, mapped)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -182,18 +271,148 @@ func (h *Handlers) GetKey(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// keyEventHeartbeat is how often an idle event stream gets a comment, which
+// keeps proxies from closing it and lets the server notice gone clients. The
+// credential of the stream is re-checked at the same pace.
+var keyEventHeartbeat = 30 * time.Second
+
+// GetEvents streams the changes of the keys the user sees, personal ones and
+// those of their vaults, as server-sent events until the client disconnects.
+// The stream ends when the access token expires and, at the latest on the
+// next heartbeat, once the credential is revoked; the client then reconnects
+// with a fresh token.
+//
+// Every event is named after its kind – add, update or delete – has the
+// revision of the change as its id and a dto.KeyEvent as its data; key data
+// is never sent. A resync event means that events may have been missed.
+// Events are not replayed: after a resync or a reconnection clients catch up
+// with GetChanges.
+//
+// Status codes:
+//
+//	200 OK – the stream is open.
+//	401 Unauthorized – if the user is not authenticated.
+//	403 Forbidden – the access token scope does not allow reading keys.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, ok := middleware.GetUserIDFromCtx(ctx)
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.InternalError(w, ErrStreamingUnsupported)
+		return
+	}
+
+	events, err := h.keychainEventService.Subscribe(ctx, userId)
+	if err != nil {
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	cred, _ := middleware.CredentialFromCtx(ctx)
+
+	var expired <-chan time.Time
+	if !cred.ExpiresAt.IsZero() {
+		timer := time.NewTimer(time.Until(cred.ExpiresAt))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	heartbeat := time.NewTicker(keyEventHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-expired:
+			return
+
+		case event, open := <-events:
+			if !open {
+				return
+			}
+			if err := writeKeyEvent(w, event); err != nil {
+				return
+			}
+
+		case <-heartbeat.C:
+			if cred.Recheck != nil && cred.Recheck(ctx) != nil {
+				return
+			}
+			if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeKeyEvent writes event as a server-sent event.
+func writeKeyEvent(w io.Writer, event keychain.Event) error {
+	data := dto.KeyEvent{Event: event.Type}
+	if event.Type != keychain.EventResync {
+		keyUUID := event.KeyUUID
+		data.KeyUUID = &keyUUID
+		data.Vault = event.VaultUUID
+		data.KeyType = event.KeyType
+		data.Title = event.Title
+		data.Tags = event.Tags
+		data.Revision = event.Revision
+	}
+
+	raw, err := easyjson.Marshal(&data)
+	if err != nil {
+		return err
+	}
+
+	var frame bytes.Buffer
+	frame.WriteString("event: " + string(event.Type) + "\n")
+	if event.Revision > 0 {
+		frame.WriteString("id: " + strconv.FormatInt(event.Revision, 10) + "\n")
+	}
+	frame.WriteString("data: ")
+	frame.Write(raw)
+	frame.WriteString("\n\n")
+
+	_, err = w.Write(frame.Bytes())
+	return err
+}
+
 // DeleteKey deletes a user key by UUID.
 
 // URL parameters:
 //
 //	uuid – the key UUID.
 //
+// Query parameters:
+//
+//	vault (optional) – UUID of the shared vault holding the key.
+//
 // Status codes:
 //
 //	204 NoContent – the key was successfully deleted.
 //	400 BadRequest – invalid UUID.
 //	401 Unauthorized – user is not authenticated.
-//	404 NotFound – key not found.
+//	403 Forbidden – the access token scope or the vault role does not cover the key.
+//	404 NotFound – key or vault not found.
 //	500 InternalServerError – internal service error.
 func (h *Handlers) DeleteKey(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -210,15 +429,31 @@ func (h *Handlers) DeleteKey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	vaultUUID, ok := vaultParam(r)
+	if !ok {
+		h.PublicError(w, http.StatusBadRequest, ErrBadQuery)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	err := h.keychainService.DeleteKey(ctx, userId, keyUUID)
+	var err error
+	if vaultUUID != "" {
+		err = h.keychainService.DeleteVaultKey(ctx, userId, vaultUUID, keyUUID)
+	} else {
+		err = h.keychainService.DeleteKey(ctx, userId, keyUUID)
+	}
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, vault.ErrNotFound) {
 			h.PublicError(w, http.StatusNotFound, ErrNotFound)
 			return
 		}
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
+			return
+		}
 		h.InternalError(w, err)
 		return
 	}
@@ -232,7 +467,9 @@ func (h *Handlers) DeleteKey(w http.ResponseWriter, r *http.Request) {
 // Body (JSON):
 //
 //	{
+//	  "vault": "uuid, optional – shared vault to add the entry to",
 //	  "title": "string",
+//	  "tags": ["string"],
 //	  "login": "string",
 //	  "password": "string"
 //	}
@@ -242,6 +479,8 @@ func (h *Handlers) DeleteKey(w http.ResponseWriter, r *http.Request) {
 //	201 Created – the key was successfully added.
 //	400 BadRequest – invalid JSON or validation error.
 //	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the access token scope or the vault role does not allow adding the entry.
+//	404 NotFound – vault not found.
 //	500 InternalServerError – internal service error.
 func (h *Handlers) AddCredential(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -274,6 +513,15 @@ func (h *Handlers) AddCredential(w http.ResponseWriter, r *http.Request) {
 			h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
 			return
 		}
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
+			return
+		}
+		if errors.Is(err, vault.ErrNotFound) {
+			h.PublicError(w, http.StatusNotFound, err)
+			return
+		}
 		h.InternalError(w, err)
 		return
 	}
@@ -296,7 +544,9 @@ func (h *Handlers) AddCredential(w http.ResponseWriter, r *http.Request) {
 // Body (JSON):
 //
 //	{
+//	  "vault": "uuid, optional – shared vault to add the entry to",
 //	  "title": "string",
+//	  "tags": ["string"],
 //	  "number": "string",
 //	  "exp": "string"
 //	}
@@ -306,6 +556,8 @@ func (h *Handlers) AddCredential(w http.ResponseWriter, r *http.Request) {
 //	201 Created – the card was successfully added.
 //	400 BadRequest – invalid JSON or validation error.
 //	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the access token scope or the vault role does not allow adding the entry.
+//	404 NotFound – vault not found.
 //	500 InternalServerError – internal service error.
 func (h *Handlers) AddCard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -338,6 +590,15 @@ func (h *Handlers) AddCard(w http.ResponseWriter, r *http.Request) {
 			h.PublicError(w, http.StatusBadRequest, err)
 			return
 		}
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
+			return
+		}
+		if errors.Is(err, vault.ErrNotFound) {
+			h.PublicError(w, http.StatusNotFound, err)
+			return
+		}
 		h.InternalError(w, err)
 		return
 	}
@@ -360,7 +621,9 @@ func (h *Handlers) AddCard(w http.ResponseWriter, r *http.Request) {
 // Body (JSON):
 //
 //	{
+//	  "vault": "uuid, optional – shared vault to add the entry to",
 //	  "title": "string",
+//	  "tags": ["string"],
 //	  "text": "string"
 //	}
 //
@@ -369,6 +632,8 @@ func (h *Handlers) AddCard(w http.ResponseWriter, r *http.Request) {
 //	201 Created – the text entry was successfully added.
 //	400 BadRequest – invalid JSON or validation error.
 //	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the access token scope or the vault role does not allow adding the entry.
+//	404 NotFound – vault not found.
 //	500 InternalServerError – internal service error.
 func (h *Handlers) AddText(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -401,6 +666,15 @@ func (h *Handlers) AddText(w http.ResponseWriter, r *http.Request) {
 			h.PublicError(w, http.StatusBadRequest, err)
 			return
 		}
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
+			return
+		}
+		if errors.Is(err, vault.ErrNotFound) {
+			h.PublicError(w, http.StatusNotFound, err)
+			return
+		}
 		h.InternalError(w, err)
 		return
 	}
@@ -423,7 +697,9 @@ func (h *Handlers) AddText(w http.ResponseWriter, r *http.Request) {
 // Body (multipart/form-data):
 //
 //	file – file content
+//	vault – optional UUID of a shared vault to add the file to
 //	title – file title
+//	tags – optional tags, repeated or comma-separated
 //	note – optional note
 //
 // Constraints:
@@ -435,6 +711,8 @@ func (h *Handlers) AddText(w http.ResponseWriter, r *http.Request) {
 //	201 Created – the file was successfully added.
 //	400 BadRequest – file not found in the request.
 //	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the access token scope or the vault role does not allow adding the entry.
+//	404 NotFound – vault not found.
 //	413 RequestEntityTooLarge – file size exceeds the limit.
 //	500 InternalServerError – internal service error.
 func (h *Handlers) AddFile(w http.ResponseWriter, r *http.Request) {
@@ -472,8 +750,19 @@ func (h *Handlers) AddFile(w http.ResponseWriter, r *http.Request) {
 	title := r.FormValue("title")
 	note := r.FormValue("note")
 
+	var tags []string
+	for _, v := range r.MultipartForm.Value["tags"] {
+		for _, tag := range strings.Split(v, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
 	reqObj := dto.AddFileDTO{
+		Vault: r.FormValue("vault"),
 		Title: title,
+		Tags:  tags,
 		File:  raw,
 		Note:  note,
 	}
@@ -485,6 +774,15 @@ func (h *Handlers) AddFile(w http.ResponseWriter, r *http.Request) {
 			h.PublicError(w, http.StatusBadRequest, err)
 			return
 		}
+		var fe *apperr.ForbiddenError
+		if errors.As(err, &fe) {
+			h.PublicError(w, http.StatusForbidden, err)
+			return
+		}
+		if errors.Is(err, vault.ErrNotFound) {
+			h.PublicError(w, http.StatusNotFound, err)
+			return
+		}
 		h.InternalError(w, err)
 		return
 	}
@@ -501,3 +799,185 @@ func (h *Handlers) AddFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// vaultParam returns the optional vault query parameter. ok is false when
+// the parameter is set but is not a UUID.
+func vaultParam(r *http.Request) (vaultUUID string, ok bool) {
+	vaultUUID = r.URL.Query().Get("vault")
+	if vaultUUID == "" {
+		return "", true
+	}
+	if _, err := uuid.Parse(vaultUUID); err != nil {
+		return "", false
+	}
+	return vaultUUID, true
+}
+
+// writeKey writes an entry with its decrypted data as dto.GetKeyResponse.
+func (h *Handlers) writeKey(w http.ResponseWriter, rec *keychain.KeyRecord, plain []byte) {
+	data, err := keyData(rec.KeyType, plain)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.GetKeyResponse{
+		KeyUUID:   rec.KeyUUID,
+		KeyType:   rec.KeyType,
+		Title:     rec.Title,
+		Tags:      rec.Tags,
+		Data:      data,
+		CreatedAt: rec.CreatedAt,
+		UpdatedAt: rec.UpdatedAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// maxBatchSize bounds the body of a batch, file contents included.
+const maxBatchSize = 64 << 20
+
+// Batch runs a list of add, update and delete operations on the personal
+// keys of the user in one transaction, and returns the result of each.
+//
+// Request body: dto.BatchRequest. In atomic mode, the default, either every
+// operation is applied or none is; in best_effort mode the failed operations
+// are skipped and the others applied. Each operation is authorized like the
+// single key operations.
+//
+// Status codes:
+//
+//	200 OK – the batch ran; the results tell the status of each operation: applied, failed or aborted.
+//	400 BadRequest – malformed body, no or too many operations or an unknown mode.
+//	401 Unauthorized – user is not authenticated.
+//	413 RequestEntityTooLarge – the body exceeds the limit.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) Batch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, ok := middleware.GetUserIDFromCtx(ctx)
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBatchSize))
+	if err != nil {
+		var me *http.MaxBytesError
+		if errors.As(err, &me) {
+			h.PublicError(w, http.StatusRequestEntityTooLarge, ErrPayloadBatchLimit)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.BatchRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.logger.Error(ErrBadRequest.Error(), zap.Error(err))
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	mode, ok := keychain.ParseBatchMode(reqObj.Mode)
+	if !ok {
+		h.PublicError(w, http.StatusBadRequest, keychain.ErrBatchMode)
+		return
+	}
+
+	results, err := h.keychainService.Batch(ctx, userId, mode, reqObj.Operations)
+	if err != nil {
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.BatchResponse{
+		Mode:    mode,
+		Results: make([]*dto.BatchItemResult, 0, len(results)),
+	}
+
+	for i, res := range results {
+		if res.AuditErr != nil {
+			h.logger.Error(ErrBatchOpNotAudited.Error(), zap.Int64("user_id", userId), zap.String("uuid", res.KeyUUID), zap.Error(res.AuditErr))
+		}
+
+		item := &dto.BatchItemResult{Index: i, Op: res.Op, UUID: res.KeyUUID, Status: "applied"}
+		switch {
+		case res.Err == nil:
+			respObj.Applied++
+		case errors.Is(res.Err, keychain.ErrBatchAborted):
+			item.Status = "aborted"
+			errObj := writeResponseError(http.StatusConflict, res.Err)
+			item.Error = &errObj
+		default:
+			item.Status = "failed"
+			item.Error = h.batchItemError(res.Err)
+			respObj.Failed++
+		}
+		respObj.Results = append(respObj.Results, item)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// batchItemError describes the failure of a batch operation with the status
+// code the single operation would have answered, hiding internal errors.
+func (h *Handlers) batchItemError(err error) *dto.ErrorResponse {
+	var ve *apperr.ValidationError
+	var fe *apperr.ForbiddenError
+
+	var errObj dto.ErrorResponse
+	switch {
+	case errors.As(err, &ve):
+		errObj = writeResponseError(http.StatusBadRequest, err)
+	case errors.As(err, &fe):
+		errObj = writeResponseError(http.StatusForbidden, err)
+	case errors.Is(err, sql.ErrNoRows):
+		errObj = writeResponseError(http.StatusNotFound, ErrNotFound)
+	default:
+		h.logger.Error(ErrInternalServerError.Error(), zap.Error(err))
+		errObj = writeResponseError(http.StatusInternalServerError, ErrInternalPublicError)
+	}
+	return &errObj
+}
+
+// keyData maps the decrypted data of an entry of keyType to its response DTO.
+func keyData(keyType keychain.KeyType, plain []byte) (json.RawMessage, error) {
+	var d any
+
+	switch keyType {
+	case keychain.KeyCredential:
+		d = &dto.CredentialsResponseDTO{}
+	case keychain.KeyBankCard:
+		d = &dto.CardResponseDTO{}
+	case keychain.KeyFile:
+		d = &dto.FileResponseDTO{}
+	case keychain.KeyText:
+		d = &dto.TextResponseDTO{}
+	default:
+		return json.RawMessage(plain), nil
+	}
+
+	if err := json.Unmarshal(plain, d); err != nil {
+		return nil, err
+	}
+	return json.Marshal(d)
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"github.com/thxhix/passKeeper/internal/domain/token"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
 	"github.com/thxhix/passKeeper/internal/mocks"
 	"github.com/thxhix/passKeeper/internal/transport/http/dto"
 	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
@@ -88,6 +91,53 @@ func TestHandlers_GetKeys(t *testing.T) {
 		defer res.Body.Close()
 		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
 	})
+
+	t.Run("vault", func(t *testing.T) {
+		keySvc := new(mocks.KeychainServiceMock)
+		h := makeKeychainHandlers(keySvc)
+
+		vaultUUID := uuid.New().String()
+		keySvc.On("GetVaultKeys", mock.Anything, int64(1), vaultUUID, (*keychain.KeyType)(nil)).
+			Return([]*keychain.KeyRecord{{KeyUUID: uuid.New(), KeyType: keychain.KeyText}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/keys?vault="+vaultUUID, nil)
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.GetKeys(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		keySvc.AssertNotCalled(t, "GetKeys", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("vault not found", func(t *testing.T) {
+		keySvc := new(mocks.KeychainServiceMock)
+		h := makeKeychainHandlers(keySvc)
+
+		vaultUUID := uuid.New().String()
+		keySvc.On("GetVaultKeys", mock.Anything, int64(1), vaultUUID, (*keychain.KeyType)(nil)).
+			Return([]*keychain.KeyRecord(nil), vault.ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/keys?vault="+vaultUUID, nil)
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.GetKeys(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("bad vault", func(t *testing.T) {
+		h := makeKeychainHandlers(new(mocks.KeychainServiceMock))
+
+		req := httptest.NewRequest(http.MethodGet, "/keys?vault=nope", nil)
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.GetKeys(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
 }
 
 func TestHandlers_GetKey(t *testing.T) {
@@ -158,6 +208,160 @@ func TestHandlers_GetKey(t *testing.T) {
 	})
 }
 
+func TestHandlers_GetChanges(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		keySvc := new(mocks.KeychainServiceMock)
+		h := makeKeychainHandlers(keySvc)
+
+		userID := int64(1)
+		vaultUUID := uuid.New()
+		live := &keychain.KeyRecord{KeyUUID: uuid.New(), KeyType: keychain.KeyText, Title: "live", Revision: 3}
+		gone := &keychain.KeyRecord{KeyUUID: uuid.New(), VaultUUID: &vaultUUID, KeyType: keychain.KeyText, Title: "gone", Revision: 4, Deleted: true}
+		set := &keychain.ChangeSet{
+			Revision: 4,
+			Changes:  []keychain.Change{{Record: live, Data: []byte(`{"text":"t"}`)}, {Record: gone}},
+		}
+
+		keySvc.On("GetChanges", mock.Anything, userID, int64(2), true).Return(set, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/keys/changes?since=2&data=true", nil)
+		req = req.WithContext(contextWithUserID(userID))
+		rec := httptest.NewRecorder()
+
+		h.GetChanges(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		b, _ := io.ReadAll(res.Body)
+		resp := dto.ChangesResponse{}
+		assert.NoError(t, json.Unmarshal(b, &resp))
+		assert.Equal(t, int64(4), resp.Revision)
+		if assert.Len(t, resp.Changes, 2) {
+			assert.JSONEq(t, `{"text":"t"}`, string(resp.Changes[0].Data))
+			assert.False(t, resp.Changes[0].Deleted)
+			assert.Nil(t, resp.Changes[0].Vault)
+			assert.True(t, resp.Changes[1].Deleted)
+			assert.Empty(t, resp.Changes[1].Data)
+			assert.Equal(t, &vaultUUID, resp.Changes[1].Vault)
+		}
+
+		keySvc.AssertExpectations(t)
+	})
+	t.Run("bad since", func(t *testing.T) {
+		keySvc := new(mocks.KeychainServiceMock)
+		h := makeKeychainHandlers(keySvc)
+
+		for _, query := range []string{"since=-1", "since=x", "data=maybe"} {
+			req := httptest.NewRequest(http.MethodGet, "/keys/changes?"+query, nil)
+			req = req.WithContext(contextWithUserID(1))
+			rec := httptest.NewRecorder()
+
+			h.GetChanges(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+		}
+		keySvc.AssertNotCalled(t, "GetChanges", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("forbidden", func(t *testing.T) {
+		keySvc := new(mocks.KeychainServiceMock)
+		h := makeKeychainHandlers(keySvc)
+
+		keySvc.On("GetChanges", mock.Anything, int64(1), int64(0), false).Return(nil, pat.ErrInsufficientScope)
+
+		req := httptest.NewRequest(http.MethodGet, "/keys/changes", nil)
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.GetChanges(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestHandlers_GetEvents(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		eventSvc := new(mocks.KeychainEventServiceMock)
+		h := &Handlers{keychainEventService: eventSvc, logger: zap.NewNop()}
+
+		keyUUID := uuid.New()
+		events := make(chan keychain.Event, 2)
+		events <- keychain.Event{Type: keychain.EventAdd, UserID: 1, KeyID: 7, KeyUUID: keyUUID, KeyType: keychain.KeyText, Title: "note", Revision: 5}
+		events <- keychain.Event{Type: keychain.EventResync}
+		close(events)
+
+		eventSvc.On("Subscribe", mock.Anything, int64(1)).Return(events, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/keys/events", nil)
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.GetEvents(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "event: add\nid: 5\ndata: {\"event\":\"add\",\"uuid\":\""+keyUUID.String()+"\",\"type\":\"text\",\"title\":\"note\",\"revision\":5}\n\n"+
+			"event: resync\ndata: {\"event\":\"resync\"}\n\n", rec.Body.String())
+	})
+	t.Run("ends when the token expires", func(t *testing.T) {
+		eventSvc := new(mocks.KeychainEventServiceMock)
+		h := &Handlers{keychainEventService: eventSvc, logger: zap.NewNop()}
+
+		eventSvc.On("Subscribe", mock.Anything, int64(1)).Return(make(chan keychain.Event), nil)
+
+		cred := middleware.Credential{ExpiresAt: time.Now().Add(20 * time.Millisecond)}
+		req := httptest.NewRequest(http.MethodGet, "/keys/events", nil)
+		req = req.WithContext(middleware.WithCredential(contextWithUserID(1), cred))
+		rec := httptest.NewRecorder()
+
+		h.GetEvents(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("ends when the token is revoked", func(t *testing.T) {
+		eventSvc := new(mocks.KeychainEventServiceMock)
+		h := &Handlers{keychainEventService: eventSvc, logger: zap.NewNop()}
+
+		eventSvc.On("Subscribe", mock.Anything, int64(1)).Return(make(chan keychain.Event), nil)
+
+		heartbeat := keyEventHeartbeat
+		keyEventHeartbeat = 10 * time.Millisecond
+		defer func() { keyEventHeartbeat = heartbeat }()
+
+		checks := 0
+		cred := middleware.Credential{Recheck: func(context.Context) error {
+			checks++
+			if checks == 2 {
+				return token.ErrInvalidAuthToken
+			}
+			return nil
+		}}
+		req := httptest.NewRequest(http.MethodGet, "/keys/events", nil)
+		req = req.WithContext(middleware.WithCredential(contextWithUserID(1), cred))
+		rec := httptest.NewRecorder()
+
+		h.GetEvents(rec, req)
+
+		assert.Equal(t, 2, checks)
+		assert.Equal(t, ": ping\n\n", rec.Body.String())
+	})
+	t.Run("forbidden", func(t *testing.T) {
+		eventSvc := new(mocks.KeychainEventServiceMock)
+		h := &Handlers{keychainEventService: eventSvc, logger: zap.NewNop()}
+
+		eventSvc.On("Subscribe", mock.Anything, int64(1)).Return(nil, pat.ErrInsufficientScope)
+
+		req := httptest.NewRequest(http.MethodGet, "/keys/events", nil)
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.GetEvents(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
 func TestHandlers_DeleteKey(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		keySvc := new(mocks.KeychainServiceMock)
@@ -203,6 +407,28 @@ func TestHandlers_DeleteKey(t *testing.T) {
 		defer res.Body.Close()
 		assert.Equal(t, http.StatusNotFound, res.StatusCode)
 	})
+	t.Run("out of token scope", func(t *testing.T) {
+		keySvc := new(mocks.KeychainServiceMock)
+		h := makeKeychainHandlers(keySvc)
+
+		userID := int64(1)
+		keyUUID := uuid.New().String()
+
+		keySvc.On("DeleteKey", mock.Anything, userID, keyUUID).Return(pat.ErrInsufficientScope)
+
+		req := httptest.NewRequest(http.MethodDelete, "/keys/"+keyUUID, nil)
+		req = req.WithContext(contextWithUserID(userID))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", keyUUID)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rec := httptest.NewRecorder()
+
+		h.DeleteKey(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
 }
 
 func TestHandlers_AddCredential(t *testing.T) {
@@ -321,7 +547,9 @@ func TestHandlers_AddFile(t *testing.T) {
 		userID := int64(1)
 
 		keyUUID := uuid.New().String()
-		keySvc.On("AddFile", mock.Anything, userID, mock.Anything).Return(keyUUID, nil)
+		keySvc.On("AddFile", mock.Anything, userID, mock.MatchedBy(func(in dto.AddFileDTO) bool {
+			return in.Title == "t1" && assert.ObjectsAreEqual([]string{"prod", "ci", "backup"}, in.Tags)
+		})).Return(keyUUID, nil)
 
 		body := &bytes.Buffer{}
 		writer := multipart.NewWriter(body)
@@ -329,6 +557,8 @@ func TestHandlers_AddFile(t *testing.T) {
 		part.Write([]byte("file content"))
 		_ = writer.WriteField("title", "t1")
 		_ = writer.WriteField("note", "n1")
+		_ = writer.WriteField("tags", "prod, ci")
+		_ = writer.WriteField("tags", "backup")
 		writer.Close()
 
 		req := httptest.NewRequest(http.MethodPost, "/keys/file", body)
@@ -385,3 +615,83 @@ func TestHandlers_AddFile(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
 	})
 }
+
+func TestHandlers_Batch(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		keySvc := new(mocks.KeychainServiceMock)
+		h := makeKeychainHandlers(keySvc)
+
+		added := uuid.NewString()
+		keySvc.On("Batch", mock.Anything, int64(1), keychain.BatchBestEffort, mock.Anything).Return([]keychain.BatchResult{
+			{Op: keychain.BatchAdd, KeyUUID: added},
+			{Op: keychain.BatchDelete, KeyUUID: "missing", Err: sql.ErrNoRows},
+			{Op: keychain.BatchAdd, Err: keychain.ErrTitleEmpty},
+		}, nil)
+
+		body := `{"mode":"best_effort","operations":[{"op":"add","text":{"title":"note","text":"t"}},{"op":"delete","uuid":"missing"},{"op":"add","text":{"text":"t"}}]}`
+		req := httptest.NewRequest(http.MethodPost, "/keys/batch", strings.NewReader(body))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.Batch(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		resp := dto.BatchResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.Applied)
+		assert.Equal(t, 2, resp.Failed)
+		if assert.Len(t, resp.Results, 3) {
+			assert.Equal(t, "applied", resp.Results[0].Status)
+			assert.Equal(t, added, resp.Results[0].UUID)
+			assert.Equal(t, "failed", resp.Results[1].Status)
+			assert.Equal(t, http.StatusNotFound, resp.Results[1].Error.Code)
+			assert.Equal(t, http.StatusBadRequest, resp.Results[2].Error.Code)
+		}
+
+		ops := keySvc.Calls[0].Arguments.Get(3).([]*dto.BatchOperation)
+		if assert.Len(t, ops, 3) {
+			assert.Equal(t, "note", ops[0].Text.Title)
+		}
+	})
+	t.Run("aborted", func(t *testing.T) {
+		keySvc := new(mocks.KeychainServiceMock)
+		h := makeKeychainHandlers(keySvc)
+
+		keySvc.On("Batch", mock.Anything, int64(1), keychain.BatchAtomic, mock.Anything).Return([]keychain.BatchResult{
+			{Op: keychain.BatchAdd, Err: keychain.ErrBatchAborted},
+			{Op: keychain.BatchAdd, Err: pat.ErrInsufficientScope},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/keys/batch", strings.NewReader(`{"operations":[{"op":"add"},{"op":"add"}]}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.Batch(rec, req)
+
+		resp := dto.BatchResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, keychain.BatchAtomic, resp.Mode)
+		assert.Equal(t, 0, resp.Applied)
+		if assert.Len(t, resp.Results, 2) {
+			assert.Equal(t, "aborted", resp.Results[0].Status)
+			assert.Equal(t, http.StatusForbidden, resp.Results[1].Error.Code)
+		}
+	})
+	t.Run("bad request", func(t *testing.T) {
+		keySvc := new(mocks.KeychainServiceMock)
+		h := makeKeychainHandlers(keySvc)
+
+		keySvc.On("Batch", mock.Anything, int64(1), keychain.BatchAtomic, mock.Anything).Return(nil, keychain.ErrBatchEmpty)
+
+		for _, body := range []string{`{"mode":"later","operations":[]}`, `{"operations":`, `{"operations":[]}`} {
+			req := httptest.NewRequest(http.MethodPost, "/keys/batch", strings.NewReader(body))
+			req = req.WithContext(contextWithUserID(1))
+			rec := httptest.NewRecorder()
+
+			h.Batch(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+		}
+	})
+}
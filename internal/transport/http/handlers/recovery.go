@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/recovery"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SetRecovery replaces the account recovery setup of the user. The client
+// wraps the private sharing key with a recovery key, splits that key into
+// Shamir shares and seals the shares given to other users for their public
+// keys; shares handed out on paper are not sent.
+//
+// Body (JSON):
+//
+//	{
+//	  "public_key": "base64, the current public sharing key",
+//	  "key_material": "base64, the private key wrapped with the recovery key",
+//	  "threshold": 2,
+//	  "shares": 3,
+//	  "held": [
+//	    {"login": "string", "wrapped_key": "base64", "nonce": "base64", "data": "base64"}
+//	  ]
+//	}
+//
+// Status codes:
+//
+//	200 OK – the setup was stored and returned without key material.
+//	400 BadRequest – invalid JSON, counts, holders or sealed payload.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – the user has no key pair or a holder does not exist.
+//	409 Conflict – the public key is not the current one, or a holder has no sharing key yet.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) SetRecovery(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.SetRecoveryRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	held := make([]recovery.HeldShare, 0, len(reqObj.Held))
+	for _, s := range reqObj.Held {
+		if s == nil {
+			h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+			return
+		}
+		held = append(held, recovery.HeldShare{
+			Login:      s.Login,
+			WrappedKey: s.WrappedKey,
+			Nonce:      s.Nonce,
+			Data:       s.Data,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	setup, err := h.recoveryService.Setup(ctx, userId, reqObj.PublicKey, reqObj.KeyMaterial, reqObj.Threshold, reqObj.Shares, held)
+	if err != nil {
+		h.recoveryError(w, err)
+		return
+	}
+
+	respObj := mapRecovery(setup)
+	respObj.KeyMaterial = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetRecovery returns the account recovery setup of the user with the
+// private key still wrapped with the recovery key. It stays available after
+// a password reset, so the key can be restored from the shares.
+//
+// Status codes:
+//
+//	200 OK – the setup was returned.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – recovery is not set up.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetRecovery(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	setup, err := h.recoveryService.Get(ctx, userId)
+	if err != nil {
+		h.recoveryError(w, err)
+		return
+	}
+
+	respObj := mapRecovery(setup)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// DeleteRecovery removes the account recovery setup of the user together
+// with the shares held by other users.
+//
+// Status codes:
+//
+//	204 NoContent – the setup was removed.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – recovery is not set up.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) DeleteRecovery(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.recoveryService.Remove(ctx, userId); err != nil {
+		h.recoveryError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetHeldRecoveryShares returns the recovery shares the user holds for other
+// users, without their sealed payload.
+//
+// Status codes:
+//
+//	200 OK – the share list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetHeldRecoveryShares(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	shares, err := h.recoveryService.Held(ctx, userId)
+	if err != nil {
+		h.recoveryError(w, err)
+		return
+	}
+
+	respObj := dto.RecoverySharesResponse{
+		Shares: make([]*dto.RecoveryShareRecord, 0, len(shares)),
+	}
+	for _, sh := range shares {
+		mapped := mapRecoveryShare(sh)
+		respObj.Shares = append(respObj.Shares, &mapped)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetHeldRecoveryShare returns a recovery share the user holds with its
+// sealed payload, which only the private key of the user opens. The owner of
+// the share sees the fetch in their audit log.
+//
+// URL parameters:
+//
+//	uuid – the share UUID.
+//
+// Status codes:
+//
+//	200 OK – the share was returned.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – share not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetHeldRecoveryShare(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	sh, err := h.recoveryService.GetHeld(ctx, userId, chi.URLParam(r, "uuid"))
+	if err != nil {
+		h.recoveryError(w, err)
+		return
+	}
+
+	respObj := dto.HeldRecoveryShareResponse{
+		RecoveryShareRecord: mapRecoveryShare(sh),
+		WrappedKey:          sh.WrappedKey,
+		Nonce:               sh.Nonce,
+		Data:                sh.Data,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// recoveryError maps account recovery errors to responses.
+func (h *Handlers) recoveryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, recovery.ErrNotSetUp), errors.Is(err, recovery.ErrNotFound),
+		errors.Is(err, recovery.ErrNoKeyPair), errors.Is(err, user.ErrUserNotFound):
+		h.PublicError(w, http.StatusNotFound, err)
+		return
+	case errors.Is(err, recovery.ErrKeyMismatch), errors.Is(err, recovery.ErrNoPublicKey):
+		h.PublicError(w, http.StatusConflict, err)
+		return
+	}
+
+	var ve *apperr.ValidationError
+	if errors.As(err, &ve) {
+		h.PublicError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	h.InternalError(w, err)
+}
+
+// mapRecovery converts a recovery setup into its public representation.
+func mapRecovery(setup *recovery.Setup) dto.RecoveryResponse {
+	return dto.RecoveryResponse{
+		PublicKey:   setup.PublicKey,
+		KeyMaterial: setup.KeyMaterial,
+		Threshold:   setup.Threshold,
+		Shares:      setup.Shares,
+		Holders:     setup.Holders,
+		CreatedAt:   setup.CreatedAt,
+	}
+}
+
+// mapRecoveryShare converts a recovery share into its public representation.
+func mapRecoveryShare(sh *recovery.Share) dto.RecoveryShareRecord {
+	return dto.RecoveryShareRecord{
+		ShareUUID: sh.ShareUUID,
+		Owner:     sh.OwnerLogin,
+		Holder:    sh.HolderLogin,
+		CreatedAt: sh.CreatedAt,
+	}
+}
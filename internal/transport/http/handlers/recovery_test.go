@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/recovery"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func makeRecoveryHandlers(recoverySvc *mocks.RecoveryServiceMock) *Handlers {
+	return &Handlers{
+		recoveryService: recoverySvc,
+		logger:          zap.NewNop(),
+	}
+}
+
+func TestHandlers_SetRecovery(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"success", nil, http.StatusOK},
+		{"invalid threshold", recovery.ErrInvalidThreshold, http.StatusBadRequest},
+		{"holder is self", recovery.ErrHolderIsSelf, http.StatusBadRequest},
+		{"no key pair", recovery.ErrNoKeyPair, http.StatusNotFound},
+		{"holder not found", user.ErrUserNotFound, http.StatusNotFound},
+		{"key mismatch", recovery.ErrKeyMismatch, http.StatusConflict},
+		{"holder without key", recovery.ErrNoPublicKey, http.StatusConflict},
+		{"internal", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			recoverySvc := new(mocks.RecoveryServiceMock)
+			h := makeRecoveryHandlers(recoverySvc)
+
+			var setup *recovery.Setup
+			if tc.err == nil {
+				setup = &recovery.Setup{UserID: 1, KeyMaterial: []byte("material"), Threshold: 2, Shares: 3, Holders: []string{"bob"}}
+			}
+			held := []recovery.HeldShare{{Login: "bob", WrappedKey: []byte("wrapped"), Nonce: []byte("nonce"), Data: []byte("data")}}
+			recoverySvc.On("Setup", mock.Anything, int64(1), []byte("public"), []byte("material"), 2, 3, held).Return(setup, tc.err)
+
+			body := `{"public_key":"cHVibGlj","key_material":"bWF0ZXJpYWw=","threshold":2,"shares":3,` +
+				`"held":[{"login":"bob","wrapped_key":"d3JhcHBlZA==","nonce":"bm9uY2U=","data":"ZGF0YQ=="}]}`
+			req := httptest.NewRequest(http.MethodPut, "/api/recovery", strings.NewReader(body))
+			req = req.WithContext(contextWithUserID(1))
+			rec := httptest.NewRecorder()
+
+			h.SetRecovery(rec, req)
+
+			assert.Equal(t, tc.code, rec.Code)
+			if tc.err == nil {
+				var resp dto.RecoveryResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, []string{"bob"}, resp.Holders)
+				assert.Nil(t, resp.KeyMaterial)
+			}
+		})
+	}
+}
+
+func TestHandlers_SetRecovery_BadRequest(t *testing.T) {
+	recoverySvc := new(mocks.RecoveryServiceMock)
+	h := makeRecoveryHandlers(recoverySvc)
+
+	for _, body := range []string{`{`, `{"threshold":2,"shares":3,"held":[null]}`} {
+		req := httptest.NewRequest(http.MethodPut, "/api/recovery", strings.NewReader(body))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.SetRecovery(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+	recoverySvc.AssertNotCalled(t, "Setup", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandlers_GetRecovery(t *testing.T) {
+	recoverySvc := new(mocks.RecoveryServiceMock)
+	h := makeRecoveryHandlers(recoverySvc)
+
+	recoverySvc.On("Get", mock.Anything, int64(1)).Return(&recovery.Setup{KeyMaterial: []byte("material"), Threshold: 2, Shares: 3}, nil).Once()
+	recoverySvc.On("Get", mock.Anything, int64(1)).Return(nil, recovery.ErrNotSetUp)
+
+	rec := httptest.NewRecorder()
+	h.GetRecovery(rec, httptest.NewRequest(http.MethodGet, "/api/recovery", nil).WithContext(contextWithUserID(1)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	var resp dto.RecoveryResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, []byte("material"), resp.KeyMaterial)
+
+	rec = httptest.NewRecorder()
+	h.GetRecovery(rec, httptest.NewRequest(http.MethodGet, "/api/recovery", nil).WithContext(contextWithUserID(1)))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHandlers_GetHeldRecoveryShare(t *testing.T) {
+	recoverySvc := new(mocks.RecoveryServiceMock)
+	h := makeRecoveryHandlers(recoverySvc)
+
+	shareUUID := uuid.New()
+	recoverySvc.On("GetHeld", mock.Anything, int64(2), shareUUID.String()).
+		Return(&recovery.Share{ShareUUID: shareUUID, OwnerLogin: "alice", HolderLogin: "bob", WrappedKey: []byte("wrapped"), Nonce: []byte("nonce"), Data: []byte("data")}, nil)
+	recoverySvc.On("GetHeld", mock.Anything, int64(2), "missing").Return(nil, recovery.ErrNotFound)
+
+	get := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/recovery/held/"+id, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uuid", id)
+		req = req.WithContext(context.WithValue(contextWithUserID(2), chi.RouteCtxKey, rctx))
+		rec := httptest.NewRecorder()
+		h.GetHeldRecoveryShare(rec, req)
+		return rec
+	}
+
+	rec := get(shareUUID.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.HeldRecoveryShareResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "alice", resp.Owner)
+	assert.Equal(t, []byte("data"), resp.Data)
+
+	assert.Equal(t, http.StatusNotFound, get("missing").Code)
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/send"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+// CreateSend stores the ciphertext of a one-time secret link on behalf of the
+// user.
+//
+// The client encrypts the secret and puts the key into the fragment of the
+// link, so the server never receives it.
+//
+// Body (JSON):
+//
+//	{
+//	  "nonce": "base64",
+//	  "data": "base64, the encrypted secret",
+//	  "max_views": 1,
+//	  "expires_in_seconds": 3600
+//	}
+//
+// Status codes:
+//
+//	201 Created – the send was created.
+//	400 BadRequest – invalid JSON, payload, view limit or lifetime.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) CreateSend(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.CreateSendRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.logger.Error(ErrBadRequest.Error(), zap.Error(err))
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	ttl := time.Duration(reqObj.ExpiresInSeconds) * time.Second
+	sn, err := h.sendService.Create(ctx, userId, reqObj.Nonce, reqObj.Data, reqObj.MaxViews, ttl)
+	if err != nil {
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := mapSend(sn)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetSends returns the sends of the user without their ciphertext.
+//
+// Status codes:
+//
+//	200 OK – the send list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetSends(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.sendService.List(ctx, userId)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.SendsResponse{
+		Sends: make([]*dto.SendRecord, 0, len(list)),
+	}
+	for _, sn := range list {
+		mapped := mapSend(sn)
+		respObj.Sends = append(respObj.Sends, &mapped)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// RevokeSend deletes a send of the user; its link stops working immediately.
+//
+// URL parameters:
+//
+//	id – the send UUID.
+//
+// Status codes:
+//
+//	204 NoContent – the send was revoked.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	404 NotFound – send not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) RevokeSend(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.sendService.Revoke(ctx, userId, chi.URLParam(r, "id")); err != nil {
+		if errors.Is(err, send.ErrNotFound) {
+			h.PublicError(w, http.StatusNotFound, ErrNotFound)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// OpenSend returns the ciphertext of a send and takes one of its views. It
+// requires no authentication: the link is the credential.
+//
+// URL parameters:
+//
+//	id – the send UUID.
+//
+// Status codes:
+//
+//	200 OK – the ciphertext was returned; the last view deletes the send.
+//	404 NotFound – the send is unknown, expired or used up.
+//	429 TooManyRequests – too many failed attempts from this address.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) OpenSend(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	sn, err := h.sendService.Open(ctx, chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, send.ErrNotFound) {
+			h.PublicError(w, http.StatusNotFound, ErrNotFound)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.SendResponse{
+		Nonce:     sn.Nonce,
+		Data:      sn.Data,
+		ViewsLeft: sn.MaxViews - sn.Views,
+		ExpiresAt: sn.ExpiresAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// mapSend converts a stored send into its public representation.
+func mapSend(sn *send.Send) dto.SendRecord {
+	return dto.SendRecord{
+		SendUUID:  sn.SendUUID,
+		MaxViews:  sn.MaxViews,
+		Views:     sn.Views,
+		ExpiresAt: sn.ExpiresAt,
+		CreatedAt: sn.CreatedAt,
+	}
+}
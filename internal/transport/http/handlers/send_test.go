@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/send"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func makeSendHandlers(sendSvc *mocks.SendServiceMock) *Handlers {
+	return &Handlers{
+		sendService: sendSvc,
+		logger:      zap.NewNop(),
+	}
+}
+
+func TestHandlers_CreateSend(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		sendSvc := new(mocks.SendServiceMock)
+		h := makeSendHandlers(sendSvc)
+
+		id := uuid.New()
+		sendSvc.On("Create", mock.Anything, int64(1), []byte("nonce"), []byte("data"), 1, time.Hour).
+			Return(&send.Send{SendUUID: id, MaxViews: 1}, nil)
+
+		body := `{"nonce":"bm9uY2U=","data":"ZGF0YQ==","max_views":1,"expires_in_seconds":3600}`
+		req := httptest.NewRequest(http.MethodPost, "/api/send", strings.NewReader(body))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.CreateSend(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var resp dto.SendRecord
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, id, resp.SendUUID)
+		assert.NotContains(t, rec.Body.String(), "ZGF0YQ==")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		sendSvc := new(mocks.SendServiceMock)
+		h := makeSendHandlers(sendSvc)
+
+		sendSvc.On("Create", mock.Anything, int64(1), []byte("nonce"), []byte("data"), 0, time.Duration(0)).
+			Return(nil, send.ErrInvalidMaxViews)
+
+		body := `{"nonce":"bm9uY2U=","data":"ZGF0YQ==","max_views":0}`
+		req := httptest.NewRequest(http.MethodPost, "/api/send", strings.NewReader(body))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.CreateSend(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandlers_OpenSend(t *testing.T) {
+	sendSvc := new(mocks.SendServiceMock)
+	h := makeSendHandlers(sendSvc)
+
+	id := uuid.New()
+	sendSvc.On("Open", mock.Anything, id.String()).Return(&send.Send{
+		SendUUID: id,
+		Nonce:    []byte("nonce"),
+		Data:     []byte("data"),
+		MaxViews: 3,
+		Views:    1,
+	}, nil)
+	sendSvc.On("Open", mock.Anything, "gone").Return(nil, send.ErrNotFound)
+
+	open := func(sendID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/send/"+sendID, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", sendID)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rec := httptest.NewRecorder()
+
+		h.OpenSend(rec, req)
+		return rec
+	}
+
+	rec := open(id.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	var resp dto.SendResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, []byte("data"), resp.Data)
+	assert.Equal(t, 2, resp.ViewsLeft)
+
+	rec = open("gone")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,361 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/serviceaccount"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ExchangeServiceToken issues an access token to a service account.
+//
+// Body (JSON), client credentials grant:
+//
+//	{
+//	  "grant_type": "client_credentials",
+//	  "client_id": "uuid",
+//	  "client_secret": "string"
+//	}
+//
+// Body (JSON), JWT bearer grant with an assertion signed by the registered key:
+//
+//	{
+//	  "grant_type": "urn:ietf:params:oauth:grant-type:jwt-bearer",
+//	  "assertion": "string"
+//	}
+//
+// Status codes:
+//
+//	200 OK – the access token was issued.
+//	400 BadRequest – invalid JSON or unsupported grant type.
+//	401 Unauthorized – invalid client credentials or assertion.
+//	429 TooManyRequests – too many attempts from the address.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) ExchangeServiceToken(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.ServiceTokenRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.logger.Error(ErrBadRequest.Error(), zap.Error(err))
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var (
+		accessToken string
+		ttl         time.Duration
+	)
+	switch reqObj.GrantType {
+	case serviceaccount.GrantClientCredentials:
+		accessToken, ttl, err = h.serviceAccountService.ExchangeClientSecret(ctx, reqObj.ClientID, reqObj.ClientSecret)
+	case serviceaccount.GrantJWTBearer:
+		accessToken, ttl, err = h.serviceAccountService.ExchangeAssertion(ctx, reqObj.Assertion)
+	default:
+		h.PublicError(w, http.StatusBadRequest, serviceaccount.ErrUnsupportedGrantType)
+		return
+	}
+	if err != nil {
+		var ae *apperr.AuthError
+		if errors.As(err, &ae) {
+			h.PublicError(w, http.StatusUnauthorized, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.ServiceTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(ttl.Seconds()),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// CreateServiceAccount registers a new service account of the user.
+//
+// The client secret is returned only in this response; the server keeps its hash.
+//
+// Body (JSON):
+//
+//	{
+//	  "name": "string",
+//	  "public_key": "PEM-encoded Ed25519 or P-256 public key, optional"
+//	}
+//
+// Status codes:
+//
+//	201 Created – the service account was created.
+//	400 BadRequest – invalid JSON, name or public key.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.CreateServiceAccountRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.logger.Error(ErrBadRequest.Error(), zap.Error(err))
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	account, secret, err := h.serviceAccountService.Create(ctx, userId, reqObj.Name, reqObj.PublicKey)
+	if err != nil {
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.CreateServiceAccountResponse{
+		ServiceAccountRecord: mapServiceAccount(account),
+		ClientSecret:         secret,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetServiceAccounts returns the service accounts of the user without their secrets.
+//
+// Status codes:
+//
+//	200 OK – the service account list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.serviceAccountService.List(ctx, userId)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.ServiceAccountsResponse{
+		ServiceAccounts: make([]*dto.ServiceAccountRecord, 0, len(list)),
+	}
+	for _, account := range list {
+		mapped := mapServiceAccount(account)
+		respObj.ServiceAccounts = append(respObj.ServiceAccounts, &mapped)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// DeleteServiceAccount removes a service account of the user with all its grants.
+//
+// URL parameters:
+//
+//	client_id – the service account client ID.
+//
+// Status codes:
+//
+//	204 NoContent – the service account was deleted.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – service account not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) DeleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.serviceAccountService.Delete(ctx, userId, chi.URLParam(r, "client_id")); err != nil {
+		if errors.Is(err, serviceaccount.ErrNotFound) {
+			h.PublicError(w, http.StatusNotFound, ErrNotFound)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetServiceAccountGrants returns the UUIDs of the entries a service account may read.
+//
+// URL parameters:
+//
+//	client_id – the service account client ID.
+//
+// Status codes:
+//
+//	200 OK – the grant list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – service account not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetServiceAccountGrants(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	keys, err := h.serviceAccountService.ListGrants(ctx, userId, chi.URLParam(r, "client_id"))
+	if err != nil {
+		if errors.Is(err, serviceaccount.ErrNotFound) {
+			h.PublicError(w, http.StatusNotFound, ErrNotFound)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.ServiceAccountGrantsResponse{Keys: keys}
+	if respObj.Keys == nil {
+		respObj.Keys = []uuid.UUID{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GrantServiceAccountKey allows a service account to read one of the user's entries.
+//
+// URL parameters:
+//
+//	client_id – the service account client ID.
+//	uuid – the entry UUID.
+//
+// Status codes:
+//
+//	204 NoContent – the entry was granted.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – service account or entry not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GrantServiceAccountKey(w http.ResponseWriter, r *http.Request) {
+	h.changeServiceAccountGrant(w, r, h.serviceAccountService.Grant)
+}
+
+// RevokeServiceAccountGrant withdraws the access of a service account to an entry.
+//
+// URL parameters:
+//
+//	client_id – the service account client ID.
+//	uuid – the entry UUID.
+//
+// Status codes:
+//
+//	204 NoContent – the grant was revoked.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – the entry is not granted to the service account.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) RevokeServiceAccountGrant(w http.ResponseWriter, r *http.Request) {
+	h.changeServiceAccountGrant(w, r, h.serviceAccountService.RevokeGrant)
+}
+
+// changeServiceAccountGrant applies a grant change taken from the URL parameters.
+func (h *Handlers) changeServiceAccountGrant(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, ownerID int64, clientID string, keyUUID string) error) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := change(ctx, userId, chi.URLParam(r, "client_id"), chi.URLParam(r, "uuid")); err != nil {
+		if errors.Is(err, serviceaccount.ErrGrantNotFound) {
+			h.PublicError(w, http.StatusNotFound, ErrNotFound)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// mapServiceAccount converts a stored service account into its public representation.
+func mapServiceAccount(account *serviceaccount.ServiceAccount) dto.ServiceAccountRecord {
+	return dto.ServiceAccountRecord{
+		ClientID:   account.ClientID,
+		Name:       account.Name,
+		PublicKey:  account.PublicKey,
+		LastUsedAt: account.LastUsedAt,
+		CreatedAt:  account.CreatedAt,
+	}
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/serviceaccount"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func makeServiceAccountHandlers(saSvc *mocks.ServiceAccountServiceMock) *Handlers {
+	return &Handlers{
+		serviceAccountService: saSvc,
+		logger:                zap.NewNop(),
+	}
+}
+
+func TestHandlers_ExchangeServiceToken(t *testing.T) {
+	t.Run("client credentials", func(t *testing.T) {
+		saSvc := new(mocks.ServiceAccountServiceMock)
+		h := makeServiceAccountHandlers(saSvc)
+
+		clientID := uuid.New().String()
+		saSvc.On("ExchangeClientSecret", mock.Anything, clientID, "pks_secret").Return("access", 15*time.Minute, nil)
+
+		body := `{"grant_type":"client_credentials","client_id":"` + clientID + `","client_secret":"pks_secret"}`
+		rec := httptest.NewRecorder()
+		h.ExchangeServiceToken(rec, httptest.NewRequest(http.MethodPost, "/api/auth/token", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+		var resp dto.ServiceTokenResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "access", resp.AccessToken)
+		assert.Equal(t, "Bearer", resp.TokenType)
+		assert.Equal(t, int64(900), resp.ExpiresIn)
+	})
+
+	t.Run("invalid assertion", func(t *testing.T) {
+		saSvc := new(mocks.ServiceAccountServiceMock)
+		h := makeServiceAccountHandlers(saSvc)
+
+		saSvc.On("ExchangeAssertion", mock.Anything, "jwt").Return("", time.Duration(0), serviceaccount.ErrInvalidAssertion)
+
+		body := `{"grant_type":"` + serviceaccount.GrantJWTBearer + `","assertion":"jwt"}`
+		rec := httptest.NewRecorder()
+		h.ExchangeServiceToken(rec, httptest.NewRequest(http.MethodPost, "/api/auth/token", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("unsupported grant type", func(t *testing.T) {
+		h := makeServiceAccountHandlers(new(mocks.ServiceAccountServiceMock))
+
+		rec := httptest.NewRecorder()
+		h.ExchangeServiceToken(rec, httptest.NewRequest(http.MethodPost, "/api/auth/token", strings.NewReader(`{"grant_type":"password"}`)))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandlers_CreateServiceAccount(t *testing.T) {
+	saSvc := new(mocks.ServiceAccountServiceMock)
+	h := makeServiceAccountHandlers(saSvc)
+
+	account := &serviceaccount.ServiceAccount{ClientID: uuid.New(), Name: "deployer", CreatedAt: time.Now()}
+	saSvc.On("Create", mock.Anything, int64(1), "deployer", "").Return(account, "pks_secret", nil)
+	saSvc.On("Create", mock.Anything, int64(1), "", "").Return(nil, "", serviceaccount.ErrNameEmpty)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/service-accounts", strings.NewReader(`{"name":"deployer"}`))
+	req = req.WithContext(contextWithUserID(1))
+	rec := httptest.NewRecorder()
+	h.CreateServiceAccount(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var resp dto.CreateServiceAccountResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, account.ClientID, resp.ClientID)
+	assert.Equal(t, "pks_secret", resp.ClientSecret)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/service-accounts", strings.NewReader(`{"name":""}`))
+	req = req.WithContext(contextWithUserID(1))
+	rec = httptest.NewRecorder()
+	h.CreateServiceAccount(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandlers_GrantServiceAccountKey(t *testing.T) {
+	saSvc := new(mocks.ServiceAccountServiceMock)
+	h := makeServiceAccountHandlers(saSvc)
+
+	clientID, keyUUID := uuid.New().String(), uuid.New().String()
+	saSvc.On("Grant", mock.Anything, int64(1), clientID, keyUUID).Return(nil).Once()
+	saSvc.On("Grant", mock.Anything, int64(1), clientID, keyUUID).Return(serviceaccount.ErrGrantNotFound).Once()
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPut, "/api/service-accounts/"+clientID+"/grants/"+keyUUID, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("client_id", clientID)
+		rctx.URLParams.Add("uuid", keyUUID)
+		return req.WithContext(context.WithValue(contextWithUserID(1), chi.RouteCtxKey, rctx))
+	}
+
+	rec := httptest.NewRecorder()
+	h.GrantServiceAccountKey(rec, newRequest())
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.GrantServiceAccountKey(rec, newRequest())
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
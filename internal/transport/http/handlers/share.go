@@ -0,0 +1,424 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/share"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SetKeyPair stores the sharing key pair of the user. Entries shared with the
+// user for a previous public key are removed.
+//
+// Body (JSON):
+//
+//	{
+//	  "public_key": "base64, 32 bytes X25519",
+//	  "key_material": "base64, the client-wrapped private key"
+//	}
+//
+// Status codes:
+//
+//	204 NoContent – the key pair was stored.
+//	400 BadRequest – invalid JSON, public key or key material.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) SetKeyPair(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.SetKeyPairRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.shareService.SetKeyPair(ctx, userId, reqObj.PublicKey, reqObj.KeyMaterial); err != nil {
+		h.shareError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetKeyPair returns the sharing key pair of the user with the private key
+// still wrapped by the client.
+//
+// Status codes:
+//
+//	200 OK – the key pair was returned.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – the user has no key pair.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetKeyPair(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	publicKey, keyMaterial, err := h.shareService.KeyPair(ctx, userId)
+	if err != nil {
+		h.shareError(w, err)
+		return
+	}
+
+	respObj := dto.KeyPairResponse{
+		PublicKey:   publicKey,
+		KeyMaterial: keyMaterial,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetPublicKey returns the public sharing key of a user.
+//
+// URL parameters:
+//
+//	login – the login of the user.
+//
+// Status codes:
+//
+//	200 OK – the public key was returned.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – the user does not exist.
+//	409 Conflict – the user has no sharing key yet.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetPublicKey(w http.ResponseWriter, r *http.Request) {
+	if _, ok := middleware.GetUserIDFromCtx(r.Context()); !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	login := chi.URLParam(r, "login")
+
+	publicKey, err := h.shareService.PublicKey(ctx, login)
+	if err != nil {
+		h.shareError(w, err)
+		return
+	}
+
+	respObj := dto.PublicKeyResponse{
+		Login:     login,
+		PublicKey: publicKey,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// ShareKey shares a personal entry of the user with another user. The entry
+// is sealed by the client for the public key of the recipient; sharing it
+// again with the same user replaces the sealed copy.
+//
+// URL parameters:
+//
+//	uuid – the entry UUID.
+//
+// Body (JSON):
+//
+//	{
+//	  "login": "string",
+//	  "wrapped_key": "base64, the entry key sealed for the recipient",
+//	  "nonce": "base64",
+//	  "data": "base64, the entry encrypted with the entry key"
+//	}
+//
+// Status codes:
+//
+//	201 Created – the entry was shared.
+//	400 BadRequest – invalid JSON or sealed payload, or sharing with oneself.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – entry or user not found.
+//	409 Conflict – the recipient has no sharing key yet.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) ShareKey(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.ShareKeyRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	sh, err := h.shareService.Share(ctx, userId, chi.URLParam(r, "uuid"), reqObj.Login, reqObj.WrappedKey, reqObj.Nonce, reqObj.Data)
+	if err != nil {
+		h.shareError(w, err)
+		return
+	}
+
+	respObj := mapShare(sh)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetKeyShares returns the users a personal entry of the user is shared with.
+//
+// URL parameters:
+//
+//	uuid – the entry UUID.
+//
+// Status codes:
+//
+//	200 OK – the share list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – entry not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetKeyShares(w http.ResponseWriter, r *http.Request) {
+	h.listShares(w, r, func(ctx context.Context, userID int64) ([]*share.Share, error) {
+		return h.shareService.Recipients(ctx, userID, chi.URLParam(r, "uuid"))
+	})
+}
+
+// RevokeKeyShare stops sharing a personal entry of the user with a user.
+//
+// URL parameters:
+//
+//	uuid – the entry UUID.
+//	login – the login of the recipient.
+//
+// Status codes:
+//
+//	204 NoContent – the share was revoked.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – entry or share not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) RevokeKeyShare(w http.ResponseWriter, r *http.Request) {
+	h.deleteShare(w, r, func(ctx context.Context, userID int64) error {
+		return h.shareService.Revoke(ctx, userID, chi.URLParam(r, "uuid"), chi.URLParam(r, "login"))
+	})
+}
+
+// GetSharedKeys returns the entries other users shared with the user, without
+// their sealed payload.
+//
+// Status codes:
+//
+//	200 OK – the share list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetSharedKeys(w http.ResponseWriter, r *http.Request) {
+	h.listShares(w, r, h.shareService.SharedWithMe)
+}
+
+// GetSharedKey returns an entry shared with the user with its sealed payload,
+// which only the private key of the user opens.
+//
+// URL parameters:
+//
+//	uuid – the share UUID.
+//
+// Status codes:
+//
+//	200 OK – the share was returned.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – share not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetSharedKey(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	sh, err := h.shareService.GetShared(ctx, userId, chi.URLParam(r, "uuid"))
+	if err != nil {
+		h.shareError(w, err)
+		return
+	}
+
+	respObj := dto.SharedKeyResponse{
+		ShareRecord: mapShare(sh),
+		WrappedKey:  sh.WrappedKey,
+		Nonce:       sh.Nonce,
+		Data:        sh.Data,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// DismissSharedKey removes an entry shared with the user from their list.
+//
+// URL parameters:
+//
+//	uuid – the share UUID.
+//
+// Status codes:
+//
+//	204 NoContent – the share was removed.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – share not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) DismissSharedKey(w http.ResponseWriter, r *http.Request) {
+	h.deleteShare(w, r, func(ctx context.Context, userID int64) error {
+		return h.shareService.Dismiss(ctx, userID, chi.URLParam(r, "uuid"))
+	})
+}
+
+// listShares writes the shares returned by list.
+func (h *Handlers) listShares(w http.ResponseWriter, r *http.Request, list func(ctx context.Context, userID int64) ([]*share.Share, error)) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	shares, err := list(ctx, userId)
+	if err != nil {
+		h.shareError(w, err)
+		return
+	}
+
+	respObj := dto.SharesResponse{
+		Shares: make([]*dto.ShareRecord, 0, len(shares)),
+	}
+	for _, sh := range shares {
+		mapped := mapShare(sh)
+		respObj.Shares = append(respObj.Shares, &mapped)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// deleteShare runs remove and answers 204 on success.
+func (h *Handlers) deleteShare(w http.ResponseWriter, r *http.Request, remove func(ctx context.Context, userID int64) error) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := remove(ctx, userId); err != nil {
+		h.shareError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// shareError writes the response for an error of the share service.
+func (h *Handlers) shareError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, share.ErrNotFound), errors.Is(err, share.ErrEntryNotFound),
+		errors.Is(err, share.ErrNoKeyPair), errors.Is(err, user.ErrUserNotFound):
+		h.PublicError(w, http.StatusNotFound, err)
+		return
+	case errors.Is(err, share.ErrNoPublicKey):
+		h.PublicError(w, http.StatusConflict, err)
+		return
+	}
+
+	var ve *apperr.ValidationError
+	if errors.As(err, &ve) {
+		h.PublicError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	h.InternalError(w, err)
+}
+
+// mapShare converts a share into its public representation.
+func mapShare(sh *share.Share) dto.ShareRecord {
+	return dto.ShareRecord{
+		ShareUUID: sh.ShareUUID,
+		KeyUUID:   sh.KeyUUID,
+		KeyType:   sh.KeyType,
+		Title:     sh.Title,
+		From:      sh.OwnerLogin,
+		To:        sh.RecipientLogin,
+		CreatedAt: sh.CreatedAt,
+	}
+}
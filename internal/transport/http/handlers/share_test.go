@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/keychain"
+	"github.com/thxhix/passKeeper/internal/domain/share"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func makeShareHandlers(shareSvc *mocks.ShareServiceMock) *Handlers {
+	return &Handlers{
+		shareService: shareSvc,
+		logger:       zap.NewNop(),
+	}
+}
+
+func TestHandlers_ShareKey(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"success", nil, http.StatusCreated},
+		{"invalid payload", share.ErrInvalidPayload, http.StatusBadRequest},
+		{"with self", share.ErrShareWithSelf, http.StatusBadRequest},
+		{"entry not found", share.ErrEntryNotFound, http.StatusNotFound},
+		{"user not found", user.ErrUserNotFound, http.StatusNotFound},
+		{"recipient without key", share.ErrNoPublicKey, http.StatusConflict},
+		{"internal", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			shareSvc := new(mocks.ShareServiceMock)
+			h := makeShareHandlers(shareSvc)
+
+			keyUUID := uuid.New()
+			var sh *share.Share
+			if tc.err == nil {
+				sh = &share.Share{ShareUUID: uuid.New(), KeyUUID: keyUUID, KeyType: keychain.KeyText, OwnerLogin: "alice", RecipientLogin: "bob"}
+			}
+			shareSvc.On("Share", mock.Anything, int64(1), keyUUID.String(), "bob", []byte("wrapped"), []byte("nonce"), []byte("data")).
+				Return(sh, tc.err)
+
+			body := `{"login":"bob","wrapped_key":"d3JhcHBlZA==","nonce":"bm9uY2U=","data":"ZGF0YQ=="}`
+			req := httptest.NewRequest(http.MethodPost, "/api/keychain/"+keyUUID.String()+"/share", strings.NewReader(body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("uuid", keyUUID.String())
+			req = req.WithContext(context.WithValue(contextWithUserID(1), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			h.ShareKey(rec, req)
+
+			assert.Equal(t, tc.code, rec.Code)
+			if tc.err == nil {
+				var resp dto.ShareRecord
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, "bob", resp.To)
+				assert.NotContains(t, rec.Body.String(), "ZGF0YQ==")
+			}
+		})
+	}
+}
+
+func TestHandlers_GetSharedKey(t *testing.T) {
+	shareSvc := new(mocks.ShareServiceMock)
+	h := makeShareHandlers(shareSvc)
+
+	shareUUID := uuid.New()
+	shareSvc.On("GetShared", mock.Anything, int64(2), shareUUID.String()).Return(&share.Share{
+		ShareUUID:  shareUUID,
+		OwnerLogin: "alice",
+		WrappedKey: []byte("wrapped"),
+		Nonce:      []byte("nonce"),
+		Data:       []byte("data"),
+	}, nil)
+	shareSvc.On("GetShared", mock.Anything, int64(2), "other").Return((*share.Share)(nil), share.ErrNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/shared/"+shareUUID.String(), nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("uuid", shareUUID.String())
+	req = req.WithContext(context.WithValue(contextWithUserID(2), chi.RouteCtxKey, rctx))
+	rec := httptest.NewRecorder()
+
+	h.GetSharedKey(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	var resp dto.SharedKeyResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "alice", resp.From)
+	assert.Equal(t, []byte("data"), resp.Data)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/shared/other", nil)
+	rctx = chi.NewRouteContext()
+	rctx.URLParams.Add("uuid", "other")
+	req = req.WithContext(context.WithValue(contextWithUserID(2), chi.RouteCtxKey, rctx))
+	rec = httptest.NewRecorder()
+
+	h.GetSharedKey(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHandlers_KeyPair(t *testing.T) {
+	t.Run("set invalid", func(t *testing.T) {
+		shareSvc := new(mocks.ShareServiceMock)
+		h := makeShareHandlers(shareSvc)
+
+		shareSvc.On("SetKeyPair", mock.Anything, int64(1), []byte("short"), []byte("wrapped")).Return(share.ErrInvalidPublicKey)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/keys", strings.NewReader(`{"public_key":"c2hvcnQ=","key_material":"d3JhcHBlZA=="}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.SetKeyPair(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("get none", func(t *testing.T) {
+		shareSvc := new(mocks.ShareServiceMock)
+		h := makeShareHandlers(shareSvc)
+
+		shareSvc.On("KeyPair", mock.Anything, int64(1)).Return([]byte(nil), []byte(nil), share.ErrNoKeyPair)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/keys", nil)
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.GetKeyPair(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("public key", func(t *testing.T) {
+		shareSvc := new(mocks.ShareServiceMock)
+		h := makeShareHandlers(shareSvc)
+
+		publicKey := make([]byte, share.PublicKeySize)
+		shareSvc.On("PublicKey", mock.Anything, "bob").Return(publicKey, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/keys/bob", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("login", "bob")
+		req = req.WithContext(context.WithValue(contextWithUserID(1), chi.RouteCtxKey, rctx))
+		rec := httptest.NewRecorder()
+
+		h.GetPublicKey(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp dto.PublicKeyResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, publicKey, resp.PublicKey)
+	})
+}
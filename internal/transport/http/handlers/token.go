@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+// CreatePersonalToken issues a new scoped personal access token.
+//
+// The plain token is returned only in this response; the server keeps its hash.
+//
+// Body (JSON):
+//
+//	{
+//	  "name": "string",
+//	  "scopes": ["read:tag:prod", "write:text"],
+//	  "expires_at": "RFC3339 time, optional"
+//	}
+//
+// Status codes:
+//
+//	201 Created – the token was created.
+//	400 BadRequest – invalid JSON, name, scope or expiry.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) CreatePersonalToken(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.CreatePersonalTokenRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.logger.Error(ErrBadRequest.Error(), zap.Error(err))
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	record, token, err := h.tokenService.Create(ctx, userId, reqObj.Name, reqObj.Scopes, reqObj.ExpiresAt)
+	if err != nil {
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.CreatePersonalTokenResponse{
+		PersonalTokenRecord: mapPersonalToken(record),
+		Token:               token,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetPersonalTokens returns the personal access tokens of the user without
+// their secret values.
+//
+// Status codes:
+//
+//	200 OK – the token list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetPersonalTokens(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.tokenService.List(ctx, userId)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.PersonalTokensResponse{
+		Tokens: make([]*dto.PersonalTokenRecord, 0, len(list)),
+	}
+	for _, record := range list {
+		mapped := mapPersonalToken(record)
+		respObj.Tokens = append(respObj.Tokens, &mapped)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// RevokePersonalToken deletes a personal access token of the user.
+//
+// URL parameters:
+//
+//	uuid – the token UUID.
+//
+// Status codes:
+//
+//	204 NoContent – the token was revoked.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was authenticated with a personal access token.
+//	404 NotFound – token not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) RevokePersonalToken(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.tokenService.Revoke(ctx, userId, chi.URLParam(r, "uuid")); err != nil {
+		if errors.Is(err, pat.ErrTokenNotFound) {
+			h.PublicError(w, http.StatusNotFound, ErrNotFound)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// mapPersonalToken converts a stored token into its public representation.
+func mapPersonalToken(record *pat.PersonalTokenRecord) dto.PersonalTokenRecord {
+	return dto.PersonalTokenRecord{
+		TokenUUID:  record.TokenUUID,
+		Name:       record.Name,
+		Scopes:     record.Scopes,
+		ExpiresAt:  record.ExpiresAt,
+		LastUsedAt: record.LastUsedAt,
+		CreatedAt:  record.CreatedAt,
+	}
+}
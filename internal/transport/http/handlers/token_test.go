@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func makeTokenHandlers(tokenSvc *mocks.PersonalTokenServiceMock) *Handlers {
+	return &Handlers{
+		tokenService: tokenSvc,
+		logger:       zap.NewNop(),
+	}
+}
+
+func TestHandlers_CreatePersonalToken(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tokenSvc := new(mocks.PersonalTokenServiceMock)
+		h := makeTokenHandlers(tokenSvc)
+
+		record := &pat.PersonalTokenRecord{TokenUUID: uuid.New(), Name: "ci", Scopes: []string{"read:tag:prod"}, CreatedAt: time.Now()}
+		tokenSvc.On("Create", mock.Anything, int64(1), "ci", []string{"read:tag:prod"}, (*time.Time)(nil)).
+			Return(record, pat.TokenPrefix+"secret", nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/tokens", strings.NewReader(`{"name":"ci","scopes":["read:tag:prod"]}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.CreatePersonalToken(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+		var resp dto.CreatePersonalTokenResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, pat.TokenPrefix+"secret", resp.Token)
+		assert.Equal(t, record.TokenUUID, resp.TokenUUID)
+		assert.Equal(t, []string{"read:tag:prod"}, resp.Scopes)
+		tokenSvc.AssertExpectations(t)
+	})
+
+	t.Run("invalid scope", func(t *testing.T) {
+		tokenSvc := new(mocks.PersonalTokenServiceMock)
+		h := makeTokenHandlers(tokenSvc)
+
+		tokenSvc.On("Create", mock.Anything, int64(1), "ci", []string{"admin"}, (*time.Time)(nil)).
+			Return(nil, "", pat.ErrInvalidScope)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/tokens", strings.NewReader(`{"name":"ci","scopes":["admin"]}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.CreatePersonalToken(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("bad json", func(t *testing.T) {
+		h := makeTokenHandlers(new(mocks.PersonalTokenServiceMock))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/tokens", strings.NewReader(`{`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.CreatePersonalToken(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandlers_GetPersonalTokens(t *testing.T) {
+	tokenSvc := new(mocks.PersonalTokenServiceMock)
+	h := makeTokenHandlers(tokenSvc)
+
+	tokenSvc.On("List", mock.Anything, int64(1)).Return([]*pat.PersonalTokenRecord{
+		{TokenUUID: uuid.New(), Name: "ci", Scopes: []string{"read"}, TokenHash: "hash"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/tokens", nil)
+	req = req.WithContext(contextWithUserID(1))
+	rec := httptest.NewRecorder()
+
+	h.GetPersonalTokens(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "hash")
+
+	var resp dto.PersonalTokensResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp.Tokens, 1)
+}
+
+func TestHandlers_RevokePersonalToken(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"not found", pat.ErrTokenNotFound, http.StatusNotFound},
+		{"internal", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenSvc := new(mocks.PersonalTokenServiceMock)
+			h := makeTokenHandlers(tokenSvc)
+
+			tokenUUID := uuid.New().String()
+			tokenSvc.On("Revoke", mock.Anything, int64(1), tokenUUID).Return(tc.err)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/auth/tokens/"+tokenUUID, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("uuid", tokenUUID)
+			req = req.WithContext(context.WithValue(contextWithUserID(1), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			h.RevokePersonalToken(rec, req)
+
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,480 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+// CreateOrganization creates an organization owned by the user.
+//
+// Body (JSON):
+//
+//	{
+//	  "name": "string"
+//	}
+//
+// Status codes:
+//
+//	201 Created – the organization was created.
+//	400 BadRequest – invalid JSON or name.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.CreateOrganizationRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	org, err := h.vaultService.CreateOrganization(ctx, userId, reqObj.Name)
+	if err != nil {
+		h.vaultError(w, err)
+		return
+	}
+
+	respObj := mapOrganization(org)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetOrganizations returns the organizations the user is a member of.
+//
+// Status codes:
+//
+//	200 OK – the organization list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetOrganizations(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.vaultService.ListOrganizations(ctx, userId)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.OrganizationsResponse{
+		Organizations: make([]*dto.OrganizationRecord, 0, len(list)),
+	}
+	for _, org := range list {
+		mapped := mapOrganization(org)
+		respObj.Organizations = append(respObj.Organizations, &mapped)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetOrganizationMembers returns the members of an organization of the user.
+//
+// URL parameters:
+//
+//	org_uuid – the organization UUID.
+//
+// Status codes:
+//
+//	200 OK – the member list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – organization not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetOrganizationMembers(w http.ResponseWriter, r *http.Request) {
+	h.listMembers(w, r, "org_uuid", h.vaultService.OrganizationMembers)
+}
+
+// SetOrganizationMember adds a user to an organization or changes their role.
+//
+// URL parameters:
+//
+//	org_uuid – the organization UUID.
+//	login – the login of the member.
+//
+// Body (JSON):
+//
+//	{
+//	  "role": "owner|admin|editor|viewer"
+//	}
+//
+// Owners may assign any role, admins only editor and viewer.
+//
+// Status codes:
+//
+//	204 NoContent – the member was set.
+//	400 BadRequest – invalid JSON or role, or the last owner would be demoted.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the role of the user does not allow the change.
+//	404 NotFound – organization or user not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) SetOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	h.setMember(w, r, "org_uuid", h.vaultService.SetOrganizationMember)
+}
+
+// RemoveOrganizationMember removes a user from an organization. Any member
+// may remove themselves.
+//
+// URL parameters:
+//
+//	org_uuid – the organization UUID.
+//	login – the login of the member.
+//
+// Status codes:
+//
+//	204 NoContent – the member was removed.
+//	400 BadRequest – the last owner would be removed.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the role of the user does not allow the change.
+//	404 NotFound – organization or member not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) RemoveOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	h.removeMember(w, r, "org_uuid", h.vaultService.RemoveOrganizationMember)
+}
+
+// CreateVault creates a shared vault owned by the user.
+//
+// Body (JSON):
+//
+//	{
+//	  "name": "string",
+//	  "org": "uuid, optional – organization the vault belongs to"
+//	}
+//
+// Creating a vault in an organization requires an admin or owner role in it.
+//
+// Status codes:
+//
+//	201 Created – the vault was created.
+//	400 BadRequest – invalid JSON or name.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the organization role does not allow creating vaults.
+//	404 NotFound – organization not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) CreateVault(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.CreateVaultRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	v, err := h.vaultService.CreateVault(ctx, userId, reqObj.Name, reqObj.Org)
+	if err != nil {
+		h.vaultError(w, err)
+		return
+	}
+
+	respObj := mapVault(v)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetVaults returns the shared vaults the user has a role in.
+//
+// Status codes:
+//
+//	200 OK – the vault list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetVaults(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.vaultService.ListVaults(ctx, userId)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.VaultsResponse{
+		Vaults: make([]*dto.VaultRecord, 0, len(list)),
+	}
+	for _, v := range list {
+		mapped := mapVault(v)
+		respObj.Vaults = append(respObj.Vaults, &mapped)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetVaultMembers returns everyone with a role in a vault of the user,
+// including the members of its organization.
+//
+// URL parameters:
+//
+//	vault_uuid – the vault UUID.
+//
+// Status codes:
+//
+//	200 OK – the member list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – vault not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetVaultMembers(w http.ResponseWriter, r *http.Request) {
+	h.listMembers(w, r, "vault_uuid", h.vaultService.Members)
+}
+
+// ShareVault gives a user a role in a vault or changes their role.
+//
+// URL parameters:
+//
+//	vault_uuid – the vault UUID.
+//	login – the login of the member.
+//
+// Body (JSON):
+//
+//	{
+//	  "role": "owner|admin|editor|viewer"
+//	}
+//
+// Owners may assign any role, admins only editor and viewer.
+//
+// Status codes:
+//
+//	204 NoContent – the vault was shared.
+//	400 BadRequest – invalid JSON or role, or the last owner would be demoted.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the role of the user does not allow the change.
+//	404 NotFound – vault or user not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) ShareVault(w http.ResponseWriter, r *http.Request) {
+	h.setMember(w, r, "vault_uuid", h.vaultService.Share)
+}
+
+// UnshareVault removes the direct role of a user in a vault. Any member may
+// remove themselves.
+//
+// URL parameters:
+//
+//	vault_uuid – the vault UUID.
+//	login – the login of the member.
+//
+// Status codes:
+//
+//	204 NoContent – the member was removed.
+//	400 BadRequest – the last owner would be removed.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the role of the user does not allow the change.
+//	404 NotFound – vault or member not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) UnshareVault(w http.ResponseWriter, r *http.Request) {
+	h.removeMember(w, r, "vault_uuid", h.vaultService.Unshare)
+}
+
+// listMembers writes the members returned by list for the organization or
+// vault named by the URL parameter param.
+func (h *Handlers) listMembers(w http.ResponseWriter, r *http.Request, param string, list func(ctx context.Context, userID int64, uuid string) ([]*vault.Member, error)) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	members, err := list(ctx, userId, chi.URLParam(r, param))
+	if err != nil {
+		h.vaultError(w, err)
+		return
+	}
+
+	respObj := dto.MembersResponse{
+		Members: make([]*dto.MemberRecord, 0, len(members)),
+	}
+	for _, m := range members {
+		respObj.Members = append(respObj.Members, &dto.MemberRecord{
+			Login:     m.Login,
+			Role:      m.Role.String(),
+			Inherited: m.Inherited,
+			CreatedAt: m.CreatedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// setMember applies the role from the body to the member named by the URL
+// parameters.
+func (h *Handlers) setMember(w http.ResponseWriter, r *http.Request, param string, set func(ctx context.Context, userID int64, uuid string, login string, role string) error) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.SetMemberRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := set(ctx, userId, chi.URLParam(r, param), chi.URLParam(r, "login"), reqObj.Role); err != nil {
+		h.vaultError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// removeMember removes the member named by the URL parameters.
+func (h *Handlers) removeMember(w http.ResponseWriter, r *http.Request, param string, remove func(ctx context.Context, userID int64, uuid string, login string) error) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := remove(ctx, userId, chi.URLParam(r, param), chi.URLParam(r, "login")); err != nil {
+		h.vaultError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// vaultError maps errors of the vault service to responses.
+func (h *Handlers) vaultError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, vault.ErrNotFound), errors.Is(err, vault.ErrOrganizationNotFound),
+		errors.Is(err, vault.ErrMemberNotFound), errors.Is(err, user.ErrUserNotFound):
+		h.PublicError(w, http.StatusNotFound, err)
+		return
+	}
+
+	var ve *apperr.ValidationError
+	if errors.As(err, &ve) {
+		h.PublicError(w, http.StatusBadRequest, err)
+		return
+	}
+	var fe *apperr.ForbiddenError
+	if errors.As(err, &fe) {
+		h.PublicError(w, http.StatusForbidden, err)
+		return
+	}
+
+	h.InternalError(w, err)
+}
+
+// mapOrganization converts an organization into its public representation.
+func mapOrganization(org *vault.Organization) dto.OrganizationRecord {
+	return dto.OrganizationRecord{
+		OrgUUID:   org.OrgUUID,
+		Name:      org.Name,
+		Role:      org.Role.String(),
+		CreatedAt: org.CreatedAt,
+	}
+}
+
+// mapVault converts a vault into its public representation.
+func mapVault(v *vault.Vault) dto.VaultRecord {
+	return dto.VaultRecord{
+		VaultUUID: v.VaultUUID,
+		OrgUUID:   v.OrgUUID,
+		Name:      v.Name,
+		Role:      v.Role.String(),
+		CreatedAt: v.CreatedAt,
+	}
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/domain/vault"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func makeVaultHandlers(vaultSvc *mocks.VaultServiceMock) *Handlers {
+	return &Handlers{
+		vaultService: vaultSvc,
+		logger:       zap.NewNop(),
+	}
+}
+
+func TestHandlers_CreateVault(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		vaultSvc := new(mocks.VaultServiceMock)
+		h := makeVaultHandlers(vaultSvc)
+
+		orgUUID := uuid.New()
+		v := &vault.Vault{ID: 1, VaultUUID: uuid.New(), OrgID: new(int64), OrgUUID: &orgUUID, Name: "Ops", Role: vault.RoleOwner, CreatedAt: time.Now()}
+		vaultSvc.On("CreateVault", mock.Anything, int64(1), "Ops", orgUUID.String()).Return(v, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/vaults", strings.NewReader(`{"name":"Ops","org":"`+orgUUID.String()+`"}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.CreateVault(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var resp dto.VaultRecord
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, v.VaultUUID, resp.VaultUUID)
+		assert.Equal(t, &orgUUID, resp.OrgUUID)
+		assert.Equal(t, "owner", resp.Role)
+		vaultSvc.AssertExpectations(t)
+	})
+
+	t.Run("insufficient role in organization", func(t *testing.T) {
+		vaultSvc := new(mocks.VaultServiceMock)
+		h := makeVaultHandlers(vaultSvc)
+
+		vaultSvc.On("CreateVault", mock.Anything, int64(1), "Ops", "org").Return((*vault.Vault)(nil), vault.ErrInsufficientRole)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/vaults", strings.NewReader(`{"name":"Ops","org":"org"}`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.CreateVault(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("bad json", func(t *testing.T) {
+		h := makeVaultHandlers(new(mocks.VaultServiceMock))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/vaults", strings.NewReader(`{`))
+		req = req.WithContext(contextWithUserID(1))
+		rec := httptest.NewRecorder()
+
+		h.CreateVault(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandlers_ShareVault(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"invalid role", vault.ErrInvalidRole, http.StatusBadRequest},
+		{"last owner", vault.ErrLastOwner, http.StatusBadRequest},
+		{"insufficient role", vault.ErrInsufficientRole, http.StatusForbidden},
+		{"vault not found", vault.ErrNotFound, http.StatusNotFound},
+		{"user not found", user.ErrUserNotFound, http.StatusNotFound},
+		{"internal", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			vaultSvc := new(mocks.VaultServiceMock)
+			h := makeVaultHandlers(vaultSvc)
+
+			vaultUUID := uuid.New().String()
+			vaultSvc.On("Share", mock.Anything, int64(1), vaultUUID, "bob", "editor").Return(tc.err)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/vaults/"+vaultUUID+"/members/bob", strings.NewReader(`{"role":"editor"}`))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("vault_uuid", vaultUUID)
+			rctx.URLParams.Add("login", "bob")
+			req = req.WithContext(context.WithValue(contextWithUserID(1), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			h.ShareVault(rec, req)
+
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+}
+
+func TestHandlers_GetVaultMembers(t *testing.T) {
+	vaultSvc := new(mocks.VaultServiceMock)
+	h := makeVaultHandlers(vaultSvc)
+
+	vaultUUID := uuid.New().String()
+	vaultSvc.On("Members", mock.Anything, int64(1), vaultUUID).Return([]*vault.Member{
+		{UserID: 1, Login: "alice", Role: vault.RoleOwner, Inherited: true},
+		{UserID: 2, Login: "bob", Role: vault.RoleViewer},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/vaults/"+vaultUUID+"/members", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("vault_uuid", vaultUUID)
+	req = req.WithContext(context.WithValue(contextWithUserID(1), chi.RouteCtxKey, rctx))
+	rec := httptest.NewRecorder()
+
+	h.GetVaultMembers(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.MembersResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp.Members, 2)
+	assert.True(t, resp.Members[0].Inherited)
+	assert.Equal(t, "viewer", resp.Members[1].Role)
+}
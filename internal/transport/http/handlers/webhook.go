@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+// CreateWebhook subscribes the user to security and vault events.
+//
+// Deliveries are POSTed as JSON and signed with HMAC-SHA256 of the signing
+// secret, which is returned only in this response.
+//
+// Body (JSON):
+//
+//	{
+//	  "url": "https://...",
+//	  "events": ["login.locked", "refresh.replayed", "key.read", "key.delete"],
+//	  "tags": ["prod"] – optional, limits entry events to entries with one of the tags
+//	}
+//
+// Status codes:
+//
+//	201 Created – the subscription was created.
+//	400 BadRequest – invalid JSON, url, events or tags, or too many subscriptions.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	h.createWebhook(w, r, userId)
+}
+
+// CreateGlobalWebhook creates a global subscription, which receives the
+// events of every user. The body and response are those of CreateWebhook.
+//
+// Status codes:
+//
+//	201 Created – the subscription was created.
+//	400 BadRequest – invalid JSON, url, events or tags, or too many subscriptions.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the user is not an administrator.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) CreateGlobalWebhook(w http.ResponseWriter, r *http.Request) {
+	h.createWebhook(w, r, webhook.GlobalOwner)
+}
+
+func (h *Handlers) createWebhook(w http.ResponseWriter, r *http.Request, userId int64) {
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	reqObj := dto.CreateWebhookRequest{}
+	if err := easyjson.Unmarshal(body, &reqObj); err != nil {
+		h.logger.Error(ErrBadRequest.Error(), zap.Error(err))
+		h.PublicError(w, http.StatusBadRequest, ErrBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	sub, secret, err := h.webhookService.Create(ctx, userId, reqObj.URL, reqObj.Events, reqObj.Tags)
+	if err != nil {
+		var ve *apperr.ValidationError
+		if errors.As(err, &ve) {
+			h.PublicError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.CreateWebhookResponse{
+		WebhookRecord: mapWebhook(sub),
+		Secret:        secret,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// GetWebhooks returns the webhook subscriptions of the user without their secrets.
+//
+// Status codes:
+//
+//	200 OK – the subscription list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	h.getWebhooks(w, r, userId)
+}
+
+// GetGlobalWebhooks returns the global subscriptions without their secrets.
+//
+// Status codes:
+//
+//	200 OK – the subscription list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the user is not an administrator.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetGlobalWebhooks(w http.ResponseWriter, r *http.Request) {
+	h.getWebhooks(w, r, webhook.GlobalOwner)
+}
+
+func (h *Handlers) getWebhooks(w http.ResponseWriter, r *http.Request, userId int64) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.webhookService.List(ctx, userId)
+	if err != nil {
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.WebhooksResponse{
+		Webhooks: make([]*dto.WebhookRecord, 0, len(list)),
+	}
+	for _, sub := range list {
+		mapped := mapWebhook(sub)
+		respObj.Webhooks = append(respObj.Webhooks, &mapped)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+// DeleteWebhook removes a webhook subscription of the user and drops its
+// pending deliveries.
+//
+// URL parameters:
+//
+//	webhook_id – the subscription ID.
+//
+// Status codes:
+//
+//	204 NoContent – the subscription was deleted.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – subscription not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	h.deleteWebhook(w, r, userId)
+}
+
+// DeleteGlobalWebhook removes a global subscription and drops its pending
+// deliveries.
+//
+// URL parameters:
+//
+//	webhook_id – the subscription ID.
+//
+// Status codes:
+//
+//	204 NoContent – the subscription was deleted.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the user is not an administrator.
+//	404 NotFound – subscription not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) DeleteGlobalWebhook(w http.ResponseWriter, r *http.Request) {
+	h.deleteWebhook(w, r, webhook.GlobalOwner)
+}
+
+func (h *Handlers) deleteWebhook(w http.ResponseWriter, r *http.Request, userId int64) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.webhookService.Delete(ctx, userId, chi.URLParam(r, "webhook_id")); err != nil {
+		if errors.Is(err, webhook.ErrNotFound) {
+			h.PublicError(w, http.StatusNotFound, ErrNotFound)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries returns the latest deliveries of a webhook subscription
+// of the user with their attempt history, newest first.
+//
+// URL parameters:
+//
+//	webhook_id – the subscription ID.
+//
+// Status codes:
+//
+//	200 OK – the delivery list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the request was not made with a user session.
+//	404 NotFound – subscription not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	userId, ok := middleware.GetUserIDFromCtx(r.Context())
+	if !ok {
+		h.PublicError(w, http.StatusUnauthorized, ErrUnauthorizedError)
+		return
+	}
+
+	h.getWebhookDeliveries(w, r, userId)
+}
+
+// GetGlobalWebhookDeliveries returns the latest deliveries of a global
+// subscription, newest first.
+//
+// URL parameters:
+//
+//	webhook_id – the subscription ID.
+//
+// Status codes:
+//
+//	200 OK – the delivery list was returned successfully.
+//	401 Unauthorized – user is not authenticated.
+//	403 Forbidden – the user is not an administrator.
+//	404 NotFound – subscription not found.
+//	500 InternalServerError – internal service error.
+func (h *Handlers) GetGlobalWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	h.getWebhookDeliveries(w, r, webhook.GlobalOwner)
+}
+
+func (h *Handlers) getWebhookDeliveries(w http.ResponseWriter, r *http.Request, userId int64) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.webhookService.Deliveries(ctx, userId, chi.URLParam(r, "webhook_id"))
+	if err != nil {
+		if errors.Is(err, webhook.ErrNotFound) {
+			h.PublicError(w, http.StatusNotFound, ErrNotFound)
+			return
+		}
+		h.InternalError(w, err)
+		return
+	}
+
+	respObj := dto.WebhookDeliveriesResponse{
+		Deliveries: make([]*dto.WebhookDeliveryRecord, 0, len(list)),
+	}
+	for _, d := range list {
+		rec := &dto.WebhookDeliveryRecord{
+			ID:             d.DeliveryID,
+			Event:          string(d.EventType),
+			Status:         string(d.Status),
+			Attempts:       d.Attempts,
+			LastStatusCode: d.LastStatusCode,
+			LastError:      d.LastError,
+			CreatedAt:      d.CreatedAt,
+			DeliveredAt:    d.DeliveredAt,
+		}
+		if d.Status == webhook.StatusPending {
+			next := d.NextAttemptAt
+			rec.NextAttemptAt = &next
+		}
+		respObj.Deliveries = append(respObj.Deliveries, rec)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := easyjson.MarshalToWriter(&respObj, w); err != nil {
+		h.logger.Error(ErrCantWriteResponseBody.Error(), zap.Error(err))
+		return
+	}
+}
+
+func mapWebhook(sub *webhook.Subscription) dto.WebhookRecord {
+	events := make([]string, 0, len(sub.Events))
+	for _, e := range sub.Events {
+		events = append(events, string(e))
+	}
+
+	return dto.WebhookRecord{
+		ID:        sub.SubscriptionID,
+		URL:       sub.URL,
+		Events:    events,
+		Tags:      sub.Tags,
+		CreatedAt: sub.CreatedAt,
+	}
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thxhix/passKeeper/internal/domain/webhook"
+	"github.com/thxhix/passKeeper/internal/mocks"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func makeWebhookHandlers(webhookSvc *mocks.WebhookServiceMock) *Handlers {
+	return &Handlers{
+		webhookService: webhookSvc,
+		logger:         zap.NewNop(),
+	}
+}
+
+func withWebhookParam(ctx context.Context, webhookID string) context.Context {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("webhook_id", webhookID)
+	return context.WithValue(ctx, chi.RouteCtxKey, rctx)
+}
+
+func TestHandlers_CreateWebhook(t *testing.T) {
+	webhookSvc := new(mocks.WebhookServiceMock)
+	h := makeWebhookHandlers(webhookSvc)
+
+	sub := &webhook.Subscription{
+		SubscriptionID: uuid.New(),
+		URL:            "https://soc.example.com/hook",
+		Events:         []webhook.EventType{webhook.EventKeyRead},
+		Tags:           []string{"prod"},
+		CreatedAt:      time.Now(),
+	}
+	webhookSvc.On("Create", mock.Anything, int64(1), sub.URL, []string{"key.read"}, []string{"prod"}).Return(sub, "whsec_secret", nil)
+	webhookSvc.On("Create", mock.Anything, int64(1), "http://soc.example.com", []string{"key.read"}, []string(nil)).Return(nil, "", webhook.ErrInvalidURL)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhooks", strings.NewReader(`{"url":"https://soc.example.com/hook","events":["key.read"],"tags":["prod"]}`))
+	req = req.WithContext(contextWithUserID(1))
+	rec := httptest.NewRecorder()
+	h.CreateWebhook(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	var resp dto.CreateWebhookResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, sub.SubscriptionID, resp.ID)
+	assert.Equal(t, []string{"key.read"}, resp.Events)
+	assert.Equal(t, "whsec_secret", resp.Secret)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/webhooks", strings.NewReader(`{"url":"http://soc.example.com","events":["key.read"]}`))
+	req = req.WithContext(contextWithUserID(1))
+	rec = httptest.NewRecorder()
+	h.CreateWebhook(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandlers_DeleteWebhook(t *testing.T) {
+	webhookSvc := new(mocks.WebhookServiceMock)
+	h := makeWebhookHandlers(webhookSvc)
+
+	webhookID := uuid.New().String()
+	webhookSvc.On("Delete", mock.Anything, int64(1), webhookID).Return(nil).Once()
+	webhookSvc.On("Delete", mock.Anything, int64(1), webhookID).Return(webhook.ErrNotFound).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/webhooks/"+webhookID, nil)
+	req = req.WithContext(withWebhookParam(contextWithUserID(1), webhookID))
+
+	rec := httptest.NewRecorder()
+	h.DeleteWebhook(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.DeleteWebhook(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHandlers_GetWebhookDeliveries(t *testing.T) {
+	webhookSvc := new(mocks.WebhookServiceMock)
+	h := makeWebhookHandlers(webhookSvc)
+
+	webhookID := uuid.New().String()
+	next := time.Now().Add(time.Minute)
+	deliveries := []*webhook.Delivery{
+		{DeliveryID: uuid.New(), EventType: webhook.EventKeyRead, Status: webhook.StatusPending, Attempts: 2, NextAttemptAt: next, LastStatusCode: 502},
+		{DeliveryID: uuid.New(), EventType: webhook.EventKeyRead, Status: webhook.StatusDelivered, Attempts: 1, LastStatusCode: 200},
+	}
+	webhookSvc.On("Deliveries", mock.Anything, int64(1), webhookID).Return(deliveries, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/webhooks/"+webhookID+"/deliveries", nil)
+	req = req.WithContext(withWebhookParam(contextWithUserID(1), webhookID))
+	rec := httptest.NewRecorder()
+	h.GetWebhookDeliveries(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.WebhookDeliveriesResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp.Deliveries, 2)
+	assert.Equal(t, "pending", resp.Deliveries[0].Status)
+	assert.NotNil(t, resp.Deliveries[0].NextAttemptAt)
+	assert.Nil(t, resp.Deliveries[1].NextAttemptAt)
+	assert.Equal(t, 502, resp.Deliveries[0].LastStatusCode)
+}
+
+func TestHandlers_GlobalWebhooks(t *testing.T) {
+	webhookSvc := new(mocks.WebhookServiceMock)
+	h := makeWebhookHandlers(webhookSvc)
+
+	sub := &webhook.Subscription{
+		SubscriptionID: uuid.New(),
+		UserID:         webhook.GlobalOwner,
+		URL:            "https://soc.example.com/hook",
+		Events:         []webhook.EventType{webhook.EventLoginLocked},
+	}
+	webhookSvc.On("Create", mock.Anything, webhook.GlobalOwner, sub.URL, []string{"login.locked"}, []string(nil)).Return(sub, "whsec_secret", nil)
+	webhookSvc.On("List", mock.Anything, webhook.GlobalOwner).Return([]*webhook.Subscription{sub}, nil)
+	webhookSvc.On("Delete", mock.Anything, webhook.GlobalOwner, sub.SubscriptionID.String()).Return(nil)
+
+	// the admin's own user ID must not be used
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/webhooks", strings.NewReader(`{"url":"https://soc.example.com/hook","events":["login.locked"]}`))
+	req = req.WithContext(contextWithUserID(1))
+	rec := httptest.NewRecorder()
+	h.CreateGlobalWebhook(rec, req)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/admin/webhooks", nil)
+	req = req.WithContext(contextWithUserID(1))
+	rec = httptest.NewRecorder()
+	h.GetGlobalWebhooks(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var list dto.WebhooksResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Len(t, list.Webhooks, 1)
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/admin/webhooks/"+sub.SubscriptionID.String(), nil)
+	req = req.WithContext(withWebhookParam(contextWithUserID(1), sub.SubscriptionID.String()))
+	rec = httptest.NewRecorder()
+	h.DeleteGlobalWebhook(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	webhookSvc.AssertExpectations(t)
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/thxhix/passKeeper/internal/domain/audit"
+	"net/http"
+)
+
+// AuditInfo stores the client address and user agent of the request in its
+// context, so audit events recorded while serving it can name the client.
+func AuditInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := audit.WithRequestInfo(r.Context(), audit.RequestInfo{
+			IP:        clientIP(r),
+			UserAgent: r.UserAgent(),
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
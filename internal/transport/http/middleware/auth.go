@@ -2,14 +2,46 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/thxhix/passKeeper/internal/apperr"
+	"github.com/thxhix/passKeeper/internal/domain/admin"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"github.com/thxhix/passKeeper/internal/domain/principal"
 	"github.com/thxhix/passKeeper/internal/domain/token"
+	"github.com/thxhix/passKeeper/internal/domain/user"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// TokenParser verifies access tokens. clientID is set for tokens issued to a
+// service account, in which case userID is the account owner.
 type TokenParser interface {
-	ParseAccessToken(tokenStr string) (userID string, err error)
+	ParseAccessToken(tokenStr string) (userID string, issuedAt time.Time, expiresAt time.Time, clientID string, err error)
+}
+
+// RevocationChecker tells whether an access token issued at issuedAt to the
+// user has been revoked since.
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, userID int64, issuedAt time.Time) (bool, error)
+}
+
+// PersonalTokenAuthenticator resolves a personal access token to its owner and scopes.
+type PersonalTokenAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (userID int64, scopes pat.Scopes, err error)
+}
+
+// ServiceAccountChecker tells whether a service account may still use its
+// access tokens.
+type ServiceAccountChecker interface {
+	Exists(ctx context.Context, clientID uuid.UUID) (bool, error)
+}
+
+// AdminChecker tells whether a user holds the administrator role.
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, userID int64) (bool, error)
 }
 
 type HTTPErrorResponser interface {
@@ -21,7 +53,39 @@ type ctxKey string
 
 const CtxUserID ctxKey = "user_id"
 
-func Authorize(jwtManager TokenParser, errorResponser HTTPErrorResponser) func(http.Handler) http.Handler {
+const ctxCredential ctxKey = "credential"
+
+// Credential is what a request was authorized with. Requests that outlive
+// the authorization, such as event streams, must end at ExpiresAt, unless it
+// is zero, and once Recheck fails.
+type Credential struct {
+	ExpiresAt time.Time
+	// Recheck repeats the checks of Authorize that may change while the
+	// credential is in use, such as revocation. nil if there are none.
+	Recheck func(ctx context.Context) error
+}
+
+// WithCredential returns a copy of ctx carrying c.
+func WithCredential(ctx context.Context, c Credential) context.Context {
+	return context.WithValue(ctx, ctxCredential, c)
+}
+
+// CredentialFromCtx returns the credential stored by Authorize.
+func CredentialFromCtx(ctx context.Context) (Credential, bool) {
+	c, ok := ctx.Value(ctxCredential).(Credential)
+	return c, ok
+}
+
+// Authorize authenticates the request by its bearer access token. Besides the
+// signature and expiry it rejects tokens revoked by logout, password change or
+// account deletion, and tokens of deleted service accounts.
+//
+// The authenticated principal is stored in the request context (see
+// principal.FromContext), together with the Credential, so that long-lived
+// requests can repeat these checks. Bearer values starting with
+// pat.TokenPrefix are personal access tokens whose scopes are enforced by
+// the keychain service.
+func Authorize(jwtManager TokenParser, revocations RevocationChecker, personalTokens PersonalTokenAuthenticator, serviceAccounts ServiceAccountChecker, errorResponser HTTPErrorResponser) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			h := r.Header.Get("Authorization")
@@ -32,19 +96,148 @@ func Authorize(jwtManager TokenParser, errorResponser HTTPErrorResponser) func(h
 
 			providedToken := strings.TrimPrefix(h, "Bearer ")
 
-			userID, err := jwtManager.ParseAccessToken(providedToken)
+			if strings.HasPrefix(providedToken, pat.TokenPrefix) {
+				id, scopes, err := personalTokens.Authenticate(r.Context(), providedToken)
+				if err != nil {
+					respondAuthError(w, errorResponser, err)
+					return
+				}
+
+				ctx := context.WithValue(r.Context(), CtxUserID, strconv.FormatInt(id, 10))
+				ctx = principal.WithPrincipal(ctx, principal.PersonalToken(id, scopes))
+				ctx = WithCredential(ctx, Credential{Recheck: func(ctx context.Context) error {
+					_, _, err := personalTokens.Authenticate(ctx, providedToken)
+					return err
+				}})
+
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			userID, issuedAt, expiresAt, clientID, err := jwtManager.ParseAccessToken(providedToken)
 			if err != nil {
 				errorResponser.PublicError(w, http.StatusUnauthorized, token.ErrInvalidAuthToken)
 				return
 			}
 
+			id, err := strconv.ParseInt(userID, 10, 64)
+			if err != nil {
+				errorResponser.PublicError(w, http.StatusUnauthorized, token.ErrInvalidAuthToken)
+				return
+			}
+
+			var p principal.Principal
+			var recheck func(ctx context.Context) error
+			if clientID != "" {
+				client, err := uuid.Parse(clientID)
+				if err != nil {
+					errorResponser.PublicError(w, http.StatusUnauthorized, token.ErrInvalidAuthToken)
+					return
+				}
+				p = principal.ServiceAccount(id, client)
+				recheck = func(ctx context.Context) error {
+					return checkServiceAccount(ctx, serviceAccounts, client)
+				}
+			} else {
+				p = principal.User(id)
+				recheck = func(ctx context.Context) error {
+					return checkRevocation(ctx, revocations, id, issuedAt)
+				}
+			}
+
+			if err := recheck(r.Context()); err != nil {
+				respondAuthError(w, errorResponser, err)
+				return
+			}
+
 			ctx := context.WithValue(r.Context(), CtxUserID, userID)
+			ctx = principal.WithPrincipal(ctx, p)
+			ctx = WithCredential(ctx, Credential{ExpiresAt: expiresAt, Recheck: recheck})
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// checkRevocation returns token.ErrInvalidAuthToken if the access token of
+// the user issued at issuedAt has been revoked.
+func checkRevocation(ctx context.Context, revocations RevocationChecker, userID int64, issuedAt time.Time) error {
+	revoked, err := revocations.IsRevoked(ctx, userID, issuedAt)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return token.ErrInvalidAuthToken
+	}
+	return nil
+}
+
+// checkServiceAccount returns token.ErrInvalidAuthToken if the service
+// account clientID no longer exists.
+func checkServiceAccount(ctx context.Context, serviceAccounts ServiceAccountChecker, clientID uuid.UUID) error {
+	ok, err := serviceAccounts.Exists(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return token.ErrInvalidAuthToken
+	}
+	return nil
+}
+
+// respondAuthError answers 401 for authentication errors and 500 otherwise.
+func respondAuthError(w http.ResponseWriter, errorResponser HTTPErrorResponser, err error) {
+	var ae *apperr.AuthError
+	if errors.As(err, &ae) || errors.Is(err, token.ErrInvalidAuthToken) {
+		errorResponser.PublicError(w, http.StatusUnauthorized, err)
+		return
+	}
+	errorResponser.InternalError(w, err)
+}
+
+// RequireSession rejects requests authenticated with a personal access token
+// or a service account. It guards account and credential management, which
+// non-interactive principals must not reach.
+func RequireSession(errorResponser HTTPErrorResponser) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if p, ok := principal.FromContext(r.Context()); ok && !p.Interactive() {
+				errorResponser.PublicError(w, http.StatusForbidden, pat.ErrSessionRequired)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireAdmin rejects requests of users who are not administrators. It must
+// be applied after Authorize and RequireSession, so that the role is only
+// exercised from an interactive session.
+func RequireAdmin(admins AdminChecker, errorResponser HTTPErrorResponser) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := GetUserIDFromCtx(r.Context())
+			if !ok {
+				errorResponser.PublicError(w, http.StatusUnauthorized, token.ErrInvalidAuthToken)
+				return
+			}
+
+			isAdmin, err := admins.IsAdmin(r.Context(), userID)
+			if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+				errorResponser.InternalError(w, err)
+				return
+			}
+			if !isAdmin {
+				errorResponser.PublicError(w, http.StatusForbidden, admin.ErrAdminRequired)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func GetUserIDFromCtx(ctx context.Context) (int64, bool) {
 	v := ctx.Value(CtxUserID)
 	str, ok := v.(string)
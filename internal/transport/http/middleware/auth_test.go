@@ -0,0 +1,243 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/thxhix/passKeeper/internal/domain/pat"
+	"github.com/thxhix/passKeeper/internal/domain/principal"
+	"github.com/thxhix/passKeeper/internal/domain/token"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type stubParser struct {
+	userID    string
+	issuedAt  time.Time
+	expiresAt time.Time
+	clientID  string
+	err       error
+}
+
+func (p stubParser) ParseAccessToken(string) (string, time.Time, time.Time, string, error) {
+	return p.userID, p.issuedAt, p.expiresAt, p.clientID, p.err
+}
+
+type stubRevocations struct {
+	revokedBefore time.Time
+	err           error
+}
+
+func (s stubRevocations) IsRevoked(_ context.Context, _ int64, issuedAt time.Time) (bool, error) {
+	return issuedAt.Before(s.revokedBefore), s.err
+}
+
+type stubPersonalTokens struct {
+	userID int64
+	scopes pat.Scopes
+	err    error
+}
+
+func (s stubPersonalTokens) Authenticate(context.Context, string) (int64, pat.Scopes, error) {
+	return s.userID, s.scopes, s.err
+}
+
+// stubServiceAccounts holds the service accounts that exist.
+type stubServiceAccounts map[uuid.UUID]bool
+
+func (s stubServiceAccounts) Exists(_ context.Context, clientID uuid.UUID) (bool, error) {
+	return s[clientID], nil
+}
+
+func doAuthorized(parser TokenParser, revocations RevocationChecker, header string) (int, int64) {
+	return doAuthorizedWith(parser, revocations, stubPersonalTokens{err: pat.ErrInvalidToken}, header)
+}
+
+func doAuthorizedWith(parser TokenParser, revocations RevocationChecker, personalTokens PersonalTokenAuthenticator, header string) (int, int64) {
+	var seen int64
+	handler := Authorize(parser, revocations, personalTokens, stubServiceAccounts{}, recordingResponser{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = GetUserIDFromCtx(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		req.Header.Set("Authorization", header)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec.Code, seen
+}
+
+func TestAuthorize(t *testing.T) {
+	issuedAt := time.Date(2025, 10, 22, 10, 0, 0, 0, time.UTC)
+	parser := stubParser{userID: "7", issuedAt: issuedAt}
+
+	code, userID := doAuthorized(parser, stubRevocations{}, "Bearer token")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(7), userID)
+
+	code, _ = doAuthorized(parser, stubRevocations{}, "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, _ = doAuthorized(stubParser{err: errors.New("bad token")}, stubRevocations{}, "Bearer token")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	// token issued before the revocation is rejected
+	code, _ = doAuthorized(parser, stubRevocations{revokedBefore: issuedAt.Add(time.Second)}, "Bearer token")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	// token issued at the revocation moment is still accepted
+	code, _ = doAuthorized(parser, stubRevocations{revokedBefore: issuedAt}, "Bearer token")
+	assert.Equal(t, http.StatusOK, code)
+
+	// revocation lookup failures fail closed
+	code, _ = doAuthorized(parser, stubRevocations{err: errors.New("db down")}, "Bearer token")
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
+
+func TestAuthorize_PersonalToken(t *testing.T) {
+	parser := stubParser{err: errors.New("not a jwt")}
+	scopes := pat.Scopes{{Action: pat.ActionRead}}
+
+	code, userID := doAuthorizedWith(parser, stubRevocations{}, stubPersonalTokens{userID: 9, scopes: scopes}, "Bearer "+pat.TokenPrefix+"abc")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(9), userID)
+
+	code, _ = doAuthorizedWith(parser, stubRevocations{}, stubPersonalTokens{err: pat.ErrInvalidToken}, "Bearer "+pat.TokenPrefix+"abc")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, _ = doAuthorizedWith(parser, stubRevocations{}, stubPersonalTokens{err: errors.New("db down")}, "Bearer "+pat.TokenPrefix+"abc")
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
+
+func TestAuthorize_ServiceAccount(t *testing.T) {
+	issuedAt := time.Date(2025, 10, 24, 9, 0, 0, 0, time.UTC)
+	clientID := uuid.New()
+	parser := stubParser{userID: "7", issuedAt: issuedAt, clientID: clientID.String()}
+	accounts := stubServiceAccounts{clientID: true}
+
+	var seen principal.Principal
+	var cred Credential
+	handler := Authorize(parser, stubRevocations{revokedBefore: issuedAt.Add(time.Hour)}, stubPersonalTokens{}, accounts, recordingResponser{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = principal.FromContext(r.Context())
+		cred, _ = CredentialFromCtx(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	// user revocations do not apply to service account tokens
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, principal.ServiceAccount(7, clientID), seen)
+	assert.NoError(t, cred.Recheck(context.Background()))
+
+	// the tokens of a deleted account stop working, also for open requests
+	delete(accounts, clientID)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.ErrorIs(t, cred.Recheck(context.Background()), token.ErrInvalidAuthToken)
+
+	code, _ := doAuthorized(stubParser{userID: "7", issuedAt: issuedAt, clientID: "bad"}, stubRevocations{}, "Bearer token")
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestAuthorize_Credential(t *testing.T) {
+	issuedAt := time.Date(2025, 10, 22, 10, 0, 0, 0, time.UTC)
+	expiresAt := issuedAt.Add(15 * time.Minute)
+	revocations := &stubRevocations{}
+	personalTokens := &stubPersonalTokens{userID: 9, scopes: pat.Scopes{{Action: pat.ActionRead}}}
+
+	var cred Credential
+	handler := Authorize(stubParser{userID: "7", issuedAt: issuedAt, expiresAt: expiresAt}, revocations, personalTokens, stubServiceAccounts{}, recordingResponser{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cred, _ = CredentialFromCtx(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, expiresAt, cred.ExpiresAt)
+	assert.NoError(t, cred.Recheck(context.Background()))
+
+	// a logout after the request was authorized is noticed by the re-check
+	revocations.revokedBefore = issuedAt.Add(time.Second)
+	assert.ErrorIs(t, cred.Recheck(context.Background()), token.ErrInvalidAuthToken)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+pat.TokenPrefix+"abc")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.True(t, cred.ExpiresAt.IsZero())
+	assert.NoError(t, cred.Recheck(context.Background()))
+
+	personalTokens.err = pat.ErrInvalidToken
+	assert.ErrorIs(t, cred.Recheck(context.Background()), pat.ErrInvalidToken)
+}
+
+func TestRequireSession(t *testing.T) {
+	handler := RequireSession(recordingResponser{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(principal.WithPrincipal(req.Context(), principal.User(1)))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(principal.WithPrincipal(req.Context(), principal.PersonalToken(1, pat.Scopes{{Action: pat.ActionRead}})))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(principal.WithPrincipal(req.Context(), principal.ServiceAccount(1, uuid.New())))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+type stubAdmins map[int64]bool
+
+func (s stubAdmins) IsAdmin(_ context.Context, userID int64) (bool, error) {
+	if userID == 99 {
+		return false, errors.New("db down")
+	}
+	return s[userID], nil
+}
+
+func TestRequireAdmin(t *testing.T) {
+	handler := RequireAdmin(stubAdmins{1: true}, recordingResponser{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	do := func(userID string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if userID != "" {
+			req = req.WithContext(context.WithValue(req.Context(), CtxUserID, userID))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, do("1"))
+	assert.Equal(t, http.StatusForbidden, do("2"))
+	assert.Equal(t, http.StatusUnauthorized, do(""))
+	assert.Equal(t, http.StatusInternalServerError, do("99"))
+}
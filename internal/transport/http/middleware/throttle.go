@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"github.com/mailru/easyjson"
+	"github.com/thxhix/passKeeper/internal/domain/user"
+	"github.com/thxhix/passKeeper/internal/transport/http/dto"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxThrottledBodySize limits how much of the request body is buffered to
+// extract the login. Larger bodies are refused instead of being passed on
+// cut off.
+const maxThrottledBodySize = 1 << 20
+
+var ErrRequestBodyTooLarge = errors.New("request body is too large, max 1Mb")
+
+type AttemptLimiter interface {
+	Allow(key string) (retryAfter time.Duration, ok bool)
+	Failure(key string)
+	Reset(key string)
+}
+
+// statusRecorder remembers the status code written by the next handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// ThrottleLogin limits login attempts by client IP and by the login from the
+// request body. Bodies over 1 MB are refused with 413 and count as a failure
+// of the IP key. Every 4xx response counts as a failure for both keys, a
+// successful response resets the login key. The IP key is never reset on
+// success, so valid logins cannot be used to clear it.
+func ThrottleLogin(limiter AttemptLimiter, errorResponser HTTPErrorResponser) func(http.Handler) http.Handler {
+	return throttle(limiter, errorResponser, false)
+}
+
+// ThrottleRegister limits registration attempts the same way as ThrottleLogin,
+// but successful registrations count as attempts too, which slows down mass
+// account creation from a single address.
+func ThrottleRegister(limiter AttemptLimiter, errorResponser HTTPErrorResponser) func(http.Handler) http.Handler {
+	return throttle(limiter, errorResponser, true)
+}
+
+func throttle(limiter AttemptLimiter, errorResponser HTTPErrorResponser, countSuccess bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys := []string{"ip:" + clientIP(r)}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxThrottledBodySize))
+			if err != nil {
+				var me *http.MaxBytesError
+				if errors.As(err, &me) {
+					limiter.Failure(keys[0])
+					errorResponser.PublicError(w, http.StatusRequestEntityTooLarge, ErrRequestBodyTooLarge)
+					return
+				}
+				errorResponser.InternalError(w, err)
+				return
+			}
+			_ = r.Body.Close()
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			var creds dto.LoginRequest
+			if err := easyjson.Unmarshal(body, &creds); err == nil && creds.Login != "" {
+				keys = append(keys, "login:"+creds.Login)
+			}
+
+			var wait time.Duration
+			for _, key := range keys {
+				if retryAfter, ok := limiter.Allow(key); !ok && retryAfter > wait {
+					wait = retryAfter
+				}
+			}
+			if wait > 0 {
+				w.Header().Set("Retry-After", RetryAfterSeconds(wait))
+				errorResponser.PublicError(w, http.StatusTooManyRequests, user.ErrTooManyAttempts)
+				return
+			}
+
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+
+			switch {
+			case rec.status >= 400 && rec.status < 500 && rec.status != http.StatusTooManyRequests:
+				for _, key := range keys {
+					limiter.Failure(key)
+				}
+			case rec.status >= 200 && rec.status < 300:
+				if countSuccess {
+					limiter.Failure(keys[0])
+					return
+				}
+				for _, key := range keys[1:] {
+					limiter.Reset(key)
+				}
+			}
+		})
+	}
+}
+
+// RetryAfterSeconds formats d as a Retry-After header value, rounding up to
+// whole seconds.
+func RetryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// clientIP returns the host part of the request remote address.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/thxhix/passKeeper/internal/ratelimit"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.t }
+
+type recordingResponser struct{}
+
+func (recordingResponser) PublicError(w http.ResponseWriter, code int, err error) {
+	w.WriteHeader(code)
+	_, _ = w.Write([]byte(err.Error()))
+}
+
+func (recordingResponser) InternalError(w http.ResponseWriter, err error) {
+	w.WriteHeader(http.StatusInternalServerError)
+}
+
+func newThrottleLimiter() (*ratelimit.Limiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)}
+	return ratelimit.NewLimiter(ratelimit.Policy{
+		FreeAttempts: 1,
+		BaseDelay:    2 * time.Second,
+		MaxDelay:     time.Minute,
+		Window:       time.Hour,
+	}, clock.Now), clock
+}
+
+func doLogin(h http.Handler, ip, body string) *http.Response {
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body))
+	req.RemoteAddr = ip + ":12345"
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Result()
+}
+
+func TestThrottleLogin(t *testing.T) {
+	limiter, clock := newThrottleLimiter()
+
+	handler := ThrottleLogin(limiter, recordingResponser{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), `"password":"good"`) {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	// first failure is free, second one starts the backoff
+	assert.Equal(t, http.StatusUnauthorized, doLogin(handler, "10.0.0.1", `{"login":"alice","password":"bad"}`).StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, doLogin(handler, "10.0.0.1", `{"login":"alice","password":"bad"}`).StatusCode)
+
+	res := doLogin(handler, "10.0.0.1", `{"login":"alice","password":"good"}`)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	assert.Equal(t, "2", res.Header.Get("Retry-After"))
+
+	// the login key is blocked from any other address as well
+	res = doLogin(handler, "10.0.0.2", `{"login":"alice","password":"good"}`)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+
+	// other logins from a fresh address are not affected
+	assert.Equal(t, http.StatusOK, doLogin(handler, "10.0.0.3", `{"login":"bob","password":"good"}`).StatusCode)
+
+	clock.t = clock.t.Add(2 * time.Second)
+	assert.Equal(t, http.StatusOK, doLogin(handler, "10.0.0.2", `{"login":"alice","password":"good"}`).StatusCode)
+
+	// success resets the login key but not the IP key
+	_, ok := limiter.Allow("login:alice")
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnauthorized, doLogin(handler, "10.0.0.1", `{"login":"carol","password":"bad"}`).StatusCode)
+	res = doLogin(handler, "10.0.0.1", `{"login":"dave","password":"good"}`)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	assert.Equal(t, "4", res.Header.Get("Retry-After"))
+}
+
+func TestThrottleRegister_CountsSuccess(t *testing.T) {
+	limiter, _ := newThrottleLimiter()
+
+	handler := ThrottleRegister(limiter, recordingResponser{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	assert.Equal(t, http.StatusCreated, doLogin(handler, "10.0.0.1", `{"login":"u1","password":"p"}`).StatusCode)
+	assert.Equal(t, http.StatusCreated, doLogin(handler, "10.0.0.1", `{"login":"u2","password":"p"}`).StatusCode)
+	assert.Equal(t, http.StatusTooManyRequests, doLogin(handler, "10.0.0.1", `{"login":"u3","password":"p"}`).StatusCode)
+}
+
+func TestThrottleLogin_BodyTooLarge(t *testing.T) {
+	limiter, _ := newThrottleLimiter()
+
+	called := false
+	handler := ThrottleLogin(limiter, recordingResponser{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	body := `{"login":"alice","password":"` + strings.Repeat("a", maxThrottledBodySize) + `"}`
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, doLogin(handler, "10.0.0.1", body).StatusCode)
+	assert.False(t, called, "a cut off body must not reach the handler")
+}
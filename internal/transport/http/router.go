@@ -6,22 +6,203 @@ import (
 	"github.com/thxhix/passKeeper/internal/transport/http/middleware"
 )
 
-func NewRouter(handlers handlers.Handlers, jwtParser middleware.TokenParser) *chi.Mux {
+func NewRouter(handlers handlers.Handlers, jwtParser middleware.TokenParser, revocations middleware.RevocationChecker, personalTokens middleware.PersonalTokenAuthenticator, serviceAccounts middleware.ServiceAccountChecker, admins middleware.AdminChecker, limiter middleware.AttemptLimiter) *chi.Mux {
 	router := chi.NewRouter()
 	router.Use(middleware.GzipMiddleware)
+	router.Use(middleware.AuditInfo)
 
 	router.Route("/", func(r chi.Router) {
+		r.Get("/.well-known/jwks.json", handlers.JWKS)
+
 		r.Route("/api", func(r chi.Router) {
 			r.Route("/auth", func(r chi.Router) {
-				r.Post("/register", handlers.Register)
-				r.Post("/login", handlers.Login)
+				r.With(middleware.ThrottleRegister(limiter, &handlers)).Post("/register", handlers.Register)
+				r.With(middleware.ThrottleLogin(limiter, &handlers)).Post("/login", handlers.Login)
+				r.With(middleware.ThrottleLogin(limiter, &handlers)).Post("/device/challenge", handlers.DeviceChallenge)
 				r.Post("/refresh", handlers.Refresh)
+				r.With(middleware.ThrottleLogin(limiter, &handlers)).Post("/token", handlers.ExchangeServiceToken)
+
+				r.Post("/email/verify", handlers.VerifyEmail)
+				r.With(middleware.ThrottleRegister(limiter, &handlers)).Post("/password/forgot", handlers.ForgotPassword)
+				r.With(middleware.ThrottleRegister(limiter, &handlers)).Post("/password/reset", handlers.ResetPassword)
+
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+					r.Use(middleware.RequireSession(&handlers))
+
+					r.Post("/password", handlers.ChangePassword)
+					r.Post("/logout", handlers.Logout)
+
+					r.Post("/tokens", handlers.CreatePersonalToken)
+					r.Get("/tokens", handlers.GetPersonalTokens)
+					r.Delete("/tokens/{uuid}", handlers.RevokePersonalToken)
+				})
+			})
+
+			r.Route("/send", func(r chi.Router) {
+				r.With(middleware.ThrottleLogin(limiter, &handlers)).Get("/{id}", handlers.OpenSend)
+
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+					r.Use(middleware.RequireSession(&handlers))
+
+					r.Post("/", handlers.CreateSend)
+					r.Get("/", handlers.GetSends)
+					r.Delete("/{id}", handlers.RevokeSend)
+				})
+			})
+
+			r.Route("/account", func(r chi.Router) {
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+				r.Use(middleware.RequireSession(&handlers))
+
+				r.With(middleware.ThrottleLogin(limiter, &handlers)).Delete("/", handlers.DeleteAccount)
+				r.With(middleware.ThrottleLogin(limiter, &handlers)).Post("/export", handlers.ExportAccount)
+				r.Put("/email", handlers.ChangeEmail)
+			})
+
+			r.Route("/devices", func(r chi.Router) {
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+				r.Use(middleware.RequireSession(&handlers))
+
+				r.Get("/", handlers.GetDevices)
+				r.Post("/{device_id}/approve", handlers.ApproveDevice)
+				r.Delete("/{device_id}", handlers.RemoveDevice)
+			})
+
+			r.Route("/service-accounts", func(r chi.Router) {
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+				r.Use(middleware.RequireSession(&handlers))
+
+				r.Post("/", handlers.CreateServiceAccount)
+				r.Get("/", handlers.GetServiceAccounts)
+				r.Delete("/{client_id}", handlers.DeleteServiceAccount)
+
+				r.Get("/{client_id}/grants", handlers.GetServiceAccountGrants)
+				r.Put("/{client_id}/grants/{uuid}", handlers.GrantServiceAccountKey)
+				r.Delete("/{client_id}/grants/{uuid}", handlers.RevokeServiceAccountGrant)
+			})
+
+			r.Route("/invites", func(r chi.Router) {
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+				r.Use(middleware.RequireSession(&handlers))
+
+				r.Post("/", handlers.CreateInvite)
+				r.Get("/", handlers.GetInvites)
+				r.Delete("/{uuid}", handlers.RevokeInvite)
+			})
+
+			r.Route("/orgs", func(r chi.Router) {
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+				r.Use(middleware.RequireSession(&handlers))
+
+				r.Post("/", handlers.CreateOrganization)
+				r.Get("/", handlers.GetOrganizations)
+				r.Get("/{org_uuid}/members", handlers.GetOrganizationMembers)
+				r.Put("/{org_uuid}/members/{login}", handlers.SetOrganizationMember)
+				r.Delete("/{org_uuid}/members/{login}", handlers.RemoveOrganizationMember)
+			})
+
+			r.Route("/vaults", func(r chi.Router) {
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+				r.Use(middleware.RequireSession(&handlers))
+
+				r.Post("/", handlers.CreateVault)
+				r.Get("/", handlers.GetVaults)
+				r.Get("/{vault_uuid}/members", handlers.GetVaultMembers)
+				r.Put("/{vault_uuid}/members/{login}", handlers.ShareVault)
+				r.Delete("/{vault_uuid}/members/{login}", handlers.UnshareVault)
+			})
+
+			r.Route("/keys", func(r chi.Router) {
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+				r.Use(middleware.RequireSession(&handlers))
+
+				r.Put("/", handlers.SetKeyPair)
+				r.Get("/", handlers.GetKeyPair)
+				r.Get("/{login}", handlers.GetPublicKey)
+			})
+
+			r.Route("/shared", func(r chi.Router) {
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+				r.Use(middleware.RequireSession(&handlers))
+
+				r.Get("/", handlers.GetSharedKeys)
+				r.Get("/{uuid}", handlers.GetSharedKey)
+				r.Delete("/{uuid}", handlers.DismissSharedKey)
+			})
+
+			r.Route("/emergency", func(r chi.Router) {
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+				r.Use(middleware.RequireSession(&handlers))
+
+				r.Post("/contacts", handlers.NominateEmergencyContact)
+				r.Get("/contacts", handlers.GetEmergencyContacts)
+				r.Post("/contacts/{uuid}/approve", handlers.ApproveEmergencyAccess)
+				r.Post("/contacts/{uuid}/reject", handlers.RejectEmergencyAccess)
+				r.Delete("/contacts/{uuid}", handlers.RemoveEmergencyContact)
+
+				r.Get("/grantors", handlers.GetEmergencyGrantors)
+				r.Post("/grantors/{uuid}/request", handlers.RequestEmergencyAccess)
+				r.Get("/grantors/{uuid}/keychain", handlers.GetEmergencyKeys)
+				r.Get("/grantors/{uuid}/keychain/{key_uuid}", handlers.GetEmergencyKey)
+				r.Post("/grantors/{uuid}/takeover", handlers.EmergencyTakeover)
+				r.Delete("/grantors/{uuid}", handlers.RemoveEmergencyContact)
+			})
+
+			r.Route("/recovery", func(r chi.Router) {
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+				r.Use(middleware.RequireSession(&handlers))
+
+				r.Put("/", handlers.SetRecovery)
+				r.Get("/", handlers.GetRecovery)
+				r.Delete("/", handlers.DeleteRecovery)
+
+				r.Get("/held", handlers.GetHeldRecoveryShares)
+				r.Get("/held/{uuid}", handlers.GetHeldRecoveryShare)
+			})
+
+			r.Route("/audit", func(r chi.Router) {
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+				r.Use(middleware.RequireSession(&handlers))
+
+				r.Get("/", handlers.GetAuditEvents)
+			})
+
+			r.Route("/webhooks", func(r chi.Router) {
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+				r.Use(middleware.RequireSession(&handlers))
+
+				r.Post("/", handlers.CreateWebhook)
+				r.Get("/", handlers.GetWebhooks)
+				r.Delete("/{webhook_id}", handlers.DeleteWebhook)
+				r.Get("/{webhook_id}/deliveries", handlers.GetWebhookDeliveries)
+			})
+
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
+				r.Use(middleware.RequireSession(&handlers))
+				r.Use(middleware.RequireAdmin(admins, &handlers))
+
+				r.Get("/users", handlers.GetAdminUsers)
+				r.Post("/users/{user_id}/disable", handlers.DisableUser)
+				r.Post("/users/{user_id}/enable", handlers.EnableUser)
+				r.Post("/users/{user_id}/logout", handlers.LogoutUser)
+				r.Post("/users/{user_id}/reset-2fa", handlers.ResetUserSecondFactor)
+				r.Get("/users/{user_id}/stats", handlers.GetUserStats)
+
+				r.Post("/webhooks", handlers.CreateGlobalWebhook)
+				r.Get("/webhooks", handlers.GetGlobalWebhooks)
+				r.Delete("/webhooks/{webhook_id}", handlers.DeleteGlobalWebhook)
+				r.Get("/webhooks/{webhook_id}/deliveries", handlers.GetGlobalWebhookDeliveries)
 			})
 
 			r.Route("/keychain", func(r chi.Router) {
-				r.Use(middleware.Authorize(jwtParser, &handlers))
+				r.Use(middleware.Authorize(jwtParser, revocations, personalTokens, serviceAccounts, &handlers))
 
 				r.Get("/", handlers.GetKeys)
+				r.Get("/changes", handlers.GetChanges)
+				r.Get("/events", handlers.GetEvents)
 
 				r.Get("/{uuid}", handlers.GetKey)
 				r.Delete("/{uuid}", handlers.DeleteKey)
@@ -30,6 +211,15 @@ func NewRouter(handlers handlers.Handlers, jwtParser middleware.TokenParser) *ch
 				r.Post("/card", handlers.AddCard)
 				r.Post("/text", handlers.AddText)
 				r.Post("/file", handlers.AddFile)
+				r.Post("/batch", handlers.Batch)
+
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.RequireSession(&handlers))
+
+					r.Post("/{uuid}/share", handlers.ShareKey)
+					r.Get("/{uuid}/share", handlers.GetKeyShares)
+					r.Delete("/{uuid}/share/{login}", handlers.RevokeKeyShare)
+				})
 			})
 		})
 	})
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS access_token_revocations (
+    user_id        BIGINT PRIMARY KEY,
+    revoked_before TIMESTAMPTZ NOT NULL,
+    expires_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_token_revocations_expires_at ON access_token_revocations (expires_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_keychain_tags;
+
+ALTER TABLE keychain DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,3 @@
+ALTER TABLE keychain ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_keychain_tags ON keychain USING GIN (tags);
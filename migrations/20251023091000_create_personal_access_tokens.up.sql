@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id           BIGSERIAL PRIMARY KEY,
+    token_uuid   UUID UNIQUE NOT NULL,
+    user_id      BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         VARCHAR(64) NOT NULL,
+    token_hash   TEXT UNIQUE NOT NULL,
+    scopes       TEXT[] NOT NULL,
+    expires_at   TIMESTAMPTZ NULL,
+    last_used_at TIMESTAMPTZ NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_user_id ON personal_access_tokens (user_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS service_account_grants;
+DROP TABLE IF EXISTS service_accounts;
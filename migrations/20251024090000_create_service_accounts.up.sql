@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS service_accounts (
+    id           BIGSERIAL PRIMARY KEY,
+    client_id    UUID UNIQUE NOT NULL,
+    owner_id     BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         VARCHAR(64) NOT NULL,
+    secret_hash  TEXT UNIQUE NOT NULL,
+    public_key   TEXT NULL,
+    last_used_at TIMESTAMPTZ NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_service_accounts_owner_id ON service_accounts (owner_id);
+
+CREATE TABLE IF NOT EXISTS service_account_grants (
+    service_account_id BIGINT NOT NULL REFERENCES service_accounts(id) ON DELETE CASCADE,
+    key_id             INTEGER NOT NULL REFERENCES keychain(id) ON DELETE CASCADE,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (service_account_id, key_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_service_account_grants_key_id ON service_account_grants (key_id);
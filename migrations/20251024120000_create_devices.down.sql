@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_auth_refresh_tokens_device_id;
+
+ALTER TABLE auth_refresh_tokens DROP COLUMN IF EXISTS device_id;
+
+DROP TABLE IF EXISTS devices;
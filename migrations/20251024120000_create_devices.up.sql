@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS devices (
+    id                  BIGSERIAL PRIMARY KEY,
+    device_uuid         UUID NOT NULL,
+    user_id             BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name                VARCHAR(64) NOT NULL,
+    public_key          TEXT NOT NULL,
+    trusted             BOOLEAN NOT NULL DEFAULT false,
+    approval_code_hash  TEXT NULL,
+    approval_expires_at TIMESTAMPTZ NULL,
+    last_seen_at        TIMESTAMPTZ NULL,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (user_id, device_uuid)
+);
+
+ALTER TABLE auth_refresh_tokens
+    ADD COLUMN IF NOT EXISTS device_id BIGINT NULL REFERENCES devices(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_auth_refresh_tokens_device_id ON auth_refresh_tokens (device_id);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+
+DROP FUNCTION IF EXISTS audit_events_append_only();
+
+DROP TABLE IF EXISTS audit_events;
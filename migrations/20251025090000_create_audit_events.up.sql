@@ -0,0 +1,26 @@
+-- user_id deliberately has no foreign key: events outlive deleted accounts.
+CREATE TABLE IF NOT EXISTS audit_events (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NULL,
+    actor      VARCHAR(64) NOT NULL,
+    action     VARCHAR(32) NOT NULL,
+    key_uuid   UUID NULL,
+    ip         TEXT NOT NULL,
+    user_agent TEXT NOT NULL,
+    result     VARCHAR(16) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    prev_hash  TEXT NOT NULL,
+    hash       TEXT UNIQUE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_user_id ON audit_events (user_id, id);
+
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id               BIGSERIAL PRIMARY KEY,
+    subscription_id  UUID NOT NULL UNIQUE,
+    user_id          BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    url              TEXT NOT NULL,
+    events           TEXT[] NOT NULL,
+    tags             TEXT[] NOT NULL DEFAULT '{}',
+    secret_nonce     BYTEA NOT NULL,
+    secret_encrypted BYTEA NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_user_id ON webhook_subscriptions (user_id);
+
+-- outbox of deliveries, written together with the events and drained by the dispatcher
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id               BIGSERIAL PRIMARY KEY,
+    delivery_id      UUID NOT NULL UNIQUE,
+    subscription_id  BIGINT NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_type       TEXT NOT NULL,
+    payload          BYTEA NOT NULL,
+    status           TEXT NOT NULL DEFAULT 'pending',
+    attempts         INT NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_status_code INT NULL,
+    last_error       TEXT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at     TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries (subscription_id, id);
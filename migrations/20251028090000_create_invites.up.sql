@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS invites (
+    id          BIGSERIAL PRIMARY KEY,
+    invite_uuid UUID UNIQUE NOT NULL,
+    created_by  BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash   TEXT UNIQUE NOT NULL,
+    login       VARCHAR(64) NULL,
+    max_uses    INT NOT NULL CHECK (max_uses > 0),
+    uses        INT NOT NULL DEFAULT 0 CHECK (uses >= 0),
+    expires_at  TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_invites_created_by ON invites (created_by);
@@ -0,0 +1,11 @@
+DELETE FROM keychain WHERE vault_id IS NOT NULL;
+
+ALTER TABLE keychain DROP CONSTRAINT IF EXISTS keychain_owner_check;
+DROP INDEX IF EXISTS idx_keychain_vault_id;
+ALTER TABLE keychain DROP COLUMN IF EXISTS vault_id;
+ALTER TABLE keychain ALTER COLUMN user_id SET NOT NULL;
+
+DROP TABLE IF EXISTS vault_members;
+DROP TABLE IF EXISTS vaults;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
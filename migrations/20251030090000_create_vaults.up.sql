@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS organizations (
+    id         BIGSERIAL PRIMARY KEY,
+    org_uuid   UUID UNIQUE NOT NULL,
+    name       VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    org_id     BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id    BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role       VARCHAR(16) NOT NULL CHECK (role IN ('owner','admin','editor','viewer')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members (user_id);
+
+CREATE TABLE IF NOT EXISTS vaults (
+    id         BIGSERIAL PRIMARY KEY,
+    vault_uuid UUID UNIQUE NOT NULL,
+    org_id     BIGINT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name       VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vaults_org_id ON vaults (org_id);
+
+CREATE TABLE IF NOT EXISTS vault_members (
+    vault_id   BIGINT NOT NULL REFERENCES vaults(id) ON DELETE CASCADE,
+    user_id    BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role       VARCHAR(16) NOT NULL CHECK (role IN ('owner','admin','editor','viewer')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (vault_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_vault_members_user_id ON vault_members (user_id);
+
+-- An entry belongs either to a vault or, as a personal entry, to a user.
+ALTER TABLE keychain ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE keychain ADD COLUMN IF NOT EXISTS vault_id BIGINT NULL REFERENCES vaults(id) ON DELETE CASCADE;
+ALTER TABLE keychain ADD CONSTRAINT keychain_owner_check CHECK ((user_id IS NULL) <> (vault_id IS NULL));
+
+CREATE INDEX IF NOT EXISTS idx_keychain_vault_id ON keychain (vault_id) WHERE vault_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS key_shares;
+
+ALTER TABLE users DROP COLUMN IF EXISTS public_key;
@@ -0,0 +1,18 @@
+-- The X25519 public key of the user. The matching private key is kept
+-- client-wrapped in key_material.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS public_key BYTEA NULL;
+
+CREATE TABLE IF NOT EXISTS key_shares (
+    id           BIGSERIAL PRIMARY KEY,
+    share_uuid   UUID UNIQUE NOT NULL,
+    key_id       BIGINT NOT NULL REFERENCES keychain(id) ON DELETE CASCADE,
+    owner_id     BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wrapped_key  BYTEA NOT NULL,
+    nonce        BYTEA NOT NULL,
+    data         BYTEA NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (key_id, recipient_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_key_shares_recipient_id ON key_shares (recipient_id);
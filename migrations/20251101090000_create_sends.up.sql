@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS sends (
+    id         BIGSERIAL PRIMARY KEY,
+    send_uuid  UUID UNIQUE NOT NULL,
+    owner_id   BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    nonce      BYTEA NOT NULL,
+    data       BYTEA NOT NULL,
+    max_views  INT NOT NULL CHECK (max_views > 0),
+    views      INT NOT NULL DEFAULT 0 CHECK (views >= 0),
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sends_owner_id ON sends (owner_id);
+CREATE INDEX IF NOT EXISTS idx_sends_expires_at ON sends (expires_at);
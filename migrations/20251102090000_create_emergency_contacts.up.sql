@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS emergency_contacts (
+    id           BIGSERIAL PRIMARY KEY,
+    contact_uuid UUID UNIQUE NOT NULL,
+    grantor_id   BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    grantee_id   BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    access       VARCHAR(16) NOT NULL CHECK (access IN ('view','takeover')),
+    wait_seconds BIGINT NOT NULL CHECK (wait_seconds > 0),
+    status       VARCHAR(16) NOT NULL DEFAULT 'idle' CHECK (status IN ('idle','requested','approved','rejected')),
+    requested_at TIMESTAMPTZ NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (grantor_id, grantee_id),
+    CHECK (grantor_id <> grantee_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_emergency_contacts_grantee_id ON emergency_contacts (grantee_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS recovery_shares;
+DROP TABLE IF EXISTS recovery_setups;
@@ -0,0 +1,27 @@
+-- The private key of the user wrapped with a recovery key that is split into
+-- Shamir shares on the client. Kept on password reset, which is when it is
+-- needed.
+CREATE TABLE IF NOT EXISTS recovery_setups (
+    user_id      BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    public_key   BYTEA NOT NULL,
+    key_material BYTEA NOT NULL,
+    threshold    SMALLINT NOT NULL,
+    shares       SMALLINT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (threshold >= 2 AND threshold <= shares)
+);
+
+CREATE TABLE IF NOT EXISTS recovery_shares (
+    id          BIGSERIAL PRIMARY KEY,
+    share_uuid  UUID UNIQUE NOT NULL,
+    owner_id    BIGINT NOT NULL REFERENCES recovery_setups(user_id) ON DELETE CASCADE,
+    holder_id   BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wrapped_key BYTEA NOT NULL,
+    nonce       BYTEA NOT NULL,
+    data        BYTEA NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (owner_id, holder_id),
+    CHECK (owner_id <> holder_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_recovery_shares_holder_id ON recovery_shares (holder_id);
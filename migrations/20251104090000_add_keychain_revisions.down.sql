@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_keychain_user_revision;
+
+ALTER TABLE keychain DROP COLUMN IF EXISTS revision;
+ALTER TABLE users DROP COLUMN IF EXISTS keychain_revision;
@@ -0,0 +1,26 @@
+-- Every change of a personal entry, including its soft deletion, takes the
+-- next revision of its user, so clients can ask for what changed since the
+-- last revision they saw.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS keychain_revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE keychain ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
+
+UPDATE keychain k
+SET revision = r.revision
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY user_id ORDER BY id) AS revision
+    FROM keychain
+    WHERE user_id IS NOT NULL
+) r
+WHERE k.id = r.id;
+
+UPDATE users u
+SET keychain_revision = r.revision
+FROM (
+    SELECT user_id, max(revision) AS revision
+    FROM keychain
+    WHERE user_id IS NOT NULL
+    GROUP BY user_id
+) r
+WHERE u.id = r.user_id;
+
+CREATE INDEX IF NOT EXISTS idx_keychain_user_revision ON keychain (user_id, revision) WHERE user_id IS NOT NULL;
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS keychain_notify_update ON keychain;
+DROP TRIGGER IF EXISTS keychain_notify_insert ON keychain;
+
+DROP FUNCTION IF EXISTS keychain_notify();
@@ -0,0 +1,41 @@
+-- Every new revision of a personal entry is announced on the keychain_events
+-- channel, so that each server instance can push it to the event streams of
+-- its clients. NOTIFY is only delivered once the transaction commits.
+CREATE OR REPLACE FUNCTION keychain_notify() RETURNS trigger AS $$
+DECLARE
+    event TEXT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        event := 'add';
+    ELSIF NEW.soft_deleted AND NOT COALESCE(OLD.soft_deleted, false) THEN
+        event := 'delete';
+    ELSE
+        event := 'update';
+    END IF;
+
+    PERFORM pg_notify('keychain_events', json_build_object(
+        'event', event,
+        'user_id', NEW.user_id,
+        'id', NEW.id,
+        'uuid', NEW.key_uuid,
+        'type', NEW.type,
+        'title', NEW.title,
+        'tags', NEW.tags,
+        'revision', NEW.revision
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER keychain_notify_insert
+    AFTER INSERT ON keychain
+    FOR EACH ROW
+    WHEN (NEW.user_id IS NOT NULL)
+    EXECUTE FUNCTION keychain_notify();
+
+CREATE TRIGGER keychain_notify_update
+    AFTER UPDATE ON keychain
+    FOR EACH ROW
+    WHEN (NEW.user_id IS NOT NULL AND NEW.revision IS DISTINCT FROM OLD.revision)
+    EXECUTE FUNCTION keychain_notify();
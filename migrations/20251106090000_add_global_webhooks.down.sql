@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_webhook_subscriptions_global;
+
+DELETE FROM webhook_subscriptions WHERE user_id IS NULL;
+
+ALTER TABLE webhook_subscriptions ALTER COLUMN user_id SET NOT NULL;
@@ -0,0 +1,4 @@
+-- global subscriptions belong to no user and receive the events of every user
+ALTER TABLE webhook_subscriptions ALTER COLUMN user_id DROP NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_global ON webhook_subscriptions (created_at) WHERE user_id IS NULL;
@@ -0,0 +1,8 @@
+-- challenges a device signs to prove it holds its key, one per device
+CREATE TABLE IF NOT EXISTS device_challenges (
+    device_uuid UUID PRIMARY KEY,
+    nonce_hash  TEXT NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_device_challenges_expires_at ON device_challenges (expires_at);
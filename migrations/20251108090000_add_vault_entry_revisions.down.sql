@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS vault_entry_notify_update ON vault_entry_revisions;
+DROP TRIGGER IF EXISTS vault_entry_notify_insert ON vault_entry_revisions;
+
+DROP FUNCTION IF EXISTS vault_entry_notify();
+DROP FUNCTION IF EXISTS vault_visible(BIGINT, BIGINT);
+
+DROP TABLE IF EXISTS vault_entry_revisions;
@@ -0,0 +1,94 @@
+-- A vault entry has no user of its own: every change of it, and every change
+-- of who may see its vault, takes the next revision of each user concerned
+-- and is recorded here, so the delta sync and the event stream of a user
+-- cover vault entries like personal ones.
+CREATE TABLE IF NOT EXISTS vault_entry_revisions (
+    user_id  BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key_id   BIGINT NOT NULL REFERENCES keychain(id) ON DELETE CASCADE,
+    revision BIGINT NOT NULL,
+    PRIMARY KEY (user_id, key_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_vault_entry_revisions_user_revision ON vault_entry_revisions (user_id, revision);
+
+-- vault_visible reports whether the user has a role in the vault, directly or
+-- through its organization.
+CREATE OR REPLACE FUNCTION vault_visible(uid BIGINT, vid BIGINT) RETURNS BOOLEAN AS $$
+    SELECT EXISTS (SELECT 1 FROM vault_members WHERE vault_id = vid AND user_id = uid)
+        OR EXISTS (
+            SELECT 1
+            FROM vaults v
+            JOIN organization_members om ON om.org_id = v.org_id
+            WHERE v.id = vid AND om.user_id = uid
+        );
+$$ LANGUAGE sql STABLE;
+
+INSERT INTO vault_entry_revisions (user_id, key_id, revision)
+SELECT a.user_id, a.key_id, u.keychain_revision + a.n
+FROM (
+    SELECT m.user_id, k.id AS key_id, row_number() OVER (PARTITION BY m.user_id ORDER BY k.id) AS n
+    FROM keychain k
+    JOIN (
+        SELECT vault_id, user_id FROM vault_members
+        UNION
+        SELECT v.id, om.user_id FROM vaults v JOIN organization_members om ON om.org_id = v.org_id
+    ) m ON m.vault_id = k.vault_id
+    WHERE NOT k.soft_deleted
+) a
+JOIN users u ON u.id = a.user_id;
+
+UPDATE users u
+SET keychain_revision = r.revision
+FROM (
+    SELECT user_id, max(revision) AS revision
+    FROM vault_entry_revisions
+    GROUP BY user_id
+) r
+WHERE u.id = r.user_id;
+
+-- Every new revision of a vault entry is announced on keychain_events for the
+-- user it was recorded for, like keychain_notify does for personal entries.
+-- An entry the user no longer sees is announced as deleted.
+CREATE OR REPLACE FUNCTION vault_entry_notify() RETURNS trigger AS $$
+DECLARE
+    k     keychain%ROWTYPE;
+    vault UUID;
+    event TEXT;
+BEGIN
+    SELECT * INTO k FROM keychain WHERE id = NEW.key_id;
+    SELECT vault_uuid INTO vault FROM vaults WHERE id = k.vault_id;
+
+    IF k.soft_deleted OR NOT vault_visible(NEW.user_id, k.vault_id) THEN
+        event := 'delete';
+    ELSIF TG_OP = 'INSERT' THEN
+        event := 'add';
+    ELSE
+        event := 'update';
+    END IF;
+
+    PERFORM pg_notify('keychain_events', json_build_object(
+        'event', event,
+        'user_id', NEW.user_id,
+        'id', k.id,
+        'uuid', k.key_uuid,
+        'vault_uuid', vault,
+        'type', k.type,
+        'title', k.title,
+        'tags', k.tags,
+        'revision', NEW.revision
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER vault_entry_notify_insert
+    AFTER INSERT ON vault_entry_revisions
+    FOR EACH ROW
+    EXECUTE FUNCTION vault_entry_notify();
+
+CREATE TRIGGER vault_entry_notify_update
+    AFTER UPDATE ON vault_entry_revisions
+    FOR EACH ROW
+    WHEN (NEW.revision IS DISTINCT FROM OLD.revision)
+    EXECUTE FUNCTION vault_entry_notify();
@@ -0,0 +1 @@
+ALTER TABLE invites ADD COLUMN IF NOT EXISTS email VARCHAR(254) NULL;